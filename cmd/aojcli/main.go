@@ -4,17 +4,13 @@ package main
 import (
 	"os"
 
+	"github.com/YuminosukeSato/AOJ-cli/internal/app"
 	"github.com/YuminosukeSato/AOJ-cli/internal/cli"
-	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/repository"
-	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
 )
 
-const (
-	aojBaseURL = "https://judgeapi.u-aizu.ac.jp"
-)
-
 func main() {
 	// Initialize logger
 	logConfig := logger.Config{
@@ -24,69 +20,64 @@ func main() {
 	}
 	logger.SetGlobal(logger.New(logConfig))
 
-	// Load configuration
+	// Load configuration. It decides where the store lives and which AOJ
+	// endpoints the repositories use, so it is loaded once before the commands
+	// are built rather than by a command middleware
+	if dir, ok := cli.ConfigDirFromArgs(os.Args[1:]); ok {
+		config.SetConfigDir(config.ExpandHome(dir))
+	}
 	configDir, err := config.GetConfigDir()
 	if err != nil {
 		logger.Error("failed to get config directory", "error", err)
 		os.Exit(1)
 	}
 
-	// Ensure config directory exists
-	if err := config.EnsureConfigDir(); err != nil {
-		logger.Error("failed to ensure config directory", "error", err)
-		os.Exit(1)
+	// Keep sessions, caches and history elsewhere if the config directory is
+	// read-only, e.g. in containers and CI
+	dataDir := configDir
+	if err := config.CheckWritable(configDir); err != nil {
+		logger.Debug("config directory is not writable", "path", configDir, "error", err)
+		dataDir, err = config.FallbackDataDir()
+		if err != nil {
+			logger.Error("failed to create a writable data directory", "error", err)
+			os.Exit(1)
+		}
+		cli.WarnReadOnlyConfigDir(configDir, dataDir)
 	}
 
-	// Initialize dependencies
-	dependencies := initializeDependencies(configDir)
-
-	// Create root command
-	rootCmd := cli.NewRootCommand()
-	rootCommand := rootCmd.Command()
-
-	// Create and add login command
-	loginCmd := cli.NewLoginCommand(dependencies.LoginUseCase)
-	loginCommand := loginCmd.Command()
-
-	// Create and add init command
-	initCmd := cli.NewInitCommand(dependencies.InitUseCase)
-	initCommand := initCmd.Command()
-
-	// Create and add submit command
-	submitCmd := cli.NewSubmitCommand(dependencies.SubmitUseCase)
-	submitCommand := submitCmd.Command()
-
-	// Add subcommands to root
-	rootCmd.AddSubcommands(rootCommand, loginCommand, initCommand, submitCommand)
-
-	// Execute root command
-	err = rootCmd.Execute(rootCommand)
-	rootCmd.HandleError(err)
-}
-
-// Dependencies holds all application dependencies
-type Dependencies struct {
-	LoginUseCase  *usecase.LoginUseCase
-	InitUseCase   *usecase.InitUseCase
-	SubmitUseCase *usecase.SubmitUseCase
-}
-
-// initializeDependencies initializes all application dependencies
-func initializeDependencies(configDir string) *Dependencies {
-	// Initialize repositories
-	authRepo := repository.NewAOJAuthRepository(aojBaseURL)
-	sessionRepo := repository.NewLocalSessionRepository(configDir)
-	problemRepo := repository.NewAOJProblemRepository(aojBaseURL)
-	submissionRepo := repository.NewAOJSubmissionRepository(aojBaseURL)
+	cfg, err := config.LoadDefaultWithProfile(cli.ProfileFromArgs(os.Args[1:]))
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
 
-	// Initialize use cases
-	loginUseCase := usecase.NewLoginUseCase(authRepo, sessionRepo)
-	initUseCase := usecase.NewInitUseCase(problemRepo)
-	submitUseCase := usecase.NewSubmitUseCase(submissionRepo, sessionRepo)
+	// Freeze the clock when a fake time is given
+	clk := clock.System()
+	fakeTime, ok, err := cli.FakeTimeFromArgs(os.Args[1:])
+	if err != nil {
+		logger.Error("invalid fake time", "error", err)
+		os.Exit(1)
+	}
+	if ok {
+		clk = clock.Frozen(fakeTime)
+	}
 
-	return &Dependencies{
-		LoginUseCase:  loginUseCase,
-		InitUseCase:   initUseCase,
-		SubmitUseCase: submitUseCase,
+	// Build the application
+	application, err := app.New(app.Options{
+		Config:          cfg,
+		ConfigDir:       configDir,
+		DataDir:         dataDir,
+		Clock:           clk,
+		PersistSessions: !cli.NoPersistSessionFromArgs(os.Args[1:]),
+	})
+	if err != nil {
+		logger.Error("failed to initialize dependencies", "error", err)
+		os.Exit(1)
 	}
+
+	// Execute the command, or forward it to an aoj-<name> plugin
+	exitCode, err := application.Execute(os.Args[1:])
+	application.HandleError(err)
+	_ = application.Close()
+	os.Exit(exitCode)
 }
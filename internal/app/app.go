@@ -0,0 +1,564 @@
+// Package app builds the AOJ CLI from a loaded configuration. It wires the
+// repositories, use cases and commands, so that the whole CLI can also be
+// constructed in tests, e.g. against the aojfake server.
+package app
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/cli"
+	domainrepo "github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/difficulty"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/plugin"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/reminder"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/runner"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/vcs"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/crash"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/encryption"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// Options configures how the application is built
+type Options struct {
+	Config          *config.Config // loaded configuration, including the AOJ endpoints
+	ConfigDir       string         // directory settings and templates are read from
+	DataDir         string         // Optional: directory of sessions, caches and history (defaults to ConfigDir)
+	Clock           clock.Clock    // Optional: source of the current time (defaults to the system clock)
+	PersistSessions bool           // write sessions to disk instead of keeping them in memory only
+}
+
+// App is the fully wired CLI
+type App struct {
+	Dependencies *Dependencies
+
+	root    *cli.RootCommand
+	command *cobra.Command
+	plugins *cli.PluginCommand
+	store   storage.Store
+}
+
+// New builds the application described by opts
+func New(opts Options) (*App, error) {
+	if opts.Config == nil {
+		return nil, cerrors.New("config is nil")
+	}
+	if opts.DataDir == "" {
+		opts.DataDir = opts.ConfigDir
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.System()
+	}
+
+	// Open the store that holds sessions, caches and history
+	store, err := storage.Open(opts.Config.Storage.Backend, opts.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := newDependencies(store, opts.ConfigDir, opts.DataDir, opts.Config, opts.Clock, opts.PersistSessions)
+	if err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+
+	app := &App{Dependencies: dependencies, store: store}
+	app.buildCommands(opts)
+	return app, nil
+}
+
+// Command returns the root command with all subcommands
+func (a *App) Command() *cobra.Command {
+	return a.command
+}
+
+// Execute runs the command line args, given without the program name.
+// Unknown subcommands are forwarded to aoj-<name> plugins, whose exit code is
+// returned; otherwise the exit code is 1 if the command failed
+func (a *App) Execute(args []string) (int, error) {
+	if exitCode, forwarded, err := a.plugins.Forward(a.command, args); forwarded {
+		return exitCode, err
+	}
+
+	a.command.SetArgs(args)
+	if err := a.root.Execute(a.command); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// HandleError presents an error of Execute and exits with a non-zero status
+func (a *App) HandleError(err error) {
+	a.root.HandleError(err)
+}
+
+// Close releases the local data store
+func (a *App) Close() error {
+	return a.store.Close()
+}
+
+// buildCommands creates the root command and all subcommands
+func (a *App) buildCommands(opts Options) {
+	dependencies := a.Dependencies
+
+	// Create root command
+	var usageStats *usecase.StatsUseCase
+	if opts.Config.Storage.UsageStats {
+		usageStats = dependencies.StatsUseCase
+	}
+	a.root = cli.NewRootCommandWithSettings(cli.RootSettings{
+		CrashDir:       filepath.Join(opts.DataDir, crash.DirName),
+		Stats:          usageStats,
+		SlowThresholds: slowThresholds(opts.Config.Log.SlowThresholds),
+		ConfigPath:     filepath.Join(opts.ConfigDir, config.FileName),
+		LogRotation:    logRotation(opts.Config.Log),
+	})
+	a.command = a.root.Command()
+	// Create and add login command
+	loginCmd := cli.NewLoginCommand(dependencies.LoginUseCase)
+	loginCommand := loginCmd.Command()
+
+	// Create and add init command
+	initCmd := cli.NewInitCommand(dependencies.InitUseCase, dependencies.ProblemResolver)
+	initCommand := initCmd.Command()
+
+	// Create and add submit command
+	submitCmd := cli.NewSubmitCommand(dependencies.SubmitUseCase, dependencies.TestUseCase)
+	submitCommand := cli.Use(submitCmd.Command(), cli.RequireSession(dependencies.SessionUseCase))
+
+	// Create and add resubmit command
+	resubmitCmd := cli.NewResubmitCommand(dependencies.SubmitUseCase)
+	resubmitCommand := cli.Use(resubmitCmd.Command(), cli.RequireSession(dependencies.SessionUseCase))
+
+	// Create and add test command
+	testCmd := cli.NewTestCommand(dependencies.TestUseCase)
+	testCommand := testCmd.Command()
+
+	// Create and add bench command
+	benchCmd := cli.NewBenchCommand(dependencies.TestUseCase)
+	benchCommand := benchCmd.Command()
+
+	// Create and add calibrate command
+	calibrateCmd := cli.NewCalibrateCommand(dependencies.CalibrateUseCase)
+	calibrateCommand := calibrateCmd.Command()
+
+	// Create and add gen command
+	genCmd := cli.NewGenCommand(dependencies.GenUseCase)
+	genCommand := genCmd.Command()
+
+	// Create and add diff-run command
+	diffRunCmd := cli.NewDiffRunCommand(dependencies.TestUseCase)
+	diffRunCommand := diffRunCmd.Command()
+
+	// Create and add listen command
+	listenCmd := cli.NewListenCommand(dependencies.ListenUseCase)
+	listenCommand := listenCmd.Command()
+
+	// Create and add backup command
+	backupCmd := cli.NewBackupCommand(dependencies.BackupUseCase)
+	backupCommand := backupCmd.Command()
+
+	// Create and add remind command
+	remindCmd := cli.NewRemindCommand(dependencies.ReminderUseCase)
+	remindCommand := remindCmd.Command()
+
+	// Create and add progress command
+	progressCmd := cli.NewProgressCommand(dependencies.ProgressUseCase)
+	progressCommand := progressCmd.Command()
+
+	// Create and add tag command
+	tagCmd := cli.NewTagCommand(dependencies.TagUseCase)
+	tagCommand := tagCmd.Command()
+
+	// Create and add show command
+	showCmd := cli.NewShowCommand(dependencies.StatementUseCase)
+	showCommand := showCmd.Command()
+
+	// Create and add source command
+	sourceCmd := cli.NewSourceCommand(dependencies.ProblemResolver)
+	sourceCommand := sourceCmd.Command()
+
+	// Create and add serve command
+	serveCmd := cli.NewServeCommand(dependencies.InitUseCase, dependencies.TestUseCase, dependencies.SubmitUseCase, dependencies.SessionUseCase)
+	serveCommand := serveCmd.Command()
+
+	// Create and add run command
+	runCmd := cli.NewRunCommand(dependencies.TestUseCase)
+	runCommand := runCmd.Command()
+
+	// Create and add verify command
+	verifyCmd := cli.NewVerifyCommand(dependencies.TestUseCase)
+	verifyCommand := verifyCmd.Command()
+
+	// Create and add session command
+	sessionCmd := cli.NewSessionCommand(dependencies.SessionUseCase)
+	sessionCommand := sessionCmd.Command()
+
+	// Create and add workspace and list commands
+	workspaceCmd := cli.NewWorkspaceCommand(dependencies.WorkspaceUseCase)
+	workspaceCommand := workspaceCmd.Command()
+	listCommand := workspaceCmd.ListCommand()
+
+	// Create and add testcase command
+	testCaseCmd := cli.NewTestCaseCommand(dependencies.TestCaseUseCase)
+	testCaseCommand := testCaseCmd.Command()
+
+	// Create and add template command
+	templateCmd := cli.NewTemplateCommand(dependencies.TemplateUseCase)
+	templateCommand := templateCmd.Command()
+
+	// Create and add export command
+	exportCmd := cli.NewExportCommand(dependencies.ExportUseCase)
+	exportCommand := exportCmd.Command()
+
+	// Create and add history command
+	historyCmd := cli.NewHistoryCommand(dependencies.HistoryUseCase)
+	historyCommand := historyCmd.Command()
+
+	// Create and add ranking command
+	rankingCmd := cli.NewRankingCommand(dependencies.RankingUseCase)
+	rankingCommand := rankingCmd.Command()
+
+	// Create and add random command
+	randomCmd := cli.NewRandomCommand(dependencies.RandomUseCase, dependencies.InitUseCase)
+	randomCommand := randomCmd.Command()
+
+	// Create and add todo command
+	todoCmd := cli.NewTodoCommand(dependencies.TodoUseCase, dependencies.InitUseCase, dependencies.DifficultyUseCase, opts.Clock)
+	todoCommand := todoCmd.Command()
+
+	// Create and add note command
+	noteCmd := cli.NewNoteCommand(dependencies.NoteUseCase)
+	noteCommand := noteCmd.Command()
+
+	// Create and add plugin command
+	a.plugins = cli.NewPluginCommand(dependencies.PluginUseCase)
+	pluginCommand := a.plugins.Command()
+
+	// Create and add setup command
+	setupCmd := cli.NewSetupCommand(dependencies.SetupUseCase, loginCmd)
+	setupCommand := setupCmd.Command()
+
+	// Create and add alias command
+	aliasCmd := cli.NewAliasCommand(dependencies.AliasUseCase)
+	aliasCommand := aliasCmd.Command()
+
+	// Create and add doctor command
+	doctorCmd := cli.NewDoctorCommand(dependencies.DoctorUseCase)
+	doctorCommand := doctorCmd.Command()
+
+	// Create and add stats command
+	statsCmd := cli.NewStatsCommand(dependencies.StatsUseCase, dependencies.StreakUseCase)
+	statsCommand := statsCmd.Command()
+
+	// Add subcommands to root
+	a.root.AddSubcommands(a.command, loginCommand, initCommand, testCommand, runCommand, benchCommand, calibrateCommand, genCommand, diffRunCommand, verifyCommand, submitCommand, resubmitCommand, sessionCommand,
+		workspaceCommand, listCommand, testCaseCommand, templateCommand, exportCommand, historyCommand, rankingCommand, randomCommand, todoCommand, noteCommand, pluginCommand, setupCommand, aliasCommand, doctorCommand, statsCommand, serveCommand, listenCommand, backupCommand, remindCommand, progressCommand, tagCommand, sourceCommand, showCommand)
+}
+
+// Dependencies holds all application dependencies
+type Dependencies struct {
+	LoginUseCase      *usecase.LoginUseCase
+	InitUseCase       *usecase.InitUseCase
+	SubmitUseCase     *usecase.SubmitUseCase
+	SessionUseCase    *usecase.SessionUseCase
+	WorkspaceUseCase  *usecase.WorkspaceUseCase
+	TestUseCase       *usecase.TestUseCase
+	TestCaseUseCase   *usecase.TestCaseUseCase
+	TemplateUseCase   *usecase.TemplateUseCase
+	DoctorUseCase     *usecase.DoctorUseCase
+	ExportUseCase     *usecase.ExportUseCase
+	HistoryUseCase    *usecase.HistoryUseCase
+	RankingUseCase    *usecase.RankingUseCase
+	RandomUseCase     *usecase.RandomUseCase
+	TodoUseCase       *usecase.TodoUseCase
+	NoteUseCase       *usecase.NoteUseCase
+	PluginUseCase     *usecase.PluginUseCase
+	SetupUseCase      *usecase.SetupUseCase
+	AliasUseCase      *usecase.AliasUseCase
+	StatsUseCase      *usecase.StatsUseCase
+	CalibrateUseCase  *usecase.CalibrateUseCase
+	GenUseCase        *usecase.GenUseCase
+	ListenUseCase     *usecase.ListenUseCase
+	BackupUseCase     *usecase.BackupUseCase
+	StreakUseCase     *usecase.StreakUseCase
+	ReminderUseCase   *usecase.ReminderUseCase
+	ProgressUseCase   *usecase.ProgressUseCase
+	TagUseCase        *usecase.TagUseCase
+	ProblemResolver   *usecase.ProblemResolver
+	StatementUseCase  *usecase.StatementUseCase
+	DifficultyUseCase *usecase.DifficultyUseCase
+}
+
+// newDependencies initializes all application dependencies on store.
+// Settings and templates are read from configDir
+func newDependencies(store storage.Store, configDir, dataDir string, cfg *config.Config, clk clock.Clock, persistSessions bool) (*Dependencies, error) {
+
+	// Initialize repositories
+	authRepo := repository.NewAOJAuthRepository(cfg.API.BaseURL, clk)
+	sessionRepo, err := newSessionRepository(store, cfg, clk, persistSessions)
+	if err != nil {
+		return nil, err
+	}
+	remoteProblemRepo := repository.NewAOJProblemRepositoryWithAPI(cfg.API.DataURL, cfg.API.BaseURL)
+	problemRepo := repository.NewCachedProblemRepositoryWithTTL(
+		remoteProblemRepo,
+		store,
+		time.Duration(cfg.Storage.ProblemCacheHours*float64(time.Hour)),
+		clk,
+	)
+	submissionRepo := repository.NewCachedSubmissionRepository(
+		repository.NewAOJSubmissionRepository(cfg.API.BaseURL, clk),
+		store,
+	)
+	languageRepo := repository.NewAOJLanguageRepository(cfg.API.BaseURL, store, clk)
+	todoRepo := repository.NewLocalTodoRepository(store)
+	tagRepo := repository.NewLocalTagRepository(store)
+	resultRepo := repository.NewLocalResultRepository(store)
+
+	// Initialize use cases
+	aliases := usecase.ProblemAliases(cfg.Aliases)
+	difficultyUseCase := usecase.NewDifficultyUseCase(newDifficultyProvider(store, cfg, clk))
+	loginUseCase := usecase.NewLoginUseCase(authRepo, sessionRepo)
+	workspaceRoot := config.ExpandHome(cfg.Workspace.Root)
+	templateDir := filepath.Join(configDir, "templates")
+	statementRepo := repository.NewAOJStatementRepository(cfg.API.BaseURL, store)
+	initUseCase := usecase.NewInitUseCase(problemRepo, usecase.InitLayout{
+		Root:              workspaceRoot,
+		SourceFile:        cfg.Init.SourceFile,
+		TestDir:           cfg.Init.TestDir,
+		CreateReadme:      cfg.Init.CreateReadme,
+		CreateNotes:       cfg.Init.CreateNotes,
+		ScaffoldDir:       config.ExpandHome(cfg.Init.ScaffoldDir),
+		EditorFiles:       cfg.Init.EditorFiles,
+		Language:          cfg.Init.Language,
+		Languages:         initLanguages(),
+		TemplateDir:       templateDir,
+		SaveStatement:     cfg.Init.SaveStatement,
+		StatementLanguage: cfg.Init.StatementLanguage,
+		Statements:        statementRepo,
+		Sessions:          sessionRepo,
+		Clock:             clk,
+		Aliases:           aliases,
+	})
+	submitUseCase := usecase.NewSubmitUseCase(submissionRepo, sessionRepo, languageRepo, usecase.SubmitSettings{
+		SourceFile:    cfg.Submit.SourceFile,
+		Language:      cfg.Submit.Language,
+		Watch:         cfg.Submit.Watch,
+		Clock:         clk,
+		Git:           vcs.NewGit(),
+		GitCommitOnAC: cfg.Submit.GitCommitOnAC,
+		GitTag:        cfg.Submit.GitTag,
+		Todos:         todoRepo,
+		Transforms:    submitTransforms(cfg.Submit.Transform),
+		Aliases:       aliases,
+		Pending:       repository.NewLocalPendingSubmissionRepository(store),
+		RetryAttempts: cfg.Submit.RetryAttempts,
+		JudgeQueue:    repository.NewAOJJudgeQueueRepository(cfg.API.BaseURL),
+		BatchDelay:    time.Duration(cfg.Submit.BatchDelay * float64(time.Second)),
+	})
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo, clk)
+	streakUseCase := usecase.NewStreakUseCase(submissionRepo, clk)
+	workspaceUseCase := usecase.NewWorkspaceUseCase(workspaceRoot, submissionRepo, usecase.WorkspaceSettings{
+		Aliases:    aliases,
+		Tags:       tagRepo,
+		Results:    resultRepo,
+		Difficulty: difficultyUseCase,
+	})
+	testUseCase := usecase.NewTestUseCase(runner.NewCachingRunner(runner.NewProcessRunner()), usecase.TestSettings{
+		SourceFile:      cfg.Init.SourceFile,
+		TestDir:         cfg.Init.TestDir,
+		Timeout:         time.Duration(cfg.Test.Timeout * float64(time.Second)),
+		TimeLimitFactor: cfg.Test.TimeLimitFactor,
+		SpeedFactor:     cfg.Test.SpeedFactor,
+		TLEWarnRatio:    cfg.Test.TLEWarnRatio,
+		Languages:       languageCommands(),
+		Interactor:      cfg.Test.InteractorCommand,
+		ExtraBuildFlags: cfg.Test.ExtraBuildFlags,
+		Aliases:         aliases,
+		Results:         resultRepo,
+		Clock:           clk,
+	})
+	testCaseUseCase := usecase.NewTestCaseUseCase(
+		repository.NewAOJTestCaseRepository(cfg.API.DataURL, repository.DefaultTestCaseRequestInterval),
+		usecase.TestCaseSettings{TestDir: cfg.Init.TestDir, Aliases: aliases, Problems: problemRepo},
+	)
+	configPath := filepath.Join(configDir, config.FileName)
+	// The API check must reach AOJ, so the doctor bypasses the problem cache
+	doctorUseCase := usecase.NewDoctorUseCase(authRepo, sessionRepo, remoteProblemRepo, usecase.DoctorSettings{
+		ConfigPath: configPath,
+		ConfigDir:  configDir,
+		SourceFile: cfg.Init.SourceFile,
+		Languages:  languageCommands(),
+		Clock:      clk,
+	})
+
+	return &Dependencies{
+		LoginUseCase:     loginUseCase,
+		InitUseCase:      initUseCase,
+		SubmitUseCase:    submitUseCase,
+		SessionUseCase:   sessionUseCase,
+		WorkspaceUseCase: workspaceUseCase,
+		TestUseCase:      testUseCase,
+		TestCaseUseCase:  testCaseUseCase,
+		TemplateUseCase:  usecase.NewTemplateUseCase(templateDir),
+		DoctorUseCase:    doctorUseCase,
+		ExportUseCase:    usecase.NewExportUseCase(submissionRepo, problemRepo),
+		HistoryUseCase: usecase.NewHistoryUseCase(
+			submissionRepo,
+			repository.NewAOJSubmissionHistoryRepository(cfg.API.BaseURL),
+			sessionRepo,
+		),
+		RankingUseCase: usecase.NewRankingUseCase(repository.NewAOJSolutionRepository(cfg.API.BaseURL), submissionRepo, usecase.RankingSettings{Aliases: aliases}),
+		RandomUseCase:  usecase.NewRandomUseCase(problemRepo, submissionRepo, usecase.RandomSettings{Difficulty: difficultyUseCase}),
+		TodoUseCase:    usecase.NewTodoUseCase(todoRepo, usecase.TodoSettings{Clock: clk, Aliases: aliases}),
+		NoteUseCase:    usecase.NewNoteUseCase(workspaceRoot, usecase.NoteSettings{Aliases: aliases}),
+		PluginUseCase: usecase.NewPluginUseCase(plugin.NewExecutableHost(), usecase.PluginSettings{
+			ConfigDir:     configDir,
+			WorkspaceRoot: workspaceRoot,
+			Sessions:      sessionRepo,
+			Clock:         clk,
+			Aliases:       aliases,
+		}),
+		SetupUseCase:     usecase.NewSetupUseCase(configPath, initLanguages(), usecase.NewTemplateUseCase(templateDir)),
+		AliasUseCase:     usecase.NewAliasUseCase(configPath),
+		StatsUseCase:     usecase.NewStatsUseCase(repository.NewLocalUsageStatsRepository(store), clk),
+		CalibrateUseCase: usecase.NewCalibrateUseCase(configPath, nil),
+		GenUseCase:       usecase.NewGenUseCase(cfg.Init.TestDir),
+		ListenUseCase:    usecase.NewListenUseCase(initUseCase),
+		BackupUseCase: usecase.NewBackupUseCase(usecase.BackupSettings{
+			ConfigDir: configDir,
+			DataDir:   dataDir,
+			Sessions:  sessionRepo,
+			Clock:     clk,
+		}),
+		StreakUseCase: streakUseCase,
+		ReminderUseCase: usecase.NewReminderUseCase(usecase.ReminderSettings{
+			Streak:    streakUseCase,
+			Scheduler: reminder.NewScheduler(),
+			Notifier:  reminder.NewDesktopNotifier(),
+			ConfigDir: configDir,
+		}),
+		ProgressUseCase: usecase.NewProgressUseCase(repository.NewAOJCourseRepository(cfg.API.BaseURL, store, clk), submissionRepo),
+		TagUseCase:      usecase.NewTagUseCase(tagRepo, usecase.TagSettings{Aliases: aliases}),
+		StatementUseCase: usecase.NewStatementUseCase(statementRepo, usecase.StatementSettings{
+			Aliases:    aliases,
+			Language:   cfg.Init.StatementLanguage,
+			Difficulty: difficultyUseCase,
+		}),
+		ProblemResolver:   usecase.NewProblemResolver(repository.NewAOJChallengeRepository(cfg.API.BaseURL, store, clk), cfg.Sources),
+		DifficultyUseCase: difficultyUseCase,
+	}, nil
+}
+
+// newDifficultyProvider returns the difficulty table of the configuration, or
+// nil when none is configured
+func newDifficultyProvider(store storage.Store, cfg *config.Config, clk clock.Clock) service.DifficultyProvider {
+	if cfg.Difficulty.URL == "" {
+		return nil
+	}
+	return difficulty.NewDataset(
+		config.ExpandHome(cfg.Difficulty.URL),
+		store,
+		time.Duration(cfg.Difficulty.CacheHours*float64(time.Hour)),
+		clk,
+	)
+}
+
+// newSessionRepository creates the session repository, encrypting sessions when
+// configured. Without persistSessions sessions only live in memory
+func newSessionRepository(store storage.Store, cfg *config.Config, clk clock.Clock, persistSessions bool) (domainrepo.SessionRepository, error) {
+	if !persistSessions {
+		return repository.NewMemorySessionRepository(clk), nil
+	}
+	if !cfg.Login.EncryptSessions {
+		return repository.NewLocalSessionRepositoryWithStore(store, nil, clk), nil
+	}
+
+	cipher, err := encryption.NewDefaultCipher()
+	if err != nil {
+		return nil, err
+	}
+	return repository.NewLocalSessionRepositoryWithStore(store, cipher, clk), nil
+}
+
+// submitTransforms converts the configured source transforms for the submit use case
+func submitTransforms(configured map[string]config.SourceTransform) map[string]usecase.SourceTransform {
+	transforms := make(map[string]usecase.SourceTransform, len(configured))
+	for language, transform := range configured {
+		transforms[language] = usecase.SourceTransform{Prelude: transform.Prelude, Epilogue: transform.Epilogue}
+	}
+	return transforms
+}
+
+// logRotation converts the configured rotation limits of the log file
+func logRotation(configured config.LogConfig) logger.FileConfig {
+	return logger.FileConfig{
+		MaxSize:    int64(configured.MaxSizeMB) << 20,
+		MaxAge:     time.Duration(configured.MaxAgeDays) * 24 * time.Hour,
+		MaxBackups: configured.MaxBackups,
+	}
+}
+
+// slowThresholds converts the configured slow-operation thresholds from seconds
+func slowThresholds(configured map[string]float64) map[string]time.Duration {
+	thresholds := make(map[string]time.Duration, len(configured))
+	for operation, seconds := range configured {
+		thresholds[operation] = time.Duration(seconds * float64(time.Second))
+	}
+	return thresholds
+}
+
+// initLanguages returns the configured languages init can scaffold, in name order
+func initLanguages() []usecase.InitLanguage {
+	languages := config.DefaultLanguages()
+
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]usecase.InitLanguage, 0, len(names))
+	for _, name := range names {
+		lang := languages[name]
+		result = append(result, usecase.InitLanguage{
+			Name:      name,
+			AOJName:   lang.AOJLanguageID,
+			Extension: lang.Extension,
+		})
+	}
+	return result
+}
+
+// languageCommands returns the build and run commands of the configured languages
+func languageCommands() []usecase.LanguageCommand {
+	languages := config.DefaultLanguages()
+
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	// Sort so that the oldest standard (e.g. cpp17 before cpp23) wins for shared extensions
+	sort.Strings(names)
+
+	commands := make([]usecase.LanguageCommand, 0, len(names))
+	for _, name := range names {
+		lang := languages[name]
+		commands = append(commands, usecase.LanguageCommand{
+			Extension:    lang.Extension,
+			BuildCommand: lang.BuildCommand,
+			RunCommand:   lang.RunCommand,
+		})
+	}
+	return commands
+}
@@ -0,0 +1,91 @@
+package app_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/app"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/aojfake"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// newApp builds the CLI against a fake AOJ with one problem and one user
+func newApp(t *testing.T) (*app.App, *aojfake.Server, *config.Config) {
+	t.Helper()
+	server := aojfake.New()
+	t.Cleanup(server.Close)
+	server.AddUser("alice", "secret")
+	server.AddProblem(aojfake.Problem{
+		ID:          "ITP1_1_A",
+		Name:        "Hello World",
+		TimeLimit:   time.Second,
+		MemoryLimit: 131072,
+		Samples:     []aojfake.Case{{In: "", Out: "Hello World\n"}},
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.API.BaseURL = server.URL()
+	cfg.API.DataURL = server.URL()
+	cfg.Workspace.Root = t.TempDir()
+	application, err := app.New(app.Options{
+		Config:          cfg,
+		ConfigDir:       t.TempDir(),
+		PersistSessions: true,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = application.Close() })
+	return application, server, cfg
+}
+
+func TestApp_InitAndSubmit(t *testing.T) {
+	// Given
+	application, server, cfg := newApp(t)
+	problemDir := filepath.Join(cfg.Workspace.Root, "ITP1_1_A")
+
+	// When
+	initCode, initErr := application.Execute([]string{"init", "ITP1_1_A"})
+	_, loginErr := application.Dependencies.LoginUseCase.Execute(context.Background(), usecase.LoginRequest{Username: "alice", Password: "secret"})
+	t.Chdir(problemDir)
+	submitCode, submitErr := application.Execute([]string{"submit", "--poll-interval", "10ms"})
+
+	// Then
+	if initErr != nil || loginErr != nil || submitErr != nil {
+		t.Fatalf("init: %v, login: %v, submit: %v", initErr, loginErr, submitErr)
+	}
+	assert.Equal(t, 0, initCode)
+	assert.Equal(t, 0, submitCode)
+	sample, err := os.ReadFile(filepath.Join(problemDir, cfg.Init.TestDir, "sample-1.out"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello World\n", string(sample))
+
+	received := server.Submissions()
+	if len(received) != 1 {
+		t.Fatalf("expected one submission, got %d", len(received))
+	}
+	assert.Equal(t, "alice", received[0].UserID)
+	assert.Equal(t, "ITP1_1_A", received[0].ProblemID)
+	assert.Equal(t, cfg.Submit.Language, received[0].Language)
+}
+
+func TestApp_Execute_UnknownCommand(t *testing.T) {
+	application, _, _ := newApp(t)
+
+	exitCode, err := application.Execute([]string{"no-such-command"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, exitCode)
+}
+
+func TestNew_RequiresConfig(t *testing.T) {
+	_, err := app.New(app.Options{ConfigDir: t.TempDir()})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// AliasCommand represents the alias command
+type AliasCommand struct {
+	aliasUseCase *usecase.AliasUseCase
+	logger       *logger.Logger
+}
+
+// NewAliasCommand creates a new alias command
+func NewAliasCommand(aliasUseCase *usecase.AliasUseCase) *AliasCommand {
+	return &AliasCommand{
+		aliasUseCase: aliasUseCase,
+		logger:       logger.WithGroup("alias_command"),
+	}
+}
+
+// Command returns the cobra command for alias
+func (c *AliasCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage names for problem IDs",
+		Long: `Give problems memorable names. An alias is accepted wherever a problem ID
+is, e.g. by init, submit, note show and ranking, and a problem directory
+named after an alias is recognized as that problem.
+
+Aliases are stored in the [aliases] table of config.toml.`,
+	}
+
+	cmd.AddCommand(c.addCommand(), c.listCommand(), c.removeCommand())
+
+	return cmd
+}
+
+// addCommand returns the cobra command for alias add
+func (c *AliasCommand) addCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <name> <problem-id>",
+		Short: "Define an alias for a problem",
+		Long: `Define an alias for a problem, replacing an alias of the same name.
+
+Examples:
+  aoj alias add two-sum ITP1_6_D
+  aoj init two-sum`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			alias, err := c.aliasUseCase.Add(ctx, args[0], args[1])
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to add alias", "alias", args[0], "error", err)
+				return fmt.Errorf("failed to add alias %s: %w", args[0], err)
+			}
+			fmt.Printf("%s now stands for %s\n", alias.Name, alias.ProblemID)
+			return nil
+		},
+	}
+}
+
+// listCommand returns the cobra command for alias list
+func (c *AliasCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show the aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			aliases, err := c.aliasUseCase.List(ctx)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to list aliases", "error", err)
+				return fmt.Errorf("failed to read aliases: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(aliases)
+			}
+			if len(aliases) == 0 {
+				fmt.Println("No aliases. Add one with 'aoj alias add <name> <problem-id>'")
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, alias := range aliases {
+				fmt.Fprintf(w, "%s\t%s\n", alias.Name, alias.ProblemID)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the aliases as JSON")
+
+	return cmd
+}
+
+// removeCommand returns the cobra command for alias rm
+func (c *AliasCommand) removeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <name>",
+		Aliases: []string{"remove"},
+		Short:   "Remove an alias",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := c.aliasUseCase.Remove(ctx, args[0]); err != nil {
+				c.logger.ErrorContext(ctx, "failed to remove alias", "alias", args[0], "error", err)
+				return fmt.Errorf("failed to remove alias %s: %w", args[0], err)
+			}
+			fmt.Printf("Removed alias %s\n", args[0])
+			return nil
+		},
+	}
+}
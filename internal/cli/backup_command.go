@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// BackupPassphraseEnvVar is the environment variable holding the passphrase of the sessions in a backup
+const BackupPassphraseEnvVar = "AOJ_BACKUP_PASSPHRASE"
+
+// BackupCommand represents the backup command
+type BackupCommand struct {
+	backupUseCase *usecase.BackupUseCase
+	logger        *logger.Logger
+}
+
+// NewBackupCommand creates a new backup command
+func NewBackupCommand(backupUseCase *usecase.BackupUseCase) *BackupCommand {
+	return &BackupCommand{
+		backupUseCase: backupUseCase,
+		logger:        logger.WithGroup("backup_command"),
+	}
+}
+
+// Command returns the cobra command for backup
+func (c *BackupCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Move the configuration and local data to another machine",
+		Long: `Archive config.toml with its aliases, templates, caches and submission
+history into a single tarball, and restore it on another machine.
+
+Sessions are left out unless --include-sessions is given; they are then
+encrypted with a passphrase read from ` + BackupPassphraseEnvVar + ` or asked
+for. Crash reports are never archived.`,
+	}
+
+	cmd.AddCommand(c.createCommand(), c.restoreCommand())
+
+	return cmd
+}
+
+// createCommand returns the cobra command for backup create
+func (c *BackupCommand) createCommand() *cobra.Command {
+	var (
+		opts       usecase.BackupCreateOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <file>",
+		Short: "Archive the configuration and local data",
+		Long: `Archive the configuration and local data into a gzipped tarball.
+
+Examples:
+  aoj backup create aoj-backup.tar.gz
+  aoj backup create aoj-backup.tar.gz --include-sessions`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			opts.Path = args[0]
+
+			if opts.IncludeSessions {
+				passphrase, err := newBackupPassphrase()
+				if err != nil {
+					return err
+				}
+				opts.Passphrase = passphrase
+			}
+
+			result, err := c.backupUseCase.Create(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to create backup", "path", opts.Path, "error", err)
+				return err
+			}
+
+			if jsonOutput {
+				return printBackupJSON(result)
+			}
+			fmt.Printf("%s Backed up %d file(s) to %s (%d bytes)\n", paint(colorGreen, "✓"), result.Files, result.Path, result.Size)
+			if result.SessionsSkipped {
+				fmt.Println("  Sessions were not included; log in again on the new machine or pass --include-sessions")
+			} else {
+				fmt.Printf("  %d session(s) encrypted with the passphrase\n", result.Sessions)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.IncludeSessions, "include-sessions", false, "Include the sessions, encrypted with a passphrase")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+
+	return cmd
+}
+
+// restoreCommand returns the cobra command for backup restore
+func (c *BackupCommand) restoreCommand() *cobra.Command {
+	var (
+		opts       usecase.BackupRestoreOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore a backup made by aoj backup create",
+		Long: `Restore a backup made by aoj backup create. An existing configuration is
+only replaced with --force.
+
+Sessions in the backup are restored when the passphrase is given in
+` + BackupPassphraseEnvVar + ` or typed in; an empty passphrase skips them.
+
+Examples:
+  aoj backup restore aoj-backup.tar.gz
+  aoj backup restore aoj-backup.tar.gz --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			opts.Path = args[0]
+			opts.Passphrase = func() (string, error) {
+				if passphrase := os.Getenv(BackupPassphraseEnvVar); passphrase != "" {
+					return passphrase, nil
+				}
+				fmt.Print("Backup passphrase (empty to skip sessions): ")
+				return readPassword()
+			}
+
+			result, err := c.backupUseCase.Restore(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to restore backup", "path", opts.Path, "error", err)
+				return err
+			}
+
+			if jsonOutput {
+				return printBackupJSON(result)
+			}
+			fmt.Printf("%s Restored %d file(s) from %s\n", paint(colorGreen, "✓"), result.Files, result.Path)
+			if result.SessionsSkipped {
+				fmt.Println("  No sessions were restored; log in with 'aoj login'")
+			} else {
+				fmt.Printf("  %d session(s) restored\n", result.Sessions)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Replace the existing configuration and data")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+
+	return cmd
+}
+
+// newBackupPassphrase reads the passphrase to encrypt sessions with,
+// asking twice when it is not set in the environment
+func newBackupPassphrase() (string, error) {
+	if passphrase := os.Getenv(BackupPassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fmt.Print("Backup passphrase: ")
+	passphrase, err := readPassword()
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to read the backup passphrase")
+	}
+	fmt.Print("Repeat the passphrase: ")
+	repeated, err := readPassword()
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to read the backup passphrase")
+	}
+	if passphrase != repeated {
+		return "", cerrors.NewAppError(cerrors.CodeInvalidInput, "the passphrases do not match", nil)
+	}
+	return passphrase, nil
+}
+
+// printBackupJSON writes a backup result as JSON
+func printBackupJSON(result *usecase.BackupResult) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// BenchCommand represents the bench command
+type BenchCommand struct {
+	testUseCase *usecase.TestUseCase
+	logger      *logger.Logger
+}
+
+// NewBenchCommand creates a new bench command
+func NewBenchCommand(testUseCase *usecase.TestUseCase) *BenchCommand {
+	return &BenchCommand{
+		testUseCase: testUseCase,
+		logger:      logger.WithGroup("bench_command"),
+	}
+}
+
+// Command returns the cobra command for bench
+func (c *BenchCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.BenchOptions
+		jsonOutput bool
+		csvFile    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bench [file]",
+		Short: "Measure the running time of a solution",
+		Long: `Build the solution and run it several times over one input, then report
+the min, mean and 95th percentile wall time and the peak memory usage,
+compared against the time limit. A sparkline of the runs in order and a
+histogram show how the times are distributed; --csv writes every run for
+plotting elsewhere.
+
+By default the largest sample input is used.
+
+Examples:
+  # Run main.go 10 times over the largest sample
+  aoj bench
+
+  # Run 50 times over a generated input with a 1 second limit
+  aoj bench main.cpp --input large.in --runs 50 --time-limit 1s
+
+  # Keep the time and memory of every run
+  aoj bench --runs 100 --csv runs.csv`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.SourceFile = args[0]
+			}
+			return c.run(cmd, opts, jsonOutput, csvFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.InputFile, "input", "i", "", "Input file (default: largest sample)")
+	cmd.Flags().IntVarP(&opts.Case, "case", "c", 0, "Use the sample with this number as input")
+	cmd.Flags().IntVarP(&opts.Runs, "runs", "n", 10, "Number of runs")
+	cmd.Flags().DurationVar(&opts.TimeLimit, "time-limit", 0, "Time limit to compare against (default: problem time limit)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+	cmd.Flags().StringVar(&csvFile, "csv", "", "Write the time and memory of every run to this CSV file")
+
+	return cmd
+}
+
+// run executes the bench command
+func (c *BenchCommand) run(cmd *cobra.Command, opts usecase.BenchOptions, jsonOutput bool, csvFile string) error {
+	ctx := cmd.Context()
+
+	report, err := c.testUseCase.Bench(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "benchmark failed", "error", err)
+		return fmt.Errorf("benchmark failed: %w", err)
+	}
+
+	if csvFile != "" {
+		if err := writeBenchCSV(csvFile, report.Samples); err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+		return interruptedError(report)
+	}
+
+	fmt.Printf("Source: %s\n", report.SourceFile)
+	fmt.Printf("Input: %s\n", report.Input)
+	fmt.Printf("Runs: %d\n\n", report.Runs)
+	fmt.Printf("min   %s\n", formatMillis(report.Min))
+	fmt.Printf("mean  %s\n", formatMillis(report.Mean))
+	fmt.Printf("p95   %s\n", formatMillis(report.P95))
+	fmt.Printf("max   %s\n", formatMillis(report.Max))
+	if report.MaxMemoryKB > 0 {
+		fmt.Printf("mem   %d KB\n", report.MaxMemoryKB)
+	}
+	fmt.Println()
+	if len(report.Samples) > 1 {
+		fmt.Print(benchGraph(report.Samples))
+		fmt.Println()
+	}
+	if csvFile != "" {
+		fmt.Printf("Wrote %d runs to %s\n\n", len(report.Samples), csvFile)
+	}
+
+	if report.Failures > 0 {
+		fmt.Println(paint(colorYellow, fmt.Sprintf("! %d of %d runs crashed or were killed", report.Failures, report.Runs)))
+	}
+	p95 := "p95"
+	if report.JudgeP95() != report.P95 {
+		fmt.Printf("Speed factor %.2f: p95 is about %s on the judge\n", report.SpeedFactor, formatMillis(report.JudgeP95()))
+		p95 = "estimated judge p95"
+	}
+	if report.ExceedsTimeLimit() {
+		fmt.Println(paint(colorRed, "✗ "+p95+" exceeds the time limit of "+formatMillis(report.TimeLimit)))
+	} else {
+		fmt.Println(paint(colorGreen, fmt.Sprintf("✓ %s is within the time limit of %s (%.0f%%)",
+			p95,
+			formatMillis(report.TimeLimit),
+			100*float64(report.JudgeP95())/float64(report.TimeLimit))))
+	}
+
+	return interruptedError(report)
+}
+
+// interruptedError returns an error when the benchmark was cut short by a signal
+func interruptedError(report *usecase.BenchReport) error {
+	if !report.Interrupted {
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, paintFor(os.Stderr, colorYellow, fmt.Sprintf("! Interrupted after %d runs", report.Runs)))
+	return fmt.Errorf("benchmark interrupted: %w", context.Canceled)
+}
+
+// benchHistogramBuckets is the number of rows of the time histogram
+const benchHistogramBuckets = 8
+
+// benchHistogramWidth is the width of the longest histogram bar
+const benchHistogramWidth = 30
+
+// benchGraph renders sparklines of the time and memory of the runs in order
+// and a histogram of their times
+func benchGraph(samples []usecase.BenchSample) string {
+	times := make([]float64, len(samples))
+	memory := make([]float64, len(samples))
+	hasMemory := false
+	for i, sample := range samples {
+		times[i] = float64(sample.Time)
+		memory[i] = float64(sample.MemoryKB)
+		hasMemory = hasMemory || sample.MemoryKB > 0
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "time  %s\n", sparkline(times))
+	if hasMemory {
+		fmt.Fprintf(&b, "mem   %s\n", sparkline(memory))
+	}
+	b.WriteString("\n")
+
+	buckets := usecase.TimeHistogram(samples, benchHistogramBuckets)
+	largest := 0
+	for _, bucket := range buckets {
+		largest = max(largest, bucket.Count)
+	}
+	t := table{header: []string{"TIME", "RUNS", ""}}
+	for _, bucket := range buckets {
+		t.addRow(
+			cell{text: formatMillis(bucket.From) + "-" + formatMillis(bucket.To)},
+			cell{text: strconv.Itoa(bucket.Count)},
+			cell{text: strings.Repeat("█", bucket.Count*benchHistogramWidth/largest)},
+		)
+	}
+	b.WriteString(t.String())
+	return b.String()
+}
+
+// writeBenchCSV writes the samples of a benchmark to a CSV file
+func writeBenchCSV(path string, samples []usecase.BenchSample) error {
+	trace.File("write", path)
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := usecase.WriteBenchCSV(file, samples); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// formatMillis formats a duration in milliseconds
+func formatMillis(d time.Duration) string {
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
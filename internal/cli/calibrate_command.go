@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// CalibrateCommand represents the calibrate command
+type CalibrateCommand struct {
+	calibrateUseCase *usecase.CalibrateUseCase
+	logger           *logger.Logger
+}
+
+// NewCalibrateCommand creates a new calibrate command
+func NewCalibrateCommand(calibrateUseCase *usecase.CalibrateUseCase) *CalibrateCommand {
+	return &CalibrateCommand{
+		calibrateUseCase: calibrateUseCase,
+		logger:           logger.WithGroup("calibrate_command"),
+	}
+}
+
+// Command returns the cobra command for calibrate
+func (c *CalibrateCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.CalibrateOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Measure how fast this machine is compared to the judge",
+		Long: `Run a standard micro-benchmark and store how many times slower than the
+AOJ judge this machine is as test.speed_factor in config.toml.
+
+aoj test and aoj bench divide local running times by the speed factor
+before comparing them with the time limit of a problem. Calibrate again
+after changing machines, and on an otherwise idle machine.
+
+Examples:
+  aoj calibrate
+  aoj calibrate --runs 10 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			result, err := c.calibrateUseCase.Execute(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "calibration failed", "error", err)
+				return fmt.Errorf("calibration failed: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+
+			fmt.Printf("Fastest of %d runs: %s (the judge takes about %s)\n",
+				result.Runs, formatMillis(result.Fastest), formatMillis(result.Reference))
+			fmt.Printf("Speed factor: %.2f (%s)\n", result.SpeedFactor, speedDescription(result.SpeedFactor))
+			if result.Saved {
+				fmt.Println(paint(colorGreen, fmt.Sprintf("✓ Saved test.speed_factor to %s (was %.2f)", result.ConfigPath, result.PreviousFactor)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.Runs, "runs", "n", 5, "Number of runs; the fastest one counts")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Measure without saving the speed factor")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+
+	return cmd
+}
+
+// speedDescription explains a speed factor in words
+func speedDescription(factor float64) string {
+	switch {
+	case factor > 1:
+		return fmt.Sprintf("this machine is %.1fx slower than the judge", factor)
+	case factor < 1:
+		return fmt.Sprintf("this machine is %.1fx faster than the judge", 1/factor)
+	default:
+		return "as fast as the judge"
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !windows
+
+package cli
+
+// enableANSI does nothing; terminals outside Windows render escape sequences natively
+func enableANSI() {}
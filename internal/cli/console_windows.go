@@ -0,0 +1,25 @@
+//go:build windows
+
+package cli
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableANSI turns on escape sequence processing in the Windows console so that
+// colored output is rendered instead of printed as raw codes. Redirected output
+// is left unchanged, and colors are turned off on consoles that do not support it
+func enableANSI() {
+	for _, file := range []*os.File{os.Stdout, os.Stderr} {
+		handle := windows.Handle(file.Fd())
+		var mode uint32
+		if err := windows.GetConsoleMode(handle, &mode); err != nil {
+			continue
+		}
+		if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil && currentColorMode == colorAuto {
+			setColorMode(colorNever)
+		}
+	}
+}
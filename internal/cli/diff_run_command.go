@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+const (
+	// diffColumnWidth is the width of each output column of a side-by-side diff
+	diffColumnWidth = 32
+	// diffMaxLines is how many output lines of a mismatch are shown
+	diffMaxLines = 20
+	// diffMaxShown is how many mismatches are shown in detail
+	diffMaxShown = 3
+)
+
+// DiffRunCommand represents the diff-run command
+type DiffRunCommand struct {
+	testUseCase *usecase.TestUseCase
+	logger      *logger.Logger
+}
+
+// NewDiffRunCommand creates a new diff-run command
+func NewDiffRunCommand(testUseCase *usecase.TestUseCase) *DiffRunCommand {
+	return &DiffRunCommand{
+		testUseCase: testUseCase,
+		logger:      logger.WithGroup("diff_run_command"),
+	}
+}
+
+// Command returns the cobra command for diff-run
+func (c *DiffRunCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.DiffRunOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff-run <a> <b>",
+		Short: "Run two solutions on the same inputs and compare them",
+		Long: `Build two solutions, run both on the same inputs and compare their outputs
+and running times, e.g. to check that a faster rewrite still gives the
+same answers as a slow but trusted solution.
+
+The samples are used by default; --gen compares on inputs generated from
+test/gen.toml (see aoj gen). Outputs are compared like aoj test does,
+ignoring trailing whitespace. Mismatches are shown side by side.
+
+Examples:
+  aoj diff-run naive.cpp main.cpp
+  aoj diff-run naive.py main.cpp --gen 200
+  aoj diff-run naive.cpp main.cpp --gen 1 --seed 1234`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.SourceA, opts.SourceB = args[0], args[1]
+			return c.run(cmd, opts, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Dir, "dir", "d", "", "Problem directory with the samples and test/gen.toml (default: current directory)")
+	cmd.Flags().IntVarP(&opts.Case, "case", "c", 0, "Use only the sample with this number")
+	cmd.Flags().IntVarP(&opts.Gen, "gen", "g", 0, "Compare on this many generated inputs instead of the samples")
+	cmd.Flags().Uint64Var(&opts.Seed, "seed", 0, "Seed of the first generated input (default: random)")
+	cmd.Flags().DurationVarP(&opts.Timeout, "timeout", "t", 0, "Time limit per run (default: problem time limit × test.time_limit_factor)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the comparison as JSON")
+
+	return cmd
+}
+
+// run executes the diff-run command
+func (c *DiffRunCommand) run(cmd *cobra.Command, opts usecase.DiffRunOptions, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	report, err := c.testUseCase.DiffRun(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "comparison failed", "error", err)
+		return fmt.Errorf("comparison failed: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printDiffRunReport(report)
+	}
+
+	if report.Interrupted {
+		return fmt.Errorf("comparison interrupted: %w", context.Canceled)
+	}
+	if mismatches := report.Mismatches(); mismatches > 0 {
+		return fmt.Errorf("%d of %d inputs differ", mismatches, len(report.Cases))
+	}
+	return nil
+}
+
+// printDiffRunReport prints the comparison table, the first mismatches and a summary
+func printDiffRunReport(report *usecase.DiffRunReport) {
+	fmt.Printf("A: %s\nB: %s\n\n", report.SourceA, report.SourceB)
+	fmt.Print(diffRunTable(report.Cases))
+
+	shown := 0
+	for _, c := range report.Cases {
+		if c.Same {
+			continue
+		}
+		if shown == diffMaxShown {
+			fmt.Printf("\n... %d more mismatches, see --json\n", report.Mismatches()-shown)
+			break
+		}
+		shown++
+		fmt.Printf("\n%s %s\n", paint(colorRed, "✗"), c.Name)
+		fmt.Printf("Input:\n%s\n", truncateLines(strings.TrimRight(c.Input, "\n"), diffMaxLines))
+		fmt.Print(sideBySide("A: "+report.SourceA, c.A.Output, "B: "+report.SourceB, c.B.Output))
+	}
+
+	totalA, totalB := report.TotalTimes()
+	fmt.Println()
+	if mismatches := report.Mismatches(); mismatches > 0 {
+		fmt.Println(paint(colorRed, fmt.Sprintf("❌ %d of %d inputs differ", mismatches, len(report.Cases))))
+		return
+	}
+	summary := fmt.Sprintf("✅ Same output on %d inputs, A took %s and B %s in total", len(report.Cases), formatMillis(totalA), formatMillis(totalB))
+	if totalA > 0 && totalB > 0 {
+		summary += fmt.Sprintf(" (B is %.1fx as fast)", float64(totalA)/float64(totalB))
+	}
+	fmt.Println(paint(colorGreen, summary))
+}
+
+// diffRunTable renders one row per input with the times of both solutions
+func diffRunTable(cases []usecase.DiffRunCase) string {
+	t := &table{header: []string{"INPUT", "A TIME", "B TIME", "RESULT"}}
+	for _, c := range cases {
+		result := cell{text: "✓ same", color: colorGreen}
+		switch {
+		case c.A.Failed():
+			result = cell{text: "! A " + outcomeProblem(c.A), color: colorYellow}
+		case c.B.Failed():
+			result = cell{text: "✗ B " + outcomeProblem(c.B), color: colorRed}
+		case !c.Same:
+			result = cell{text: "✗ differs", color: colorRed}
+		}
+		t.addRow(cell{text: c.Name}, cell{text: formatMillis(c.A.Duration)}, cell{text: formatMillis(c.B.Duration)}, result)
+	}
+	return t.String()
+}
+
+// outcomeProblem describes why a run failed
+func outcomeProblem(outcome usecase.DiffRunOutcome) string {
+	if outcome.TimedOut {
+		return "timed out"
+	}
+	return fmt.Sprintf("exited with %d", outcome.ExitCode)
+}
+
+// sideBySide renders two outputs in columns, marking differing lines with ≠
+func sideBySide(titleA, a, titleB, b string) string {
+	linesA := strings.Split(strings.TrimRight(a, "\n"), "\n")
+	linesB := strings.Split(strings.TrimRight(b, "\n"), "\n")
+	rows := max(len(linesA), len(linesB))
+
+	var out strings.Builder
+	out.WriteString(padColumn(titleA) + "   " + titleB + "\n")
+	for i := 0; i < min(rows, diffMaxLines); i++ {
+		left, right := lineAt(linesA, i), lineAt(linesB, i)
+		if strings.TrimSpace(left) == strings.TrimSpace(right) {
+			out.WriteString(padColumn(left) + " | " + right + "\n")
+			continue
+		}
+		out.WriteString(paint(colorRed, padColumn(left)+" ≠ "+right) + "\n")
+	}
+	if rows > diffMaxLines {
+		out.WriteString(fmt.Sprintf("... %d more lines\n", rows-diffMaxLines))
+	}
+	return out.String()
+}
+
+// lineAt returns the i-th line, or "" past the end
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// padColumn cuts or pads text to the width of a diff column
+func padColumn(text string) string {
+	if utf8.RuneCountInString(text) > diffColumnWidth {
+		return string([]rune(text)[:diffColumnWidth-1]) + "…"
+	}
+	return text + strings.Repeat(" ", diffColumnWidth-utf8.RuneCountInString(text))
+}
+
+// truncateLines keeps the first n lines of text
+func truncateLines(text string, n int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) <= n {
+		return text
+	}
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n... %d more lines", len(lines)-n)
+}
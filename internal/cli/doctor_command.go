@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// DoctorCommand represents the doctor command
+type DoctorCommand struct {
+	doctorUseCase *usecase.DoctorUseCase
+	logger        *logger.Logger
+}
+
+// NewDoctorCommand creates a new doctor command
+func NewDoctorCommand(doctorUseCase *usecase.DoctorUseCase) *DoctorCommand {
+	return &DoctorCommand{
+		doctorUseCase: doctorUseCase,
+		logger:        logger.WithGroup("doctor_command"),
+	}
+}
+
+// Command returns the cobra command for doctor
+func (c *DoctorCommand) Command() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the environment",
+		Long: `Check the configuration file, the config directory, the connection to
+AOJ, the login session and the compilers and interpreters used by
+'aoj test', and print how to fix anything that is wrong.
+
+Missing tools of the default solution file's language are failures;
+missing tools of other languages are only warnings.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+
+	return cmd
+}
+
+// run executes the doctor command
+func (c *DoctorCommand) run(cmd *cobra.Command, jsonOutput bool) error {
+	report := c.doctorUseCase.Execute(cmd.Context())
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		for _, check := range report.Checks {
+			fmt.Printf("%s %s: %s\n", checkMark(check.Status), check.Name, check.Detail)
+			if check.Fix != "" && check.Status != usecase.CheckOK {
+				fmt.Printf("    fix: %s\n", check.Fix)
+			}
+		}
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("some checks failed")
+	}
+	return nil
+}
+
+// checkMark returns a colored mark for a check status
+func checkMark(status string) string {
+	switch status {
+	case usecase.CheckOK:
+		return paint(colorGreen, "✓")
+	case usecase.CheckWarn:
+		return paint(colorYellow, "!")
+	case usecase.CheckFail:
+		return paint(colorRed, "✗")
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
+)
+
+// ErrorPresenter renders command errors as user-facing messages
+type ErrorPresenter struct {
+	out io.Writer
+}
+
+// NewErrorPresenter creates a new error presenter writing to out
+func NewErrorPresenter(out io.Writer) *ErrorPresenter {
+	return &ErrorPresenter{out: out}
+}
+
+// Present writes the error, with secrets masked, together with hints on how
+// to resolve it and a link to its documentation
+func (p *ErrorPresenter) Present(err error) {
+	if err == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(p.out, "%s %s\n", paintFor(p.out, colorRed, "✗ Error:"), redact.Text(err.Error()))
+	for _, suggestion := range Suggestions(err) {
+		_, _ = fmt.Fprintf(p.out, "%s %s\n", paintFor(p.out, colorYellow, "  hint:"), suggestion)
+	}
+	if docs := cerrors.Docs(err); docs != "" {
+		_, _ = fmt.Fprintf(p.out, "%s %s\n", paintFor(p.out, colorYellow, "  docs:"), docs)
+	}
+}
+
+// Suggestions returns the hints attached to the error and its AppError, or
+// the default hint of its error code when there are none
+func Suggestions(err error) []string {
+	hints := cerrors.GetAllHints(err)
+	var appErr *cerrors.AppError
+	if cerrors.As(err, &appErr) {
+		hints = append(hints, appErr.Hint)
+	}
+
+	var suggestions []string
+	for _, hint := range hints {
+		if hint = strings.TrimSpace(hint); hint != "" {
+			suggestions = append(suggestions, hint)
+		}
+	}
+	if len(suggestions) > 0 {
+		return suggestions
+	}
+
+	if hint := cerrors.DefaultHint(cerrors.GetErrorCode(err)); hint != "" {
+		return []string{hint}
+	}
+	return nil
+}
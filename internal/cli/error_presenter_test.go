@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestSuggestions(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "hint takes precedence over the error code",
+			err: cerrors.WithHint(
+				cerrors.NewAppError(cerrors.CodeUnauthorized, "authentication failed", nil),
+				"Check your username and password.",
+			),
+			want: []string{"Check your username and password."},
+		},
+		{
+			name: "default suggestion for the error code",
+			err:  fmt.Errorf("submission failed: %w", cerrors.NewAppError(cerrors.CodeNetworkError, "failed to connect", nil)),
+			want: []string{cerrors.DefaultHint(cerrors.CodeNetworkError)},
+		},
+		{
+			name: "hint of a catalogued error",
+			err:  cerrors.Wrap(cerrors.ErrProblemNotFound("ITP1_1_Z"), "init failed"),
+			want: []string{"Check the problem ID, e.g. ITP1_1_A; IDs are case-sensitive."},
+		},
+		{
+			name: "no suggestion for plain errors",
+			err:  cerrors.New("something went wrong"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Suggestions(tt.err))
+		})
+	}
+}
+
+func TestErrorPresenter_Present(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+	presenter := NewErrorPresenter(&out)
+	err := cerrors.NewAppError(cerrors.CodeUnauthorized, "session expired", nil)
+
+	// When
+	presenter.Present(err)
+
+	// Then
+	assert.Contains(t, out.String(), "session expired")
+	assert.Contains(t, out.String(), "aoj login")
+	assert.Contains(t, out.String(), "docs: https://github.com/YuminosukeSato/AOJ-cli#aoj-login")
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ExportCommand represents the export command
+type ExportCommand struct {
+	exportUseCase *usecase.ExportUseCase
+	logger        *logger.Logger
+}
+
+// NewExportCommand creates a new export command
+func NewExportCommand(exportUseCase *usecase.ExportUseCase) *ExportCommand {
+	return &ExportCommand{
+		exportUseCase: exportUseCase,
+		logger:        logger.WithGroup("export_command"),
+	}
+}
+
+// Command returns the cobra command for export
+func (c *ExportCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.ExportOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export accepted solutions to an archive",
+		Long: `Collect the accepted solutions recorded in the local submission history
+into a directory or zip archive with one directory per problem, e.g. for
+publishing a repository of your solutions.
+
+Each solution starts with a comment holding its metadata (problem, title,
+language, time, memory, submission date) as YAML front matter. By default
+only the latest accepted solution of each problem is exported.
+
+Examples:
+  # Write ./archive/ITP1_1_A/main.cpp, ...
+  aoj export --dest ./archive
+
+  # Write every accepted submission to solutions.zip
+  aoj export --format zip --dest solutions --all`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			result, err := c.exportUseCase.Export(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "export failed", "error", err)
+				return fmt.Errorf("export failed: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+			fmt.Printf("%s %d solutions to %s\n", paint(colorGreen, "✓ Exported"), len(result.Solutions), result.Dest)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Format, "format", usecase.ExportFormatDir, "Archive format: dir or zip")
+	cmd.Flags().StringVar(&opts.Dest, "dest", "archive", "Destination directory, or zip file")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Export every accepted submission, not only the latest per problem")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the exported files as JSON")
+
+	return cmd
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// fakeTimeFlag is the hidden global flag that freezes the clock for tests
+const fakeTimeFlag = "fake-time"
+
+// FakeTimeFromArgs returns the time given with --fake-time, if any.
+// Dependencies are built before cobra parses flags, so the arguments are scanned directly
+func FakeTimeFromArgs(args []string) (time.Time, bool, error) {
+	value, ok := flagValueFromArgs(args, fakeTimeFlag)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	fakeTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"--"+fakeTimeFlag+" must be an RFC 3339 time such as 2024-01-02T15:04:05Z",
+			err,
+		)
+	}
+	return fakeTime, true, nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeTimeFromArgs(t *testing.T) {
+	want := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantOK  bool
+		wantErr bool
+	}{
+		{name: "separate value", args: []string{"session", "list", "--fake-time", "2024-01-02T15:04:05Z"}, wantOK: true},
+		{name: "inline value", args: []string{"--fake-time=2024-01-02T15:04:05Z", "submit"}, wantOK: true},
+		{name: "absent", args: []string{"submit", "--lang", "C++17"}},
+		{name: "after terminator", args: []string{"test", "--", "--fake-time", "2024-01-02T15:04:05Z"}},
+		{name: "invalid", args: []string{"--fake-time", "yesterday"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := FakeTimeFromArgs(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.True(t, want.Equal(got))
+			}
+		})
+	}
+}
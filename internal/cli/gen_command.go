@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// GenCommand represents the gen command
+type GenCommand struct {
+	genUseCase *usecase.GenUseCase
+	logger     *logger.Logger
+}
+
+// NewGenCommand creates a new gen command
+func NewGenCommand(genUseCase *usecase.GenUseCase) *GenCommand {
+	return &GenCommand{
+		genUseCase: genUseCase,
+		logger:     logger.WithGroup("gen_command"),
+	}
+}
+
+// Command returns the cobra command for gen
+func (c *GenCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.GenOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate random inputs from test/gen.toml",
+		Long: `Generate random inputs from the declarative spec in test/gen.toml instead
+of writing a generator script for every problem.
+
+Variables are generated in order; bounds and lengths can refer to earlier
+int variables, e.g. "n", "n-1" or "2*n". Types are int, array,
+permutation, string and graph. format prints them with {name}
+placeholders; without it each variable is printed on its own line.
+
+  format = """
+  {n} {m}
+  {a}
+  {g}
+  """
+
+  [[var]]
+  name = "n"
+  type = "int"
+  min = 2
+  max = 100
+
+  [[var]]
+  name = "m"
+  type = "int"
+  min = "n-1"
+  max = "2*n"
+
+  [[var]]
+  name = "a"
+  type = "array"
+  length = "n"
+  min = 1
+  max = 1_000_000_000
+
+  [[var]]
+  name = "g"
+  type = "graph"
+  vertices = "n"
+  edges = "m"
+  connected = true
+
+The seed of each input is printed to stderr; the same seed always gives
+the same input.
+
+Examples:
+  # Print one input
+  aoj gen
+
+  # Reproduce an input
+  aoj gen --seed 42
+
+  # Write 100 inputs to test/gen
+  aoj gen --count 100 --out test/gen`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			if opts.Count > 1 && opts.OutDir == "" && !jsonOutput {
+				return fmt.Errorf("--count needs --out or --json")
+			}
+			inputs, err := c.genUseCase.Execute(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to generate inputs", "error", err)
+				return fmt.Errorf("failed to generate inputs: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(inputs)
+			}
+			for _, input := range inputs {
+				if input.Path != "" {
+					fmt.Printf("Wrote %s\n", input.Path)
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "seed: %d\n", input.Seed)
+				fmt.Print(input.Content)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint64Var(&opts.Seed, "seed", 0, "Seed of the first input (default: random)")
+	cmd.Flags().IntVarP(&opts.Count, "count", "n", 1, "Number of inputs, with consecutive seeds")
+	cmd.Flags().StringVarP(&opts.OutDir, "out", "o", "", "Write the inputs to gen-<seed>.in files in this directory")
+	cmd.Flags().StringVarP(&opts.Dir, "dir", "d", "", "Problem directory (default: current directory)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the inputs and their seeds as JSON")
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+package cli
+
+import "strings"
+
+// profileFlag is the global flag that selects a profile of config.toml
+const profileFlag = "profile"
+
+// ProfileFromArgs returns the profile given with --profile, or "" when the
+// flag is absent. The configuration is loaded before cobra parses flags
+func ProfileFromArgs(args []string) string {
+	profile, _ := flagValueFromArgs(args, profileFlag)
+	return profile
+}
+
+// flagValueFromArgs finds the value of a global --name flag in raw arguments,
+// given either as --name value or --name=value
+func flagValueFromArgs(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return "", false
+		case arg == "--"+name && i+1 < len(args):
+			return args[i+1], true
+		case strings.HasPrefix(arg, "--"+name+"="):
+			return strings.TrimPrefix(arg, "--"+name+"="), true
+		}
+	}
+	return "", false
+}
+
+// configDirFlag is the global flag that overrides the configuration directory
+const configDirFlag = "config-dir"
+
+// ConfigDirFromArgs returns the directory given with --config-dir and whether
+// the flag is present. Like ProfileFromArgs it scans the raw arguments
+func ConfigDirFromArgs(args []string) (string, bool) {
+	return flagValueFromArgs(args, configDirFlag)
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileFromArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "separate value", args: []string{"--profile", "contest", "submit"}, want: "contest"},
+		{name: "inline value", args: []string{"submit", "--profile=practice"}, want: "practice"},
+		{name: "absent", args: []string{"submit", "--lang", "C++17"}},
+		{name: "after terminator", args: []string{"test", "--", "--profile", "contest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ProfileFromArgs(tt.args))
+		})
+	}
+}
+
+func TestConfigDirFromArgs(t *testing.T) {
+	// When
+	dir, ok := ConfigDirFromArgs([]string{"--config-dir", "/work/.aoj", "test"})
+	inline, inlineOK := ConfigDirFromArgs([]string{"submit", "--config-dir=/ci/aoj"})
+	_, absent := ConfigDirFromArgs([]string{"submit"})
+
+	// Then
+	assert.True(t, ok)
+	assert.Equal(t, "/work/.aoj", dir)
+	assert.True(t, inlineOK)
+	assert.Equal(t, "/ci/aoj", inline)
+	assert.False(t, absent)
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// HistoryCommand represents the history command
+type HistoryCommand struct {
+	historyUseCase *usecase.HistoryUseCase
+	logger         *logger.Logger
+}
+
+// NewHistoryCommand creates a new history command
+func NewHistoryCommand(historyUseCase *usecase.HistoryUseCase) *HistoryCommand {
+	return &HistoryCommand{
+		historyUseCase: historyUseCase,
+		logger:         logger.WithGroup("history_command"),
+	}
+}
+
+// Command returns the cobra command for history
+func (c *HistoryCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Manage the local submission history",
+		Long:  "Maintain the local history of submissions used by list, resubmit and export",
+	}
+
+	cmd.AddCommand(c.syncCommand())
+
+	return cmd
+}
+
+// syncCommand returns the cobra command for history sync
+func (c *HistoryCommand) syncCommand() *cobra.Command {
+	var (
+		opts       usecase.HistorySyncOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Import your submissions from AOJ into the local history",
+		Long: `Page through your submission records on AOJ and add the ones missing
+from the local history, including submissions made on the website, so that
+commands reading the history work offline.
+
+The source code of each imported submission is downloaded as well, which
+requires being logged in as its author. Syncing stops at the first page that
+contains an already known submission; use --full to read every page, e.g.
+after an interrupted sync.
+
+Examples:
+  # Import new submissions of the logged-in user
+  aoj history sync
+
+  # Import the verdicts of another user without source code
+  aoj history sync --user alice --no-source`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			if !jsonOutput {
+				opts.Progress = func(imported int) {
+					fmt.Fprintf(os.Stderr, "  imported %d submissions...\n", imported)
+				}
+			}
+			result, err := c.historyUseCase.Sync(ctx, opts)
+			if result == nil {
+				c.logger.ErrorContext(ctx, "history sync failed", "error", err)
+				return fmt.Errorf("history sync failed: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if encodeErr := encoder.Encode(result); encodeErr != nil {
+					return encodeErr
+				}
+			} else {
+				fmt.Printf("%s %d new submissions of %s (%d fetched, %d updated)\n",
+					paint(colorGreen, "✓ Imported"), result.Imported, result.User, result.Fetched, result.Updated)
+				if result.MissingSource > 0 && !opts.NoSource {
+					fmt.Println(paint(colorYellow, fmt.Sprintf("⚠ %d submissions were imported without source code", result.MissingSource)))
+				}
+			}
+			if err != nil {
+				c.logger.ErrorContext(ctx, "history sync failed", "error", err)
+				return fmt.Errorf("history sync failed: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.User, "user", "", "AOJ user ID (default: the logged-in user)")
+	cmd.Flags().BoolVar(&opts.Full, "full", false, "Read every page instead of stopping at the first known submission")
+	cmd.Flags().BoolVar(&opts.NoSource, "no-source", false, "Do not download the source code of imported submissions")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+
+	return cmd
+}
@@ -13,48 +13,115 @@ import (
 // InitCommand represents the init command
 type InitCommand struct {
 	initUseCase *usecase.InitUseCase
+	resolver    *usecase.ProblemResolver
 	logger      *logger.Logger
 }
 
-// NewInitCommand creates a new init command
-func NewInitCommand(initUseCase *usecase.InitUseCase) *InitCommand {
+// NewInitCommand creates a new init command. resolver expands references to
+// past contests such as icpc-domestic:2020/A into problem IDs
+func NewInitCommand(initUseCase *usecase.InitUseCase, resolver *usecase.ProblemResolver) *InitCommand {
 	return &InitCommand{
 		initUseCase: initUseCase,
+		resolver:    resolver,
 		logger:      logger.WithGroup("init_command"),
 	}
 }
 
 // Command returns the cobra command for init
 func (c *InitCommand) Command() *cobra.Command {
+	var opts usecase.InitOptions
+
 	cmd := &cobra.Command{
-		Use:   "init <problem-id>",
+		Use:   "init <problem-id|source:contest[/letter]>...",
 		Short: "Initialize a problem directory",
 		Long: `Initialize a new problem directory with the given problem ID.
 This command will:
 - Create a directory named after the problem ID
 - Download test cases from AOJ
-- Generate solution template files`,
-		Args: cobra.ExactArgs(1),
-		RunE: c.run,
+- Generate solution template files
+
+The solution file is named after init.source_file. With --lang (or
+init.language in the config) its extension and template follow the
+chosen language instead. The content comes from --template, the
+language's default template ('aoj template use') or a built-in template.
+
+Problems of past ICPC and JAG contests can be named by their contest, as
+<source>:<year or contest>[/<letter>]; without a letter every problem of
+the contest is initialized. The directories are named after the AOJ
+problem IDs, so test and submit work as usual. See 'aoj source list'.
+
+Examples:
+  # Scaffold main.go (or the configured source file)
+  aoj init ITP1_1_A
+
+  # Scaffold main.py
+  aoj init ITP1_1_A --lang python
+
+  # Use the stored template fast-io and its language
+  aoj init ITP1_1_A --template fast-io
+
+  # Initialize several problems; failures are reported at the end
+  aoj init ITP1_1_A ITP1_1_B ITP1_1_C
+
+  # Initialize problem A, or all problems, of ICPC domestic 2020
+  aoj init icpc-domestic:2020/A
+  aoj init icpc-domestic:2020`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args, opts)
+		},
 	}
 
+	cmd.Flags().StringVarP(&opts.Language, "lang", "l", "", "Solution language, e.g. cpp17, python, java or go (default: init.language from config)")
+	cmd.Flags().StringVarP(&opts.Template, "template", "t", "", "Named template to use (default: the language's default template)")
+	cmd.Flags().StringVar(&opts.StatementLanguage, "lang-statement", "", "Language of the statement saved by init.save_statement, en or ja (default: init.statement_language from config)")
+
 	return cmd
 }
 
 // run executes the init command
-func (c *InitCommand) run(cmd *cobra.Command, args []string) error {
+func (c *InitCommand) run(cmd *cobra.Command, args []string, opts usecase.InitOptions) error {
 	ctx := cmd.Context()
+	args, err := c.resolver.Expand(ctx, args)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to resolve problems", "error", err)
+		return fmt.Errorf("failed to resolve problems: %w", err)
+	}
+	if len(args) > 1 {
+		return c.runAll(cmd, args, opts)
+	}
 	problemID := args[0]
 
 	c.logger.InfoContext(ctx, "initializing problem directory", "problem_id", problemID)
 
 	// Execute the use case
-	if err := c.initUseCase.Execute(ctx, problemID); err != nil {
+	if err := c.initUseCase.ExecuteWithOptions(ctx, problemID, opts); err != nil {
 		c.logger.ErrorContext(ctx, "failed to initialize problem", "problem_id", problemID, "error", err)
 		return fmt.Errorf("failed to initialize problem %s: %w", problemID, err)
 	}
 
 	c.logger.InfoContext(ctx, "successfully initialized problem directory", "problem_id", problemID)
 	fmt.Printf("Successfully initialized problem: %s\n", problemID)
+	if dir := c.initUseCase.ProblemDir(problemID); dir != problemID {
+		fmt.Printf("Directory: %s\n", dir)
+	}
+	return nil
+}
+
+// runAll initializes several problems, going on after failures
+func (c *InitCommand) runAll(cmd *cobra.Command, problemIDs []string, opts usecase.InitOptions) error {
+	ctx := cmd.Context()
+	err := c.initUseCase.ExecuteAll(ctx, problemIDs, opts, func(problemID string, err error) {
+		if err != nil {
+			c.logger.ErrorContext(ctx, "failed to initialize problem", "problem_id", problemID, "error", err)
+			fmt.Printf("%s %s\n", paint(colorRed, "✗"), problemID)
+			return
+		}
+		fmt.Printf("%s %s (%s)\n", paint(colorGreen, "✓"), problemID, c.initUseCase.ProblemDir(problemID))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize problems: %w", err)
+	}
+	fmt.Printf("Successfully initialized %d problems\n", len(problemIDs))
 	return nil
 }
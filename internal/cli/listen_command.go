@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// extensionOrigins are the origins of browser extensions, which competitive-companion sends from
+var extensionOrigins = []string{"chrome-extension://", "moz-extension://", "safari-web-extension://"}
+
+// ListenCommand represents the listen command
+type ListenCommand struct {
+	listenUseCase *usecase.ListenUseCase
+	logger        *logger.Logger
+}
+
+// NewListenCommand creates a new listen command
+func NewListenCommand(listenUseCase *usecase.ListenUseCase) *ListenCommand {
+	return &ListenCommand{
+		listenUseCase: listenUseCase,
+		logger:        logger.WithGroup("listen_command"),
+	}
+}
+
+// Command returns the cobra command for listen
+func (c *ListenCommand) Command() *cobra.Command {
+	var (
+		opts usecase.InitOptions
+		port int
+		once bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Create problem directories from the competitive-companion browser extension",
+		Long: `Listen for problems sent by the competitive-companion browser extension
+and create a problem directory for each one, like aoj init, with the samples
+the extension parsed from the page.
+
+Open an AOJ problem in the browser and click the green plus of the
+extension. Problems of other judges are rejected.
+
+Examples:
+  aoj listen
+  aoj listen --lang python --once`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd.Context(), port, once, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&port, "port", usecase.CompanionPort, "Port competitive-companion sends problems to")
+	cmd.Flags().StringVarP(&opts.Language, "lang", "l", "", "Solution language, e.g. cpp17, python, java or go (default: init.language from config)")
+	cmd.Flags().StringVarP(&opts.Template, "template", "t", "", "Named template to use (default: the language's default template)")
+	cmd.Flags().BoolVar(&once, "once", false, "Stop after the first problem, or the first batch such as a whole contest")
+
+	return cmd
+}
+
+// run receives problems until the context is cancelled or, with once, the first batch is complete
+func (c *ListenCommand) run(ctx context.Context, port int, once bool, opts usecase.InitOptions) error {
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return cerrors.WithHint(
+			cerrors.Wrap(err, "failed to listen on "+addr),
+			"Another competitive-companion listener may be running; stop it or pass --port",
+		)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	receiver := &companionReceiver{
+		listenUseCase: c.listenUseCase,
+		opts:          opts,
+		once:          once,
+		done:          cancel,
+		received:      make(map[string]int),
+		logger:        c.logger,
+	}
+	server := &http.Server{
+		Handler:           receiver,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	fmt.Printf("Waiting for competitive-companion on port %d (Ctrl+C to stop)\n", port)
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return cerrors.Wrap(err, "listener stopped")
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, stop := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+	defer stop()
+	_ = server.Shutdown(shutdownCtx)
+	return nil
+}
+
+// companionReceiver handles the problems POSTed by competitive-companion
+type companionReceiver struct {
+	listenUseCase *usecase.ListenUseCase
+	opts          usecase.InitOptions
+	once          bool
+	done          func()
+
+	mu       sync.Mutex
+	received map[string]int // problems received per batch
+	logger   *logger.Logger
+}
+
+// ServeHTTP imports one problem
+func (r *companionReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !isLoopbackHost(req.Host) || !isExtensionOrigin(req.Header.Get("Origin")) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	var problem usecase.CompanionProblem
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRPCRequestSize)).Decode(&problem); err != nil {
+		http.Error(w, "invalid problem: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	// Problems of a contest arrive at once; create their directories one by one
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result, err := r.listenUseCase.Import(req.Context(), problem, r.opts)
+	if err != nil {
+		r.logger.ErrorContext(req.Context(), "failed to import problem", "name", problem.Name, "error", err)
+		fmt.Fprintf(os.Stderr, "%s %s: %v\n", paintFor(os.Stderr, colorRed, "✗"), problem.Name, err)
+	} else {
+		samples := fmt.Sprintf("%d samples", result.Samples)
+		if result.Samples == 1 {
+			samples = "1 sample"
+		}
+		fmt.Printf("%s %s (%s): %s → %s\n", paint(colorGreen, "✓"), result.ProblemID, result.Name, samples, result.Dir)
+		if problem.Interactive {
+			fmt.Println(paint(colorYellow, "  ! interactive problem: set an interactor in problem.toml to test it"))
+		}
+	}
+
+	r.received[problem.Batch.ID]++
+	if r.once && r.received[problem.Batch.ID] >= max(problem.Batch.Size, 1) {
+		r.done()
+	}
+}
+
+// isExtensionOrigin reports whether a request comes from a browser extension or not from a browser at all
+func isExtensionOrigin(origin string) bool {
+	if origin == "" {
+		return true
+	}
+	for _, prefix := range extensionOrigins {
+		if strings.HasPrefix(origin, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,9 +2,11 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"fmt"
-	"syscall"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -70,6 +72,9 @@ func (c *LoginCommand) run(cmd *cobra.Command, _ []string) error {
 
 	// Display success message
 	c.displaySuccessMessage(response)
+	if noPersist, _ := cmd.Flags().GetBool(noPersistSessionFlag); noPersist {
+		fmt.Println("The session is kept in memory only and ends with this command.")
+	}
 
 	c.logger.InfoContext(ctx, "login command completed successfully", 
 		"username", response.Username)
@@ -102,16 +107,11 @@ func (c *LoginCommand) promptUsername() (string, error) {
 func (c *LoginCommand) promptPassword() (string, error) {
 	fmt.Print("Password: ")
 	
-	// Read password without echoing to terminal
-	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+	password, err := readPassword()
 	if err != nil {
 		return "", cerrors.Wrap(err, "failed to read password")
 	}
-	
-	// Print newline after password input
-	fmt.Println()
 
-	password := string(passwordBytes)
 	if password == "" {
 		return "", cerrors.NewAppError(
 			cerrors.CodeInvalidInput,
@@ -123,39 +123,40 @@ func (c *LoginCommand) promptPassword() (string, error) {
 	return password, nil
 }
 
-// handleLoginError handles different types of login errors
-func (c *LoginCommand) handleLoginError(err error) error {
-	c.logger.ErrorContext(context.Background(), "login failed", "error", err)
-
-	// Extract error code for user-friendly messages
-	if cerrors.IsAppError(err, cerrors.CodeUnauthorized) {
-		fmt.Println("❌ Login failed: Invalid username or password")
-		return nil // Don't return error to avoid double error output
+// readPassword reads the password without echoing it when stdin is a terminal.
+// Otherwise, e.g. when piped or under mintty on Windows, it reads a plain line
+func readPassword() (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		passwordBytes, err := term.ReadPassword(fd)
+		// Print newline after password input
+		fmt.Println()
+		return string(passwordBytes), err
 	}
 
-	if cerrors.IsAppError(err, cerrors.CodeNetworkError) {
-		fmt.Println("❌ Login failed: Unable to connect to AOJ. Please check your internet connection.")
-		return nil
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "warning: stdin is not a terminal, the password is read as plain input")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
 	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
 
-	if cerrors.IsAppError(err, cerrors.CodeServiceUnavailable) {
-		fmt.Println("❌ Login failed: AOJ service is currently unavailable. Please try again later.")
-		return nil
-	}
+// handleLoginError adds login-specific hints to login errors
+func (c *LoginCommand) handleLoginError(err error) error {
+	c.logger.ErrorContext(context.Background(), "login failed", "error", err)
 
-	if cerrors.IsAppError(err, cerrors.CodeInvalidInput) {
-		fmt.Printf("❌ Login failed: %s\n", err.Error())
-		return nil
+	if cerrors.IsAppError(err, cerrors.CodeUnauthorized) {
+		err = cerrors.WithHint(err, "Check your username and password.")
 	}
 
-	// Generic error
-	fmt.Printf("❌ Login failed: %s\n", err.Error())
-	return nil
+	return cerrors.Wrap(err, "login failed")
 }
 
 // displaySuccessMessage displays a success message to the user
 func (c *LoginCommand) displaySuccessMessage(response *usecase.LoginResponse) {
-	fmt.Println("✅ Login successful!")
+	fmt.Println(paint(colorGreen, "✅ Login successful!"))
 	fmt.Printf("Logged in as: %s\n", response.Username)
 	fmt.Printf("Session ID: %s\n", response.SessionID[:8]+"...")
 	fmt.Println("You can now use AOJ CLI commands.")
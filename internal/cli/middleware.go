@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// RunFunc is the function cobra runs for a command
+type RunFunc = func(cmd *cobra.Command, args []string) error
+
+// Middleware wraps the run function of a command with a cross-cutting
+// concern, so that commands declare such concerns instead of implementing
+// them in their RunE
+type Middleware func(next RunFunc) RunFunc
+
+// Use wraps the RunE of cmd with middlewares, the first one outermost, and
+// returns cmd. Commands without RunE, which only group subcommands, are left alone
+func Use(cmd *cobra.Command, middlewares ...Middleware) *cobra.Command {
+	if cmd.RunE == nil {
+		return cmd
+	}
+	run := cmd.RunE
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		run = middlewares[i](run)
+	}
+	cmd.RunE = run
+	return cmd
+}
+
+// useAll wraps cmd and all of its subcommands with middlewares
+func useAll(cmd *cobra.Command, middlewares ...Middleware) {
+	Use(cmd, middlewares...)
+	for _, sub := range cmd.Commands() {
+		useAll(sub, middlewares...)
+	}
+}
+
+// HandleSignals cancels the command context on SIGINT or SIGTERM, so that
+// running operations can stop cleanly
+func HandleSignals() Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			cmd.SetContext(ctx)
+			return next(cmd, args)
+		}
+	}
+}
+
+// SuggestSetup points first-time users to the setup wizard before the command
+// runs while there is no config file at configPath
+func SuggestSetup(configPath string) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(configPath); os.IsNotExist(err) {
+				w := cmd.ErrOrStderr()
+				fmt.Fprintf(w, "%s no %s yet; run 'aoj setup' to choose your language and workspace\n", paintFor(w, colorYellow, "hint:"), configPath)
+			}
+			return next(cmd, args)
+		}
+	}
+}
+
+// RequireSession fails before the command runs unless there is an active
+// session, e.g. so that 'aoj submit --all' does not run every test first
+func RequireSession(sessionUseCase *usecase.SessionUseCase) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if _, err := sessionUseCase.RequireActive(cmd.Context()); err != nil {
+				return err
+			}
+			return next(cmd, args)
+		}
+	}
+}
+
+// RecordUsage times the command, logs the duration and, unless statsUseCase
+// is nil, adds the run to the usage statistics
+func RecordUsage(statsUseCase *usecase.StatsUseCase) Middleware {
+	log := logger.WithGroup("usage")
+	return func(next RunFunc) RunFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			started := time.Now()
+			err := next(cmd, args)
+			duration := time.Since(started)
+
+			command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+			ctx := context.WithoutCancel(cmd.Context())
+			log.DebugContext(ctx, "command finished", "command", command, "elapsed", duration)
+			if statsUseCase != nil {
+				if recordErr := statsUseCase.RecordCommand(ctx, command, duration, err); recordErr != nil {
+					log.DebugContext(ctx, "failed to record usage statistics", "command", command, "error", recordErr)
+				}
+			}
+			return err
+		}
+	}
+}
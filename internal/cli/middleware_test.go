@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+func TestUse_Order(t *testing.T) {
+	// Given
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				calls = append(calls, name)
+				return next(cmd, args)
+			}
+		}
+	}
+	cmd := &cobra.Command{Use: "test", RunE: func(cmd *cobra.Command, args []string) error {
+		calls = append(calls, "run")
+		return nil
+	}}
+
+	// When
+	Use(cmd, trace("outer"), trace("inner"))
+	err := cmd.RunE(cmd, nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "run"}, calls)
+}
+
+func TestRequireSession(t *testing.T) {
+	// Given
+	sessions := usecase.NewSessionUseCase(repository.NewMemorySessionRepository(nil), clock.System())
+	ran := false
+	cmd := Use(&cobra.Command{Use: "submit", RunE: func(cmd *cobra.Command, args []string) error {
+		ran = true
+		return nil
+	}}, RequireSession(sessions))
+	cmd.SetContext(context.Background())
+
+	// When
+	err := cmd.RunE(cmd, nil)
+
+	// Then
+	if !cerrors.IsAppError(err, cerrors.CodeUnauthorized) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	assert.False(t, ran)
+}
+
+func TestRecordUsage(t *testing.T) {
+	// Given
+	stats := usecase.NewStatsUseCase(repository.NewLocalUsageStatsRepository(storage.NewFileStore(t.TempDir())), clock.System())
+	failure := errors.New("boom")
+	root := &cobra.Command{Use: "aoj"}
+	cmd := &cobra.Command{Use: "test", RunE: func(cmd *cobra.Command, args []string) error {
+		return failure
+	}}
+	root.AddCommand(cmd)
+	cmd.SetContext(context.Background())
+	Use(cmd, RecordUsage(stats))
+
+	// When
+	err := cmd.RunE(cmd, nil)
+
+	// Then
+	assert.ErrorIs(t, err, failure)
+	report, err := stats.CLIStats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.Commands, 1)
+	assert.Equal(t, "test", report.Commands[0].Command)
+	assert.Equal(t, 1, report.Commands[0].Failures)
+}
+
+func TestSuggestSetup(t *testing.T) {
+	// Given
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	var stderr bytes.Buffer
+	ran := 0
+	cmd := Use(&cobra.Command{Use: "init", RunE: func(cmd *cobra.Command, args []string) error {
+		ran++
+		return nil
+	}}, SuggestSetup(configPath))
+	cmd.SetErr(&stderr)
+
+	// When
+	missingErr := cmd.RunE(cmd, nil)
+	hint := stderr.String()
+	stderr.Reset()
+	require.NoError(t, os.WriteFile(configPath, nil, 0600))
+	presentErr := cmd.RunE(cmd, nil)
+
+	// Then
+	assert.NoError(t, missingErr)
+	assert.NoError(t, presentErr)
+	assert.Equal(t, 2, ran)
+	assert.Contains(t, hint, "run 'aoj setup'")
+	assert.Empty(t, stderr.String())
+}
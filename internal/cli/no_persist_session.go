@@ -0,0 +1,18 @@
+package cli
+
+// noPersistSessionFlag is the global flag that keeps sessions in memory only
+const noPersistSessionFlag = "no-persist-session"
+
+// NoPersistSessionFromArgs reports whether --no-persist-session is given.
+// Like FakeTimeFromArgs it scans the arguments because dependencies are built before cobra parses flags
+func NoPersistSessionFromArgs(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "--" + noPersistSessionFlag, "--" + noPersistSessionFlag + "=true":
+			return true
+		}
+	}
+	return false
+}
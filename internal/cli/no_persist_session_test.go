@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoPersistSessionFromArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{name: "flag", args: []string{"--no-persist-session", "login"}, want: true},
+		{name: "explicit true", args: []string{"submit", "--no-persist-session=true"}, want: true},
+		{name: "explicit false", args: []string{"submit", "--no-persist-session=false"}},
+		{name: "absent", args: []string{"submit", "--lang", "C++17"}},
+		{name: "after terminator", args: []string{"test", "--", "--no-persist-session"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NoPersistSessionFromArgs(tt.args))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// NoteCommand represents the note command
+type NoteCommand struct {
+	noteUseCase *usecase.NoteUseCase
+	logger      *logger.Logger
+}
+
+// NewNoteCommand creates a new note command
+func NewNoteCommand(noteUseCase *usecase.NoteUseCase) *NoteCommand {
+	return &NoteCommand{
+		noteUseCase: noteUseCase,
+		logger:      logger.WithGroup("note_command"),
+	}
+}
+
+// Command returns the cobra command for note
+func (c *NoteCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "note",
+		Short: "Edit or show the notes of a problem",
+		Long: `Record approaches and pitfalls in notes.md next to the solution.
+
+Without a problem ID, the current directory is the problem directory. The
+first line of the notes is shown by 'aoj list'.`,
+	}
+
+	cmd.AddCommand(c.editCommand(), c.showCommand())
+
+	return cmd
+}
+
+// editCommand returns the cobra command for note edit
+func (c *NoteCommand) editCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit [problem-id]",
+		Short: "Open the notes in your editor",
+		Long: `Open notes.md in $VISUAL or $EDITOR, creating it if needed.
+
+Examples:
+  aoj note edit
+  aoj note edit ALDS1_1_D`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			problemID := ""
+			if len(args) == 1 {
+				problemID = args[0]
+			}
+
+			note, err := c.noteUseCase.Prepare(ctx, problemID)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to prepare notes", "error", err)
+				return fmt.Errorf("failed to open notes: %w", err)
+			}
+
+			editor := editorCommand()
+			c.logger.DebugContext(ctx, "opening notes", "editor", editor, "path", note.Path)
+			fields := strings.Fields(editor)
+			edit := exec.CommandContext(ctx, fields[0], append(fields[1:], note.Path)...)
+			edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+			if err := edit.Run(); err != nil {
+				return fmt.Errorf("failed to run editor %q: %w", editor, err)
+			}
+			return nil
+		},
+	}
+}
+
+// showCommand returns the cobra command for note show
+func (c *NoteCommand) showCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [problem-id]",
+		Short: "Print the notes",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			problemID := ""
+			if len(args) == 1 {
+				problemID = args[0]
+			}
+
+			_, content, err := c.noteUseCase.Read(ctx, problemID)
+			if err != nil {
+				return err
+			}
+			fmt.Print(content)
+			if !strings.HasSuffix(content, "\n") {
+				fmt.Println()
+			}
+			return nil
+		},
+	}
+}
+
+// editorCommand returns the user's editor, falling back to the platform default
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// PluginCommand represents the plugin command and the forwarding of unknown
+// subcommands to plugins
+type PluginCommand struct {
+	pluginUseCase *usecase.PluginUseCase
+	logger        *logger.Logger
+}
+
+// NewPluginCommand creates a new plugin command
+func NewPluginCommand(pluginUseCase *usecase.PluginUseCase) *PluginCommand {
+	return &PluginCommand{
+		pluginUseCase: pluginUseCase,
+		logger:        logger.WithGroup("plugin_command"),
+	}
+}
+
+// Command returns the cobra command for plugin
+func (c *PluginCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage plugins",
+		Long: `Plugins are executables named ` + service.PluginPrefix + `<name> on PATH. 'aoj <name>' runs
+the plugin with the remaining arguments, like git and kubectl do.
+
+Plugins receive the context of the run in environment variables:
+  AOJ_CONTEXT         JSON with version, config_dir, workspace_root,
+                      problem_id, problem_dir and user
+  AOJ_CONFIG_DIR      the configuration directory
+  AOJ_WORKSPACE_ROOT  the workspace root
+  AOJ_PROBLEM_ID      the problem of the current directory, if any
+  AOJ_USER            the logged in user, if any`,
+	}
+
+	cmd.AddCommand(c.listCommand())
+
+	return cmd
+}
+
+// listCommand returns the cobra command for plugin list
+func (c *PluginCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the plugins found on PATH",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			plugins, err := c.pluginUseCase.List(ctx)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to list plugins", "error", err)
+				return err
+			}
+
+			if jsonOutput {
+				if plugins == nil {
+					plugins = []service.Plugin{}
+				}
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(plugins)
+			}
+			if len(plugins) == 0 {
+				fmt.Printf("No plugins found. Install an executable named %s<name> on PATH to add 'aoj <name>'.\n", service.PluginPrefix)
+				return nil
+			}
+
+			t := &table{header: []string{"COMMAND", "PATH"}}
+			for _, plugin := range plugins {
+				t.addRow(cell{text: plugin.Name}, cell{text: plugin.Path})
+			}
+			fmt.Print(t.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output plugins as JSON")
+
+	return cmd
+}
+
+// Forward runs the plugin named by the first argument after the global flags
+// when it is not a built-in command. It reports whether a plugin was run and
+// its exit status. Of the global flags, --offline is passed on to the plugin
+func (c *PluginCommand) Forward(root *cobra.Command, args []string) (int, bool, error) {
+	at := commandIndex(root, args)
+	if at < 0 || isBuiltinCommand(root, args[at]) {
+		return 0, false, nil
+	}
+	if err := root.PersistentFlags().Parse(args[:at]); err != nil {
+		return 0, false, nil
+	}
+	offlineMode, err := root.PersistentFlags().GetBool("offline")
+	if err != nil {
+		return 0, true, err
+	}
+	name, args := args[at], args[at+1:]
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = offline.WithOffline(ctx, offlineMode)
+
+	plugin, err := c.pluginUseCase.Find(ctx, name)
+	if cerrors.IsAppError(err, cerrors.CodeNotFound) || cerrors.IsAppError(err, cerrors.CodeInvalidInput) {
+		// Let cobra report the unknown command
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, true, err
+	}
+
+	code, err := c.pluginUseCase.Run(ctx, *plugin, args)
+	return code, true, err
+}
+
+// commandIndex returns the index of the command name in args, skipping the
+// global flags of root and their values, or -1 when args hold no command name
+// or an unknown flag comes first
+func commandIndex(root *cobra.Command, args []string) int {
+	flags := root.PersistentFlags()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--" || arg == "-":
+			return -1
+		case strings.HasPrefix(arg, "--"):
+			name, _, inline := strings.Cut(arg[2:], "=")
+			flag := flags.Lookup(name)
+			if flag == nil {
+				return -1
+			}
+			if !inline && flag.NoOptDefVal == "" {
+				i++
+			}
+		case strings.HasPrefix(arg, "-"):
+			for j := 1; j < len(arg); j++ {
+				flag := flags.ShorthandLookup(arg[j : j+1])
+				if flag == nil {
+					return -1
+				}
+				if flag.NoOptDefVal == "" {
+					if j == len(arg)-1 {
+						i++
+					}
+					break
+				}
+			}
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+// isBuiltinCommand returns true if name is a subcommand of root, including
+// the help and completion commands cobra adds when executing
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	switch name {
+	case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandIndex(t *testing.T) {
+	root := NewRootCommand(t.TempDir()).Command()
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "command first", args: []string{"graph", "--depth", "2"}, want: 0},
+		{name: "flag with separate value", args: []string{"--profile", "contest", "graph"}, want: 2},
+		{name: "flag with inline value", args: []string{"--profile=contest", "graph"}, want: 1},
+		{name: "boolean flag", args: []string{"--offline", "graph"}, want: 1},
+		{name: "shorthand flags", args: []string{"-vq", "graph"}, want: 1},
+		{name: "several flags", args: []string{"--offline", "--config-dir", "/work/.aoj", "-v", "graph", "ALDS1_1_A"}, want: 4},
+		{name: "unknown flag", args: []string{"--depth", "2", "graph"}, want: -1},
+		{name: "terminator", args: []string{"--", "graph"}, want: -1},
+		{name: "flags only", args: []string{"--profile", "contest"}, want: -1},
+		{name: "empty", args: nil, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, commandIndex(root, tt.args))
+		})
+	}
+}
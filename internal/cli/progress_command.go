@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// progressBarWidth is the width of the completion bar of a chapter
+const progressBarWidth = 20
+
+// ProgressCommand represents the progress command
+type ProgressCommand struct {
+	progressUseCase *usecase.ProgressUseCase
+	logger          *logger.Logger
+}
+
+// NewProgressCommand creates a new progress command
+func NewProgressCommand(progressUseCase *usecase.ProgressUseCase) *ProgressCommand {
+	return &ProgressCommand{
+		progressUseCase: progressUseCase,
+		logger:          logger.WithGroup("progress_command"),
+	}
+}
+
+// Command returns the cobra command for progress
+func (c *ProgressCommand) Command() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "progress <course>",
+		Short: "Show the completion of a course per chapter",
+		Long: `Show how many problems of each chapter of a course are solved, with the
+first unsolved problem of each chapter.
+
+Problems count as solved when an accepted submission is in the local history;
+import submissions made elsewhere with aoj history sync.
+
+Examples:
+  aoj progress ITP1
+  aoj progress ALDS1 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			progress, err := c.progressUseCase.Progress(ctx, args[0])
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to compute course progress", "course", args[0], "error", err)
+				return err
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(progress)
+			}
+			fmt.Print(renderProgress(progress))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the progress as JSON")
+
+	return cmd
+}
+
+// renderProgress renders the completion bar of every chapter of a course
+func renderProgress(progress *usecase.CourseProgress) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %d/%d solved (%d%%)\n\n", progress.Course, progress.Title, progress.Solved, progress.Total, percent(progress.Solved, progress.Total))
+
+	t := &table{header: []string{"#", "TOPIC", "PROGRESS", "SOLVED", "NEXT"}}
+	for _, chapter := range progress.Chapters {
+		color := colorYellow
+		next := ""
+		switch {
+		case chapter.Solved == chapter.Total:
+			color = colorGreen
+		case chapter.Solved == 0:
+			color = ""
+		}
+		if len(chapter.Unsolved) > 0 {
+			next = chapter.Unsolved[0].ID
+		}
+		t.addRow(
+			cell{text: strconv.Itoa(chapter.Chapter)},
+			cell{text: chapter.Title},
+			cell{text: progressBar(chapter.Solved, chapter.Total, progressBarWidth), color: color},
+			cell{text: fmt.Sprintf("%d/%d", chapter.Solved, chapter.Total)},
+			cell{text: next},
+		)
+	}
+	b.WriteString(t.String())
+	return b.String()
+}
+
+// percent returns value as a whole percentage of total
+func percent(value, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return value * 100 / total
+}
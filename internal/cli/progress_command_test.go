@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+)
+
+func TestRenderProgress(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// Given
+	progress := &usecase.CourseProgress{
+		Course: "ITP1",
+		Title:  "Introduction to Programming I",
+		Solved: 3,
+		Total:  8,
+		Chapters: []usecase.ChapterProgress{
+			{Chapter: 1, Title: "Getting Started", Solved: 2, Total: 2},
+			{Chapter: 2, Title: "Branch on Condition", Solved: 1, Total: 4, Unsolved: []repository.CourseProblem{{ID: "ITP1_2_B"}, {ID: "ITP1_2_C"}, {ID: "ITP1_2_D"}}},
+			{Chapter: 10, Title: "Math Functions", Solved: 0, Total: 2, Unsolved: []repository.CourseProblem{{ID: "ITP1_10_A"}, {ID: "ITP1_10_B"}}},
+		},
+	}
+
+	// When
+	out := renderProgress(progress)
+
+	// Then
+	assert.Equal(t, ""+
+		"ITP1 Introduction to Programming I: 3/8 solved (37%)\n\n"+
+		"#   TOPIC                PROGRESS              SOLVED  NEXT\n"+
+		"1   Getting Started      ████████████████████  2/2\n"+
+		"2   Branch on Condition  █████░░░░░░░░░░░░░░░  1/4     ITP1_2_B\n"+
+		"10  Math Functions       ░░░░░░░░░░░░░░░░░░░░  0/2     ITP1_10_A\n", out)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// RandomCommand represents the random command
+type RandomCommand struct {
+	randomUseCase *usecase.RandomUseCase
+	initUseCase   *usecase.InitUseCase
+	logger        *logger.Logger
+}
+
+// NewRandomCommand creates a new random command
+func NewRandomCommand(randomUseCase *usecase.RandomUseCase, initUseCase *usecase.InitUseCase) *RandomCommand {
+	return &RandomCommand{
+		randomUseCase: randomUseCase,
+		initUseCase:   initUseCase,
+		logger:        logger.WithGroup("random_command"),
+	}
+}
+
+// Command returns the cobra command for random
+func (c *RandomCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.RandomOptions
+		initOpts   usecase.InitOptions
+		yes        bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "random",
+		Short: "Pick a random problem to practice",
+		Long: `Pick a random problem matching the filters and offer to initialize it.
+
+--category matches the beginning of the problem ID, e.g. ITP, ITP1 or
+ALDS1_1. --unsolved skips the problems accepted in the local history; run
+'aoj history sync' first to include submissions made on the website.
+--min-difficulty and --max-difficulty need the difficulty table set in
+difficulty.url and skip problems it does not rate.
+
+Examples:
+  # Daily practice from the ITP course
+  aoj random --category ITP --unsolved
+
+  # An ICPC problem of moderate difficulty
+  aoj random --min-difficulty 200 --max-difficulty 400
+
+  # Initialize the pick without asking
+  aoj random --category ALDS1 --yes --lang python`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			problem, err := c.randomUseCase.Pick(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to pick a problem", "error", err)
+				return fmt.Errorf("failed to pick a problem: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(problem); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("%s %s\n", paint(colorGreen, problem.ProblemID), problem.Title)
+				fmt.Printf("Time limit: %s, memory limit: %s (picked from %d problems)\n",
+					formatSolutionTime(problem.TimeLimit), usecase.FormatSize(problem.MemoryLimit*1024), problem.Candidates)
+				if problem.Difficulty != nil {
+					fmt.Printf("Estimated difficulty: %s\n", usecase.FormatDifficulty(*problem.Difficulty))
+				}
+				fmt.Println(problem.URL)
+			}
+
+			if !yes && (jsonOutput || !confirm(fmt.Sprintf("Initialize %s now?", problem.ProblemID))) {
+				return nil
+			}
+			if err := c.initUseCase.ExecuteWithOptions(ctx, problem.ProblemID, initOpts); err != nil {
+				c.logger.ErrorContext(ctx, "failed to initialize problem", "problem_id", problem.ProblemID, "error", err)
+				return fmt.Errorf("failed to initialize problem %s: %w", problem.ProblemID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Initialized %s in %s\n", problem.ProblemID, c.initUseCase.ProblemDir(problem.ProblemID))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Category, "category", "", "Only pick problems whose ID starts with this, e.g. ITP or ALDS1")
+	cmd.Flags().StringVar(&opts.Title, "title", "", "Only pick problems whose title contains this")
+	cmd.Flags().BoolVar(&opts.Unsolved, "unsolved", false, "Skip problems accepted in the local history")
+	cmd.Flags().Float64Var(&opts.Difficulty.Min, "min-difficulty", 0, "Only pick problems at least this difficult")
+	cmd.Flags().Float64Var(&opts.Difficulty.Max, "max-difficulty", 0, "Only pick problems at most this difficult")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Initialize the picked problem without asking")
+	cmd.Flags().StringVarP(&initOpts.Language, "lang", "l", "", "Solution language when initializing (default: init.language from config)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the picked problem as JSON")
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// RankingCommand represents the ranking command
+type RankingCommand struct {
+	rankingUseCase *usecase.RankingUseCase
+	logger         *logger.Logger
+}
+
+// NewRankingCommand creates a new ranking command
+func NewRankingCommand(rankingUseCase *usecase.RankingUseCase) *RankingCommand {
+	return &RankingCommand{
+		rankingUseCase: rankingUseCase,
+		logger:         logger.WithGroup("ranking_command"),
+	}
+}
+
+// Command returns the cobra command for ranking
+func (c *RankingCommand) Command() *cobra.Command {
+	var (
+		opts       usecase.RankingOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ranking <problem-id>",
+		Short: "Show the fastest or shortest accepted solutions of a problem",
+		Long: `Fetch the accepted solutions of a problem from AOJ and show the best ones
+by execution time, memory usage or code length, with the median for
+comparison. Your best accepted submission in the local history is ranked
+among them, so you can gauge how optimized it is.
+
+Examples:
+  # Top 10 by execution time
+  aoj ranking ITP1_1_A
+
+  # Shortest C++ solutions
+  aoj ranking ITP1_1_A --by length --language C++`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			opts.ProblemID = args[0]
+
+			result, err := c.rankingUseCase.Rank(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "ranking failed", "error", err)
+				return fmt.Errorf("ranking failed: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+			printRanking(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.By, "by", usecase.RankByTime, "Order: time, memory or length")
+	cmd.Flags().StringVar(&opts.Language, "language", "", "Only rank solutions in this language, e.g. C++ or C++17")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "n", 10, "Number of solutions to show")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the ranking as JSON")
+
+	return cmd
+}
+
+// printRanking prints the top solutions, the median and the user's own rank
+func printRanking(result *usecase.RankingResult) {
+	fmt.Printf("%s: %d accepted solutions by %s\n\n", result.ProblemID, result.Total, result.By)
+
+	t := &table{header: []string{"RANK", "USER", "LANGUAGE", "TIME", "MEMORY", "LENGTH", "SUBMITTED"}}
+	for _, solution := range result.Solutions {
+		color := ""
+		if result.Own != nil && solution.JudgeID == result.Own.JudgeID {
+			color = colorGreen
+		}
+		t.addRow(
+			cell{text: strconv.Itoa(solution.Rank), color: color},
+			cell{text: solution.UserID, color: color},
+			cell{text: solution.Language},
+			cell{text: formatSolutionTime(solution.Time)},
+			cell{text: usecase.FormatSize(solution.Memory * 1024)},
+			cell{text: usecase.FormatSize(solution.CodeSize)},
+			cell{text: solution.SubmittedAt.Format("2006-01-02")},
+		)
+	}
+	fmt.Print(t.String())
+
+	median := result.Median
+	fmt.Printf("\nMedian: %s, %s, %s\n", formatSolutionTime(median.Time), usecase.FormatSize(median.Memory*1024), usecase.FormatSize(median.CodeSize))
+	if own := result.Own; own != nil {
+		top := min(float64(own.Rank)*100/float64(result.Total), 100)
+		fmt.Println(paint(colorGreen, fmt.Sprintf("Your best: #%d of %d (top %.0f%%) with %s, %s, %s",
+			own.Rank, result.Total, top, formatSolutionTime(own.Time), usecase.FormatSize(own.Memory*1024), usecase.FormatSize(own.CodeSize))))
+	}
+}
+
+// formatSolutionTime formats an execution time the way AOJ shows it, e.g. 0.01s
+func formatSolutionTime(d time.Duration) string {
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
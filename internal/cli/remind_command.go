@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// RemindCommand represents the remind command
+type RemindCommand struct {
+	reminderUseCase *usecase.ReminderUseCase
+	logger          *logger.Logger
+}
+
+// NewRemindCommand creates a new remind command
+func NewRemindCommand(reminderUseCase *usecase.ReminderUseCase) *RemindCommand {
+	return &RemindCommand{
+		reminderUseCase: reminderUseCase,
+		logger:          logger.WithGroup("remind_command"),
+	}
+}
+
+// Command returns the cobra command for remind
+func (c *RemindCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remind",
+		Short: "Get reminded to practice on days without an accepted submission",
+		Long: `Keep your streak alive: a daily job checks whether a problem was solved
+today and shows a desktop notification if not.
+
+The job is installed in the crontab, or as a launch agent on macOS, and runs
+aoj remind check --notify. Submissions made on other machines count once they
+are imported with aoj history sync.`,
+	}
+
+	cmd.AddCommand(c.installCommand(), c.uninstallCommand(), c.checkCommand())
+
+	return cmd
+}
+
+// installCommand returns the cobra command for remind install
+func (c *RemindCommand) installCommand() *cobra.Command {
+	var opts usecase.ReminderInstallOptions
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Schedule the daily practice reminder",
+		Long: `Schedule the daily practice reminder, replacing an installed one.
+
+Examples:
+  aoj remind install
+  aoj remind install --at 21:30`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			executable, err := os.Executable()
+			if err != nil {
+				return cerrors.Wrap(err, "failed to find the aoj executable")
+			}
+			if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+				executable = resolved
+			}
+			opts.Executable = executable
+
+			result, err := c.reminderUseCase.Install(ctx, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to install reminder", "error", err)
+				return err
+			}
+			fmt.Printf("%s Reminder scheduled every day at %s (%s)\n", paint(colorGreen, "✓"), result.At, result.Location)
+			fmt.Printf("  Runs: %s\n", strings.Join(result.Command, " "))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.At, "at", usecase.DefaultReminderTime, "Local time of day to check, as HH:MM")
+
+	return cmd
+}
+
+// uninstallCommand returns the cobra command for remind uninstall
+func (c *RemindCommand) uninstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the daily practice reminder",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			removed, err := c.reminderUseCase.Uninstall(ctx)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to uninstall reminder", "error", err)
+				return err
+			}
+			if !removed {
+				fmt.Println("No reminder is installed")
+				return nil
+			}
+			fmt.Printf("%s Reminder removed\n", paint(colorGreen, "✓"))
+			return nil
+		},
+	}
+}
+
+// checkCommand returns the cobra command for remind check
+func (c *RemindCommand) checkCommand() *cobra.Command {
+	var (
+		notify     bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Print a reminder unless a problem was solved today",
+		Long: `Print a reminder unless a problem was solved today. This is what the
+installed job runs.
+
+Examples:
+  aoj remind check
+  aoj remind check --notify`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			result, err := c.reminderUseCase.Check(ctx, notify)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to check today's practice", "error", err)
+				return err
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+			if result.Message == "" {
+				fmt.Printf("%s Solved today; streak: %s\n", paint(colorGreen, "✓"), formatDays(result.Streak.Current))
+				return nil
+			}
+			fmt.Println(paint(colorYellow, result.Message))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&notify, "notify", false, "Also show the reminder as a desktop notification")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ResubmitCommand represents the resubmit command
+type ResubmitCommand struct {
+	submitUseCase *usecase.SubmitUseCase
+	logger        *logger.Logger
+}
+
+// NewResubmitCommand creates a new resubmit command
+func NewResubmitCommand(submitUseCase *usecase.SubmitUseCase) *ResubmitCommand {
+	return &ResubmitCommand{
+		submitUseCase: submitUseCase,
+		logger:        logger.WithGroup("resubmit_command"),
+	}
+}
+
+// Command returns the cobra command for resubmit
+func (c *ResubmitCommand) Command() *cobra.Command {
+	var problemID string
+
+	cmd := &cobra.Command{
+		Use:   "resubmit [submission-id]",
+		Short: "Resubmit a previous solution to AOJ",
+		Long: `Resubmit the source code of a previous submission from the local history.
+
+Without arguments, the most recent submission for the current problem is
+resubmitted. This is useful after transient judge errors.
+
+Examples:
+  # Resubmit the latest submission for the problem in the current directory
+  aoj resubmit
+
+  # Resubmit the latest submission for a specific problem
+  aoj resubmit --problem-id ITP1_1_A
+
+  # Resubmit a specific submission
+  aoj resubmit 1718000000000000000`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			submissionID := ""
+			if len(args) == 1 {
+				submissionID = args[0]
+			}
+			return c.run(cmd, problemID, submissionID)
+		},
+	}
+
+	cmd.Flags().StringVarP(&problemID, "problem-id", "p", "", "Problem ID or alias (default: inferred from problem.toml or the directory names)")
+
+	return cmd
+}
+
+// run executes the resubmit command
+func (c *ResubmitCommand) run(cmd *cobra.Command, problemID, submissionID string) error {
+	ctx := cmd.Context()
+
+	c.logger.InfoContext(ctx, "executing resubmit command",
+		"problem_id", problemID,
+		"submission_id", submissionID)
+
+	submission, err := c.submitUseCase.Resubmit(ctx, usecase.ResubmitOptions{
+		ProblemID:    problemID,
+		SubmissionID: submissionID,
+	})
+	if submission != nil {
+		printSubmissionResult(submission)
+	}
+	if err != nil {
+		c.logger.ErrorContext(ctx, "resubmission failed", "error", err)
+		return fmt.Errorf("resubmission failed: %w", err)
+	}
+
+	return nil
+}
@@ -2,22 +2,50 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/crash"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
 )
 
+// exitCodeInterrupted is the conventional exit status after SIGINT
+const exitCodeInterrupted = 130
+
 // RootCommand represents the root command
 type RootCommand struct {
-	logger *logger.Logger
+	settings RootSettings
+	logger   *logger.Logger
+}
+
+// RootSettings configures what the root command does around every command
+type RootSettings struct {
+	CrashDir       string                   // where crash reports are written
+	Stats          *usecase.StatsUseCase    // Optional: records the run of every command
+	SlowThresholds map[string]time.Duration // Optional: operations reported as slow after these durations
+	ConfigPath     string                   // Optional: config file whose absence points commands to 'aoj setup'
+	LogRotation    logger.FileConfig        // Optional: rotation limits of --log-file; Path comes from the flag
+}
+
+// NewRootCommand creates a new root command that writes crash reports into crashDir
+func NewRootCommand(crashDir string) *RootCommand {
+	return NewRootCommandWithSettings(RootSettings{CrashDir: crashDir})
 }
 
-// NewRootCommand creates a new root command
-func NewRootCommand() *RootCommand {
+// NewRootCommandWithSettings creates a new root command with the given settings
+func NewRootCommandWithSettings(settings RootSettings) *RootCommand {
 	return &RootCommand{
-		logger: logger.WithGroup("root_command"),
+		settings: settings,
+		logger:   logger.WithGroup("root_command"),
 	}
 }
 
@@ -38,7 +66,49 @@ Features:
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			// Setup context for the command
-			ctx := context.Background()
+			ctx := cmd.Context()
+
+			offlineMode, err := cmd.Flags().GetBool("offline")
+			if err != nil {
+				return err
+			}
+			ctx = offline.WithOffline(ctx, offlineMode)
+
+			traceMode, err := cmd.Flags().GetBool("trace")
+			if err != nil {
+				return err
+			}
+			if traceMode {
+				trace.Enable(os.Stderr)
+			}
+
+			noColor, err := cmd.Flags().GetBool("no-color")
+			if err != nil {
+				return err
+			}
+			if noColor {
+				setColorMode(colorNever)
+			}
+
+			// Correlate all log records of this command run
+			ctx = logger.WithRequestID(ctx, logger.NewRequestID())
+			ctx = logger.WithSlowOperations(ctx, logger.SlowOperations{
+				Thresholds: c.settings.SlowThresholds,
+				Report:     printSlowOperation,
+			})
+
+			logFile, err := cmd.Flags().GetString("log-file")
+			if err != nil {
+				return err
+			}
+			if logFile != "" {
+				fileConfig := c.settings.LogRotation
+				fileConfig.Path = config.ExpandHome(logFile)
+				if err := logger.AttachFile(fileConfig); err != nil {
+					return cerrors.Wrap(err, "failed to open log file")
+				}
+			}
+
 			cmd.SetContext(ctx)
 			return nil
 		},
@@ -47,24 +117,78 @@ Features:
 	// Add global flags
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
 	cmd.PersistentFlags().BoolP("quiet", "q", false, "quiet output")
+	cmd.PersistentFlags().Bool("offline", false, "use locally cached data only and never access the network")
+	cmd.PersistentFlags().Bool("trace", false, "print every AOJ request (secrets masked) and file change before it happens")
+	cmd.PersistentFlags().Bool("no-color", false, "disable colored output (also disabled by the NO_COLOR environment variable)")
+	cmd.PersistentFlags().String("log-file", "", "also write debug logs to this file (rotated as set in [log] of config.toml)")
+	// Read by main through ConfigDirFromArgs, ProfileFromArgs, NoPersistSessionFromArgs and FakeTimeFromArgs; declared so that cobra accepts them
+	cmd.PersistentFlags().String(configDirFlag, "", "read the configuration from this directory (default: $AOJ_CONFIG_DIR or ~/.aoj-cli)")
+	cmd.PersistentFlags().String(profileFlag, "", "apply this profile of config.toml (default: $AOJ_PROFILE)")
+	cmd.PersistentFlags().Bool(noPersistSessionFlag, false, "keep the login session in memory only and never write it to disk")
+	cmd.PersistentFlags().String(fakeTimeFlag, "", "freeze the clock at this RFC 3339 time (for tests)")
+	_ = cmd.PersistentFlags().MarkHidden(fakeTimeFlag)
 
 	return cmd
 }
 
-// AddSubcommands adds all subcommands to the root command
+// AddSubcommands adds all subcommands to the root command, wrapping them and
+// their subcommands with the middlewares every command uses
 func (c *RootCommand) AddSubcommands(cmd *cobra.Command, commands ...*cobra.Command) {
+	for _, command := range commands {
+		middlewares := []Middleware{RecordUsage(c.settings.Stats), HandleSignals()}
+		if c.settings.ConfigPath != "" && command.Name() != "setup" {
+			middlewares = append([]Middleware{SuggestSetup(c.settings.ConfigPath)}, middlewares...)
+		}
+		useAll(command, middlewares...)
+	}
 	cmd.AddCommand(commands...)
 }
 
-// Execute executes the root command
-func (c *RootCommand) Execute(cmd *cobra.Command) error {
-	return cmd.Execute()
+// Execute executes the root command, turning panics into errors
+func (c *RootCommand) Execute(cmd *cobra.Command) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = crash.PanicError(recovered)
+		}
+	}()
+
+	enableANSI()
+
+	return cmd.ExecuteContext(context.Background())
 }
 
-// HandleError handles command execution errors
+// HandleError presents command execution errors and exits with the status
+// of their error code
 func (c *RootCommand) HandleError(err error) {
 	if err != nil {
-		c.logger.Error("command execution failed", "error", err)
-		os.Exit(1)
+		c.logger.Debug("command execution failed", "error", err)
+		if cerrors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "Interrupted")
+			os.Exit(exitCodeInterrupted)
+		}
+		NewErrorPresenter(os.Stderr).Present(err)
+		if crash.IsUnexpected(err) {
+			c.writeCrashReport(err)
+		}
+		os.Exit(cerrors.ExitCode(err))
+	}
+}
+
+// writeCrashReport writes a local crash report and tells the user where it is
+func (c *RootCommand) writeCrashReport(err error) {
+	path, writeErr := crash.WriteReport(c.settings.CrashDir, err, os.Args, time.Now())
+	if writeErr != nil {
+		c.logger.Warn("failed to write crash report", "error", writeErr)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "A crash report was written to %s\nPlease attach it when reporting this problem.\n", path)
+}
+
+// printSlowOperation warns that an operation took longer than its threshold
+func printSlowOperation(op logger.SlowOperation) {
+	fmt.Fprintf(os.Stderr, "%s %s took %s (over %s)\n", paintFor(os.Stderr, colorYellow, "warning:"),
+		strings.ReplaceAll(op.Name, "_", " "), op.Elapsed.Round(100*time.Millisecond), op.Threshold)
+	if op.Hint != "" {
+		fmt.Fprintf(os.Stderr, "%s %s\n", paintFor(os.Stderr, colorYellow, "hint:"), op.Hint)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// RunCommand represents the run command
+type RunCommand struct {
+	testUseCase *usecase.TestUseCase
+	logger      *logger.Logger
+}
+
+// NewRunCommand creates a new run command
+func NewRunCommand(testUseCase *usecase.TestUseCase) *RunCommand {
+	return &RunCommand{
+		testUseCase: testUseCase,
+		logger:      logger.WithGroup("run_command"),
+	}
+}
+
+// Command returns the cobra command for run
+func (c *RunCommand) Command() *cobra.Command {
+	var opts usecase.RunOptions
+
+	cmd := &cobra.Command{
+		Use:   "run [file]",
+		Short: "Build and run a solution with your own input",
+		Long: `Build the solution if it changed since the last build and run it once,
+with its standard input, output and error connected to the terminal, so
+you do not have to remember the run command of each language.
+
+Compiler output goes to stderr. The command fails if the solution exits
+with a non-zero status.
+
+Examples:
+  # Type the input yourself
+  aoj run
+
+  # Read the input from a file
+  aoj run < my.txt
+  aoj run main.py --input my.txt`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.SourceFile = args[0]
+			}
+			return c.run(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.InputFile, "input", "i", "", "Read standard input from this file")
+	cmd.Flags().StringVar(&opts.Sanitize, "sanitize", "", "Build C/C++ solutions with these sanitizers, e.g. address,undefined")
+
+	return cmd
+}
+
+// run executes the run command
+func (c *RunCommand) run(cmd *cobra.Command, opts usecase.RunOptions) error {
+	ctx := cmd.Context()
+
+	opts.Stdin = os.Stdin
+	opts.Stdout = os.Stdout
+	opts.Stderr = os.Stderr
+
+	report, err := c.testUseCase.Run(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "run failed", "error", err)
+		return fmt.Errorf("run failed: %w", err)
+	}
+	c.logger.DebugContext(ctx, "solution finished",
+		"exit_code", report.ExitCode,
+		"duration", report.Duration,
+		"memory_kb", report.MemoryKB)
+	if report.ExitCode != 0 {
+		return fmt.Errorf("solution exited with status %d", report.ExitCode)
+	}
+	return nil
+}
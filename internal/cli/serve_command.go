@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// defaultServeAddr is the address aoj serve listens on by default
+const defaultServeAddr = "127.0.0.1:7878"
+
+// serveShutdownTimeout is how long running requests may take to finish after an interrupt
+const serveShutdownTimeout = 5 * time.Second
+
+// ServeCommand represents the serve command
+type ServeCommand struct {
+	initUseCase    *usecase.InitUseCase
+	testUseCase    *usecase.TestUseCase
+	submitUseCase  *usecase.SubmitUseCase
+	sessionUseCase *usecase.SessionUseCase
+	logger         *logger.Logger
+}
+
+// NewServeCommand creates a new serve command
+func NewServeCommand(
+	initUseCase *usecase.InitUseCase,
+	testUseCase *usecase.TestUseCase,
+	submitUseCase *usecase.SubmitUseCase,
+	sessionUseCase *usecase.SessionUseCase,
+) *ServeCommand {
+	return &ServeCommand{
+		initUseCase:    initUseCase,
+		testUseCase:    testUseCase,
+		submitUseCase:  submitUseCase,
+		sessionUseCase: sessionUseCase,
+		logger:         logger.WithGroup("serve_command"),
+	}
+}
+
+// Command returns the cobra command for serve
+func (c *ServeCommand) Command() *cobra.Command {
+	var (
+		addr           string
+		allowedOrigins []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve init, test and submit to editor plugins",
+		Long: `Run a local JSON-RPC 2.0 server so that editor plugins can drive the CLI
+without starting a process for every action.
+
+Requests are POSTed to /rpc with Content-Type application/json. The methods
+are init, test, submit and status; their params mirror the flags of the
+commands. Progress is streamed as server-sent events from /events:
+test/case after every test case and submit/status, submit/queue and
+submit/warning while a submission is judged. Every event carries the ID of
+the request it belongs to as request_id.
+
+The server only listens on loopback addresses and rejects requests from web
+pages unless their origin is allowed with --allow-origin. The first line
+printed is the address, so that plugins can pass --addr 127.0.0.1:0 and
+read the port.
+
+Examples:
+  aoj serve
+  aoj serve --addr 127.0.0.1:0
+
+  curl -s localhost:7878/rpc -H 'Content-Type: application/json' \
+    -d '{"jsonrpc":"2.0","id":1,"method":"test","params":{"dir":"ITP1_1_A"}}'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd.Context(), addr, allowedOrigins)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", defaultServeAddr, "Loopback address to listen on; port 0 picks a free port")
+	cmd.Flags().StringSliceVar(&allowedOrigins, "allow-origin", nil, "Origin of web pages allowed to send requests, e.g. vscode-webview://abc")
+
+	return cmd
+}
+
+// run serves until the context is cancelled
+func (c *ServeCommand) run(ctx context.Context, addr string, allowedOrigins []string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return cerrors.NewAppError(cerrors.CodeInvalidInput, fmt.Sprintf("invalid address %q", addr), err)
+	}
+	if !isLoopbackHost(host) {
+		return cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("aoj serve only listens on loopback addresses such as 127.0.0.1, not %s", host),
+			nil,
+		)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to listen on "+addr)
+	}
+
+	editorServer := NewEditorServer(c.initUseCase, c.testUseCase, c.submitUseCase, c.sessionUseCase, allowedOrigins)
+	server := &http.Server{
+		Handler:           editorServer.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	fmt.Printf("Listening on http://%s\n", listener.Addr())
+	c.logger.InfoContext(ctx, "serving editor requests", "addr", listener.Addr().String())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return cerrors.Wrap(err, "server stopped")
+	case <-ctx.Done():
+	}
+
+	// Running requests share the cancelled context; wait for them to stop
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !cerrors.Is(err, context.DeadlineExceeded) {
+		return cerrors.Wrap(err, "failed to stop the server")
+	}
+	fmt.Println("Stopped")
+	return nil
+}
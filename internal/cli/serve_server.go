@@ -0,0 +1,636 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
+)
+
+// jsonRPCVersion is the JSON-RPC version spoken by aoj serve
+const jsonRPCVersion = "2.0"
+
+// Error codes of JSON-RPC 2.0; rpcAppError is used for errors of the operations themselves
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcAppError       = -32000
+)
+
+// maxRPCRequestSize is the largest request body accepted, enough for a source of MaxSourceSize
+const maxRPCRequestSize = 1 << 20
+
+// eventBuffer is how many events a slow event stream may fall behind before events are dropped for it
+const eventBuffer = 256
+
+// rpcRequest is a JSON-RPC request; requests without an ID are notifications
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is the JSON-RPC response to a request
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed request
+type rpcError struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    *rpcErrorData `json:"data,omitempty"`
+}
+
+// rpcErrorData describes an error of an operation like the CLI presents it
+type rpcErrorData struct {
+	Code     string   `json:"code,omitempty"`
+	Hints    []string `json:"hints,omitempty"`
+	Docs     string   `json:"docs,omitempty"`
+	ExitCode int      `json:"exit_code"`
+}
+
+// rpcNotification is an event sent to the clients of /events
+type rpcNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// EditorServer serves init, test, submit and status to editor plugins as
+// JSON-RPC 2.0 over HTTP. Requests are POSTed to /rpc; progress such as the
+// result of each test case and verdict changes is streamed to every client of
+// /events as server-sent events carrying JSON-RPC notifications
+type EditorServer struct {
+	initUseCase    *usecase.InitUseCase
+	testUseCase    *usecase.TestUseCase
+	submitUseCase  *usecase.SubmitUseCase
+	sessionUseCase *usecase.SessionUseCase
+	allowedOrigins []string
+	events         *eventHub
+	locks          *dirLocks
+	now            func() time.Time
+
+	mu        sync.Mutex
+	running   map[int]runningOperation
+	operation int
+	logger    *logger.Logger
+}
+
+// runningOperation is a request being served, reported by status
+type runningOperation struct {
+	ID        json.RawMessage `json:"id"`
+	Method    string          `json:"method"`
+	Dir       string          `json:"dir,omitempty"`
+	StartedAt time.Time       `json:"started_at"`
+}
+
+// NewEditorServer creates an editor server; browsers are only let in from allowedOrigins
+func NewEditorServer(
+	initUseCase *usecase.InitUseCase,
+	testUseCase *usecase.TestUseCase,
+	submitUseCase *usecase.SubmitUseCase,
+	sessionUseCase *usecase.SessionUseCase,
+	allowedOrigins []string,
+) *EditorServer {
+	return &EditorServer{
+		initUseCase:    initUseCase,
+		testUseCase:    testUseCase,
+		submitUseCase:  submitUseCase,
+		sessionUseCase: sessionUseCase,
+		allowedOrigins: allowedOrigins,
+		events:         newEventHub(),
+		locks:          newDirLocks(),
+		now:            time.Now,
+		running:        make(map[int]runningOperation),
+		logger:         logger.WithGroup("editor_server"),
+	}
+}
+
+// Handler returns the HTTP handler of the server
+func (s *EditorServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rpc", s.handleRPC)
+	mux.HandleFunc("GET /events", s.handleEvents)
+	return s.guard(mux)
+}
+
+// guard rejects requests that a web page could make: requests for another
+// host name, as with DNS rebinding, and requests from other origins
+func (s *EditorServer) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			if !slices.Contains(s.allowedOrigins, origin) {
+				http.Error(w, "forbidden origin", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether the Host header names this machine
+func isLoopbackHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// handleRPC serves one JSON-RPC request
+func (s *EditorServer) handleRPC(w http.ResponseWriter, r *http.Request) {
+	if mediaType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";"); strings.TrimSpace(mediaType) != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRPCRequestSize))
+	if err != nil {
+		writeRPCResponse(w, rpcResponse{Error: &rpcError{Code: rpcInvalidRequest, Message: "request too large"}})
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeRPCResponse(w, rpcResponse{Error: &rpcError{Code: rpcParseError, Message: "parse error: " + err.Error()}})
+		return
+	}
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		writeRPCResponse(w, rpcResponse{ID: req.ID, Error: &rpcError{Code: rpcInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}})
+		return
+	}
+
+	result, rpcErr := s.call(r.Context(), req)
+	if req.ID == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeRPCResponse(w, rpcResponse{ID: req.ID, Result: result, Error: rpcErr})
+}
+
+// writeRPCResponse writes a JSON-RPC response; JSON-RPC errors are sent with status 200
+func writeRPCResponse(w http.ResponseWriter, resp rpcResponse) {
+	resp.JSONRPC = jsonRPCVersion
+	if resp.ID == nil {
+		resp.ID = json.RawMessage("null")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// call dispatches a request to its method
+func (s *EditorServer) call(ctx context.Context, req rpcRequest) (any, *rpcError) {
+	s.logger.InfoContext(ctx, "serving request", "method", req.Method, "id", string(req.ID))
+
+	switch req.Method {
+	case "init":
+		var params initParams
+		if rpcErr := decodeParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return s.initProblem(ctx, req.ID, params)
+	case "test":
+		var params testParams
+		if rpcErr := decodeParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return s.test(ctx, req.ID, params)
+	case "submit":
+		var params submitParams
+		if rpcErr := decodeParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return s.submit(ctx, req.ID, params)
+	case "status":
+		var params statusParams
+		if rpcErr := decodeParams(req.Params, &params); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return s.status(ctx, params)
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+// decodeParams decodes the params of a request, which may be omitted
+func decodeParams(raw json.RawMessage, params any) *rpcError {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(params); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// appError converts the error of an operation to a JSON-RPC error with the
+// hints and exit code the CLI would show
+func appError(err error) *rpcError {
+	return &rpcError{
+		Code:    rpcAppError,
+		Message: redact.Text(err.Error()),
+		Data: &rpcErrorData{
+			Code:     string(cerrors.GetErrorCode(err)),
+			Hints:    Suggestions(err),
+			Docs:     cerrors.Docs(err),
+			ExitCode: cerrors.ExitCode(err),
+		},
+	}
+}
+
+// initParams are the params of init
+type initParams struct {
+	ProblemID string `json:"problem_id"`
+	Language  string `json:"language"`
+	Template  string `json:"template"`
+}
+
+// initResult is the result of init
+type initResult struct {
+	ProblemID string `json:"problem_id"`
+	Dir       string `json:"dir"`
+}
+
+// initProblem creates the directory of a problem like aoj init
+func (s *EditorServer) initProblem(ctx context.Context, id json.RawMessage, params initParams) (any, *rpcError) {
+	if params.ProblemID == "" {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: "invalid params: problem_id is required"}
+	}
+	dir := s.initUseCase.ProblemDir(params.ProblemID)
+	release, rpcErr := s.begin(ctx, id, "init", dir)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	defer release()
+
+	opts := usecase.InitOptions{Language: params.Language, Template: params.Template}
+	if err := s.initUseCase.ExecuteWithOptions(ctx, params.ProblemID, opts); err != nil {
+		return nil, appError(err)
+	}
+	return initResult{ProblemID: params.ProblemID, Dir: dir}, nil
+}
+
+// testParams are the params of test
+type testParams struct {
+	Dir        string `json:"dir"`
+	SourceFile string `json:"source_file"`
+	Case       int    `json:"case"`
+	TimeoutMS  int64  `json:"timeout_ms"`
+	Aggregate  bool   `json:"aggregate"`
+}
+
+// test runs the samples of a problem like aoj test, sending a test/case
+// event for every finished case
+func (s *EditorServer) test(ctx context.Context, id json.RawMessage, params testParams) (any, *rpcError) {
+	release, rpcErr := s.begin(ctx, id, "test", params.Dir)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	defer release()
+
+	report, err := s.testUseCase.Execute(ctx, usecase.TestOptions{
+		Dir:        params.Dir,
+		SourceFile: params.SourceFile,
+		Case:       params.Case,
+		Timeout:    time.Duration(params.TimeoutMS) * time.Millisecond,
+		Aggregate:  params.Aggregate,
+		OnCase: func(result usecase.CaseResult) {
+			s.publish("test/case", id, "case", result)
+		},
+	})
+	if err != nil {
+		return nil, appError(err)
+	}
+	return report, nil
+}
+
+// submitParams are the params of submit; Source, e.g. an unsaved editor
+// buffer, is submitted instead of File when it is set
+type submitParams struct {
+	Dir         string `json:"dir"`
+	ProblemID   string `json:"problem_id"`
+	File        string `json:"file"`
+	Source      string `json:"source"`
+	Language    string `json:"language"`
+	Watch       *bool  `json:"watch"`
+	NoGit       bool   `json:"no_git"`
+	NoTransform bool   `json:"no_transform"`
+	TimeoutMS   int64  `json:"timeout_ms"`
+}
+
+// submissionResult is a submission as returned by submit
+type submissionResult struct {
+	ID           string  `json:"id"`
+	JudgeID      string  `json:"judge_id,omitempty"`
+	ProblemID    string  `json:"problem_id"`
+	Language     string  `json:"language"`
+	Status       string  `json:"status"`
+	Accepted     bool    `json:"accepted"`
+	Score        int     `json:"score,omitempty"`
+	TimeMS       float64 `json:"time_ms,omitempty"`
+	MemoryKB     int64   `json:"memory_kb,omitempty"`
+	Message      string  `json:"message,omitempty"`
+	CompileError string  `json:"compile_error,omitempty"`
+	FailedCase   int     `json:"failed_case,omitempty"`
+}
+
+// newSubmissionResult converts a submission to its JSON form
+func newSubmissionResult(submission *entity.Submission) submissionResult {
+	return submissionResult{
+		ID:           submission.ID().String(),
+		JudgeID:      submission.JudgeID(),
+		ProblemID:    submission.ProblemID().String(),
+		Language:     submission.Language(),
+		Status:       string(submission.Status()),
+		Accepted:     submission.IsAccepted(),
+		Score:        submission.Score(),
+		TimeMS:       float64(submission.Time()) / float64(time.Millisecond),
+		MemoryKB:     submission.Memory(),
+		Message:      submission.Message(),
+		CompileError: submission.CompileError(),
+		FailedCase:   submission.FailedCase(),
+	}
+}
+
+// submit submits a solution like aoj submit, sending submit/status and
+// submit/queue events while waiting for the verdict and submit/warning events
+// for problems of the source
+func (s *EditorServer) submit(ctx context.Context, id json.RawMessage, params submitParams) (any, *rpcError) {
+	release, rpcErr := s.begin(ctx, id, "submit", params.Dir)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	defer release()
+
+	opts := usecase.SubmitOptions{
+		Dir:         params.Dir,
+		ProblemID:   params.ProblemID,
+		FilePath:    params.File,
+		Language:    params.Language,
+		NoGit:       params.NoGit,
+		NoTransform: params.NoTransform,
+		Timeout:     time.Duration(params.TimeoutMS) * time.Millisecond,
+		OnStatus: func(status entity.SubmissionStatus) {
+			s.publish("submit/status", id, "status", status)
+		},
+		OnQueue: func(queue repository.JudgeQueue) {
+			s.publish("submit/queue", id, "queue", queue)
+		},
+		OnWarning: func(message string) {
+			s.publish("submit/warning", id, "message", message)
+		},
+	}
+	if params.Source != "" {
+		opts.FilePath = usecase.StdinSource
+		opts.Stdin = strings.NewReader(params.Source)
+	}
+	if params.Watch != nil {
+		opts.Watch = *params.Watch
+		opts.NoWatch = !*params.Watch
+	}
+
+	submission, err := s.submitUseCase.Execute(ctx, opts)
+	if err != nil {
+		return nil, appError(err)
+	}
+	return newSubmissionResult(submission), nil
+}
+
+// defaultStatusLimit is how many submissions status returns by default
+const defaultStatusLimit = 5
+
+// statusParams are the params of status; the submissions of a problem are
+// only looked up when Dir or ProblemID is set
+type statusParams struct {
+	Dir       string `json:"dir"`
+	ProblemID string `json:"problem_id"`
+	Limit     int    `json:"limit"`
+}
+
+// statusResult is the result of status
+type statusResult struct {
+	LoggedIn    bool               `json:"logged_in"`
+	Username    string             `json:"username,omitempty"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty"`
+	Running     []runningOperation `json:"running"`
+	ProblemID   string             `json:"problem_id,omitempty"`
+	Submissions []submissionResult `json:"submissions,omitempty"`
+}
+
+// status reports the session, the requests being served and, if asked for,
+// the latest submissions of a problem from the local history
+func (s *EditorServer) status(ctx context.Context, params statusParams) (any, *rpcError) {
+	result := statusResult{Running: []runningOperation{}}
+	if session, err := s.sessionUseCase.RequireActive(ctx); err == nil {
+		expiresAt := session.ExpiresAt()
+		result.LoggedIn = true
+		result.Username = session.Username()
+		result.ExpiresAt = &expiresAt
+	}
+
+	s.mu.Lock()
+	for _, operation := range s.running {
+		result.Running = append(result.Running, operation)
+	}
+	s.mu.Unlock()
+	sort.Slice(result.Running, func(i, j int) bool {
+		return result.Running[i].StartedAt.Before(result.Running[j].StartedAt)
+	})
+
+	if params.Dir == "" && params.ProblemID == "" {
+		return result, nil
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultStatusLimit
+	}
+	problemID, submissions, err := s.submitUseCase.LatestSubmissions(ctx, params.Dir, params.ProblemID, limit)
+	if err != nil {
+		return nil, appError(err)
+	}
+	result.ProblemID = problemID.String()
+	result.Submissions = make([]submissionResult, 0, len(submissions))
+	for _, submission := range submissions {
+		result.Submissions = append(result.Submissions, newSubmissionResult(submission))
+	}
+	return result, nil
+}
+
+// begin waits until no other request works in dir and records the request
+// for status. The returned function ends the request
+func (s *EditorServer) begin(ctx context.Context, id json.RawMessage, method, dir string) (func(), *rpcError) {
+	unlock, err := s.locks.lock(ctx, dir)
+	if err != nil {
+		return nil, appError(err)
+	}
+
+	s.mu.Lock()
+	s.operation++
+	key := s.operation
+	s.running[key] = runningOperation{ID: id, Method: method, Dir: dir, StartedAt: s.now()}
+	s.mu.Unlock()
+	return func() {
+		s.mu.Lock()
+		delete(s.running, key)
+		s.mu.Unlock()
+		unlock()
+	}, nil
+}
+
+// publish sends the event of the request with the given ID to the event streams
+func (s *EditorServer) publish(method string, id json.RawMessage, key string, value any) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	data, err := json.Marshal(rpcNotification{
+		JSONRPC: jsonRPCVersion,
+		Method:  method,
+		Params:  map[string]any{"request_id": id, key: value},
+	})
+	if err != nil {
+		s.logger.Warn("failed to encode event", "method", method, "error", err)
+		return
+	}
+	s.events.publish(method, data)
+}
+
+// handleEvents streams the events to the client as server-sent events until it disconnects
+func (s *EditorServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	// A comment tells the client that the stream is open
+	_, _ = io.WriteString(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, event.data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// serverEvent is one event of the event streams
+type serverEvent struct {
+	name string
+	data []byte
+}
+
+// eventHub broadcasts events to the subscribed event streams
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan serverEvent]struct{}
+}
+
+// newEventHub creates an event hub without subscribers
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan serverEvent]struct{})}
+}
+
+// subscribe returns a channel receiving all further events and the function that ends the subscription
+func (h *eventHub) subscribe() (<-chan serverEvent, func()) {
+	events := make(chan serverEvent, eventBuffer)
+	h.mu.Lock()
+	h.subscribers[events] = struct{}{}
+	h.mu.Unlock()
+	return events, func() {
+		h.mu.Lock()
+		delete(h.subscribers, events)
+		h.mu.Unlock()
+	}
+}
+
+// publish sends an event to every subscriber; subscribers too slow to keep up miss it
+func (h *eventHub) publish(name string, data []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for events := range h.subscribers {
+		select {
+		case events <- serverEvent{name: name, data: data}:
+		default:
+		}
+	}
+}
+
+// dirLocks serializes the requests working in the same directory, so that
+// e.g. two tests do not build into the same executable
+type dirLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// newDirLocks creates dirLocks with no directory locked
+func newDirLocks() *dirLocks {
+	return &dirLocks{locks: make(map[string]chan struct{})}
+}
+
+// lock waits until dir is free or ctx is done and returns the function that frees it
+func (l *dirLocks) lock(ctx context.Context, dir string) (func(), error) {
+	key, err := filepath.Abs(dir)
+	if err != nil {
+		key = filepath.Clean(dir)
+	}
+
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.locks[key] = lock
+	}
+	l.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// echoRunner is a solution that prints its input
+type echoRunner struct{}
+
+func (echoRunner) Build(_ context.Context, _ service.RunSpec) (*service.BuildResult, error) {
+	return &service.BuildResult{Success: true}, nil
+}
+
+func (echoRunner) Run(_ context.Context, _ service.RunSpec, input string, _ time.Duration) (*service.RunResult, error) {
+	return &service.RunResult{Stdout: input}, nil
+}
+
+func (echoRunner) RunInteractive(_ context.Context, _ service.RunSpec, _ service.InteractorSpec, _ time.Duration) (*service.InteractiveResult, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (echoRunner) RunAttached(_ context.Context, _ service.RunSpec, _ io.Reader, _, _ io.Writer) (*service.RunResult, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func newTestEditorServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	testUseCase := usecase.NewTestUseCase(echoRunner{}, usecase.TestSettings{
+		SourceFile: "main.py",
+		Languages:  []usecase.LanguageCommand{{Extension: "py", RunCommand: "python3 {file}"}},
+	})
+	sessions := usecase.NewSessionUseCase(repository.NewMemorySessionRepository(nil), clock.System())
+	server := httptest.NewServer(NewEditorServer(nil, testUseCase, nil, sessions, []string{"vscode-webview://plugin"}).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func postRPC(t *testing.T, url, body string) map[string]any {
+	t.Helper()
+	resp, err := http.Post(url+"/rpc", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var decoded map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return decoded
+}
+
+func TestEditorServer_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode float64
+	}{
+		{name: "parse error", body: `{"jsonrpc":`, wantCode: rpcParseError},
+		{name: "not JSON-RPC 2.0", body: `{"id":1,"method":"status"}`, wantCode: rpcInvalidRequest},
+		{name: "unknown method", body: `{"jsonrpc":"2.0","id":1,"method":"explode"}`, wantCode: rpcMethodNotFound},
+		{name: "unknown param", body: `{"jsonrpc":"2.0","id":1,"method":"test","params":{"file":"a.py"}}`, wantCode: rpcInvalidParams},
+		{name: "failed operation", body: `{"jsonrpc":"2.0","id":1,"method":"test","params":{"dir":"missing"}}`, wantCode: rpcAppError},
+	}
+
+	server := newTestEditorServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			resp := postRPC(t, server.URL, tt.body)
+
+			// Then
+			rpcErr, ok := resp["error"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected an error, got %v", resp)
+			}
+			assert.Equal(t, tt.wantCode, rpcErr["code"])
+			assert.Equal(t, "2.0", resp["jsonrpc"])
+		})
+	}
+}
+
+func TestEditorServer_Guard(t *testing.T) {
+	tests := []struct {
+		name        string
+		host        string
+		origin      string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "editor plugin", contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "allowed origin", origin: "vscode-webview://plugin", contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "web page", origin: "https://example.com", contentType: "application/json", wantStatus: http.StatusForbidden},
+		{name: "DNS rebinding", host: "attacker.example:7878", contentType: "application/json", wantStatus: http.StatusForbidden},
+		{name: "form post", contentType: "text/plain", wantStatus: http.StatusUnsupportedMediaType},
+	}
+
+	server := newTestEditorServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/rpc", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"status"}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+
+			// When
+			resp, err := http.DefaultClient.Do(req)
+
+			// Then
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestEditorServer_Status(t *testing.T) {
+	// Given
+	server := newTestEditorServer(t)
+
+	// When
+	resp := postRPC(t, server.URL, `{"jsonrpc":"2.0","id":"s","method":"status"}`)
+
+	// Then
+	assert.Equal(t, "s", resp["id"])
+	assert.Equal(t, map[string]any{"logged_in": false, "running": []any{}}, resp["result"])
+}
+
+func TestEditorServer_TestStreamsCases(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	testDir := filepath.Join(dir, "test")
+	require.NoError(t, os.MkdirAll(testDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.py"), []byte("print(input())\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(testDir, "sample-1.in"), []byte("1\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(testDir, "sample-1.out"), []byte("1\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(testDir, "sample-2.in"), []byte("2\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(testDir, "sample-2.out"), []byte("3\n"), 0644))
+	server := newTestEditorServer(t)
+
+	events, err := http.Get(server.URL + "/events")
+	require.NoError(t, err)
+	defer func() { _ = events.Body.Close() }()
+	require.Equal(t, "text/event-stream", events.Header.Get("Content-Type"))
+	stream := bufio.NewReader(events.Body)
+	_, err = stream.ReadString('\n') // ": connected"
+	require.NoError(t, err)
+
+	// When
+	params, err := json.Marshal(map[string]any{"dir": dir})
+	require.NoError(t, err)
+	resp := postRPC(t, server.URL, `{"jsonrpc":"2.0","id":7,"method":"test","params":`+string(params)+`}`)
+
+	// Then
+	result, ok := resp["result"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a result, got %v", resp)
+	}
+	assert.Equal(t, usecase.VerdictWrongAnswer, result["verdict"])
+
+	var verdicts []string
+	for len(verdicts) < 2 {
+		line, err := stream.ReadString('\n')
+		require.NoError(t, err)
+		data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: ")
+		if !ok {
+			continue
+		}
+		var event struct {
+			Method string `json:"method"`
+			Params struct {
+				RequestID int                `json:"request_id"`
+				Case      usecase.CaseResult `json:"case"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(data), &event))
+		assert.Equal(t, "test/case", event.Method)
+		assert.Equal(t, 7, event.Params.RequestID)
+		verdicts = append(verdicts, event.Params.Case.Name+" "+event.Params.Case.Verdict)
+	}
+	assert.Equal(t, []string{"sample-1 " + usecase.VerdictAccepted, "sample-2 " + usecase.VerdictWrongAnswer}, verdicts)
+}
@@ -0,0 +1,130 @@
+// Package cli provides command-line interface functionality for the AOJ CLI.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// SessionCommand represents the session command
+type SessionCommand struct {
+	sessionUseCase *usecase.SessionUseCase
+	logger         *logger.Logger
+}
+
+// NewSessionCommand creates a new session command
+func NewSessionCommand(sessionUseCase *usecase.SessionUseCase) *SessionCommand {
+	return &SessionCommand{
+		sessionUseCase: sessionUseCase,
+		logger:         logger.WithGroup("session_command"),
+	}
+}
+
+// Command returns the cobra command for session
+func (c *SessionCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage local login sessions",
+		Long:  "Inspect and clean up the login sessions stored under the config directory",
+	}
+
+	cmd.AddCommand(c.listCommand(), c.pruneCommand())
+
+	return cmd
+}
+
+// listCommand returns the cobra command for session list
+func (c *SessionCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored sessions",
+		Long: `List the login sessions stored locally.
+
+Session IDs are masked and tokens are never shown. The current session
+is marked with an asterisk.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.runList(cmd, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output sessions as JSON")
+
+	return cmd
+}
+
+// runList executes the session list command
+func (c *SessionCommand) runList(cmd *cobra.Command, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	sessions, err := c.sessionUseCase.List(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to list sessions", "error", err)
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sessions)
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No sessions found. Run 'aoj login' to create one.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CURRENT\tID\tUSERNAME\tCREATED\tEXPIRES")
+	for _, session := range sessions {
+		current := ""
+		if session.Current {
+			current = "*"
+		}
+		expires := session.ExpiresAt.Local().Format(time.DateTime)
+		if session.Expired {
+			expires += " (expired)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			current,
+			session.MaskedID,
+			session.Username,
+			session.CreatedAt.Local().Format(time.DateTime),
+			expires)
+	}
+	return w.Flush()
+}
+
+// pruneCommand returns the cobra command for session prune
+func (c *SessionCommand) pruneCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Delete expired sessions",
+		Args:  cobra.NoArgs,
+		RunE:  c.runPrune,
+	}
+}
+
+// runPrune executes the session prune command
+func (c *SessionCommand) runPrune(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	deleted, err := c.sessionUseCase.Prune(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to prune sessions", "error", err)
+		return fmt.Errorf("failed to prune sessions: %w", err)
+	}
+
+	fmt.Printf("Removed %d expired session(s)\n", deleted)
+	return nil
+}
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// defaultWorkspaceRoot is offered by setup when no workspace root is configured
+const defaultWorkspaceRoot = "~/aoj"
+
+// SetupCommand represents the setup command
+type SetupCommand struct {
+	setupUseCase *usecase.SetupUseCase
+	login        *LoginCommand
+	logger       *logger.Logger
+}
+
+// NewSetupCommand creates a new setup command. login runs the login step
+func NewSetupCommand(setupUseCase *usecase.SetupUseCase, login *LoginCommand) *SetupCommand {
+	return &SetupCommand{
+		setupUseCase: setupUseCase,
+		login:        login,
+		logger:       logger.WithGroup("setup_command"),
+	}
+}
+
+// setupOptions holds the flags of the setup command
+type setupOptions struct {
+	language  string
+	template  string
+	workspace string
+	login     bool
+	yes       bool
+}
+
+// Command returns the cobra command for setup
+func (c *SetupCommand) Command() *cobra.Command {
+	var opts setupOptions
+
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Choose your language, template and workspace",
+		Long: `Guide through the first-run settings and write them to config.toml:
+the solution language, its default template, the workspace directory and,
+optionally, logging in to AOJ.
+
+Each question offers the current setting, which Enter keeps. Flags answer
+questions in advance; with --yes, or when stdin is not a terminal, the
+remaining questions keep their defaults. An existing config.toml is backed
+up as config.toml.bak and settings the wizard does not ask about are kept.`,
+		Example: `  aoj setup
+  aoj setup --lang python --workspace ~/aoj --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.run(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.language, "lang", "l", "", "Solution language, e.g. cpp17, python or Java")
+	cmd.Flags().StringVarP(&opts.template, "template", "t", "", "Stored template to use by default for the language")
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Directory for problem directories")
+	cmd.Flags().BoolVar(&opts.login, "login", false, "Log in to AOJ after writing the configuration")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Do not ask; keep the defaults of unanswered questions")
+
+	return cmd
+}
+
+// run executes the setup command
+func (c *SetupCommand) run(cmd *cobra.Command, opts setupOptions) error {
+	ctx := cmd.Context()
+
+	choices, err := c.setupUseCase.Choices(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to read current settings", "error", err)
+		return fmt.Errorf("failed to read current settings: %w", err)
+	}
+
+	interactive := !opts.yes && term.IsTerminal(int(os.Stdin.Fd()))
+	reader := bufio.NewReader(os.Stdin)
+	answers := usecase.SetupAnswers{
+		Language:      opts.language,
+		Template:      opts.template,
+		WorkspaceRoot: opts.workspace,
+	}
+
+	if answers.Language == "" {
+		answers.Language = choices.Language
+		if interactive {
+			answers.Language = askLanguage(reader, choices)
+		}
+	}
+	if answers.Template == "" && interactive {
+		answers.Template = askTemplate(reader, choices, answers.Language)
+	}
+	if !cmd.Flags().Changed("workspace") {
+		answers.WorkspaceRoot = choices.WorkspaceRoot
+		if interactive {
+			root := choices.WorkspaceRoot
+			if root == "" {
+				root = defaultWorkspaceRoot
+			}
+			answers.WorkspaceRoot = ask(reader, "Workspace directory for problems (- for none)", root)
+			if answers.WorkspaceRoot == "-" {
+				answers.WorkspaceRoot = ""
+			}
+		}
+	}
+
+	result, err := c.setupUseCase.Apply(ctx, answers)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "setup failed", "error", err)
+		return fmt.Errorf("setup failed: %w", err)
+	}
+
+	fmt.Printf("%s %s\n", paint(colorGreen, "Wrote"), result.ConfigPath)
+	if result.BackupPath != "" {
+		fmt.Printf("  previous settings: %s\n", result.BackupPath)
+	}
+	fmt.Printf("  language:  %s (%s)\n", result.Language.AOJName, result.SourceFile)
+	if result.Template != "" {
+		fmt.Printf("  template:  %s\n", result.Template)
+	}
+	if result.WorkspaceRoot != "" {
+		fmt.Printf("  workspace: %s\n", result.WorkspaceRoot)
+	}
+
+	if opts.login || (interactive && confirm("Log in to AOJ now?")) {
+		return c.login.run(cmd, nil)
+	}
+	fmt.Println("Run 'aoj login' to log in to AOJ.")
+	return nil
+}
+
+// askLanguage asks for the solution language by number or name
+func askLanguage(reader *bufio.Reader, choices *usecase.SetupChoices) string {
+	fmt.Println("Languages:")
+	for i, lang := range choices.Languages {
+		fmt.Printf("  %d) %s (%s)\n", i+1, lang.Name, lang.AOJName)
+	}
+	answer := ask(reader, "Solution language", choices.Language)
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices.Languages) {
+		return choices.Languages[n-1].Name
+	}
+	return answer
+}
+
+// askTemplate asks for the default template among the stored templates of
+// the language. It returns "" when there are none or none is chosen
+func askTemplate(reader *bufio.Reader, choices *usecase.SetupChoices, language string) string {
+	var extension string
+	for _, lang := range choices.Languages {
+		if strings.EqualFold(lang.Name, language) || strings.EqualFold(lang.AOJName, language) {
+			extension = lang.Extension
+		}
+	}
+
+	var names []string
+	current := ""
+	for _, template := range choices.Templates {
+		if strings.EqualFold(template.Extension, extension) {
+			names = append(names, template.Name)
+			if template.Default {
+				current = template.Name
+			}
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	fmt.Printf("Templates: %s\n", strings.Join(names, ", "))
+	if current == "" {
+		current = "-"
+	}
+	answer := ask(reader, "Default template (- to leave as is)", current)
+	if answer == "-" {
+		return ""
+	}
+	return answer
+}
+
+// ask prints a question with its default and returns the answer, or the
+// default when the answer is empty
+func ask(reader *bufio.Reader, question, defaultValue string) string {
+	fmt.Printf("%s [%s]: ", question, defaultValue)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return defaultValue
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return defaultValue
+}
+
+// WarnReadOnlyConfigDir tells that sessions, caches and history are kept in
+// dataDir because configDir cannot be written to
+func WarnReadOnlyConfigDir(configDir, dataDir string) {
+	fmt.Fprintf(os.Stderr, "%s %s is not writable; sessions, caches and history are kept in %s instead\n",
+		paintFor(os.Stderr, colorYellow, "warning:"), configDir, dataDir)
+	fmt.Fprintf(os.Stderr, "%s pass --config-dir or set %s to a writable directory to keep them\n",
+		paintFor(os.Stderr, colorYellow, "hint:"), config.ConfigDirEnv)
+}
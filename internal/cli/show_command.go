@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/htmlmd"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ShowCommand represents the show command
+type ShowCommand struct {
+	statementUseCase *usecase.StatementUseCase
+	logger           *logger.Logger
+}
+
+// NewShowCommand creates a new show command
+func NewShowCommand(statementUseCase *usecase.StatementUseCase) *ShowCommand {
+	return &ShowCommand{
+		statementUseCase: statementUseCase,
+		logger:           logger.WithGroup("show_command"),
+	}
+}
+
+// Command returns the cobra command for show
+func (c *ShowCommand) Command() *cobra.Command {
+	var (
+		markdown   bool
+		jsonOutput bool
+		opts       usecase.StatementOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "show [problem-id]",
+		Short: "Print the problem statement",
+		Long: `Print the statement of a problem as text for the terminal. Formulas are
+shown with symbols such as ≤ and images as their URLs. With --markdown the
+statement is printed as Markdown with its TeX formulas kept, like the
+README written by aoj init.
+
+The statement is shown in English, or Japanese if there is no English
+version; --lang-statement or init.statement_language in the config picks
+the preferred language. Without a problem ID, the problem of the current
+directory is shown. When a difficulty table is configured, the estimated
+difficulty of the problem is printed above the text statement.
+Statements are cached, so they can be read with --offline once downloaded.
+
+Examples:
+  aoj show ITP1_1_A
+  aoj show ITP1_1_A --lang-statement ja
+  aoj show --markdown > statement.md`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			problemID := ""
+			if len(args) == 1 {
+				problemID = args[0]
+			}
+			opts.Format = htmlmd.Text
+			if markdown {
+				opts.Format = htmlmd.Markdown
+			}
+
+			statement, err := c.statementUseCase.Show(ctx, problemID, opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to show statement", "problem_id", problemID, "error", err)
+				return fmt.Errorf("failed to show the statement: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(statement)
+			}
+			if statement.Preferred != "" && statement.Language != statement.Preferred {
+				fmt.Fprintf(os.Stderr, "%s has no %s statement, showing the %s one\n", statement.ProblemID, statement.Preferred, statement.Language)
+			}
+			if statement.Difficulty != nil && opts.Format == htmlmd.Text {
+				fmt.Printf("Estimated difficulty: %s\n\n", usecase.FormatDifficulty(*statement.Difficulty))
+			}
+			fmt.Print(statement.Content)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&markdown, "markdown", false, "Print Markdown with TeX formulas instead of text")
+	cmd.Flags().StringVar(&opts.Language, "lang-statement", "", "Statement language, en or ja (default: init.statement_language from config)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the problem ID, language, difficulty and statement as JSON")
+
+	return cmd
+}
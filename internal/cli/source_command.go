@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// SourceCommand represents the source command
+type SourceCommand struct {
+	resolver *usecase.ProblemResolver
+	logger   *logger.Logger
+}
+
+// NewSourceCommand creates a new source command
+func NewSourceCommand(resolver *usecase.ProblemResolver) *SourceCommand {
+	return &SourceCommand{
+		resolver: resolver,
+		logger:   logger.WithGroup("source_command"),
+	}
+}
+
+// Command returns the cobra command for source
+func (c *SourceCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "source",
+		Short: "Browse past ICPC and JAG contests archived on AOJ",
+		Long: `Browse the archives of past contests on AOJ, such as the ICPC domestic
+and Asia regional contests. Their problems can be initialized with
+aoj init <source>:<year or contest>[/<letter>]. More archives can be added
+under [sources] in config.toml.`,
+	}
+
+	cmd.AddCommand(c.listCommand())
+
+	return cmd
+}
+
+// listCommand returns the cobra command for source list
+func (c *SourceCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list [source]",
+		Short: "List the sources, or the contests of a source",
+		Long: `List the known sources, or the contests of a source with their problems.
+
+Examples:
+  aoj source list
+  aoj source list icpc-domestic`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+
+			if len(args) == 0 {
+				sources := c.resolver.Sources()
+				if jsonOutput {
+					return encoder.Encode(sources)
+				}
+				t := &table{header: []string{"SOURCE", "AOJ CATEGORY"}}
+				for _, source := range sources {
+					t.addRow(cell{text: source.Name}, cell{text: source.Category})
+				}
+				fmt.Print(t.String())
+				return nil
+			}
+
+			contests, err := c.resolver.Contests(ctx, args[0])
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to list contests", "source", args[0], "error", err)
+				return fmt.Errorf("failed to list contests of %s: %w", args[0], err)
+			}
+			if jsonOutput {
+				return encoder.Encode(contests)
+			}
+			fmt.Print(renderContests(contests))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// renderContests formats contests as a table, listing the problem IDs by letter
+func renderContests(contests []repository.ChallengeContest) string {
+	t := &table{header: []string{"YEAR", "CONTEST", "TITLE", "PROBLEMS"}}
+	for _, contest := range contests {
+		problems := make([]string, 0, len(contest.Problems))
+		for i, problem := range contest.Problems {
+			problems = append(problems, fmt.Sprintf("%c:%s", 'A'+i, problem.ID))
+		}
+		t.addRow(
+			cell{text: strconv.Itoa(contest.Year)},
+			cell{text: contest.Abbr},
+			cell{text: contest.Title},
+			cell{text: strings.Join(problems, " ")},
+		)
+	}
+	return t.String()
+}
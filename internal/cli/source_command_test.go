@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+)
+
+func TestRenderContests(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// Given
+	contests := []repository.ChallengeContest{
+		{Year: 2020, Abbr: "ICPCOOC2020", Title: "ICPC Domestic 2020", Problems: []repository.ChallengeProblem{{ID: "1630"}, {ID: "1631"}}},
+		{Year: 2019, Abbr: "ICPCOOC2019", Problems: []repository.ChallengeProblem{{ID: "1626"}}},
+	}
+
+	// When
+	out := renderContests(contests)
+
+	// Then
+	assert.Equal(t, ""+
+		"YEAR  CONTEST      TITLE               PROBLEMS\n"+
+		"2020  ICPCOOC2020  ICPC Domestic 2020  A:1630 B:1631\n"+
+		"2019  ICPCOOC2019                      A:1626\n", out)
+}
@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// StatsCommand represents the stats command
+type StatsCommand struct {
+	statsUseCase  *usecase.StatsUseCase
+	streakUseCase *usecase.StreakUseCase
+	logger        *logger.Logger
+}
+
+// NewStatsCommand creates a new stats command
+func NewStatsCommand(statsUseCase *usecase.StatsUseCase, streakUseCase *usecase.StreakUseCase) *StatsCommand {
+	return &StatsCommand{
+		statsUseCase:  statsUseCase,
+		streakUseCase: streakUseCase,
+		logger:        logger.WithGroup("stats_command"),
+	}
+}
+
+// Command returns the cobra command for stats
+func (c *StatsCommand) Command() *cobra.Command {
+	var (
+		cliStats       bool
+		reset          bool
+		jsonOutput     bool
+		heatmap        bool
+		heatmapOptions usecase.HeatmapOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show your practice streak and local statistics",
+		Long: `Show statistics kept on this machine.
+
+Without flags, show the current and longest streak of days with at least
+one accepted submission. The days are read from the local history, which
+holds the submissions made with this CLI and those imported by
+aoj history sync.
+
+--heatmap draws a calendar of the accepted submissions of each day, like
+the contribution graph of GitHub; --submissions counts every submission.
+
+--cli shows how often each command was run, how long it took and why it
+failed, which helps to find slow operations. The numbers are recorded in
+stats.json in the configuration directory and are never uploaded; set
+storage.usage_stats = false in config.toml to stop recording them.
+
+Examples:
+  aoj stats
+  aoj stats --heatmap
+  aoj stats --heatmap --submissions --weeks 26
+  aoj stats --cli
+  aoj stats --cli --reset`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			if !cliStats {
+				if reset {
+					return cerrors.NewAppError(cerrors.CodeInvalidInput, "--reset can only be used with --cli", nil)
+				}
+				if heatmap {
+					return c.showHeatmap(cmd, heatmapOptions, jsonOutput)
+				}
+				return c.showStreak(cmd, jsonOutput)
+			}
+			if reset {
+				if err := c.statsUseCase.ResetCLI(ctx); err != nil {
+					c.logger.ErrorContext(ctx, "failed to reset usage statistics", "error", err)
+					return fmt.Errorf("failed to reset usage statistics: %w", err)
+				}
+				fmt.Println("Usage statistics were reset")
+				return nil
+			}
+
+			report, err := c.statsUseCase.CLIStats(ctx)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to read usage statistics", "error", err)
+				return fmt.Errorf("failed to read usage statistics: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+			if len(report.Commands) == 0 {
+				fmt.Println("No commands were recorded yet")
+				return nil
+			}
+			fmt.Printf("Commands run since %s\n\n", report.Since.Local().Format("2006-01-02 15:04"))
+			fmt.Print(cliStatsTable(report))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&cliStats, "cli", false, "Show the runs, durations and failures of each command")
+	cmd.Flags().BoolVar(&reset, "reset", false, "Delete the recorded statistics")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the statistics as JSON")
+	cmd.Flags().BoolVar(&heatmap, "heatmap", false, "Draw a calendar of the accepted submissions per day")
+	cmd.Flags().IntVar(&heatmapOptions.Weeks, "weeks", usecase.DefaultHeatmapWeeks, "Weeks the heatmap covers")
+	cmd.Flags().BoolVar(&heatmapOptions.Submissions, "submissions", false, "Count all submissions in the heatmap, not only accepted ones")
+	cmd.MarkFlagsMutuallyExclusive("cli", "heatmap")
+	cmd.MarkFlagsMutuallyExclusive("reset", "json")
+
+	return cmd
+}
+
+// showStreak prints the practice streak
+func (c *StatsCommand) showStreak(cmd *cobra.Command, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	report, err := c.streakUseCase.Streak(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to read the streak", "error", err)
+		return fmt.Errorf("failed to read the streak: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	if report.ActiveDays == 0 {
+		fmt.Println("No accepted submissions in the local history yet. Import your past submissions with 'aoj history sync'")
+		return nil
+	}
+
+	today := paint(colorYellow, "not solved today yet")
+	if report.SolvedToday {
+		today = paint(colorGreen, "solved today ✓")
+	}
+	fmt.Printf("Current streak:  %s (%s)\n", formatDays(report.Current), today)
+	fmt.Printf("Longest streak:  %s\n", formatDays(report.Longest))
+	fmt.Printf("Active days:     %d\n", report.ActiveDays)
+	fmt.Printf("Last accepted:   %s\n", report.LastAccepted.Local().Format("2006-01-02 15:04"))
+	return nil
+}
+
+// showHeatmap draws the submissions per day
+func (c *StatsCommand) showHeatmap(cmd *cobra.Command, opts usecase.HeatmapOptions, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	heatmap, err := c.streakUseCase.Heatmap(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to read the heatmap", "error", err)
+		return fmt.Errorf("failed to read the heatmap: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(heatmap)
+	}
+	fmt.Print(renderHeatmap(heatmap))
+	kind := "submissions"
+	if heatmap.Accepted {
+		kind = "accepted submissions"
+	}
+	fmt.Printf("\n%d %s on %s in the last %d weeks\n", heatmap.Total, kind, formatDays(heatmap.ActiveDays), heatmap.Weeks)
+	return nil
+}
+
+// heatmapLevels are the cells of a heatmap from no submission to the most
+var heatmapLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapMonthWidth is the number of columns a month name takes, with a space
+const heatmapMonthWidth = 4
+
+// heatmapRowLabels label every other weekday, starting with Sunday, as GitHub does
+var heatmapRowLabels = []string{"", "Mon", "", "Wed", "", "Fri", ""}
+
+// renderHeatmap draws a heatmap as one column per week, one row per weekday
+// and one cell per day, with the months above the weeks they start in
+func renderHeatmap(heatmap *usecase.Heatmap) string {
+	weeks := (len(heatmap.Days) + 6) / 7
+	const labelWidth = 4
+
+	// Month names above the first week starting in each month; the partial
+	// month of the first week is only named if there is room before the next
+	var starts []int
+	for week := 0; week < weeks; week++ {
+		if week == 0 || heatmap.Days[week*7].Date[:7] != heatmap.Days[(week-1)*7].Date[:7] {
+			starts = append(starts, week)
+		}
+	}
+	if len(starts) > 1 && starts[1] < heatmapMonthWidth {
+		starts = starts[1:]
+	}
+	months := []rune(strings.Repeat(" ", labelWidth+weeks+heatmapMonthWidth))
+	for _, week := range starts {
+		if date, err := time.Parse(time.DateOnly, heatmap.Days[week*7].Date); err == nil {
+			copy(months[labelWidth+week:], []rune(date.Format("Jan")))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(string(months), " ") + "\n")
+	for weekday := 0; weekday < 7; weekday++ {
+		var row strings.Builder
+		row.WriteString(fmt.Sprintf("%-*s", labelWidth, heatmapRowLabels[weekday]))
+		for week := 0; week < weeks; week++ {
+			index := week*7 + weekday
+			if index >= len(heatmap.Days) {
+				break
+			}
+			row.WriteString(heatmapCell(heatmap.Days[index].Count, heatmap.Max))
+		}
+		b.WriteString(strings.TrimRight(row.String(), " ") + "\n")
+	}
+
+	legend := make([]string, 0, len(heatmapLevels))
+	for level := range heatmapLevels {
+		legend = append(legend, heatmapLevel(level))
+	}
+	b.WriteString(strings.Repeat(" ", labelWidth) + "Less " + strings.Join(legend, "") + " More\n")
+	return b.String()
+}
+
+// heatmapCell returns the cell of a day, scaled to the busiest day so that
+// each level covers a quarter of the range
+func heatmapCell(count, highest int) string {
+	level := 0
+	if count > 0 && highest > 0 {
+		level = min((count*(len(heatmapLevels)-1)+highest-1)/highest, len(heatmapLevels)-1)
+	}
+	return heatmapLevel(level)
+}
+
+// heatmapLevel paints the character of a level, green unless it is empty
+func heatmapLevel(level int) string {
+	if level == 0 {
+		return heatmapLevels[0]
+	}
+	return paint(colorGreen, heatmapLevels[level])
+}
+
+// formatDays formats a number of days
+func formatDays(days int) string {
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// cliStatsTable renders the usage of every command
+func cliStatsTable(report *usecase.CLIStatsReport) string {
+	t := &table{header: []string{"COMMAND", "RUNS", "FAILED", "AVG", "MAX", "LAST RUN", "FAILURES"}}
+	for _, command := range report.Commands {
+		failed := cell{text: fmt.Sprint(command.Failures)}
+		if command.Failures > 0 {
+			failed.color = colorRed
+		}
+		t.addRow(
+			cell{text: command.Command},
+			cell{text: fmt.Sprint(command.Runs)},
+			failed,
+			cell{text: formatMillis(command.Average())},
+			cell{text: formatMillis(command.Max())},
+			cell{text: command.LastRun.Local().Format("2006-01-02 15:04")},
+			cell{text: formatFailureCodes(command.FailureCodes)},
+		)
+	}
+	return t.String()
+}
+
+// formatFailureCodes lists failure codes with their counts, the most frequent first
+func formatFailureCodes(codes map[string]int) string {
+	names := make([]string, 0, len(codes))
+	for code := range codes {
+		names = append(names, code)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if codes[names[i]] != codes[names[j]] {
+			return codes[names[i]] > codes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, 0, len(names))
+	for _, code := range names {
+		parts = append(parts, fmt.Sprintf("%s×%d", code, codes[code]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// Given: six weeks from Sunday, March 29 to Wednesday, May 6
+	start := time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+	heatmap := &usecase.Heatmap{Accepted: true, Weeks: 6, Max: 4}
+	for i := 0; i < 39; i++ {
+		heatmap.Days = append(heatmap.Days, usecase.HeatmapDay{Date: start.AddDate(0, 0, i).Format(time.DateOnly)})
+	}
+	heatmap.Days[1].Count = 1  // Monday of the first week
+	heatmap.Days[9].Count = 2  // Tuesday of the second week
+	heatmap.Days[38].Count = 4 // today
+
+	// When
+	out := renderHeatmap(heatmap)
+
+	// Then
+	assert.Equal(t, ""+
+		"     Apr May\n"+
+		"    ······\n"+
+		"Mon ░·····\n"+
+		"    ·▒····\n"+
+		"Wed ·····█\n"+
+		"    ·····\n"+
+		"Fri ·····\n"+
+		"    ·····\n"+
+		"    Less ·░▒▓█ More\n", out)
+}
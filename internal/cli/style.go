@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ANSI color codes used for verdicts, warnings and errors
+const (
+	colorRed    = "31"
+	colorGreen  = "32"
+	colorYellow = "33"
+)
+
+// colorMode decides when output is colored
+type colorMode int
+
+const (
+	colorAuto   colorMode = iota // only on terminals that render escape sequences
+	colorAlways                  // also when redirected, e.g. for CI logs that render colors
+	colorNever
+)
+
+// currentColorMode follows the NO_COLOR (https://no-color.org) and
+// FORCE_COLOR conventions and is set to colorNever by --no-color
+var currentColorMode = colorModeFromEnv()
+
+// colorModeFromEnv returns the color mode requested by environment variables
+func colorModeFromEnv() colorMode {
+	switch {
+	case os.Getenv("NO_COLOR") != "":
+		return colorNever
+	case os.Getenv("FORCE_COLOR") != "":
+		return colorAlways
+	default:
+		return colorAuto
+	}
+}
+
+// setColorMode changes when output is colored for the rest of the command
+func setColorMode(mode colorMode) {
+	currentColorMode = mode
+}
+
+// colorSupported reports whether text written to w should be colored: w must
+// be a terminal other than TERM=dumb, unless colors are forced or disabled
+func colorSupported(w io.Writer) bool {
+	switch currentColorMode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// paint colors text written to stdout
+func paint(color, text string) string {
+	return paintFor(os.Stdout, color, text)
+}
+
+// paintFor wraps text in the escape sequences of an ANSI color if w supports
+// colors and a color is given
+func paintFor(w io.Writer, color, text string) string {
+	if color == "" || !colorSupported(w) {
+		return text
+	}
+	return "\u001b[" + color + "m" + text + "\u001b[0m"
+}
+
+// progressBar renders value out of total as a bar of width cells, e.g. "██████░░░░"
+func progressBar(value, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(max(value*width/total, 0), width)
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// sparkLevels are the block characters of a sparkline from lowest to highest
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as one block character each, scaled between the
+// smallest and the largest value, e.g. "▁▃█▂"
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lowest, highest := slices.Min(values), slices.Max(values)
+
+	var b strings.Builder
+	for _, value := range values {
+		level := 0
+		if highest > lowest {
+			level = int((value - lowest) / (highest - lowest) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// table renders rows with aligned columns. Unlike tabwriter it measures cells
+// without their color, so colored cells do not break the alignment
+type table struct {
+	header []string
+	rows   [][]cell
+}
+
+// cell is a table cell painted in color, if not empty
+type cell struct {
+	text  string
+	color string
+}
+
+// addRow appends a row of cells
+func (t *table) addRow(cells ...cell) {
+	t.rows = append(t.rows, cells)
+}
+
+// String returns the table with columns separated by two spaces
+func (t *table) String() string {
+	widths := make([]int, len(t.header))
+	for i, title := range t.header {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range t.rows {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c.text))
+		}
+	}
+
+	var b strings.Builder
+	writeLine := func(cells []cell) {
+		var line strings.Builder
+		for i, c := range cells {
+			line.WriteString(paint(c.color, c.text))
+			if i < len(cells)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c.text)+2))
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	header := make([]cell, len(t.header))
+	for i, title := range t.header {
+		header[i] = cell{text: title}
+	}
+	writeLine(header)
+	for _, row := range t.rows {
+		writeLine(row)
+	}
+	return b.String()
+}
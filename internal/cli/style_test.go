@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+)
+
+func TestPaint(t *testing.T) {
+	defer setColorMode(currentColorMode)
+
+	setColorMode(colorAlways)
+	assert.Equal(t, "\u001b[32mok\u001b[0m", paint(colorGreen, "ok"))
+	assert.Equal(t, "ok", paint("", "ok"))
+
+	setColorMode(colorNever)
+	assert.Equal(t, "ok", paint(colorGreen, "ok"))
+}
+
+func TestColorSupported(t *testing.T) {
+	defer setColorMode(currentColorMode)
+
+	tests := []struct {
+		name string
+		mode colorMode
+		want bool
+	}{
+		{name: "auto is off when redirected", mode: colorAuto, want: false},
+		{name: "forced", mode: colorAlways, want: true},
+		{name: "disabled", mode: colorNever, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setColorMode(tt.mode)
+			assert.Equal(t, tt.want, colorSupported(&bytes.Buffer{}))
+		})
+	}
+}
+
+func TestColorModeFromEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	assert.Equal(t, colorAuto, colorModeFromEnv())
+
+	t.Setenv("FORCE_COLOR", "1")
+	assert.Equal(t, colorAlways, colorModeFromEnv())
+
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, colorNever, colorModeFromEnv(), "NO_COLOR wins over FORCE_COLOR")
+}
+
+func TestTable_String(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorAlways)
+
+	// Given
+	tbl := &table{header: []string{"CASE", "RESULT", "TIME"}}
+	tbl.addRow(cell{text: "sample-1"}, cell{text: "✓ AC", color: colorGreen}, cell{text: "3ms"})
+	tbl.addRow(cell{text: "judge-12"}, cell{text: "✗ TLE", color: colorRed}, cell{text: "2000ms"})
+
+	// When
+	out := tbl.String()
+
+	// Then
+	assert.Equal(t, "CASE      RESULT  TIME\n"+
+		"sample-1  \u001b[32m✓ AC\u001b[0m    3ms\n"+
+		"judge-12  \u001b[31m✗ TLE\u001b[0m   2000ms\n", out)
+}
+
+func TestResultTable(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// Given
+	report := &usecase.TestReport{
+		Verdict: usecase.VerdictWrongAnswer,
+		Total:   3,
+		Cases: []usecase.CaseResult{
+			{Name: "sample-1", Verdict: usecase.VerdictAccepted, Duration: 12 * time.Millisecond, MemoryKB: 3072},
+			{Name: "sample-2", Verdict: usecase.VerdictWrongAnswer, Duration: 312 * time.Millisecond},
+			{Name: "judge-1", Verdict: usecase.VerdictSkipped, Description: "slow"},
+		},
+	}
+
+	// When
+	out := resultTable(report.Cases)
+	summary := summaryLine(report)
+
+	// Then
+	assert.Equal(t, "CASE      RESULT  TIME   MEMORY   DESCRIPTION\n"+
+		"sample-1  ✓ AC    12ms   3.0 MiB\n"+
+		"sample-2  ✗ WA    312ms  -\n"+
+		"judge-1   - SKIP  -      -        slow\n", out)
+	assert.Equal(t, "❌ 1/2 passed, 1 skipped, slowest 312ms", summary)
+}
+
+func TestJudgeCaseTable(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// Given
+	results := []entity.CaseResult{
+		{Serial: 1, Label: "testcase_00", Status: entity.StatusAccepted, Time: 30 * time.Millisecond, Memory: 3072},
+		{Serial: 2, Label: "testcase_01", Status: entity.StatusTimeLimitExceeded, Time: 2 * time.Second, Memory: 4096},
+		{Serial: 3},
+	}
+
+	// When
+	out := judgeCaseTable(results)
+
+	// Then
+	assert.Equal(t, "CASE            VERDICT                TIME    MEMORY\n"+
+		"#1 testcase_00  ✓ ACCEPTED             30ms    3.0 MiB\n"+
+		"#2 testcase_01  ✗ TIME_LIMIT_EXCEEDED  2000ms  4.0 MiB\n"+
+		"#3              - SKIPPED              -       -\n", out)
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		value, total int
+		want         string
+	}{
+		{value: 0, total: 100, want: "░░░░░░░░░░"},
+		{value: 60, total: 100, want: "██████░░░░"},
+		{value: 100, total: 100, want: "██████████"},
+		{value: 120, total: 100, want: "██████████"},
+		{value: 5, total: 0, want: "░░░░░░░░░░"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, progressBar(tt.value, tt.total, 10), "%d/%d", tt.value, tt.total)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█▁", sparkline([]float64{1, 4.5, 8, 1}))
+	assert.Equal(t, "▁▁▁", sparkline([]float64{3, 3, 3}))
+	assert.Empty(t, sparkline(nil))
+}
+
+func TestBenchGraph(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// Given
+	samples := []usecase.BenchSample{
+		{Run: 1, Time: 10 * time.Millisecond},
+		{Run: 2, Time: 20 * time.Millisecond},
+		{Run: 3, Time: 10 * time.Millisecond},
+	}
+
+	// When
+	out := benchGraph(samples)
+
+	// Then
+	assert.Equal(t, "time  ▁█▁\n\n"+
+		"TIME       RUNS\n"+
+		"10ms-11ms  2     ██████████████████████████████\n"+
+		"11ms-12ms  0\n"+
+		"12ms-13ms  0\n"+
+		"13ms-15ms  0\n"+
+		"15ms-16ms  0\n"+
+		"16ms-17ms  0\n"+
+		"17ms-18ms  0\n"+
+		"18ms-20ms  1     ███████████████\n", out)
+}
+
+func TestTLEWarningLine(t *testing.T) {
+	sameSpeed := usecase.TLEWarning{Case: "sample-2", Local: 1700 * time.Millisecond, Estimated: 1700 * time.Millisecond, Ratio: 0.85}
+	slower := usecase.TLEWarning{Case: "sample-2", Local: 1800 * time.Millisecond, Estimated: 900 * time.Millisecond, Ratio: 0.9}
+
+	assert.Equal(t, "! Likely TLE on judge: sample-2 took 1700ms, 85% of the 2000ms limit", tleWarningLine(sameSpeed, 2*time.Second))
+	assert.Equal(t, "! Likely TLE on judge: sample-2 took 1800ms (about 900ms on the judge), 90% of the 1000ms limit", tleWarningLine(slower, time.Second))
+}
+
+func TestSideBySide(t *testing.T) {
+	defer setColorMode(currentColorMode)
+	setColorMode(colorNever)
+
+	// When
+	out := sideBySide("A", "3\n10\n", "B", "3\n11\n8\n")
+
+	// Then
+	pad := func(text string) string { return text + strings.Repeat(" ", diffColumnWidth-len(text)) }
+	assert.Equal(t, pad("A")+"   B\n"+
+		pad("3")+" | 3\n"+
+		pad("10")+" ≠ 11\n"+
+		pad("")+" ≠ 8\n", out)
+}
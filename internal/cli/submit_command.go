@@ -2,10 +2,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
 	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
 )
@@ -13,13 +19,15 @@ import (
 // SubmitCommand represents the submit command
 type SubmitCommand struct {
 	submitUseCase *usecase.SubmitUseCase
+	testUseCase   *usecase.TestUseCase
 	logger        *logger.Logger
 }
 
 // NewSubmitCommand creates a new submit command
-func NewSubmitCommand(submitUseCase *usecase.SubmitUseCase) *SubmitCommand {
+func NewSubmitCommand(submitUseCase *usecase.SubmitUseCase, testUseCase *usecase.TestUseCase) *SubmitCommand {
 	return &SubmitCommand{
 		submitUseCase: submitUseCase,
+		testUseCase:   testUseCase,
 		logger:        logger.WithGroup("submit_command"),
 	}
 }
@@ -27,23 +35,35 @@ func NewSubmitCommand(submitUseCase *usecase.SubmitUseCase) *SubmitCommand {
 // Command returns the cobra command for submit
 func (c *SubmitCommand) Command() *cobra.Command {
 	var (
-		problemID string
-		filePath  string
-		language  string
+		problemID    string
+		filePath     string
+		language     string
+		watch        bool
+		noWatch      bool
+		noGit        bool
+		noTransform  bool
+		retryPending bool
+		pollInterval time.Duration
+		timeout      time.Duration
+		all          bool
+		delay        time.Duration
 	)
 
 	cmd := &cobra.Command{
-		Use:   "submit",
+		Use:   "submit [- | --all [pattern...]]",
 		Short: "Submit a solution to AOJ",
 		Long: `Submit a solution to AOJ for the current problem.
 
 By default, this command:
 - Uses the current directory name as the problem ID
-- Submits the main.go file
-- Auto-detects the language from the file extension
+- Submits submit.source_file from the config, or else the most recently
+  modified source file (main.cpp, main.py, Main.java, ...)
+- Auto-detects the language from the file extension, preferring the
+  configured submit.language for files of the same language
+- Waits for the verdict when submit.watch is enabled
 
 Examples:
-  # Submit main.go in current directory (problem ID from directory name)
+  # Submit the solution in current directory (problem ID from directory name)
   aoj submit
 
   # Submit a specific file
@@ -53,58 +73,364 @@ Examples:
   aoj submit --problem-id ITP1_1_A
 
   # Submit with explicit language
-  aoj submit --language C++17`,
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			return c.run(cmd, problemID, filePath, language)
+  aoj submit --language C++17
+
+  # Submit the source code piped to stdin, e.g. from an editor buffer
+  cat main.cpp | aoj submit -p ITP1_1_A -l C++17 -
+
+  # Do not commit the solution even if submit.git_commit_on_ac is set
+  aoj submit --no-git
+
+  # Send the submissions queued while AOJ was unavailable
+  aoj submit --retry-pending
+
+  # Wait for the verdict for at most two minutes
+  aoj submit --watch --timeout 2m
+
+  # Submit every problem of the contest directory whose samples pass
+  aoj submit --all
+
+With "-" as the file, the source code is read from stdin instead. The
+language cannot be detected then, so it is --language or submit.language,
+and an accepted solution is not committed.
+
+When AOJ answers with a server error or does not respond, the submission
+is sent again up to submit.retry_attempts times with growing delays. If
+it still fails, it is queued locally so that no work is lost; send the
+queue later with --retry-pending.
+
+While waiting for the verdict, the verdict is polled every --poll-interval
+at first, and less often while it does not change. As long as the
+submission waits for the judge, its place in the judge queue is shown.
+
+With --all, the samples of every problem directory below the current one
+(or matched by the patterns, as for 'aoj verify') are run, and the problems
+that pass are submitted one after another, at least submit.batch_delay
+seconds apart. The verdicts are then awaited together and summarized in a
+table.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && args[0] == usecase.StdinSource && !all {
+				if filePath != "" {
+					return fmt.Errorf("cannot submit both --file %s and stdin", filePath)
+				}
+				filePath = usecase.StdinSource
+				args = nil
+			}
+			if len(args) > 0 && !all {
+				return fmt.Errorf("unexpected arguments %q: problem directory patterns require --all", args)
+			}
+			if all {
+				return c.runAll(cmd, usecase.SubmitAllOptions{
+					Patterns:     args,
+					Delay:        delay,
+					Watch:        watch,
+					NoWatch:      noWatch,
+					NoGit:        noGit,
+					PollInterval: pollInterval,
+					Timeout:      timeout,
+				})
+			}
+			if retryPending {
+				return c.runRetryPending(cmd, usecase.RetryPendingOptions{
+					Watch:        watch,
+					NoWatch:      noWatch,
+					PollInterval: pollInterval,
+					Timeout:      timeout,
+				})
+			}
+			return c.run(cmd, usecase.SubmitOptions{
+				ProblemID:    problemID,
+				FilePath:     filePath,
+				Language:     language,
+				Watch:        watch,
+				NoWatch:      noWatch,
+				NoGit:        noGit,
+				NoTransform:  noTransform,
+				PollInterval: pollInterval,
+				Timeout:      timeout,
+				Stdin:        cmd.InOrStdin(),
+			})
 		},
 	}
 
 	// Add flags
-	cmd.Flags().StringVarP(&problemID, "problem-id", "p", "", "Problem ID (default: current directory name)")
-	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Source file to submit (default: main.go)")
+	cmd.Flags().StringVarP(&problemID, "problem-id", "p", "", "Problem ID or alias (default: inferred from problem.toml or the directory names)")
+	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Source file to submit, or - for stdin (default: configured or newest source file)")
 	cmd.Flags().StringVarP(&language, "language", "l", "", "Programming language (default: auto-detect from extension)")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Wait for the verdict (default: submit.watch from config)")
+	cmd.Flags().BoolVar(&noWatch, "no-watch", false, "Do not wait for the verdict")
+	cmd.MarkFlagsMutuallyExclusive("watch", "no-watch")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", 0, "First interval between verdict polls, e.g. 1s (default 2s)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Stop waiting for the verdict after this long, e.g. 2m (default: wait until judged)")
+	for _, flag := range []string{"poll-interval", "timeout"} {
+		cmd.MarkFlagsMutuallyExclusive("no-watch", flag)
+	}
+	cmd.Flags().BoolVar(&noGit, "no-git", false, "Do not commit the accepted solution (default: submit.git_commit_on_ac from config)")
+	cmd.Flags().BoolVar(&noTransform, "no-transform", false, "Submit the file as is, without the submit.transform snippets")
+	cmd.Flags().BoolVar(&retryPending, "retry-pending", false, "Send the submissions queued while AOJ was unavailable")
+	for _, flag := range []string{"problem-id", "file", "language", "no-git", "no-transform"} {
+		cmd.MarkFlagsMutuallyExclusive("retry-pending", flag)
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Submit every problem below the current directory whose samples pass")
+	cmd.Flags().DurationVar(&delay, "delay", 0, "Shortest time between two submissions of --all (default: submit.batch_delay from config)")
+	for _, flag := range []string{"problem-id", "file", "language", "no-transform", "retry-pending"} {
+		cmd.MarkFlagsMutuallyExclusive("all", flag)
+	}
 
 	return cmd
 }
 
 // run executes the submit command
-func (c *SubmitCommand) run(cmd *cobra.Command, problemID, filePath, language string) error {
+func (c *SubmitCommand) run(cmd *cobra.Command, opts usecase.SubmitOptions) error {
 	ctx := cmd.Context()
 
 	c.logger.InfoContext(ctx, "executing submit command",
-		"problem_id", problemID,
-		"file_path", filePath,
-		"language", language)
+		"problem_id", opts.ProblemID,
+		"file_path", opts.FilePath,
+		"language", opts.Language)
 
-	// Prepare options
-	opts := usecase.SubmitOptions{
-		ProblemID: problemID,
-		FilePath:  filePath,
-		Language:  language,
+	opts.OnStatus = func(status entity.SubmissionStatus) {
+		fmt.Printf("Judging... %s\n", status)
+	}
+	opts.OnQueue = printJudgeQueue
+	opts.OnWarning = func(message string) {
+		fmt.Fprintf(os.Stderr, "%s %s\n", paintFor(os.Stderr, colorYellow, "warning:"), message)
+	}
+	var gitCommit *usecase.GitCommitResult
+	opts.OnGitCommit = func(result usecase.GitCommitResult) {
+		gitCommit = &result
+	}
+	var score *usecase.ScoreReport
+	opts.OnScore = func(report usecase.ScoreReport) {
+		score = &report
 	}
 
 	// Execute use case
 	submission, err := c.submitUseCase.Execute(ctx, opts)
+	if submission != nil {
+		printSubmissionResult(submission)
+	}
+	if score != nil {
+		printScoreReport(*score)
+	}
+	if gitCommit != nil {
+		printGitCommit(*gitCommit)
+	}
 	if err != nil {
 		c.logger.ErrorContext(ctx, "submission failed", "error", err)
 		return fmt.Errorf("submission failed: %w", err)
 	}
 
-	// Display result
+	return nil
+}
+
+// runRetryPending executes submit --retry-pending
+func (c *SubmitCommand) runRetryPending(cmd *cobra.Command, opts usecase.RetryPendingOptions) error {
+	ctx := cmd.Context()
+
+	opts.OnSubmitted = func(submission *entity.Submission) {
+		printSubmissionResult(submission)
+		fmt.Println()
+	}
+	opts.OnStatus = func(status entity.SubmissionStatus) {
+		fmt.Printf("Judging... %s\n", status)
+	}
+	opts.OnQueue = printJudgeQueue
+	opts.OnWarning = func(message string) {
+		fmt.Fprintf(os.Stderr, "%s %s\n", paintFor(os.Stderr, colorYellow, "warning:"), message)
+	}
+
+	result, err := c.submitUseCase.RetryPending(ctx, opts)
+	if result != nil {
+		for _, rejected := range result.Rejected {
+			fmt.Printf("%s %s (%s, queued %s): %v\n", paint(colorRed, "✗ Rejected"),
+				rejected.Pending.ProblemID, rejected.Pending.Language,
+				rejected.Pending.QueuedAt.Local().Format("2006-01-02 15:04"), rejected.Err)
+		}
+		switch {
+		case len(result.Submitted) == 0 && len(result.Rejected) == 0 && result.Remaining == 0:
+			fmt.Println("No pending submissions")
+		case result.Remaining > 0:
+			fmt.Println(paint(colorYellow, fmt.Sprintf("! %d submissions are still pending", result.Remaining)))
+		default:
+			fmt.Printf("%s %d queued submissions\n", paint(colorGreen, "✓ Sent"), len(result.Submitted))
+		}
+	}
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to send pending submissions", "error", err)
+		return fmt.Errorf("failed to send pending submissions: %w", err)
+	}
+	if result != nil && len(result.Rejected) > 0 {
+		return fmt.Errorf("%d queued submissions were rejected", len(result.Rejected))
+	}
+	return nil
+}
+
+// runAll executes submit --all
+func (c *SubmitCommand) runAll(cmd *cobra.Command, opts usecase.SubmitAllOptions) error {
+	ctx := cmd.Context()
+
+	opts.OnTested = printVerifyResult
+	opts.OnSubmitted = func(result usecase.SubmitAllResult) {
+		if result.Submission == nil {
+			fmt.Printf("%s %s: %v\n", paint(colorRed, "✗ Not submitted"), result.Test.Problem, result.Err)
+			return
+		}
+		fmt.Printf("%s %s (%s)\n", paint(colorGreen, "↑ Submitted"), result.Submission.ProblemID().String(), result.Submission.Language())
+	}
+	opts.OnStatus = func(problem string, status entity.SubmissionStatus) {
+		fmt.Printf("%s: Judging... %s\n", problem, status)
+	}
+	opts.OnWarning = func(message string) {
+		fmt.Fprintf(os.Stderr, "%s %s\n", paintFor(os.Stderr, colorYellow, "warning:"), message)
+	}
+
+	report, err := c.submitUseCase.SubmitAll(ctx, c.testUseCase, opts)
+	if report != nil {
+		fmt.Println()
+		fmt.Print(submitAllTable(report).String())
+		for _, result := range report.Results {
+			if result.GitCommit != nil {
+				printGitCommit(*result.GitCommit)
+			}
+		}
+		summary := fmt.Sprintf("%d submitted, %d accepted of %d problems", report.SubmittedCount(), report.AcceptedCount(), len(report.Results))
+		if report.AcceptedCount() == len(report.Results) {
+			fmt.Printf("\n%s\n", paint(colorGreen, "✅ "+summary))
+		} else {
+			fmt.Printf("\n%s\n", paint(colorYellow, summary))
+		}
+	}
+	if err != nil {
+		c.logger.ErrorContext(ctx, "submit --all failed", "error", err)
+		return fmt.Errorf("submission failed: %w", err)
+	}
+	if report.Interrupted {
+		return fmt.Errorf("submit --all interrupted: %w", context.Canceled)
+	}
+	return nil
+}
+
+// submitAllTable lists the local test result and the verdict of every problem of submit --all
+func submitAllTable(report *usecase.SubmitAllReport) *table {
+	t := &table{header: []string{"PROBLEM", "SAMPLES", "VERDICT", "TIME", "MEMORY"}}
+	for _, result := range report.Results {
+		samples := cell{text: "✓ " + result.Test.Verdict, color: colorGreen}
+		if result.Test.Verdict != usecase.VerdictAccepted {
+			samples = cell{text: "✗ " + result.Test.Verdict, color: colorRed}
+		}
+		verdict, timeText, memory := cell{text: "-"}, "-", "-"
+		switch submission := result.Submission; {
+		case submission == nil && result.Err != nil:
+			verdict = cell{text: "not submitted", color: colorRed}
+		case submission == nil:
+		case submission.IsAccepted():
+			verdict = cell{text: "✓ " + string(submission.Status()), color: colorGreen}
+		case submission.HasError():
+			verdict = cell{text: "✗ " + string(submission.Status()), color: colorRed}
+		default:
+			verdict = cell{text: string(submission.Status()), color: colorYellow}
+		}
+		if submission := result.Submission; submission != nil && submission.Status().IsFinal() && submission.Time() > 0 {
+			timeText = fmt.Sprintf("%.2fs", submission.Time().Seconds())
+			memory = usecase.FormatSize(submission.Memory() * 1024)
+		}
+		t.addRow(cell{text: result.Test.Problem}, samples, verdict, cell{text: timeText}, cell{text: memory})
+	}
+	return t
+}
+
+// printJudgeQueue reports the place of a submission in the judge queue
+func printJudgeQueue(queue repository.JudgeQueue) {
+	fmt.Printf("Waiting in the judge queue... position %d of %d\n", queue.Position, queue.Length)
+}
+
+// printSubmissionResult displays the result of a submission
+func printSubmissionResult(submission *entity.Submission) {
 	fmt.Printf("Successfully submitted solution!\n")
 	fmt.Printf("Problem ID: %s\n", submission.ProblemID().String())
 	fmt.Printf("Language: %s\n", submission.Language())
 	fmt.Printf("Status: %s\n", submission.Status())
 	fmt.Printf("Submission ID: %s\n", submission.ID().String())
+	if submission.Score() > 0 {
+		fmt.Printf("Score: %s\n", scoreBar(submission.Score()))
+	}
 
 	if submission.IsAccepted() {
-		fmt.Printf("\n\u001b[32m✓ Accepted!\u001b[0m\n")
+		fmt.Printf("\n%s\n", paint(colorGreen, "✓ Accepted!"))
 	} else if submission.HasError() {
-		fmt.Printf("\n\u001b[31m✗ %s\u001b[0m\n", submission.Status())
+		fmt.Printf("\n%s\n", paint(colorRed, "✗ "+string(submission.Status())))
 		if submission.Message() != "" {
 			fmt.Printf("Message: %s\n", submission.Message())
 		}
+		if submission.CompileError() != "" {
+			fmt.Printf("\nCompiler output:\n%s\n", strings.TrimRight(submission.CompileError(), "\n"))
+		}
 	}
 
-	return nil
+	if results := submission.CaseResults(); len(results) > 0 {
+		fmt.Printf("\nJudge test cases:\n%s", judgeCaseTable(results))
+	}
+	if serial := submission.FailedCase(); serial > 0 && submission.HasError() {
+		fmt.Printf("Failed on judge test case #%d\n", serial)
+		fmt.Printf("Run 'aoj testcase pull %d' to download it and reproduce the failure with 'aoj test'\n", serial)
+	}
+}
+
+// judgeCaseTable lists the verdict, time and memory of every judge test case of a submission
+func judgeCaseTable(results []entity.CaseResult) string {
+	t := &table{header: []string{"CASE", "VERDICT", "TIME", "MEMORY"}}
+	for _, result := range results {
+		verdict := cell{text: "✗ " + string(result.Status), color: colorRed}
+		duration := cell{text: formatMillis(result.Time)}
+		memory := cell{text: usecase.FormatSize(result.Memory * 1024)}
+		switch {
+		case result.Skipped():
+			verdict = cell{text: "- SKIPPED", color: colorYellow}
+			duration, memory = cell{text: "-"}, cell{text: "-"}
+		case !result.Failed():
+			verdict = cell{text: "✓ " + string(result.Status), color: colorGreen}
+		}
+
+		name := fmt.Sprintf("#%d", result.Serial)
+		if result.Label != "" {
+			name += " " + result.Label
+		}
+		t.addRow(cell{text: name}, verdict, duration, memory)
+	}
+	return t.String()
+}
+
+// scoreBar renders a score with a bar toward the full score, e.g. "60/100 ████████████░░░░░░░░"
+func scoreBar(score int) string {
+	color := colorYellow
+	if score >= entity.MaxScore {
+		color = colorGreen
+	}
+	return fmt.Sprintf("%d/%d %s", score, entity.MaxScore, paint(color, progressBar(score, entity.MaxScore, 20)))
+}
+
+// printScoreReport compares a score with the best earlier score of the problem
+func printScoreReport(report usecase.ScoreReport) {
+	switch {
+	case report.Earlier == 0:
+	case report.NewBest():
+		fmt.Println(paint(colorGreen, fmt.Sprintf("New best score for %s (previously %d)", report.ProblemID, report.PreviousBest)))
+	default:
+		fmt.Printf("Best score for %s so far: %d\n", report.ProblemID, report.Best())
+	}
+}
+
+// printGitCommit reports the commit of an accepted solution
+func printGitCommit(result usecase.GitCommitResult) {
+	switch {
+	case result.Err != nil:
+		fmt.Println(paint(colorYellow, "! Could not commit the solution: "+result.Err.Error()))
+	case result.Hash == "":
+		fmt.Println("Solution unchanged since the last commit")
+	case result.Tag != "":
+		fmt.Printf("Committed %s \"%s\" and tagged it %s\n", result.Hash, result.Message, result.Tag)
+	default:
+		fmt.Printf("Committed %s \"%s\"\n", result.Hash, result.Message)
+	}
 }
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// TagCommand represents the tag command
+type TagCommand struct {
+	tagUseCase *usecase.TagUseCase
+	logger     *logger.Logger
+}
+
+// NewTagCommand creates a new tag command
+func NewTagCommand(tagUseCase *usecase.TagUseCase) *TagCommand {
+	return &TagCommand{
+		tagUseCase: tagUseCase,
+		logger:     logger.WithGroup("tag_command"),
+	}
+}
+
+// Command returns the cobra command for tag
+func (c *TagCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Tag problems with topics such as dp or greedy",
+		Long: `Put your own tags on problems, e.g. the algorithm that solves them, and
+find problems by tag. Tags are kept locally and shown by aoj list.`,
+	}
+
+	cmd.AddCommand(c.addCommand(), c.removeCommand(), c.listCommand())
+
+	return cmd
+}
+
+// addCommand returns the cobra command for tag add
+func (c *TagCommand) addCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <problem-id> <tag>...",
+		Short: "Tag a problem",
+		Long: `Tag a problem. Tags are lowercased.
+
+Examples:
+  aoj tag add ITP1_7_B dp greedy`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			problem, err := c.tagUseCase.Add(ctx, args[0], args[1:])
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to tag problem", "problem_id", args[0], "error", err)
+				return fmt.Errorf("failed to tag %s: %w", args[0], err)
+			}
+			fmt.Printf("%s: %s\n", problem.ProblemID, strings.Join(problem.Tags, ", "))
+			return nil
+		},
+	}
+}
+
+// removeCommand returns the cobra command for tag remove
+func (c *TagCommand) removeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <problem-id> <tag>...",
+		Aliases: []string{"rm"},
+		Short:   "Remove tags from a problem",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			problem, err := c.tagUseCase.Remove(ctx, args[0], args[1:])
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to untag problem", "problem_id", args[0], "error", err)
+				return fmt.Errorf("failed to untag %s: %w", args[0], err)
+			}
+			if len(problem.Tags) == 0 {
+				fmt.Printf("%s has no tags left\n", problem.ProblemID)
+				return nil
+			}
+			fmt.Printf("%s: %s\n", problem.ProblemID, strings.Join(problem.Tags, ", "))
+			return nil
+		},
+	}
+}
+
+// listCommand returns the cobra command for tag list
+func (c *TagCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list [tag...]",
+		Short: "List tagged problems",
+		Long: `List the tagged problems, or only the ones having every given tag. Unlike
+aoj list, this includes problems not initialized in the workspace.
+
+Examples:
+  aoj tag list
+  aoj tag list dp`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			problems, err := c.tagUseCase.List(ctx, args)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to list tagged problems", "error", err)
+				return fmt.Errorf("failed to list tagged problems: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(problems)
+			}
+			if len(problems) == 0 && len(args) > 0 {
+				fmt.Printf("No problems are tagged %s\n", strings.Join(args, ", "))
+				return nil
+			}
+			if len(problems) == 0 {
+				fmt.Println("No problems are tagged. Tag one with 'aoj tag add <problem-id> <tag>...'")
+				return nil
+			}
+
+			t := &table{header: []string{"PROBLEM", "TAGS"}}
+			for _, problem := range problems {
+				t.addRow(cell{text: problem.ProblemID}, cell{text: strings.Join(problem.Tags, ", ")})
+			}
+			fmt.Print(t.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the problems as JSON")
+
+	return cmd
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// TemplateCommand represents the template command
+type TemplateCommand struct {
+	templateUseCase *usecase.TemplateUseCase
+	logger          *logger.Logger
+}
+
+// NewTemplateCommand creates a new template command
+func NewTemplateCommand(templateUseCase *usecase.TemplateUseCase) *TemplateCommand {
+	return &TemplateCommand{
+		templateUseCase: templateUseCase,
+		logger:          logger.WithGroup("template_command"),
+	}
+}
+
+// Command returns the cobra command for template
+func (c *TemplateCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage solution templates",
+		Long: `Manage the named solution templates used by 'aoj init'.
+
+Templates are stored under ~/.aoj-cli/templates. Each language can have a
+default template, which init uses unless --template is given. Languages
+without a default use the built-in template.`,
+	}
+
+	cmd.AddCommand(c.listCommand(), c.addCommand(), c.showCommand(), c.useCommand())
+
+	return cmd
+}
+
+// listCommand returns the cobra command for template list
+func (c *TemplateCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored templates",
+		Long: `List the stored templates. The default template of each language
+is marked with an asterisk.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.runList(cmd, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output templates as JSON")
+
+	return cmd
+}
+
+// runList executes the template list command
+func (c *TemplateCommand) runList(cmd *cobra.Command, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	templates, err := c.templateUseCase.List(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to list templates", "error", err)
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(templates)
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("No templates found. Run 'aoj template add <name> <file>' to add one.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "DEFAULT\tNAME\tLANGUAGE\tPATH")
+	for _, template := range templates {
+		current := ""
+		if template.Default {
+			current = "*"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, template.Name, template.Extension, template.Path)
+	}
+	return w.Flush()
+}
+
+// addCommand returns the cobra command for template add
+func (c *TemplateCommand) addCommand() *cobra.Command {
+	var (
+		force      bool
+		useDefault bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <file>",
+		Short: "Store a file as a named template",
+		Long: `Store a copy of a file as a named template. The template's language
+is taken from the file extension.
+
+Examples:
+  # Add a C++ template and make it the default for .cpp files
+  aoj template add fast-io ~/snippets/fast-io.cpp --use`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runAdd(cmd, args[0], args[1], force, useDefault)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Replace an existing template with the same name")
+	cmd.Flags().BoolVar(&useDefault, "use", false, "Also make it the default template of its language")
+
+	return cmd
+}
+
+// runAdd executes the template add command
+func (c *TemplateCommand) runAdd(cmd *cobra.Command, name, file string, force, useDefault bool) error {
+	ctx := cmd.Context()
+
+	template, err := c.templateUseCase.Add(ctx, name, file, force)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to add template", "name", name, "error", err)
+		return fmt.Errorf("failed to add template %s: %w", name, err)
+	}
+	fmt.Printf("Added template %s (.%s)\n", template.Name, template.Extension)
+
+	if useDefault {
+		return c.runUse(cmd, name)
+	}
+	return nil
+}
+
+// showCommand returns the cobra command for template show
+func (c *TemplateCommand) showCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <name>",
+		Short: "Print a template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			content, err := c.templateUseCase.Show(ctx, args[0])
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to show template", "name", args[0], "error", err)
+				return fmt.Errorf("failed to show template %s: %w", args[0], err)
+			}
+			fmt.Print(content)
+			return nil
+		},
+	}
+}
+
+// useCommand returns the cobra command for template use
+func (c *TemplateCommand) useCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a template the default of its language",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runUse(cmd, args[0])
+		},
+	}
+}
+
+// runUse executes the template use command
+func (c *TemplateCommand) runUse(cmd *cobra.Command, name string) error {
+	ctx := cmd.Context()
+
+	template, err := c.templateUseCase.Use(ctx, name)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to change the default template", "name", name, "error", err)
+		return fmt.Errorf("failed to use template %s: %w", name, err)
+	}
+
+	fmt.Printf("Using template %s for .%s files\n", template.Name, template.Extension)
+	return nil
+}
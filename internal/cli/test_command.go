@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// TestCommand represents the test command
+type TestCommand struct {
+	testUseCase *usecase.TestUseCase
+	logger      *logger.Logger
+}
+
+// NewTestCommand creates a new test command
+func NewTestCommand(testUseCase *usecase.TestUseCase) *TestCommand {
+	return &TestCommand{
+		testUseCase: testUseCase,
+		logger:      logger.WithGroup("test_command"),
+	}
+}
+
+// Command returns the cobra command for test
+func (c *TestCommand) Command() *cobra.Command {
+	var (
+		testCase     int
+		timeout      time.Duration
+		aggregate    bool
+		interactor   string
+		sanitize     string
+		reportPath   string
+		reportFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "test [file]",
+		Short: "Run a solution against the sample test cases",
+		Long: `Build the solution and run it against the sample test cases of the
+problem in the current directory.
+
+Many AOJ volume problems read several datasets from a single input that
+ends with a sentinel such as "0 0". With --aggregate, all samples are
+joined into one input stream (keeping the sentinel only at the end) and
+compared with the joined expected outputs.
+
+For interactive problems, the solution is connected through pipes to an
+interactor given with --interactor, interactor_command in problem.toml or
+test.interactor_command in the config. {input} and {output} in its command
+are replaced with files holding the test case, and its exit status decides
+the verdict. The dialogue is saved as <case>.transcript in the test directory.
+
+Examples:
+  # Test main.go against all samples
+  aoj test
+
+  # Test a specific file against the second sample
+  aoj test main.cpp --case 2
+
+  # Feed all samples as one multi-dataset input
+  aoj test --aggregate
+
+  # Build a C++ solution with AddressSanitizer and UBSan
+  aoj test --sanitize address,undefined
+
+  # Talk to an interactor for an interactive problem
+  aoj test --interactor "python3 judge.py {input}"
+
+  # Record the results for CI as JUnit XML or TAP
+  aoj test --report results/junit.xml
+  aoj test --report results.tap`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := usecase.TestOptions{
+				Case:       testCase,
+				Timeout:    timeout,
+				Aggregate:  aggregate,
+				Interactor: interactor,
+				Sanitize:   sanitize,
+			}
+			if len(args) == 1 {
+				opts.SourceFile = args[0]
+			}
+			return c.run(cmd, opts, reportPath, reportFormat)
+		},
+	}
+
+	cmd.Flags().IntVarP(&testCase, "case", "c", 0, "Run only the sample with this number")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 0, "Time limit per test case (default: problem time limit × test.time_limit_factor)")
+	cmd.Flags().BoolVar(&aggregate, "aggregate", false, "Run all samples as a single multi-dataset input")
+	cmd.Flags().StringVar(&sanitize, "sanitize", "", "Build C/C++ solutions with these sanitizers, e.g. address,undefined")
+	cmd.Flags().StringVar(&interactor, "interactor", "", "Interactor command for interactive problems (default: problem.toml, then test.interactor_command)")
+	cmd.Flags().StringVar(&reportPath, "report", "", "Also write the results to this file as JUnit XML (.xml) or TAP (.tap)")
+	cmd.Flags().StringVar(&reportFormat, "report-format", "", "Format of --report: junit or tap (default: from the file extension)")
+
+	return cmd
+}
+
+// run executes the test command, saving the results to reportPath if given
+func (c *TestCommand) run(cmd *cobra.Command, opts usecase.TestOptions, reportPath, reportFormat string) error {
+	ctx := cmd.Context()
+
+	if reportPath != "" {
+		// Fail before running the tests rather than after
+		if _, err := usecase.ResolveReportFormat(reportPath, reportFormat); err != nil {
+			return err
+		}
+	}
+
+	report, err := c.testUseCase.Execute(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "test failed", "error", err)
+		return fmt.Errorf("test failed: %w", err)
+	}
+	if reportPath != "" {
+		if err := c.testUseCase.WriteReport(reportPath, reportFormat, report); err != nil {
+			return fmt.Errorf("failed to write test report: %w", err)
+		}
+	}
+
+	if report.Verdict == usecase.VerdictCompileError {
+		fmt.Printf("%s\n%s\n", paint(colorRed, "✗ Compile error"), strings.TrimRight(report.BuildOutput, "\n"))
+		return fmt.Errorf("compile error")
+	}
+
+	printCompilerWarnings(report.BuildOutput)
+	if len(report.Corrupted) > 0 {
+		fmt.Fprintf(os.Stderr, "%s %s changed since download; run 'aoj testcase verify' to restore\n",
+			paintFor(os.Stderr, colorYellow, "warning:"), strings.Join(report.Corrupted, ", "))
+	}
+	fmt.Print(resultTable(report.Cases))
+
+	for _, result := range report.Cases {
+		if result.Passed() || result.Verdict == usecase.VerdictSkipped {
+			continue
+		}
+
+		fmt.Printf("\n%s %s\n", paint(colorRed, "✗ "+result.Verdict), result.Name)
+		if result.Verdict == usecase.VerdictWrongAnswer {
+			fmt.Printf("Expected:\n%s\n", strings.TrimRight(result.Expected, "\n"))
+			fmt.Printf("Actual:\n%s\n", strings.TrimRight(result.Actual, "\n"))
+		}
+		if result.Stderr != "" {
+			fmt.Printf("Stderr:\n%s\n", strings.TrimRight(result.Stderr, "\n"))
+		}
+		if result.Interactor != "" {
+			fmt.Printf("Interactor:\n%s\n", strings.TrimRight(result.Interactor, "\n"))
+		}
+		if result.Transcript != "" {
+			fmt.Printf("Transcript: %s\n", result.Transcript)
+		}
+	}
+
+	if len(report.TLEWarnings) > 0 {
+		fmt.Println()
+		for _, warning := range report.TLEWarnings {
+			fmt.Println(paint(colorYellow, tleWarningLine(warning, report.TimeLimit)))
+		}
+	}
+
+	fmt.Printf("\n%s\n", summaryLine(report))
+	if report.Interrupted {
+		fmt.Println(paint(colorYellow, fmt.Sprintf("! Interrupted after %d of %d cases", len(report.Cases), report.Total)))
+		return fmt.Errorf("test interrupted: %w", context.Canceled)
+	}
+	if report.Verdict != usecase.VerdictAccepted {
+		return fmt.Errorf("test failed: %s", report.Verdict)
+	}
+
+	return nil
+}
+
+// printCompilerWarnings shows the compiler output of a successful build, which
+// usually holds warnings worth fixing before submitting
+func printCompilerWarnings(output string) {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return
+	}
+	if warnings := usecase.CountCompilerWarnings(output); warnings > 0 {
+		fmt.Println(paint(colorYellow, fmt.Sprintf("! %d compiler warning(s)", warnings)))
+	} else {
+		fmt.Println(paint(colorYellow, "! Compiler output"))
+	}
+	fmt.Printf("%s\n\n", output)
+}
+
+// tleWarningLine describes a case that will likely exceed the time limit on the judge
+func tleWarningLine(warning usecase.TLEWarning, timeLimit time.Duration) string {
+	line := fmt.Sprintf("! Likely TLE on judge: %s took %s", warning.Case, formatMillis(warning.Local))
+	if warning.Estimated != warning.Local {
+		line += fmt.Sprintf(" (about %s on the judge)", formatMillis(warning.Estimated))
+	}
+	return line + fmt.Sprintf(", %.0f%% of the %s limit", 100*warning.Ratio, formatMillis(timeLimit))
+}
+
+// resultTable renders one row per test case with its verdict, running time
+// and peak memory
+func resultTable(cases []usecase.CaseResult) string {
+	t := &table{header: []string{"CASE", "RESULT", "TIME", "MEMORY"}}
+	for _, c := range cases {
+		if c.Description != "" {
+			t.header = append(t.header, "DESCRIPTION")
+			break
+		}
+	}
+
+	for _, c := range cases {
+		result := cell{text: "✗ " + c.Verdict, color: colorRed}
+		duration := cell{text: formatMillis(c.Duration)}
+		memory := cell{text: "-"}
+		switch {
+		case c.Verdict == usecase.VerdictSkipped:
+			result = cell{text: "- " + c.Verdict, color: colorYellow}
+			duration = cell{text: "-"}
+		case c.Passed():
+			result = cell{text: "✓ " + c.Verdict, color: colorGreen}
+		}
+		if c.MemoryKB > 0 {
+			memory = cell{text: usecase.FormatSize(c.MemoryKB * 1024)}
+		}
+
+		row := []cell{{text: c.Name}, result, duration, memory}
+		if len(t.header) > 4 {
+			row = append(row, cell{text: c.Description})
+		}
+		t.addRow(row...)
+	}
+	return t.String()
+}
+
+// summaryLine returns the verdict badge line, e.g. "✅ 5/6 passed, slowest 312ms"
+func summaryLine(report *usecase.TestReport) string {
+	summary := caseSummary(report)
+	if report.Verdict == usecase.VerdictAccepted {
+		return paint(colorGreen, "✅ "+summary)
+	}
+	return paint(colorRed, "❌ "+summary)
+}
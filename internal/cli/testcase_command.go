@@ -0,0 +1,376 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// TestCaseCommand represents the testcase command
+type TestCaseCommand struct {
+	testCaseUseCase *usecase.TestCaseUseCase
+	logger          *logger.Logger
+}
+
+// NewTestCaseCommand creates a new testcase command
+func NewTestCaseCommand(testCaseUseCase *usecase.TestCaseUseCase) *TestCaseCommand {
+	return &TestCaseCommand{
+		testCaseUseCase: testCaseUseCase,
+		logger:          logger.WithGroup("testcase_command"),
+	}
+}
+
+// Command returns the cobra command for testcase
+func (c *TestCaseCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "testcase",
+		Short: "Manage judge test cases",
+		Long: `Manage the judge test cases of a problem.
+
+Besides the samples, AOJ publishes most of the test cases its judge uses.
+Downloading them lets you reproduce a wrong answer locally.
+
+Local test cases can be annotated in test/meta.toml with a timeout that
+overrides the time limit, a skip flag and a description. Cases are given
+by number, as with 'aoj test --case', or by name such as sample-1.`,
+	}
+
+	cmd.AddCommand(c.pullCommand(), c.listCommand(), c.setCommand(), c.skipCommand(true), c.skipCommand(false), c.verifyCommand())
+
+	return cmd
+}
+
+// pullCommand returns the cobra command for testcase pull
+func (c *TestCaseCommand) pullCommand() *cobra.Command {
+	var (
+		opts usecase.PullOptions
+		yes  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pull [case...]",
+		Short: "Download judge test cases",
+		Long: `Download judge test cases into the test directory as judge-<n>.in and
+judge-<n>.out, where 'aoj test' runs them together with the samples.
+
+Give the case numbers to download or use --all. Downloads larger than
+10 MiB must be confirmed. Requests are rate limited to spare AOJ.
+
+Cases are streamed straight to disk, so even huge ones do not need to fit
+in memory. A file larger than 256 MiB is refused, and a file is only
+written once it has been received completely.`,
+		Example: `  aoj testcase pull 3
+  aoj testcase pull --all
+  aoj testcase pull --all -p ITP1_1_A -y`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, arg := range args {
+				serial, err := strconv.Atoi(arg)
+				if err != nil || serial <= 0 {
+					return fmt.Errorf("invalid test case number %q", arg)
+				}
+				opts.Cases = append(opts.Cases, serial)
+			}
+			return c.runPull(cmd, opts, yes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Download every judge test case")
+	cmd.Flags().StringVarP(&opts.ProblemID, "problem-id", "p", "", "Problem ID or alias (default: inferred from problem.toml or the directory names)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Download large test sets without asking")
+
+	return cmd
+}
+
+// runPull executes the testcase pull command
+func (c *TestCaseCommand) runPull(cmd *cobra.Command, opts usecase.PullOptions, yes bool) error {
+	ctx := cmd.Context()
+
+	opts.Confirm = func(count int, size int64) bool {
+		if yes {
+			return true
+		}
+		return confirm(fmt.Sprintf("Download %d test cases (%s)?", count, usecase.FormatSize(size)))
+	}
+	var cases, totalCases int
+	var received, totalSize int64
+	printed := false
+	render := func() {
+		fmt.Fprintf(os.Stderr, "\rDownloading test cases... %d/%d (%s / %s)",
+			cases, totalCases, usecase.FormatSize(received), usecase.FormatSize(totalSize))
+		printed = true
+	}
+	opts.Progress = func(done, total int) {
+		cases, totalCases = done, total
+		render()
+		if done == total {
+			fmt.Fprintln(os.Stderr)
+			printed = false
+		}
+	}
+	opts.BytesProgress = func(done, total int64) {
+		received, totalSize = done, total
+		render()
+	}
+
+	result, err := c.testCaseUseCase.Pull(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to download test cases", "error", err)
+		if printed {
+			fmt.Fprintln(os.Stderr)
+		}
+		if cerrors.IsAppError(err, cerrors.CodeNotFound) {
+			err = cerrors.WithHint(err, "Some problems, e.g. those with special judges, do not publish their test cases.")
+		}
+		return fmt.Errorf("failed to download test cases: %w", err)
+	}
+
+	fmt.Printf("%s %d test cases of %s (%s)\n", paint(colorGreen, "✓ Downloaded"), len(result.Files), result.ProblemID, usecase.FormatSize(result.Size))
+	fmt.Println("Run 'aoj test' to test your solution against them")
+	return nil
+}
+
+// listCommand returns the cobra command for testcase list
+func (c *TestCaseCommand) listCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List local test cases and their annotations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.runList(cmd, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output test cases as JSON")
+
+	return cmd
+}
+
+// runList executes the testcase list command
+func (c *TestCaseCommand) runList(cmd *cobra.Command, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	infos, err := c.testCaseUseCase.List(ctx, "")
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to list test cases", "error", err)
+		return fmt.Errorf("failed to list test cases: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(infos)
+	}
+
+	if len(infos) == 0 {
+		fmt.Println("No test cases found. Run 'aoj init' or 'aoj testcase pull' to download them.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "#\tNAME\tSIZE\tTIMEOUT\tSKIP\tDESCRIPTION")
+	for _, info := range infos {
+		timeout := "-"
+		if info.Timeout > 0 {
+			timeout = info.Timeout.String()
+		}
+		skip := ""
+		if info.Skip {
+			skip = "yes"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			info.Number, info.Name, usecase.FormatSize(info.InputSize), timeout, skip, info.Description)
+	}
+	return w.Flush()
+}
+
+// setCommand returns the cobra command for testcase set
+func (c *TestCaseCommand) setCommand() *cobra.Command {
+	var (
+		timeout     time.Duration
+		description string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set <case>...",
+		Short: "Set the timeout or description of test cases",
+		Long: `Set the timeout or description of test cases in test/meta.toml.
+A timeout of 0 or an empty description removes it.`,
+		Example: `  aoj testcase set 3 --timeout 10s
+  aoj testcase set judge-12 --description "n = 10^5, all equal"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var update usecase.CaseMetaUpdate
+			if cmd.Flags().Changed("timeout") {
+				update.Timeout = &timeout
+			}
+			if cmd.Flags().Changed("description") {
+				update.Description = &description
+			}
+			if update.Timeout == nil && update.Description == nil {
+				return fmt.Errorf("specify --timeout or --description")
+			}
+			return c.runAnnotate(cmd, args, update)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", 0, "Time limit for these cases, overriding the problem's")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Description shown by aoj test")
+
+	return cmd
+}
+
+// skipCommand returns the cobra command for testcase skip, or testcase unskip if skip is false
+func (c *TestCaseCommand) skipCommand(skip bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skip <case>...",
+		Short: "Exclude test cases from aoj test",
+		Long: `Exclude test cases from 'aoj test' runs. A skipped case still runs
+when selected with --case.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runAnnotate(cmd, args, usecase.CaseMetaUpdate{Skip: &skip})
+		},
+	}
+	if !skip {
+		cmd.Use = "unskip <case>..."
+		cmd.Short = "Include skipped test cases in aoj test again"
+		cmd.Long = ""
+	}
+
+	return cmd
+}
+
+// runAnnotate updates the annotations of test cases
+func (c *TestCaseCommand) runAnnotate(cmd *cobra.Command, refs []string, update usecase.CaseMetaUpdate) error {
+	ctx := cmd.Context()
+
+	infos, err := c.testCaseUseCase.Annotate(ctx, "", refs, update)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to update test cases", "error", err)
+		return fmt.Errorf("failed to update test cases: %w", err)
+	}
+
+	for _, info := range infos {
+		var notes []string
+		if info.Skip {
+			notes = append(notes, "skipped")
+		}
+		if info.Timeout > 0 {
+			notes = append(notes, "timeout "+info.Timeout.String())
+		}
+		if info.Description != "" {
+			notes = append(notes, strconv.Quote(info.Description))
+		}
+		if len(notes) == 0 {
+			notes = append(notes, "no annotations")
+		}
+		fmt.Printf("%s %s: %s\n", paint(colorGreen, "✓"), info.Name, strings.Join(notes, ", "))
+	}
+	return nil
+}
+
+// verifyCommand returns the cobra command for testcase verify
+func (c *TestCaseCommand) verifyCommand() *cobra.Command {
+	var (
+		opts       usecase.VerifyTestDataOptions
+		noRestore  bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check downloaded test data and repair damaged files",
+		Long: `Check the samples and judge test cases against the SHA-256 sums recorded
+in test/checksums.sha256 when they were downloaded. Files that were
+truncated, changed or deleted are downloaded again.
+
+Test cases you added yourself have no checksum and are not checked.`,
+		Example: `  aoj testcase verify
+  aoj testcase verify --no-restore`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.Restore = !noRestore
+			return c.runVerify(cmd, opts, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.ProblemID, "problem-id", "p", "", "Problem ID or alias (default: inferred from problem.toml or the directory names)")
+	cmd.Flags().BoolVar(&noRestore, "no-restore", false, "Only report damaged files without downloading them again")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as JSON")
+
+	return cmd
+}
+
+// runVerify executes the testcase verify command
+func (c *TestCaseCommand) runVerify(cmd *cobra.Command, opts usecase.VerifyTestDataOptions, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	result, err := c.testCaseUseCase.Verify(ctx, opts)
+	if result == nil {
+		c.logger.ErrorContext(ctx, "failed to verify test data", "error", err)
+		return fmt.Errorf("failed to verify test data: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(result); encodeErr != nil {
+			return encodeErr
+		}
+	} else {
+		for _, file := range result.Files {
+			switch file.Status {
+			case usecase.TestFileOK:
+				fmt.Printf("%s %s\n", paint(colorGreen, "✓"), file.Name)
+			case usecase.TestFileRestored:
+				fmt.Printf("%s %s: restored\n", paint(colorYellow, "↻"), file.Name)
+			default:
+				line := fmt.Sprintf("%s %s: %s", paint(colorRed, "✗"), file.Name, file.Status)
+				if file.Error != "" {
+					line += " (" + file.Error + ")"
+				}
+				fmt.Println(line)
+			}
+		}
+	}
+
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to restore test data", "error", err)
+		return fmt.Errorf("failed to restore test data: %w", err)
+	}
+	if damaged := result.DamagedCount(); damaged > 0 {
+		return fmt.Errorf("%d test files are damaged", damaged)
+	}
+	return nil
+}
+
+// confirm asks a yes/no question on the terminal. Without a terminal the
+// answer is no, so scripts must opt in explicitly
+func confirm(question string) bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprintf(os.Stderr, "%s Use --yes to confirm.\n", question)
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
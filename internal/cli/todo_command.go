@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// TodoCommand represents the todo command
+type TodoCommand struct {
+	todoUseCase       *usecase.TodoUseCase
+	initUseCase       *usecase.InitUseCase
+	difficultyUseCase *usecase.DifficultyUseCase
+	clock             clock.Clock // tells which items are due
+	logger            *logger.Logger
+}
+
+// NewTodoCommand creates a new todo command. A nil clk means the system clock
+func NewTodoCommand(todoUseCase *usecase.TodoUseCase, initUseCase *usecase.InitUseCase, difficultyUseCase *usecase.DifficultyUseCase, clk clock.Clock) *TodoCommand {
+	if clk == nil {
+		clk = clock.System()
+	}
+	return &TodoCommand{
+		todoUseCase:       todoUseCase,
+		initUseCase:       initUseCase,
+		difficultyUseCase: difficultyUseCase,
+		clock:             clk,
+		logger:            logger.WithGroup("todo_command"),
+	}
+}
+
+// Command returns the cobra command for todo
+func (c *TodoCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "todo",
+		Short: "Manage the list of problems to practice",
+		Long: `Keep a list of problems to solve or review later.
+
+An accepted submission of a problem on the list marks it done automatically.`,
+	}
+
+	cmd.AddCommand(c.addCommand(), c.listCommand(), c.doneCommand(), c.nextCommand())
+
+	return cmd
+}
+
+// addCommand returns the cobra command for todo add
+func (c *TodoCommand) addCommand() *cobra.Command {
+	var (
+		opts  usecase.TodoAddOptions
+		delay string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <problem-id>",
+		Short: "Add a problem to the list",
+		Long: `Add a problem to the list. --in postpones it, e.g. to revisit a problem
+after a few days.
+
+Examples:
+  aoj todo add ALDS1_1_D --note "try the O(n) solution"
+  aoj todo add ITP1_7_B --in 3d`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if delay != "" {
+				d, err := usecase.ParseInterval(delay)
+				if err != nil {
+					return err
+				}
+				opts.Delay = d
+			}
+
+			item, err := c.todoUseCase.Add(ctx, args[0], opts)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to add TODO", "problem_id", args[0], "error", err)
+				return fmt.Errorf("failed to add %s: %w", args[0], err)
+			}
+			if item.Due != nil {
+				fmt.Printf("Added %s, due %s\n", item.ProblemID, item.Due.Format("2006-01-02 15:04"))
+			} else {
+				fmt.Printf("Added %s\n", item.ProblemID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Note, "note", "", "Reminder shown in the list")
+	cmd.Flags().StringVar(&delay, "in", "", "Postpone the problem, e.g. 3d, 2w or 12h")
+
+	return cmd
+}
+
+// listCommand returns the cobra command for todo list
+func (c *TodoCommand) listCommand() *cobra.Command {
+	var (
+		all        bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show the problems on the list",
+		Long:  "Show the pending problems, the ones due first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			items, err := c.todoUseCase.List(ctx, all)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to list TODOs", "error", err)
+				return fmt.Errorf("failed to read the TODO list: %w", err)
+			}
+
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(items)
+			}
+			if len(items) == 0 {
+				fmt.Println("The TODO list is empty")
+				return nil
+			}
+			printTodos(items, c.clock.Now())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include done problems")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the list as JSON")
+
+	return cmd
+}
+
+// doneCommand returns the cobra command for todo done
+func (c *TodoCommand) doneCommand() *cobra.Command {
+	var again string
+
+	cmd := &cobra.Command{
+		Use:   "done <problem-id>",
+		Short: "Mark a problem as done",
+		Long: `Mark a problem as done. With --again the problem stays on the list and
+comes back for review after the interval.
+
+Examples:
+  aoj todo done ALDS1_1_D
+  aoj todo done ALDS1_1_D --again 7d`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			var interval time.Duration
+			if again != "" {
+				d, err := usecase.ParseInterval(again)
+				if err != nil {
+					return err
+				}
+				interval = d
+			}
+
+			item, err := c.todoUseCase.Done(ctx, args[0], interval)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "failed to complete TODO", "problem_id", args[0], "error", err)
+				return fmt.Errorf("failed to mark %s done: %w", args[0], err)
+			}
+			if item.IsDone() {
+				fmt.Printf("Marked %s done\n", item.ProblemID)
+			} else {
+				fmt.Printf("Marked %s done, review due %s\n", item.ProblemID, item.Due.Format("2006-01-02 15:04"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&again, "again", "", "Review the problem again after this interval, e.g. 7d")
+
+	return cmd
+}
+
+// nextCommand returns the cobra command for todo next
+func (c *TodoCommand) nextCommand() *cobra.Command {
+	var initOpts usecase.InitOptions
+
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Initialize the next problem on the list",
+		Long:  "Initialize the first due problem on the list, like 'aoj init'",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			item, err := c.todoUseCase.Next(ctx)
+			if err != nil {
+				return err
+			}
+			line := paint(colorGreen, item.ProblemID)
+			if difficulty, ok := c.difficultyUseCase.Lookup(ctx)[item.ProblemID]; ok {
+				line += " (difficulty " + usecase.FormatDifficulty(difficulty) + ")"
+			}
+			if item.Note != "" {
+				line += ": " + item.Note
+			}
+			fmt.Println(line)
+
+			if err := c.initUseCase.ExecuteWithOptions(ctx, item.ProblemID, initOpts); err != nil {
+				c.logger.ErrorContext(ctx, "failed to initialize problem", "problem_id", item.ProblemID, "error", err)
+				return fmt.Errorf("failed to initialize problem %s: %w", item.ProblemID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Initialized %s in %s\n", item.ProblemID, c.initUseCase.ProblemDir(item.ProblemID))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&initOpts.Language, "lang", "l", "", "Solution language (default: init.language from config)")
+
+	return cmd
+}
+
+// printTodos prints the items with when each one is due
+func printTodos(items []repository.TodoItem, now time.Time) {
+	t := &table{header: []string{"PROBLEM", "DUE", "REVIEWS", "NOTE"}}
+	for _, item := range items {
+		due := cell{text: "now", color: colorGreen}
+		switch {
+		case item.IsDone():
+			due = cell{text: "done " + item.DoneAt.Format("2006-01-02")}
+		case !item.IsDueAt(now):
+			due = cell{text: item.Due.Format("2006-01-02 15:04"), color: colorYellow}
+		}
+		t.addRow(
+			cell{text: item.ProblemID},
+			due,
+			cell{text: strconv.Itoa(item.Reviews)},
+			cell{text: item.Note},
+		)
+	}
+	fmt.Print(t.String())
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// VerifyCommand represents the verify command
+type VerifyCommand struct {
+	testUseCase *usecase.TestUseCase
+	logger      *logger.Logger
+}
+
+// NewVerifyCommand creates a new verify command
+func NewVerifyCommand(testUseCase *usecase.TestUseCase) *VerifyCommand {
+	return &VerifyCommand{
+		testUseCase: testUseCase,
+		logger:      logger.WithGroup("verify_command"),
+	}
+}
+
+// Command returns the cobra command for verify
+func (c *VerifyCommand) Command() *cobra.Command {
+	var (
+		jsonOutput   bool
+		reportPath   string
+		reportFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify [pattern...]",
+		Short: "Test every problem of a solutions repository",
+		Long: `Find the problem directories with sample test cases, build each solution
+and run it against its samples, then print an aggregated report. The command
+fails if any problem fails, so it can verify a solutions repository in CI.
+
+Patterns work like Go package patterns: "dir" is a single problem directory
+and "dir/..." also searches its subdirectories. Hidden directories such as
+.git are skipped. When run in GitHub Actions, failures are also reported as
+annotations on the solution files.
+
+Examples:
+  # Verify every problem below the current directory
+  aoj verify ./...
+
+  # Verify two volumes and record the results as JUnit XML
+  aoj verify ITP1/... ALDS1/... --report verify.xml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args, jsonOutput, reportPath, reportFormat)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+	cmd.Flags().StringVar(&reportPath, "report", "", "Also write the results to this file as JUnit XML (.xml) or TAP (.tap)")
+	cmd.Flags().StringVar(&reportFormat, "report-format", "", "Format of --report: junit or tap (default: from the file extension)")
+
+	return cmd
+}
+
+// run executes the verify command
+func (c *VerifyCommand) run(cmd *cobra.Command, patterns []string, jsonOutput bool, reportPath, reportFormat string) error {
+	ctx := cmd.Context()
+
+	if reportPath != "" {
+		if _, err := usecase.ResolveReportFormat(reportPath, reportFormat); err != nil {
+			return err
+		}
+	}
+
+	opts := usecase.VerifyOptions{Patterns: patterns}
+	if !jsonOutput {
+		opts.Progress = printVerifyResult
+	}
+	report, err := c.testUseCase.Verify(ctx, opts)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "verify failed", "error", err)
+		return fmt.Errorf("verify failed: %w", err)
+	}
+
+	if reportPath != "" {
+		if err := c.testUseCase.WriteReport(reportPath, reportFormat, report.Reports()...); err != nil {
+			return fmt.Errorf("failed to write verify report: %w", err)
+		}
+	}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		for _, result := range report.Results {
+			printGitHubAnnotation(result)
+		}
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		summary := fmt.Sprintf("%d/%d problems passed in %.1fs", report.PassedCount(), len(report.Results), report.Duration.Seconds())
+		if report.PassedCount() == len(report.Results) {
+			fmt.Printf("\n%s\n", paint(colorGreen, "✅ "+summary))
+		} else {
+			fmt.Printf("\n%s\n", paint(colorRed, "❌ "+summary))
+		}
+	}
+
+	if report.Interrupted {
+		return fmt.Errorf("verify interrupted: %w", context.Canceled)
+	}
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("verify failed: %w", err)
+	}
+	return nil
+}
+
+// printVerifyResult prints one line per verified problem as soon as it finishes
+func printVerifyResult(result usecase.VerifyResult) {
+	report := result.Report
+	switch report.Verdict {
+	case usecase.VerdictAccepted:
+		fmt.Printf("%s %s (%s)\n", paint(colorGreen, "✓"), result.Dir, caseSummary(report))
+	case usecase.VerdictCompileError:
+		fmt.Printf("%s %s compile error\n", paint(colorRed, "✗"), result.Dir)
+	case usecase.VerdictError:
+		fmt.Printf("%s %s %s\n", paint(colorRed, "✗"), result.Dir, report.Error)
+	default:
+		fmt.Printf("%s %s %s (%s)\n", paint(colorRed, "✗"), result.Dir, report.Verdict, caseSummary(report))
+	}
+}
+
+// caseSummary describes the test cases of a problem, e.g. "2/3 passed, slowest 12ms"
+func caseSummary(report *usecase.TestReport) string {
+	skipped := report.SkippedCount()
+	summary := fmt.Sprintf("%d/%d passed", report.PassedCount(), report.Total-skipped)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	return summary + ", slowest " + formatMillis(report.Slowest())
+}
+
+// printGitHubAnnotation reports a failed problem as a GitHub Actions error
+// annotation on its solution file
+func printGitHubAnnotation(result usecase.VerifyResult) {
+	if result.Passed() {
+		return
+	}
+
+	report := result.Report
+	var message string
+	switch report.Verdict {
+	case usecase.VerdictCompileError:
+		message = "compile error\n" + report.BuildOutput
+	case usecase.VerdictError:
+		message = report.Error
+	default:
+		var failed []string
+		for _, c := range report.Cases {
+			if !c.Passed() && c.Verdict != usecase.VerdictSkipped {
+				failed = append(failed, c.Name+": "+c.Verdict)
+			}
+		}
+		message = strings.Join(failed, "\n")
+	}
+
+	properties := "title=" + escapeAnnotationProperty("aoj verify "+report.Problem)
+	if report.SourceFile != "" {
+		properties = "file=" + escapeAnnotationProperty(filepath.ToSlash(filepath.Join(result.Dir, report.SourceFile))) + "," + properties
+	}
+	fmt.Printf("::error %s::%s\n", properties, escapeAnnotationData(message))
+}
+
+// escapeAnnotationData escapes the message of a GitHub Actions workflow command
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(strings.TrimRight(s, "\n"))
+}
+
+// escapeAnnotationProperty escapes a property value of a GitHub Actions workflow command
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,12 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeAnnotation(t *testing.T) {
+	assert.Equal(t, "sample-1: WA%0Asample-2: 100%25 TLE", escapeAnnotationData("sample-1: WA\nsample-2: 100% TLE\n"))
+	assert.Equal(t, "aoj verify ITP1_1_A%3A main%2C c", escapeAnnotationProperty("aoj verify ITP1_1_A: main, c"))
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// WorkspaceCommand represents the workspace and list commands
+type WorkspaceCommand struct {
+	workspaceUseCase *usecase.WorkspaceUseCase
+	logger           *logger.Logger
+}
+
+// NewWorkspaceCommand creates a new workspace command
+func NewWorkspaceCommand(workspaceUseCase *usecase.WorkspaceUseCase) *WorkspaceCommand {
+	return &WorkspaceCommand{
+		workspaceUseCase: workspaceUseCase,
+		logger:           logger.WithGroup("workspace_command"),
+	}
+}
+
+// Command returns the cobra command for workspace
+func (c *WorkspaceCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Show or set the workspace root",
+		Long: `Show the workspace root, the directory where 'aoj init' creates
+problem directories regardless of the current directory.
+
+Without a configured root, problems are created in the current directory.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			fmt.Println(c.workspaceUseCase.Root())
+			return nil
+		},
+	}
+
+	cmd.AddCommand(c.setCommand(), c.unsetCommand())
+
+	return cmd
+}
+
+// ListCommand returns the cobra command for list
+func (c *WorkspaceCommand) ListCommand() *cobra.Command {
+	var (
+		tags       []string
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List problems initialized in the workspace",
+		Long: `List the problem directories in the workspace root together with the
+verdict of their latest submission, their estimated difficulty, the first
+line of their notes and their tags.
+
+Examples:
+  aoj list
+  aoj list --tag dp --tag greedy`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.runList(cmd, tags, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only list problems with this tag; repeat to require several")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output problems as JSON")
+
+	return cmd
+}
+
+// setCommand returns the cobra command for workspace set
+func (c *WorkspaceCommand) setCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <dir>",
+		Short: "Set the workspace root",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := filepath.Abs(config.ExpandHome(args[0]))
+			if err != nil {
+				return fmt.Errorf("failed to resolve workspace root: %w", err)
+			}
+			if err := c.saveRoot(cmd, root); err != nil {
+				return err
+			}
+			fmt.Printf("Workspace root set to %s\n", root)
+			return nil
+		},
+	}
+}
+
+// unsetCommand returns the cobra command for workspace unset
+func (c *WorkspaceCommand) unsetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset",
+		Short: "Create problems in the current directory again",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := c.saveRoot(cmd, ""); err != nil {
+				return err
+			}
+			fmt.Println("Workspace root cleared")
+			return nil
+		},
+	}
+}
+
+// saveRoot stores the workspace root in the configuration file
+func (c *WorkspaceCommand) saveRoot(cmd *cobra.Command, root string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.Workspace.Root = root
+	if err := config.SaveDefault(cfg); err != nil {
+		c.logger.ErrorContext(ctx, "failed to save workspace root", "error", err)
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "workspace root saved", "root", root)
+	return nil
+}
+
+// runList executes the list command
+func (c *WorkspaceCommand) runList(cmd *cobra.Command, tags []string, jsonOutput bool) error {
+	ctx := cmd.Context()
+
+	problems, err := c.workspaceUseCase.List(ctx, tags...)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to list problems", "error", err)
+		return fmt.Errorf("failed to list problems: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(problems)
+	}
+
+	if len(problems) == 0 && len(tags) > 0 {
+		fmt.Printf("No problems in %s are tagged %s\n", c.workspaceUseCase.Root(), strings.Join(tags, ", "))
+		return nil
+	}
+	if len(problems) == 0 {
+		fmt.Printf("No problems found in %s. Run 'aoj init <problem-id>' to add one.\n", c.workspaceUseCase.Root())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PROBLEM\tLOCAL\tREMOTE\tSUBMITTED\tDIFFICULTY\tDIR\tTAGS\tNOTES")
+	for _, problem := range problems {
+		local, verdict, submitted, difficulty, tags, notes := "-", "-", "-", "-", "-", "-"
+		if problem.LocalVerdict != "" {
+			local = problem.LocalVerdict
+		}
+		if problem.RemoteVerdict != "" {
+			verdict = problem.RemoteVerdict
+		}
+		if problem.SubmittedAt != nil {
+			submitted = problem.SubmittedAt.Local().Format(time.DateTime)
+		}
+		if problem.Difficulty != nil {
+			difficulty = usecase.FormatDifficulty(*problem.Difficulty)
+		}
+		if len(problem.Tags) > 0 {
+			tags = strings.Join(problem.Tags, ", ")
+		}
+		if problem.Notes != "" {
+			notes = problem.Notes
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			problem.ProblemID,
+			local,
+			verdict,
+			submitted,
+			difficulty,
+			problem.Dir,
+			tags,
+			notes)
+	}
+	return w.Flush()
+}
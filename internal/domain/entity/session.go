@@ -1,9 +1,11 @@
 package entity
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
 )
 
 // Session represents an AOJ login session
@@ -22,7 +24,15 @@ func NewSession(
 	username, token string,
 	expiresAt time.Time,
 ) *Session {
-	now := time.Now()
+	return NewSessionAt(id, username, token, expiresAt, time.Now())
+}
+
+// NewSessionAt creates a new Session created and last used at now
+func NewSessionAt(
+	id model.SessionID,
+	username, token string,
+	expiresAt, now time.Time,
+) *Session {
 	return &Session{
 		id:        id,
 		username:  username,
@@ -40,13 +50,22 @@ func NewSessionWithDuration(
 	duration time.Duration,
 ) *Session {
 	now := time.Now()
+	return NewSessionAt(id, username, token, now.Add(duration), now)
+}
+
+// RestoreSession recreates a Session from persisted data, keeping all timestamps
+func RestoreSession(
+	id model.SessionID,
+	username, token string,
+	expiresAt, createdAt, lastUsed time.Time,
+) *Session {
 	return &Session{
 		id:        id,
 		username:  username,
 		token:     token,
-		expiresAt: now.Add(duration),
-		createdAt: now,
-		lastUsed:  now,
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+		lastUsed:  lastUsed,
 	}
 }
 
@@ -82,15 +101,20 @@ func (s *Session) LastUsed() time.Time {
 
 // IsValid returns true if the session is valid
 func (s *Session) IsValid() bool {
+	return s.IsValidAt(time.Now())
+}
+
+// IsValidAt returns true if the session is valid at the given time
+func (s *Session) IsValidAt(t time.Time) bool {
 	return s.id.IsValid() &&
 		s.username != "" &&
 		s.token != "" &&
-		!s.IsExpired()
+		!s.IsExpiredAt(t)
 }
 
 // IsExpired returns true if the session has expired
 func (s *Session) IsExpired() bool {
-	return time.Now().After(s.expiresAt)
+	return s.IsExpiredAt(time.Now())
 }
 
 // IsExpiredAt returns true if the session is expired at the given time
@@ -114,7 +138,12 @@ func (s *Session) RemainingTime() time.Duration {
 
 // Age returns the age of the session
 func (s *Session) Age() time.Duration {
-	return time.Since(s.createdAt)
+	return s.AgeAt(time.Now())
+}
+
+// AgeAt returns the age of the session at the given time
+func (s *Session) AgeAt(t time.Time) time.Duration {
+	return t.Sub(s.createdAt)
 }
 
 // TimeSinceLastUse returns the time since the session was last used
@@ -124,7 +153,7 @@ func (s *Session) TimeSinceLastUse() time.Duration {
 
 // UpdateLastUsed updates the last used time to now
 func (s *Session) UpdateLastUsed() {
-	s.lastUsed = time.Now()
+	s.UpdateLastUsedAt(time.Now())
 }
 
 // UpdateLastUsedAt updates the last used time to the specified time
@@ -167,18 +196,29 @@ func (s *Session) Clone() *Session {
 	}
 }
 
-// ToMap converts the session to a map for serialization
+// ToMap converts the session to a map for display and logging. The ID and
+// token are masked; sessions are stored by the repositories, not through maps
 func (s *Session) ToMap() map[string]interface{} {
 	return map[string]interface{}{
-		"id":         s.id.String(),
+		"id":         s.id.MaskedString(),
 		"username":   s.username,
-		"token":      s.token,
+		"token":      redact.Mask(s.token),
 		"expires_at": s.expiresAt.Unix(),
 		"created_at": s.createdAt.Unix(),
 		"last_used":  s.lastUsed.Unix(),
 	}
 }
 
+// LogValue logs the session without its token, so that logging a session
+// cannot leak it
+func (s *Session) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", s.id.MaskedString()),
+		slog.String("username", s.username),
+		slog.Time("expires_at", s.expiresAt),
+	)
+}
+
 // FromMap creates a session from a map
 func FromMap(data map[string]interface{}) (*Session, error) {
 	id, err := model.NewSessionID(data["id"].(string))
@@ -24,6 +24,9 @@ const (
 	StatusInternalError SubmissionStatus = "INTERNAL_ERROR"
 )
 
+// MaxScore is the score of a submission that passes every test case
+const MaxScore = 100
+
 // IsSuccess returns true if the status indicates success
 func (s SubmissionStatus) IsSuccess() bool {
 	return s == StatusAccepted
@@ -39,6 +42,25 @@ func (s SubmissionStatus) IsFinal() bool {
 	return s != StatusPending && s != StatusJudging
 }
 
+// CaseResult is the judge result of a submission on one test case
+type CaseResult struct {
+	Serial int
+	Label  string           // name of the test case, e.g. "testcase_01"
+	Status SubmissionStatus // empty if the case was skipped after an earlier failure
+	Time   time.Duration
+	Memory int64 // in KB
+}
+
+// Skipped returns true if the judge did not run the case
+func (c CaseResult) Skipped() bool {
+	return c.Status == ""
+}
+
+// Failed returns true if the submission failed the case
+func (c CaseResult) Failed() bool {
+	return !c.Skipped() && c.Status.IsError()
+}
+
 // Submission represents a code submission to AOJ
 type Submission struct {
 	id         model.SubmissionID
@@ -52,13 +74,27 @@ type Submission struct {
 	message    string
 	submittedAt time.Time
 	judgedAt   *time.Time
+	judgeID      string // submission ID assigned by AOJ
+	compileError string
+	failedCase   int // serial of the first judge test case that failed, 0 if unknown
+	caseResults  []CaseResult
 }
 
-// NewSubmission creates a new Submission instance
+// NewSubmission creates a new Submission instance submitted now
 func NewSubmission(
 	id model.SubmissionID,
 	problemID model.ProblemID,
 	language, sourceCode string,
+) *Submission {
+	return NewSubmissionAt(id, problemID, language, sourceCode, time.Now())
+}
+
+// NewSubmissionAt creates a new Submission instance submitted at the given time
+func NewSubmissionAt(
+	id model.SubmissionID,
+	problemID model.ProblemID,
+	language, sourceCode string,
+	submittedAt time.Time,
 ) *Submission {
 	return &Submission{
 		id:         id,
@@ -70,11 +106,39 @@ func NewSubmission(
 		time:       0,
 		memory:     0,
 		message:    "",
-		submittedAt: time.Now(),
+		submittedAt: submittedAt,
 		judgedAt:   nil,
 	}
 }
 
+// RestoreSubmission rebuilds a Submission from persisted data
+func RestoreSubmission(
+	id model.SubmissionID,
+	problemID model.ProblemID,
+	language, sourceCode string,
+	status SubmissionStatus,
+	score int,
+	execTime time.Duration,
+	memory int64,
+	message string,
+	submittedAt time.Time,
+	judgedAt *time.Time,
+) *Submission {
+	return &Submission{
+		id:          id,
+		problemID:   problemID,
+		language:    language,
+		sourceCode:  sourceCode,
+		status:      status,
+		score:       score,
+		time:        execTime,
+		memory:      memory,
+		message:     message,
+		submittedAt: submittedAt,
+		judgedAt:    judgedAt,
+	}
+}
+
 // ID returns the submission ID
 func (s *Submission) ID() model.SubmissionID {
 	return s.id
@@ -134,6 +198,53 @@ func (s *Submission) JudgedAt() *time.Time {
 	return &judgedTime
 }
 
+// JudgeID returns the submission ID assigned by AOJ (empty if not submitted yet)
+func (s *Submission) JudgeID() string {
+	return s.judgeID
+}
+
+// SetJudgeID sets the submission ID assigned by AOJ
+func (s *Submission) SetJudgeID(judgeID string) {
+	s.judgeID = judgeID
+}
+
+// CompileError returns the compiler message of a compile error
+func (s *Submission) CompileError() string {
+	return s.compileError
+}
+
+// SetCompileError sets the compiler message of a compile error
+func (s *Submission) SetCompileError(message string) {
+	s.compileError = message
+}
+
+// FailedCase returns the serial of the first judge test case that failed, or 0 if unknown
+func (s *Submission) FailedCase() int {
+	return s.failedCase
+}
+
+// SetFailedCase sets the serial of the first judge test case that failed
+func (s *Submission) SetFailedCase(serial int) {
+	s.failedCase = serial
+}
+
+// CaseResults returns the judge results per test case, empty if unknown
+func (s *Submission) CaseResults() []CaseResult {
+	return s.caseResults
+}
+
+// SetCaseResults sets the judge results per test case and the first failed case
+func (s *Submission) SetCaseResults(results []CaseResult) {
+	s.caseResults = results
+	s.failedCase = 0
+	for _, result := range results {
+		if result.Failed() {
+			s.failedCase = result.Serial
+			break
+		}
+	}
+}
+
 // UpdateStatus updates the submission status
 func (s *Submission) UpdateStatus(status SubmissionStatus) {
 	s.status = status
@@ -214,6 +325,10 @@ func (s *Submission) Clone() *Submission {
 		message:    s.message,
 		submittedAt: s.submittedAt,
 		judgedAt:   nil,
+		judgeID:      s.judgeID,
+		compileError: s.compileError,
+		failedCase:   s.failedCase,
+		caseResults:  append([]CaseResult(nil), s.caseResults...),
 	}
 	
 	if s.judgedAt != nil {
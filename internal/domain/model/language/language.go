@@ -0,0 +1,124 @@
+// Package language is the catalogue of the programming languages AOJ judges:
+// their canonical AOJ IDs, source file extensions and display names.
+package language
+
+import "strings"
+
+// Language is a programming language accepted by AOJ
+type Language struct {
+	ID          string   // canonical AOJ language ID, e.g. "C++17"
+	DisplayName string   // human readable name, e.g. "C++ 17"
+	Extensions  []string // source file extensions without the dot, the usual one first
+	Aliases     []string // other names accepted for the language, e.g. "cpp17"
+}
+
+// Extension returns the usual source file extension of the language
+func (l Language) Extension() string {
+	return l.Extensions[0]
+}
+
+// Family returns the language without its version, e.g. "c++" for C++17
+func (l Language) Family() string {
+	return Family(l.ID)
+}
+
+// catalogue lists the known languages. Where several languages share an
+// extension, the first one is the default for source files with it
+var catalogue = []Language{
+	{ID: "C", DisplayName: "C", Extensions: []string{"c"}},
+	{ID: "C++17", DisplayName: "C++ 17", Extensions: []string{"cpp", "cc", "cxx", "c++"}, Aliases: []string{"C++", "cpp", "cpp17"}},
+	{ID: "C++23", DisplayName: "C++ 23", Extensions: []string{"cpp", "cc", "cxx", "c++"}, Aliases: []string{"cpp23"}},
+	{ID: "C++14", DisplayName: "C++ 14", Extensions: []string{"cpp", "cc", "cxx", "c++"}, Aliases: []string{"cpp14"}},
+	{ID: "C++11", DisplayName: "C++ 11", Extensions: []string{"cpp", "cc", "cxx", "c++"}, Aliases: []string{"cpp11"}},
+	{ID: "JAVA", DisplayName: "Java", Extensions: []string{"java"}},
+	{ID: "Python3", DisplayName: "Python 3", Extensions: []string{"py"}, Aliases: []string{"Python"}},
+	{ID: "PyPy3", DisplayName: "PyPy 3", Extensions: []string{"py"}, Aliases: []string{"pypy"}},
+	{ID: "Ruby", DisplayName: "Ruby", Extensions: []string{"rb"}},
+	{ID: "Go", DisplayName: "Go", Extensions: []string{"go"}, Aliases: []string{"golang"}},
+	{ID: "JavaScript", DisplayName: "JavaScript", Extensions: []string{"js"}},
+	{ID: "C#", DisplayName: "C#", Extensions: []string{"cs"}, Aliases: []string{"csharp"}},
+	{ID: "PHP", DisplayName: "PHP", Extensions: []string{"php"}},
+	{ID: "D", DisplayName: "D", Extensions: []string{"d"}},
+	{ID: "Rust", DisplayName: "Rust", Extensions: []string{"rs"}},
+	{ID: "Kotlin", DisplayName: "Kotlin", Extensions: []string{"kt"}},
+	{ID: "Scala", DisplayName: "Scala", Extensions: []string{"scala"}},
+	{ID: "Haskell", DisplayName: "Haskell", Extensions: []string{"hs"}},
+	{ID: "OCaml", DisplayName: "OCaml", Extensions: []string{"ml"}},
+}
+
+// All returns every known language, defaults for shared extensions first
+func All() []Language {
+	return append([]Language(nil), catalogue...)
+}
+
+// Lookup finds a language by its ID or one of its aliases, ignoring case
+func Lookup(name string) (Language, bool) {
+	name = strings.TrimSpace(name)
+	for _, lang := range catalogue {
+		if strings.EqualFold(lang.ID, name) {
+			return lang, true
+		}
+	}
+	for _, lang := range catalogue {
+		for _, alias := range lang.Aliases {
+			if strings.EqualFold(alias, name) {
+				return lang, true
+			}
+		}
+	}
+	return Language{}, false
+}
+
+// MustLookup is like Lookup but panics for unknown languages. It is meant for
+// IDs fixed in the code
+func MustLookup(name string) Language {
+	lang, ok := Lookup(name)
+	if !ok {
+		panic("unknown language " + name)
+	}
+	return lang
+}
+
+// ByExtension returns the default language of source files with the given
+// extension, with or without the dot and ignoring case
+func ByExtension(ext string) (Language, bool) {
+	ext = strings.ToLower(strings.TrimPrefix(ext, "."))
+	for _, lang := range catalogue {
+		for _, candidate := range lang.Extensions {
+			if candidate == ext {
+				return lang, true
+			}
+		}
+	}
+	return Language{}, false
+}
+
+// Normalize returns the canonical AOJ ID of a language name, or the name
+// unchanged if the language is unknown
+func Normalize(name string) string {
+	if lang, ok := Lookup(name); ok {
+		return lang.ID
+	}
+	return name
+}
+
+// Family strips the version from a language name, e.g. "C++17" becomes "c++"
+// and "Python3" becomes "python"
+func Family(name string) string {
+	return strings.TrimRight(strings.ToLower(name), "0123456789")
+}
+
+// ExtensionOf returns the usual source file extension of a language name,
+// falling back to another version of the same language, e.g. "cpp" for C++20
+func ExtensionOf(name string) (string, bool) {
+	if lang, ok := Lookup(name); ok {
+		return lang.Extension(), true
+	}
+	family := Family(name)
+	for _, lang := range catalogue {
+		if lang.Family() == family {
+			return lang.Extension(), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,112 @@
+package language_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
+)
+
+func TestByExtension(t *testing.T) {
+	tests := []struct {
+		ext  string
+		want string
+	}{
+		{ext: ".cpp", want: "C++17"},
+		{ext: "cc", want: "C++17"},
+		{ext: ".C++", want: "C++17"},
+		{ext: ".py", want: "Python3"},
+		{ext: ".java", want: "JAVA"},
+		{ext: ".rs", want: "Rust"},
+		{ext: ".hs", want: "Haskell"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ext, func(t *testing.T) {
+			// When
+			lang, ok := language.ByExtension(tt.ext)
+
+			// Then
+			if !ok {
+				t.Fatalf("no language for %s", tt.ext)
+			}
+			assert.Equal(t, tt.want, lang.ID)
+		})
+	}
+
+	_, ok := language.ByExtension(".txt")
+	assert.False(t, ok)
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "C++17", want: "C++17"},
+		{name: "c++23", want: "C++23"},
+		{name: "C++", want: "C++17"},
+		{name: "cpp17", want: "C++17"},
+		{name: "Java", want: "JAVA"},
+		{name: "Python", want: "Python3"},
+		{name: " go ", want: "Go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			lang, ok := language.Lookup(tt.name)
+
+			// Then
+			if !ok {
+				t.Fatalf("unknown language %q", tt.name)
+			}
+			assert.Equal(t, tt.want, lang.ID)
+		})
+	}
+
+	assert.Equal(t, "Brainfuck", language.Normalize("Brainfuck"), "unknown names are kept")
+	assert.Equal(t, "JAVA", language.Normalize("java"))
+}
+
+func TestFamilyAndExtensionOf(t *testing.T) {
+	assert.Equal(t, "c++", language.Family("C++17"))
+	assert.Equal(t, "python", language.Family("Python3"))
+	assert.Equal(t, "c++", language.MustLookup("C++23").Family())
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "C++14", want: "cpp", wantOK: true},
+		{name: "C++20", want: "cpp", wantOK: true},
+		{name: "PyPy3", want: "py", wantOK: true},
+		{name: "Kotlin", want: "kt", wantOK: true},
+		{name: "Brainfuck"},
+	}
+	for _, tt := range tests {
+		ext, ok := language.ExtensionOf(tt.name)
+		assert.Equal(t, tt.wantOK, ok, tt.name)
+		assert.Equal(t, tt.want, ext, tt.name)
+	}
+}
+
+func TestAll_Consistent(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, lang := range language.All() {
+		assert.False(t, seen[lang.ID], "%s is listed twice", lang.ID)
+		seen[lang.ID] = true
+		assert.NotEmpty(t, lang.DisplayName, lang.ID)
+		assert.NotEmpty(t, lang.Extensions, lang.ID)
+
+		found, ok := language.Lookup(lang.ID)
+		assert.True(t, ok, lang.ID)
+		assert.Equal(t, lang.ID, found.ID, "the ID of %s is not shadowed by an alias", lang.ID)
+		for _, alias := range lang.Aliases {
+			found, _ := language.Lookup(alias)
+			assert.Equal(t, lang.ID, found.ID, "alias %s", alias)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package model
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
@@ -13,16 +14,37 @@ type ProblemID struct {
 	value string
 }
 
-// Problem ID patterns for AOJ
-var (
-	// Course problems like ITP1_1_A, ALDS1_1_A
-	coursePattern = regexp.MustCompile(`^[A-Z]+\d+_\d+_[A-Z]$`)
-	// Volume problems like 0001, 1000
-	volumePattern = regexp.MustCompile(`^\d{4}$`)
-	// Contest problems like abc123_a, arc456_b
-	contestPattern = regexp.MustCompile(`^[a-z]+\d+_[a-z]$`)
+// Problem ID types
+const (
+	ProblemTypeCourse    = "course"
+	ProblemTypeVolume    = "volume"
+	ProblemTypeContest   = "contest"
+	ProblemTypeChallenge = "challenge"
+	ProblemTypeUnknown   = "unknown"
 )
 
+// problemIDFamily is a kind of AOJ problem ID and the pattern of its IDs
+type problemIDFamily struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// problemIDFamilies are the problem ID formats of AOJ, checked in order.
+// Support for a new kind of ID only needs an entry here
+var problemIDFamilies = []problemIDFamily{
+	// Course problems like ITP1_1_A, ALDS1_1_A and DSL_2_B
+	{ProblemTypeCourse, regexp.MustCompile(`^[A-Z]+\d*_\d+_[A-Z]$`)},
+	// Volume problems like 0001, 2870 and the five-digit IDs of newer volumes
+	{ProblemTypeVolume, regexp.MustCompile(`^\d{4,5}$`)},
+	// Contest problems like abc123_a, arc456_b
+	{ProblemTypeContest, regexp.MustCompile(`^[a-z]+\d+_[a-z]$`)},
+	// Challenge and joint judge problems like ICPCOOC_A, JAGSPRING2019_B
+	{ProblemTypeChallenge, regexp.MustCompile(`^[A-Z][A-Z0-9]*_[A-Z]\d?$`)},
+}
+
+// Course problem IDs are made of the course, chapter and problem, e.g. ITP1_1_A
+var courseIDPattern = regexp.MustCompile(`^([A-Z]+)(\d*)_(\d+)_([A-Z])$`)
+
 // NewProblemID creates a new ProblemID
 func NewProblemID(value string) (ProblemID, error) {
 	if value == "" {
@@ -40,7 +62,7 @@ func NewProblemID(value string) (ProblemID, error) {
 		return ProblemID{}, cerrors.NewAppError(
 			cerrors.CodeInvalidInput,
 			"invalid problem ID format",
-			cerrors.WithDetail(nil, "expected format: ITP1_1_A, 0001, or abc123_a"),
+			cerrors.WithDetail(nil, "expected format: ITP1_1_A, DSL_1_A, 0001, abc123_a or ICPCOOC_A"),
 		)
 	}
 
@@ -76,33 +98,32 @@ func (p ProblemID) IsEmpty() bool {
 	return p.value == ""
 }
 
-// Type returns the type of problem ID
+// Type returns the type of problem ID, one of the ProblemType constants
 func (p ProblemID) Type() string {
-	if coursePattern.MatchString(p.value) {
-		return "course"
-	}
-	if volumePattern.MatchString(p.value) {
-		return "volume"
+	if family, ok := findProblemIDFamily(p.value); ok {
+		return family.kind
 	}
-	if contestPattern.MatchString(p.value) {
-		return "contest"
-	}
-	return "unknown"
+	return ProblemTypeUnknown
 }
 
 // IsCourse returns true if this is a course problem
 func (p ProblemID) IsCourse() bool {
-	return p.Type() == "course"
+	return p.Type() == ProblemTypeCourse
 }
 
 // IsVolume returns true if this is a volume problem
 func (p ProblemID) IsVolume() bool {
-	return p.Type() == "volume"
+	return p.Type() == ProblemTypeVolume
 }
 
 // IsContest returns true if this is a contest problem
 func (p ProblemID) IsContest() bool {
-	return p.Type() == "contest"
+	return p.Type() == ProblemTypeContest
+}
+
+// IsChallenge returns true if this is a challenge or joint judge problem
+func (p ProblemID) IsChallenge() bool {
+	return p.Type() == ProblemTypeChallenge
 }
 
 // Equals compares two problem IDs
@@ -121,39 +142,33 @@ func (p ProblemID) GetCourseInfo() (course string, chapter int, section int, pro
 		return "", 0, 0, "", false
 	}
 
-	// Parse ITP1_1_A format
-	parts := strings.Split(p.value, "_")
-	if len(parts) != 3 {
+	// Parse ITP1_1_A format; courses such as DSL have no number
+	matches := courseIDPattern.FindStringSubmatch(p.value)
+	if matches == nil {
 		return "", 0, 0, "", false
 	}
 
-	// Extract course name and number
-	courseRe := regexp.MustCompile(`^([A-Z]+)(\d+)$`)
-	matches := courseRe.FindStringSubmatch(parts[0])
-	if len(matches) != 3 {
-		return "", 0, 0, "", false
-	}
-
-	courseName := matches[1]
 	courseNum := 0
-	if len(matches[2]) > 0 {
-		courseNum = int(matches[2][0] - '0') // Simple single digit parsing
-	}
-
-	// Parse chapter and section
-	chapterNum := 0
-	if len(parts[1]) > 0 {
-		chapterNum = int(parts[1][0] - '0')
+	if matches[2] != "" {
+		courseNum, _ = strconv.Atoi(matches[2])
 	}
+	chapterNum, _ := strconv.Atoi(matches[3])
 
-	problemLetter := parts[2]
-
-	return courseName, courseNum, chapterNum, problemLetter, true
+	return matches[1], courseNum, chapterNum, matches[4], true
 }
 
 // isValidProblemIDFormat checks if the problem ID matches any valid format
 func isValidProblemIDFormat(id string) bool {
-	return coursePattern.MatchString(id) ||
-		volumePattern.MatchString(id) ||
-		contestPattern.MatchString(id)
+	_, ok := findProblemIDFamily(id)
+	return ok
+}
+
+// findProblemIDFamily returns the first family whose pattern matches id
+func findProblemIDFamily(id string) (problemIDFamily, bool) {
+	for _, family := range problemIDFamilies {
+		if family.pattern.MatchString(id) {
+			return family, true
+		}
+	}
+	return problemIDFamily{}, false
 }
\ No newline at end of file
@@ -0,0 +1,80 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestNewProblemID_Families(t *testing.T) {
+	tests := []struct {
+		id       string
+		wantType string
+	}{
+		{"ITP1_1_A", model.ProblemTypeCourse},
+		{"ALDS1_10_C", model.ProblemTypeCourse},
+		{"DSL_2_B", model.ProblemTypeCourse},
+		{"NTL_1_A", model.ProblemTypeCourse},
+		{"0001", model.ProblemTypeVolume},
+		{"2870", model.ProblemTypeVolume},
+		{"10001", model.ProblemTypeVolume},
+		{"abc123_a", model.ProblemTypeContest},
+		{"ICPCOOC_A", model.ProblemTypeChallenge},
+		{"JAGSPRING2019_B", model.ProblemTypeChallenge},
+		{"RUPC2020_J2", model.ProblemTypeChallenge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			// When
+			id, err := model.NewProblemID(tt.id)
+
+			// Then
+			if err != nil {
+				t.Fatalf("NewProblemID failed: %v", err)
+			}
+			assert.Equal(t, tt.id, id.String())
+			assert.Equal(t, tt.wantType, id.Type())
+		})
+	}
+}
+
+func TestNewProblemID_Invalid(t *testing.T) {
+	for _, value := range []string{"", "001", "123456", "itp1_1_a", "ITP1_1", "../etc", "two-sum", "ICPC_AB"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := model.NewProblemID(value)
+
+			assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+		})
+	}
+}
+
+func TestProblemID_GetCourseInfo(t *testing.T) {
+	tests := []struct {
+		id              string
+		course          string
+		number, chapter int
+		problem         string
+		ok              bool
+	}{
+		{"ITP1_1_A", "ITP", 1, 1, "A", true},
+		{"ALDS1_12_C", "ALDS", 1, 12, "C", true},
+		{"DSL_2_B", "DSL", 0, 2, "B", true},
+		{"0001", "", 0, 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			course, number, chapter, problem, ok := model.MustNewProblemID(tt.id).GetCourseInfo()
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.course, course)
+			assert.Equal(t, tt.number, number)
+			assert.Equal(t, tt.chapter, chapter)
+			assert.Equal(t, tt.problem, problem)
+		})
+	}
+}
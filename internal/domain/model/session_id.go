@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
 )
 
 // SessionID represents a unique identifier for a session
@@ -131,10 +132,7 @@ func (s SessionID) Truncate(length int) string {
 
 // MaskedString returns a masked version of the session ID for logging
 func (s SessionID) MaskedString() string {
-	if len(s.value) <= 8 {
-		return strings.Repeat("*", len(s.value))
-	}
-	return s.value[:4] + strings.Repeat("*", len(s.value)-8) + s.value[len(s.value)-4:]
+	return redact.Mask(s.value)
 }
 
 // isValidSessionIDFormat checks if the session ID is a valid hexadecimal format
@@ -160,4 +160,92 @@ func (tc *TestCase) Clone() *TestCase {
 		name:     tc.name,
 		timeout:  tc.timeout,
 	}
-}
\ No newline at end of file
+}
+
+// AggregateTestCases joins test cases into a single case whose input is one
+// stream of datasets, as used by ICPC-style problems. When every input ends
+// with the same sentinel line (such as "0 0"), the sentinel is kept only at
+// the end of the combined input
+func AggregateTestCases(testCases []TestCase) *TestCase {
+	var input, expected strings.Builder
+
+	sentinel := commonLastLine(testCases)
+	for i, tc := range testCases {
+		lines := tc.InputLines()
+		if sentinel != "" && i < len(testCases)-1 {
+			lines = trimTrailingSentinel(lines, sentinel)
+		}
+		for _, line := range lines {
+			input.WriteString(line)
+			input.WriteString("\n")
+		}
+		for _, line := range tc.ExpectedLines() {
+			expected.WriteString(line)
+			expected.WriteString("\n")
+		}
+	}
+
+	return NewNamedTestCase(0, input.String(), expected.String(), "all")
+}
+
+// commonLastLine returns the sentinel line that ends every test case input, or ""
+func commonLastLine(testCases []TestCase) string {
+	if len(testCases) < 2 {
+		return ""
+	}
+
+	var sentinel string
+	for i, tc := range testCases {
+		last := lastNonEmptyLine(tc.InputLines())
+		if last == "" {
+			return ""
+		}
+		if i == 0 {
+			sentinel = last
+		} else if last != sentinel {
+			return ""
+		}
+	}
+	if !isSentinelLine(sentinel) {
+		return ""
+	}
+	return sentinel
+}
+
+// isSentinelLine reports whether a line looks like an end-of-input marker such as "0", "0 0" or "#"
+func isSentinelLine(line string) bool {
+	switch line {
+	case "#", ".", "END", "end", "EOF":
+		return true
+	}
+
+	for _, field := range strings.Fields(line) {
+		if field != "0" && field != "-1" {
+			return false
+		}
+	}
+	return true
+}
+
+func lastNonEmptyLine(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func trimTrailingSentinel(lines []string, sentinel string) []string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		if line == sentinel {
+			return lines[:i]
+		}
+		break
+	}
+	return lines
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+)
+
+// ChallengeRepository defines the interface for the archives of past contests
+// hosted on the judge, such as the ICPC and JAG contests
+type ChallengeRepository interface {
+	// ListContests retrieves the contests of an archive category, e.g.
+	// icpc/domestic, with their problems in contest order
+	ListContests(ctx context.Context, category string) ([]ChallengeContest, error)
+}
+
+// ChallengeContest is a past contest of an archive
+type ChallengeContest struct {
+	Year     int                `json:"year"`
+	Abbr     string             `json:"abbr"`
+	Title    string             `json:"title"`
+	Problems []ChallengeProblem `json:"problems"`
+}
+
+// ChallengeProblem is a problem of a past contest
+type ChallengeProblem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+)
+
+// CourseRepository defines the interface for reading the course catalogue of the judge
+type CourseRepository interface {
+	// GetCourse retrieves a course by its short name, e.g. ITP1, with its problems per topic
+	GetCourse(ctx context.Context, name string) (*Course, error)
+}
+
+// Course is a course of the judge, made of numbered topics
+type Course struct {
+	Name   string        `json:"name"`
+	Title  string        `json:"title"`
+	Topics []CourseTopic `json:"topics"`
+}
+
+// CourseTopic is a chapter of a course
+type CourseTopic struct {
+	Chapter  int             `json:"chapter"`
+	Title    string          `json:"title"`
+	Problems []CourseProblem `json:"problems"`
+}
+
+// CourseProblem is a problem of a course topic
+type CourseProblem struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
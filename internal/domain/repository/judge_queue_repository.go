@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+)
+
+// JudgeQueueRepository defines the interface for reading the load of the judge
+type JudgeQueueRepository interface {
+	// GetJudgeQueue returns where a submission waits in the judge queue
+	GetJudgeQueue(ctx context.Context, id model.SubmissionID) (JudgeQueue, error)
+}
+
+// JudgeQueue describes the submissions waiting for the judge
+type JudgeQueue struct {
+	Position int `json:"position"` // 1 for the next submission to be judged; 0 if the submission is not waiting or not listed
+	Length   int `json:"length"`   // submissions waiting in total
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+)
+
+// LanguageRepository defines the interface for retrieving the languages accepted by the judge
+type LanguageRepository interface {
+	// List returns the language names accepted by AOJ for submissions
+	List(ctx context.Context) ([]string, error)
+}
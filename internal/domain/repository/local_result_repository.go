@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LocalResult is the outcome of the last local test run of a problem
+type LocalResult struct {
+	ProblemID string    `json:"problem_id"`
+	Verdict   string    `json:"verdict"`
+	Passed    int       `json:"passed"`
+	Total     int       `json:"total"`
+	TestedAt  time.Time `json:"tested_at"`
+}
+
+// LocalResultRepository defines the interface for the outcomes of local test runs
+type LocalResultRepository interface {
+	// List retrieves the last outcome of every tested problem, keyed by problem ID
+	List(ctx context.Context) (map[string]LocalResult, error)
+
+	// Save replaces the last outcome of a problem
+	Save(ctx context.Context, result LocalResult) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PendingSubmissionRepository defines the interface for the queue of
+// submissions that could not be sent while AOJ was unavailable
+type PendingSubmissionRepository interface {
+	// List retrieves the queued submissions, oldest first
+	List(ctx context.Context) ([]PendingSubmission, error)
+
+	// SaveAll replaces the queued submissions
+	SaveAll(ctx context.Context, pending []PendingSubmission) error
+}
+
+// PendingSubmission is a submission waiting to be sent to AOJ
+type PendingSubmission struct {
+	ProblemID  string    `json:"problem_id"`
+	Language   string    `json:"language"`
+	SourceCode string    `json:"source_code"`
+	SourceFile string    `json:"source_file,omitempty"` // file the source was read from, empty for resubmissions
+	QueuedAt   time.Time `json:"queued_at"`
+	Attempts   int       `json:"attempts"`             // times sending it has failed
+	LastError  string    `json:"last_error,omitempty"` // why the last attempt failed
+}
@@ -27,8 +27,8 @@ type SessionRepository interface {
 	// DeleteByUsername deletes all sessions for a username
 	DeleteByUsername(ctx context.Context, username string) error
 
-	// DeleteExpired deletes all expired sessions
-	DeleteExpired(ctx context.Context) error
+	// DeleteExpired deletes all expired sessions and returns how many were deleted
+	DeleteExpired(ctx context.Context) (int, error)
 
 	// Exists checks if a session exists
 	Exists(ctx context.Context, id model.SessionID) (bool, error)
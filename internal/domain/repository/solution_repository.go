@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+)
+
+// SolutionRepository defines the interface for reading the accepted solutions
+// of all users of the judge
+type SolutionRepository interface {
+	// GetByProblemID retrieves up to limit accepted solutions of a problem
+	GetByProblemID(ctx context.Context, problemID model.ProblemID, limit int) ([]Solution, error)
+}
+
+// Solution is an accepted solution of a problem as listed by the judge
+type Solution struct {
+	JudgeID     string        `json:"judge_id"`
+	UserID      string        `json:"user_id"`
+	Language    string        `json:"language"`
+	Time        time.Duration `json:"time"`
+	Memory      int64         `json:"memory"`    // in KB
+	CodeSize    int64         `json:"code_size"` // in bytes
+	SubmittedAt time.Time     `json:"submitted_at"`
+}
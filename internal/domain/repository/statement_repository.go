@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+)
+
+// StatementRepository defines the interface for problem statement access
+type StatementRepository interface {
+	// GetStatement retrieves the HTML statement of a problem in a language such as "en" or "ja"
+	GetStatement(ctx context.Context, id model.ProblemID, language string) (string, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+)
+
+// SubmissionHistoryRepository defines the interface for reading a user's
+// submission records from the judge, including submissions made on its website
+type SubmissionHistoryRepository interface {
+	// GetUserSubmissions retrieves one page of the user's submissions, newest first.
+	// Pages are numbered from 0; a page shorter than size is the last one
+	GetUserSubmissions(ctx context.Context, userID string, page, size int) ([]*entity.Submission, error)
+
+	// GetSourceCode retrieves the source code of a submission by its judge ID
+	GetSourceCode(ctx context.Context, judgeID string) (string, error)
+}
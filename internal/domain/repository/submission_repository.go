@@ -25,6 +25,13 @@ type SubmissionRepository interface {
 	// GetStatus retrieves the current status of a submission
 	GetStatus(ctx context.Context, id model.SubmissionID) (entity.SubmissionStatus, error)
 
+	// GetCompileError retrieves the compiler message of a submission rejected with COMPILE_ERROR
+	GetCompileError(ctx context.Context, submission *entity.Submission) (string, error)
+
+	// GetJudgeDetail retrieves the score, time and memory of a judged submission
+	// and its results per test case, if AOJ reports them
+	GetJudgeDetail(ctx context.Context, submission *entity.Submission) (JudgeDetail, error)
+
 	// WatchStatus watches for status changes of a submission
 	WatchStatus(ctx context.Context, id model.SubmissionID, interval time.Duration) (<-chan entity.SubmissionStatus, error)
 
@@ -41,6 +48,19 @@ type SubmissionRepository interface {
 	Exists(ctx context.Context, id model.SubmissionID) (bool, error)
 }
 
+// JudgeDetail is the judge record of a submission
+type JudgeDetail struct {
+	Score  int
+	Time   time.Duration
+	Memory int64               // in KB
+	Cases  []entity.CaseResult // empty if AOJ reports no test case results, e.g. for compile errors
+}
+
+// IsEmpty returns true if AOJ reported nothing about the submission
+func (d JudgeDetail) IsEmpty() bool {
+	return d.Score == 0 && d.Time == 0 && d.Memory == 0 && len(d.Cases) == 0
+}
+
 // SubmissionSearchCriteria defines search criteria for submissions
 type SubmissionSearchCriteria struct {
 	ProblemID   *model.ProblemID
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+// TagRepository defines the interface for the tags users put on problems
+type TagRepository interface {
+	// List retrieves the tags of every tagged problem, keyed by problem ID
+	List(ctx context.Context) (map[string][]string, error)
+
+	// SaveAll replaces the stored tags
+	SaveAll(ctx context.Context, tags map[string][]string) error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+)
+
+// TestCaseHeader describes a judge test case without its data
+type TestCaseHeader struct {
+	Serial     int
+	Name       string
+	InputSize  int64 // in bytes
+	OutputSize int64 // in bytes
+}
+
+// JudgeCasePart selects the input or the expected output of a judge test case
+type JudgeCasePart string
+
+// Parts of a judge test case
+const (
+	JudgeCaseInput  JudgeCasePart = "in"
+	JudgeCaseOutput JudgeCasePart = "out"
+)
+
+// TestCaseRepository defines the interface for access to the judge test cases,
+// which include the hidden ones beyond the samples
+type TestCaseRepository interface {
+	// ListHeaders retrieves the headers of all judge test cases of a problem
+	ListHeaders(ctx context.Context, problemID model.ProblemID) ([]TestCaseHeader, error)
+
+	// GetJudgeCase downloads the full input and output of a judge test case
+	GetJudgeCase(ctx context.Context, problemID model.ProblemID, serial int) (*model.TestCase, error)
+
+	// DownloadJudgeCase streams one part of a judge test case to w without
+	// holding it in memory and returns the number of bytes written. Errors
+	// returned by w are passed through unchanged
+	DownloadJudgeCase(ctx context.Context, problemID model.ProblemID, serial int, part JudgeCasePart, w io.Writer) (int64, error)
+}
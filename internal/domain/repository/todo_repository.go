@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TodoRepository defines the interface for the list of problems to practice
+type TodoRepository interface {
+	// List retrieves every item, including done ones, in insertion order
+	List(ctx context.Context) ([]TodoItem, error)
+
+	// SaveAll replaces the stored items
+	SaveAll(ctx context.Context, items []TodoItem) error
+}
+
+// TodoItem is a problem on the practice list
+type TodoItem struct {
+	ProblemID string     `json:"problem_id"`
+	Note      string     `json:"note,omitempty"`
+	AddedAt   time.Time  `json:"added_at"`
+	Due       *time.Time `json:"due,omitempty"`     // not before this time; nil means right away
+	DoneAt    *time.Time `json:"done_at,omitempty"` // nil while pending
+	Reviews   int        `json:"reviews,omitempty"` // times the problem was rescheduled for review
+}
+
+// IsDone returns true if the item was completed
+func (i TodoItem) IsDone() bool {
+	return i.DoneAt != nil
+}
+
+// IsDueAt returns true if the item is pending and may be practiced at t
+func (i TodoItem) IsDueAt(t time.Time) bool {
+	return !i.IsDone() && (i.Due == nil || !i.Due.After(t))
+}
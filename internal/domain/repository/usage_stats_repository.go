@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// UsageStatsRepository defines the interface for the local statistics of
+// command runs. The statistics never leave this machine
+type UsageStatsRepository interface {
+	// Load retrieves the statistics; missing statistics are empty
+	Load(ctx context.Context) (UsageStats, error)
+
+	// Save replaces the stored statistics
+	Save(ctx context.Context, stats UsageStats) error
+}
+
+// UsageStats holds the usage of every command since the statistics were started
+type UsageStats struct {
+	Since    time.Time               `json:"since"`
+	Commands map[string]CommandUsage `json:"commands"` // keyed by command path, e.g. "history sync"
+}
+
+// CommandUsage counts the runs of one command
+type CommandUsage struct {
+	Runs         int            `json:"runs"`
+	Failures     int            `json:"failures"`
+	TotalMillis  int64          `json:"total_ms"`
+	MaxMillis    int64          `json:"max_ms"`
+	LastRun      time.Time      `json:"last_run"`
+	FailureCodes map[string]int `json:"failure_codes,omitempty"` // failures by error code
+}
+
+// Average returns the mean duration of a run
+func (u CommandUsage) Average() time.Duration {
+	if u.Runs == 0 {
+		return 0
+	}
+	return time.Duration(u.TotalMillis/int64(u.Runs)) * time.Millisecond
+}
+
+// Max returns the duration of the slowest run
+func (u CommandUsage) Max() time.Duration {
+	return time.Duration(u.MaxMillis) * time.Millisecond
+}
@@ -0,0 +1,11 @@
+package service
+
+import "context"
+
+// DifficultyProvider estimates how hard problems are, e.g. from a difficulty
+// table maintained outside of AOJ
+type DifficultyProvider interface {
+	// Difficulties returns the estimated difficulty of every problem the
+	// provider knows, keyed by problem ID. Larger is harder
+	Difficulties(ctx context.Context) (map[string]float64, error)
+}
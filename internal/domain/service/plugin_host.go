@@ -0,0 +1,26 @@
+package service
+
+import "context"
+
+// PluginPrefix is the prefix of plugin executables: aoj-graph adds 'aoj graph'
+const PluginPrefix = "aoj-"
+
+// Plugin is an external executable that adds a subcommand
+type Plugin struct {
+	Name string `json:"name"` // subcommand name, without the prefix
+	Path string `json:"path"`
+}
+
+// PluginHost discovers and runs plugins
+type PluginHost interface {
+	// List returns the plugins found, in name order. When several executables
+	// provide the same name, the first one on the search path wins
+	List(ctx context.Context) ([]Plugin, error)
+
+	// Find returns the plugin providing the subcommand, or a NotFound error
+	Find(ctx context.Context, name string) (*Plugin, error)
+
+	// Run executes the plugin attached to the standard streams with env added
+	// to the environment and returns its exit status
+	Run(ctx context.Context, plugin Plugin, args []string, env []string) (int, error)
+}
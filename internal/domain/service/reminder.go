@@ -0,0 +1,29 @@
+package service
+
+import "context"
+
+// DailyJob is a command run once a day at a fixed local time
+type DailyJob struct {
+	Name    string            // identifies the job when it is replaced or removed
+	Command []string          // program and arguments
+	Env     map[string]string // variables the command needs, e.g. to reach the desktop session
+	Hour    int
+	Minute  int
+}
+
+// Scheduler runs commands periodically with the scheduler of the operating system
+type Scheduler interface {
+	// Install adds the job, replacing a job of the same name, and returns
+	// where it was installed, e.g. the path of a launchd agent
+	Install(ctx context.Context, job DailyJob) (string, error)
+
+	// Uninstall removes the job of the given name. It reports whether the
+	// job was installed
+	Uninstall(ctx context.Context, name string) (bool, error)
+}
+
+// Notifier shows desktop notifications
+type Notifier interface {
+	// Notify shows a notification with the title and message
+	Notify(ctx context.Context, title, message string) error
+}
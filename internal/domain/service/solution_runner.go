@@ -0,0 +1,78 @@
+// Package service defines domain services implemented by the infrastructure layer.
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// SolutionRunner builds and executes solutions on the local machine
+type SolutionRunner interface {
+	// Build compiles the solution. It is a no-op when the spec has no build command
+	Build(ctx context.Context, spec RunSpec) (*BuildResult, error)
+
+	// Run executes the solution with the given standard input
+	Run(ctx context.Context, spec RunSpec, input string, timeout time.Duration) (*RunResult, error)
+
+	// RunInteractive executes the solution connected to an interactor through
+	// pipes: the output of each program is the input of the other
+	RunInteractive(ctx context.Context, spec RunSpec, interactor InteractorSpec, timeout time.Duration) (*InteractiveResult, error)
+
+	// RunAttached executes the solution without a time limit, connected to the
+	// given streams instead of capturing its output
+	RunAttached(ctx context.Context, spec RunSpec, stdin io.Reader, stdout, stderr io.Writer) (*RunResult, error)
+}
+
+// RunSpec describes how to build and run a solution
+type RunSpec struct {
+	Dir          string // working directory
+	SourceFile   string // solution file relative to Dir
+	BuildCommand string // shell command to compile the solution, empty for interpreted languages
+	RunCommand   string // shell command to execute the solution
+}
+
+// BuildResult holds the outcome of a build
+type BuildResult struct {
+	Success  bool
+	Output   string // combined compiler output
+	Duration time.Duration
+	Cached   bool // the previous build was still up to date
+}
+
+// RunResult holds the outcome of a single execution
+type RunResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+	TimedOut bool
+	MemoryKB int64 // peak resident set size, 0 when unavailable
+}
+
+// Placeholders replaced in the interactor command
+const (
+	InteractorInputPlaceholder  = "{input}"  // file holding the input of the test case
+	InteractorOutputPlaceholder = "{output}" // file holding the expected output of the test case
+)
+
+// InteractorSpec describes the judge program of an interactive problem
+type InteractorSpec struct {
+	Command  string // shell command run in the solution's directory; exits non-zero to reject the solution
+	Input    string // test case input, passed as the file {input}
+	Expected string // expected output, passed as the file {output}
+}
+
+// InteractiveResult holds the outcome of an interactive execution
+type InteractiveResult struct {
+	RunResult                           // result of the solution; Stdout is everything it sent to the interactor
+	InteractorExitCode int              // -1 if the interactor was killed
+	InteractorStderr   string           // usually the reason for rejecting the solution
+	Transcript         []TranscriptLine // the dialogue in the order it was observed
+}
+
+// TranscriptLine is a message of the dialogue between solution and interactor
+type TranscriptLine struct {
+	FromSolution bool // false for messages from the interactor
+	Text         string
+}
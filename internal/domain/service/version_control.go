@@ -0,0 +1,15 @@
+package service
+
+import "context"
+
+// VersionControl records files in the version control repository that contains them
+type VersionControl interface {
+	// Commit commits the current content of the files with the message and
+	// returns the abbreviated commit hash. It returns an empty hash without
+	// an error if the files have no changes to commit
+	Commit(ctx context.Context, files []string, message string) (string, error)
+
+	// Tag creates a lightweight tag on the latest commit of the repository
+	// that contains dir
+	Tag(ctx context.Context, dir, name string) error
+}
@@ -0,0 +1,564 @@
+// Package aojfake provides an in-process fake of the AOJ judge API and judge
+// data API for hermetic integration tests.
+package aojfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status is a verdict code of the AOJ API
+type Status int
+
+// Verdict codes as reported by the AOJ API
+const (
+	StatusCompileError        Status = 0
+	StatusWrongAnswer         Status = 1
+	StatusTimeLimitExceeded   Status = 2
+	StatusMemoryLimitExceeded Status = 3
+	StatusAccepted            Status = 4
+	StatusPending             Status = 5
+	StatusOutputLimitExceeded Status = 6
+	StatusRuntimeError        Status = 7
+	StatusPresentationError   Status = 8
+	StatusJudging             Status = 9
+)
+
+// caseLabels are the per test case labels of the failing verdicts
+var caseLabels = map[Status]string{
+	StatusWrongAnswer:         "WA",
+	StatusTimeLimitExceeded:   "TLE",
+	StatusMemoryLimitExceeded: "MLE",
+	StatusOutputLimitExceeded: "OLE",
+	StatusRuntimeError:        "RE",
+	StatusPresentationError:   "PE",
+}
+
+// Problem is a problem served by the fake
+type Problem struct {
+	ID          string
+	Name        string
+	TimeLimit   time.Duration
+	MemoryLimit int64 // in KB
+	Samples     []Case
+	JudgeCases  []Case
+}
+
+// Case is a test case of a problem
+type Case struct {
+	In  string
+	Out string
+}
+
+// Verdict is the judgement the fake gives to submissions
+type Verdict struct {
+	Status       Status
+	Score        int // reported score; accepted verdicts without one score 100
+	CPUTime      time.Duration
+	Memory       int64  // in KB
+	FailedCase   int    // serial of the first failing judge case, for failing verdicts
+	CompileError string // compiler message, for StatusCompileError
+	PendingPolls int    // verdict requests answered with StatusJudging first
+}
+
+// score returns the score reported for the verdict
+func (v Verdict) score() int {
+	if v.Score == 0 && v.Status == StatusAccepted {
+		return 100
+	}
+	return v.Score
+}
+
+// Submission is a solution received by the fake
+type Submission struct {
+	JudgeID     int64
+	UserID      string
+	ProblemID   string
+	Language    string
+	SourceCode  string
+	SubmittedAt time.Time
+	Verdict     Verdict
+	polls       int
+}
+
+// Server is a fake AOJ. The same URL serves the judge API and the judge data API
+type Server struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	users       map[string]string // user ID to password
+	tokens      map[string]string // token to user ID
+	currentUser string            // user of the latest login, who submits
+	problems    map[string]Problem
+	languages   []string
+	verdicts    map[string]Verdict // by problem ID
+	submissions []*Submission
+	nextJudgeID int64
+	requests    []string
+}
+
+// New starts a fake AOJ that accepts every submission. Close it when done
+func New() *Server {
+	s := &Server{
+		users:       make(map[string]string),
+		tokens:      make(map[string]string),
+		problems:    make(map[string]Problem),
+		languages:   []string{"C", "C++14", "C++17", "JAVA", "Python3", "Go", "Rust"},
+		verdicts:    make(map[string]Verdict),
+		nextJudgeID: 1000,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /session", s.handleLogin)
+	mux.HandleFunc("DELETE /session", s.handleLogout)
+	mux.HandleFunc("GET /user/{id}", s.handleUser)
+	mux.HandleFunc("POST /submissions", s.handleSubmit)
+	mux.HandleFunc("GET /verdicts/{id}", s.handleVerdict)
+	mux.HandleFunc("GET /reviews/{id}", s.handleReview)
+	mux.HandleFunc("GET /submission_records/users/{id}", s.handleSubmissionRecords)
+	mux.HandleFunc("GET /submission_records/recent", s.handleRecentSubmissionRecords)
+	mux.HandleFunc("GET /problems", s.handleProblemList)
+	mux.HandleFunc("GET /problems/{id}", s.handleProblem)
+	mux.HandleFunc("GET /languages", s.handleLanguages)
+	mux.HandleFunc("GET /testcases/{first}/{second}", s.handleTestCases)
+	mux.HandleFunc("GET /testcases/{id}/{serial}/{kind}", s.handleJudgeCase)
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// URL returns the base URL to use for both AOJ APIs
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddUser registers a user who can log in with password
+func (s *Server) AddUser(id, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[id] = password
+}
+
+// AddProblem serves a problem, replacing one with the same ID
+func (s *Server) AddProblem(problem Problem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.problems[problem.ID] = problem
+}
+
+// SetLanguages replaces the languages accepted for submissions
+func (s *Server) SetLanguages(languages ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.languages = languages
+}
+
+// SetVerdict sets the verdict of later submissions to the problem
+func (s *Server) SetVerdict(problemID string, verdict Verdict) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verdicts[problemID] = verdict
+}
+
+// Submissions returns the received submissions in order
+func (s *Server) Submissions() []Submission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	submissions := make([]Submission, 0, len(s.submissions))
+	for _, submission := range s.submissions {
+		submissions = append(submissions, *submission)
+	}
+	return submissions
+}
+
+// Requests returns the received requests as "METHOD /path", in order
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID       string `json:"id"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if password, ok := s.users[req.ID]; !ok || password != req.Password {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	token := fmt.Sprintf("token-%s-%d", req.ID, len(s.tokens)+1)
+	s.tokens[token] = req.ID
+	s.currentUser = req.ID
+	writeJSON(w, map[string]string{"id": req.ID, "name": req.ID, "sessionId": token, "token": token})
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, bearerToken(r))
+	s.currentUser = ""
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user, ok := s.tokens[bearerToken(r)]; !ok || user != r.PathValue("id") {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, map[string]string{"id": r.PathValue("id"), "name": r.PathValue("id")})
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ProblemID  string `json:"problemId"`
+		Language   string `json:"language"`
+		SourceCode string `json:"sourceCode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.currentUser == "" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if _, ok := s.problems[req.ProblemID]; !ok {
+		http.Error(w, "unknown problem", http.StatusBadRequest)
+		return
+	}
+
+	verdict, ok := s.verdicts[req.ProblemID]
+	if !ok {
+		verdict = Verdict{Status: StatusAccepted, CPUTime: 10 * time.Millisecond, Memory: 1024}
+	}
+	s.nextJudgeID++
+	submission := &Submission{
+		JudgeID:     s.nextJudgeID,
+		UserID:      s.currentUser,
+		ProblemID:   req.ProblemID,
+		Language:    req.Language,
+		SourceCode:  req.SourceCode,
+		SubmittedAt: time.Now(),
+		Verdict:     verdict,
+	}
+	s.submissions = append(s.submissions, submission)
+
+	writeJSON(w, map[string]any{
+		"submissionId": strconv.FormatInt(submission.JudgeID, 10),
+		"problemId":    submission.ProblemID,
+		"status":       "PENDING",
+		"submittedAt":  submission.SubmittedAt.UnixMilli(),
+	})
+}
+
+func (s *Server) handleVerdict(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	submission := s.findSubmission(r.PathValue("id"))
+	if submission == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	submission.polls++
+	status, score := submission.Verdict.Status, submission.Verdict.score()
+	var caseVerdicts []map[string]any
+	if submission.polls <= submission.Verdict.PendingPolls {
+		status, score = StatusJudging, 0
+	} else {
+		caseVerdicts = s.caseVerdicts(submission)
+	}
+
+	// Like a caching HTTP server, answer unchanged verdicts with 304
+	etag := fmt.Sprintf(`"%d-%d"`, submission.JudgeID, status)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	writeJSON(w, map[string]any{
+		"submissionRecord": map[string]any{
+			"judgeId": submission.JudgeID,
+			"status":  int(status),
+			"score":   score,
+			"cpuTime": submission.Verdict.CPUTime.Milliseconds() / 10,
+			"memory":  submission.Verdict.Memory,
+		},
+		"caseVerdicts": caseVerdicts,
+	})
+}
+
+// caseVerdicts returns the per test case results: cases before the failing
+// one pass and cases after it are skipped. Every case that ran reports the
+// time and memory of the verdict
+func (s *Server) caseVerdicts(submission *Submission) []map[string]any {
+	if submission.Verdict.Status == StatusCompileError {
+		return []map[string]any{}
+	}
+
+	count := max(len(s.problems[submission.ProblemID].JudgeCases), submission.Verdict.FailedCase, 1)
+	verdicts := make([]map[string]any, 0, count)
+	for serial := 1; serial <= count; serial++ {
+		label := "AC"
+		cpuTime, memory := submission.Verdict.CPUTime.Milliseconds()/10, submission.Verdict.Memory
+		failed := submission.Verdict.FailedCase
+		switch {
+		case failed == 0 || serial < failed:
+		case serial == failed:
+			label = caseLabels[submission.Verdict.Status]
+		default:
+			label, cpuTime, memory = "-", 0, 0
+		}
+		verdicts = append(verdicts, map[string]any{
+			"serial":  serial,
+			"status":  label,
+			"label":   fmt.Sprintf("case%d", serial),
+			"cpuTime": cpuTime,
+			"memory":  memory,
+		})
+	}
+	return verdicts
+}
+
+func (s *Server) handleReview(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	submission := s.findSubmission(r.PathValue("id"))
+	if submission == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"judgeId":      submission.JudgeID,
+		"compileError": submission.Verdict.CompileError,
+		"runtimeError": "",
+		"sourceCode":   submission.SourceCode,
+	})
+}
+
+func (s *Server) handleSubmissionRecords(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil || size <= 0 {
+		size = 20
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []map[string]any
+	for i := len(s.submissions) - 1; i >= 0; i-- {
+		submission := s.submissions[i]
+		if submission.UserID != r.PathValue("id") {
+			continue
+		}
+		records = append(records, submissionRecord(submission, submission.Verdict.Status))
+	}
+
+	start := min(page*size, len(records))
+	writeJSON(w, append([]map[string]any{}, records[start:min(start+size, len(records))]...))
+}
+
+// handleRecentSubmissionRecords lists the latest submissions of all users.
+// Submissions whose verdict is still held back by PendingPolls are waiting
+func (s *Server) handleRecentSubmissionRecords(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := []map[string]any{}
+	for i := len(s.submissions) - 1; i >= 0 && len(records) < 20; i-- {
+		submission := s.submissions[i]
+		status := submission.Verdict.Status
+		if submission.polls < submission.Verdict.PendingPolls {
+			status = StatusPending
+		}
+		records = append(records, submissionRecord(submission, status))
+	}
+	writeJSON(w, records)
+}
+
+// submissionRecord renders a submission as the submission_records endpoints do
+func submissionRecord(submission *Submission, status Status) map[string]any {
+	score := submission.Verdict.score()
+	if status != submission.Verdict.Status {
+		score = 0
+	}
+	return map[string]any{
+		"judgeId":        submission.JudgeID,
+		"userId":         submission.UserID,
+		"problemId":      submission.ProblemID,
+		"language":       submission.Language,
+		"status":         int(status),
+		"score":          score,
+		"cpuTime":        submission.Verdict.CPUTime.Milliseconds() / 10,
+		"memory":         submission.Verdict.Memory,
+		"codeSize":       len(submission.SourceCode),
+		"submissionDate": submission.SubmittedAt.UnixMilli(),
+		"judgeDate":      submission.SubmittedAt.UnixMilli(),
+	}
+}
+
+func (s *Server) handleProblemList(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.problems))
+	for id := range s.problems {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	problems := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		problems = append(problems, problemJSON(s.problems[id]))
+	}
+	writeJSON(w, problems)
+}
+
+func (s *Server) handleProblem(w http.ResponseWriter, r *http.Request) {
+	problem, ok := s.problem(r.PathValue("id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, problemJSON(problem))
+}
+
+func (s *Server) handleLanguages(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	languages := make([]map[string]string, 0, len(s.languages))
+	for _, name := range s.languages {
+		languages = append(languages, map[string]string{"name": name})
+	}
+	writeJSON(w, languages)
+}
+
+// handleTestCases serves /testcases/samples/{id} and /testcases/{id}/header,
+// which a single pattern has to match
+func (s *Server) handleTestCases(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("first") == "samples":
+		s.handleSamples(w, r, r.PathValue("second"))
+	case r.PathValue("second") == "header":
+		s.handleCaseHeaders(w, r, r.PathValue("first"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleSamples(w http.ResponseWriter, r *http.Request, problemID string) {
+	problem, ok := s.problem(problemID)
+	if !ok || len(problem.Samples) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	samples := make([]map[string]any, 0, len(problem.Samples))
+	for i, sample := range problem.Samples {
+		samples = append(samples, map[string]any{"serial": i + 1, "in": sample.In, "out": sample.Out})
+	}
+	writeJSON(w, samples)
+}
+
+func (s *Server) handleCaseHeaders(w http.ResponseWriter, r *http.Request, problemID string) {
+	problem, ok := s.problem(problemID)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	headers := make([]map[string]any, 0, len(problem.JudgeCases))
+	for i, judgeCase := range problem.JudgeCases {
+		headers = append(headers, map[string]any{
+			"serial":     i + 1,
+			"name":       fmt.Sprintf("case%d", i+1),
+			"inputSize":  len(judgeCase.In),
+			"outputSize": len(judgeCase.Out),
+		})
+	}
+	writeJSON(w, map[string]any{"problemId": problem.ID, "headers": headers})
+}
+
+func (s *Server) handleJudgeCase(w http.ResponseWriter, r *http.Request) {
+	problem, ok := s.problem(r.PathValue("id"))
+	serial, err := strconv.Atoi(r.PathValue("serial"))
+	if !ok || err != nil || serial < 1 || serial > len(problem.JudgeCases) {
+		http.NotFound(w, r)
+		return
+	}
+
+	judgeCase := problem.JudgeCases[serial-1]
+	switch r.PathValue("kind") {
+	case "in":
+		_, _ = w.Write([]byte(judgeCase.In))
+	case "out":
+		_, _ = w.Write([]byte(judgeCase.Out))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// problem returns the problem with the ID
+func (s *Server) problem(id string) (Problem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	problem, ok := s.problems[id]
+	return problem, ok
+}
+
+// findSubmission returns the submission with the judge ID; s.mu must be held
+func (s *Server) findSubmission(judgeID string) *Submission {
+	for _, submission := range s.submissions {
+		if strconv.FormatInt(submission.JudgeID, 10) == judgeID {
+			return submission
+		}
+	}
+	return nil
+}
+
+// problemJSON returns the judge API representation of a problem
+func problemJSON(problem Problem) map[string]any {
+	return map[string]any{
+		"id":                 problem.ID,
+		"name":               problem.Name,
+		"problemTimeLimit":   problem.TimeLimit.Seconds(),
+		"problemMemoryLimit": problem.MemoryLimit,
+	}
+}
+
+// bearerToken returns the token of the Authorization header
+func bearerToken(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
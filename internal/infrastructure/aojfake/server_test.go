@@ -0,0 +1,156 @@
+package aojfake_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/aojfake"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func newServer(t *testing.T) *aojfake.Server {
+	t.Helper()
+	server := aojfake.New()
+	t.Cleanup(server.Close)
+	server.AddUser("alice", "secret")
+	server.AddProblem(aojfake.Problem{
+		ID:          "ITP1_1_A",
+		Name:        "Hello World",
+		TimeLimit:   time.Second,
+		MemoryLimit: 131072,
+		Samples:     []aojfake.Case{{In: "", Out: "Hello World\n"}},
+		JudgeCases:  []aojfake.Case{{In: "1\n", Out: "1\n"}, {In: "2\n", Out: "4\n"}, {In: "3\n", Out: "9\n"}},
+	})
+	return server
+}
+
+func TestServer_Login(t *testing.T) {
+	// Given
+	server := newServer(t)
+	auth := repository.NewAOJAuthRepository(server.URL(), nil)
+	ctx := context.Background()
+
+	// When
+	session, err := auth.Login(ctx, "alice", "secret")
+	_, wrongErr := auth.Login(ctx, "alice", "wrong")
+
+	// Then
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	assert.Equal(t, "alice", session.Username())
+	assert.True(t, cerrors.IsAppError(wrongErr, cerrors.CodeUnauthorized), "got %v", wrongErr)
+
+	valid, err := auth.ValidateSession(ctx, session)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	assert.NoError(t, auth.Logout(ctx, session))
+	valid, err = auth.ValidateSession(ctx, session)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestServer_SubmitAndWatch(t *testing.T) {
+	// Given
+	server := newServer(t)
+	server.SetVerdict("ITP1_1_A", aojfake.Verdict{
+		Status:       aojfake.StatusWrongAnswer,
+		CPUTime:      120 * time.Millisecond,
+		Memory:       2048,
+		FailedCase:   2,
+		PendingPolls: 1,
+	})
+	ctx := context.Background()
+	_, err := repository.NewAOJAuthRepository(server.URL(), nil).Login(ctx, "alice", "secret")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	submissions := repository.NewAOJSubmissionRepository(server.URL(), nil)
+	submission := entity.NewSubmission(model.NewSubmissionIDFromInt(1), model.MustNewProblemID("ITP1_1_A"), "C++17", "int main() {}")
+
+	// When
+	err = submissions.Submit(ctx, submission)
+
+	// Then
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	assert.NotEmpty(t, submission.JudgeID())
+
+	statuses, err := submissions.WatchStatus(ctx, model.MustNewSubmissionID(submission.JudgeID()), time.Millisecond)
+	assert.NoError(t, err)
+	var seen []entity.SubmissionStatus
+	for status := range statuses {
+		seen = append(seen, status)
+	}
+	assert.Equal(t, []entity.SubmissionStatus{entity.StatusJudging, entity.StatusWrongAnswer}, seen)
+
+	detail, err := submissions.GetJudgeDetail(ctx, submission)
+	assert.NoError(t, err)
+	assert.Equal(t, 120*time.Millisecond, detail.Time)
+	assert.Zero(t, detail.Score)
+	results := detail.Cases
+	submission.SetCaseResults(results)
+	assert.Equal(t, 2, submission.FailedCase())
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, entity.CaseResult{
+			Serial: 2, Label: "case2", Status: entity.StatusWrongAnswer, Time: 120 * time.Millisecond, Memory: 2048,
+		}, results[1])
+		assert.True(t, results[2].Skipped())
+	}
+
+	received := server.Submissions()
+	assert.Len(t, received, 1)
+	assert.Equal(t, "alice", received[0].UserID)
+	assert.Equal(t, "int main() {}", received[0].SourceCode)
+
+	history, err := repository.NewAOJSubmissionHistoryRepository(server.URL()).GetUserSubmissions(ctx, "alice", 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestServer_SubmitRequiresLogin(t *testing.T) {
+	server := newServer(t)
+	submission := entity.NewSubmission(model.NewSubmissionIDFromInt(1), model.MustNewProblemID("ITP1_1_A"), "C++17", "")
+
+	err := repository.NewAOJSubmissionRepository(server.URL(), nil).Submit(context.Background(), submission)
+
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeUnauthorized), "got %v", err)
+}
+
+func TestServer_TestCases(t *testing.T) {
+	// Given
+	server := newServer(t)
+	ctx := context.Background()
+	problemID := model.MustNewProblemID("ITP1_1_A")
+
+	// When
+	problem, err := repository.NewAOJProblemRepository(server.URL()).GetByID(ctx, problemID)
+
+	// Then
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	assert.Equal(t, "Hello World", problem.Title())
+	assert.Equal(t, time.Second, problem.TimeLimit())
+
+	samples, err := repository.NewAOJProblemRepository(server.URL()).GetTestCases(ctx, problemID)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+
+	testCases := repository.NewAOJTestCaseRepository(server.URL(), 0)
+	headers, err := testCases.ListHeaders(ctx, problemID)
+	assert.NoError(t, err)
+	assert.Len(t, headers, 3)
+	judgeCase, err := testCases.GetJudgeCase(ctx, problemID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "3\n", judgeCase.Input())
+	assert.Equal(t, "9\n", judgeCase.Expected())
+}
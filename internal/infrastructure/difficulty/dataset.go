@@ -0,0 +1,214 @@
+// Package difficulty provides estimated problem difficulties from JSON
+// difficulty tables such as the one maintained by the AOJ-ICPC project.
+package difficulty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// Storage bucket and key of the cached difficulty table
+const (
+	cacheBucket = "cache"
+	cacheKey    = "difficulty.json"
+)
+
+// maxTableSize bounds the size of a downloaded difficulty table
+const maxTableSize = 32 << 20
+
+// idKeys and difficultyKeys are the field names tried, in order, when a
+// table is a list of objects
+var (
+	idKeys         = []string{"problem_id", "problemId", "id"}
+	difficultyKeys = []string{"difficulty", "point", "points", "level"}
+)
+
+// Dataset implements DifficultyProvider with a JSON table read from a URL or
+// a local file, caching downloaded tables in the store
+type Dataset struct {
+	source     string
+	cache      *storage.TTLCache[cachedTable]
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewDataset creates a new Dataset reading the table at source, an http(s)
+// URL or a local path. Downloaded tables are reused for ttl, measured with clk
+func NewDataset(source string, store storage.Store, ttl time.Duration, clk clock.Clock) service.DifficultyProvider {
+	return &Dataset{
+		source:     source,
+		cache:      storage.NewTTLCache[cachedTable](store, cacheBucket, ttl, clk),
+		httpClient: httpclient.New(30 * time.Second),
+		logger:     logger.WithGroup("difficulty_dataset"),
+	}
+}
+
+// cachedTable is a downloaded table with the URL it came from, so that a
+// table cached for another URL is not used
+type cachedTable struct {
+	Source       string             `json:"source"`
+	Difficulties map[string]float64 `json:"difficulties"`
+}
+
+// Difficulties returns the table from a local file, or from the cache or the
+// URL. A stale cache is used when the URL cannot be reached
+func (d *Dataset) Difficulties(ctx context.Context) (map[string]float64, error) {
+	if !isURL(d.source) {
+		content, err := os.ReadFile(d.source)
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to read difficulty table "+d.source)
+		}
+		return parseTable(content)
+	}
+
+	cached, found, fresh := d.cache.Load(cacheKey)
+	found = found && cached.Source == d.source && len(cached.Difficulties) > 0
+	if found && (fresh || offline.IsOffline(ctx)) {
+		return cached.Difficulties, nil
+	}
+	if err := offline.Check(ctx, "downloading the difficulty table"); err != nil {
+		return nil, err
+	}
+
+	difficulties, err := d.fetch(ctx)
+	if err != nil {
+		if found {
+			d.logger.WarnContext(ctx, "failed to download difficulty table, using the cache", "error", err)
+			return cached.Difficulties, nil
+		}
+		return nil, err
+	}
+
+	if err := d.cache.Save(cacheKey, cachedTable{Source: d.source, Difficulties: difficulties}); err != nil {
+		d.logger.WarnContext(ctx, "failed to cache difficulty table", "error", err)
+	}
+	return difficulties, nil
+}
+
+// fetch downloads and parses the table
+func (d *Dataset) fetch(ctx context.Context) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", d.source, nil)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, cerrors.NewAppError(cerrors.CodeServiceUnavailable, "difficulty table is not reachable", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			d.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeServiceUnavailable,
+			"difficulty table is not available",
+			cerrors.WithDetail(cerrors.New("unexpected status"), "status_code: "+resp.Status),
+		)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxTableSize))
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to download difficulty table")
+	}
+	return parseTable(content)
+}
+
+// parseTable decodes a table that is either an object of problem IDs to
+// difficulties or a list of objects with an ID and a difficulty field
+func parseTable(content []byte) (map[string]float64, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(content, &object); err == nil {
+		difficulties := make(map[string]float64, len(object))
+		for id, value := range object {
+			if difficulty, ok := parseNumber(value); ok {
+				difficulties[id] = difficulty
+			}
+		}
+		return nonEmpty(difficulties)
+	}
+
+	var list []map[string]json.RawMessage
+	if err := json.Unmarshal(content, &list); err != nil {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "difficulty table is not a JSON object or list", err)
+	}
+	difficulties := make(map[string]float64, len(list))
+	for _, entry := range list {
+		id, ok := problemID(entry, idKeys)
+		if !ok {
+			continue
+		}
+		for _, key := range difficultyKeys {
+			if difficulty, ok := parseNumber(entry[key]); ok {
+				difficulties[id] = difficulty
+				break
+			}
+		}
+	}
+	return nonEmpty(difficulties)
+}
+
+// nonEmpty rejects tables without any difficulty, which are most likely in another format
+func nonEmpty(difficulties map[string]float64) (map[string]float64, error) {
+	if len(difficulties) == 0 {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "difficulty table has no problem with a difficulty", nil)
+	}
+	return difficulties, nil
+}
+
+// parseNumber decodes a JSON number or a string holding one
+func parseNumber(value json.RawMessage) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	var number float64
+	if err := json.Unmarshal(value, &number); err == nil {
+		return number, true
+	}
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			return number, true
+		}
+	}
+	return 0, false
+}
+
+// problemID returns the first of keys whose value is a problem ID. Numeric
+// IDs are volume problems, padded to four digits like 0001
+func problemID(entry map[string]json.RawMessage, keys []string) (string, bool) {
+	for _, key := range keys {
+		var text string
+		if err := json.Unmarshal(entry[key], &text); err == nil && text != "" {
+			return text, true
+		}
+		var number int
+		if err := json.Unmarshal(entry[key], &number); err == nil && number > 0 {
+			return fmt.Sprintf("%04d", number), true
+		}
+	}
+	return "", false
+}
+
+// isURL reports whether source is an http or https URL rather than a path
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
@@ -0,0 +1,106 @@
+package difficulty
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+func TestParseTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		want     map[string]float64
+		wantCode cerrors.ErrorCode
+	}{
+		{
+			name:    "object",
+			content: `{"ITP1_1_A": 100, "2200": 350.5, "1160": "400", "note": "unrated"}`,
+			want:    map[string]float64{"ITP1_1_A": 100, "2200": 350.5, "1160": 400},
+		},
+		{
+			name:    "list",
+			content: `[{"id": "2200", "point": 350}, {"problem_id": "ITP1_1_A", "difficulty": 100}, {"id": 1, "level": 50}, {"id": "1160"}]`,
+			want:    map[string]float64{"2200": 350, "ITP1_1_A": 100, "0001": 50},
+		},
+		{name: "no difficulties", content: `{"problems": []}`, wantCode: cerrors.CodeInvalidInput},
+		{name: "not JSON", content: `<html></html>`, wantCode: cerrors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			difficulties, err := parseTable([]byte(tt.content))
+
+			// Then
+			if tt.wantCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, difficulties)
+		})
+	}
+}
+
+func TestDataset_Difficulties_DownloadsAndCaches(t *testing.T) {
+	// Given
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"2200": 350}`))
+	}))
+	defer server.Close()
+	store := storage.NewFileStore(t.TempDir())
+	dataset := NewDataset(server.URL, store, time.Hour, clock.System())
+	ctx := context.Background()
+
+	// When
+	difficulties, err := dataset.Difficulties(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"2200": 350}, difficulties)
+
+	// When - a second call and an offline call are served from the cache
+	_, errCached := dataset.Difficulties(ctx)
+	_, errOffline := NewDataset(server.URL, store, 0, clock.System()).Difficulties(offline.WithOffline(ctx, true))
+
+	// Then
+	assert.NoError(t, errCached)
+	assert.NoError(t, errOffline)
+	assert.Equal(t, 1, requests)
+
+	// When - the cache of another source is not used
+	_, err = NewDataset(server.URL+"/other.json", store, time.Hour, clock.System()).Difficulties(offline.WithOffline(ctx, true))
+
+	// Then
+	assert.Error(t, err)
+}
+
+func TestDataset_Difficulties_LocalFile(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "difficulty.json")
+	if err := os.WriteFile(path, []byte(`[{"id": "1160", "point": 400}]`), 0644); err != nil {
+		t.Fatalf("failed to write table: %v", err)
+	}
+	dataset := NewDataset(path, storage.NewFileStore(t.TempDir()), time.Hour, clock.System())
+
+	// When
+	difficulties, err := dataset.Difficulties(offline.WithOffline(context.Background(), true))
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"1160": 400}, difficulties)
+}
@@ -0,0 +1,71 @@
+// Package httpclient provides the HTTP client used to call the AOJ API.
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// New creates an HTTP client that logs every request at debug level and
+// retries idempotent requests that fail with temporary errors
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewRetryTransport(NewLoggingTransport(http.DefaultTransport)),
+	}
+}
+
+// LoggingTransport is an http.RoundTripper that logs the method, URL, status and
+// latency of each request with secrets masked
+type LoggingTransport struct {
+	next   http.RoundTripper
+	logger *logger.Logger
+}
+
+// NewLoggingTransport creates a LoggingTransport that delegates to next
+func NewLoggingTransport(next http.RoundTripper) *LoggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &LoggingTransport{
+		next:   next,
+		logger: logger.WithGroup("http"),
+	}
+}
+
+// RoundTrip executes the request and logs its outcome. With tracing on, the
+// request is also traced before it is sent
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if trace.Enabled() {
+		trace.Request(req.Method, redact.URL(req.URL), RedactBody(req))
+	}
+	if !t.logger.Enabled(ctx, logger.LevelDebug) {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	attrs := []any{
+		"method", req.Method,
+		"url", redact.URL(req.URL),
+		"latency", latency,
+		"request_headers", redact.Headers(req.Header),
+	}
+	if err != nil {
+		t.logger.DebugContext(ctx, "http request failed", append(attrs, "error", err)...)
+		return nil, err
+	}
+
+	t.logger.DebugContext(ctx, "http request",
+		append(attrs,
+			"status", resp.StatusCode,
+			"response_headers", redact.Headers(resp.Header))...)
+	return resp, nil
+}
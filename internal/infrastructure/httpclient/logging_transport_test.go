@@ -0,0 +1,49 @@
+package httpclient
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+func TestLoggingTransport_RoundTrip(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	original := logger.Global()
+	logger.SetGlobal(logger.New(logger.Config{Level: logger.LevelDebug, Format: logger.FormatText, Output: &buf}))
+	defer logger.SetGlobal(original)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "server-secret"})
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	client := New(5 * time.Second)
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/session?token=query-secret&lang=ja", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer header-secret")
+	req.AddCookie(&http.Cookie{Name: "JSESSIONID", Value: "cookie-secret"})
+
+	// When
+	resp, err := client.Do(req)
+
+	// Then
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+
+	output := buf.String()
+	assert.Contains(t, output, "method=GET")
+	assert.Contains(t, output, "status=418")
+	assert.Contains(t, output, "latency=")
+	assert.Contains(t, output, "lang=ja")
+	for _, secret := range []string{"query-secret", "header-secret", "cookie-secret", "server-secret"} {
+		assert.NotContains(t, output, secret)
+	}
+}
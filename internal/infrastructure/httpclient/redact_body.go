@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
+)
+
+const (
+	// maxRedactedBody is how much of a request body is read for tracing
+	maxRedactedBody = 64 << 10
+	// maxRedactedValue is how many characters of a long value, such as a
+	// submitted source, are kept
+	maxRedactedValue = 60
+)
+
+// RedactBody returns the body of req for tracing, with secrets masked and
+// long values shortened. JSON and form bodies are shown; other bodies only
+// by size. The body of req is left unread
+func RedactBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if req.GetBody == nil {
+		return "[body]"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "[body]"
+	}
+	defer func() { _ = body.Close() }()
+	content, err := io.ReadAll(io.LimitReader(body, maxRedactedBody+1))
+	if err != nil {
+		return "[body]"
+	}
+	if len(content) > maxRedactedBody {
+		return fmt.Sprintf("[more than %d bytes]", maxRedactedBody)
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "json"):
+		var value any
+		if err := json.Unmarshal(content, &value); err == nil {
+			if redactedJSON, err := json.Marshal(redactValue(value)); err == nil {
+				return string(redactedJSON)
+			}
+		}
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(string(content)); err == nil {
+			return redact.Form(form).Encode()
+		}
+	}
+	return fmt.Sprintf("[%d bytes]", len(content))
+}
+
+// redactValue masks the values of sensitive keys in decoded JSON and shortens long strings
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if redact.IsSensitiveKey(key) {
+				v[key] = redact.Placeholder
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	case string:
+		if utf8.RuneCountInString(v) > maxRedactedValue {
+			return fmt.Sprintf("%s... (%d bytes)", string([]rune(v)[:maxRedactedValue]), len(v))
+		}
+	}
+	return value
+}
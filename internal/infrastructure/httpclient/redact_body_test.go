@@ -0,0 +1,95 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+func TestRedactBody(t *testing.T) {
+	longSource := strings.Repeat("x", 100)
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{name: "no body", want: ""},
+		{
+			name:        "json",
+			contentType: "application/json;charset=UTF-8",
+			body:        `{"id":"alice","password":"hunter2"}`,
+			want:        `{"id":"alice","password":"[REDACTED]"}`,
+		},
+		{
+			name:        "long json value",
+			contentType: "application/json",
+			body:        `{"sourceCode":"` + longSource + `"}`,
+			want:        `{"sourceCode":"` + longSource[:maxRedactedValue] + `... (100 bytes)"}`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "user=alice&token=abc",
+			want:        "token=%5BREDACTED%5D&user=alice",
+		},
+		{name: "other", contentType: "application/octet-stream", body: "abc", want: "[3 bytes]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(http.MethodPost, "https://judgeapi.u-aizu.ac.jp/session", body)
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", tt.contentType)
+
+			// When
+			got := RedactBody(req)
+
+			// Then
+			assert.Equal(t, tt.want, got)
+			if tt.body != "" {
+				unread, _ := io.ReadAll(req.Body)
+				assert.Equal(t, tt.body, string(unread), "the request body is left unread")
+			}
+		})
+	}
+}
+
+func TestLoggingTransport_RoundTrip_Trace(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	trace.Enable(&buf)
+	defer trace.Enable(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"id":"alice","password":"hunter2"}`, string(received))
+	}))
+	defer server.Close()
+
+	client := New(5 * time.Second)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/session", strings.NewReader(`{"id":"alice","password":"hunter2"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	// When
+	resp, err := client.Do(req)
+
+	// Then
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "trace: POST "+server.URL+`/session {"id":"alice","password":"[REDACTED]"}`+"\n", buf.String())
+}
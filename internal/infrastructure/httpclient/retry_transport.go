@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+const (
+	// defaultRetryAttempts is how often an idempotent request is sent at most
+	defaultRetryAttempts = 3
+	// defaultRetryBackoff is the delay before the second attempt, doubled for each further one
+	defaultRetryBackoff = 500 * time.Millisecond
+	// maxRetryDelay caps how long a Retry-After header can delay the next attempt
+	maxRetryDelay = 10 * time.Second
+)
+
+// retryableStatuses are responses of AOJ or a proxy in front of it that
+// usually go away by themselves
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// RetryTransport is an http.RoundTripper that sends idempotent requests again
+// with doubling delays when they fail with a retryable error, see
+// cerrors.IsRetryable, or a retryable status. Other requests, such as
+// submissions, are sent once; their callers decide whether to retry
+type RetryTransport struct {
+	next     http.RoundTripper
+	attempts int
+	backoff  time.Duration
+	logger   *logger.Logger
+}
+
+// NewRetryTransport creates a RetryTransport with the default attempts and backoff
+func NewRetryTransport(next http.RoundTripper) *RetryTransport {
+	return NewRetryTransportWithBackoff(next, defaultRetryAttempts, defaultRetryBackoff)
+}
+
+// NewRetryTransportWithBackoff creates a RetryTransport that sends a request
+// at most attempts times, waiting backoff before the second attempt
+func NewRetryTransportWithBackoff(next http.RoundTripper, attempts int, backoff time.Duration) *RetryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RetryTransport{
+		next:     next,
+		attempts: max(attempts, 1),
+		backoff:  backoff,
+		logger:   logger.WithGroup("http"),
+	}
+}
+
+// RoundTrip sends the request, retrying it while that may help
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	delay := t.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.attempts || ctx.Err() != nil {
+			return resp, err
+		}
+		wait := delay
+		switch {
+		case err != nil:
+			if !cerrors.IsRetryable(err) {
+				return nil, err
+			}
+		case retryableStatuses[resp.StatusCode]:
+			if after := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); after > 0 {
+				wait = min(after, maxRetryDelay)
+			}
+			_ = resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		t.logger.DebugContext(ctx, "retrying request", "method", req.Method, "attempt", attempt, "delay", wait, "error", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// isIdempotent reports whether sending req twice has the same effect as once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	}
+	return false
+}
+
+// ParseRetryAfter converts a Retry-After header, given in seconds or as an
+// HTTP date, to a delay. Missing, invalid and past values are 0
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		delay = at.Sub(now)
+	}
+	return max(delay, 0)
+}
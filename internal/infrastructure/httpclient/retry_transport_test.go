@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		statuses  []int
+		wantCalls int32
+		want      int
+	}{
+		{name: "retries a temporary status", method: http.MethodGet, statuses: []int{503, 502, 200}, wantCalls: 3, want: 200},
+		{name: "gives up after the last attempt", method: http.MethodGet, statuses: []int{503, 503, 503, 200}, wantCalls: 3, want: 503},
+		{name: "does not retry a permanent status", method: http.MethodGet, statuses: []int{404, 200}, wantCalls: 1, want: 404},
+		{name: "does not retry submissions", method: http.MethodPost, statuses: []int{503, 200}, wantCalls: 1, want: 503},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.statuses[calls.Add(1)-1])
+			}))
+			defer server.Close()
+			client := &http.Client{Transport: NewRetryTransportWithBackoff(http.DefaultTransport, 3, time.Millisecond)}
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(""))
+			require.NoError(t, err)
+
+			// When
+			resp, err := client.Do(req)
+
+			// Then
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.want, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestRetryTransport_NetworkError(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := server.URL
+	server.Close()
+	client := &http.Client{Transport: NewRetryTransportWithBackoff(http.DefaultTransport, 2, time.Millisecond)}
+
+	// When
+	start := time.Now()
+	_, err := client.Get(url)
+
+	// Then
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 3*time.Second, ParseRetryAfter("3", now))
+	assert.Equal(t, 2*time.Minute, ParseRetryAfter(now.Add(2*time.Minute).Format(http.TimeFormat), now))
+	assert.Zero(t, ParseRetryAfter("-1", now))
+	assert.Zero(t, ParseRetryAfter("soon", now))
+}
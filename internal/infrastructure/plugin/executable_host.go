@@ -0,0 +1,150 @@
+// Package plugin runs aoj-<name> executables found on the search path as subcommands.
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ExecutableHost implements PluginHost with executables in the directories
+// of a PATH-style list
+type ExecutableHost struct {
+	path   string
+	logger *logger.Logger
+}
+
+// NewExecutableHost creates a new ExecutableHost that searches the
+// directories of the PATH environment variable
+func NewExecutableHost() service.PluginHost {
+	return NewExecutableHostWithPath(os.Getenv("PATH"))
+}
+
+// NewExecutableHostWithPath creates a new ExecutableHost that searches the
+// directories of path, separated like PATH
+func NewExecutableHostWithPath(path string) service.PluginHost {
+	return &ExecutableHost{
+		path:   path,
+		logger: logger.WithGroup("plugin_host"),
+	}
+}
+
+// List returns the plugins found in the search path, in name order
+func (h *ExecutableHost) List(ctx context.Context) ([]service.Plugin, error) {
+	seen := make(map[string]bool)
+	var plugins []service.Plugin
+	for _, dir := range filepath.SplitList(h.path) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			h.logger.DebugContext(ctx, "skipping search path entry", "dir", dir, "error", err)
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || seen[name] || !isExecutable(filepath.Join(dir, entry.Name())) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, service.Plugin{Name: name, Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins, nil
+}
+
+// Find returns the first plugin on the search path providing the subcommand
+func (h *ExecutableHost) Find(ctx context.Context, name string) (*service.Plugin, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "invalid plugin name '"+name+"'", nil)
+	}
+
+	plugins, err := h.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, plugin := range plugins {
+		if plugin.Name == name {
+			return &plugin, nil
+		}
+	}
+	return nil, cerrors.NewAppError(
+		cerrors.CodeNotFound,
+		"no plugin '"+service.PluginPrefix+name+"' on PATH",
+		nil,
+	)
+}
+
+// Run executes the plugin attached to the standard streams. A plugin that
+// exits with a non-zero status is not an error; its status is returned
+func (h *ExecutableHost) Run(ctx context.Context, plugin service.Plugin, args []string, env []string) (int, error) {
+	h.logger.DebugContext(ctx, "running plugin", "name", plugin.Name, "path", plugin.Path, "args", args)
+
+	cmd := exec.CommandContext(ctx, plugin.Path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), env...)
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, cerrors.Wrap(err, "failed to run plugin "+plugin.Name)
+	}
+	return 0, nil
+}
+
+// pluginName returns the subcommand name of an executable file name. On
+// Windows the executable extension is removed
+func pluginName(fileName string) (string, bool) {
+	name, ok := strings.CutPrefix(fileName, service.PluginPrefix)
+	if !ok {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		ext := filepath.Ext(name)
+		if !isWindowsExecutableExt(ext) {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name, name != ""
+}
+
+// isExecutable returns true if path is a regular file the user may execute
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// isWindowsExecutableExt returns true if ext is listed in PATHEXT
+func isWindowsExecutableExt(ext string) bool {
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".com;.exe;.bat;.cmd"
+	}
+	for _, candidate := range strings.Split(pathExt, ";") {
+		if candidate != "" && strings.EqualFold(candidate, ext) {
+			return true
+		}
+	}
+	return false
+}
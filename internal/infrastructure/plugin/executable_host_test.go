@@ -0,0 +1,69 @@
+//go:build !windows
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func writeScript(t *testing.T, dir, name, body string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), mode); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func TestExecutableHost_List(t *testing.T) {
+	// Given
+	first, second := t.TempDir(), t.TempDir()
+	writeScript(t, first, "aoj-graph", "exit 0", 0755)
+	writeScript(t, first, "aoj-notexec", "exit 0", 0644)
+	writeScript(t, first, "other-tool", "exit 0", 0755)
+	writeScript(t, second, "aoj-graph", "exit 1", 0755)
+	writeScript(t, second, "aoj-calendar", "exit 0", 0755)
+	host := NewExecutableHostWithPath(first + string(os.PathListSeparator) + filepath.Join(first, "missing") + string(os.PathListSeparator) + second)
+
+	// When
+	plugins, err := host.List(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, "calendar", plugins[0].Name)
+	assert.Equal(t, "graph", plugins[1].Name)
+	assert.Equal(t, filepath.Join(first, "aoj-graph"), plugins[1].Path, "the first directory on the path wins")
+}
+
+func TestExecutableHost_FindAndRun(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	writeScript(t, dir, "aoj-echo", `echo "$1 $AOJ_TEST_VALUE" > "`+out+`"; exit 3`, 0755)
+	host := NewExecutableHostWithPath(dir)
+	ctx := context.Background()
+
+	// When
+	plugin, err := host.Find(ctx, "echo")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	code, runErr := host.Run(ctx, *plugin, []string{"hello"}, []string{"AOJ_TEST_VALUE=world"})
+	_, missingErr := host.Find(ctx, "missing")
+	_, invalidErr := host.Find(ctx, "../echo")
+
+	// Then
+	assert.NoError(t, runErr)
+	assert.Equal(t, 3, code)
+	content, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\n", string(content))
+	assert.True(t, cerrors.IsAppError(missingErr, cerrors.CodeNotFound), "got %v", missingErr)
+	assert.True(t, cerrors.IsAppError(invalidErr, cerrors.CodeInvalidInput), "got %v", invalidErr)
+}
@@ -0,0 +1,136 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// cronMarker ends the crontab lines of installed jobs, followed by the job name
+const cronMarker = "# aoj-cli:"
+
+// desktopEnvVars are copied into cron jobs, which otherwise cannot reach the
+// desktop session to show notifications
+var desktopEnvVars = []string{"DISPLAY", "WAYLAND_DISPLAY", "DBUS_SESSION_BUS_ADDRESS", "XDG_RUNTIME_DIR"}
+
+// Cron installs jobs in the crontab of the current user
+type Cron struct {
+	crontab func(ctx context.Context, stdin string, args ...string) (string, error)
+	getenv  func(string) string
+	logger  *logger.Logger
+}
+
+// NewCron creates a Cron that edits the crontab with the crontab command
+func NewCron() *Cron {
+	return &Cron{
+		crontab: func(ctx context.Context, stdin string, args ...string) (string, error) {
+			return run(ctx, stdin, "crontab", args...)
+		},
+		getenv: os.Getenv,
+		logger: logger.WithGroup("cron"),
+	}
+}
+
+// Install replaces the crontab line of the job
+func (c *Cron) Install(ctx context.Context, job service.DailyJob) (string, error) {
+	lines, _, err := c.read(ctx, job.Name)
+	if err != nil {
+		return "", err
+	}
+	lines = append(lines, c.line(job))
+	if err := c.write(ctx, lines); err != nil {
+		return "", err
+	}
+	c.logger.InfoContext(ctx, "cron job installed", "name", job.Name)
+	return "crontab", nil
+}
+
+// Uninstall removes the crontab line of the job
+func (c *Cron) Uninstall(ctx context.Context, name string) (bool, error) {
+	lines, found, err := c.read(ctx, name)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := c.write(ctx, lines); err != nil {
+		return false, err
+	}
+	c.logger.InfoContext(ctx, "cron job removed", "name", name)
+	return true, nil
+}
+
+// read returns the lines of the crontab without those of the named job, and
+// whether there were any
+func (c *Cron) read(ctx context.Context, name string) ([]string, bool, error) {
+	content, err := c.crontab(ctx, "", "-l")
+	if err != nil {
+		// crontab -l fails for users without a crontab
+		if !strings.Contains(err.Error(), "no crontab") {
+			return nil, false, err
+		}
+		content = ""
+	}
+
+	var (
+		lines []string
+		found bool
+	)
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		if strings.HasSuffix(line, cronMarker+name) {
+			found = true
+			continue
+		}
+		if line != "" || len(lines) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, found, nil
+}
+
+// write replaces the crontab
+func (c *Cron) write(ctx context.Context, lines []string) error {
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	_, err := c.crontab(ctx, content, "-")
+	return err
+}
+
+// line returns the crontab line of a job
+func (c *Cron) line(job service.DailyJob) string {
+	env := make(map[string]string, len(job.Env)+len(desktopEnvVars))
+	for _, name := range desktopEnvVars {
+		if value := c.getenv(name); value != "" {
+			env[name] = value
+		}
+	}
+	for name, value := range job.Env {
+		env[name] = value
+	}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names)+len(job.Command))
+	for _, name := range names {
+		parts = append(parts, name+"="+cronQuote(env[name]))
+	}
+	for _, arg := range job.Command {
+		parts = append(parts, cronQuote(arg))
+	}
+	return fmt.Sprintf("%d %d * * * %s %s%s", job.Minute, job.Hour, strings.Join(parts, " "), cronMarker, job.Name)
+}
+
+// cronQuote quotes a word for the shell cron runs commands with; % starts
+// the standard input of the command in a crontab and is escaped
+func cronQuote(word string) string {
+	quoted := "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+	return strings.ReplaceAll(quoted, "%", `\%`)
+}
@@ -0,0 +1,81 @@
+package reminder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// newTestCron returns a Cron editing the crontab in *content; an empty
+// content means that the user has no crontab
+func newTestCron(content *string, env map[string]string) *Cron {
+	return &Cron{
+		crontab: func(_ context.Context, stdin string, args ...string) (string, error) {
+			if args[0] == "-l" {
+				if *content == "" {
+					return "", cerrors.New("crontab failed: no crontab for alice")
+				}
+				return *content, nil
+			}
+			*content = stdin
+			return "", nil
+		},
+		getenv: func(name string) string { return env[name] },
+		logger: logger.WithGroup("cron"),
+	}
+}
+
+func TestCron_Install(t *testing.T) {
+	// Given
+	content := "MAILTO=alice\n0 1 * * * backup.sh\n30 6 * * * '/old/aoj' 'remind' 'check' # aoj-cli:remind\n"
+	cron := newTestCron(&content, map[string]string{"DISPLAY": ":0"})
+
+	// When
+	_, err := cron.Install(context.Background(), service.DailyJob{
+		Name:    "remind",
+		Command: []string{"/usr/bin/aoj", "--config-dir", "/home/alice/it's", "remind", "check"},
+		Hour:    21,
+		Minute:  5,
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "MAILTO=alice\n0 1 * * * backup.sh\n"+
+		`5 21 * * * DISPLAY=':0' '/usr/bin/aoj' '--config-dir' '/home/alice/it'\''s' 'remind' 'check' # aoj-cli:remind`+"\n", content)
+}
+
+func TestCron_InstallWithoutCrontab(t *testing.T) {
+	// Given
+	content := ""
+	cron := newTestCron(&content, nil)
+
+	// When
+	_, err := cron.Install(context.Background(), service.DailyJob{Name: "remind", Command: []string{"date +%F"}, Hour: 8})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `0 8 * * * 'date +\%F' # aoj-cli:remind`+"\n", content)
+}
+
+func TestCron_Uninstall(t *testing.T) {
+	// Given
+	content := "0 1 * * * backup.sh\n5 21 * * * 'aoj' # aoj-cli:remind\n"
+	cron := newTestCron(&content, nil)
+
+	// When
+	removed, err := cron.Uninstall(context.Background(), "remind")
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.Equal(t, "0 1 * * * backup.sh\n", content)
+
+	removed, err = cron.Uninstall(context.Background(), "remind")
+	assert.NoError(t, err)
+	assert.False(t, removed)
+}
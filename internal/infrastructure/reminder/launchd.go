@@ -0,0 +1,133 @@
+package reminder
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/filelock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// launchdLabelPrefix starts the labels of installed launch agents, followed by the job name
+const launchdLabelPrefix = "io.github.aoj-cli."
+
+// Launchd installs jobs as launch agents of the current user
+type Launchd struct {
+	agentDir  string
+	launchctl func(ctx context.Context, args ...string) error
+	logger    *logger.Logger
+}
+
+// NewLaunchd creates a Launchd that writes agents to agentDir, by default
+// ~/Library/LaunchAgents
+func NewLaunchd(agentDir string) (*Launchd, error) {
+	if agentDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to find the home directory")
+		}
+		agentDir = filepath.Join(home, "Library", "LaunchAgents")
+	}
+	return &Launchd{
+		agentDir: agentDir,
+		launchctl: func(ctx context.Context, args ...string) error {
+			_, err := run(ctx, "", "launchctl", args...)
+			return err
+		},
+		logger: logger.WithGroup("launchd"),
+	}, nil
+}
+
+// Install writes the agent of the job and loads it
+func (l *Launchd) Install(ctx context.Context, job service.DailyJob) (string, error) {
+	path := l.path(job.Name)
+	if err := os.MkdirAll(l.agentDir, 0755); err != nil {
+		return "", cerrors.Wrap(err, "failed to create "+l.agentDir)
+	}
+	// A loaded agent keeps its old schedule until it is unloaded
+	if _, err := os.Stat(path); err == nil {
+		_ = l.launchctl(ctx, "unload", path)
+	}
+	if err := filelock.WriteFileAtomic(path, launchdPlist(job), 0644); err != nil {
+		return "", cerrors.Wrap(err, "failed to write "+path)
+	}
+	if err := l.launchctl(ctx, "load", "-w", path); err != nil {
+		return "", err
+	}
+	l.logger.InfoContext(ctx, "launch agent installed", "path", path)
+	return path, nil
+}
+
+// Uninstall unloads the agent of the job and deletes it
+func (l *Launchd) Uninstall(ctx context.Context, name string) (bool, error) {
+	path := l.path(name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false, nil
+	}
+	_ = l.launchctl(ctx, "unload", path)
+	trace.File("remove", path)
+	if err := os.Remove(path); err != nil {
+		return false, cerrors.Wrap(err, "failed to delete "+path)
+	}
+	l.logger.InfoContext(ctx, "launch agent removed", "path", path)
+	return true, nil
+}
+
+// path returns the file of a job's agent
+func (l *Launchd) path(name string) string {
+	return filepath.Join(l.agentDir, launchdLabelPrefix+name+".plist")
+}
+
+// launchdPlist returns the property list of a job's agent
+func launchdPlist(job service.DailyJob) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	writePlistString(&b, "\t", "Label", launchdLabelPrefix+job.Name)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range job.Command {
+		b.WriteString("\t\t<string>" + xmlEscape(arg) + "</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	if len(job.Env) > 0 {
+		names := make([]string, 0, len(job.Env))
+		for name := range job.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		for _, name := range names {
+			writePlistString(&b, "\t\t", name, job.Env[name])
+		}
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("\t<key>StartCalendarInterval</key>\n\t<dict>\n")
+	b.WriteString("\t\t<key>Hour</key>\n\t\t<integer>" + strconv.Itoa(job.Hour) + "</integer>\n")
+	b.WriteString("\t\t<key>Minute</key>\n\t\t<integer>" + strconv.Itoa(job.Minute) + "</integer>\n")
+	b.WriteString("\t</dict>\n</dict>\n</plist>\n")
+	return []byte(b.String())
+}
+
+// writePlistString writes a key with a string value
+func writePlistString(b *strings.Builder, indent, key, value string) {
+	b.WriteString(indent + "<key>" + xmlEscape(key) + "</key>\n")
+	b.WriteString(indent + "<string>" + xmlEscape(value) + "</string>\n")
+}
+
+// xmlEscape escapes text for an XML element
+func xmlEscape(text string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
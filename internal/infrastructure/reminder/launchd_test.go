@@ -0,0 +1,55 @@
+package reminder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+)
+
+func TestLaunchd_InstallAndUninstall(t *testing.T) {
+	// Given
+	launchd, err := NewLaunchd(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLaunchd failed: %v", err)
+	}
+	var calls []string
+	launchd.launchctl = func(_ context.Context, args ...string) error {
+		calls = append(calls, strings.Join(args[:len(args)-1], " ")+" "+filepath.Base(args[len(args)-1]))
+		return nil
+	}
+
+	// When
+	path, err := launchd.Install(context.Background(), service.DailyJob{
+		Name:    "remind",
+		Command: []string{"/usr/local/bin/aoj", "remind", "check"},
+		Env:     map[string]string{"LANG": "en_US.UTF-8"},
+		Hour:    20,
+		Minute:  0,
+	})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	plist, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("agent was not written: %v", err)
+	}
+	assert.Contains(t, string(plist), "<string>io.github.aoj-cli.remind</string>")
+	assert.Contains(t, string(plist), "\t\t<string>/usr/local/bin/aoj</string>\n\t\t<string>remind</string>\n\t\t<string>check</string>\n")
+	assert.Contains(t, string(plist), "<key>LANG</key>\n\t\t<string>en_US.UTF-8</string>")
+	assert.Contains(t, string(plist), "<key>Hour</key>\n\t\t<integer>20</integer>")
+	assert.Equal(t, []string{"load -w io.github.aoj-cli.remind.plist"}, calls)
+
+	removed, err := launchd.Uninstall(context.Background(), "remind")
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.NoFileExists(t, path)
+	assert.Equal(t, "unload io.github.aoj-cli.remind.plist", calls[len(calls)-1])
+}
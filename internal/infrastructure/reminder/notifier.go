@@ -0,0 +1,38 @@
+package reminder
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// Desktop shows notifications with osascript on macOS and notify-send elsewhere
+type Desktop struct{}
+
+// NewDesktopNotifier creates a notifier for the desktop of the current user
+func NewDesktopNotifier() service.Notifier {
+	return Desktop{}
+}
+
+// Notify shows a notification
+func (Desktop) Notify(ctx context.Context, title, message string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + appleScriptString(message) + " with title " + appleScriptString(title)
+		_, err := run(ctx, "", "osascript", "-e", script)
+		return err
+	case "windows":
+		return cerrors.NewAppError(cerrors.CodeInvalidInput, "desktop notifications are not supported on Windows", nil)
+	default:
+		_, err := run(ctx, "", "notify-send", "--app-name", "aoj", title, message)
+		return err
+	}
+}
+
+// appleScriptString quotes text as an AppleScript string literal
+func appleScriptString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,65 @@
+// Package reminder schedules the daily practice reminder with cron or launchd
+// and shows it as a desktop notification.
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// NewScheduler returns the scheduler of the current operating system:
+// launchd on macOS and cron on other Unix systems. On Windows, and when no
+// scheduler can be set up, the returned scheduler fails with the reason
+func NewScheduler() service.Scheduler {
+	switch runtime.GOOS {
+	case "darwin":
+		launchd, err := NewLaunchd("")
+		if err != nil {
+			return unsupported{err: err}
+		}
+		return launchd
+	case "windows":
+		return unsupported{err: cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeInvalidInput, "scheduled reminders need cron or launchd, which Windows does not have", nil),
+			"Create a daily task that runs 'aoj remind check' in the Task Scheduler",
+		)}
+	default:
+		return NewCron()
+	}
+}
+
+// unsupported is the scheduler of systems where jobs cannot be installed
+type unsupported struct {
+	err error
+}
+
+func (u unsupported) Install(context.Context, service.DailyJob) (string, error) {
+	return "", u.err
+}
+
+func (u unsupported) Uninstall(context.Context, string) (bool, error) {
+	return false, u.err
+}
+
+// run runs a program with the given standard input and returns its standard output
+func run(ctx context.Context, stdin string, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return stdout.String(), cerrors.NewAppError(cerrors.CodeInternalServer, name+" failed: "+message, err)
+	}
+	return stdout.String(), nil
+}
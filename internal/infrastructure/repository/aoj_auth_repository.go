@@ -11,25 +11,31 @@ import (
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
 )
 
 // AOJAuthRepository implements AuthRepository for AOJ API
 type AOJAuthRepository struct {
 	baseURL    string
 	httpClient *http.Client
+	clock      clock.Clock // tells when sessions are created and expire
 	logger     *logger.Logger
 }
 
-// NewAOJAuthRepository creates a new AOJAuthRepository
-func NewAOJAuthRepository(baseURL string) repository.AuthRepository {
+// NewAOJAuthRepository creates a new AOJAuthRepository. A nil clk means the system clock
+func NewAOJAuthRepository(baseURL string, clk clock.Clock) repository.AuthRepository {
+	if clk == nil {
+		clk = clock.System()
+	}
 	return &AOJAuthRepository{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger.WithGroup("aoj_auth_repository"),
+		baseURL:    baseURL,
+		httpClient: httpclient.New(30 * time.Second),
+		clock:      clk,
+		logger:     logger.WithGroup("aoj_auth_repository"),
 	}
 }
 
@@ -51,6 +57,10 @@ type LoginResponse struct {
 func (r *AOJAuthRepository) Login(ctx context.Context, username, password string) (*entity.Session, error) {
 	r.logger.InfoContext(ctx, "attempting AOJ login", "username", username)
 
+	if err := offline.Check(ctx, "logging in"); err != nil {
+		return nil, err
+	}
+
 	// Prepare request payload
 	loginReq := LoginRequest{
 		ID:       username,
@@ -75,11 +85,7 @@ func (r *AOJAuthRepository) Login(ctx context.Context, username, password string
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
-		return nil, cerrors.NewAppError(
-			cerrors.CodeNetworkError,
-			"failed to connect to AOJ",
-			err,
-		)
+		return nil, cerrors.ErrAOJUnreachable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -105,11 +111,7 @@ func (r *AOJAuthRepository) Login(ctx context.Context, username, password string
 			nil,
 		)
 	case http.StatusInternalServerError:
-		return nil, cerrors.NewAppError(
-			cerrors.CodeServiceUnavailable,
-			"AOJ server error",
-			nil,
-		)
+		return nil, cerrors.ErrJudgeUnavailable(resp.Status)
 	default:
 		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
 		return nil, cerrors.NewAppError(
@@ -134,11 +136,13 @@ func (r *AOJAuthRepository) parseLoginResponse(ctx context.Context, resp *http.R
 	}
 
 	// Create session entity
-	session := entity.NewSessionWithDuration(
+	now := r.clock.Now()
+	session := entity.NewSessionAt(
 		sessionID,
 		loginResp.ID,
 		loginResp.Token,
-		24*time.Hour, // AOJ sessions typically last 24 hours
+		now.Add(24*time.Hour), // AOJ sessions typically last 24 hours
+		now,
 	)
 
 	r.logger.InfoContext(ctx, "login successful", 
@@ -218,11 +222,13 @@ func (r *AOJAuthRepository) RefreshSession(ctx context.Context, session *entity.
 		return nil, cerrors.Wrap(err, "failed to generate new session ID")
 	}
 
-	refreshedSession := entity.NewSessionWithDuration(
+	now := r.clock.Now()
+	refreshedSession := entity.NewSessionAt(
 		newSessionID,
 		session.Username(),
 		session.Token(), // Keep the same token or get a new one from AOJ
-		24*time.Hour,
+		now.Add(24*time.Hour),
+		now,
 	)
 
 	r.logger.InfoContext(ctx, "session refreshed", 
@@ -238,7 +244,7 @@ func (r *AOJAuthRepository) ValidateSession(ctx context.Context, session *entity
 		"session_id", session.ID().MaskedString())
 
 	// Check if session is expired locally first
-	if session.IsExpired() {
+	if session.IsExpiredAt(r.clock.Now()) {
 		r.logger.DebugContext(ctx, "session is locally expired")
 		return false, nil
 	}
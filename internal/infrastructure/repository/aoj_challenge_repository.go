@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// challengeCacheBucket is the storage bucket of the cached contest archives
+const challengeCacheBucket = "cache"
+
+// challengeCacheTTL is how long a fetched archive is reused; new contests are added once a year
+const challengeCacheTTL = 7 * 24 * time.Hour
+
+// AOJChallengeRepository implements ChallengeRepository for AOJ API with a local cache
+type AOJChallengeRepository struct {
+	baseURL    string
+	cache      *storage.TTLCache[[]repository.ChallengeContest]
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJChallengeRepository creates a new AOJChallengeRepository that caches
+// archives in store, measuring their age with clk
+func NewAOJChallengeRepository(baseURL string, store storage.Store, clk clock.Clock) repository.ChallengeRepository {
+	return &AOJChallengeRepository{
+		baseURL:    baseURL,
+		cache:      storage.NewTTLCache[[]repository.ChallengeContest](store, challengeCacheBucket, challengeCacheTTL, clk),
+		httpClient: httpclient.New(30 * time.Second),
+		logger:     logger.WithGroup("aoj_challenge_repository"),
+	}
+}
+
+// ChallengeListResponse represents the contests of an archive category in the API response
+type ChallengeListResponse struct {
+	Contests []ChallengeContestResponse `json:"contests"`
+}
+
+// ChallengeContestResponse represents a contest in the API response. Problems
+// are grouped by contest day
+type ChallengeContestResponse struct {
+	Abbr  string `json:"abbr"`
+	Year  int    `json:"year"`
+	Title string `json:"title"`
+	Days  []struct {
+		Title    string            `json:"title"`
+		Problems []ProblemResponse `json:"problems"`
+	} `json:"days"`
+}
+
+// ListContests returns the contests of category, e.g. icpc/domestic, from the
+// cache or AOJ; a stale cache is used when AOJ cannot be reached
+func (r *AOJChallengeRepository) ListContests(ctx context.Context, category string) ([]repository.ChallengeContest, error) {
+	key := "challenges-" + strings.ReplaceAll(category, "/", "-") + ".json"
+	cached, found, fresh := r.cache.Load(key)
+	if fresh || (found && offline.IsOffline(ctx)) {
+		return cached, nil
+	}
+	if err := offline.Check(ctx, "listing past contests"); err != nil {
+		return nil, err
+	}
+
+	contests, err := r.fetch(ctx, category)
+	if err != nil {
+		if found && !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+			r.logger.WarnContext(ctx, "failed to fetch contests, using the cache", "category", category, "error", err)
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	if err := r.cache.Save(key, contests); err != nil {
+		r.logger.WarnContext(ctx, "failed to cache contests", "category", category, "error", err)
+	}
+	return contests, nil
+}
+
+// fetch retrieves the contests of a category from AOJ
+func (r *AOJChallengeRepository) fetch(ctx context.Context, category string) ([]repository.ChallengeContest, error) {
+	var list ChallengeListResponse
+	endpoint := r.baseURL + "/challenges/cl/" + category
+	if err := getJSON(ctx, r.httpClient, r.logger, endpoint, "contest archive "+category, &list); err != nil {
+		return nil, err
+	}
+
+	contests := make([]repository.ChallengeContest, 0, len(list.Contests))
+	for _, c := range list.Contests {
+		contest := repository.ChallengeContest{Year: c.Year, Abbr: c.Abbr, Title: c.Title}
+		for _, day := range c.Days {
+			if contest.Title == "" {
+				contest.Title = day.Title
+			}
+			for _, problem := range day.Problems {
+				contest.Problems = append(contest.Problems, repository.ChallengeProblem{ID: problem.ID, Title: problem.Name})
+			}
+		}
+		if len(contest.Problems) > 0 {
+			contests = append(contests, contest)
+		}
+	}
+	if len(contests) == 0 {
+		return nil, cerrors.NewAppError(cerrors.CodeNotFound, "no contests found in archive "+category, nil)
+	}
+	return contests, nil
+}
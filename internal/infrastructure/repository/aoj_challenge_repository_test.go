@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// newChallengeServer serves the ICPC domestic archive with two contests
+func newChallengeServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /challenges/cl/icpc/domestic", func(w http.ResponseWriter, _ *http.Request) {
+		*requests++
+		_, _ = w.Write([]byte(`{"largeCl": {"id": "icpc"}, "contests": [
+			{"abbr": "ICPCOOC2020", "year": 2020, "days": [{"title": "ICPC Domestic 2020", "problems": [
+				{"id": "1630", "name": "Hanafuda"}, {"id": "1631", "name": "Sum"}]}]},
+			{"abbr": "ICPCOOC2019", "year": 2019, "title": "ICPC Domestic 2019", "days": [{"problems": [
+				{"id": "1626", "name": "Taxi"}]}]},
+			{"abbr": "EMPTY", "year": 2018, "days": []}]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAOJChallengeRepository_ListContests(t *testing.T) {
+	// Given
+	requests := 0
+	server := newChallengeServer(t, &requests)
+	repo := NewAOJChallengeRepository(server.URL, storage.NewFileStore(t.TempDir()), clock.System())
+	ctx := context.Background()
+
+	// When
+	contests, err := repo.ListContests(ctx, "icpc/domestic")
+
+	// Then
+	if err != nil {
+		t.Fatalf("ListContests failed: %v", err)
+	}
+	assert.Equal(t, []repository.ChallengeContest{
+		{Year: 2020, Abbr: "ICPCOOC2020", Title: "ICPC Domestic 2020", Problems: []repository.ChallengeProblem{
+			{ID: "1630", Title: "Hanafuda"},
+			{ID: "1631", Title: "Sum"},
+		}},
+		{Year: 2019, Abbr: "ICPCOOC2019", Title: "ICPC Domestic 2019", Problems: []repository.ChallengeProblem{
+			{ID: "1626", Title: "Taxi"},
+		}},
+	}, contests)
+
+	// When - second call is served from the cache, even offline
+	cached, err := repo.ListContests(offline.WithOffline(ctx, true), "icpc/domestic")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, contests, cached)
+	assert.Equal(t, 1, requests)
+}
+
+func TestAOJChallengeRepository_ListContests_UnknownCategory(t *testing.T) {
+	// Given
+	requests := 0
+	server := newChallengeServer(t, &requests)
+	repo := NewAOJChallengeRepository(server.URL, storage.NewFileStore(t.TempDir()), clock.System())
+
+	// When
+	_, err := repo.ListContests(context.Background(), "icpc/unknown")
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// courseCacheBucket is the storage bucket of the cached courses
+const courseCacheBucket = "cache"
+
+// courseCacheTTL is how long a fetched course is reused
+const courseCacheTTL = 7 * 24 * time.Hour
+
+// AOJCourseRepository implements CourseRepository for AOJ API with a local cache
+type AOJCourseRepository struct {
+	baseURL    string
+	cache      *storage.TTLCache[repository.Course]
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJCourseRepository creates a new AOJCourseRepository that caches
+// courses in store, measuring their age with clk
+func NewAOJCourseRepository(baseURL string, store storage.Store, clk clock.Clock) repository.CourseRepository {
+	return &AOJCourseRepository{
+		baseURL:    baseURL,
+		cache:      storage.NewTTLCache[repository.Course](store, courseCacheBucket, courseCacheTTL, clk),
+		httpClient: httpclient.New(10 * time.Second),
+		logger:     logger.WithGroup("aoj_course_repository"),
+	}
+}
+
+// CourseListResponse represents the API response of the course list
+type CourseListResponse struct {
+	Courses []CourseResponse `json:"courses"`
+}
+
+// CourseResponse represents a course in the API response
+type CourseResponse struct {
+	ID        int             `json:"id"`
+	ShortName string          `json:"shortName"`
+	Name      string          `json:"name"`
+	Topics    []TopicResponse `json:"topics"`
+}
+
+// TopicResponse represents a topic of a course in the API response
+type TopicResponse struct {
+	Serial    int    `json:"serial"`
+	ShortName string `json:"shortName"`
+	Name      string `json:"name"`
+}
+
+// GetCourse returns a course from the cache or AOJ; a stale cache is used when AOJ cannot be reached
+func (r *AOJCourseRepository) GetCourse(ctx context.Context, name string) (*repository.Course, error) {
+	key := "course-" + strings.ToUpper(name) + ".json"
+	cached, found, fresh := r.cache.Load(key)
+	if fresh || (found && offline.IsOffline(ctx)) {
+		return &cached, nil
+	}
+	if err := offline.Check(ctx, "reading the course catalogue"); err != nil {
+		return nil, err
+	}
+
+	course, err := r.fetch(ctx, name)
+	if err != nil {
+		if found && !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+			r.logger.WarnContext(ctx, "failed to fetch course, using the cache", "course", name, "error", err)
+			return &cached, nil
+		}
+		return nil, err
+	}
+
+	if err := r.cache.Save(key, *course); err != nil {
+		r.logger.WarnContext(ctx, "failed to cache course", "course", name, "error", err)
+	}
+	return course, nil
+}
+
+// fetch retrieves a course, its topics and its problems from AOJ
+func (r *AOJCourseRepository) fetch(ctx context.Context, name string) (*repository.Course, error) {
+	var list CourseListResponse
+	if err := getJSON(ctx, r.httpClient, r.logger, r.baseURL+"/courses", "course list", &list); err != nil {
+		return nil, err
+	}
+
+	var summary *CourseResponse
+	known := make([]string, 0, len(list.Courses))
+	for i, course := range list.Courses {
+		known = append(known, course.ShortName)
+		if strings.EqualFold(course.ShortName, name) {
+			summary = &list.Courses[i]
+		}
+	}
+	if summary == nil {
+		return nil, cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeNotFound, "course "+name+" not found on AOJ", nil),
+			"Known courses: "+strings.Join(known, ", "),
+		)
+	}
+
+	var detail CourseResponse
+	endpoint := fmt.Sprintf("%s/courses/%d", r.baseURL, summary.ID)
+	if err := getJSON(ctx, r.httpClient, r.logger, endpoint, "course "+summary.ShortName, &detail); err != nil {
+		return nil, err
+	}
+
+	var problems []ProblemResponse
+	endpoint = fmt.Sprintf("%s/problems/courses/%s?page=0&size=%d", r.baseURL, url.PathEscape(summary.ShortName), problemListSize)
+	if err := getJSON(ctx, r.httpClient, r.logger, endpoint, "problems of course "+summary.ShortName, &problems); err != nil {
+		return nil, err
+	}
+
+	return buildCourse(summary, detail.Topics, problems), nil
+}
+
+// buildCourse groups the problems of a course by the chapter in their IDs
+func buildCourse(summary *CourseResponse, topics []TopicResponse, problems []ProblemResponse) *repository.Course {
+	course := &repository.Course{Name: summary.ShortName, Title: summary.Name}
+
+	chapters := make(map[int]int, len(topics)) // chapter to index in course.Topics
+	for _, topic := range topics {
+		chapter := topicChapter(topic)
+		if _, ok := chapters[chapter]; ok {
+			continue
+		}
+		chapters[chapter] = len(course.Topics)
+		course.Topics = append(course.Topics, repository.CourseTopic{Chapter: chapter, Title: topic.Name})
+	}
+
+	for _, problem := range problems {
+		id, err := model.NewProblemID(problem.ID)
+		if err != nil {
+			continue
+		}
+		_, _, chapter, _, ok := id.GetCourseInfo()
+		if !ok {
+			continue
+		}
+		index, known := chapters[chapter]
+		if !known {
+			index = len(course.Topics)
+			chapters[chapter] = index
+			course.Topics = append(course.Topics, repository.CourseTopic{Chapter: chapter, Title: fmt.Sprintf("Topic %d", chapter)})
+		}
+		course.Topics[index].Problems = append(course.Topics[index].Problems, repository.CourseProblem{ID: problem.ID, Title: problem.Name})
+	}
+
+	sort.SliceStable(course.Topics, func(i, j int) bool { return course.Topics[i].Chapter < course.Topics[j].Chapter })
+	for _, topic := range course.Topics {
+		sort.Slice(topic.Problems, func(i, j int) bool { return topic.Problems[i].ID < topic.Problems[j].ID })
+	}
+	return course
+}
+
+// topicChapter returns the chapter of a topic from its short name, e.g. 3 for ITP1_3,
+// falling back to its position in the course
+func topicChapter(topic TopicResponse) int {
+	if i := strings.LastIndex(topic.ShortName, "_"); i >= 0 {
+		if chapter, err := strconv.Atoi(topic.ShortName[i+1:]); err == nil {
+			return chapter
+		}
+	}
+	return topic.Serial
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// newCourseServer serves the ITP1 course with two topics
+func newCourseServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /courses", func(w http.ResponseWriter, _ *http.Request) {
+		*requests++
+		_, _ = w.Write([]byte(`{"filter": null, "courses": [
+			{"id": 1, "serial": 1, "shortName": "ITP1", "name": "Introduction to Programming I"},
+			{"id": 5, "serial": 5, "shortName": "ALDS1", "name": "Algorithms and Data Structures I"}]}`))
+	})
+	mux.HandleFunc("GET /courses/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "shortName": "ITP1", "name": "Introduction to Programming I", "topics": [
+			{"id": 2, "serial": 2, "shortName": "ITP1_2", "name": "Branch on Condition"},
+			{"id": 1, "serial": 1, "shortName": "ITP1_1", "name": "Getting Started"}]}`))
+	})
+	mux.HandleFunc("GET /problems/courses/ITP1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`[
+			{"id": "ITP1_2_A", "name": "Small, Large, or Equal"},
+			{"id": "ITP1_1_B", "name": "X Cubic"},
+			{"id": "ITP1_1_A", "name": "Hello World"}]`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAOJCourseRepository_GetCourse(t *testing.T) {
+	// Given
+	requests := 0
+	server := newCourseServer(t, &requests)
+	repo := NewAOJCourseRepository(server.URL, storage.NewFileStore(t.TempDir()), clock.System())
+	ctx := context.Background()
+
+	// When
+	course, err := repo.GetCourse(ctx, "itp1")
+
+	// Then
+	if err != nil {
+		t.Fatalf("GetCourse failed: %v", err)
+	}
+	assert.Equal(t, &repository.Course{
+		Name:  "ITP1",
+		Title: "Introduction to Programming I",
+		Topics: []repository.CourseTopic{
+			{Chapter: 1, Title: "Getting Started", Problems: []repository.CourseProblem{
+				{ID: "ITP1_1_A", Title: "Hello World"},
+				{ID: "ITP1_1_B", Title: "X Cubic"},
+			}},
+			{Chapter: 2, Title: "Branch on Condition", Problems: []repository.CourseProblem{
+				{ID: "ITP1_2_A", Title: "Small, Large, or Equal"},
+			}},
+		},
+	}, course)
+
+	// When - second call is served from the cache, even offline
+	cached, err := repo.GetCourse(offline.WithOffline(ctx, true), "ITP1")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, course, cached)
+	assert.Equal(t, 1, requests)
+}
+
+func TestAOJCourseRepository_GetCourse_Unknown(t *testing.T) {
+	// Given
+	requests := 0
+	server := newCourseServer(t, &requests)
+	repo := NewAOJCourseRepository(server.URL, storage.NewFileStore(t.TempDir()), clock.System())
+
+	// When
+	_, err := repo.GetCourse(context.Background(), "XYZ1")
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+	assert.Contains(t, cerrors.GetAllHints(err), "Known courses: ITP1, ALDS1")
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// getJSON sends a GET request to the AOJ API and decodes the JSON response
+// into v. subject names the requested resource in the not found error
+func getJSON(ctx context.Context, client *http.Client, log *logger.Logger, endpoint, subject string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		log.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return cerrors.Wrap(err, "failed to decode AOJ response")
+		}
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return cerrors.ErrSessionRejected()
+	case http.StatusNotFound:
+		return cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			subject+" not found on AOJ",
+			nil,
+		)
+	default:
+		log.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return cerrors.ErrJudgeUnavailable(resp.Status)
+	}
+}
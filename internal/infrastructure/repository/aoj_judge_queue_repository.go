@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// AOJJudgeQueueRepository implements JudgeQueueRepository with the recent
+// submission records of the AOJ API. AOJ does not publish its queue, so the
+// queue is derived from the latest submissions that still wait for a verdict
+type AOJJudgeQueueRepository struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJJudgeQueueRepository creates a new AOJJudgeQueueRepository
+func NewAOJJudgeQueueRepository(baseURL string) repository.JudgeQueueRepository {
+	return &AOJJudgeQueueRepository{
+		baseURL:    baseURL,
+		httpClient: httpclient.New(10 * time.Second),
+		logger:     logger.WithGroup("aoj_judge_queue_repository"),
+	}
+}
+
+// GetJudgeQueue returns where a submission waits among the recent submissions
+func (r *AOJJudgeQueueRepository) GetJudgeQueue(ctx context.Context, id model.SubmissionID) (repository.JudgeQueue, error) {
+	if err := offline.Check(ctx, "checking the judge queue"); err != nil {
+		return repository.JudgeQueue{}, err
+	}
+
+	var records []SubmissionRecord
+	if err := getJSON(ctx, r.httpClient, r.logger, r.baseURL+"/submission_records/recent", "recent submissions", &records); err != nil {
+		return repository.JudgeQueue{}, err
+	}
+
+	var queue repository.JudgeQueue
+	var own int64 = -1
+	for _, record := range records {
+		if aojStatusCodes[record.Status] != entity.StatusPending {
+			continue
+		}
+		queue.Length++
+		if model.NewSubmissionIDFromInt(record.JudgeID) == id {
+			own = record.JudgeID
+		}
+	}
+	if own < 0 {
+		return queue, nil
+	}
+	for _, record := range records {
+		// Judge IDs grow with time, so smaller ones were submitted earlier
+		if aojStatusCodes[record.Status] == entity.StatusPending && record.JudgeID <= own {
+			queue.Position++
+		}
+	}
+	return queue, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+)
+
+func TestAOJJudgeQueueRepository_GetJudgeQueue(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want repository.JudgeQueue
+	}{
+		{name: "waiting", id: "1002", want: repository.JudgeQueue{Position: 2, Length: 3}},
+		{name: "judged", id: "1001", want: repository.JudgeQueue{Length: 3}},
+		{name: "not listed", id: "999", want: repository.JudgeQueue{Length: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/submission_records/recent", r.URL.Path)
+				_, _ = w.Write([]byte(`[
+					{"judgeId": 1004, "status": 5},
+					{"judgeId": 1003, "status": 9},
+					{"judgeId": 1002, "status": 5},
+					{"judgeId": 1001, "status": 4},
+					{"judgeId": 1000, "status": 5}
+				]`))
+			}))
+			defer server.Close()
+
+			repo := NewAOJJudgeQueueRepository(server.URL)
+
+			// When
+			queue, err := repo.GetJudgeQueue(context.Background(), model.MustNewSubmissionID(tt.id))
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, queue)
+		})
+	}
+}
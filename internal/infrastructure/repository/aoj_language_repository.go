@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// Storage bucket and key of the cached language list
+const (
+	languageCacheBucket = "cache"
+	languageCacheKey    = "languages.json"
+)
+
+// languageCacheTTL is how long a fetched language list is reused
+const languageCacheTTL = 7 * 24 * time.Hour
+
+// defaultAOJLanguages is used when the language list cannot be fetched or cached
+var defaultAOJLanguages = []string{
+	"C", "C++", "C++11", "C++14", "C++17", "C++23", "JAVA", "C#", "D", "Go",
+	"Ruby", "Rust", "Python", "Python3", "PyPy3", "JavaScript", "Scala",
+	"Haskell", "OCaml", "PHP", "Kotlin",
+}
+
+// AOJLanguageRepository implements LanguageRepository for AOJ API with a local cache
+type AOJLanguageRepository struct {
+	baseURL    string
+	cache      *storage.TTLCache[[]string]
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJLanguageRepository creates a new AOJLanguageRepository that caches
+// the list in store, measuring its age with clk
+func NewAOJLanguageRepository(baseURL string, store storage.Store, clk clock.Clock) repository.LanguageRepository {
+	return &AOJLanguageRepository{
+		baseURL:    baseURL,
+		cache:      storage.NewTTLCache[[]string](store, languageCacheBucket, languageCacheTTL, clk),
+		httpClient: httpclient.New(10 * time.Second),
+		logger:     logger.WithGroup("aoj_language_repository"),
+	}
+}
+
+// LanguageResponse represents a single language in the API response
+type LanguageResponse struct {
+	Name string `json:"name"`
+}
+
+// List returns the accepted languages from the cache, AOJ, or the built-in list, in that order
+func (r *AOJLanguageRepository) List(ctx context.Context) ([]string, error) {
+	cached, found, fresh := r.cache.Load(languageCacheKey)
+	if fresh || (found && offline.IsOffline(ctx)) {
+		return cached, nil
+	}
+
+	if offline.IsOffline(ctx) {
+		return defaultAOJLanguages, nil
+	}
+
+	languages, err := r.fetch(ctx)
+	if err != nil {
+		r.logger.DebugContext(ctx, "failed to fetch language list, using fallback", "error", err)
+		if found {
+			return cached, nil
+		}
+		return defaultAOJLanguages, nil
+	}
+
+	if err := r.cache.Save(languageCacheKey, languages); err != nil {
+		r.logger.WarnContext(ctx, "failed to cache language list", "error", err)
+	}
+
+	return languages, nil
+}
+
+// fetch retrieves the language list from AOJ
+func (r *AOJLanguageRepository) fetch(ctx context.Context) ([]string, error) {
+	url := r.baseURL + "/languages"
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeServiceUnavailable,
+			"language list is not available",
+			cerrors.WithDetail(cerrors.New("unexpected status"), "status_code: "+resp.Status),
+		)
+	}
+
+	var apiLanguages []LanguageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiLanguages); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode language list")
+	}
+
+	languages := make([]string, 0, len(apiLanguages))
+	for _, lang := range apiLanguages {
+		if lang.Name != "" {
+			languages = append(languages, lang.Name)
+		}
+	}
+	if len(languages) == 0 {
+		return nil, cerrors.New("empty language list")
+	}
+
+	return languages, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+func TestAOJLanguageRepository_List_FetchesAndCaches(t *testing.T) {
+	// Given
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/languages", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"name": "C++17"}, {"name": "Python3"}]`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJLanguageRepository(server.URL, storage.NewFileStore(t.TempDir()), clock.System())
+	ctx := context.Background()
+
+	// When
+	languages, err := repo.List(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C++17", "Python3"}, languages)
+
+	// When - second call is served from the cache
+	languages, err = repo.List(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C++17", "Python3"}, languages)
+	assert.Equal(t, 1, requests)
+}
+
+func TestAOJLanguageRepository_List_FallsBackToDefaults(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	repo := NewAOJLanguageRepository(server.URL, storage.NewFileStore(t.TempDir()), clock.System())
+
+	// When
+	languages, err := repo.List(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, languages, "C++17")
+	assert.Contains(t, languages, "Python3")
+}
+
+func TestAOJLanguageRepository_List_Offline(t *testing.T) {
+	// Given
+	repo := NewAOJLanguageRepository("http://invalid-url-that-does-not-exist.local", storage.NewFileStore(t.TempDir()), clock.System())
+	ctx := offline.WithOffline(context.Background(), true)
+
+	// When
+	languages, err := repo.List(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, languages, "C++17")
+}
@@ -6,30 +6,41 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
 )
 
 // AOJProblemRepository implements ProblemRepository for AOJ API
 type AOJProblemRepository struct {
-	baseURL    string
+	baseURL    string // judge data API serving test cases
+	apiURL     string // judge API serving problem metadata
 	httpClient *http.Client
 	logger     *logger.Logger
 }
 
-// NewAOJProblemRepository creates a new AOJProblemRepository
+// NewAOJProblemRepository creates a new AOJProblemRepository that uses baseURL for every request
 func NewAOJProblemRepository(baseURL string) repository.ProblemRepository {
+	return NewAOJProblemRepositoryWithAPI(baseURL, baseURL)
+}
+
+// NewAOJProblemRepositoryWithAPI creates a new AOJProblemRepository that fetches
+// test cases from baseURL and problem metadata from apiURL
+func NewAOJProblemRepositoryWithAPI(baseURL, apiURL string) repository.ProblemRepository {
 	return &AOJProblemRepository{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger.WithGroup("aoj_problem_repository"),
+		baseURL:    baseURL,
+		apiURL:     apiURL,
+		httpClient: httpclient.New(30 * time.Second),
+		logger:     logger.WithGroup("aoj_problem_repository"),
 	}
 }
 
@@ -40,19 +51,149 @@ type TestCaseResponse struct {
 	Out    string `json:"out"`
 }
 
-// GetByID retrieves a problem by its ID
-func (r *AOJProblemRepository) GetByID(_ context.Context, _ model.ProblemID) (*entity.Problem, error) {
-	return nil, cerrors.New("GetByID not implemented")
+// ProblemResponse represents the problem metadata in the API response
+type ProblemResponse struct {
+	ID                 string  `json:"id"`
+	Name               string  `json:"name"`
+	ProblemTimeLimit   float64 `json:"problemTimeLimit"`   // in seconds
+	ProblemMemoryLimit int64   `json:"problemMemoryLimit"` // in KB
 }
 
-// GetByIDs retrieves multiple problems by their IDs
-func (r *AOJProblemRepository) GetByIDs(_ context.Context, _ []model.ProblemID) ([]*entity.Problem, error) {
-	return nil, cerrors.New("GetByIDs not implemented")
+// problemListSize is the page size requested when listing problems, large
+// enough to hold every problem of AOJ
+const problemListSize = 10000
+
+// problemFetchConcurrency bounds the requests GetByIDs sends at the same time
+const problemFetchConcurrency = 4
+
+// toProblem converts the metadata of a problem to a Problem without test cases
+func (p ProblemResponse) toProblem(id model.ProblemID) *entity.Problem {
+	return entity.NewProblem(
+		id,
+		p.Name,
+		"",
+		time.Duration(p.ProblemTimeLimit*float64(time.Second)),
+		p.ProblemMemoryLimit,
+		"",
+		0,
+	)
 }
 
-// Search searches for problems by criteria
-func (r *AOJProblemRepository) Search(_ context.Context, _ repository.ProblemSearchCriteria) ([]*entity.Problem, error) {
-	return nil, cerrors.New("Search not implemented")
+// GetByID retrieves the metadata of a problem from the AOJ judge API
+func (r *AOJProblemRepository) GetByID(ctx context.Context, id model.ProblemID) (*entity.Problem, error) {
+	if err := offline.Check(ctx, "fetching problem metadata"); err != nil {
+		return nil, err
+	}
+
+	r.logger.InfoContext(ctx, "fetching problem from AOJ", "problem_id", id.String())
+
+	url := fmt.Sprintf("%s/problems/%s", r.apiURL, id.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return nil, cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var problemResp ProblemResponse
+		if err := json.NewDecoder(resp.Body).Decode(&problemResp); err != nil {
+			return nil, cerrors.Wrap(err, "failed to decode problem response")
+		}
+		return problemResp.toProblem(id), nil
+	case http.StatusNotFound:
+		return nil, cerrors.ErrProblemNotFound(id.String())
+	case http.StatusInternalServerError:
+		return nil, cerrors.ErrJudgeUnavailable(resp.Status)
+	default:
+		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInternalServer,
+			"unexpected response from AOJ: "+resp.Status,
+			nil,
+		)
+	}
+}
+
+// GetByIDs retrieves the metadata of several problems concurrently, in the order of ids
+func (r *AOJProblemRepository) GetByIDs(ctx context.Context, ids []model.ProblemID) ([]*entity.Problem, error) {
+	return getProblems(ctx, ids, r.GetByID)
+}
+
+// getProblems calls get for every ID with at most problemFetchConcurrency calls
+// at a time. The first error cancels the calls that have not finished
+func getProblems(
+	ctx context.Context,
+	ids []model.ProblemID,
+	get func(context.Context, model.ProblemID) (*entity.Problem, error),
+) ([]*entity.Problem, error) {
+	problems := make([]*entity.Problem, len(ids))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(problemFetchConcurrency)
+	for i, id := range ids {
+		g.Go(func() error {
+			problem, err := get(ctx, id)
+			if err != nil {
+				return err
+			}
+			problems[i] = problem
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// Search lists the problems of the AOJ judge API matching the criteria. The
+// category matches a prefix of the problem ID, e.g. ITP or ITP1_1, and the
+// title a case-insensitive substring; the difficulty is not known to AOJ
+func (r *AOJProblemRepository) Search(ctx context.Context, criteria repository.ProblemSearchCriteria) ([]*entity.Problem, error) {
+	if err := offline.Check(ctx, "listing problems"); err != nil {
+		return nil, err
+	}
+
+	r.logger.InfoContext(ctx, "listing problems from AOJ", "category", criteria.Category)
+
+	url := fmt.Sprintf("%s/problems?page=0&size=%d", r.apiURL, problemListSize)
+	var problems []ProblemResponse
+	if err := getJSON(ctx, r.httpClient, r.logger, url, "problem list", &problems); err != nil {
+		return nil, err
+	}
+
+	category := strings.ToUpper(criteria.Category)
+	title := strings.ToLower(criteria.Title)
+	matched := make([]*entity.Problem, 0, len(problems))
+	for _, problemResp := range problems {
+		if !strings.HasPrefix(strings.ToUpper(problemResp.ID), category) || !strings.Contains(strings.ToLower(problemResp.Name), title) {
+			continue
+		}
+		id, err := model.NewProblemID(problemResp.ID)
+		if err != nil {
+			r.logger.DebugContext(ctx, "skipping problem with unsupported ID", "problem_id", problemResp.ID)
+			continue
+		}
+		matched = append(matched, problemResp.toProblem(id))
+	}
+
+	if criteria.Offset > 0 {
+		matched = matched[min(criteria.Offset, len(matched)):]
+	}
+	if criteria.Limit > 0 && len(matched) > criteria.Limit {
+		matched = matched[:criteria.Limit]
+	}
+	return matched, nil
 }
 
 // Save saves a problem
@@ -70,50 +211,25 @@ func (r *AOJProblemRepository) Exists(_ context.Context, _ model.ProblemID) (boo
 	return false, cerrors.New("Exists not implemented")
 }
 
-// GetTestCases retrieves test cases for a problem from AOJ API
-// AOJ API requires fetching test cases one by one by serial number
+// GetTestCases retrieves the sample test cases for a problem from the AOJ judge data API
 func (r *AOJProblemRepository) GetTestCases(ctx context.Context, problemID model.ProblemID) ([]model.TestCase, error) {
-	r.logger.InfoContext(ctx, "fetching test cases from AOJ", "problem_id", problemID.String())
-
-	testCases := make([]model.TestCase, 0)
-
-	// Fetch test cases sequentially until we get a 404
-	// Most problems have 1-20 test cases
-	const maxTestCases = 100
-	for serial := 1; serial <= maxTestCases; serial++ {
-		testCase, found, err := r.fetchSingleTestCase(ctx, problemID, serial)
-		if err != nil {
-			return nil, err
-		}
-		if !found {
-			// No more test cases available
-			break
-		}
-		testCases = append(testCases, *testCase)
+	if err := offline.Check(ctx, "downloading test cases"); err != nil {
+		return nil, err
 	}
 
-	r.logger.InfoContext(ctx, "successfully fetched test cases", "count", len(testCases))
-	return testCases, nil
-}
+	r.logger.InfoContext(ctx, "fetching test cases from AOJ", "problem_id", problemID.String())
 
-// fetchSingleTestCase fetches a single test case by serial number
-// Returns (testCase, found, error)
-func (r *AOJProblemRepository) fetchSingleTestCase(ctx context.Context, problemID model.ProblemID, serial int) (*model.TestCase, bool, error) {
-	// AOJ test cases are available at https://judgedat.u-aizu.ac.jp/testcases/{problemId}/{serial}
-	url := fmt.Sprintf("https://judgedat.u-aizu.ac.jp/testcases/%s/%d", problemID.String(), serial)
+	// Samples are served at {baseURL}/testcases/samples/{problemId}
+	url := fmt.Sprintf("%s/testcases/samples/%s", r.baseURL, problemID.String())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, false, cerrors.Wrap(err, "failed to create HTTP request")
+		return nil, cerrors.Wrap(err, "failed to create HTTP request")
 	}
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err, "serial", serial)
-		return nil, false, cerrors.NewAppError(
-			cerrors.CodeNetworkError,
-			"failed to connect to AOJ",
-			err,
-		)
+		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return nil, cerrors.ErrAOJUnreachable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -123,30 +239,30 @@ func (r *AOJProblemRepository) fetchSingleTestCase(ctx context.Context, problemI
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		var apiTC TestCaseResponse
-		if err := json.NewDecoder(resp.Body).Decode(&apiTC); err != nil {
-			return nil, false, cerrors.Wrap(err, "failed to decode test case response")
+		var apiTCs []TestCaseResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiTCs); err != nil {
+			return nil, cerrors.Wrap(err, "failed to decode test case response")
 		}
-		tc := model.NewTestCase(apiTC.Serial, apiTC.In, apiTC.Out)
-		return tc, true, nil
+		testCases := make([]model.TestCase, 0, len(apiTCs))
+		for _, apiTC := range apiTCs {
+			testCases = append(testCases, *model.NewTestCase(apiTC.Serial, apiTC.In, apiTC.Out))
+		}
+		r.logger.InfoContext(ctx, "successfully fetched test cases", "count", len(testCases))
+		return testCases, nil
 	case http.StatusNotFound:
-		// No more test cases available
-		return nil, false, nil
+		// The problem has no published samples
+		return []model.TestCase{}, nil
 	case http.StatusBadRequest:
-		return nil, false, cerrors.NewAppError(
+		return nil, cerrors.NewAppError(
 			cerrors.CodeInvalidInput,
 			"invalid problem ID format",
 			nil,
 		)
 	case http.StatusInternalServerError:
-		return nil, false, cerrors.NewAppError(
-			cerrors.CodeServiceUnavailable,
-			"AOJ server error",
-			nil,
-		)
+		return nil, cerrors.ErrJudgeUnavailable(resp.Status)
 	default:
-		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode, "serial", serial)
-		return nil, false, cerrors.NewAppError(
+		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return nil, cerrors.NewAppError(
 			cerrors.CodeInternalServer,
 			"unexpected response from AOJ",
 			cerrors.WithDetail(nil, "status_code: "+resp.Status),
@@ -4,9 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
 )
 
@@ -168,13 +172,6 @@ func TestAOJProblemRepository_NotImplementedMethods(t *testing.T) {
 
 	pid, _ := model.NewProblemID("TEST")
 
-	t.Run("GetByID", func(t *testing.T) {
-		_, err := repo.GetByID(ctx, pid)
-		if err == nil {
-			t.Error("expected error for GetByID, got nil")
-		}
-	})
-
 	t.Run("Exists", func(t *testing.T) {
 		_, err := repo.Exists(ctx, pid)
 		if err == nil {
@@ -182,3 +179,143 @@ func TestAOJProblemRepository_NotImplementedMethods(t *testing.T) {
 		}
 	})
 }
+
+func TestAOJProblemRepository_GetByID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/problems/ITP1_1_A" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "ITP1_1_A", "name": "Hello World", "problemTimeLimit": 1.5, "problemMemoryLimit": 131072}`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJProblemRepositoryWithAPI("http://judgedat.invalid", server.URL)
+	ctx := context.Background()
+
+	problem, err := repo.GetByID(ctx, model.MustNewProblemID("ITP1_1_A"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if problem.Title() != "Hello World" {
+		t.Errorf("expected title Hello World, got %s", problem.Title())
+	}
+	if problem.TimeLimit() != 1500*time.Millisecond {
+		t.Errorf("expected time limit 1.5s, got %v", problem.TimeLimit())
+	}
+	if problem.MemoryLimit() != 131072 {
+		t.Errorf("expected memory limit 131072, got %d", problem.MemoryLimit())
+	}
+
+	_, err = repo.GetByID(ctx, model.MustNewProblemID("ITP1_1_B"))
+	if !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestAOJProblemRepository_GetByIDs(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		id := strings.TrimPrefix(r.URL.Path, "/problems/")
+		if id == "ITP1_9_Z" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "` + id + `", "name": "Problem ` + id + `", "problemTimeLimit": 1, "problemMemoryLimit": 131072}`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJProblemRepository(server.URL)
+	ctx := context.Background()
+	var ids []model.ProblemID
+	for _, letter := range "ABCDEFGHIJ" {
+		ids = append(ids, model.MustNewProblemID("ITP1_1_"+string(letter)))
+	}
+
+	problems, err := repo.GetByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(problems) != len(ids) {
+		t.Fatalf("expected %d problems, got %d", len(ids), len(problems))
+	}
+	for i, problem := range problems {
+		if !problem.ID().Equals(ids[i]) {
+			t.Errorf("expected %s at %d, got %s", ids[i], i, problem.ID())
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > problemFetchConcurrency {
+		t.Errorf("expected between 2 and %d concurrent requests, got %d", problemFetchConcurrency, maxInFlight)
+	}
+
+	_, err = repo.GetByIDs(ctx, append(ids, model.MustNewProblemID("ITP1_9_Z")))
+	if !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestAOJProblemRepository_Search(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/problems" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "ITP1_1_A", "name": "Hello World", "problemTimeLimit": 1, "problemMemoryLimit": 131072},
+			{"id": "ITP1_1_B", "name": "X Cubic", "problemTimeLimit": 1, "problemMemoryLimit": 131072},
+			{"id": "ALDS1_1_A", "name": "Insertion Sort", "problemTimeLimit": 1, "problemMemoryLimit": 131072},
+			{"id": "0000", "name": "QQ", "problemTimeLimit": 1, "problemMemoryLimit": 65536}
+		]`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJProblemRepositoryWithAPI("http://judgedat.invalid", server.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		criteria repository.ProblemSearchCriteria
+		want     []string
+	}{
+		{name: "all", criteria: repository.ProblemSearchCriteria{}, want: []string{"ITP1_1_A", "ITP1_1_B", "ALDS1_1_A", "0000"}},
+		{name: "category", criteria: repository.ProblemSearchCriteria{Category: "itp"}, want: []string{"ITP1_1_A", "ITP1_1_B"}},
+		{name: "title", criteria: repository.ProblemSearchCriteria{Title: "sort"}, want: []string{"ALDS1_1_A"}},
+		{name: "limit and offset", criteria: repository.ProblemSearchCriteria{Limit: 2, Offset: 1}, want: []string{"ITP1_1_B", "ALDS1_1_A"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := repo.Search(ctx, tt.criteria)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []string
+			for _, problem := range problems {
+				ids = append(ids, problem.ID().String())
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+}
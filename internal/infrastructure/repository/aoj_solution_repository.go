@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// AOJSolutionRepository implements SolutionRepository with the solutions
+// endpoint of the AOJ API
+type AOJSolutionRepository struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJSolutionRepository creates a new AOJSolutionRepository
+func NewAOJSolutionRepository(baseURL string) repository.SolutionRepository {
+	return &AOJSolutionRepository{
+		baseURL:    baseURL,
+		httpClient: httpclient.New(30 * time.Second),
+		logger:     logger.WithGroup("aoj_solution_repository"),
+	}
+}
+
+// SolutionRecord represents an accepted solution in the solutions response
+type SolutionRecord struct {
+	JudgeID        int64  `json:"judgeId"`
+	UserID         string `json:"userId"`
+	Language       string `json:"language"`
+	CPUTime        int64  `json:"cpuTime"` // in 1/100 seconds
+	Memory         int64  `json:"memory"`  // in KB
+	CodeSize       int64  `json:"codeSize"`
+	SubmissionDate int64  `json:"submissionDate"` // Unix milliseconds
+}
+
+// GetByProblemID retrieves up to limit accepted solutions of a problem
+func (r *AOJSolutionRepository) GetByProblemID(ctx context.Context, problemID model.ProblemID, limit int) ([]repository.Solution, error) {
+	if err := offline.Check(ctx, "fetching the solutions"); err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "fetching solutions from AOJ", "problem_id", problemID.String(), "limit", limit)
+	endpoint := fmt.Sprintf("%s/solutions/problems/%s?page=0&size=%d", r.baseURL, url.PathEscape(problemID.String()), limit)
+	var records []SolutionRecord
+	if err := getJSON(ctx, r.httpClient, r.logger, endpoint, "problem "+problemID.String(), &records); err != nil {
+		return nil, err
+	}
+
+	solutions := make([]repository.Solution, 0, len(records))
+	for _, record := range records {
+		solutions = append(solutions, repository.Solution{
+			JudgeID:     strconv.FormatInt(record.JudgeID, 10),
+			UserID:      record.UserID,
+			Language:    record.Language,
+			Time:        time.Duration(record.CPUTime) * 10 * time.Millisecond,
+			Memory:      record.Memory,
+			CodeSize:    record.CodeSize,
+			SubmittedAt: time.UnixMilli(record.SubmissionDate),
+		})
+	}
+	return solutions, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+func TestAOJSolutionRepository_GetByProblemID(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/solutions/problems/ITP1_1_A", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("size"))
+		_, _ = w.Write([]byte(`[{"judgeId": 123, "userId": "alice", "problemId": "ITP1_1_A", "language": "C++17",
+			"cpuTime": 2, "memory": 3100, "codeSize": 210, "submissionDate": 1775034000000}]`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJSolutionRepository(server.URL)
+
+	// When
+	solutions, err := repo.GetByProblemID(context.Background(), model.MustNewProblemID("ITP1_1_A"), 100)
+
+	// Then
+	assert.NoError(t, err)
+	if len(solutions) != 1 {
+		t.Fatalf("expected 1 solution, got %d", len(solutions))
+	}
+	assert.Equal(t, "123", solutions[0].JudgeID)
+	assert.Equal(t, "alice", solutions[0].UserID)
+	assert.Equal(t, 20*time.Millisecond, solutions[0].Time)
+	assert.Equal(t, int64(3100), solutions[0].Memory)
+	assert.Equal(t, int64(210), solutions[0].CodeSize)
+}
+
+func TestAOJSolutionRepository_GetByProblemID_Offline(t *testing.T) {
+	// Given
+	repo := NewAOJSolutionRepository("http://invalid-url-that-does-not-exist.local")
+	ctx := offline.WithOffline(context.Background(), true)
+
+	// When
+	_, err := repo.GetByProblemID(ctx, model.MustNewProblemID("ITP1_1_A"), 100)
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeOffline), "got %v", err)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// statementCacheBucket holds downloaded statements as <language>_<problem>.html
+const statementCacheBucket = "cache/statements"
+
+// AOJStatementRepository implements StatementRepository for AOJ API.
+// Statements rarely change, so downloaded ones are cached without expiry
+type AOJStatementRepository struct {
+	apiURL     string
+	store      storage.Store
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJStatementRepository creates a new AOJStatementRepository that caches statements in store
+func NewAOJStatementRepository(apiURL string, store storage.Store) repository.StatementRepository {
+	return &AOJStatementRepository{
+		apiURL:     apiURL,
+		store:      store,
+		httpClient: httpclient.New(30 * time.Second),
+		logger:     logger.WithGroup("aoj_statement_repository"),
+	}
+}
+
+// DescriptionResponse represents a problem description in the API response
+type DescriptionResponse struct {
+	ProblemID string `json:"problem_id"`
+	Language  string `json:"language"`
+	HTML      string `json:"html"`
+}
+
+// GetStatement returns the cached statement or downloads it from AOJ
+func (r *AOJStatementRepository) GetStatement(ctx context.Context, id model.ProblemID, language string) (string, error) {
+	key := language + "_" + id.String() + ".html"
+	if cached, err := r.store.Get(statementCacheBucket, key); err == nil {
+		return string(cached), nil
+	}
+
+	if err := offline.Check(ctx, "downloading the problem statement"); err != nil {
+		return "", err
+	}
+
+	r.logger.InfoContext(ctx, "fetching problem statement from AOJ", "problem_id", id.String(), "language", language)
+
+	url := fmt.Sprintf("%s/resources/descriptions/%s/%s", r.apiURL, language, id.String())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return "", cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var description DescriptionResponse
+		if err := json.NewDecoder(resp.Body).Decode(&description); err != nil {
+			return "", cerrors.Wrap(err, "failed to decode description response")
+		}
+		if description.HTML == "" {
+			return "", cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				fmt.Sprintf("no %s statement for problem %s", language, id.String()),
+				nil,
+			)
+		}
+		if err := r.store.Put(statementCacheBucket, key, []byte(description.HTML)); err != nil {
+			r.logger.WarnContext(ctx, "failed to cache problem statement", "error", err)
+		}
+		return description.HTML, nil
+	case http.StatusNotFound:
+		return "", cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("no %s statement for problem %s", language, id.String()),
+			nil,
+		)
+	case http.StatusInternalServerError:
+		return "", cerrors.ErrJudgeUnavailable(resp.Status)
+	default:
+		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return "", cerrors.NewAppError(
+			cerrors.CodeInternalServer,
+			"unexpected response from AOJ: "+resp.Status,
+			nil,
+		)
+	}
+}
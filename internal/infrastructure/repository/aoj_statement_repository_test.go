@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+func TestAOJStatementRepository_GetStatement_FetchesAndCaches(t *testing.T) {
+	// Given
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/resources/descriptions/en/ITP1_1_A", r.URL.Path)
+		_, _ = w.Write([]byte(`{"problem_id": "ITP1_1_A", "language": "en", "html": "<h1>Hello World</h1>"}`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJStatementRepository(server.URL, storage.NewFileStore(t.TempDir()))
+	pid := model.MustNewProblemID("ITP1_1_A")
+
+	// When
+	first, err := repo.GetStatement(context.Background(), pid, "en")
+	assert.NoError(t, err)
+	second, err := repo.GetStatement(offline.WithOffline(context.Background(), true), pid, "en")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "<h1>Hello World</h1>", first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, requests)
+}
+
+func TestAOJStatementRepository_GetStatement_NotFound(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	repo := NewAOJStatementRepository(server.URL, storage.NewFileStore(t.TempDir()))
+
+	// When
+	_, err := repo.GetStatement(context.Background(), model.MustNewProblemID("ITP1_1_A"), "en")
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound))
+}
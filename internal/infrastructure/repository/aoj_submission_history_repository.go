@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// AOJSubmissionHistoryRepository implements SubmissionHistoryRepository with
+// the submission_records and reviews endpoints of the AOJ API
+type AOJSubmissionHistoryRepository struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     *logger.Logger
+}
+
+// NewAOJSubmissionHistoryRepository creates a new AOJSubmissionHistoryRepository
+func NewAOJSubmissionHistoryRepository(baseURL string) repository.SubmissionHistoryRepository {
+	return &AOJSubmissionHistoryRepository{
+		baseURL:    baseURL,
+		httpClient: httpclient.New(30 * time.Second),
+		logger:     logger.WithGroup("aoj_submission_history_repository"),
+	}
+}
+
+// SubmissionRecord represents a submission in the submission_records response
+type SubmissionRecord struct {
+	JudgeID        int64  `json:"judgeId"`
+	UserID         string `json:"userId"`
+	ProblemID      string `json:"problemId"`
+	Language       string `json:"language"`
+	Status         int    `json:"status"`
+	Score          int    `json:"score"`
+	CPUTime        int64  `json:"cpuTime"` // in 1/100 seconds
+	Memory         int64  `json:"memory"`  // in KB
+	CodeSize       int64  `json:"codeSize"`
+	Accuracy       string `json:"accuracy"`       // passed/total judge cases, e.g. "4/4"
+	SubmissionDate int64  `json:"submissionDate"` // Unix milliseconds
+	JudgeDate      int64  `json:"judgeDate"`      // Unix milliseconds
+}
+
+// SourceReviewResponse represents the source code returned by the review endpoint
+type SourceReviewResponse struct {
+	SourceCode string `json:"sourceCode"`
+}
+
+// GetUserSubmissions retrieves one page of the user's submission records, newest first
+func (r *AOJSubmissionHistoryRepository) GetUserSubmissions(ctx context.Context, userID string, page, size int) ([]*entity.Submission, error) {
+	if err := offline.Check(ctx, "fetching the submission history"); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/submission_records/users/%s?page=%d&size=%d", r.baseURL, url.PathEscape(userID), page, size)
+	var records []SubmissionRecord
+	if err := getJSON(ctx, r.httpClient, r.logger, endpoint, "user "+userID, &records); err != nil {
+		return nil, err
+	}
+
+	submissions := make([]*entity.Submission, 0, len(records))
+	for _, record := range records {
+		submission, err := recordToSubmission(record)
+		if err != nil {
+			r.logger.WarnContext(ctx, "skipping invalid submission record", "judge_id", record.JudgeID, "error", err)
+			continue
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, nil
+}
+
+// GetSourceCode retrieves the source code of a submission from the review endpoint,
+// which requires being logged in as its author
+func (r *AOJSubmissionHistoryRepository) GetSourceCode(ctx context.Context, judgeID string) (string, error) {
+	if err := offline.Check(ctx, "fetching the source code"); err != nil {
+		return "", err
+	}
+
+	var review SourceReviewResponse
+	if err := getJSON(ctx, r.httpClient, r.logger, r.baseURL+"/reviews/"+url.PathEscape(judgeID), "submission "+judgeID, &review); err != nil {
+		return "", err
+	}
+	return review.SourceCode, nil
+}
+
+// recordToSubmission converts a submission record to a Submission identified
+// by its judge ID. The source code is fetched separately
+func recordToSubmission(record SubmissionRecord) (*entity.Submission, error) {
+	problemID, err := model.NewProblemID(record.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := aojStatusCodes[record.Status]
+	if !ok {
+		status = entity.StatusPending
+	}
+
+	var judgedAt *time.Time
+	if record.JudgeDate > 0 {
+		t := time.UnixMilli(record.JudgeDate)
+		judgedAt = &t
+	}
+
+	judgeID := model.NewSubmissionIDFromInt(record.JudgeID)
+	submission := entity.RestoreSubmission(
+		judgeID,
+		problemID,
+		record.Language,
+		"",
+		status,
+		record.Score,
+		time.Duration(record.CPUTime)*10*time.Millisecond,
+		record.Memory,
+		"",
+		time.UnixMilli(record.SubmissionDate),
+		judgedAt,
+	)
+	submission.SetJudgeID(judgeID.String())
+	return submission, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestAOJSubmissionHistoryRepository_GetUserSubmissions(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/submission_records/users/alice", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		assert.Equal(t, "50", r.URL.Query().Get("size"))
+		_, _ = w.Write([]byte(`[
+			{"judgeId": 9000001, "userId": "alice", "problemId": "ITP1_1_A", "language": "C++17", "status": 4,
+			 "score": 100, "cpuTime": 3, "memory": 3456, "accuracy": "4/4",
+			 "submissionDate": 1775034000000, "judgeDate": 1775034001000},
+			{"judgeId": 9000000, "problemId": "not a problem", "status": 1}
+		]`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionHistoryRepository(server.URL)
+
+	// When
+	submissions, err := repo.GetUserSubmissions(context.Background(), "alice", 1, 50)
+
+	// Then
+	assert.NoError(t, err)
+	if len(submissions) != 1 {
+		t.Fatalf("expected 1 submission, got %d", len(submissions))
+	}
+	submission := submissions[0]
+	assert.Equal(t, "9000001", submission.ID().String())
+	assert.Equal(t, "9000001", submission.JudgeID())
+	assert.Equal(t, "ITP1_1_A", submission.ProblemID().String())
+	assert.Equal(t, entity.StatusAccepted, submission.Status())
+	assert.Equal(t, 30*time.Millisecond, submission.Time())
+	assert.Equal(t, int64(3456), submission.Memory())
+	assert.Equal(t, time.UnixMilli(1775034000000), submission.SubmittedAt())
+	assert.True(t, submission.IsJudged())
+}
+
+func TestAOJSubmissionHistoryRepository_GetSourceCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		want     string
+		wantCode cerrors.ErrorCode
+	}{
+		{name: "own submission", status: http.StatusOK, want: "int main() {}\n"},
+		{name: "not logged in", status: http.StatusUnauthorized, wantCode: cerrors.CodeUnauthorized},
+		{name: "unknown submission", status: http.StatusNotFound, wantCode: cerrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/reviews/9000001", r.URL.Path)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"judgeId": 9000001, "sourceCode": "int main() {}\n"}`))
+			}))
+			defer server.Close()
+
+			repo := NewAOJSubmissionHistoryRepository(server.URL)
+
+			// When
+			source, err := repo.GetSourceCode(context.Background(), "9000001")
+
+			// Then
+			if tt.wantCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, source)
+		})
+	}
+}
@@ -5,31 +5,39 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
 )
 
 // AOJSubmissionRepository implements SubmissionRepository for AOJ API
 type AOJSubmissionRepository struct {
 	baseURL    string
 	httpClient *http.Client
+	clock      clock.Clock // paces WatchStatus polls
 	logger     *logger.Logger
 }
 
-// NewAOJSubmissionRepository creates a new AOJSubmissionRepository
-func NewAOJSubmissionRepository(baseURL string) repository.SubmissionRepository {
+// NewAOJSubmissionRepository creates a new AOJSubmissionRepository. A nil clk means the system clock
+func NewAOJSubmissionRepository(baseURL string, clk clock.Clock) repository.SubmissionRepository {
+	if clk == nil {
+		clk = clock.System()
+	}
 	return &AOJSubmissionRepository{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: logger.WithGroup("aoj_submission_repository"),
+		baseURL:    baseURL,
+		httpClient: httpclient.New(30 * time.Second),
+		clock:      clk,
+		logger:     logger.WithGroup("aoj_submission_repository"),
 	}
 }
 
@@ -53,16 +61,101 @@ type SubmitResponse struct {
 	Message         string `json:"message"`
 }
 
+// ReviewResponse represents the JSON response from the review endpoint
+type ReviewResponse struct {
+	JudgeID      json.Number `json:"judgeId"`
+	CompileError string      `json:"compileError"`
+	RuntimeError string      `json:"runtimeError"`
+}
+
+// VerdictResponse represents the JSON response from the verdict endpoint
+type VerdictResponse struct {
+	SubmissionRecord VerdictRecord `json:"submissionRecord"`
+	CaseVerdicts     []CaseVerdict `json:"caseVerdicts"`
+}
+
+// VerdictRecord is the judge record of a submission
+type VerdictRecord struct {
+	JudgeID json.Number `json:"judgeId"`
+	Status  int         `json:"status"`
+	Score   int         `json:"score"`
+	CPUTime int         `json:"cpuTime"` // in 1/100 seconds
+	Memory  int64       `json:"memory"`  // in KB
+}
+
+// CaseVerdict is the result of a submission on one judge test case
+type CaseVerdict struct {
+	Serial  int    `json:"serial"`
+	Status  string `json:"status"` // e.g. "AC" or "WA", "-" if the case was skipped
+	Label   string `json:"label"`
+	CPUTime int    `json:"cpuTime"` // in 1/100 seconds
+	Memory  int64  `json:"memory"`  // in KB
+}
+
+// aojCaseStatuses maps the verdict labels of single test cases to domain statuses
+var aojCaseStatuses = map[string]entity.SubmissionStatus{
+	"AC":  entity.StatusAccepted,
+	"WA":  entity.StatusWrongAnswer,
+	"TLE": entity.StatusTimeLimitExceeded,
+	"MLE": entity.StatusMemoryLimitExceeded,
+	"OLE": entity.StatusOutputLimitExceeded,
+	"RE":  entity.StatusRuntimeError,
+	"PE":  entity.StatusPresentationError,
+}
+
+// toCaseResult converts a case verdict of AOJ to a domain case result
+func (v CaseVerdict) toCaseResult() entity.CaseResult {
+	result := entity.CaseResult{
+		Serial: v.Serial,
+		Label:  v.Label,
+		Time:   time.Duration(v.CPUTime) * 10 * time.Millisecond,
+		Memory: v.Memory,
+	}
+	if v.Status != "-" {
+		status, ok := aojCaseStatuses[v.Status]
+		if !ok {
+			status = entity.StatusInternalError
+		}
+		result.Status = status
+	}
+	return result
+}
+
+// aojStatusCodes maps the numeric verdict codes of AOJ to domain statuses
+var aojStatusCodes = map[int]entity.SubmissionStatus{
+	0: entity.StatusCompileError,
+	1: entity.StatusWrongAnswer,
+	2: entity.StatusTimeLimitExceeded,
+	3: entity.StatusMemoryLimitExceeded,
+	4: entity.StatusAccepted,
+	5: entity.StatusPending,
+	6: entity.StatusOutputLimitExceeded,
+	7: entity.StatusRuntimeError,
+	8: entity.StatusPresentationError,
+	9: entity.StatusJudging,
+}
+
+// Watch polling limits
+const (
+	defaultWatchInterval = 2 * time.Second
+	maxWatchFailures     = 3 // consecutive failed polls after which watching stops
+	maxWatchBackoff      = 5 // the poll interval grows up to this multiple while the verdict does not change
+)
+
 // Submit submits a solution to AOJ
 func (r *AOJSubmissionRepository) Submit(ctx context.Context, submission *entity.Submission) error {
 	r.logger.InfoContext(ctx, "submitting solution to AOJ",
 		"problem_id", submission.ProblemID().String(),
 		"language", submission.Language())
 
+	if err := offline.Check(ctx, "submitting a solution"); err != nil {
+		return err
+	}
+
 	// Prepare request payload
 	submitReq := SubmitRequest{
 		ProblemID:  submission.ProblemID().String(),
-		Language:   r.normalizeLanguage(submission.Language()),
+		Language:   language.Normalize(submission.Language()),
 		SourceCode: submission.SourceCode(),
 	}
 
@@ -85,11 +178,15 @@ func (r *AOJSubmissionRepository) Submit(ctx context.Context, submission *entity
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
 		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
-		return cerrors.NewAppError(
-			cerrors.CodeNetworkError,
-			"failed to connect to AOJ",
-			err,
-		)
+		var netErr net.Error
+		if cerrors.As(err, &netErr) && netErr.Timeout() {
+			return cerrors.NewAppError(
+				cerrors.CodeTimeout,
+				"AOJ did not respond in time",
+				err,
+			)
+		}
+		return cerrors.ErrAOJUnreachable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -102,23 +199,15 @@ func (r *AOJSubmissionRepository) Submit(ctx context.Context, submission *entity
 	case http.StatusOK, http.StatusCreated:
 		return r.parseSubmitResponse(ctx, resp, submission)
 	case http.StatusUnauthorized:
-		return cerrors.NewAppError(
-			cerrors.CodeUnauthorized,
-			"authentication required. Please login first",
-			nil,
-		)
+		return cerrors.ErrSessionRejected()
 	case http.StatusBadRequest:
 		return cerrors.NewAppError(
 			cerrors.CodeInvalidInput,
 			"invalid submission request",
 			nil,
 		)
-	case http.StatusInternalServerError:
-		return cerrors.NewAppError(
-			cerrors.CodeServiceUnavailable,
-			"AOJ server error",
-			nil,
-		)
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return cerrors.ErrJudgeUnavailable(resp.Status)
 	default:
 		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
 		return cerrors.NewAppError(
@@ -137,6 +226,7 @@ func (r *AOJSubmissionRepository) parseSubmitResponse(_ context.Context, resp *h
 	}
 
 	// Update submission with response data
+	submission.SetJudgeID(submitResp.SubmissionID)
 	status := r.mapSubmissionStatus(submitResp.Status)
 	submission.UpdateResult(
 		status,
@@ -149,34 +239,201 @@ func (r *AOJSubmissionRepository) parseSubmitResponse(_ context.Context, resp *h
 	return nil
 }
 
-// normalizeLanguage normalizes language names for AOJ API
-func (r *AOJSubmissionRepository) normalizeLanguage(lang string) string {
-	// Map common language names to AOJ's expected format
-	languageMap := map[string]string{
-		"C":          "C",
-		"C++":        "C++14",
-		"C++14":      "C++14",
-		"C++17":      "C++17",
-		"Java":       "JAVA",
-		"JAVA":       "JAVA",
-		"Python":     "Python3",
-		"Python3":    "Python3",
-		"Ruby":       "Ruby",
-		"Go":         "Go",
-		"JavaScript": "JavaScript",
-		"C#":         "C#",
-		"PHP":        "PHP",
-		"D":          "D",
-		"Rust":       "Rust",
-		"Kotlin":     "Kotlin",
-		"Scala":      "Scala",
+// GetCompileError retrieves the compiler message from AOJ's review endpoint
+func (r *AOJSubmissionRepository) GetCompileError(ctx context.Context, submission *entity.Submission) (string, error) {
+	if err := offline.Check(ctx, "fetching the compile error"); err != nil {
+		return "", err
+	}
+
+	if submission.JudgeID() == "" {
+		return "", cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"submission has not been judged by AOJ",
+			nil,
+		)
+	}
+
+	url := r.baseURL + "/reviews/" + submission.JudgeID()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to create HTTP request")
 	}
 
-	if normalized, ok := languageMap[lang]; ok {
-		return normalized
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return "", cerrors.ErrAOJUnreachable(err)
 	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
 
-	return lang
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var review ReviewResponse
+		if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+			return "", cerrors.Wrap(err, "failed to decode review response")
+		}
+		return review.CompileError, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", cerrors.ErrSessionRejected()
+	case http.StatusNotFound:
+		return "", cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"review not found for submission "+submission.JudgeID(),
+			nil,
+		)
+	default:
+		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return "", cerrors.ErrJudgeUnavailable(resp.Status)
+	}
+}
+
+// GetJudgeDetail retrieves the judge record of the submission and its case
+// verdicts from AOJ's verdict endpoint
+func (r *AOJSubmissionRepository) GetJudgeDetail(ctx context.Context, submission *entity.Submission) (repository.JudgeDetail, error) {
+	if err := offline.Check(ctx, "fetching the test case results"); err != nil {
+		return repository.JudgeDetail{}, err
+	}
+
+	if submission.JudgeID() == "" {
+		return repository.JudgeDetail{}, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"submission has not been judged by AOJ",
+			nil,
+		)
+	}
+
+	url := r.baseURL + "/verdicts/" + submission.JudgeID()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return repository.JudgeDetail{}, cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return repository.JudgeDetail{}, cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var verdict VerdictResponse
+		if err := json.NewDecoder(resp.Body).Decode(&verdict); err != nil {
+			return repository.JudgeDetail{}, cerrors.Wrap(err, "failed to decode verdict response")
+		}
+		detail := repository.JudgeDetail{
+			Score:  verdict.SubmissionRecord.Score,
+			Time:   time.Duration(verdict.SubmissionRecord.CPUTime) * 10 * time.Millisecond,
+			Memory: verdict.SubmissionRecord.Memory,
+			Cases:  make([]entity.CaseResult, len(verdict.CaseVerdicts)),
+		}
+		for i, caseVerdict := range verdict.CaseVerdicts {
+			detail.Cases[i] = caseVerdict.toCaseResult()
+		}
+		return detail, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return repository.JudgeDetail{}, cerrors.ErrSessionRejected()
+	case http.StatusNotFound:
+		return repository.JudgeDetail{}, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"verdict not found for submission "+submission.JudgeID(),
+			nil,
+		)
+	default:
+		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return repository.JudgeDetail{}, cerrors.ErrJudgeUnavailable(resp.Status)
+	}
+}
+
+// GetStatus retrieves the current verdict of a submission by its AOJ judge ID
+func (r *AOJSubmissionRepository) GetStatus(ctx context.Context, id model.SubmissionID) (entity.SubmissionStatus, error) {
+	if err := offline.Check(ctx, "checking the verdict"); err != nil {
+		return "", err
+	}
+
+	poll, err := r.pollVerdict(ctx, id, verdictPoll{})
+	if err != nil {
+		return "", err
+	}
+	return poll.status, nil
+}
+
+// WatchStatus polls the verdict of a submission and sends every change on the
+// returned channel. The channel is closed once the verdict is final, the
+// context is cancelled, or polling keeps failing. Polls back off while the
+// verdict stays the same or AOJ fails, up to maxWatchBackoff times interval,
+// and return to interval as soon as the verdict changes. Polls are
+// conditional requests, and a Retry-After from AOJ delays the next one
+func (r *AOJSubmissionRepository) WatchStatus(ctx context.Context, id model.SubmissionID, interval time.Duration) (<-chan entity.SubmissionStatus, error) {
+	if err := offline.Check(ctx, "watching the verdict"); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	statuses := make(chan entity.SubmissionStatus, 1)
+	go func() {
+		defer close(statuses)
+
+		var poll verdictPoll
+		var last entity.SubmissionStatus
+		failures := 0
+		delay := interval
+		for {
+			next, err := r.pollVerdict(ctx, id, poll)
+			status := next.status
+			switch {
+			case err != nil:
+				if ctx.Err() != nil || cerrors.IsAppError(err, cerrors.CodeUnauthorized) || cerrors.IsAppError(err, cerrors.CodeNotFound) {
+					r.logger.DebugContext(ctx, "stopped watching verdict", "error", err)
+					return
+				}
+				failures++
+				r.logger.DebugContext(ctx, "failed to poll verdict", "failures", failures, "error", err)
+				if failures >= maxWatchFailures {
+					return
+				}
+				delay *= 2
+			case status != last:
+				failures = 0
+				last = status
+				delay = interval
+				select {
+				case statuses <- status:
+				case <-ctx.Done():
+					return
+				}
+			default:
+				failures = 0
+				delay += delay / 2
+			}
+			if err == nil {
+				poll = next
+			}
+
+			if last != "" && last.IsFinal() {
+				return
+			}
+
+			delay = min(delay, interval*maxWatchBackoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-r.clock.After(max(delay, next.retryAfter)):
+			}
+		}
+	}()
+
+	return statuses, nil
 }
 
 // mapSubmissionStatus maps AOJ status to our domain status
@@ -216,14 +473,6 @@ func (r *AOJSubmissionRepository) GetRecent(_ context.Context, _ int) ([]*entity
 	return nil, cerrors.New("GetRecent not implemented")
 }
 
-func (r *AOJSubmissionRepository) GetStatus(_ context.Context, _ model.SubmissionID) (entity.SubmissionStatus, error) {
-	return "", cerrors.New("GetStatus not implemented")
-}
-
-func (r *AOJSubmissionRepository) WatchStatus(_ context.Context, _ model.SubmissionID, _ time.Duration) (<-chan entity.SubmissionStatus, error) {
-	return nil, cerrors.New("WatchStatus not implemented")
-}
-
 func (r *AOJSubmissionRepository) Search(_ context.Context, _ repository.SubmissionSearchCriteria) ([]*entity.Submission, error) {
 	return nil, cerrors.New("Search not implemented")
 }
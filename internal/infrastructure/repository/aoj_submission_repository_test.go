@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+func newTestSubmission(t *testing.T) *entity.Submission {
+	t.Helper()
+	problemID, err := model.NewProblemID("ITP1_1_A")
+	assert.NoError(t, err)
+	submissionID, err := model.GenerateSubmissionID()
+	assert.NoError(t, err)
+	return entity.NewSubmission(submissionID, problemID, "C++17", "int main() {}")
+}
+
+func TestAOJSubmissionRepository_GetCompileError(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reviews/12345", r.URL.Path)
+		_, _ = w.Write([]byte(`{"judgeId": 12345, "compileError": "main.cpp:1:1: error: expected ';'\n", "runtimeError": ""}`))
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionRepository(server.URL, nil)
+	submission := newTestSubmission(t)
+	submission.SetJudgeID("12345")
+
+	// When
+	message, err := repo.GetCompileError(context.Background(), submission)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "main.cpp:1:1: error: expected ';'\n", message)
+}
+
+func TestAOJSubmissionRepository_GetJudgeDetail(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       repository.JudgeDetail
+		wantFailed int
+	}{
+		{
+			name: "wrong answer",
+			body: `{"submissionRecord": {"judgeId": 12345, "status": 1, "score": 0, "cpuTime": 12, "memory": 4096}, "caseVerdicts": [` +
+				`{"serial": 1, "status": "AC", "label": "testcase_00", "cpuTime": 3, "memory": 3168},` +
+				`{"serial": 2, "status": "WA", "label": "testcase_01", "cpuTime": 12, "memory": 4096},` +
+				`{"serial": 3, "status": "-", "label": "testcase_02", "cpuTime": 0, "memory": 0}]}`,
+			want: repository.JudgeDetail{
+				Time:   120 * time.Millisecond,
+				Memory: 4096,
+				Cases: []entity.CaseResult{
+					{Serial: 1, Label: "testcase_00", Status: entity.StatusAccepted, Time: 30 * time.Millisecond, Memory: 3168},
+					{Serial: 2, Label: "testcase_01", Status: entity.StatusWrongAnswer, Time: 120 * time.Millisecond, Memory: 4096},
+					{Serial: 3, Label: "testcase_02"},
+				},
+			},
+			wantFailed: 2,
+		},
+		{
+			name: "partial score",
+			body: `{"submissionRecord": {"judgeId": 12345, "status": 2, "score": 40, "cpuTime": 200, "memory": 1024}, "caseVerdicts": [` +
+				`{"serial": 1, "status": "AC", "label": "subtask1", "cpuTime": 1, "memory": 1024},` +
+				`{"serial": 2, "status": "TLE", "label": "subtask2", "cpuTime": 200, "memory": 1024}]}`,
+			want: repository.JudgeDetail{
+				Score:  40,
+				Time:   2 * time.Second,
+				Memory: 1024,
+				Cases: []entity.CaseResult{
+					{Serial: 1, Label: "subtask1", Status: entity.StatusAccepted, Time: 10 * time.Millisecond, Memory: 1024},
+					{Serial: 2, Label: "subtask2", Status: entity.StatusTimeLimitExceeded, Time: 2 * time.Second, Memory: 1024},
+				},
+			},
+			wantFailed: 2,
+		},
+		{
+			name: "unknown case status",
+			body: `{"caseVerdicts": [{"serial": 1, "status": "IE", "label": "testcase_00"}]}`,
+			want: repository.JudgeDetail{
+				Cases: []entity.CaseResult{
+					{Serial: 1, Label: "testcase_00", Status: entity.StatusInternalError},
+				},
+			},
+			wantFailed: 1,
+		},
+		{
+			name: "no case verdicts",
+			body: `{"submissionRecord": {"judgeId": 12345, "status": 1}}`,
+			want: repository.JudgeDetail{Cases: []entity.CaseResult{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/verdicts/12345", r.URL.Path)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			repo := NewAOJSubmissionRepository(server.URL, nil)
+			submission := newTestSubmission(t)
+			submission.SetJudgeID("12345")
+
+			// When
+			detail, err := repo.GetJudgeDetail(context.Background(), submission)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, detail)
+			submission.SetCaseResults(detail.Cases)
+			assert.Equal(t, tt.wantFailed, submission.FailedCase())
+		})
+	}
+}
+
+func TestAOJSubmissionRepository_GetCompileError_NotSubmitted(t *testing.T) {
+	// Given
+	repo := NewAOJSubmissionRepository("http://invalid-url-that-does-not-exist.local", nil)
+	submission := newTestSubmission(t)
+
+	// When
+	message, err := repo.GetCompileError(context.Background(), submission)
+
+	// Then
+	assert.Error(t, err)
+	assert.Empty(t, message)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+}
+
+func TestAOJSubmissionRepository_WatchStatus(t *testing.T) {
+	// Given
+	codes := []int{5, 9, 9, 4}
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/verdicts/12345", r.URL.Path)
+		code := codes[min(polls, len(codes)-1)]
+		polls++
+		_, _ = fmt.Fprintf(w, `{"submissionRecord": {"judgeId": 12345, "status": %d}}`, code)
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionRepository(server.URL, nil)
+
+	// When
+	statuses, err := repo.WatchStatus(context.Background(), model.MustNewSubmissionID("12345"), time.Millisecond)
+
+	// Then
+	assert.NoError(t, err)
+	var got []entity.SubmissionStatus
+	for status := range statuses {
+		got = append(got, status)
+	}
+	assert.Equal(t, []entity.SubmissionStatus{entity.StatusPending, entity.StatusJudging, entity.StatusAccepted}, got)
+	assert.Equal(t, 4, polls)
+}
+
+func TestAOJSubmissionRepository_WatchStatus_BacksOff(t *testing.T) {
+	// Given
+	codes := []int{5, 5, 5, 5, 5, 4}
+	clk := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	var mu sync.Mutex
+	var polls []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		code := codes[min(len(polls), len(codes)-1)]
+		polls = append(polls, clk.Now())
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"submissionRecord": {"judgeId": 12345, "status": %d}}`, code)
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionRepository(server.URL, clk)
+	interval := time.Second
+
+	// When
+	statuses, err := repo.WatchStatus(context.Background(), model.MustNewSubmissionID("12345"), interval)
+	assert.NoError(t, err)
+	for done := false; !done; {
+		select {
+		case _, ok := <-statuses:
+			done = !ok
+		case <-time.After(time.Millisecond):
+			clk.Advance(interval / 8)
+		}
+	}
+
+	// Then
+	mu.Lock()
+	defer mu.Unlock()
+	if len(polls) != len(codes) {
+		t.Fatalf("expected %d polls, got %d", len(codes), len(polls))
+	}
+	// The unchanged verdict was polled after 1.5, 2.25, 3.375 and then the
+	// capped 5 intervals
+	assert.GreaterOrEqual(t, polls[2].Sub(polls[1]), interval+interval/2)
+	assert.GreaterOrEqual(t, polls[3].Sub(polls[2]), 2*interval+interval/4)
+	assert.GreaterOrEqual(t, polls[4].Sub(polls[3]), 3*interval)
+	assert.GreaterOrEqual(t, polls[5].Sub(polls[4]), 5*interval)
+}
+
+func TestAOJSubmissionRepository_WatchStatus_StopsOnNotFound(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionRepository(server.URL, nil)
+
+	// When
+	statuses, err := repo.WatchStatus(context.Background(), model.MustNewSubmissionID("12345"), time.Millisecond)
+
+	// Then
+	assert.NoError(t, err)
+	_, open := <-statuses
+	assert.False(t, open)
+}
+
+func TestAOJSubmissionRepository_Submit_TransientErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   cerrors.ErrorCode
+	}{
+		{name: "internal server error", status: http.StatusInternalServerError, want: cerrors.CodeServiceUnavailable},
+		{name: "bad gateway", status: http.StatusBadGateway, want: cerrors.CodeServiceUnavailable},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, want: cerrors.CodeServiceUnavailable},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, want: cerrors.CodeServiceUnavailable},
+		{name: "bad request", status: http.StatusBadRequest, want: cerrors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			repo := NewAOJSubmissionRepository(server.URL, nil)
+
+			// When
+			err := repo.Submit(context.Background(), newTestSubmission(t))
+
+			// Then
+			assert.True(t, cerrors.IsAppError(err, tt.want), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestAOJSubmissionRepository_Submit_Timeout(t *testing.T) {
+	// Given
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	repo := NewAOJSubmissionRepository(server.URL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// When
+	err := repo.Submit(ctx, newTestSubmission(t))
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeTimeout), "unexpected error: %v", err)
+}
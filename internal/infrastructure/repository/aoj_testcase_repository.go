@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// DefaultTestCaseRequestInterval is the minimum time between two requests to
+// the judge data API, so that downloading many cases does not hammer AOJ
+const DefaultTestCaseRequestInterval = 500 * time.Millisecond
+
+// AOJTestCaseRepository implements TestCaseRepository for the AOJ judge data API
+type AOJTestCaseRepository struct {
+	baseURL    string
+	interval   time.Duration
+	httpClient *http.Client
+	logger     *logger.Logger
+
+	mu   sync.Mutex
+	next time.Time // earliest time of the next request
+}
+
+// NewAOJTestCaseRepository creates a new AOJTestCaseRepository that waits at
+// least interval between requests
+func NewAOJTestCaseRepository(baseURL string, interval time.Duration) repository.TestCaseRepository {
+	return &AOJTestCaseRepository{
+		baseURL:    baseURL,
+		interval:   interval,
+		httpClient: httpclient.New(5 * time.Minute),
+		logger:     logger.WithGroup("aoj_testcase_repository"),
+	}
+}
+
+// TestCaseHeadersResponse represents the test case headers in the API response
+type TestCaseHeadersResponse struct {
+	ProblemID string                   `json:"problemId"`
+	Headers   []TestCaseHeaderResponse `json:"headers"`
+}
+
+// TestCaseHeaderResponse represents a single test case header in the API response
+type TestCaseHeaderResponse struct {
+	Serial     int    `json:"serial"`
+	Name       string `json:"name"`
+	InputSize  int64  `json:"inputSize"`
+	OutputSize int64  `json:"outputSize"`
+}
+
+// ListHeaders retrieves the headers of all judge test cases of a problem
+func (r *AOJTestCaseRepository) ListHeaders(ctx context.Context, problemID model.ProblemID) ([]repository.TestCaseHeader, error) {
+	body, err := r.get(ctx, fmt.Sprintf("/testcases/%s/header", problemID.String()), problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response TestCaseHeadersResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode test case headers")
+	}
+
+	headers := make([]repository.TestCaseHeader, 0, len(response.Headers))
+	for _, header := range response.Headers {
+		headers = append(headers, repository.TestCaseHeader{
+			Serial:     header.Serial,
+			Name:       header.Name,
+			InputSize:  header.InputSize,
+			OutputSize: header.OutputSize,
+		})
+	}
+	return headers, nil
+}
+
+// GetJudgeCase downloads the full input and output of a judge test case
+func (r *AOJTestCaseRepository) GetJudgeCase(ctx context.Context, problemID model.ProblemID, serial int) (*model.TestCase, error) {
+	input, err := r.get(ctx, fmt.Sprintf("/testcases/%s/%d/in", problemID.String(), serial), problemID)
+	if err != nil {
+		return nil, err
+	}
+	output, err := r.get(ctx, fmt.Sprintf("/testcases/%s/%d/out", problemID.String(), serial), problemID)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewNamedTestCase(serial, string(input), string(output), fmt.Sprintf("judge-%d", serial)), nil
+}
+
+// DownloadJudgeCase streams one part of a judge test case from the plain text
+// endpoint of the judge data API to w
+func (r *AOJTestCaseRepository) DownloadJudgeCase(ctx context.Context, problemID model.ProblemID, serial int, part repository.JudgeCasePart, w io.Writer) (int64, error) {
+	var written int64
+	path := fmt.Sprintf("/testcases/%s/%d/%s", problemID.String(), serial, part)
+	err := r.do(ctx, path, problemID, func(body io.Reader) error {
+		tracked := &errorTrackingWriter{w: w}
+		n, err := io.Copy(tracked, body)
+		written = n
+		if err != nil && tracked.err != nil {
+			return tracked.err
+		}
+		if err != nil {
+			return cerrors.NewAppError(
+				cerrors.CodeNetworkError,
+				"failed to download judge data",
+				err,
+			)
+		}
+		return nil
+	})
+	return written, err
+}
+
+// errorTrackingWriter remembers the error of the underlying writer, telling
+// it apart from errors reading the response
+type errorTrackingWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (t *errorTrackingWriter) Write(p []byte) (int, error) {
+	n, err := t.w.Write(p)
+	if err != nil {
+		t.err = err
+	}
+	return n, err
+}
+
+// get performs a rate limited GET request and returns the response body
+func (r *AOJTestCaseRepository) get(ctx context.Context, path string, problemID model.ProblemID) ([]byte, error) {
+	var body []byte
+	err := r.do(ctx, path, problemID, func(reader io.Reader) error {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return cerrors.NewAppError(
+				cerrors.CodeNetworkError,
+				"failed to download judge data",
+				err,
+			)
+		}
+		body = data
+		return nil
+	})
+	return body, err
+}
+
+// do performs a rate limited GET request and passes the body of a successful
+// response to read
+func (r *AOJTestCaseRepository) do(ctx context.Context, path string, problemID model.ProblemID, read func(body io.Reader) error) error {
+	if err := offline.Check(ctx, "downloading judge test cases"); err != nil {
+		return err
+	}
+	if err := r.wait(ctx); err != nil {
+		return err
+	}
+
+	r.logger.DebugContext(ctx, "fetching judge data", "path", path)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", r.baseURL+path, nil)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create HTTP request")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "HTTP request failed", "error", err)
+		return cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return read(resp.Body)
+	case http.StatusNotFound:
+		return cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"judge test cases of "+problemID.String()+" are not available",
+			nil,
+		)
+	case http.StatusInternalServerError:
+		return cerrors.ErrJudgeUnavailable(resp.Status)
+	default:
+		r.logger.ErrorContext(ctx, "unexpected response status", "status", resp.StatusCode)
+		return cerrors.NewAppError(
+			cerrors.CodeInternalServer,
+			"unexpected response from AOJ: "+resp.Status,
+			nil,
+		)
+	}
+}
+
+// wait blocks until the minimum interval since the previous request has passed
+func (r *AOJTestCaseRepository) wait(ctx context.Context) error {
+	r.mu.Lock()
+	now := time.Now()
+	start := r.next
+	if start.Before(now) {
+		start = now
+	}
+	r.next = start.Add(r.interval)
+	r.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
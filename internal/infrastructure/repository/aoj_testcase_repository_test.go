@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+func TestAOJTestCaseRepository_ListHeadersAndGetJudgeCase(t *testing.T) {
+	// Given
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/testcases/ITP1_1_A/header":
+			_, _ = w.Write([]byte(`{"problemId": "ITP1_1_A", "headers": [` +
+				`{"serial": 1, "name": "in1.txt", "inputSize": 4, "outputSize": 2},` +
+				`{"serial": 2, "name": "in2.txt", "inputSize": 8, "outputSize": 3}]}`))
+		case "/testcases/ITP1_1_A/2/in":
+			_, _ = w.Write([]byte("1 2\n"))
+		case "/testcases/ITP1_1_A/2/out":
+			_, _ = w.Write([]byte("3\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	repo := NewAOJTestCaseRepository(server.URL, 20*time.Millisecond)
+	pid := model.MustNewProblemID("ITP1_1_A")
+	start := time.Now()
+
+	// When
+	headers, err := repo.ListHeaders(context.Background(), pid)
+	assert.NoError(t, err)
+	tc, err := repo.GetJudgeCase(context.Background(), pid, 2)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []repository.TestCaseHeader{
+		{Serial: 1, Name: "in1.txt", InputSize: 4, OutputSize: 2},
+		{Serial: 2, Name: "in2.txt", InputSize: 8, OutputSize: 3},
+	}, headers)
+	assert.Equal(t, "1 2\n", tc.Input())
+	assert.Equal(t, "3\n", tc.Expected())
+	assert.Equal(t, "judge-2", tc.Name())
+	assert.Equal(t, []string{"/testcases/ITP1_1_A/header", "/testcases/ITP1_1_A/2/in", "/testcases/ITP1_1_A/2/out"}, paths)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "requests are spaced by the interval")
+}
+
+func TestAOJTestCaseRepository_Errors(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	repo := NewAOJTestCaseRepository(server.URL, 0)
+	pid := model.MustNewProblemID("ITP1_1_A")
+
+	// When
+	_, notFoundErr := repo.ListHeaders(context.Background(), pid)
+	_, offlineErr := repo.GetJudgeCase(offline.WithOffline(context.Background(), true), pid, 1)
+
+	// Then
+	assert.True(t, cerrors.IsAppError(notFoundErr, cerrors.CodeNotFound))
+	assert.True(t, cerrors.IsAppError(offlineErr, cerrors.CodeOffline))
+}
+
+func TestAOJTestCaseRepository_DownloadJudgeCase(t *testing.T) {
+	// Given
+	data := strings.Repeat("1234567\n", 1<<16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/testcases/ITP1_1_A/1/in":
+			_, _ = w.Write([]byte(data))
+		case "/testcases/ITP1_1_A/2/in":
+			// Announce more data than is sent to simulate a dropped connection
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			_, _ = w.Write([]byte(data[:100]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	repo := NewAOJTestCaseRepository(server.URL, 0)
+	pid := model.MustNewProblemID("ITP1_1_A")
+	ctx := context.Background()
+	writeErr := errors.New("disk full")
+
+	// When
+	var buf bytes.Buffer
+	n, err := repo.DownloadJudgeCase(ctx, pid, 1, repository.JudgeCaseInput, &buf)
+	_, failedWriteErr := repo.DownloadJudgeCase(ctx, pid, 1, repository.JudgeCaseInput, failingWriter{writeErr})
+	_, truncatedErr := repo.DownloadJudgeCase(ctx, pid, 2, repository.JudgeCaseInput, &bytes.Buffer{})
+	_, notFoundErr := repo.DownloadJudgeCase(ctx, pid, 1, repository.JudgeCaseOutput, &bytes.Buffer{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.Equal(t, data, buf.String())
+	assert.ErrorIs(t, failedWriteErr, writeErr)
+	assert.True(t, cerrors.IsAppError(truncatedErr, cerrors.CodeNetworkError), "unexpected error: %v", truncatedErr)
+	assert.True(t, cerrors.IsAppError(notFoundErr, cerrors.CodeNotFound))
+}
+
+// failingWriter fails every write with err
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/httpclient"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// maxRetryAfter caps how long a Retry-After header can pause verdict polling
+const maxRetryAfter = time.Minute
+
+// verdictPoll is the outcome of one request for the verdict of a submission.
+// AOJ offers no push channel for verdicts, so watching polls; the validators
+// of the previous poll make unchanged verdicts cheap 304 responses when AOJ
+// supports conditional requests
+type verdictPoll struct {
+	status       entity.SubmissionStatus
+	etag         string        // sent back as If-None-Match
+	lastModified string        // sent back as If-Modified-Since
+	notModified  bool          // AOJ answered 304 and status is the previous one
+	retryAfter   time.Duration // how long AOJ asked clients to wait; 0 if it did not
+}
+
+// pollVerdict requests the verdict of a submission, conditional on previous
+func (r *AOJSubmissionRepository) pollVerdict(ctx context.Context, id model.SubmissionID, previous verdictPoll) (verdictPoll, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.baseURL+"/verdicts/"+id.String(), nil)
+	if err != nil {
+		return verdictPoll{}, cerrors.Wrap(err, "failed to create HTTP request")
+	}
+	if previous.status != "" {
+		if previous.etag != "" {
+			req.Header.Set("If-None-Match", previous.etag)
+		}
+		if previous.lastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.lastModified)
+		}
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return verdictPoll{}, cerrors.ErrAOJUnreachable(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	poll := verdictPoll{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		retryAfter:   parseRetryAfter(resp.Header.Get("Retry-After"), r.clock.Now()),
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var verdict VerdictResponse
+		if err := json.NewDecoder(resp.Body).Decode(&verdict); err != nil {
+			return poll, cerrors.Wrap(err, "failed to decode verdict response")
+		}
+		status, ok := aojStatusCodes[verdict.SubmissionRecord.Status]
+		if !ok {
+			status = entity.StatusPending
+		}
+		poll.status = status
+		return poll, nil
+	case http.StatusNotModified:
+		poll.status = previous.status
+		poll.notModified = true
+		if poll.etag == "" {
+			poll.etag = previous.etag
+		}
+		if poll.lastModified == "" {
+			poll.lastModified = previous.lastModified
+		}
+		return poll, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return poll, cerrors.ErrSessionRejected()
+	case http.StatusNotFound:
+		return poll, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"verdict not found for submission "+id.String(),
+			nil,
+		)
+	case http.StatusTooManyRequests:
+		return poll, cerrors.NewAppError(
+			cerrors.CodeServiceUnavailable,
+			"AOJ is limiting requests: "+resp.Status,
+			nil,
+		)
+	default:
+		return poll, cerrors.ErrJudgeUnavailable(resp.Status)
+	}
+}
+
+// parseRetryAfter converts a Retry-After header, given in seconds or as an
+// HTTP date, to a delay of at most maxRetryAfter. Missing or invalid values are 0
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	return min(httpclient.ParseRetryAfter(value, now), maxRetryAfter)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestAOJSubmissionRepository_WatchStatus_ConditionalRequests(t *testing.T) {
+	// Given
+	codes := []int{5, 5, 5, 4}
+	var conditions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := codes[min(len(conditions), len(codes)-1)]
+		conditions = append(conditions, r.Header.Get("If-None-Match"))
+		etag := fmt.Sprintf(`"v%d"`, code)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = fmt.Fprintf(w, `{"submissionRecord": {"judgeId": 12345, "status": %d}}`, code)
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionRepository(server.URL, nil)
+
+	// When
+	statuses, err := repo.WatchStatus(context.Background(), model.MustNewSubmissionID("12345"), time.Millisecond)
+
+	// Then
+	assert.NoError(t, err)
+	var got []entity.SubmissionStatus
+	for status := range statuses {
+		got = append(got, status)
+	}
+	assert.Equal(t, []entity.SubmissionStatus{entity.StatusPending, entity.StatusAccepted}, got)
+	assert.Equal(t, []string{"", `"v5"`, `"v5"`, `"v5"`}, conditions,
+		"the validator of the last full response is sent after 304s")
+}
+
+func TestAOJSubmissionRepository_pollVerdict_RateLimited(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	repo := NewAOJSubmissionRepository(server.URL, nil).(*AOJSubmissionRepository)
+
+	// When
+	poll, err := repo.pollVerdict(context.Background(), model.MustNewSubmissionID("12345"), verdictPoll{})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeServiceUnavailable), "unexpected error: %v", err)
+	assert.Equal(t, 3*time.Second, poll.retryAfter)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "5", want: 5 * time.Second},
+		{name: "date", value: "Wed, 01 Apr 2026 12:00:10 GMT", want: 10 * time.Second},
+		{name: "past date", value: "Wed, 01 Apr 2026 11:00:00 GMT", want: 0},
+		{name: "capped", value: "3600", want: maxRetryAfter},
+		{name: "negative", value: "-5", want: 0},
+		{name: "invalid", value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.value, now))
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// Storage buckets of cached test cases and problem metadata
+const (
+	testCasesBucket = "cache/testcases"
+	problemsBucket  = "cache/problems"
+)
+
+// DefaultProblemCacheTTL is how long fetched problem metadata is reused by default
+const DefaultProblemCacheTTL = 7 * 24 * time.Hour
+
+// CachedProblemRepository decorates a ProblemRepository with a local cache
+// so that downloaded data stays available in offline mode and problem
+// metadata is not fetched again while it is fresh
+type CachedProblemRepository struct {
+	remote repository.ProblemRepository
+	store  storage.Store
+	ttl    time.Duration
+	clock  clock.Clock
+	logger *logger.Logger
+}
+
+// NewCachedProblemRepository creates a new CachedProblemRepository that caches into store
+func NewCachedProblemRepository(remote repository.ProblemRepository, store storage.Store) repository.ProblemRepository {
+	return NewCachedProblemRepositoryWithTTL(remote, store, DefaultProblemCacheTTL, clock.System())
+}
+
+// NewCachedProblemRepositoryWithTTL creates a new CachedProblemRepository that
+// reuses problem metadata for ttl, measured with clk. A ttl of 0 always
+// fetches and only falls back to the cache offline or without network
+func NewCachedProblemRepositoryWithTTL(remote repository.ProblemRepository, store storage.Store, ttl time.Duration, clk clock.Clock) repository.ProblemRepository {
+	return &CachedProblemRepository{
+		remote: remote,
+		store:  store,
+		ttl:    ttl,
+		clock:  clk,
+		logger: logger.WithGroup("cached_problem_repository"),
+	}
+}
+
+// ProblemCache represents the JSON structure of cached problem metadata
+type ProblemCache struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	TimeLimit   float64 `json:"time_limit"`   // in seconds
+	MemoryLimit int64   `json:"memory_limit"` // in KB
+	Category    string  `json:"category,omitempty"`
+	Difficulty  int     `json:"difficulty,omitempty"`
+	FetchedAt   int64   `json:"fetched_at"`
+}
+
+// GetByID retrieves the metadata of a problem from the cache while it is
+// fresh, and from the remote repository otherwise. Offline or without
+// network, stale metadata is used as well
+func (r *CachedProblemRepository) GetByID(ctx context.Context, id model.ProblemID) (*entity.Problem, error) {
+	cached, fresh := r.loadProblem(id)
+	if fresh || (cached != nil && offline.IsOffline(ctx)) {
+		r.logger.DebugContext(ctx, "using cached problem", "problem_id", id.String())
+		return cached, nil
+	}
+	if offline.IsOffline(ctx) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeOffline,
+			"no cached metadata for "+id.String()+". Run the command again without --offline to download it",
+			nil,
+		)
+	}
+
+	problem, err := r.remote.GetByID(ctx, id)
+	if err != nil {
+		if cached != nil && cerrors.IsAppError(err, cerrors.CodeNetworkError) {
+			r.logger.WarnContext(ctx, "network unavailable, using cached problem",
+				"problem_id", id.String(),
+				"error", err)
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	if err := r.Save(ctx, problem); err != nil {
+		r.logger.WarnContext(ctx, "failed to cache problem", "error", err)
+	}
+	return problem, nil
+}
+
+// GetByIDs retrieves several problems like GetByID, fetching the ones not
+// cached concurrently
+func (r *CachedProblemRepository) GetByIDs(ctx context.Context, ids []model.ProblemID) ([]*entity.Problem, error) {
+	return getProblems(ctx, ids, r.GetByID)
+}
+
+// Search searches for problems by criteria
+func (r *CachedProblemRepository) Search(ctx context.Context, criteria repository.ProblemSearchCriteria) ([]*entity.Problem, error) {
+	return r.remote.Search(ctx, criteria)
+}
+
+// Save stores the metadata of a problem in the local cache
+func (r *CachedProblemRepository) Save(ctx context.Context, problem *entity.Problem) error {
+	content, err := json.Marshal(ProblemCache{
+		ID:          problem.ID().String(),
+		Title:       problem.Title(),
+		Description: problem.Description(),
+		TimeLimit:   problem.TimeLimit().Seconds(),
+		MemoryLimit: problem.MemoryLimit(),
+		Category:    problem.Category(),
+		Difficulty:  problem.Difficulty(),
+		FetchedAt:   r.clock.Now().Unix(),
+	})
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode problem")
+	}
+
+	if err := r.store.Put(problemsBucket, problemKey(problem.ID()), content); err != nil {
+		return cerrors.Wrap(err, "failed to write problem cache")
+	}
+
+	r.logger.DebugContext(ctx, "problem cached", "problem_id", problem.ID().String())
+	return nil
+}
+
+// Delete deletes the cached data of a problem
+func (r *CachedProblemRepository) Delete(_ context.Context, id model.ProblemID) error {
+	if err := r.store.Delete(testCasesBucket, testCasesKey(id)); err != nil {
+		return cerrors.Wrap(err, "failed to delete cached test cases")
+	}
+	if err := r.store.Delete(problemsBucket, problemKey(id)); err != nil {
+		return cerrors.Wrap(err, "failed to delete cached problem")
+	}
+	return nil
+}
+
+// Exists checks if a problem exists, answering from the cache when possible
+func (r *CachedProblemRepository) Exists(ctx context.Context, id model.ProblemID) (bool, error) {
+	if cached, _ := r.loadProblem(id); cached != nil {
+		return true, nil
+	}
+	return r.remote.Exists(ctx, id)
+}
+
+// GetTestCases retrieves test cases, preferring the remote repository and
+// falling back to the cache when offline or when the network is unavailable
+func (r *CachedProblemRepository) GetTestCases(ctx context.Context, problemID model.ProblemID) ([]model.TestCase, error) {
+	if offline.IsOffline(ctx) {
+		testCases, err := r.loadTestCases(problemID)
+		if err != nil {
+			if cerrors.IsAppError(err, cerrors.CodeNotFound) {
+				return nil, cerrors.NewAppError(
+					cerrors.CodeOffline,
+					"no cached test cases for "+problemID.String()+". Run the command again without --offline to download them",
+					nil,
+				)
+			}
+			return nil, err
+		}
+		r.logger.DebugContext(ctx, "using cached test cases",
+			"problem_id", problemID.String(),
+			"count", len(testCases))
+		return testCases, nil
+	}
+
+	testCases, err := r.remote.GetTestCases(ctx, problemID)
+	if err != nil {
+		if !cerrors.IsAppError(err, cerrors.CodeNetworkError) {
+			return nil, err
+		}
+
+		cached, cacheErr := r.loadTestCases(problemID)
+		if cacheErr != nil {
+			return nil, err
+		}
+		r.logger.WarnContext(ctx, "network unavailable, using cached test cases",
+			"problem_id", problemID.String(),
+			"error", err)
+		return cached, nil
+	}
+
+	if err := r.SaveTestCases(ctx, problemID, testCases); err != nil {
+		r.logger.WarnContext(ctx, "failed to cache test cases", "error", err)
+	}
+
+	return testCases, nil
+}
+
+// SaveTestCases saves test cases for a problem to the local cache
+func (r *CachedProblemRepository) SaveTestCases(ctx context.Context, problemID model.ProblemID, testCases []model.TestCase) error {
+	data := make([]TestCaseResponse, 0, len(testCases))
+	for _, tc := range testCases {
+		data = append(data, TestCaseResponse{
+			Serial: tc.ID(),
+			In:     tc.Input(),
+			Out:    tc.Expected(),
+		})
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode test cases")
+	}
+
+	if err := r.store.Put(testCasesBucket, testCasesKey(problemID), content); err != nil {
+		return cerrors.Wrap(err, "failed to write test case cache")
+	}
+
+	r.logger.DebugContext(ctx, "test cases cached",
+		"problem_id", problemID.String(),
+		"count", len(testCases))
+
+	return nil
+}
+
+// Helper methods
+
+func (r *CachedProblemRepository) loadTestCases(problemID model.ProblemID) ([]model.TestCase, error) {
+	content, err := r.store.Get(testCasesBucket, testCasesKey(problemID))
+	if storage.IsNotFound(err) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"test cases not cached",
+			nil,
+		)
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read test case cache")
+	}
+
+	var data []TestCaseResponse
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode test case cache")
+	}
+
+	testCases := make([]model.TestCase, 0, len(data))
+	for _, tc := range data {
+		testCases = append(testCases, *model.NewTestCase(tc.Serial, tc.In, tc.Out))
+	}
+	return testCases, nil
+}
+
+// loadProblem returns the cached problem (nil when absent) and whether it is still fresh
+func (r *CachedProblemRepository) loadProblem(id model.ProblemID) (*entity.Problem, bool) {
+	content, err := r.store.Get(problemsBucket, problemKey(id))
+	if err != nil {
+		return nil, false
+	}
+
+	var cache ProblemCache
+	if err := json.Unmarshal(content, &cache); err != nil || cache.ID != id.String() {
+		return nil, false
+	}
+
+	problem := entity.NewProblem(
+		id,
+		cache.Title,
+		cache.Description,
+		time.Duration(cache.TimeLimit*float64(time.Second)),
+		cache.MemoryLimit,
+		cache.Category,
+		cache.Difficulty,
+	)
+	fresh := r.clock.Now().Sub(time.Unix(cache.FetchedAt, 0)) < r.ttl
+	return problem, fresh
+}
+
+func testCasesKey(id model.ProblemID) string {
+	return id.String() + ".json"
+}
+
+func problemKey(id model.ProblemID) string {
+	return id.String() + ".json"
+}
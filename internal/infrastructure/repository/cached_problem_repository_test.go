@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+func TestCachedProblemRepository_GetTestCases_CachesRemoteResult(t *testing.T) {
+	// Given
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[{"serial": 1, "in": "1 2\n", "out": "3\n"}]`))
+	}))
+	defer server.Close()
+
+	repo := NewCachedProblemRepository(NewAOJProblemRepository(server.URL), storage.NewFileStore(t.TempDir()))
+	pid := model.MustNewProblemID("ITP1_1_A")
+	ctx := context.Background()
+
+	// When - fetch online to populate the cache
+	testCases, err := repo.GetTestCases(ctx, pid)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, testCases, 1)
+	assert.Equal(t, 1, requests)
+
+	// When - fetch offline
+	cached, err := repo.GetTestCases(offline.WithOffline(ctx, true), pid)
+
+	// Then - served from the cache without another request
+	assert.NoError(t, err)
+	assert.Len(t, cached, 1)
+	assert.Equal(t, "1 2\n", cached[0].Input())
+	assert.Equal(t, "3\n", cached[0].Expected())
+	assert.Equal(t, 1, requests)
+}
+
+func TestCachedProblemRepository_GetTestCases_OfflineWithoutCache(t *testing.T) {
+	// Given
+	repo := NewCachedProblemRepository(NewAOJProblemRepository("http://example.com"), storage.NewFileStore(t.TempDir()))
+	ctx := offline.WithOffline(context.Background(), true)
+
+	// When
+	testCases, err := repo.GetTestCases(ctx, model.MustNewProblemID("ITP1_1_A"))
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, testCases)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeOffline))
+}
+
+func TestCachedProblemRepository_GetTestCases_FallsBackOnNetworkError(t *testing.T) {
+	// Given
+	store := storage.NewFileStore(t.TempDir())
+	pid := model.MustNewProblemID("ITP1_1_A")
+	ctx := context.Background()
+
+	seeded := NewCachedProblemRepository(NewMockProblemRepository(), store)
+	err := seeded.SaveTestCases(ctx, pid, []model.TestCase{*model.NewTestCase(1, "in\n", "out\n")})
+	assert.NoError(t, err)
+
+	repo := NewCachedProblemRepository(NewAOJProblemRepository("http://invalid-url-that-does-not-exist.local"), store)
+
+	// When
+	testCases, err := repo.GetTestCases(ctx, pid)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, testCases, 1)
+}
+
+func TestCachedProblemRepository_GetByID_TTL(t *testing.T) {
+	// Given
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"id": "ITP1_1_A", "name": "Hello World", "problemTimeLimit": 1, "problemMemoryLimit": 131072}`))
+	}))
+	defer server.Close()
+
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	repo := NewCachedProblemRepositoryWithTTL(NewAOJProblemRepository(server.URL), storage.NewFileStore(t.TempDir()), time.Hour, clk)
+	pid := model.MustNewProblemID("ITP1_1_A")
+	ctx := context.Background()
+
+	// When
+	first, firstErr := repo.GetByID(ctx, pid)
+	clk.Advance(30 * time.Minute)
+	cached, cachedErr := repo.GetByID(ctx, pid)
+	requestsWhileFresh := requests
+	clk.Advance(time.Hour)
+	_, refetchErr := repo.GetByID(ctx, pid)
+
+	// Then
+	if firstErr != nil || cachedErr != nil || refetchErr != nil {
+		t.Fatalf("GetByID failed: %v, %v, %v", firstErr, cachedErr, refetchErr)
+	}
+	assert.Equal(t, "Hello World", first.Title())
+	assert.Equal(t, "Hello World", cached.Title())
+	assert.Equal(t, time.Second, cached.TimeLimit())
+	assert.Equal(t, int64(131072), cached.MemoryLimit())
+	assert.Equal(t, 1, requestsWhileFresh, "fresh metadata is not fetched again")
+	assert.Equal(t, 2, requests, "stale metadata is fetched again")
+}
+
+func TestCachedProblemRepository_GetByID_Offline(t *testing.T) {
+	// Given
+	store := storage.NewFileStore(t.TempDir())
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	pid := model.MustNewProblemID("ITP1_1_A")
+	repo := NewCachedProblemRepositoryWithTTL(NewAOJProblemRepository("http://invalid-url-that-does-not-exist.local"), store, time.Hour, clk)
+	offlineCtx := offline.WithOffline(context.Background(), true)
+
+	// When
+	_, missingErr := repo.GetByID(offlineCtx, pid)
+	saveErr := repo.Save(context.Background(), entity.NewProblem(pid, "Hello World", "", time.Second, 131072, "", 0))
+	clk.Advance(24 * time.Hour)
+	stale, staleErr := repo.GetByID(offlineCtx, pid)
+	fallback, fallbackErr := repo.GetByID(context.Background(), pid)
+
+	// Then
+	assert.True(t, cerrors.IsAppError(missingErr, cerrors.CodeOffline), "got %v", missingErr)
+	assert.NoError(t, saveErr)
+	if staleErr != nil || fallbackErr != nil {
+		t.Fatalf("GetByID failed: %v, %v", staleErr, fallbackErr)
+	}
+	assert.Equal(t, "Hello World", stale.Title(), "stale metadata is used offline")
+	assert.Equal(t, "Hello World", fallback.Title(), "stale metadata is used without network")
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// historyBucket is the storage bucket of the submission history
+const historyBucket = "history"
+
+// CachedSubmissionRepository decorates a SubmissionRepository with a local
+// history of submissions, including their source code
+type CachedSubmissionRepository struct {
+	remote repository.SubmissionRepository
+	store  storage.Store
+	logger *logger.Logger
+}
+
+// NewCachedSubmissionRepository creates a new CachedSubmissionRepository that keeps the history in store
+func NewCachedSubmissionRepository(remote repository.SubmissionRepository, store storage.Store) repository.SubmissionRepository {
+	return &CachedSubmissionRepository{
+		remote: remote,
+		store:  store,
+		logger: logger.WithGroup("cached_submission_repository"),
+	}
+}
+
+// SubmissionData represents the JSON structure for submission history storage
+type SubmissionData struct {
+	ID           string     `json:"id"`
+	JudgeID      string     `json:"judge_id,omitempty"`
+	ProblemID    string     `json:"problem_id"`
+	Language     string     `json:"language"`
+	SourceCode   string     `json:"source_code"`
+	Status       string     `json:"status"`
+	Score        int        `json:"score"`
+	TimeMillis   int64      `json:"time_ms"`
+	Memory       int64      `json:"memory"`
+	Message      string     `json:"message,omitempty"`
+	CompileError string     `json:"compile_error,omitempty"`
+	FailedCase   int        `json:"failed_case,omitempty"`
+	Cases        []CaseData `json:"cases,omitempty"`
+	SubmittedAt  int64      `json:"submitted_at"`
+	JudgedAt     *int64     `json:"judged_at,omitempty"`
+}
+
+// CaseData represents the judge result of a submission on one test case in the local history
+type CaseData struct {
+	Serial     int    `json:"serial"`
+	Label      string `json:"label,omitempty"`
+	Status     string `json:"status,omitempty"`
+	TimeMillis int64  `json:"time_ms"`
+	Memory     int64  `json:"memory"`
+}
+
+// Submit submits a solution and records it in the local history
+func (r *CachedSubmissionRepository) Submit(ctx context.Context, submission *entity.Submission) error {
+	if err := r.remote.Submit(ctx, submission); err != nil {
+		return err
+	}
+
+	if err := r.Save(ctx, submission); err != nil {
+		r.logger.WarnContext(ctx, "failed to record submission history", "error", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a submission from the local history
+func (r *CachedSubmissionRepository) GetByID(_ context.Context, id model.SubmissionID) (*entity.Submission, error) {
+	content, err := r.store.Get(historyBucket, submissionKey(id))
+	if storage.IsNotFound(err) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"submission "+id.String()+" not found in local history",
+			nil,
+		)
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+
+	var data SubmissionData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode submission history")
+	}
+
+	return r.dataToSubmission(data)
+}
+
+// GetByProblemID retrieves the most recent submissions for a problem from the local history
+func (r *CachedSubmissionRepository) GetByProblemID(ctx context.Context, problemID model.ProblemID, limit int) ([]*entity.Submission, error) {
+	criteria := repository.NewSubmissionSearchCriteria().
+		WithProblemID(problemID).
+		WithLimit(limit)
+	return r.Search(ctx, criteria)
+}
+
+// GetRecent retrieves the most recent submissions from the local history
+func (r *CachedSubmissionRepository) GetRecent(ctx context.Context, limit int) ([]*entity.Submission, error) {
+	return r.Search(ctx, repository.NewSubmissionSearchCriteria().WithLimit(limit))
+}
+
+// GetStatus retrieves the current status of a submission
+func (r *CachedSubmissionRepository) GetStatus(ctx context.Context, id model.SubmissionID) (entity.SubmissionStatus, error) {
+	return r.remote.GetStatus(ctx, id)
+}
+
+// GetCompileError retrieves the compiler message of a submission
+func (r *CachedSubmissionRepository) GetCompileError(ctx context.Context, submission *entity.Submission) (string, error) {
+	return r.remote.GetCompileError(ctx, submission)
+}
+
+// GetJudgeDetail retrieves the judge record of a submission
+func (r *CachedSubmissionRepository) GetJudgeDetail(ctx context.Context, submission *entity.Submission) (repository.JudgeDetail, error) {
+	return r.remote.GetJudgeDetail(ctx, submission)
+}
+
+// WatchStatus watches for status changes of a submission
+func (r *CachedSubmissionRepository) WatchStatus(ctx context.Context, id model.SubmissionID, interval time.Duration) (<-chan entity.SubmissionStatus, error) {
+	return r.remote.WatchStatus(ctx, id, interval)
+}
+
+// Search searches the local history, newest first
+func (r *CachedSubmissionRepository) Search(ctx context.Context, criteria repository.SubmissionSearchCriteria) ([]*entity.Submission, error) {
+	submissions, err := r.loadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchSubmissions(submissions, criteria), nil
+}
+
+// Save records a submission in the local history
+func (r *CachedSubmissionRepository) Save(ctx context.Context, submission *entity.Submission) error {
+	data := SubmissionData{
+		ID:           submission.ID().String(),
+		JudgeID:      submission.JudgeID(),
+		ProblemID:    submission.ProblemID().String(),
+		Language:     submission.Language(),
+		SourceCode:   submission.SourceCode(),
+		Status:       string(submission.Status()),
+		Score:        submission.Score(),
+		TimeMillis:   submission.Time().Milliseconds(),
+		Memory:       submission.Memory(),
+		Message:      submission.Message(),
+		CompileError: submission.CompileError(),
+		FailedCase:   submission.FailedCase(),
+		SubmittedAt:  submission.SubmittedAt().Unix(),
+	}
+	for _, result := range submission.CaseResults() {
+		data.Cases = append(data.Cases, CaseData{
+			Serial:     result.Serial,
+			Label:      result.Label,
+			Status:     string(result.Status),
+			TimeMillis: result.Time.Milliseconds(),
+			Memory:     result.Memory,
+		})
+	}
+	if judgedAt := submission.JudgedAt(); judgedAt != nil {
+		unix := judgedAt.Unix()
+		data.JudgedAt = &unix
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode submission history")
+	}
+
+	if err := r.store.Put(historyBucket, submissionKey(submission.ID()), content); err != nil {
+		return cerrors.Wrap(err, "failed to write submission history")
+	}
+
+	r.logger.DebugContext(ctx, "submission recorded",
+		"submission_id", submission.ID().String(),
+		"problem_id", submission.ProblemID().String())
+
+	return nil
+}
+
+// Delete removes a submission from the local history
+func (r *CachedSubmissionRepository) Delete(_ context.Context, id model.SubmissionID) error {
+	if err := r.store.Delete(historyBucket, submissionKey(id)); err != nil {
+		return cerrors.Wrap(err, "failed to delete submission history")
+	}
+	return nil
+}
+
+// Exists checks if a submission exists in the local history
+func (r *CachedSubmissionRepository) Exists(_ context.Context, id model.SubmissionID) (bool, error) {
+	_, err := r.store.Get(historyBucket, submissionKey(id))
+	if storage.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, cerrors.Wrap(err, "failed to check submission history")
+	}
+	return true, nil
+}
+
+// Helper methods
+
+func (r *CachedSubmissionRepository) loadAll(ctx context.Context) ([]*entity.Submission, error) {
+	keys, err := r.store.Keys(historyBucket)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read history directory")
+	}
+
+	submissions := make([]*entity.Submission, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ".json") {
+			continue
+		}
+
+		id, err := model.NewSubmissionID(strings.TrimSuffix(key, ".json"))
+		if err != nil {
+			continue
+		}
+
+		submission, err := r.GetByID(ctx, id)
+		if err != nil {
+			r.logger.WarnContext(ctx, "skipping unreadable submission history",
+				"file", key,
+				"error", err)
+			continue
+		}
+		submissions = append(submissions, submission)
+	}
+
+	return submissions, nil
+}
+
+func (r *CachedSubmissionRepository) dataToSubmission(data SubmissionData) (*entity.Submission, error) {
+	id, err := model.NewSubmissionID(data.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	problemID, err := model.NewProblemID(data.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var judgedAt *time.Time
+	if data.JudgedAt != nil {
+		t := time.Unix(*data.JudgedAt, 0)
+		judgedAt = &t
+	}
+
+	submission := entity.RestoreSubmission(
+		id,
+		problemID,
+		data.Language,
+		data.SourceCode,
+		entity.SubmissionStatus(data.Status),
+		data.Score,
+		time.Duration(data.TimeMillis)*time.Millisecond,
+		data.Memory,
+		data.Message,
+		time.Unix(data.SubmittedAt, 0),
+		judgedAt,
+	)
+	submission.SetJudgeID(data.JudgeID)
+	submission.SetCompileError(data.CompileError)
+	submission.SetFailedCase(data.FailedCase)
+	if len(data.Cases) > 0 {
+		results := make([]entity.CaseResult, len(data.Cases))
+		for i, c := range data.Cases {
+			results[i] = entity.CaseResult{
+				Serial: c.Serial,
+				Label:  c.Label,
+				Status: entity.SubmissionStatus(c.Status),
+				Time:   time.Duration(c.TimeMillis) * time.Millisecond,
+				Memory: c.Memory,
+			}
+		}
+		submission.SetCaseResults(results)
+	}
+
+	return submission, nil
+}
+
+func submissionKey(id model.SubmissionID) string {
+	return id.String() + ".json"
+}
+
+// searchSubmissions returns the submissions matching the criteria, newest first
+func searchSubmissions(submissions []*entity.Submission, criteria repository.SubmissionSearchCriteria) []*entity.Submission {
+	matched := make([]*entity.Submission, 0, len(submissions))
+	for _, submission := range submissions {
+		if criteria.ProblemID != nil && !submission.ProblemID().Equals(*criteria.ProblemID) {
+			continue
+		}
+		if criteria.Language != "" && !strings.EqualFold(submission.Language(), criteria.Language) {
+			continue
+		}
+		if criteria.Status != nil && submission.Status() != *criteria.Status {
+			continue
+		}
+		if criteria.SubmittedAt != nil && !criteria.SubmittedAt.Contains(submission.SubmittedAt()) {
+			continue
+		}
+		matched = append(matched, submission)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].SubmittedAt().After(matched[j].SubmittedAt())
+	})
+
+	if criteria.Offset > 0 {
+		if criteria.Offset >= len(matched) {
+			return []*entity.Submission{}
+		}
+		matched = matched[criteria.Offset:]
+	}
+	if criteria.Limit > 0 && len(matched) > criteria.Limit {
+		matched = matched[:criteria.Limit]
+	}
+
+	return matched
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+func TestCachedSubmissionRepository_SaveAndGetByID(t *testing.T) {
+	// Given
+	repo := NewCachedSubmissionRepository(NewAOJSubmissionRepository("http://invalid-url-that-does-not-exist.local", nil), storage.NewFileStore(t.TempDir()))
+	ctx := context.Background()
+
+	submission := newTestSubmission(t)
+	submission.SetJudgeID("12345")
+	submission.UpdateResult(entity.StatusCompileError, 0, 0, 0, "")
+	submission.SetCompileError("error: expected ';'")
+
+	// When
+	err := repo.Save(ctx, submission)
+	assert.NoError(t, err)
+	restored, err := repo.GetByID(ctx, submission.ID())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, submission.ID(), restored.ID())
+	assert.Equal(t, submission.ProblemID(), restored.ProblemID())
+	assert.Equal(t, submission.SourceCode(), restored.SourceCode())
+	assert.Equal(t, entity.StatusCompileError, restored.Status())
+	assert.Equal(t, "12345", restored.JudgeID())
+	assert.Equal(t, "error: expected ';'", restored.CompileError())
+	assert.True(t, restored.IsJudged())
+}
+
+func TestCachedSubmissionRepository_GetByIDNotFound(t *testing.T) {
+	// Given
+	repo := NewCachedSubmissionRepository(NewAOJSubmissionRepository("http://invalid-url-that-does-not-exist.local", nil), storage.NewFileStore(t.TempDir()))
+
+	// When
+	_, err := repo.GetByID(context.Background(), model.NewSubmissionIDFromInt(1))
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound))
+}
+
+func TestCachedSubmissionRepository_GetByProblemID_NewestFirst(t *testing.T) {
+	// Given
+	repo := NewCachedSubmissionRepository(NewAOJSubmissionRepository("http://invalid-url-that-does-not-exist.local", nil), storage.NewFileStore(t.TempDir()))
+	ctx := context.Background()
+
+	target := model.MustNewProblemID("ITP1_1_A")
+	other := model.MustNewProblemID("ITP1_1_B")
+	base := time.Now().Add(-time.Hour)
+
+	for i, problemID := range []model.ProblemID{target, other, target} {
+		submission := entity.RestoreSubmission(
+			model.NewSubmissionIDFromInt(int64(i+1)),
+			problemID,
+			"C++17",
+			"source",
+			entity.StatusAccepted,
+			100,
+			0,
+			0,
+			"",
+			base.Add(time.Duration(i)*time.Minute),
+			nil,
+		)
+		assert.NoError(t, repo.Save(ctx, submission))
+	}
+
+	// When
+	submissions, err := repo.GetByProblemID(ctx, target, 1)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, submissions, 1)
+	assert.Equal(t, "3", submissions[0].ID().String())
+}
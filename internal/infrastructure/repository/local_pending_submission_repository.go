@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// pendingSubmissionsKey is the key of the submission queue in the root bucket
+const pendingSubmissionsKey = "pending_submissions.json"
+
+// LocalPendingSubmissionRepository implements PendingSubmissionRepository by
+// storing the queue as one JSON document
+type LocalPendingSubmissionRepository struct {
+	store  storage.Store
+	logger *logger.Logger
+}
+
+// NewLocalPendingSubmissionRepository creates a new LocalPendingSubmissionRepository that keeps the queue in store
+func NewLocalPendingSubmissionRepository(store storage.Store) repository.PendingSubmissionRepository {
+	return &LocalPendingSubmissionRepository{
+		store:  store,
+		logger: logger.WithGroup("local_pending_submission_repository"),
+	}
+}
+
+// List retrieves the queued submissions, oldest first; a missing queue is empty
+func (r *LocalPendingSubmissionRepository) List(_ context.Context) ([]repository.PendingSubmission, error) {
+	content, err := r.store.Get("", pendingSubmissionsKey)
+	if storage.IsNotFound(err) {
+		return []repository.PendingSubmission{}, nil
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read pending submissions")
+	}
+
+	var pending []repository.PendingSubmission
+	if err := json.Unmarshal(content, &pending); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode pending submissions")
+	}
+	return pending, nil
+}
+
+// SaveAll replaces the queued submissions, removing the queue when it is empty
+func (r *LocalPendingSubmissionRepository) SaveAll(ctx context.Context, pending []repository.PendingSubmission) error {
+	if len(pending) == 0 {
+		if err := r.store.Delete("", pendingSubmissionsKey); err != nil {
+			return cerrors.Wrap(err, "failed to clear pending submissions")
+		}
+		return nil
+	}
+
+	content, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode pending submissions")
+	}
+	if err := r.store.Put("", pendingSubmissionsKey, content); err != nil {
+		return cerrors.Wrap(err, "failed to write pending submissions")
+	}
+
+	r.logger.DebugContext(ctx, "pending submissions saved", "count", len(pending))
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// localResultsBucket is the storage bucket of the local test outcomes, one
+// entry per problem so that concurrent runs do not overwrite each other
+const localResultsBucket = "local_results"
+
+// LocalResultRepository implements repository.LocalResultRepository on a storage.Store
+type LocalResultRepository struct {
+	store  storage.Store
+	logger *logger.Logger
+}
+
+// NewLocalResultRepository creates a new LocalResultRepository that keeps the outcomes in store
+func NewLocalResultRepository(store storage.Store) repository.LocalResultRepository {
+	return &LocalResultRepository{
+		store:  store,
+		logger: logger.WithGroup("local_result_repository"),
+	}
+}
+
+// List retrieves the last outcome of every tested problem; unreadable entries are skipped
+func (r *LocalResultRepository) List(ctx context.Context) (map[string]repository.LocalResult, error) {
+	keys, err := r.store.Keys(localResultsBucket)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list local test results")
+	}
+
+	results := make(map[string]repository.LocalResult, len(keys))
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ".json") {
+			continue
+		}
+		content, err := r.store.Get(localResultsBucket, key)
+		if err != nil {
+			r.logger.WarnContext(ctx, "failed to read local test result", "key", key, "error", err)
+			continue
+		}
+		var result repository.LocalResult
+		if err := json.Unmarshal(content, &result); err != nil || result.ProblemID == "" {
+			r.logger.WarnContext(ctx, "skipping invalid local test result", "key", key, "error", err)
+			continue
+		}
+		results[result.ProblemID] = result
+	}
+	return results, nil
+}
+
+// Save replaces the last outcome of a problem
+func (r *LocalResultRepository) Save(ctx context.Context, result repository.LocalResult) error {
+	content, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode local test result")
+	}
+	if err := r.store.Put(localResultsBucket, result.ProblemID+".json", content); err != nil {
+		return cerrors.Wrap(err, "failed to write local test result")
+	}
+
+	r.logger.DebugContext(ctx, "local test result saved", "problem_id", result.ProblemID, "verdict", result.Verdict)
+	return nil
+}
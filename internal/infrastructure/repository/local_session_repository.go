@@ -3,28 +3,54 @@ package repository
 import (
 	"context"
 	"encoding/json"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/encryption"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
 )
 
-// LocalSessionRepository implements SessionRepository for local file storage
+// Storage buckets and keys of the session repository
+const (
+	sessionsBucket    = "sessions"
+	currentSessionKey = "current_session" // stored in the root bucket
+)
+
+// LocalSessionRepository implements SessionRepository for local storage
 type LocalSessionRepository struct {
-	configDir string
-	logger    *logger.Logger
+	store  storage.Store
+	cipher encryption.Cipher // nil means sessions are stored as plain JSON
+	clock  clock.Clock       // tells which sessions have expired
+	logger *logger.Logger
 }
 
-// NewLocalSessionRepository creates a new LocalSessionRepository
+// NewLocalSessionRepository creates a new LocalSessionRepository that keeps sessions as files in configDir
 func NewLocalSessionRepository(configDir string) repository.SessionRepository {
+	return NewLocalSessionRepositoryWithStore(storage.NewFileStore(configDir), nil, nil)
+}
+
+// NewEncryptedLocalSessionRepository creates a LocalSessionRepository that
+// encrypts session files at rest with the given cipher
+func NewEncryptedLocalSessionRepository(configDir string, cipher encryption.Cipher) repository.SessionRepository {
+	return NewLocalSessionRepositoryWithStore(storage.NewFileStore(configDir), cipher, nil)
+}
+
+// NewLocalSessionRepositoryWithStore creates a LocalSessionRepository backed by store.
+// Sessions are encrypted at rest when cipher is not nil. A nil clk means the system clock
+func NewLocalSessionRepositoryWithStore(store storage.Store, cipher encryption.Cipher, clk clock.Clock) repository.SessionRepository {
+	if clk == nil {
+		clk = clock.System()
+	}
 	return &LocalSessionRepository{
-		configDir: configDir,
-		logger:    logger.WithGroup("local_session_repository"),
+		store:  store,
+		cipher: cipher,
+		clock:  clk,
+		logger: logger.WithGroup("local_session_repository"),
 	}
 }
 
@@ -43,10 +69,6 @@ func (r *LocalSessionRepository) Save(ctx context.Context, session *entity.Sessi
 	r.logger.DebugContext(ctx, "saving session", 
 		"session_id", session.ID().MaskedString())
 
-	if err := r.ensureConfigDir(); err != nil {
-		return cerrors.Wrap(err, "failed to ensure config directory")
-	}
-
 	// Convert session to storage format
 	data := SessionData{
 		ID:        session.ID().String(),
@@ -57,30 +79,24 @@ func (r *LocalSessionRepository) Save(ctx context.Context, session *entity.Sessi
 		LastUsed:  session.LastUsed().Unix(),
 	}
 
-	// Write to file
-	sessionFile := r.getSessionFilePath(session.ID())
-	file, err := os.Create(sessionFile)
+	content, err := json.Marshal(data)
 	if err != nil {
-		return cerrors.Wrap(err, "failed to create session file")
+		return cerrors.Wrap(err, "failed to encode session data")
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			r.logger.WarnContext(ctx, "failed to close file", "error", err)
-		}
-	}()
 
-	if err := json.NewEncoder(file).Encode(data); err != nil {
-		return cerrors.Wrap(err, "failed to encode session data")
+	if r.cipher != nil {
+		content, err = r.cipher.Encrypt(content)
+		if err != nil {
+			return cerrors.Wrap(err, "failed to encrypt session data")
+		}
 	}
 
-	// Set file permissions to be readable only by owner
-	if err := os.Chmod(sessionFile, 0600); err != nil {
-		r.logger.WarnContext(ctx, "failed to set session file permissions", "error", err)
+	if err := r.store.Put(sessionsBucket, session.ID().String(), content); err != nil {
+		return cerrors.Wrap(err, "failed to write session file")
 	}
 
 	r.logger.DebugContext(ctx, "session saved successfully", 
-		"session_id", session.ID().MaskedString(),
-		"file", sessionFile)
+		"session_id", session.ID().MaskedString())
 
 	return nil
 }
@@ -90,30 +106,35 @@ func (r *LocalSessionRepository) GetByID(ctx context.Context, id model.SessionID
 	r.logger.DebugContext(ctx, "getting session by ID", 
 		"session_id", id.MaskedString())
 
-	sessionFile := r.getSessionFilePath(id)
-	
-	// Check if file exists
-	if _, err := os.Stat(sessionFile); os.IsNotExist(err) {
+	// Read and parse session file
+	content, err := r.store.Get(sessionsBucket, id.String())
+	if storage.IsNotFound(err) {
 		return nil, cerrors.NewAppError(
 			cerrors.CodeNotFound,
 			"session not found",
 			nil,
 		)
 	}
-
-	// Read and parse session file
-	file, err := os.Open(sessionFile)
 	if err != nil {
-		return nil, cerrors.Wrap(err, "failed to open session file")
+		return nil, cerrors.Wrap(err, "failed to read session file")
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			r.logger.WarnContext(ctx, "failed to close file", "error", err)
+
+	if encryption.IsEncrypted(content) {
+		if r.cipher == nil {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeUnauthorized,
+				"session file is encrypted. Set login.encrypt_sessions = true in config.toml",
+				nil,
+			)
+		}
+		content, err = r.cipher.Decrypt(content)
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to decrypt session data")
 		}
-	}()
+	}
 
 	var data SessionData
-	if err := json.NewDecoder(file).Decode(&data); err != nil {
+	if err := json.Unmarshal(content, &data); err != nil {
 		return nil, cerrors.Wrap(err, "failed to decode session data")
 	}
 
@@ -140,7 +161,7 @@ func (r *LocalSessionRepository) GetByUsername(ctx context.Context, username str
 	}
 
 	for _, session := range sessions {
-		if session.Username() == username && session.IsValid() {
+		if session.Username() == username && session.IsValidAt(r.clock.Now()) {
 			return session, nil
 		}
 	}
@@ -156,19 +177,15 @@ func (r *LocalSessionRepository) GetByUsername(ctx context.Context, username str
 func (r *LocalSessionRepository) GetCurrent(ctx context.Context) (*entity.Session, error) {
 	r.logger.DebugContext(ctx, "getting current session")
 
-	currentFile := r.getCurrentSessionFilePath()
-	
-	// Check if current session file exists
-	if _, err := os.Stat(currentFile); os.IsNotExist(err) {
+	// Read current session ID
+	content, err := r.store.Get("", currentSessionKey)
+	if storage.IsNotFound(err) {
 		return nil, cerrors.NewAppError(
 			cerrors.CodeNotFound,
 			"no current session",
 			nil,
 		)
 	}
-
-	// Read current session ID
-	content, err := os.ReadFile(currentFile)
 	if err != nil {
 		return nil, cerrors.Wrap(err, "failed to read current session file")
 	}
@@ -187,9 +204,7 @@ func (r *LocalSessionRepository) Delete(ctx context.Context, id model.SessionID)
 	r.logger.DebugContext(ctx, "deleting session", 
 		"session_id", id.MaskedString())
 
-	sessionFile := r.getSessionFilePath(id)
-	
-	if err := os.Remove(sessionFile); err != nil && !os.IsNotExist(err) {
+	if err := r.store.Delete(sessionsBucket, id.String()); err != nil {
 		return cerrors.Wrap(err, "failed to delete session file")
 	}
 
@@ -228,18 +243,18 @@ func (r *LocalSessionRepository) DeleteByUsername(ctx context.Context, username
 	return nil
 }
 
-// DeleteExpired deletes all expired sessions
-func (r *LocalSessionRepository) DeleteExpired(ctx context.Context) error {
+// DeleteExpired deletes all expired sessions and returns how many were deleted
+func (r *LocalSessionRepository) DeleteExpired(ctx context.Context) (int, error) {
 	r.logger.DebugContext(ctx, "deleting expired sessions")
 
 	sessions, err := r.List(ctx)
 	if err != nil {
-		return cerrors.Wrap(err, "failed to list sessions")
+		return 0, cerrors.Wrap(err, "failed to list sessions")
 	}
 
 	deleted := 0
 	for _, session := range sessions {
-		if session.IsExpired() {
+		if session.IsExpiredAt(r.clock.Now()) {
 			if err := r.Delete(ctx, session.ID()); err != nil {
 				r.logger.WarnContext(ctx, "failed to delete expired session", 
 					"session_id", session.ID().MaskedString(), 
@@ -253,14 +268,13 @@ func (r *LocalSessionRepository) DeleteExpired(ctx context.Context) error {
 	r.logger.DebugContext(ctx, "expired sessions deleted", 
 		"deleted_count", deleted)
 
-	return nil
+	return deleted, nil
 }
 
 // Exists checks if a session exists
 func (r *LocalSessionRepository) Exists(_ context.Context, id model.SessionID) (bool, error) {
-	sessionFile := r.getSessionFilePath(id)
-	_, err := os.Stat(sessionFile)
-	if os.IsNotExist(err) {
+	_, err := r.store.Get(sessionsBucket, id.String())
+	if storage.IsNotFound(err) {
 		return false, nil
 	}
 	if err != nil {
@@ -278,7 +292,7 @@ func (r *LocalSessionRepository) IsValid(ctx context.Context, id model.SessionID
 		}
 		return false, err
 	}
-	return session.IsValid(), nil
+	return session.IsValidAt(r.clock.Now()), nil
 }
 
 // SetCurrent sets the current active session
@@ -286,13 +300,7 @@ func (r *LocalSessionRepository) SetCurrent(ctx context.Context, session *entity
 	r.logger.DebugContext(ctx, "setting current session", 
 		"session_id", session.ID().MaskedString())
 
-	if err := r.ensureConfigDir(); err != nil {
-		return cerrors.Wrap(err, "failed to ensure config directory")
-	}
-
-	currentFile := r.getCurrentSessionFilePath()
-	
-	if err := os.WriteFile(currentFile, []byte(session.ID().String()), 0600); err != nil {
+	if err := r.store.Put("", currentSessionKey, []byte(session.ID().String())); err != nil {
 		return cerrors.Wrap(err, "failed to write current session file")
 	}
 
@@ -306,9 +314,7 @@ func (r *LocalSessionRepository) SetCurrent(ctx context.Context, session *entity
 func (r *LocalSessionRepository) ClearCurrent(ctx context.Context) error {
 	r.logger.DebugContext(ctx, "clearing current session")
 
-	currentFile := r.getCurrentSessionFilePath()
-	
-	if err := os.Remove(currentFile); err != nil && !os.IsNotExist(err) {
+	if err := r.store.Delete("", currentSessionKey); err != nil {
 		return cerrors.Wrap(err, "failed to remove current session file")
 	}
 
@@ -321,30 +327,18 @@ func (r *LocalSessionRepository) ClearCurrent(ctx context.Context) error {
 func (r *LocalSessionRepository) List(ctx context.Context) ([]*entity.Session, error) {
 	r.logger.DebugContext(ctx, "listing all sessions")
 
-	sessionsDir := r.getSessionsDir()
-	
-	// Check if sessions directory exists
-	if _, err := os.Stat(sessionsDir); os.IsNotExist(err) {
-		return []*entity.Session{}, nil
-	}
-
-	// Read directory
-	entries, err := os.ReadDir(sessionsDir)
+	keys, err := r.store.Keys(sessionsBucket)
 	if err != nil {
 		return nil, cerrors.Wrap(err, "failed to read sessions directory")
 	}
 
-	var sessions []*entity.Session
-	for _, entry := range entries {
-		if entry.IsDir() || !entry.Type().IsRegular() {
-			continue
-		}
-
+	sessions := []*entity.Session{}
+	for _, key := range keys {
 		// Try to parse as session ID
-		sessionID, err := model.NewSessionID(entry.Name())
+		sessionID, err := model.NewSessionID(key)
 		if err != nil {
 			r.logger.WarnContext(ctx, "invalid session file name", 
-				"filename", entry.Name())
+				"filename", key)
 			continue
 		}
 
@@ -367,39 +361,17 @@ func (r *LocalSessionRepository) List(ctx context.Context) ([]*entity.Session, e
 
 // Helper methods
 
-func (r *LocalSessionRepository) ensureConfigDir() error {
-	return os.MkdirAll(r.getSessionsDir(), 0755)
-}
-
-func (r *LocalSessionRepository) getSessionsDir() string {
-	return filepath.Join(r.configDir, "sessions")
-}
-
-func (r *LocalSessionRepository) getSessionFilePath(id model.SessionID) string {
-	return filepath.Join(r.getSessionsDir(), id.String())
-}
-
-func (r *LocalSessionRepository) getCurrentSessionFilePath() string {
-	return filepath.Join(r.configDir, "current_session")
-}
-
 func (r *LocalSessionRepository) dataToSession(data SessionData) (*entity.Session, error) {
 	sessionID, err := model.NewSessionID(data.ID)
 	if err != nil {
 		return nil, err
 	}
-	// Use reflection or factory method to create session with all fields
-	// This is a simplified version - in practice, you might need a more sophisticated approach
-	
-	session := entity.NewSession(
+	return entity.RestoreSession(
 		sessionID,
 		data.Username,
 		data.Token,
 		time.Unix(data.ExpiresAt, 0),
-	)
-
-	// Update timestamps
-	session.UpdateLastUsedAt(time.Unix(data.LastUsed, 0))
-
-	return session, nil
-}
\ No newline at end of file
+		time.Unix(data.CreatedAt, 0),
+		time.Unix(data.LastUsed, 0),
+	), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/encryption"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
 )
 
 func TestLocalSessionRepository_SaveAndGetByID(t *testing.T) {
@@ -306,10 +309,11 @@ func TestLocalSessionRepository_DeleteExpired(t *testing.T) {
 	assert.NoError(t, err)
 
 	// When - DeleteExpired
-	err = repo.DeleteExpired(ctx)
+	deleted, err := repo.DeleteExpired(ctx)
 
 	// Then
 	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
 
 	// Verify expired session is deleted
 	_, err = repo.GetByID(ctx, expiredSession.ID())
@@ -405,4 +409,103 @@ func TestLocalSessionRepository_IsValid(t *testing.T) {
 	isValid, err = repo.IsValid(ctx, nonExistentID)
 	assert.NoError(t, err)
 	assert.False(t, isValid)
-}
\ No newline at end of file
+}
+
+func TestLocalSessionRepository_EncryptedSaveAndGetByID(t *testing.T) {
+	// Given
+	tmpDir := t.TempDir()
+	cipher, err := encryption.NewPassphraseCipher("test passphrase")
+	assert.NoError(t, err)
+	repo := NewEncryptedLocalSessionRepository(tmpDir, cipher)
+	ctx := context.Background()
+
+	sessionID := model.MustGenerateSessionID()
+	session := entity.NewSessionWithDuration(
+		sessionID,
+		"testuser",
+		"test_token_123",
+		24*time.Hour,
+	)
+
+	// When - Save
+	err = repo.Save(ctx, session)
+
+	// Then - token is not stored in plaintext
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(tmpDir, "sessions", sessionID.String()))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "test_token_123")
+
+	// When - GetByID
+	retrievedSession, err := repo.GetByID(ctx, sessionID)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "test_token_123", retrievedSession.Token())
+
+	// When - read without a cipher
+	_, err = NewLocalSessionRepository(tmpDir).GetByID(ctx, sessionID)
+
+	// Then
+	assert.Error(t, err)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeUnauthorized))
+}
+
+func TestLocalSessionRepository_ConcurrentSetCurrent(t *testing.T) {
+	// Given
+	tmpDir := t.TempDir()
+	repo := NewLocalSessionRepository(tmpDir)
+	ctx := context.Background()
+
+	sessions := make([]*entity.Session, 5)
+	for i := range sessions {
+		sessions[i] = entity.NewSessionWithDuration(
+			model.MustGenerateSessionID(),
+			"testuser",
+			"token",
+			24*time.Hour,
+		)
+		assert.NoError(t, repo.Save(ctx, sessions[i]))
+	}
+
+	// When - several writers update the current pointer at once
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		wg.Add(1)
+		go func(s *entity.Session) {
+			defer wg.Done()
+			assert.NoError(t, repo.SetCurrent(ctx, s))
+		}(session)
+	}
+	wg.Wait()
+
+	// Then - the current pointer is intact and names one of the sessions
+	current, err := repo.GetCurrent(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, current)
+	assert.Equal(t, "testuser", current.Username())
+}
+
+func TestLocalSessionRepository_KVStore(t *testing.T) {
+	// Given
+	tmpDir := t.TempDir()
+	store, err := storage.OpenKVStore(filepath.Join(tmpDir, storage.KVFileName))
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	repo := NewLocalSessionRepositoryWithStore(store, nil, nil)
+	ctx := context.Background()
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "testuser", "token", 24*time.Hour)
+
+	// When
+	assert.NoError(t, repo.Save(ctx, session))
+	assert.NoError(t, repo.SetCurrent(ctx, session))
+	current, err := repo.GetCurrent(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, session.ID(), current.ID())
+	assert.FileExists(t, filepath.Join(tmpDir, storage.KVFileName))
+	assert.NoDirExists(t, filepath.Join(tmpDir, "sessions"))
+}
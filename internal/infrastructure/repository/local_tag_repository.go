@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// tagsKey is the key of the problem tags in the root bucket
+const tagsKey = "tags.json"
+
+// LocalTagRepository implements TagRepository by storing the tags as one
+// JSON document
+type LocalTagRepository struct {
+	store  storage.Store
+	logger *logger.Logger
+}
+
+// NewLocalTagRepository creates a new LocalTagRepository that keeps the tags in store
+func NewLocalTagRepository(store storage.Store) repository.TagRepository {
+	return &LocalTagRepository{
+		store:  store,
+		logger: logger.WithGroup("local_tag_repository"),
+	}
+}
+
+// List retrieves the tags of every tagged problem; missing tags are empty
+func (r *LocalTagRepository) List(_ context.Context) (map[string][]string, error) {
+	content, err := r.store.Get("", tagsKey)
+	if storage.IsNotFound(err) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read tags")
+	}
+
+	tags := map[string][]string{}
+	if err := json.Unmarshal(content, &tags); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode tags")
+	}
+	return tags, nil
+}
+
+// SaveAll replaces the stored tags
+func (r *LocalTagRepository) SaveAll(ctx context.Context, tags map[string][]string) error {
+	content, err := json.MarshalIndent(tags, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode tags")
+	}
+	if err := r.store.Put("", tagsKey, content); err != nil {
+		return cerrors.Wrap(err, "failed to write tags")
+	}
+
+	r.logger.DebugContext(ctx, "tags saved", "problems", len(tags))
+	return nil
+}
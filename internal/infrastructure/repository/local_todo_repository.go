@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// todoKey is the key of the practice list in the root bucket
+const todoKey = "todo.json"
+
+// LocalTodoRepository implements TodoRepository by storing the list as one
+// JSON document
+type LocalTodoRepository struct {
+	store  storage.Store
+	logger *logger.Logger
+}
+
+// NewLocalTodoRepository creates a new LocalTodoRepository that keeps the list in store
+func NewLocalTodoRepository(store storage.Store) repository.TodoRepository {
+	return &LocalTodoRepository{
+		store:  store,
+		logger: logger.WithGroup("local_todo_repository"),
+	}
+}
+
+// List retrieves every item in insertion order; a missing list is empty
+func (r *LocalTodoRepository) List(_ context.Context) ([]repository.TodoItem, error) {
+	content, err := r.store.Get("", todoKey)
+	if storage.IsNotFound(err) {
+		return []repository.TodoItem{}, nil
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read TODO list")
+	}
+
+	var items []repository.TodoItem
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode TODO list")
+	}
+	return items, nil
+}
+
+// SaveAll replaces the stored items
+func (r *LocalTodoRepository) SaveAll(ctx context.Context, items []repository.TodoItem) error {
+	content, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode TODO list")
+	}
+	if err := r.store.Put("", todoKey, content); err != nil {
+		return cerrors.Wrap(err, "failed to write TODO list")
+	}
+
+	r.logger.DebugContext(ctx, "TODO list saved", "items", len(items))
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// usageStatsKey is the key of the command statistics in the root bucket
+const usageStatsKey = "stats.json"
+
+// LocalUsageStatsRepository implements UsageStatsRepository by storing the
+// statistics as one JSON document
+type LocalUsageStatsRepository struct {
+	store  storage.Store
+	logger *logger.Logger
+}
+
+// NewLocalUsageStatsRepository creates a new LocalUsageStatsRepository that keeps the statistics in store
+func NewLocalUsageStatsRepository(store storage.Store) repository.UsageStatsRepository {
+	return &LocalUsageStatsRepository{
+		store:  store,
+		logger: logger.WithGroup("local_usage_stats_repository"),
+	}
+}
+
+// Load retrieves the statistics; missing statistics are empty
+func (r *LocalUsageStatsRepository) Load(_ context.Context) (repository.UsageStats, error) {
+	content, err := r.store.Get("", usageStatsKey)
+	if storage.IsNotFound(err) {
+		return repository.UsageStats{Commands: map[string]repository.CommandUsage{}}, nil
+	}
+	if err != nil {
+		return repository.UsageStats{}, cerrors.Wrap(err, "failed to read usage statistics")
+	}
+
+	var stats repository.UsageStats
+	if err := json.Unmarshal(content, &stats); err != nil {
+		return repository.UsageStats{}, cerrors.Wrap(err, "failed to decode usage statistics")
+	}
+	if stats.Commands == nil {
+		stats.Commands = map[string]repository.CommandUsage{}
+	}
+	return stats, nil
+}
+
+// Save replaces the stored statistics
+func (r *LocalUsageStatsRepository) Save(ctx context.Context, stats repository.UsageStats) error {
+	content, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode usage statistics")
+	}
+	if err := r.store.Put("", usageStatsKey, content); err != nil {
+		return cerrors.Wrap(err, "failed to write usage statistics")
+	}
+
+	r.logger.DebugContext(ctx, "usage statistics saved", "commands", len(stats.Commands))
+	return nil
+}
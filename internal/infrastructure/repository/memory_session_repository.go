@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// MemorySessionRepository implements SessionRepository in memory. Sessions
+// are never written to disk and are lost when the process exits, which suits
+// tests and shared machines. It is safe for concurrent use
+type MemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*entity.Session // by session ID
+	current  string                     // empty when there is no current session
+	clock    clock.Clock                // tells which sessions have expired
+}
+
+// NewMemorySessionRepository creates an empty MemorySessionRepository. A nil clk means the system clock
+func NewMemorySessionRepository(clk clock.Clock) repository.SessionRepository {
+	if clk == nil {
+		clk = clock.System()
+	}
+	return &MemorySessionRepository{
+		sessions: make(map[string]*entity.Session),
+		clock:    clk,
+	}
+}
+
+// Save saves a copy of the session
+func (r *MemorySessionRepository) Save(_ context.Context, session *entity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID().String()] = session.Clone()
+	return nil
+}
+
+// GetByID retrieves a session by its ID
+func (r *MemorySessionRepository) GetByID(_ context.Context, id model.SessionID) (*entity.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.get(id.String())
+}
+
+// GetByUsername retrieves a valid session for a username
+func (r *MemorySessionRepository) GetByUsername(ctx context.Context, username string) (*entity.Session, error) {
+	sessions, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.Username() == username && session.IsValidAt(r.clock.Now()) {
+			return session, nil
+		}
+	}
+	return nil, cerrors.NewAppError(
+		cerrors.CodeNotFound,
+		"no valid session found for username",
+		nil,
+	)
+}
+
+// GetCurrent retrieves the current active session
+func (r *MemorySessionRepository) GetCurrent(_ context.Context) (*entity.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.current == "" {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no current session",
+			nil,
+		)
+	}
+	return r.get(r.current)
+}
+
+// Delete deletes a session by its ID
+func (r *MemorySessionRepository) Delete(_ context.Context, id model.SessionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, id.String())
+	return nil
+}
+
+// DeleteByUsername deletes all sessions for a username
+func (r *MemorySessionRepository) DeleteByUsername(_ context.Context, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, session := range r.sessions {
+		if session.Username() == username {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+// DeleteExpired deletes all expired sessions and returns how many were deleted
+func (r *MemorySessionRepository) DeleteExpired(_ context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deleted := 0
+	for id, session := range r.sessions {
+		if session.IsExpiredAt(r.clock.Now()) {
+			delete(r.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Exists checks if a session exists
+func (r *MemorySessionRepository) Exists(_ context.Context, id model.SessionID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.sessions[id.String()]
+	return ok, nil
+}
+
+// IsValid checks if a session is valid (exists and not expired)
+func (r *MemorySessionRepository) IsValid(_ context.Context, id model.SessionID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	session, ok := r.sessions[id.String()]
+	return ok && session.IsValidAt(r.clock.Now()), nil
+}
+
+// SetCurrent sets the current active session
+func (r *MemorySessionRepository) SetCurrent(_ context.Context, session *entity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = session.ID().String()
+	return nil
+}
+
+// ClearCurrent clears the current active session
+func (r *MemorySessionRepository) ClearCurrent(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = ""
+	return nil
+}
+
+// List lists all sessions in ID order
+func (r *MemorySessionRepository) List(_ context.Context) ([]*entity.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.sessions))
+	for id := range r.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	sessions := make([]*entity.Session, 0, len(ids))
+	for _, id := range ids {
+		sessions = append(sessions, r.sessions[id].Clone())
+	}
+	return sessions, nil
+}
+
+// get returns a copy of the session with the given ID; r.mu must be held
+func (r *MemorySessionRepository) get(id string) (*entity.Session, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"session not found",
+			nil,
+		)
+	}
+	return session.Clone(), nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+func TestMemorySessionRepository_CurrentSession(t *testing.T) {
+	// Given
+	repo := NewMemorySessionRepository(nil)
+	ctx := context.Background()
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "alice", "token", time.Hour)
+
+	// When
+	_, noneErr := repo.GetCurrent(ctx)
+	assert.NoError(t, repo.Save(ctx, session))
+	assert.NoError(t, repo.SetCurrent(ctx, session))
+	current, err := repo.GetCurrent(ctx)
+
+	// Then
+	assert.True(t, cerrors.IsAppError(noneErr, cerrors.CodeNotFound), "got %v", noneErr)
+	if err != nil {
+		t.Fatalf("GetCurrent failed: %v", err)
+	}
+	assert.Equal(t, session.ID(), current.ID())
+	assert.Equal(t, "token", current.Token())
+
+	byName, err := repo.GetByUsername(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, session.ID(), byName.ID())
+
+	assert.NoError(t, repo.ClearCurrent(ctx))
+	_, err = repo.GetCurrent(ctx)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
+
+func TestMemorySessionRepository_DeleteExpired(t *testing.T) {
+	// Given
+	repo := NewMemorySessionRepository(nil)
+	ctx := context.Background()
+	valid := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "alice", "a", time.Hour)
+	expired := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "bob", "b", -time.Hour)
+	assert.NoError(t, repo.Save(ctx, valid))
+	assert.NoError(t, repo.Save(ctx, expired))
+
+	// When
+	deleted, err := repo.DeleteExpired(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	sessions, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	exists, err := repo.Exists(ctx, expired.ID())
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, repo.DeleteByUsername(ctx, "alice"))
+	_, err = repo.GetByID(ctx, valid.ID())
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
+
+func TestMemorySessionRepository_ConcurrentUse(t *testing.T) {
+	repo := NewMemorySessionRepository(nil)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "alice", "token", time.Hour)
+			assert.NoError(t, repo.Save(ctx, session))
+			assert.NoError(t, repo.SetCurrent(ctx, session))
+			_, err := repo.GetCurrent(ctx)
+			assert.NoError(t, err)
+			_, err = repo.List(ctx)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	sessions, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 20)
+}
+
+func TestMemorySessionRepository_ExpiresWithClock(t *testing.T) {
+	// Given
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	repo := NewMemorySessionRepository(clk)
+	ctx := context.Background()
+	session := entity.NewSessionAt(model.MustGenerateSessionID(), "alice", "token", start.Add(time.Hour), start)
+	assert.NoError(t, repo.Save(ctx, session))
+
+	// When
+	validBefore, err := repo.IsValid(ctx, session.ID())
+	assert.NoError(t, err)
+	clk.Advance(2 * time.Hour)
+	validAfter, err := repo.IsValid(ctx, session.ID())
+	assert.NoError(t, err)
+	deleted, err := repo.DeleteExpired(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, validBefore)
+	assert.False(t, validAfter)
+	assert.Equal(t, 1, deleted)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// mockSessionDuration is how long sessions of MockAuthRepository last, like AOJ sessions
+const mockSessionDuration = 24 * time.Hour
+
+// MockAuthRepository is an in-memory implementation of AuthRepository for
+// testing. Users are registered with AddUser and their tokens stay valid
+// until they log out
+type MockAuthRepository struct {
+	mu     sync.Mutex
+	users  map[string]string // username to password
+	tokens map[string]string // token to username
+	issued int
+}
+
+// NewMockAuthRepository creates a new mock auth repository without users
+func NewMockAuthRepository() *MockAuthRepository {
+	return &MockAuthRepository{
+		users:  make(map[string]string),
+		tokens: make(map[string]string),
+	}
+}
+
+// AddUser registers a user who can log in with password
+func (r *MockAuthRepository) AddUser(username, password string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[username] = password
+}
+
+// Login returns a new session if the password matches
+func (r *MockAuthRepository) Login(_ context.Context, username, password string) (*entity.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if expected, ok := r.users[username]; !ok || expected != password {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeUnauthorized,
+			"invalid username or password",
+			nil,
+		)
+	}
+	return r.issue(username)
+}
+
+// Logout invalidates the token of the session
+func (r *MockAuthRepository) Logout(_ context.Context, session *entity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, session.Token())
+	return nil
+}
+
+// RefreshSession returns a new session with the same token if it is still valid
+func (r *MockAuthRepository) RefreshSession(ctx context.Context, session *entity.Session) (*entity.Session, error) {
+	valid, err := r.ValidateSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeUnauthorized,
+			"session is no longer valid",
+			nil,
+		)
+	}
+
+	sessionID, err := model.GenerateSessionID()
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to generate session ID")
+	}
+	return entity.NewSessionWithDuration(sessionID, session.Username(), session.Token(), mockSessionDuration), nil
+}
+
+// ValidateSession checks that the session has not expired and its token was
+// issued to its user and not logged out
+func (r *MockAuthRepository) ValidateSession(_ context.Context, session *entity.Session) (bool, error) {
+	if session.IsExpired() {
+		return false, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	username, ok := r.tokens[session.Token()]
+	return ok && username == session.Username(), nil
+}
+
+// issue creates a session with a new token; r.mu must be held
+func (r *MockAuthRepository) issue(username string) (*entity.Session, error) {
+	sessionID, err := model.GenerateSessionID()
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to generate session ID")
+	}
+
+	r.issued++
+	token := "mock-token-" + username + "-" + strconv.Itoa(r.issued)
+	r.tokens[token] = username
+	return entity.NewSessionWithDuration(sessionID, username, token, mockSessionDuration), nil
+}
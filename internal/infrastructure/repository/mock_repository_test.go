@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	domainrepo "github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// The mocks must stay usable wherever the interfaces are expected
+var (
+	_ domainrepo.SubmissionRepository = (*MockSubmissionRepository)(nil)
+	_ domainrepo.AuthRepository       = (*MockAuthRepository)(nil)
+)
+
+func TestMockSubmissionRepository_SubmitAndJudge(t *testing.T) {
+	// Given
+	repo := NewMockSubmissionRepository()
+	ctx := context.Background()
+	accepted := entity.NewSubmission(model.NewSubmissionIDFromInt(1), model.MustNewProblemID("ITP1_1_A"), "C++17", "ok")
+	assert.NoError(t, repo.Submit(ctx, accepted))
+	repo.SetVerdict(MockVerdict{Status: entity.StatusWrongAnswer, FailedCase: 3})
+	rejected := entity.NewSubmission(model.NewSubmissionIDFromInt(2), model.MustNewProblemID("ITP1_1_A"), "C++17", "ng")
+	assert.NoError(t, repo.Submit(ctx, rejected))
+
+	// When
+	statuses, err := repo.WatchStatus(ctx, model.MustNewSubmissionID(rejected.JudgeID()), time.Second)
+
+	// Then
+	assert.NoError(t, err)
+	var seen []entity.SubmissionStatus
+	for status := range statuses {
+		seen = append(seen, status)
+	}
+	assert.Equal(t, []entity.SubmissionStatus{entity.StatusWrongAnswer}, seen)
+	detail, err := repo.GetJudgeDetail(ctx, rejected)
+	assert.NoError(t, err)
+	rejected.SetCaseResults(detail.Cases)
+	assert.Len(t, detail.Cases, 3)
+	assert.Equal(t, 3, rejected.FailedCase())
+
+	status, err := repo.GetStatus(ctx, model.MustNewSubmissionID(accepted.JudgeID()))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.StatusAccepted, status, "the verdict is the one configured at submission")
+	assert.Len(t, repo.Submitted(), 2)
+
+	_, err = repo.GetStatus(ctx, model.NewSubmissionIDFromInt(42))
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
+
+func TestMockSubmissionRepository_History(t *testing.T) {
+	// Given
+	repo := NewMockSubmissionRepository()
+	ctx := context.Background()
+	first := entity.NewSubmission(model.NewSubmissionIDFromInt(1), model.MustNewProblemID("ITP1_1_A"), "C++17", "")
+	second := entity.NewSubmission(model.NewSubmissionIDFromInt(2), model.MustNewProblemID("ITP1_1_B"), "C++17", "")
+	assert.NoError(t, repo.Save(ctx, first))
+	assert.NoError(t, repo.Save(ctx, second))
+
+	// When
+	byProblem, err := repo.GetByProblemID(ctx, model.MustNewProblemID("ITP1_1_B"), 10)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Submission{second}, byProblem)
+
+	exists, err := repo.Exists(ctx, first.ID())
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, repo.Delete(ctx, first.ID()))
+	exists, err = repo.Exists(ctx, first.ID())
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestMockAuthRepository(t *testing.T) {
+	// Given
+	repo := NewMockAuthRepository()
+	repo.AddUser("alice", "secret")
+	ctx := context.Background()
+
+	// When
+	session, err := repo.Login(ctx, "alice", "secret")
+	_, wrongErr := repo.Login(ctx, "alice", "wrong")
+
+	// Then
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	assert.Equal(t, "alice", session.Username())
+	assert.True(t, cerrors.IsAppError(wrongErr, cerrors.CodeUnauthorized), "got %v", wrongErr)
+
+	refreshed, err := repo.RefreshSession(ctx, session)
+	assert.NoError(t, err)
+	assert.Equal(t, session.Token(), refreshed.Token())
+
+	assert.NoError(t, repo.Logout(ctx, session))
+	valid, err := repo.ValidateSession(ctx, refreshed)
+	assert.NoError(t, err)
+	assert.False(t, valid, "logging out invalidates the token")
+	_, err = repo.RefreshSession(ctx, session)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeUnauthorized), "got %v", err)
+}
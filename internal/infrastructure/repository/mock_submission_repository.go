@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// MockSubmissionRepository is an in-memory implementation of
+// SubmissionRepository for testing. Submitted solutions are judged with the
+// configured verdict and kept as the local history
+type MockSubmissionRepository struct {
+	mu          sync.Mutex
+	submissions []*entity.Submission
+	submitted   []*entity.Submission
+	verdict     MockVerdict
+	verdicts    map[string]MockVerdict // by judge ID
+	nextJudgeID int64
+}
+
+// MockVerdict is the judgement MockSubmissionRepository gives to submissions
+type MockVerdict struct {
+	Status       entity.SubmissionStatus
+	Score        int
+	Time         time.Duration
+	Memory       int64 // in KB
+	FailedCase   int
+	CompileError string
+}
+
+// NewMockSubmissionRepository creates a new mock submission repository that
+// accepts every submission
+func NewMockSubmissionRepository() *MockSubmissionRepository {
+	return &MockSubmissionRepository{
+		verdict:     MockVerdict{Status: entity.StatusAccepted, Time: 10 * time.Millisecond, Memory: 1024},
+		verdicts:    make(map[string]MockVerdict),
+		nextJudgeID: 1000,
+	}
+}
+
+// SetVerdict sets the verdict of later submissions
+func (r *MockSubmissionRepository) SetVerdict(verdict MockVerdict) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.verdict = verdict
+}
+
+// Submitted returns the submissions received by Submit, in order
+func (r *MockSubmissionRepository) Submitted() []*entity.Submission {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*entity.Submission(nil), r.submitted...)
+}
+
+// Submit assigns a judge ID to the submission and leaves it pending until its
+// status is requested
+func (r *MockSubmissionRepository) Submit(_ context.Context, submission *entity.Submission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextJudgeID++
+	submission.SetJudgeID(strconv.FormatInt(r.nextJudgeID, 10))
+	submission.UpdateStatus(entity.StatusPending)
+	r.verdicts[submission.JudgeID()] = r.verdict
+	r.submitted = append(r.submitted, submission)
+	return nil
+}
+
+// GetByID retrieves a submission of the history by its ID
+func (r *MockSubmissionRepository) GetByID(_ context.Context, id model.SubmissionID) (*entity.Submission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, submission := range r.submissions {
+		if submission.ID().Equals(id) {
+			return submission, nil
+		}
+	}
+	return nil, cerrors.NewAppError(cerrors.CodeNotFound, "submission "+id.String()+" not found", nil)
+}
+
+// GetByProblemID retrieves the most recent submissions for a problem
+func (r *MockSubmissionRepository) GetByProblemID(ctx context.Context, problemID model.ProblemID, limit int) ([]*entity.Submission, error) {
+	return r.Search(ctx, repository.NewSubmissionSearchCriteria().WithProblemID(problemID).WithLimit(limit))
+}
+
+// GetRecent retrieves the most recent submissions
+func (r *MockSubmissionRepository) GetRecent(ctx context.Context, limit int) ([]*entity.Submission, error) {
+	return r.Search(ctx, repository.NewSubmissionSearchCriteria().WithLimit(limit))
+}
+
+// GetStatus judges a submitted solution with the verdict configured when it was submitted
+func (r *MockSubmissionRepository) GetStatus(_ context.Context, id model.SubmissionID) (entity.SubmissionStatus, error) {
+	submission, _, err := r.judge(id.String())
+	if err != nil {
+		return "", err
+	}
+	return submission.Status(), nil
+}
+
+// GetCompileError returns the compiler message of the configured verdict
+func (r *MockSubmissionRepository) GetCompileError(_ context.Context, submission *entity.Submission) (string, error) {
+	_, verdict, err := r.judge(submission.JudgeID())
+	if err != nil {
+		return "", err
+	}
+	return verdict.CompileError, nil
+}
+
+// GetJudgeDetail returns the configured verdict with the cases up to its
+// failed case: the cases before it pass and it fails with the verdict's status
+func (r *MockSubmissionRepository) GetJudgeDetail(_ context.Context, submission *entity.Submission) (repository.JudgeDetail, error) {
+	_, verdict, err := r.judge(submission.JudgeID())
+	if err != nil {
+		return repository.JudgeDetail{}, err
+	}
+
+	detail := repository.JudgeDetail{
+		Score:  verdict.Score,
+		Time:   verdict.Time,
+		Memory: verdict.Memory,
+		Cases:  make([]entity.CaseResult, 0, verdict.FailedCase),
+	}
+	for serial := 1; serial <= verdict.FailedCase; serial++ {
+		status := entity.StatusAccepted
+		if serial == verdict.FailedCase {
+			status = verdict.Status
+		}
+		detail.Cases = append(detail.Cases, entity.CaseResult{
+			Serial: serial,
+			Label:  fmt.Sprintf("case%d", serial),
+			Status: status,
+			Time:   verdict.Time,
+			Memory: verdict.Memory,
+		})
+	}
+	return detail, nil
+}
+
+// WatchStatus sends the final status of the submission and closes the channel
+func (r *MockSubmissionRepository) WatchStatus(ctx context.Context, id model.SubmissionID, _ time.Duration) (<-chan entity.SubmissionStatus, error) {
+	status, err := r.GetStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(chan entity.SubmissionStatus, 1)
+	statuses <- status
+	close(statuses)
+	return statuses, nil
+}
+
+// Search searches the history, newest first
+func (r *MockSubmissionRepository) Search(_ context.Context, criteria repository.SubmissionSearchCriteria) ([]*entity.Submission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return searchSubmissions(r.submissions, criteria), nil
+}
+
+// Save records a submission in the history, replacing one with the same ID
+func (r *MockSubmissionRepository) Save(_ context.Context, submission *entity.Submission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.submissions {
+		if existing.ID().Equals(submission.ID()) {
+			r.submissions[i] = submission
+			return nil
+		}
+	}
+	r.submissions = append(r.submissions, submission)
+	return nil
+}
+
+// Delete removes a submission from the history
+func (r *MockSubmissionRepository) Delete(_ context.Context, id model.SubmissionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, submission := range r.submissions {
+		if submission.ID().Equals(id) {
+			r.submissions = append(r.submissions[:i], r.submissions[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// Exists checks if a submission is in the history
+func (r *MockSubmissionRepository) Exists(ctx context.Context, id model.SubmissionID) (bool, error) {
+	_, err := r.GetByID(ctx, id)
+	if cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// judge applies the verdict configured when a solution was submitted, found
+// by its judge ID, the first time it is requested
+func (r *MockSubmissionRepository) judge(judgeID string) (*entity.Submission, MockVerdict, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, submission := range r.submitted {
+		if submission.JudgeID() != judgeID {
+			continue
+		}
+		verdict := r.verdicts[judgeID]
+		if submission.IsPending() {
+			submission.UpdateResult(verdict.Status, verdict.Score, verdict.Time, verdict.Memory, "")
+		}
+		return submission, verdict, nil
+	}
+	return nil, MockVerdict{}, cerrors.NewAppError(cerrors.CodeNotFound, "verdict not found for submission "+judgeID, nil)
+}
@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// BuildStampFile records the last successful build in the solution's directory
+const BuildStampFile = ".aoj-build.json"
+
+// CachingRunner wraps a SolutionRunner and skips builds whose source file and
+// build command are unchanged since the last successful build
+type CachingRunner struct {
+	service.SolutionRunner
+	logger *logger.Logger
+}
+
+// NewCachingRunner creates a new CachingRunner around runner
+func NewCachingRunner(runner service.SolutionRunner) service.SolutionRunner {
+	return &CachingRunner{
+		SolutionRunner: runner,
+		logger:         logger.WithGroup("caching_runner"),
+	}
+}
+
+// buildStamp is the content of the build stamp file
+type buildStamp struct {
+	Key    string `json:"key"`    // hash of the build command and the source file
+	Output string `json:"output"` // compiler output, so that warnings are shown again
+}
+
+// Build reuses the previous build if it is still up to date
+func (r *CachingRunner) Build(ctx context.Context, spec service.RunSpec) (*service.BuildResult, error) {
+	if strings.TrimSpace(spec.BuildCommand) == "" {
+		return r.SolutionRunner.Build(ctx, spec)
+	}
+
+	stampPath := filepath.Join(spec.Dir, BuildStampFile)
+	key, err := buildKey(spec)
+	if err != nil {
+		r.logger.DebugContext(ctx, "cannot cache build", "error", err)
+		return r.SolutionRunner.Build(ctx, spec)
+	}
+	if stamp, ok := readBuildStamp(stampPath); ok && stamp.Key == key && executableExists(spec) {
+		r.logger.DebugContext(ctx, "build is up to date", "source_file", spec.SourceFile)
+		return &service.BuildResult{Success: true, Output: stamp.Output, Cached: true}, nil
+	}
+
+	result, err := r.SolutionRunner.Build(ctx, spec)
+	if err != nil || !result.Success {
+		if _, statErr := os.Stat(stampPath); statErr == nil {
+			trace.File("remove", stampPath)
+			_ = os.Remove(stampPath)
+		}
+		return result, err
+	}
+
+	content, err := json.Marshal(buildStamp{Key: key, Output: result.Output})
+	if err == nil {
+		trace.File("write", stampPath)
+		err = os.WriteFile(stampPath, content, 0644)
+	}
+	if err != nil {
+		r.logger.WarnContext(ctx, "failed to record build", "error", err)
+	}
+	return result, nil
+}
+
+// buildKey hashes the build command and the content of the source file
+func buildKey(spec service.RunSpec) (string, error) {
+	source, err := os.ReadFile(filepath.Join(spec.Dir, spec.SourceFile))
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write([]byte(spec.BuildCommand))
+	hash.Write([]byte{0})
+	hash.Write(source)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readBuildStamp reads the build stamp file
+func readBuildStamp(path string) (buildStamp, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return buildStamp{}, false
+	}
+	var stamp buildStamp
+	if err := json.Unmarshal(content, &stamp); err != nil {
+		return buildStamp{}, false
+	}
+	return stamp, true
+}
+
+// executableExists checks that the program started by the run command, such
+// as ./a.out, has not been deleted since the build. Run commands that go
+// through an interpreter or the shell cannot be checked and are trusted
+func executableExists(spec service.RunSpec) bool {
+	program, _, ok := localProgram(spec.RunCommand)
+	if !ok {
+		return true
+	}
+	if _, err := os.Stat(filepath.Join(spec.Dir, program)); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(spec.Dir, program+".exe"))
+	return err == nil
+}
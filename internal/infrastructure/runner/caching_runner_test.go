@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+)
+
+// countingRunner counts builds and creates the executable like a compiler would
+type countingRunner struct {
+	service.SolutionRunner
+	builds  int
+	success bool
+}
+
+func (r *countingRunner) Build(_ context.Context, spec service.RunSpec) (*service.BuildResult, error) {
+	r.builds++
+	if !r.success {
+		return &service.BuildResult{Success: false, Output: "error"}, nil
+	}
+	if err := os.WriteFile(filepath.Join(spec.Dir, "a.out"), nil, 0755); err != nil {
+		return nil, err
+	}
+	return &service.BuildResult{Success: true, Output: "warning: unused variable"}, nil
+}
+
+func TestCachingRunner_Build(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.cpp")
+	assert.NoError(t, os.WriteFile(source, []byte("int main() {}\n"), 0644))
+	inner := &countingRunner{success: true}
+	r := NewCachingRunner(inner)
+	spec := service.RunSpec{Dir: dir, SourceFile: "main.cpp", BuildCommand: "g++ main.cpp", RunCommand: "./a.out"}
+	ctx := context.Background()
+
+	// When
+	first, err := r.Build(ctx, spec)
+	assert.NoError(t, err)
+	second, err := r.Build(ctx, spec)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(source, []byte("int main() { return 0; }\n"), 0644))
+	_, err = r.Build(ctx, spec)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Remove(filepath.Join(dir, "a.out")))
+	_, err = r.Build(ctx, spec)
+	assert.NoError(t, err)
+	spec.BuildCommand = "g++ -O2 main.cpp"
+	_, err = r.Build(ctx, spec)
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, first.Cached)
+	assert.True(t, second.Cached)
+	assert.Equal(t, "warning: unused variable", second.Output)
+	assert.Equal(t, 4, inner.builds, "only the unchanged second build is skipped")
+}
+
+func TestCachingRunner_Build_FailureIsNotCached(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.cpp"), []byte("int main() {\n"), 0644))
+	inner := &countingRunner{success: false}
+	r := NewCachingRunner(inner)
+	spec := service.RunSpec{Dir: dir, SourceFile: "main.cpp", BuildCommand: "g++ main.cpp", RunCommand: "./a.out"}
+
+	// When
+	_, _ = r.Build(context.Background(), spec)
+	result, err := r.Build(context.Background(), spec)
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 2, inner.builds)
+	assert.NoFileExists(t, filepath.Join(dir, BuildStampFile))
+}
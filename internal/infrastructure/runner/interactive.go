@@ -0,0 +1,283 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// RunInteractive executes the solution and the interactor with the standard
+// output of each connected to the standard input of the other, recording the
+// dialogue line by line. Both are killed when the timeout expires
+func (r *ProcessRunner) RunInteractive(ctx context.Context, spec service.RunSpec, interactor service.InteractorSpec, timeout time.Duration) (*service.InteractiveResult, error) {
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	interactorLine, cleanup, err := interactorCommandLine(interactor)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	r.logger.DebugContext(ctx, "running interactive solution", "command", spec.RunCommand, "interactor", interactorLine)
+
+	// Each program writes into a pipe that the other one reads as its stdin
+	toSolution, fromInteractor, err := os.Pipe()
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create pipe")
+	}
+	toInteractor, fromSolution, err := os.Pipe()
+	if err != nil {
+		closeFiles(toSolution, fromInteractor)
+		return nil, cerrors.Wrap(err, "failed to create pipe")
+	}
+
+	var solutionStderr, interactorStderr bytes.Buffer
+	solution := shellCommand(runCtx, spec.RunCommand)
+	solution.Dir = spec.Dir
+	solution.Stdin = toSolution
+	solution.Stderr = &solutionStderr
+	judge := shellCommand(runCtx, interactorLine)
+	judge.Dir = spec.Dir
+	judge.Stdin = toInteractor
+	judge.Stderr = &interactorStderr
+
+	pipes := []*os.File{toSolution, fromInteractor, toInteractor, fromSolution}
+	solutionOut, err := solution.StdoutPipe()
+	if err != nil {
+		closeFiles(pipes...)
+		return nil, cerrors.Wrap(err, "failed to connect solution and interactor")
+	}
+	judgeOut, err := judge.StdoutPipe()
+	if err != nil {
+		closeFiles(pipes...)
+		return nil, cerrors.Wrap(err, "failed to connect solution and interactor")
+	}
+
+	result, err := r.dialogue(runCtx, solution, judge, solutionOut, judgeOut, pipes)
+	if err != nil {
+		return nil, err
+	}
+	result.Stderr = solutionStderr.String()
+	result.InteractorStderr = interactorStderr.String()
+
+	if runCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		result.TimedOut = true
+		return result, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return result, nil
+}
+
+// dialogue starts both programs, relays their output to each other until both
+// have exited and collects the result
+func (r *ProcessRunner) dialogue(
+	ctx context.Context,
+	solution, judge *exec.Cmd,
+	solutionOut, judgeOut io.Reader,
+	pipes []*os.File, // toSolution, fromInteractor, toInteractor, fromSolution
+) (*service.InteractiveResult, error) {
+	toSolution, fromInteractor, toInteractor, fromSolution := pipes[0], pipes[1], pipes[2], pipes[3]
+
+	start := time.Now()
+	if err := judge.Start(); err != nil {
+		closeFiles(pipes...)
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInternalServer,
+			"failed to start interactor: "+judge.String(),
+			err,
+		)
+	}
+	if err := solution.Start(); err != nil {
+		closeFiles(pipes...)
+		_ = judge.Process.Kill()
+		_ = judge.Wait()
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInternalServer,
+			"failed to start run command",
+			err,
+		)
+	}
+	// The children hold their own copies of the read ends
+	closeFiles(toSolution, toInteractor)
+
+	transcript := &transcriptRecorder{}
+	var solutionOutput strings.Builder
+	var relays sync.WaitGroup
+	relays.Add(2)
+	go func() {
+		defer relays.Done()
+		relay(judgeOut, fromInteractor, transcript.writer(false), nil)
+	}()
+	go func() {
+		defer relays.Done()
+		relay(solutionOut, fromSolution, transcript.writer(true), &solutionOutput)
+	}()
+	relays.Wait()
+
+	solutionErr := solution.Wait()
+	duration := time.Since(start)
+	judgeErr := judge.Wait()
+	transcript.flush()
+
+	for _, err := range []error{solutionErr, judgeErr} {
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) && ctx.Err() == nil {
+			return nil, cerrors.NewAppError(cerrors.CodeInternalServer, "interactive run failed", err)
+		}
+	}
+
+	result := &service.InteractiveResult{
+		RunResult: service.RunResult{
+			Stdout:   solutionOutput.String(),
+			Duration: duration,
+		},
+		InteractorExitCode: -1,
+		Transcript:         transcript.lines,
+	}
+	if solution.ProcessState != nil {
+		result.ExitCode = solution.ProcessState.ExitCode()
+		result.MemoryKB = peakMemoryKB(solution.ProcessState)
+	}
+	if judge.ProcessState != nil {
+		result.InteractorExitCode = judge.ProcessState.ExitCode()
+	}
+	return result, nil
+}
+
+// relay copies the output of one program to the input of the other until the
+// output ends, then closes the input so that the other program sees EOF.
+// Output is still drained and recorded after the other program has gone away
+func relay(src io.Reader, dst *os.File, record io.Writer, output *strings.Builder) {
+	buf := make([]byte, 32*1024)
+	open := true
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			_, _ = record.Write(buf[:n])
+			if output != nil {
+				output.Write(buf[:n])
+			}
+			if open {
+				if _, werr := dst.Write(buf[:n]); werr != nil {
+					open = false
+				}
+			}
+		}
+		if err != nil {
+			_ = dst.Close()
+			return
+		}
+	}
+}
+
+// closeFiles closes files, ignoring errors
+func closeFiles(files ...*os.File) {
+	for _, f := range files {
+		_ = f.Close()
+	}
+}
+
+// interactorCommandLine writes the test case to temporary files and fills
+// the placeholders of the interactor command with their paths
+func interactorCommandLine(interactor service.InteractorSpec) (string, func(), error) {
+	line := interactor.Command
+	var files []string
+	cleanup := func() {
+		for _, file := range files {
+			_ = os.Remove(file)
+		}
+	}
+
+	for placeholder, content := range map[string]string{
+		service.InteractorInputPlaceholder:  interactor.Input,
+		service.InteractorOutputPlaceholder: interactor.Expected,
+	} {
+		if !strings.Contains(line, placeholder) {
+			continue
+		}
+		file, err := os.CreateTemp("", "aoj-interactor-*.txt")
+		if err != nil {
+			cleanup()
+			return "", nil, cerrors.Wrap(err, "failed to create test case file for the interactor")
+		}
+		files = append(files, file.Name())
+		_, err = file.WriteString(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return "", nil, cerrors.Wrap(err, "failed to write test case file for the interactor")
+		}
+		line = strings.ReplaceAll(line, placeholder, file.Name())
+	}
+	return line, cleanup, nil
+}
+
+// transcriptRecorder assembles the output of both programs into lines
+type transcriptRecorder struct {
+	mu      sync.Mutex
+	lines   []service.TranscriptLine
+	pending [2]strings.Builder // partial lines from the interactor and the solution
+}
+
+// writer returns a writer that records the output of one side of the dialogue
+func (t *transcriptRecorder) writer(fromSolution bool) io.Writer {
+	return transcriptWriter{recorder: t, fromSolution: fromSolution}
+}
+
+// flush records partial lines left at the end of the dialogue
+func (t *transcriptRecorder) flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for side, fromSolution := range []bool{false, true} {
+		if t.pending[side].Len() > 0 {
+			t.lines = append(t.lines, service.TranscriptLine{FromSolution: fromSolution, Text: t.pending[side].String()})
+			t.pending[side].Reset()
+		}
+	}
+}
+
+type transcriptWriter struct {
+	recorder     *transcriptRecorder
+	fromSolution bool
+}
+
+func (w transcriptWriter) Write(p []byte) (int, error) {
+	t := w.recorder
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	side := 0
+	if w.fromSolution {
+		side = 1
+	}
+	text := string(p)
+	for {
+		i := strings.IndexByte(text, '\n')
+		if i < 0 {
+			t.pending[side].WriteString(text)
+			return len(p), nil
+		}
+		t.pending[side].WriteString(strings.TrimSuffix(text[:i], "\r"))
+		t.lines = append(t.lines, service.TranscriptLine{FromSolution: w.fromSolution, Text: t.pending[side].String()})
+		t.pending[side].Reset()
+		text = text[i+1:]
+	}
+}
@@ -0,0 +1,35 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalProgram(t *testing.T) {
+	tests := []struct {
+		line        string
+		wantProgram string
+		wantArgs    []string
+		wantOK      bool
+	}{
+		{line: "./a.out", wantProgram: "./a.out", wantArgs: []string{}, wantOK: true},
+		{line: `.\main.exe --fast`, wantProgram: `.\main.exe`, wantArgs: []string{"--fast"}, wantOK: true},
+		{line: "python3 main.py"},
+		{line: "./a.out < in.txt"},
+		{line: "./a.out && echo done"},
+		{line: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			program, args, ok := localProgram(tt.line)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantProgram, program)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantArgs, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Package runner implements local execution of solutions.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ProcessRunner implements SolutionRunner by running shell commands as child processes
+type ProcessRunner struct {
+	logger *logger.Logger
+}
+
+// NewProcessRunner creates a new ProcessRunner
+func NewProcessRunner() service.SolutionRunner {
+	return &ProcessRunner{
+		logger: logger.WithGroup("process_runner"),
+	}
+}
+
+// Build compiles the solution with the build command
+func (r *ProcessRunner) Build(ctx context.Context, spec service.RunSpec) (*service.BuildResult, error) {
+	if strings.TrimSpace(spec.BuildCommand) == "" {
+		return &service.BuildResult{Success: true}, nil
+	}
+
+	r.logger.DebugContext(ctx, "building solution", "command", spec.BuildCommand, "dir", spec.Dir)
+
+	var output bytes.Buffer
+	cmd := shellCommand(ctx, spec.BuildCommand)
+	cmd.Dir = spec.Dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInternalServer,
+				"failed to start build command: "+spec.BuildCommand,
+				err,
+			)
+		}
+		return &service.BuildResult{Success: false, Output: output.String(), Duration: duration}, nil
+	}
+
+	return &service.BuildResult{Success: true, Output: output.String(), Duration: duration}, nil
+}
+
+// Run executes the solution, killing it when the timeout expires
+func (r *ProcessRunner) Run(ctx context.Context, spec service.RunSpec, input string, timeout time.Duration) (*service.RunResult, error) {
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := shellCommand(runCtx, spec.RunCommand)
+	cmd.Dir = spec.Dir
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	duration := time.Since(start)
+
+	result := &service.RunResult{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Duration: duration,
+	}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+		result.MemoryKB = peakMemoryKB(cmd.ProcessState)
+	}
+
+	if runCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		result.TimedOut = true
+		return result, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInternalServer,
+				"failed to start run command: "+spec.RunCommand,
+				err,
+			)
+		}
+	}
+
+	return result, nil
+}
+
+// RunAttached executes the solution connected to the given streams. A *os.File
+// such as the terminal is passed to the solution directly
+func (r *ProcessRunner) RunAttached(ctx context.Context, spec service.RunSpec, stdin io.Reader, stdout, stderr io.Writer) (*service.RunResult, error) {
+	r.logger.DebugContext(ctx, "running solution attached", "command", spec.RunCommand, "dir", spec.Dir)
+
+	cmd := shellCommand(ctx, spec.RunCommand)
+	cmd.Dir = spec.Dir
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	result := &service.RunResult{Duration: time.Since(start)}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+		result.MemoryKB = peakMemoryKB(cmd.ProcessState)
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInternalServer,
+				"failed to start run command: "+spec.RunCommand,
+				err,
+			)
+		}
+	}
+
+	return result, nil
+}
+
+// shellCommand creates a command that runs line through the platform shell
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	cmd := platformCommand(ctx, line)
+	configureProcessGroup(cmd)
+	return cmd
+}
+
+// shellMetacharacters are characters that need a shell to interpret them
+const shellMetacharacters = "&|<>^%!()\"'`$;*?"
+
+// localProgram splits a command line such as `./a.out` or `.\main.exe arg`
+// that runs a program from the working directory without any shell syntax
+func localProgram(line string) (string, []string, bool) {
+	if strings.ContainsAny(line, shellMetacharacters) {
+		return "", nil, false
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+	if !strings.HasPrefix(fields[0], "./") && !strings.HasPrefix(fields[0], `.\`) {
+		return "", nil, false
+	}
+	return fields[0], fields[1:], true
+}
@@ -0,0 +1,157 @@
+//go:build !windows
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+)
+
+func TestProcessRunner_Run(t *testing.T) {
+	// Given
+	r := NewProcessRunner()
+	spec := service.RunSpec{Dir: t.TempDir(), RunCommand: "cat"}
+
+	// When
+	result, err := r.Run(context.Background(), spec, "1 2\n", time.Second)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1 2\n", result.Stdout)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.False(t, result.TimedOut)
+}
+
+func TestProcessRunner_Run_Timeout(t *testing.T) {
+	// Given
+	r := NewProcessRunner()
+	spec := service.RunSpec{Dir: t.TempDir(), RunCommand: "sleep 5"}
+
+	// When
+	start := time.Now()
+	result, err := r.Run(context.Background(), spec, "", 100*time.Millisecond)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestProcessRunner_Run_Cancelled(t *testing.T) {
+	// Given
+	r := NewProcessRunner()
+	spec := service.RunSpec{Dir: t.TempDir(), RunCommand: "sleep 5"}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	// When
+	start := time.Now()
+	_, err := r.Run(ctx, spec, "", 10*time.Second)
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestProcessRunner_Build_Failure(t *testing.T) {
+	// Given
+	r := NewProcessRunner()
+	spec := service.RunSpec{Dir: t.TempDir(), BuildCommand: "echo 'syntax error' >&2; exit 1"}
+
+	// When
+	result, err := r.Build(context.Background(), spec)
+
+	// Then
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, "syntax error\n", result.Output)
+}
+
+func TestProcessRunner_RunInteractive(t *testing.T) {
+	tests := []struct {
+		name           string
+		solution       string
+		wantExitCode   int
+		wantTranscript []service.TranscriptLine
+	}{
+		{
+			name:         "accepted",
+			solution:     `read x; echo $((x * 2))`,
+			wantExitCode: 0,
+			wantTranscript: []service.TranscriptLine{
+				{FromSolution: false, Text: "21"},
+				{FromSolution: true, Text: "42"},
+			},
+		},
+		{
+			name:         "rejected",
+			solution:     `read x; echo $((x + 1))`,
+			wantExitCode: 1,
+			wantTranscript: []service.TranscriptLine{
+				{FromSolution: false, Text: "21"},
+				{FromSolution: true, Text: "22"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			r := NewProcessRunner()
+			spec := service.RunSpec{Dir: t.TempDir(), RunCommand: tt.solution}
+			interactor := service.InteractorSpec{
+				Command:  `read x < {input}; echo "$x"; read y; test "$y" = "$(cat {output})"`,
+				Input:    "21\n",
+				Expected: "42\n",
+			}
+
+			// When
+			result, err := r.RunInteractive(context.Background(), spec, interactor, 5*time.Second)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, 0, result.ExitCode)
+			assert.Equal(t, tt.wantExitCode, result.InteractorExitCode)
+			assert.Equal(t, tt.wantTranscript, result.Transcript)
+			assert.False(t, result.TimedOut)
+		})
+	}
+}
+
+func TestProcessRunner_RunInteractive_Timeout(t *testing.T) {
+	// Given
+	r := NewProcessRunner()
+	spec := service.RunSpec{Dir: t.TempDir(), RunCommand: "read x; sleep 5"}
+	interactor := service.InteractorSpec{Command: "echo 1; read y"}
+
+	// When
+	start := time.Now()
+	result, err := r.RunInteractive(context.Background(), spec, interactor, 100*time.Millisecond)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestProcessRunner_RunAttached(t *testing.T) {
+	// Given
+	r := NewProcessRunner()
+	spec := service.RunSpec{Dir: t.TempDir(), RunCommand: "cat; echo oops >&2; exit 3"}
+	var stdout, stderr strings.Builder
+
+	// When
+	result, err := r.RunAttached(context.Background(), spec, strings.NewReader("hello\n"), &stdout, &stderr)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "hello\n", stdout.String())
+	assert.Equal(t, "oops\n", stderr.String())
+}
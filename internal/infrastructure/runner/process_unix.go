@@ -0,0 +1,40 @@
+//go:build !windows
+
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// platformCommand runs line through sh
+func platformCommand(ctx context.Context, line string) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
+
+// configureProcessGroup runs the command in its own process group so that
+// children spawned by the shell are killed on timeout as well
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
+}
+
+// peakMemoryKB returns the peak resident set size of the finished process
+func peakMemoryKB(state *os.ProcessState) int64 {
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || usage == nil {
+		return 0
+	}
+	// ru_maxrss is reported in bytes on macOS and in kilobytes elsewhere
+	if runtime.GOOS == "darwin" {
+		return int64(usage.Maxrss) / 1024
+	}
+	return int64(usage.Maxrss)
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// platformCommand runs line through cmd.exe. Programs in the working directory
+// such as ./a.out are started directly, because cmd.exe neither accepts the
+// ./ prefix nor runs executables without an .exe extension
+func platformCommand(ctx context.Context, line string) *exec.Cmd {
+	if program, args, ok := localProgram(line); ok {
+		return exec.CommandContext(ctx, program, args...)
+	}
+	return exec.CommandContext(ctx, "cmd", "/C", line)
+}
+
+// configureProcessGroup makes sure pipes are released shortly after the process is killed
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.WaitDelay = time.Second
+}
+
+// peakMemoryKB is not available on Windows
+func peakMemoryKB(_ *os.ProcessState) int64 {
+	return 0
+}
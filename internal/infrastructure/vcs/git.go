@@ -0,0 +1,88 @@
+// Package vcs records solutions in version control repositories.
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// Git implements VersionControl with the git command
+type Git struct {
+	logger *logger.Logger
+}
+
+// NewGit creates a new Git
+func NewGit() service.VersionControl {
+	return &Git{
+		logger: logger.WithGroup("git"),
+	}
+}
+
+// Commit stages the files and commits only them, leaving other staged changes
+// of the repository alone. The files must belong to the same repository
+func (g *Git) Commit(ctx context.Context, files []string, message string) (string, error) {
+	if len(files) == 0 {
+		return "", nil
+	}
+	dir := filepath.Dir(files[0])
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return "", cerrors.Wrap(err, "failed to resolve file path")
+		}
+		paths = append(paths, abs)
+	}
+
+	if _, err := g.run(ctx, dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return "", cerrors.NewAppError(cerrors.CodeInvalidInput, dir+" is not inside a git repository", err)
+	}
+	if _, err := g.run(ctx, dir, append([]string{"add", "--"}, paths...)...); err != nil {
+		return "", err
+	}
+	status, err := g.run(ctx, dir, append([]string{"status", "--porcelain", "--"}, paths...)...)
+	if err != nil {
+		return "", err
+	}
+	if status == "" {
+		g.logger.DebugContext(ctx, "nothing to commit", "files", files)
+		return "", nil
+	}
+
+	if _, err := g.run(ctx, dir, append([]string{"commit", "--quiet", "--message", message, "--"}, paths...)...); err != nil {
+		return "", err
+	}
+	return g.run(ctx, dir, "rev-parse", "--short", "HEAD")
+}
+
+// Tag creates a lightweight tag on HEAD
+func (g *Git) Tag(ctx context.Context, dir, name string) error {
+	_, err := g.run(ctx, dir, "tag", name)
+	return err
+}
+
+// run runs git in dir and returns its trimmed standard output
+func (g *Git) run(ctx context.Context, dir string, args ...string) (string, error) {
+	g.logger.DebugContext(ctx, "running git", "dir", dir, "args", args)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", cerrors.NewAppError(cerrors.CodeInternalServer, "git "+args[0]+" failed: "+message, err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,88 @@
+package vcs
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// initRepository creates a git repository with a local identity
+func initRepository(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "user.name", "Tester"},
+		{"config", "user.email", "tester@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	return dir
+}
+
+// gitOutput runs git in dir and returns its output
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestGit_CommitAndTag(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := initRepository(t)
+	problemDir := filepath.Join(repo, "ITP1_1_A")
+	assert.NoError(t, os.MkdirAll(problemDir, 0755))
+	source := filepath.Join(problemDir, "main.cpp")
+	assert.NoError(t, os.WriteFile(source, []byte("int main() {}\n"), 0644))
+	other := filepath.Join(repo, "notes.md")
+	assert.NoError(t, os.WriteFile(other, []byte("draft\n"), 0644))
+	gitOutput(t, repo, "add", "notes.md")
+	git := NewGit()
+
+	// When
+	hash, err := git.Commit(ctx, []string{source}, "AC ITP1_1_A (C++17)")
+	tagErr := git.Tag(ctx, problemDir, "ac/ITP1_1_A")
+	again, againErr := git.Commit(ctx, []string{source}, "AC ITP1_1_A (C++17)")
+
+	// Then
+	assert.NoError(t, err)
+	assert.NoError(t, tagErr)
+	assert.NoError(t, againErr)
+	assert.Equal(t, gitOutput(t, repo, "rev-parse", "--short", "HEAD"), hash)
+	assert.Equal(t, "AC ITP1_1_A (C++17)", gitOutput(t, repo, "log", "-1", "--format=%s"))
+	assert.Equal(t, "ITP1_1_A/main.cpp", gitOutput(t, repo, "show", "--name-only", "--format=", "HEAD"))
+	assert.Equal(t, "A  notes.md", gitOutput(t, repo, "status", "--porcelain"), "other staged changes stay staged")
+	assert.Equal(t, hash, gitOutput(t, repo, "rev-parse", "--short", "ac/ITP1_1_A"))
+	assert.Empty(t, again, "an unchanged solution is not committed again")
+}
+
+func TestGit_Commit_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	source := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(source, []byte("int main() {}\n"), 0644))
+
+	_, err := NewGit().Commit(context.Background(), []string{source}, "AC ITP1_1_A")
+
+	assert.ErrorContains(t, err, "not inside a git repository")
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// aliasPattern restricts alias names to what can be typed and used as a directory name
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ProblemAliases maps alias names such as "two-sum" to problem IDs. The zero
+// value has no aliases
+type ProblemAliases map[string]string
+
+// ParseProblemID returns the problem an alias stands for, or parses value as a problem ID
+func (a ProblemAliases) ParseProblemID(value string) (model.ProblemID, error) {
+	value = strings.TrimSpace(value)
+	if target, ok := a[value]; ok {
+		return model.NewProblemID(target)
+	}
+	return model.NewProblemID(value)
+}
+
+// Alias is a name that can be used in place of a problem ID
+type Alias struct {
+	Name      string `json:"name"`
+	ProblemID string `json:"problem_id"`
+}
+
+// AliasUseCase manages the problem aliases stored in config.toml
+type AliasUseCase struct {
+	configPath string
+	logger     *logger.Logger
+}
+
+// NewAliasUseCase creates a new AliasUseCase for the config file at configPath
+func NewAliasUseCase(configPath string) *AliasUseCase {
+	return &AliasUseCase{
+		configPath: configPath,
+		logger:     logger.WithGroup("alias_usecase"),
+	}
+}
+
+// List returns the aliases sorted by name
+func (uc *AliasUseCase) List(_ context.Context) ([]Alias, error) {
+	cfg, err := config.Load(uc.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make([]Alias, 0, len(cfg.Aliases))
+	for name, problemID := range cfg.Aliases {
+		aliases = append(aliases, Alias{Name: name, ProblemID: problemID})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases, nil
+}
+
+// Add defines an alias for a problem, replacing an existing alias of the same name
+func (uc *AliasUseCase) Add(ctx context.Context, name, problemID string) (*Alias, error) {
+	if !aliasPattern.MatchString(name) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("invalid alias name '%s': use letters, digits, '-', '_' and '.'", name),
+			nil,
+		)
+	}
+	if _, err := model.NewProblemID(name); err == nil {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("alias '%s' is itself a problem ID", name),
+			nil,
+		)
+	}
+	id, err := model.NewProblemID(problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(uc.configPath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[name] = id.String()
+	if err := config.Save(cfg, uc.configPath); err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "alias added", "alias", name, "problem_id", id.String())
+	return &Alias{Name: name, ProblemID: id.String()}, nil
+}
+
+// Remove deletes an alias
+func (uc *AliasUseCase) Remove(ctx context.Context, name string) error {
+	cfg, err := config.Load(uc.configPath)
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Aliases[name]; !ok {
+		return cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("alias '%s' not found", name),
+			nil,
+		)
+	}
+
+	delete(cfg.Aliases, name)
+	if err := config.Save(cfg, uc.configPath); err != nil {
+		return err
+	}
+
+	uc.logger.InfoContext(ctx, "alias removed", "alias", name)
+	return nil
+}
@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+func TestAliasUseCase_AddListRemove(t *testing.T) {
+	// Given
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	assert.NoError(t, os.WriteFile(configPath, []byte("[test]\ntimeout = 5.0\n"), 0644))
+	uc := usecase.NewAliasUseCase(configPath)
+	ctx := context.Background()
+
+	// When
+	_, addErr := uc.Add(ctx, "two-sum", "ITP1_6_D")
+	_, otherErr := uc.Add(ctx, "dijkstra", "ALDS1_12_B")
+	removeErr := uc.Remove(ctx, "dijkstra")
+	aliases, listErr := uc.List(ctx)
+
+	// Then
+	if addErr != nil || otherErr != nil || removeErr != nil || listErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v, %v", addErr, otherErr, removeErr, listErr)
+	}
+	assert.Equal(t, []usecase.Alias{{Name: "two-sum", ProblemID: "ITP1_6_D"}}, aliases)
+
+	cfg, err := config.Load(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"two-sum": "ITP1_6_D"}, cfg.Aliases)
+	assert.Equal(t, 5.0, cfg.Test.Timeout, "other settings are kept")
+}
+
+func TestAliasUseCase_Errors(t *testing.T) {
+	uc := usecase.NewAliasUseCase(filepath.Join(t.TempDir(), "config.toml"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		err  func() error
+		code cerrors.ErrorCode
+	}{
+		{"invalid name", func() error { _, err := uc.Add(ctx, "two sum", "ITP1_6_D"); return err }, cerrors.CodeInvalidInput},
+		{"name is a problem ID", func() error { _, err := uc.Add(ctx, "ITP1_1_A", "ITP1_6_D"); return err }, cerrors.CodeInvalidInput},
+		{"invalid problem ID", func() error { _, err := uc.Add(ctx, "two-sum", "../etc"); return err }, cerrors.CodeInvalidInput},
+		{"unknown alias", func() error { return uc.Remove(ctx, "two-sum") }, cerrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+
+			assert.True(t, cerrors.IsAppError(err, tt.code), "got %v", err)
+		})
+	}
+}
+
+func TestProblemAliases_ParseProblemID(t *testing.T) {
+	aliases := usecase.ProblemAliases{"two-sum": "ITP1_6_D"}
+
+	alias, err := aliases.ParseProblemID("two-sum")
+	assert.NoError(t, err)
+	assert.Equal(t, "ITP1_6_D", alias.String())
+
+	id, err := aliases.ParseProblemID("ALDS1_1_A")
+	assert.NoError(t, err)
+	assert.Equal(t, "ALDS1_1_A", id.String())
+
+	_, err = usecase.ProblemAliases(nil).ParseProblemID("two-sum")
+	assert.Error(t, err)
+}
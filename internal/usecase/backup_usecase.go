@@ -0,0 +1,595 @@
+package usecase
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/crash"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/encryption"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/filelock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// BackupVersion is the layout version of the archives written by this build
+const BackupVersion = 1
+
+// Entries of a backup archive
+const (
+	backupManifestName = "manifest.json"
+	backupConfigPrefix = "config/"
+	backupDataPrefix   = "data/"
+	backupSessionsName = "sessions.enc"
+)
+
+// Where the local session repository keeps sessions; they are left out of
+// the copied files and only archived encrypted
+const (
+	backupSessionsBucket    = "sessions"
+	backupCurrentSessionKey = "current_session"
+)
+
+// BackupSettings configures the backup use case
+type BackupSettings struct {
+	ConfigDir string                       // directory of config.toml and templates
+	DataDir   string                       // directory of sessions, caches and history; may equal ConfigDir
+	Sessions  repository.SessionRepository // sessions to archive and restore
+	Clock     clock.Clock                  // Optional: source of the creation time (defaults to the system clock)
+}
+
+// BackupCreateOptions configures creating a backup
+type BackupCreateOptions struct {
+	Path            string // archive to write
+	IncludeSessions bool   // archive the sessions encrypted with Passphrase
+	Passphrase      string // required with IncludeSessions
+}
+
+// BackupRestoreOptions configures restoring a backup
+type BackupRestoreOptions struct {
+	Path  string // archive to read
+	Force bool   // overwrite an existing configuration
+	// Passphrase is asked for when the archive holds sessions; an empty
+	// passphrase skips them
+	Passphrase func() (string, error)
+}
+
+// BackupResult describes a created or restored backup
+type BackupResult struct {
+	Path            string `json:"path"`
+	Files           int    `json:"files"`
+	Size            int64  `json:"size"`
+	Sessions        int    `json:"sessions"`
+	SessionsSkipped bool   `json:"sessions_skipped"`
+}
+
+// backupManifest is the first entry of an archive
+type backupManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	SharedDir bool      `json:"shared_dir"` // config and data were the same directory
+	Sessions  bool      `json:"sessions"`
+}
+
+// backupSession is an archived session
+type backupSession struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	LastUsed  time.Time `json:"last_used"`
+	Current   bool      `json:"current"`
+}
+
+// BackupUseCase moves the configuration and local data between machines
+type BackupUseCase struct {
+	settings BackupSettings
+	logger   *logger.Logger
+}
+
+// NewBackupUseCase creates a new backup use case
+func NewBackupUseCase(settings BackupSettings) *BackupUseCase {
+	if settings.DataDir == "" {
+		settings.DataDir = settings.ConfigDir
+	}
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+	return &BackupUseCase{
+		settings: settings,
+		logger:   logger.WithGroup("backup_usecase"),
+	}
+}
+
+// Create archives config, templates, caches, aliases and history into a
+// gzipped tarball. Sessions are left out unless opts.IncludeSessions is set,
+// in which case they are archived encrypted with the passphrase
+func (uc *BackupUseCase) Create(ctx context.Context, opts BackupCreateOptions) (*BackupResult, error) {
+	uc.logger.InfoContext(ctx, "creating backup", "path", opts.Path, "include_sessions", opts.IncludeSessions)
+
+	if opts.Path == "" {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "backup file path cannot be empty", nil)
+	}
+	result := &BackupResult{Path: opts.Path, SessionsSkipped: !opts.IncludeSessions}
+	var sessions []byte
+	if opts.IncludeSessions {
+		var err error
+		if sessions, result.Sessions, err = uc.encryptSessions(ctx, opts.Passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := filepath.Abs(opts.Path)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to resolve "+opts.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return nil, cerrors.Wrap(err, "failed to create backup directory")
+	}
+	// The archive holds the store; write it to a temporary file first so that
+	// a failed backup never replaces a good one
+	file, err := os.CreateTemp(filepath.Dir(output), ".aoj-backup-*")
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create "+opts.Path)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	writeErr := uc.writeArchive(file, output, sessions, result)
+	if closeErr := file.Close(); writeErr == nil && closeErr != nil {
+		writeErr = cerrors.Wrap(closeErr, "failed to write "+opts.Path)
+	}
+	if writeErr != nil {
+		return nil, writeErr
+	}
+	trace.File("write", output)
+	if err := os.Rename(file.Name(), output); err != nil {
+		return nil, cerrors.Wrap(err, "failed to write "+opts.Path)
+	}
+
+	info, err := os.Stat(output)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to stat "+opts.Path)
+	}
+	result.Size = info.Size()
+	uc.logger.InfoContext(ctx, "backup created", "path", opts.Path, "files", result.Files, "size", result.Size)
+	return result, nil
+}
+
+// writeArchive writes the manifest, the encrypted sessions and the files of both directories
+func (uc *BackupUseCase) writeArchive(w io.Writer, output string, sessions []byte, result *BackupResult) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := uc.settings.Clock.Now()
+
+	shared := sameDir(uc.settings.ConfigDir, uc.settings.DataDir)
+	manifest, err := json.MarshalIndent(backupManifest{
+		Version:   BackupVersion,
+		CreatedAt: now,
+		SharedDir: shared,
+		Sessions:  sessions != nil,
+	}, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode backup manifest")
+	}
+	if err := writeTarFile(tw, backupManifestName, manifest, 0644, now); err != nil {
+		return err
+	}
+	// Sessions come before the files, so that a wrong passphrase is noticed
+	// before anything is restored
+	if sessions != nil {
+		if err := writeTarFile(tw, backupSessionsName, sessions, 0600, now); err != nil {
+			return err
+		}
+	}
+
+	// A shared directory is archived once, holding both config and data
+	if err := uc.archiveDir(tw, uc.settings.ConfigDir, backupConfigPrefix, shared, output, result); err != nil {
+		return err
+	}
+	if !shared {
+		if err := uc.archiveDir(tw, uc.settings.DataDir, backupDataPrefix, true, output, result); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to write backup archive")
+	}
+	if err := gz.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to write backup archive")
+	}
+	return nil
+}
+
+// archiveDir adds the files under dir with the given prefix. In a data
+// directory, sessions, crash reports and lock files are skipped and the kv
+// store is replaced by a snapshot without sessions
+func (uc *BackupUseCase) archiveDir(tw *tar.Writer, dir, prefix string, isData bool, output string, result *BackupResult) error {
+	if dir == "" {
+		return nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return cerrors.Wrap(err, "failed to read "+p)
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return cerrors.Wrap(err, "failed to read "+p)
+		}
+		if rel == "." {
+			return nil
+		}
+		if skipBackupEntry(rel, d.IsDir(), isData) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		if abs, err := filepath.Abs(p); err == nil && abs == output {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return cerrors.Wrap(err, "failed to read "+p)
+		}
+		var content []byte
+		if isData && rel == storage.KVFileName {
+			content, err = snapshotKVStore(p)
+		} else {
+			content, err = os.ReadFile(p)
+		}
+		if err != nil {
+			return cerrors.Wrap(err, "failed to read "+p)
+		}
+		if err := writeTarFile(tw, prefix+filepath.ToSlash(rel), content, info.Mode().Perm(), info.ModTime()); err != nil {
+			return err
+		}
+		result.Files++
+		return nil
+	})
+}
+
+// skipBackupEntry reports whether a path relative to an archived directory is left out
+func skipBackupEntry(rel string, isDir, isData bool) bool {
+	if strings.HasSuffix(rel, ".lock") || strings.HasPrefix(filepath.Base(rel), ".aoj-backup-") {
+		return true
+	}
+	if !isData {
+		return false
+	}
+	switch {
+	case isDir && (rel == backupSessionsBucket || rel == crash.DirName):
+		return true
+	case !isDir && rel == backupCurrentSessionKey:
+		return true
+	}
+	return false
+}
+
+// snapshotKVStore returns the kv store at path without its sessions
+func snapshotKVStore(path string) ([]byte, error) {
+	store, err := storage.OpenKVStore(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Close() }()
+
+	return store.Snapshot(func(bucket, key string) bool {
+		return bucket == backupSessionsBucket || (bucket == "" && key == backupCurrentSessionKey)
+	})
+}
+
+// encryptSessions returns all sessions encrypted with the passphrase and how many there are
+func (uc *BackupUseCase) encryptSessions(ctx context.Context, passphrase string) ([]byte, int, error) {
+	if passphrase == "" {
+		return nil, 0, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"a passphrase is required to include sessions in a backup",
+			nil,
+		)
+	}
+	cipher, err := encryption.NewPassphraseCipher(passphrase)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sessions, err := uc.settings.Sessions.List(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	var currentID string
+	if current, err := uc.settings.Sessions.GetCurrent(ctx); err == nil && current != nil {
+		currentID = current.ID().String()
+	}
+
+	archived := make([]backupSession, 0, len(sessions))
+	for _, session := range sessions {
+		archived = append(archived, backupSession{
+			ID:        session.ID().String(),
+			Username:  session.Username(),
+			Token:     session.Token(),
+			ExpiresAt: session.ExpiresAt(),
+			CreatedAt: session.CreatedAt(),
+			LastUsed:  session.LastUsed(),
+			Current:   session.ID().String() == currentID,
+		})
+	}
+	plaintext, err := json.Marshal(archived)
+	if err != nil {
+		return nil, 0, cerrors.Wrap(err, "failed to encode sessions")
+	}
+	encrypted, err := cipher.Encrypt(plaintext)
+	if err != nil {
+		return nil, 0, err
+	}
+	return encrypted, len(archived), nil
+}
+
+// Restore extracts a backup made by Create into the config and data
+// directories. An existing configuration is only overwritten with opts.Force
+func (uc *BackupUseCase) Restore(ctx context.Context, opts BackupRestoreOptions) (*BackupResult, error) {
+	uc.logger.InfoContext(ctx, "restoring backup", "path", opts.Path, "force", opts.Force)
+
+	if !opts.Force {
+		configPath := filepath.Join(uc.settings.ConfigDir, config.FileName)
+		if _, err := os.Stat(configPath); err == nil {
+			return nil, cerrors.WithHint(
+				cerrors.NewAppError(
+					cerrors.CodeConflict,
+					fmt.Sprintf("%s already exists; restoring would overwrite it", configPath),
+					nil,
+				),
+				"Pass --force to replace the current configuration and data",
+			)
+		}
+	}
+
+	file, err := os.Open(opts.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, cerrors.NewAppError(cerrors.CodeNotFound, "backup file "+opts.Path+" not found", err)
+		}
+		return nil, cerrors.Wrap(err, "failed to open "+opts.Path)
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to stat "+opts.Path)
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, invalidBackup(opts.Path, err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+
+	manifest, err := readBackupManifest(tr, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackupResult{Path: opts.Path, Size: info.Size(), SessionsSkipped: !manifest.Sessions}
+	var sessions []backupSession
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, invalidBackup(opts.Path, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if header.Name == backupSessionsName {
+			encrypted, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, invalidBackup(opts.Path, err)
+			}
+			if sessions, err = decryptSessions(encrypted, opts.Passphrase); err != nil {
+				return nil, err
+			}
+			result.SessionsSkipped = sessions == nil
+			continue
+		}
+		target, err := uc.restoreTarget(header.Name, manifest.SharedDir)
+		if err != nil {
+			return nil, err
+		}
+		if err := restoreFile(tr, target, header); err != nil {
+			return nil, err
+		}
+		result.Files++
+	}
+
+	// Sessions are saved last, as the restored store replaces the current one
+	if err := uc.restoreSessions(ctx, sessions); err != nil {
+		return nil, err
+	}
+	result.Sessions = len(sessions)
+
+	uc.logger.InfoContext(ctx, "backup restored", "path", opts.Path, "files", result.Files, "sessions", result.Sessions)
+	return result, nil
+}
+
+// readBackupManifest reads and checks the first entry of an archive
+func readBackupManifest(tr *tar.Reader, archive string) (*backupManifest, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return nil, invalidBackup(archive, err)
+	}
+	if header.Name != backupManifestName {
+		return nil, invalidBackup(archive, nil)
+	}
+
+	var manifest backupManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, invalidBackup(archive, err)
+	}
+	if manifest.Version > BackupVersion {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("%s has backup version %d but this aoj only understands up to version %d. Please upgrade aoj", archive, manifest.Version, BackupVersion),
+			nil,
+		)
+	}
+	return &manifest, nil
+}
+
+// restoreTarget returns where an archived file is restored to
+func (uc *BackupUseCase) restoreTarget(name string, sharedDir bool) (string, error) {
+	var dir, rel string
+	switch {
+	case strings.HasPrefix(name, backupConfigPrefix):
+		dir, rel = uc.settings.ConfigDir, strings.TrimPrefix(name, backupConfigPrefix)
+		// Data archived from a shared directory belongs to the data directory here
+		if sharedDir && isBackupDataFile(rel) {
+			dir = uc.settings.DataDir
+		}
+	case strings.HasPrefix(name, backupDataPrefix):
+		dir, rel = uc.settings.DataDir, strings.TrimPrefix(name, backupDataPrefix)
+	default:
+		return "", cerrors.NewAppError(cerrors.CodeInvalidInput, "unexpected backup entry "+name, nil)
+	}
+
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", cerrors.NewAppError(cerrors.CodeInvalidInput, "backup entry "+name+" points outside of its directory", nil)
+	}
+	return filepath.Join(dir, filepath.FromSlash(rel)), nil
+}
+
+// isBackupDataFile reports whether a file of a shared directory is local data
+// rather than configuration
+func isBackupDataFile(rel string) bool {
+	first, _, _ := strings.Cut(rel, "/")
+	return first != config.FileName && first != "templates" && path.Ext(first) != ".toml"
+}
+
+// restoreFile writes one archived file
+func restoreFile(r io.Reader, target string, header *tar.Header) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to read backup entry "+header.Name)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return cerrors.Wrap(err, "failed to create "+filepath.Dir(target))
+	}
+	perm := fs.FileMode(header.Mode).Perm()
+	if perm == 0 {
+		perm = 0600
+	}
+	if err := filelock.WriteFileAtomic(target, content, perm); err != nil {
+		return cerrors.Wrap(err, "failed to write "+target)
+	}
+	return nil
+}
+
+// decryptSessions decrypts the archived sessions with the passphrase asked
+// for. Without a passphrase they are skipped and nil is returned
+func decryptSessions(data []byte, ask func() (string, error)) ([]backupSession, error) {
+	if ask == nil {
+		return nil, nil
+	}
+	passphrase, err := ask()
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read the backup passphrase")
+	}
+	if passphrase == "" {
+		return nil, nil
+	}
+	cipher, err := encryption.NewPassphraseCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := cipher.Decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []backupSession{}
+	if err := json.Unmarshal(plaintext, &sessions); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode the archived sessions")
+	}
+	return sessions, nil
+}
+
+// restoreSessions saves the archived sessions and makes the current one current again
+func (uc *BackupUseCase) restoreSessions(ctx context.Context, sessions []backupSession) error {
+	for _, s := range sessions {
+		id, err := model.NewSessionID(s.ID)
+		if err != nil {
+			return err
+		}
+		session := entity.RestoreSession(id, s.Username, s.Token, s.ExpiresAt, s.CreatedAt, s.LastUsed)
+		if err := uc.settings.Sessions.Save(ctx, session); err != nil {
+			return err
+		}
+		if s.Current {
+			if err := uc.settings.Sessions.SetCurrent(ctx, session); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTarFile adds a regular file to an archive
+func writeTarFile(tw *tar.Writer, name string, content []byte, perm fs.FileMode, modTime time.Time) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(perm),
+		Size:     int64(len(content)),
+		ModTime:  modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return cerrors.Wrap(err, "failed to write backup entry "+name)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return cerrors.Wrap(err, "failed to write backup entry "+name)
+	}
+	return nil
+}
+
+// invalidBackup returns the error reported for an archive not made by aoj backup create
+func invalidBackup(archive string, err error) error {
+	return cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		archive+" is not an aoj backup",
+		err,
+	)
+}
+
+// sameDir reports whether two paths name the same directory
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
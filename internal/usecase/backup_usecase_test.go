@@ -0,0 +1,229 @@
+package usecase_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	domainrepo "github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/infrastructure/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+)
+
+// backupMachine is the config and data directories of one machine
+type backupMachine struct {
+	configDir string
+	dataDir   string
+	store     storage.Store
+	backup    *usecase.BackupUseCase
+	sessions  domainrepo.SessionRepository
+}
+
+func newBackupMachine(t *testing.T) *backupMachine {
+	t.Helper()
+	root := t.TempDir()
+	m := &backupMachine{configDir: filepath.Join(root, "config"), dataDir: filepath.Join(root, "data")}
+	store, err := storage.Open(storage.BackendKV, m.dataDir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	sessionRepo := repository.NewLocalSessionRepositoryWithStore(store, nil, nil)
+	m.store = store
+	m.backup = usecase.NewBackupUseCase(usecase.BackupSettings{ConfigDir: m.configDir, DataDir: m.dataDir, Sessions: sessionRepo})
+	m.sessions = sessionRepo
+	return m
+}
+
+func seedBackupMachine(t *testing.T, m *backupMachine) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(m.configDir, "templates"), 0755); err != nil {
+		t.Fatalf("failed to create templates: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(m.configDir, "config.toml"), []byte("[aliases]\nhello = \"ITP1_1_A\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(m.configDir, "templates", "main.cpp"), []byte("int main() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	if err := m.store.Put("history", "1.json", []byte(`{"verdict":"AC"}`)); err != nil {
+		t.Fatalf("failed to write history: %v", err)
+	}
+
+	now := time.Now()
+	session := entity.RestoreSession(model.MustGenerateSessionID(), "alice", "secret-token", now.Add(time.Hour), now, now)
+	if err := m.sessions.Save(context.Background(), session); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+	if err := m.sessions.SetCurrent(context.Background(), session); err != nil {
+		t.Fatalf("failed to set the current session: %v", err)
+	}
+}
+
+// archiveEntries returns the names of the entries of a backup
+func archiveEntries(t *testing.T, path string) []string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("backup is not gzipped: %v", err)
+	}
+	tr := tar.NewReader(gz)
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	return names
+}
+
+func TestBackupUseCase_CreateLeavesOutSessions(t *testing.T) {
+	// Given
+	machine := newBackupMachine(t)
+	seedBackupMachine(t, machine)
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	// When
+	result, err := machine.backup.Create(context.Background(), usecase.BackupCreateOptions{Path: archive})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	assert.True(t, result.SessionsSkipped)
+	assert.Equal(t, []string{"manifest.json", "config/config.toml", "config/templates/main.cpp", "data/store.db"}, archiveEntries(t, archive))
+
+	file, err := os.Open(archive)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("backup is not gzipped: %v", err)
+	}
+	plain, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	assert.NotContains(t, string(plain), "secret-token")
+	assert.Contains(t, string(plain), `{"verdict":"AC"}`)
+}
+
+func TestBackupUseCase_RoundTrip(t *testing.T) {
+	// Given
+	source := newBackupMachine(t)
+	seedBackupMachine(t, source)
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	_, err := source.backup.Create(context.Background(), usecase.BackupCreateOptions{
+		Path:            archive,
+		IncludeSessions: true,
+		Passphrase:      "correct horse",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	target := newBackupMachine(t)
+
+	// When
+	result, err := target.backup.Restore(context.Background(), usecase.BackupRestoreOptions{
+		Path:       archive,
+		Passphrase: func() (string, error) { return "correct horse", nil },
+	})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	assert.Equal(t, 3, result.Files)
+	assert.Equal(t, 1, result.Sessions)
+	template, err := os.ReadFile(filepath.Join(target.configDir, "templates", "main.cpp"))
+	if err != nil {
+		t.Fatalf("template was not restored: %v", err)
+	}
+	assert.Equal(t, "int main() {}\n", string(template))
+	history, err := target.store.Get("history", "1.json")
+	if err != nil {
+		t.Fatalf("history was not restored: %v", err)
+	}
+	assert.Equal(t, `{"verdict":"AC"}`, string(history))
+	current, err := target.sessions.GetCurrent(context.Background())
+	if err != nil {
+		t.Fatalf("session was not restored: %v", err)
+	}
+	assert.Equal(t, "alice", current.Username())
+	assert.Equal(t, "secret-token", current.Token())
+}
+
+func TestBackupUseCase_Restore(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   bool
+		force      bool
+		passphrase string
+		wantCode   cerrors.ErrorCode
+		wantSkip   bool
+	}{
+		{name: "existing configuration", existing: true, wantCode: cerrors.CodeConflict},
+		{name: "forced", existing: true, force: true, passphrase: "correct horse"},
+		{name: "wrong passphrase", passphrase: "wrong", wantCode: cerrors.CodeUnauthorized},
+		{name: "sessions skipped", wantSkip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			source := newBackupMachine(t)
+			seedBackupMachine(t, source)
+			archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+			_, err := source.backup.Create(context.Background(), usecase.BackupCreateOptions{
+				Path:            archive,
+				IncludeSessions: true,
+				Passphrase:      "correct horse",
+			})
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			target := newBackupMachine(t)
+			if tt.existing {
+				seedBackupMachine(t, target)
+			}
+
+			// When
+			result, err := target.backup.Restore(context.Background(), usecase.BackupRestoreOptions{
+				Path:       archive,
+				Force:      tt.force,
+				Passphrase: func() (string, error) { return tt.passphrase, nil },
+			})
+
+			// Then
+			if tt.wantCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+			assert.Equal(t, tt.wantSkip, result.SessionsSkipped)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+const defaultBenchRuns = 10
+
+// BenchOptions contains options for benchmarking a solution
+type BenchOptions struct {
+	Dir        string        // Optional: problem directory (defaults to the current directory)
+	SourceFile string        // Optional: solution file (defaults to the configured source file)
+	InputFile  string        // Optional: input file (defaults to the largest sample)
+	Case       int           // Optional: use the sample with this number as input
+	Runs       int           // Optional: number of runs (defaults to 10)
+	TimeLimit  time.Duration // Optional: time limit to compare against (defaults to the problem's limit)
+}
+
+// BenchReport holds the timing statistics of a benchmark
+type BenchReport struct {
+	SourceFile  string        `json:"source_file"`
+	Input       string        `json:"input"`
+	Runs        int           `json:"runs"`
+	Failures    int           `json:"failures"` // runs that crashed or were killed
+	Min         time.Duration `json:"min"`
+	Mean        time.Duration `json:"mean"`
+	P95         time.Duration `json:"p95"`
+	Max         time.Duration `json:"max"`
+	MaxMemoryKB int64         `json:"max_memory_kb"`
+	TimeLimit   time.Duration `json:"time_limit"`
+	SpeedFactor float64       `json:"speed_factor"` // how many times slower this machine is than the judge
+	BuildOutput string        `json:"build_output,omitempty"`
+	Interrupted bool          `json:"interrupted,omitempty"` // Runs counts only the runs finished before the interruption
+	Samples     []BenchSample `json:"samples"`
+}
+
+// BenchSample is the measurement of a single benchmark run
+type BenchSample struct {
+	Run      int           `json:"run"`
+	Time     time.Duration `json:"time"`
+	MemoryKB int64         `json:"memory_kb"`
+	Failed   bool          `json:"failed,omitempty"` // crashed or killed
+}
+
+// JudgeP95 estimates the 95th percentile on the judge from the local one
+func (r *BenchReport) JudgeP95() time.Duration {
+	if r.SpeedFactor <= 0 {
+		return r.P95
+	}
+	return time.Duration(float64(r.P95) / r.SpeedFactor)
+}
+
+// ExceedsTimeLimit returns true if the estimated 95th percentile on the judge
+// is above the time limit
+func (r *BenchReport) ExceedsTimeLimit() bool {
+	return r.TimeLimit > 0 && r.JudgeP95() > r.TimeLimit
+}
+
+// Bench builds the solution and runs it repeatedly over one input
+func (uc *TestUseCase) Bench(ctx context.Context, opts BenchOptions) (*BenchReport, error) {
+	uc.logger.InfoContext(ctx, "starting benchmark", "options", fmt.Sprintf("%+v", opts))
+
+	dir, spec, err := uc.prepare(opts.Dir, opts.SourceFile, "")
+	if err != nil {
+		return nil, err
+	}
+
+	runs := opts.Runs
+	if runs <= 0 {
+		runs = defaultBenchRuns
+	}
+	timeLimit := opts.TimeLimit
+	if timeLimit <= 0 {
+		timeLimit = uc.problemTimeLimit(ctx, dir)
+	}
+	if timeLimit <= 0 {
+		timeLimit = uc.settings.Timeout
+	}
+
+	name, input, err := uc.benchInput(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	build, err := uc.build(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if !build.Success {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"compile error:\n"+build.Output,
+			nil,
+		)
+	}
+
+	report := &BenchReport{
+		SourceFile:  spec.SourceFile,
+		Input:       name,
+		Runs:        runs,
+		TimeLimit:   timeLimit,
+		SpeedFactor: uc.settings.SpeedFactor,
+		BuildOutput: build.Output,
+	}
+
+	// Let slow runs finish so that their time is measured, but not forever
+	runTimeout := time.Duration(float64(2*timeLimit) * max(uc.settings.SpeedFactor, 1))
+	durations := make([]time.Duration, 0, runs)
+	for i := 0; i < runs; i++ {
+		result, err := uc.runner.Run(ctx, spec, input, runTimeout)
+		if err != nil {
+			if ctx.Err() != nil && len(durations) > 0 {
+				// Summarize the runs that finished before the interruption
+				report.Interrupted = true
+				report.Runs = len(durations)
+				break
+			}
+			return nil, err
+		}
+		failed := result.TimedOut || result.ExitCode != 0
+		if failed {
+			report.Failures++
+		}
+		if result.MemoryKB > report.MaxMemoryKB {
+			report.MaxMemoryKB = result.MemoryKB
+		}
+		durations = append(durations, result.Duration)
+		report.Samples = append(report.Samples, BenchSample{
+			Run:      i + 1,
+			Time:     result.Duration,
+			MemoryKB: result.MemoryKB,
+			Failed:   failed,
+		})
+	}
+
+	report.Min, report.Mean, report.P95, report.Max = summarizeDurations(durations)
+
+	uc.logger.InfoContext(ctx, "benchmark finished",
+		"mean", report.Mean,
+		"p95", report.P95,
+		"failures", report.Failures)
+
+	return report, nil
+}
+
+// benchInput returns the name and content of the benchmark input
+func (uc *TestUseCase) benchInput(dir string, opts BenchOptions) (string, string, error) {
+	if opts.InputFile != "" {
+		content, err := os.ReadFile(opts.InputFile)
+		if err != nil {
+			return "", "", cerrors.Wrap(err, fmt.Sprintf("failed to read input file %s", opts.InputFile))
+		}
+		return opts.InputFile, string(content), nil
+	}
+
+	testCases, err := uc.loadTestCases(dir, opts.Case)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The largest sample is the most representative of the worst case
+	largest := testCases[0]
+	for _, tc := range testCases[1:] {
+		if len(tc.Input()) > len(largest.Input()) {
+			largest = tc
+		}
+	}
+	return largest.GetDisplayName(), largest.Input(), nil
+}
+
+// summarizeDurations returns the min, mean, 95th percentile and max of the durations
+func summarizeDurations(durations []time.Duration) (minimum, mean, p95, maximum time.Duration) {
+	if len(durations) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	// Nearest-rank percentile
+	rank := (95*len(sorted) + 99) / 100
+	return sorted[0], total / time.Duration(len(sorted)), sorted[rank-1], sorted[len(sorted)-1]
+}
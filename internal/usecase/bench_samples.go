@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// HistogramBucket counts the runs whose time lies in [From, To)
+type HistogramBucket struct {
+	From  time.Duration
+	To    time.Duration
+	Count int
+}
+
+// TimeHistogram sorts the run times of the samples into at most buckets
+// buckets of equal width between the fastest and the slowest run. The last
+// bucket includes the slowest run
+func TimeHistogram(samples []BenchSample, buckets int) []HistogramBucket {
+	if len(samples) == 0 || buckets <= 0 {
+		return nil
+	}
+
+	lowest, highest := samples[0].Time, samples[0].Time
+	for _, sample := range samples[1:] {
+		lowest = min(lowest, sample.Time)
+		highest = max(highest, sample.Time)
+	}
+	if highest == lowest {
+		return []HistogramBucket{{From: lowest, To: highest, Count: len(samples)}}
+	}
+
+	span := highest - lowest
+	if time.Duration(buckets) > span {
+		buckets = int(span)
+	}
+	result := make([]HistogramBucket, buckets)
+	for i := range result {
+		result[i].From = lowest + span*time.Duration(i)/time.Duration(buckets)
+		result[i].To = lowest + span*time.Duration(i+1)/time.Duration(buckets)
+	}
+	for _, sample := range samples {
+		i := min(int((sample.Time-lowest)*time.Duration(buckets)/span), buckets-1)
+		result[i].Count++
+	}
+	return result
+}
+
+// WriteBenchCSV writes the samples as CSV with a header row, one row per run
+// with its time in milliseconds, so that they can be plotted elsewhere
+func WriteBenchCSV(w io.Writer, samples []BenchSample) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"run", "time_ms", "memory_kb", "failed"}); err != nil {
+		return err
+	}
+	for _, sample := range samples {
+		record := []string{
+			strconv.Itoa(sample.Run),
+			strconv.FormatFloat(float64(sample.Time)/float64(time.Millisecond), 'f', 3, 64),
+			strconv.FormatInt(sample.MemoryKB, 10),
+			strconv.FormatBool(sample.Failed),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
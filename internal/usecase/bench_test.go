@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeDurations(t *testing.T) {
+	durations := make([]time.Duration, 0, 20)
+	for i := 20; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	minimum, mean, p95, maximum := summarizeDurations(durations)
+
+	assert.Equal(t, time.Millisecond, minimum)
+	assert.Equal(t, 10500*time.Microsecond, mean)
+	assert.Equal(t, 19*time.Millisecond, p95)
+	assert.Equal(t, 20*time.Millisecond, maximum)
+}
+
+func TestTestUseCase_Bench_UsesLargestSample(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n0 0\n", "3\n"},
+		"sample-2": {"5 5\n7 1\n0 0\n", "10\n8\n"},
+	})
+	runner := &fakeSolutionRunner{}
+	uc := newTestTestUseCase(runner)
+
+	// When
+	report, err := uc.Bench(context.Background(), BenchOptions{Dir: dir, Runs: 3, TimeLimit: time.Second})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "sample-2", report.Input)
+	assert.Equal(t, 3, report.Runs)
+	assert.Len(t, runner.inputs, 3)
+	assert.Equal(t, 0, report.Failures)
+	assert.False(t, report.ExceedsTimeLimit())
+	assert.Len(t, report.Samples, 3)
+	assert.Equal(t, 3, report.Samples[2].Run)
+}
+
+func TestTestUseCase_Bench_Interrupted(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n0 0\n", "3\n"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc := newTestTestUseCase(&interruptingRunner{runsBeforeCancel: 4, cancel: cancel})
+
+	// When
+	report, err := uc.Bench(ctx, BenchOptions{Dir: dir, Runs: 10, TimeLimit: time.Second})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, report.Interrupted)
+	assert.Equal(t, 4, report.Runs)
+}
+
+func TestTimeHistogram(t *testing.T) {
+	// Given
+	samples := []BenchSample{
+		{Time: 10 * time.Millisecond},
+		{Time: 12 * time.Millisecond},
+		{Time: 14 * time.Millisecond},
+		{Time: 30 * time.Millisecond},
+		{Time: 50 * time.Millisecond},
+	}
+
+	// When
+	buckets := TimeHistogram(samples, 4)
+
+	// Then
+	assert.Equal(t, []HistogramBucket{
+		{From: 10 * time.Millisecond, To: 20 * time.Millisecond, Count: 3},
+		{From: 20 * time.Millisecond, To: 30 * time.Millisecond, Count: 0},
+		{From: 30 * time.Millisecond, To: 40 * time.Millisecond, Count: 1},
+		{From: 40 * time.Millisecond, To: 50 * time.Millisecond, Count: 1},
+	}, buckets)
+
+	same := TimeHistogram([]BenchSample{{Time: time.Second}, {Time: time.Second}}, 4)
+	assert.Equal(t, []HistogramBucket{{From: time.Second, To: time.Second, Count: 2}}, same)
+	assert.Empty(t, TimeHistogram(nil, 4))
+}
+
+func TestWriteBenchCSV(t *testing.T) {
+	// Given
+	samples := []BenchSample{
+		{Run: 1, Time: 1500 * time.Microsecond, MemoryKB: 2048},
+		{Run: 2, Time: 2 * time.Second, MemoryKB: 4096, Failed: true},
+	}
+	var out bytes.Buffer
+
+	// When
+	err := WriteBenchCSV(&out, samples)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "run,time_ms,memory_kb,failed\n"+
+		"1,1.500,2048,false\n"+
+		"2,2000.000,4096,true\n", out.String())
+}
+
+func TestBenchReport_ExceedsTimeLimit_SpeedFactor(t *testing.T) {
+	tests := []struct {
+		name        string
+		speedFactor float64
+		want        bool
+	}{
+		{name: "as fast as the judge", speedFactor: 1, want: true},
+		{name: "unknown speed", speedFactor: 0, want: true},
+		{name: "twice as slow as the judge", speedFactor: 2, want: false},
+	}
+
+	for _, tt := range tests {
+		report := &BenchReport{P95: 1500 * time.Millisecond, TimeLimit: time.Second, SpeedFactor: tt.speedFactor}
+		assert.Equal(t, tt.want, report.ExceedsTimeLimit(), tt.name)
+	}
+}
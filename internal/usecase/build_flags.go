@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// nativeExtensions are the extensions of C and C++ sources, which are built
+// with gcc or clang and accept extra flags and sanitizers
+var nativeExtensions = map[string]bool{"c": true, "cc": true, "cpp": true, "cxx": true}
+
+// sanitizerNames matches a comma separated list of sanitizers such as "address,undefined"
+var sanitizerNames = regexp.MustCompile(`^[a-z-]+(,[a-z-]+)*$`)
+
+// warningLine matches compiler diagnostics such as "main.cpp:3:5: warning: unused variable"
+var warningLine = regexp.MustCompile(`(?m)\bwarning:`)
+
+// buildFlags returns the flags appended to the build command of a source file
+// with the given extension
+func (uc *TestUseCase) buildFlags(ext, sanitize string) (string, error) {
+	var flags []string
+	if nativeExtensions[ext] && strings.TrimSpace(uc.settings.ExtraBuildFlags) != "" {
+		flags = append(flags, strings.TrimSpace(uc.settings.ExtraBuildFlags))
+	}
+
+	if sanitize != "" {
+		if !nativeExtensions[ext] {
+			return "", cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				fmt.Sprintf("sanitizers are only supported for C and C++, not .%s files", ext),
+				nil,
+			)
+		}
+		if !sanitizerNames.MatchString(sanitize) {
+			return "", cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				fmt.Sprintf("invalid sanitizer list '%s', e.g. use address,undefined", sanitize),
+				nil,
+			)
+		}
+		// Frame pointers and debug info give readable stack traces in the reports
+		flags = append(flags, "-fsanitize="+sanitize, "-g", "-fno-omit-frame-pointer")
+	}
+
+	return strings.Join(flags, " "), nil
+}
+
+// CountCompilerWarnings returns the number of warnings in the output of a build
+func CountCompilerWarnings(output string) int {
+	return len(warningLine.FindAllStringIndex(output, -1))
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+const (
+	// defaultCalibrationRuns is how often the workload runs; the fastest run counts
+	defaultCalibrationRuns = 5
+	// calibrationReference is how long the workload takes on a machine about
+	// as fast as the AOJ judge
+	calibrationReference = 150 * time.Millisecond
+)
+
+// CalibrateUseCase measures how fast this machine runs solutions compared to
+// the judge and stores the result as test.speed_factor in config.toml
+type CalibrateUseCase struct {
+	configPath string
+	benchmark  func() time.Duration
+	logger     *logger.Logger
+}
+
+// NewCalibrateUseCase creates a new CalibrateUseCase for the config file at
+// configPath that measures with benchmark, which returns the time of one run
+// of a workload that takes calibrationReference on the judge. A nil
+// benchmark runs the built-in workload
+func NewCalibrateUseCase(configPath string, benchmark func() time.Duration) *CalibrateUseCase {
+	if benchmark == nil {
+		benchmark = runCalibrationWorkload
+	}
+
+	return &CalibrateUseCase{
+		configPath: configPath,
+		benchmark:  benchmark,
+		logger:     logger.WithGroup("calibrate_usecase"),
+	}
+}
+
+// CalibrateOptions contains options for calibrating
+type CalibrateOptions struct {
+	Runs   int  // Optional: number of runs (defaults to 5)
+	DryRun bool // measure without saving the speed factor
+}
+
+// CalibrateResult describes a calibration
+type CalibrateResult struct {
+	Runs           int           `json:"runs"`
+	Fastest        time.Duration `json:"fastest"`
+	Reference      time.Duration `json:"reference"`
+	SpeedFactor    float64       `json:"speed_factor"`
+	PreviousFactor float64       `json:"previous_factor"`
+	ConfigPath     string        `json:"config_path"`
+	Saved          bool          `json:"saved"`
+}
+
+// Execute runs the workload several times and stores how many times slower
+// than the judge the fastest run was, rounded to two decimals
+func (uc *CalibrateUseCase) Execute(ctx context.Context, opts CalibrateOptions) (*CalibrateResult, error) {
+	runs := opts.Runs
+	if runs <= 0 {
+		runs = defaultCalibrationRuns
+	}
+
+	cfg, err := config.Load(uc.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Warm up caches and the CPU frequency before measuring
+	uc.benchmark()
+	var fastest time.Duration
+	for i := 0; i < runs; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		elapsed := uc.benchmark()
+		if i == 0 || elapsed < fastest {
+			fastest = elapsed
+		}
+	}
+
+	factor := math.Round(float64(fastest)/float64(calibrationReference)*100) / 100
+	result := &CalibrateResult{
+		Runs:           runs,
+		Fastest:        fastest,
+		Reference:      calibrationReference,
+		SpeedFactor:    max(factor, 0.01),
+		PreviousFactor: cfg.Test.SpeedFactor,
+		ConfigPath:     uc.configPath,
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	cfg.Test.SpeedFactor = result.SpeedFactor
+	if err := config.Save(cfg, uc.configPath); err != nil {
+		return nil, err
+	}
+	result.Saved = true
+
+	uc.logger.InfoContext(ctx, "speed factor calibrated", "fastest", fastest, "speed_factor", result.SpeedFactor)
+	return result, nil
+}
+
+// runCalibrationWorkload times a CPU and memory bound workload typical of
+// judge solutions: a sieve of Eratosthenes and sorting a million integers
+func runCalibrationWorkload() time.Duration {
+	start := time.Now()
+
+	const limit = 4_000_000
+	composite := make([]bool, limit+1)
+	for i := 2; i*i <= limit; i++ {
+		if composite[i] {
+			continue
+		}
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+	}
+
+	values := make([]uint64, 1_000_000)
+	x := uint64(88172645463325252)
+	for i := range values {
+		// xorshift64, so that every run sorts the same values
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		values[i] = x
+	}
+	slices.Sort(values)
+
+	return time.Since(start)
+}
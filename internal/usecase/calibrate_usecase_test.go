@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// fakeBenchmark returns the given times in turn, starting with the warm-up run
+func fakeBenchmark(times ...time.Duration) func() time.Duration {
+	return func() time.Duration {
+		next := times[0]
+		times = times[1:]
+		return next
+	}
+}
+
+func TestCalibrateUseCase_Execute(t *testing.T) {
+	// Given
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	assert.NoError(t, os.WriteFile(configPath, []byte("[test]\ntimeout = 5.0\n"), 0644))
+	uc := usecase.NewCalibrateUseCase(configPath,
+		fakeBenchmark(time.Second, 400*time.Millisecond, 300*time.Millisecond, 320*time.Millisecond))
+
+	// When
+	result, err := uc.Execute(context.Background(), usecase.CalibrateOptions{Runs: 3})
+
+	// Then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, 300*time.Millisecond, result.Fastest)
+	assert.Equal(t, 2.0, result.SpeedFactor)
+	assert.Equal(t, 1.0, result.PreviousFactor)
+	assert.True(t, result.Saved)
+
+	cfg, err := config.Load(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, cfg.Test.SpeedFactor)
+	assert.Equal(t, 5.0, cfg.Test.Timeout, "other settings are kept")
+}
+
+func TestCalibrateUseCase_Execute_DryRun(t *testing.T) {
+	// Given
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	uc := usecase.NewCalibrateUseCase(configPath,
+		fakeBenchmark(time.Second, 75*time.Millisecond, 90*time.Millisecond))
+
+	// When
+	result, err := uc.Execute(context.Background(), usecase.CalibrateOptions{Runs: 2, DryRun: true})
+
+	// Then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, 0.5, result.SpeedFactor)
+	assert.False(t, result.Saved)
+	_, statErr := os.Stat(configPath)
+	assert.True(t, os.IsNotExist(statErr), "config.toml was written in a dry run")
+}
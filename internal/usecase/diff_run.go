@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// DiffRunOptions contains options for comparing two solutions
+type DiffRunOptions struct {
+	Dir     string        // Optional: problem directory holding the samples and test/gen.toml (defaults to the current directory)
+	SourceA string        // first solution, usually the trusted one
+	SourceB string        // second solution, e.g. a faster rewrite
+	Case    int           // Optional: use only the sample with this number
+	Gen     int           // Optional: compare on this many inputs generated from test/gen.toml instead of the samples
+	Seed    uint64        // Optional: seed of the first generated input (defaults to a random one)
+	Timeout time.Duration // Optional: time limit per run (defaults to the problem's limit)
+}
+
+// DiffRunOutcome is the result of running one solution on one input
+type DiffRunOutcome struct {
+	Output   string        `json:"output"`
+	Stderr   string        `json:"stderr,omitempty"`
+	ExitCode int           `json:"exit_code"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+	Duration time.Duration `json:"duration"`
+	MemoryKB int64         `json:"memory_kb"`
+}
+
+// Failed returns true if the run crashed or was killed
+func (o DiffRunOutcome) Failed() bool {
+	return o.TimedOut || o.ExitCode != 0
+}
+
+// DiffRunCase compares both solutions on one input
+type DiffRunCase struct {
+	Name  string         `json:"name"`
+	Input string         `json:"input"`
+	A     DiffRunOutcome `json:"a"`
+	B     DiffRunOutcome `json:"b"`
+	Same  bool           `json:"same"` // both finished with the same output, ignoring trailing whitespace
+}
+
+// DiffRunReport holds the comparison of two solutions
+type DiffRunReport struct {
+	SourceA     string        `json:"source_a"`
+	SourceB     string        `json:"source_b"`
+	Cases       []DiffRunCase `json:"cases"`
+	Interrupted bool          `json:"interrupted,omitempty"`
+}
+
+// Mismatches returns the number of inputs on which the solutions differ
+func (r *DiffRunReport) Mismatches() int {
+	mismatches := 0
+	for _, c := range r.Cases {
+		if !c.Same {
+			mismatches++
+		}
+	}
+	return mismatches
+}
+
+// TotalTimes returns the running times of both solutions summed over all inputs
+func (r *DiffRunReport) TotalTimes() (a, b time.Duration) {
+	for _, c := range r.Cases {
+		a += c.A.Duration
+		b += c.B.Duration
+	}
+	return a, b
+}
+
+// diffRunInput is an input both solutions run on
+type diffRunInput struct {
+	name  string
+	input string
+}
+
+// DiffRun builds two solutions and runs both on the same inputs, the samples
+// or generated ones, comparing their outputs and running times. The solutions
+// are built and run one after the other, so that solutions in the same
+// directory may share the name of their executable
+func (uc *TestUseCase) DiffRun(ctx context.Context, opts DiffRunOptions) (*DiffRunReport, error) {
+	uc.logger.InfoContext(ctx, "starting solution comparison", "options", fmt.Sprintf("%+v", opts))
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	_, specA, err := uc.prepare(filepath.Dir(opts.SourceA), filepath.Base(opts.SourceA), "")
+	if err != nil {
+		return nil, err
+	}
+	_, specB, err := uc.prepare(filepath.Dir(opts.SourceB), filepath.Base(opts.SourceB), "")
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = uc.settings.Timeout
+		if timeLimit := uc.problemTimeLimit(ctx, dir); timeLimit > 0 {
+			timeout = time.Duration(float64(timeLimit) * uc.settings.TimeLimitFactor)
+		}
+	}
+
+	inputs, err := uc.diffRunInputs(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DiffRunReport{SourceA: opts.SourceA, SourceB: opts.SourceB}
+	outcomesA, err := uc.runAll(ctx, specA, inputs, timeout)
+	if err != nil {
+		return nil, err
+	}
+	outcomesB, err := uc.runAll(ctx, specB, inputs, timeout)
+	if err != nil {
+		return nil, err
+	}
+	// Compare the inputs that both solutions finished before an interruption
+	done := min(len(outcomesA), len(outcomesB))
+	report.Interrupted = done < len(inputs)
+
+	for i := 0; i < done; i++ {
+		a, b := outcomesA[i], outcomesB[i]
+		expected := model.NewTestCase(i+1, inputs[i].input, a.Output)
+		report.Cases = append(report.Cases, DiffRunCase{
+			Name:  inputs[i].name,
+			Input: inputs[i].input,
+			A:     a,
+			B:     b,
+			Same:  !a.Failed() && !b.Failed() && expected.CompareOutput(b.Output),
+		})
+	}
+
+	uc.logger.InfoContext(ctx, "solution comparison finished",
+		"inputs", len(report.Cases),
+		"mismatches", report.Mismatches(),
+		"interrupted", report.Interrupted)
+	return report, nil
+}
+
+// diffRunInputs returns the samples of the problem or the generated inputs
+func (uc *TestUseCase) diffRunInputs(dir string, opts DiffRunOptions) ([]diffRunInput, error) {
+	if opts.Gen <= 0 {
+		testCases, err := uc.loadTestCases(dir, opts.Case)
+		if err != nil {
+			return nil, err
+		}
+		if len(testCases) == 0 {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				"no sample inputs found; download them with 'aoj testcase' or use --gen",
+				nil,
+			)
+		}
+		inputs := make([]diffRunInput, len(testCases))
+		for i, tc := range testCases {
+			inputs[i] = diffRunInput{name: tc.GetDisplayName(), input: tc.Input()}
+		}
+		return inputs, nil
+	}
+
+	spec, err := config.LoadGenSpec(filepath.Join(dir, uc.settings.TestDir))
+	if err != nil {
+		return nil, err
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	inputs := make([]diffRunInput, opts.Gen)
+	for i := range inputs {
+		input, err := GenerateInput(spec, seed+uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = diffRunInput{name: fmt.Sprintf("gen-%d", seed+uint64(i)), input: input}
+	}
+	return inputs, nil
+}
+
+// runAll builds a solution and runs it on every input, stopping early when
+// the context is cancelled
+func (uc *TestUseCase) runAll(ctx context.Context, spec service.RunSpec, inputs []diffRunInput, timeout time.Duration) ([]DiffRunOutcome, error) {
+	build, err := uc.build(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if !build.Success {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("compile error in %s:\n%s", spec.SourceFile, build.Output),
+			nil,
+		)
+	}
+
+	outcomes := make([]DiffRunOutcome, 0, len(inputs))
+	for _, input := range inputs {
+		result, err := uc.runner.Run(ctx, spec, input.input, timeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return outcomes, nil
+			}
+			return nil, err
+		}
+		outcomes = append(outcomes, DiffRunOutcome{
+			Output:   result.Stdout,
+			Stderr:   result.Stderr,
+			ExitCode: result.ExitCode,
+			TimedOut: result.TimedOut,
+			Duration: result.Duration,
+			MemoryKB: result.MemoryKB,
+		})
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+)
+
+// bySourceRunner runs a fakeSolutionRunner for every solution except "wrong.cpp",
+// which prints a wrong answer for inputs starting with "5"
+type bySourceRunner struct {
+	fakeSolutionRunner
+	sources []string
+}
+
+func (r *bySourceRunner) Run(ctx context.Context, spec service.RunSpec, input string, timeout time.Duration) (*service.RunResult, error) {
+	r.sources = append(r.sources, spec.SourceFile)
+	result, err := r.fakeSolutionRunner.Run(ctx, spec, input, timeout)
+	if spec.SourceFile == "wrong.cpp" && input[0] == '5' {
+		result.Stdout = "0\n"
+	}
+	result.Duration = 10 * time.Millisecond
+	if spec.SourceFile == "slow.cpp" {
+		result.Duration = 100 * time.Millisecond
+	}
+	return result, err
+}
+
+func TestTestUseCase_DiffRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		sourceB        string
+		wantMismatches int
+	}{
+		{name: "same answers", sourceB: "main.cpp", wantMismatches: 0},
+		{name: "different answers", sourceB: "wrong.cpp", wantMismatches: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dir := t.TempDir()
+			writeSamples(t, dir, map[string][2]string{
+				"sample-1": {"1 2\n0 0\n", "3\n"},
+				"sample-2": {"5 5\n0 0\n", "10\n"},
+			})
+			for _, name := range []string{"slow.cpp", tt.sourceB} {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("int main() {}"), 0644))
+			}
+			runner := &bySourceRunner{}
+			uc := newTestTestUseCase(runner)
+
+			// When
+			report, err := uc.DiffRun(context.Background(), DiffRunOptions{
+				Dir:     dir,
+				SourceA: filepath.Join(dir, "slow.cpp"),
+				SourceB: filepath.Join(dir, tt.sourceB),
+			})
+
+			// Then
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Len(t, report.Cases, 2)
+			assert.Equal(t, tt.wantMismatches, report.Mismatches())
+			assert.True(t, report.Cases[0].Same)
+			assert.Equal(t, tt.wantMismatches == 0, report.Cases[1].Same)
+			assert.Equal(t, []string{"slow.cpp", "slow.cpp", tt.sourceB, tt.sourceB}, runner.sources, "each solution runs all inputs in turn")
+			totalA, totalB := report.TotalTimes()
+			assert.Equal(t, 200*time.Millisecond, totalA)
+			assert.Equal(t, 20*time.Millisecond, totalB)
+		})
+	}
+}
+
+func TestTestUseCase_DiffRun_GeneratedInputs(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "test"), 0755))
+	spec := "format = \"{a} {b}\\n0 0\"\n[[var]]\nname = \"a\"\ntype = \"int\"\nmin = 1\nmax = 9\n[[var]]\nname = \"b\"\ntype = \"int\"\nmin = 1\nmax = 9\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test", "gen.toml"), []byte(spec), 0644))
+	for _, name := range []string{"slow.cpp", "main.cpp"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("int main() {}"), 0644))
+	}
+	uc := newTestTestUseCase(&bySourceRunner{})
+
+	// When
+	report, err := uc.DiffRun(context.Background(), DiffRunOptions{
+		Dir:     dir,
+		SourceA: filepath.Join(dir, "slow.cpp"),
+		SourceB: filepath.Join(dir, "main.cpp"),
+		Gen:     5,
+		Seed:    100,
+	})
+
+	// Then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Len(t, report.Cases, 5)
+	assert.Equal(t, "gen-100", report.Cases[0].Name)
+	assert.Equal(t, "gen-104", report.Cases[4].Name)
+	assert.Equal(t, 0, report.Mismatches())
+}
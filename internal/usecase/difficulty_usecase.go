@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// DifficultyUseCase looks up the estimated difficulties of problems
+type DifficultyUseCase struct {
+	provider service.DifficultyProvider
+	logger   *logger.Logger
+}
+
+// NewDifficultyUseCase creates a new DifficultyUseCase. A nil provider means
+// no difficulty table is configured
+func NewDifficultyUseCase(provider service.DifficultyProvider) *DifficultyUseCase {
+	return &DifficultyUseCase{
+		provider: provider,
+		logger:   logger.WithGroup("difficulty_usecase"),
+	}
+}
+
+// DifficultyRange bounds estimated difficulties; a zero bound leaves that side open
+type DifficultyRange struct {
+	Min float64
+	Max float64
+}
+
+// IsSet returns true if either bound is set
+func (r DifficultyRange) IsSet() bool {
+	return r.Min > 0 || r.Max > 0
+}
+
+// Contains returns true if difficulty is within the bounds
+func (r DifficultyRange) Contains(difficulty float64) bool {
+	return (r.Min <= 0 || difficulty >= r.Min) && (r.Max <= 0 || difficulty <= r.Max)
+}
+
+// Table returns the difficulty of every known problem, keyed by problem ID
+func (uc *DifficultyUseCase) Table(ctx context.Context) (map[string]float64, error) {
+	if uc == nil || uc.provider == nil {
+		return nil, cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeInvalidInput, "no difficulty table is configured", nil),
+			"Set difficulty.url in config.toml to the URL or path of a JSON difficulty table",
+		)
+	}
+	difficulties, err := uc.provider.Difficulties(ctx)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read the difficulty table")
+	}
+	return difficulties, nil
+}
+
+// Lookup returns the known difficulties like Table, but is empty when no
+// table is configured or it cannot be read, for outputs that merely show them
+func (uc *DifficultyUseCase) Lookup(ctx context.Context) map[string]float64 {
+	if uc == nil || uc.provider == nil {
+		return map[string]float64{}
+	}
+	difficulties, err := uc.Table(ctx)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "difficulties are not available", "error", err)
+		return map[string]float64{}
+	}
+	return difficulties
+}
+
+// FormatDifficulty formats a difficulty without needless decimals, e.g. 400 or 2.5
+func FormatDifficulty(difficulty float64) string {
+	return strconv.FormatFloat(difficulty, 'f', -1, 64)
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// Check statuses reported by the doctor use case
+const (
+	CheckOK      = "ok"
+	CheckWarn    = "warn"
+	CheckFail    = "fail"
+	CheckSkipped = "skip"
+)
+
+// probeProblemID is a problem that always exists on AOJ, used to check API reachability
+const probeProblemID = "ITP1_1_A"
+
+// CheckResult holds the outcome of a single diagnostic check
+type CheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// DoctorReport holds the outcome of all diagnostic checks
+type DoctorReport struct {
+	Checks []CheckResult `json:"checks"`
+}
+
+// Failed returns true if any check failed
+func (r *DoctorReport) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == CheckFail {
+			return true
+		}
+	}
+	return false
+}
+
+// DoctorSettings describes the environment checked by the doctor use case
+type DoctorSettings struct {
+	ConfigPath string                            // configuration file to validate
+	ConfigDir  string                            // directory that must be writable
+	SourceFile string                            // default solution file; its tools are required
+	Languages  []LanguageCommand                 // tools of other languages are optional
+	LookPath   func(file string) (string, error) // defaults to exec.LookPath
+	Clock      clock.Clock                       // defaults to the system clock
+}
+
+// DoctorUseCase diagnoses the local environment and the connection to AOJ
+type DoctorUseCase struct {
+	authRepo    repository.AuthRepository
+	sessionRepo repository.SessionRepository
+	problemRepo repository.ProblemRepository
+	settings    DoctorSettings
+	logger      *logger.Logger
+}
+
+// NewDoctorUseCase creates a new DoctorUseCase
+func NewDoctorUseCase(
+	authRepo repository.AuthRepository,
+	sessionRepo repository.SessionRepository,
+	problemRepo repository.ProblemRepository,
+	settings DoctorSettings,
+) *DoctorUseCase {
+	if settings.LookPath == nil {
+		settings.LookPath = exec.LookPath
+	}
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+
+	return &DoctorUseCase{
+		authRepo:    authRepo,
+		sessionRepo: sessionRepo,
+		problemRepo: problemRepo,
+		settings:    settings,
+		logger:      logger.WithGroup("doctor_usecase"),
+	}
+}
+
+// Execute runs all diagnostic checks
+func (uc *DoctorUseCase) Execute(ctx context.Context) *DoctorReport {
+	uc.logger.InfoContext(ctx, "running diagnostics")
+
+	report := &DoctorReport{}
+	report.Checks = append(report.Checks,
+		uc.checkConfig(),
+		uc.checkConfigDir(),
+		uc.checkAPI(ctx),
+		uc.checkSession(ctx),
+	)
+	report.Checks = append(report.Checks, uc.checkTools()...)
+
+	uc.logger.InfoContext(ctx, "diagnostics finished", "failed", report.Failed())
+	return report
+}
+
+// checkConfig validates the configuration file
+func (uc *DoctorUseCase) checkConfig() CheckResult {
+	result := CheckResult{Name: "config"}
+
+	if _, err := os.Stat(uc.settings.ConfigPath); os.IsNotExist(err) {
+		result.Status = CheckOK
+		result.Detail = "no config file, using defaults"
+		return result
+	}
+
+	cfg, err := config.Load(uc.settings.ConfigPath)
+	if err == nil {
+		err = config.ValidateConfig(cfg)
+	}
+	if err != nil {
+		result.Status = CheckFail
+		result.Detail = err.Error()
+		result.Fix = fmt.Sprintf("fix or remove %s", uc.settings.ConfigPath)
+		return result
+	}
+
+	result.Status = CheckOK
+	result.Detail = uc.settings.ConfigPath
+	return result
+}
+
+// checkConfigDir verifies that the config directory is writable
+func (uc *DoctorUseCase) checkConfigDir() CheckResult {
+	result := CheckResult{Name: "config directory"}
+
+	file, err := os.CreateTemp(uc.settings.ConfigDir, ".doctor-*")
+	if err != nil {
+		result.Status = CheckFail
+		result.Detail = err.Error()
+		result.Fix = fmt.Sprintf("make %s writable, e.g. chmod u+w %s, or choose another directory with --config-dir", uc.settings.ConfigDir, uc.settings.ConfigDir)
+		return result
+	}
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+
+	result.Status = CheckOK
+	result.Detail = uc.settings.ConfigDir
+	return result
+}
+
+// checkAPI verifies that the AOJ API can be reached
+func (uc *DoctorUseCase) checkAPI(ctx context.Context) CheckResult {
+	result := CheckResult{Name: "AOJ API"}
+
+	if offline.IsOffline(ctx) {
+		result.Status = CheckSkipped
+		result.Detail = "offline mode"
+		return result
+	}
+
+	pid, err := model.NewProblemID(probeProblemID)
+	if err != nil {
+		result.Status = CheckFail
+		result.Detail = err.Error()
+		return result
+	}
+	if _, err := uc.problemRepo.GetByID(ctx, pid); err != nil {
+		result.Status = CheckFail
+		result.Detail = err.Error()
+		result.Fix = "check your internet connection and proxy settings"
+		return result
+	}
+
+	result.Status = CheckOK
+	result.Detail = "reachable"
+	return result
+}
+
+// checkSession verifies that a valid login session exists
+func (uc *DoctorUseCase) checkSession(ctx context.Context) CheckResult {
+	result := CheckResult{Name: "session"}
+
+	session, err := uc.sessionRepo.GetCurrent(ctx)
+	if err != nil || session == nil {
+		result.Status = CheckWarn
+		result.Detail = "not logged in"
+		result.Fix = "run 'aoj login' before submitting"
+		return result
+	}
+	if session.IsExpiredAt(uc.settings.Clock.Now()) {
+		result.Status = CheckFail
+		result.Detail = fmt.Sprintf("session of %s expired", session.Username())
+		result.Fix = "run 'aoj login' again"
+		return result
+	}
+
+	if offline.IsOffline(ctx) {
+		result.Status = CheckOK
+		result.Detail = fmt.Sprintf("logged in as %s (not verified with AOJ in offline mode)", session.Username())
+		return result
+	}
+
+	valid, err := uc.authRepo.ValidateSession(ctx, session)
+	if err != nil {
+		result.Status = CheckWarn
+		result.Detail = fmt.Sprintf("logged in as %s, but the session could not be verified: %s", session.Username(), err)
+		return result
+	}
+	if !valid {
+		result.Status = CheckFail
+		result.Detail = fmt.Sprintf("AOJ rejected the session of %s", session.Username())
+		result.Fix = "run 'aoj login' again"
+		return result
+	}
+
+	result.Status = CheckOK
+	result.Detail = fmt.Sprintf("logged in as %s", session.Username())
+	return result
+}
+
+// checkTools verifies that the compilers and interpreters of the configured languages are installed.
+// Missing tools of the default solution file's language fail; others only warn
+func (uc *DoctorUseCase) checkTools() []CheckResult {
+	defaultExt := strings.TrimPrefix(strings.ToLower(filepath.Ext(uc.settings.SourceFile)), ".")
+
+	seen := make(map[string]int)
+	var results []CheckResult
+	for _, lang := range uc.settings.Languages {
+		required := strings.EqualFold(lang.Extension, defaultExt)
+		for _, line := range []string{lang.BuildCommand, lang.RunCommand} {
+			tool := commandName(line)
+			if tool == "" {
+				continue
+			}
+			if i, ok := seen[tool]; ok {
+				// A tool shared by several languages is required if any of them is
+				if required && results[i].Status == CheckWarn {
+					results[i].Status = CheckFail
+				}
+				continue
+			}
+
+			seen[tool] = len(results)
+			results = append(results, uc.checkTool(tool, required))
+		}
+	}
+	return results
+}
+
+// checkTool verifies that a single tool is on the PATH
+func (uc *DoctorUseCase) checkTool(tool string, required bool) CheckResult {
+	result := CheckResult{Name: "tool " + tool}
+
+	path, err := uc.settings.LookPath(tool)
+	if err != nil {
+		result.Status = CheckWarn
+		if required {
+			result.Status = CheckFail
+		}
+		result.Detail = "not found in PATH"
+		result.Fix = fmt.Sprintf("install %s or change the language commands in the config", tool)
+		return result
+	}
+
+	result.Status = CheckOK
+	result.Detail = path
+	return result
+}
+
+// commandName returns the program a command line runs, or "" for programs built
+// in the problem directory such as ./a.out
+func commandName(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	name := fields[0]
+	if strings.Contains(name, "/") || strings.Contains(name, `\`) || strings.Contains(name, sourceFilePlaceholder) {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// stubProblemRepository answers GetByID with a fixed error
+type stubProblemRepository struct {
+	repository.ProblemRepository
+	getErr error
+}
+
+func (r *stubProblemRepository) GetByID(_ context.Context, id model.ProblemID) (*entity.Problem, error) {
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	return entity.NewProblem(id, "", "", time.Second, 0, "", 0), nil
+}
+
+func newTestDoctorUseCase(t *testing.T, authRepo *MockAuthRepository, sessionRepo *MockSessionRepository, problemRepo repository.ProblemRepository) *DoctorUseCase {
+	t.Helper()
+	dir := t.TempDir()
+	return NewDoctorUseCase(authRepo, sessionRepo, problemRepo, DoctorSettings{
+		ConfigPath: filepath.Join(dir, "config.toml"),
+		ConfigDir:  dir,
+		SourceFile: "main.cpp",
+		Languages: []LanguageCommand{
+			{Extension: "cpp", BuildCommand: "g++ {file}", RunCommand: "./a.out"},
+			{Extension: "py", RunCommand: "python3 {file}"},
+		},
+		LookPath: func(file string) (string, error) {
+			if file == "g++" {
+				return "/usr/bin/g++", nil
+			}
+			return "", errors.New("not found")
+		},
+	})
+}
+
+func findCheck(t *testing.T, report *DoctorReport, name string) CheckResult {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("check %q not found", name)
+	return CheckResult{}
+}
+
+func TestDoctorUseCase_Execute_Healthy(t *testing.T) {
+	// Given
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	authRepo := &MockAuthRepository{}
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	authRepo.On("ValidateSession", mock.Anything, session).Return(true, nil)
+	uc := newTestDoctorUseCase(t, authRepo, sessionRepo, &stubProblemRepository{})
+
+	// When
+	report := uc.Execute(context.Background())
+
+	// Then
+	assert.False(t, report.Failed())
+	assert.Equal(t, CheckOK, findCheck(t, report, "config").Status)
+	assert.Equal(t, CheckOK, findCheck(t, report, "config directory").Status)
+	assert.Equal(t, CheckOK, findCheck(t, report, "AOJ API").Status)
+	assert.Equal(t, "logged in as user1", findCheck(t, report, "session").Detail)
+	assert.Equal(t, CheckOK, findCheck(t, report, "tool g++").Status)
+	// python3 is not the default language, so it only warns
+	assert.Equal(t, CheckWarn, findCheck(t, report, "tool python3").Status)
+	authRepo.AssertExpectations(t)
+}
+
+func TestDoctorUseCase_Execute_Failures(t *testing.T) {
+	// Given
+	authRepo := &MockAuthRepository{}
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(nil, errors.New("no session"))
+	uc := newTestDoctorUseCase(t, authRepo, sessionRepo, &stubProblemRepository{getErr: errors.New("no such host")})
+	uc.settings.SourceFile = "main.py"
+	assert.NoError(t, os.WriteFile(uc.settings.ConfigPath, []byte("[test\ntimeout = "), 0644))
+
+	// When
+	report := uc.Execute(context.Background())
+
+	// Then
+	assert.True(t, report.Failed())
+	assert.Equal(t, CheckFail, findCheck(t, report, "config").Status)
+	assert.Equal(t, CheckFail, findCheck(t, report, "AOJ API").Status)
+	assert.Equal(t, CheckWarn, findCheck(t, report, "session").Status)
+	assert.Equal(t, CheckFail, findCheck(t, report, "tool python3").Status)
+	assert.NotEmpty(t, findCheck(t, report, "tool python3").Fix)
+}
+
+func TestDoctorUseCase_Execute_Offline(t *testing.T) {
+	// Given
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	authRepo := &MockAuthRepository{}
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	uc := newTestDoctorUseCase(t, authRepo, sessionRepo, &stubProblemRepository{getErr: errors.New("unreachable")})
+
+	// When
+	report := uc.Execute(offline.WithOffline(context.Background(), true))
+
+	// Then
+	assert.Equal(t, CheckSkipped, findCheck(t, report, "AOJ API").Status)
+	assert.Equal(t, CheckOK, findCheck(t, report, "session").Status)
+	authRepo.AssertNotCalled(t, "ValidateSession", mock.Anything, mock.Anything)
+}
+
+func TestCommandName(t *testing.T) {
+	assert.Equal(t, "g++", commandName("g++ -std=c++17 -O2 -o a.out {file}"))
+	assert.Equal(t, "python3", commandName("python3 {file}"))
+	assert.Equal(t, "", commandName("./a.out"))
+	assert.Equal(t, "", commandName(""))
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// Archive formats of aoj export
+const (
+	ExportFormatDir = "dir"
+	ExportFormatZip = "zip"
+)
+
+// hashCommentExtensions are the languages whose comments start with # rather than //
+var hashCommentExtensions = map[string]bool{"py": true, "rb": true}
+
+// ExportUseCase collects accepted solutions from the local submission history
+type ExportUseCase struct {
+	submissionRepo repository.SubmissionRepository
+	problemRepo    repository.ProblemRepository
+	logger         *logger.Logger
+}
+
+// NewExportUseCase creates a new ExportUseCase. problemRepo provides the
+// problem titles and may be nil
+func NewExportUseCase(submissionRepo repository.SubmissionRepository, problemRepo repository.ProblemRepository) *ExportUseCase {
+	return &ExportUseCase{
+		submissionRepo: submissionRepo,
+		problemRepo:    problemRepo,
+		logger:         logger.WithGroup("export_usecase"),
+	}
+}
+
+// ExportOptions contains options for exporting solutions
+type ExportOptions struct {
+	Format string // ExportFormatDir or ExportFormatZip
+	Dest   string // directory, or zip file; .zip is appended if missing
+	All    bool   // export every accepted submission instead of the latest per problem
+}
+
+// ExportedSolution describes a solution written to the archive
+type ExportedSolution struct {
+	ProblemID string `json:"problem_id"`
+	Language  string `json:"language"`
+	Path      string `json:"path"` // slash-separated path inside the archive
+}
+
+// ExportResult describes the written archive
+type ExportResult struct {
+	Dest      string             `json:"dest"`
+	Solutions []ExportedSolution `json:"solutions"`
+}
+
+// exportFile is a file of the archive
+type exportFile struct {
+	path    string
+	content []byte
+	modTime time.Time
+}
+
+// Export writes the accepted solutions of the local history to a directory or
+// zip archive, one directory per problem. Each solution starts with a comment
+// holding its metadata as YAML front matter
+func (uc *ExportUseCase) Export(ctx context.Context, opts ExportOptions) (*ExportResult, error) {
+	if opts.Format == "" {
+		opts.Format = ExportFormatDir
+	}
+	if opts.Format != ExportFormatDir && opts.Format != ExportFormatZip {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("unknown export format '%s'. Available: %s, %s", opts.Format, ExportFormatDir, ExportFormatZip),
+			nil,
+		)
+	}
+	if opts.Dest == "" {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "export destination is required", nil)
+	}
+	if opts.Format == ExportFormatZip && !strings.EqualFold(filepath.Ext(opts.Dest), ".zip") {
+		opts.Dest += ".zip"
+	}
+
+	accepted := entity.StatusAccepted
+	submissions, err := uc.submissionRepo.Search(ctx, repository.SubmissionSearchCriteria{Status: &accepted})
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+	submissions = selectExportedSubmissions(submissions, opts.All)
+	if len(submissions) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no accepted submissions in the local history",
+			nil,
+		)
+	}
+
+	result := &ExportResult{Dest: opts.Dest}
+	titles := uc.problemTitles(ctx, submissions)
+	var files []exportFile
+	used := make(map[string]bool)
+	for _, submission := range submissions {
+		problemID := submission.ProblemID().String()
+
+		ext := exportExtension(submission.Language())
+		name := sourceFileFor(InitLanguage{Extension: ext}, "main.txt")
+		path := problemID + "/" + name
+		if used[path] {
+			// Older accepted submissions of the same problem with --all
+			path = problemID + "/" + strings.TrimSuffix(name, "."+ext) + "-" + exportSubmissionID(submission) + "." + ext
+		}
+		used[path] = true
+
+		files = append(files, exportFile{
+			path:    path,
+			content: []byte(withFrontMatter(submission, titles[problemID], ext)),
+			modTime: submission.SubmittedAt(),
+		})
+		result.Solutions = append(result.Solutions, ExportedSolution{
+			ProblemID: problemID,
+			Language:  submission.Language(),
+			Path:      path,
+		})
+	}
+
+	if opts.Format == ExportFormatZip {
+		err = writeZipArchive(opts.Dest, files)
+	} else {
+		err = writeDirArchive(opts.Dest, files)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "exported solutions", "count", len(files), "dest", opts.Dest, "format", opts.Format)
+	return result, nil
+}
+
+// selectExportedSubmissions orders submissions by problem, newest first, and
+// keeps only the newest of each problem unless all is set
+func selectExportedSubmissions(submissions []*entity.Submission, all bool) []*entity.Submission {
+	sort.SliceStable(submissions, func(i, j int) bool {
+		pi, pj := submissions[i].ProblemID().String(), submissions[j].ProblemID().String()
+		if pi != pj {
+			return pi < pj
+		}
+		return submissions[i].SubmittedAt().After(submissions[j].SubmittedAt())
+	})
+	if all {
+		return submissions
+	}
+
+	selected := make([]*entity.Submission, 0, len(submissions))
+	for _, submission := range submissions {
+		if n := len(selected); n > 0 && selected[n-1].ProblemID().Equals(submission.ProblemID()) {
+			continue
+		}
+		selected = append(selected, submission)
+	}
+	return selected
+}
+
+// problemTitles returns the titles of the submitted problems, fetched
+// concurrently. Unavailable titles are ""
+func (uc *ExportUseCase) problemTitles(ctx context.Context, submissions []*entity.Submission) map[string]string {
+	titles := make(map[string]string)
+	if uc.problemRepo == nil {
+		return titles
+	}
+
+	var ids []model.ProblemID
+	for _, submission := range submissions {
+		if _, ok := titles[submission.ProblemID().String()]; !ok {
+			titles[submission.ProblemID().String()] = ""
+			ids = append(ids, submission.ProblemID())
+		}
+	}
+
+	problems, err := uc.problemRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		// One unavailable problem fails the batch; fetch the others one by one
+		uc.logger.DebugContext(ctx, "failed to fetch problems together", "error", err)
+		for _, id := range ids {
+			titles[id.String()] = uc.problemTitle(ctx, id)
+		}
+		return titles
+	}
+	for _, problem := range problems {
+		if problem != nil {
+			titles[problem.ID().String()] = problem.Title()
+		}
+	}
+	return titles
+}
+
+// problemTitle returns the title of the problem, or "" if it is unavailable
+func (uc *ExportUseCase) problemTitle(ctx context.Context, id model.ProblemID) string {
+	problem, err := uc.problemRepo.GetByID(ctx, id)
+	if err != nil {
+		uc.logger.DebugContext(ctx, "problem title unavailable", "problem_id", id.String(), "error", err)
+		return ""
+	}
+	return problem.Title()
+}
+
+// exportExtension returns the file extension for an AOJ language name
+func exportExtension(lang string) string {
+	if ext, ok := language.ExtensionOf(lang); ok {
+		return ext
+	}
+	return "txt"
+}
+
+// exportSubmissionID returns the judge's ID of the submission, or the local one
+func exportSubmissionID(submission *entity.Submission) string {
+	if submission.JudgeID() != "" {
+		return submission.JudgeID()
+	}
+	return submission.ID().String()
+}
+
+// withFrontMatter prepends the metadata of the submission to its source code
+// as YAML front matter inside line comments
+func withFrontMatter(submission *entity.Submission, title, ext string) string {
+	lines := []string{"---", "problem: " + submission.ProblemID().String()}
+	if title != "" {
+		lines = append(lines, "title: "+yamlString(title))
+	}
+	lines = append(lines,
+		"url: "+aojProblemURL+submission.ProblemID().String(),
+		"language: "+yamlString(submission.Language()),
+		"verdict: AC",
+	)
+	if submission.Time() > 0 {
+		lines = append(lines, fmt.Sprintf("time: %.2fs", submission.Time().Seconds()))
+	}
+	if submission.Memory() > 0 {
+		lines = append(lines, fmt.Sprintf("memory: %d KB", submission.Memory()))
+	}
+	lines = append(lines,
+		"submitted_at: "+submission.SubmittedAt().Format(time.RFC3339),
+		"submission_id: "+yamlString(exportSubmissionID(submission)),
+		"---",
+	)
+
+	prefix := "// "
+	if hashCommentExtensions[ext] {
+		prefix = "# "
+	}
+	var header strings.Builder
+	for _, line := range lines {
+		header.WriteString(prefix + line + "\n")
+	}
+
+	source := submission.SourceCode()
+	if ext == "php" && strings.HasPrefix(source, "<?php") {
+		// Text before the opening tag would be printed as output
+		first, rest, _ := strings.Cut(source, "\n")
+		return first + "\n" + header.String() + rest
+	}
+	if ext == "txt" {
+		return source
+	}
+	return header.String() + source
+}
+
+// yamlString quotes s if it could be misread as YAML syntax
+func yamlString(s string) string {
+	if strings.ContainsAny(s, ":#'\"{}[],&*!|>%@`") || strings.TrimSpace(s) != s {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// writeDirArchive writes the files below dest
+func writeDirArchive(dest string, files []exportFile) error {
+	for _, file := range files {
+		path := filepath.Join(dest, filepath.FromSlash(file.path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return cerrors.Wrap(err, "failed to create export directory")
+		}
+		trace.File("write", path)
+		if err := os.WriteFile(path, file.content, 0644); err != nil {
+			return cerrors.Wrap(err, "failed to write exported solution")
+		}
+	}
+	return nil
+}
+
+// writeZipArchive writes the files to a new zip archive at dest
+func writeZipArchive(dest string, files []exportFile) error {
+	if dir := filepath.Dir(dest); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return cerrors.Wrap(err, "failed to create export directory")
+		}
+	}
+	trace.File("write", dest)
+	out, err := os.Create(dest)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create zip archive")
+	}
+
+	archive := zip.NewWriter(out)
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: file.path, Method: zip.Deflate, Modified: file.modTime})
+		if err == nil {
+			_, err = w.Write(file.content)
+		}
+		if err != nil {
+			_ = out.Close()
+			return cerrors.Wrap(err, "failed to write zip archive")
+		}
+	}
+	if err := archive.Close(); err != nil {
+		_ = out.Close()
+		return cerrors.Wrap(err, "failed to write zip archive")
+	}
+	if err := out.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to write zip archive")
+	}
+	return nil
+}
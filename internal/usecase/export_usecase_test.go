@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// acceptedSubmission returns an accepted submission judged with the given ID
+func acceptedSubmission(problemID, language, source, judgeID string, submittedAt time.Time) *entity.Submission {
+	submission := entity.NewSubmissionAt(model.MustNewSubmissionID(judgeID), model.MustNewProblemID(problemID), language, source, submittedAt)
+	submission.SetJudgeID(judgeID)
+	submission.UpdateResult(entity.StatusAccepted, 100, 10*time.Millisecond, 1024, "")
+	return submission
+}
+
+// newExportTestUseCase serves the submissions as the accepted history
+func newExportTestUseCase(submissions ...*entity.Submission) *ExportUseCase {
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Search", mock.Anything, mock.Anything).Return(submissions, nil)
+	return NewExportUseCase(submissionRepo, &sampleProblemRepository{})
+}
+
+func TestExportUseCase_Export_Dir(t *testing.T) {
+	// Given
+	submittedAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	dest := filepath.Join(t.TempDir(), "archive")
+	uc := newExportTestUseCase(
+		acceptedSubmission("ITP1_1_A", "C++17", "int main() {}\n", "100", submittedAt),
+		acceptedSubmission("ITP1_1_A", "C++17", "old\n", "90", submittedAt.Add(-time.Hour)),
+		acceptedSubmission("ITP1_1_B", "Python3", "print(1)\n", "101", submittedAt),
+		acceptedSubmission("ITP1_1_C", "JAVA", "class Main {}\n", "102", submittedAt),
+	)
+
+	// When
+	result, err := uc.Export(context.Background(), ExportOptions{Dest: dest})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []ExportedSolution{
+		{ProblemID: "ITP1_1_A", Language: "C++17", Path: "ITP1_1_A/main.cpp"},
+		{ProblemID: "ITP1_1_B", Language: "Python3", Path: "ITP1_1_B/main.py"},
+		{ProblemID: "ITP1_1_C", Language: "JAVA", Path: "ITP1_1_C/Main.java"},
+	}, result.Solutions)
+
+	cpp, err := os.ReadFile(filepath.Join(dest, "ITP1_1_A", "main.cpp"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// ---\n"+
+		"// problem: ITP1_1_A\n"+
+		"// title: Hello World\n"+
+		"// url: https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n"+
+		"// language: C++17\n"+
+		"// verdict: AC\n"+
+		"// time: 0.01s\n"+
+		"// memory: 1024 KB\n"+
+		"// submitted_at: 2026-04-01T09:00:00Z\n"+
+		"// submission_id: 100\n"+
+		"// ---\n"+
+		"int main() {}\n", string(cpp))
+	py, err := os.ReadFile(filepath.Join(dest, "ITP1_1_B", "main.py"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(py), "# language: Python3\n")
+}
+
+func TestExportUseCase_Export_ZipAll(t *testing.T) {
+	// Given
+	submittedAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	dest := filepath.Join(t.TempDir(), "solutions")
+	uc := newExportTestUseCase(
+		acceptedSubmission("ITP1_1_A", "C++17", "new\n", "100", submittedAt),
+		acceptedSubmission("ITP1_1_A", "C++17", "old\n", "90", submittedAt.Add(-time.Hour)),
+	)
+
+	// When
+	result, err := uc.Export(context.Background(), ExportOptions{Format: ExportFormatZip, Dest: dest, All: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, dest+".zip", result.Dest)
+	archive, err := zip.OpenReader(dest + ".zip")
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	contents := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		_ = r.Close()
+		contents[file.Name] = string(content)
+	}
+	assert.Len(t, contents, 2)
+	assert.Contains(t, contents["ITP1_1_A/main.cpp"], "new\n")
+	assert.Contains(t, contents["ITP1_1_A/main-90.cpp"], "old\n")
+}
+
+func TestExportUseCase_Export_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     ExportOptions
+		wantCode cerrors.ErrorCode
+	}{
+		{name: "unknown format", opts: ExportOptions{Format: "tar", Dest: "archive"}, wantCode: cerrors.CodeInvalidInput},
+		{name: "no accepted submissions", opts: ExportOptions{Dest: "archive"}, wantCode: cerrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newExportTestUseCase()
+
+			_, err := uc.Export(context.Background(), tt.opts)
+
+			assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+		})
+	}
+}
+
+func TestWithFrontMatter_PHP(t *testing.T) {
+	submission := acceptedSubmission("ITP1_1_A", "PHP", "<?php\necho 1;\n", "100", time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC))
+
+	source := withFrontMatter(submission, "", "php")
+
+	assert.Regexp(t, `^<\?php\n// ---\n`, source)
+	assert.Contains(t, source, "// ---\necho 1;\n")
+}
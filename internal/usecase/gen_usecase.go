@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+const (
+	// defaultAlphabet is used for strings without an alphabet
+	defaultAlphabet = "abcdefghijklmnopqrstuvwxyz"
+	// maxGeneratedItems caps the length of arrays, strings and edge lists
+	maxGeneratedItems = 10_000_000
+)
+
+// genPlaceholder matches the {name} placeholders of a generator format
+var genPlaceholder = regexp.MustCompile(`\{[a-z_][a-z0-9_]*\}`)
+
+// GenUseCase generates random inputs from the spec in test/gen.toml
+type GenUseCase struct {
+	testDir string
+	logger  *logger.Logger
+}
+
+// NewGenUseCase creates a new GenUseCase for problems whose test cases are in testDir
+func NewGenUseCase(testDir string) *GenUseCase {
+	if testDir == "" {
+		testDir = DefaultInitLayout().TestDir
+	}
+	return &GenUseCase{
+		testDir: testDir,
+		logger:  logger.WithGroup("gen_usecase"),
+	}
+}
+
+// GenOptions contains options for generating inputs
+type GenOptions struct {
+	Dir    string // Optional: problem directory (defaults to the current directory)
+	Seed   uint64 // Optional: seed of the first input (defaults to a random one)
+	Count  int    // Optional: number of inputs (defaults to 1)
+	OutDir string // Optional: write the inputs to gen-<seed>.in files in this directory
+}
+
+// GeneratedInput is one generated input
+type GeneratedInput struct {
+	Seed    uint64 `json:"seed"`
+	Content string `json:"content"`
+	Path    string `json:"path,omitempty"` // empty if the input was not written to a file
+}
+
+// Execute generates Count inputs with consecutive seeds, so that any of them
+// can be generated again with its seed
+func (uc *GenUseCase) Execute(ctx context.Context, opts GenOptions) ([]GeneratedInput, error) {
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	count := max(opts.Count, 1)
+	seed := opts.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
+	spec, err := config.LoadGenSpec(filepath.Join(dir, uc.testDir))
+	if err != nil {
+		return nil, err
+	}
+	if opts.OutDir != "" {
+		if err := os.MkdirAll(opts.OutDir, 0755); err != nil {
+			return nil, cerrors.Wrap(err, "failed to create "+opts.OutDir)
+		}
+	}
+
+	inputs := make([]GeneratedInput, 0, count)
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return inputs, err
+		}
+		input := GeneratedInput{Seed: seed + uint64(i)}
+		input.Content, err = GenerateInput(spec, input.Seed)
+		if err != nil {
+			return nil, err
+		}
+		if opts.OutDir != "" {
+			input.Path = filepath.Join(opts.OutDir, fmt.Sprintf("gen-%d%s", input.Seed, sampleInputExtension))
+			trace.File("write", input.Path)
+			if err := os.WriteFile(input.Path, []byte(input.Content), 0644); err != nil {
+				return nil, cerrors.Wrap(err, "failed to write "+input.Path)
+			}
+		}
+		inputs = append(inputs, input)
+	}
+
+	uc.logger.InfoContext(ctx, "inputs generated", "count", len(inputs), "seed", seed)
+	return inputs, nil
+}
+
+// GenerateInput generates one input from spec. The same seed always gives
+// the same input
+func GenerateInput(spec *config.GenSpec, seed uint64) (string, error) {
+	g := &generator{
+		rng:    rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+		ints:   make(map[string]int64),
+		values: make(map[string]string),
+	}
+	for _, v := range spec.Vars {
+		text, err := g.generate(v)
+		if err != nil {
+			return "", cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				fmt.Sprintf("%s: var %s: %s", config.GenSpecFile, v.Name, err),
+				nil,
+			)
+		}
+		g.values[v.Name] = text
+	}
+
+	if spec.Format == "" {
+		var b strings.Builder
+		for _, v := range spec.Vars {
+			b.WriteString(g.values[v.Name] + "\n")
+		}
+		return b.String(), nil
+	}
+
+	var unknown string
+	output := genPlaceholder.ReplaceAllStringFunc(spec.Format, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		value, ok := g.values[name]
+		if !ok && unknown == "" {
+			unknown = name
+		}
+		return value
+	})
+	if unknown != "" {
+		return "", cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("%s: format uses {%s}, which is not a var", config.GenSpecFile, unknown),
+			nil,
+		)
+	}
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	return output, nil
+}
+
+// generator holds the state of generating one input
+type generator struct {
+	rng    *rand.Rand
+	ints   map[string]int64  // int variables, which later values can refer to
+	values map[string]string // formatted variables
+}
+
+// generate returns the text of one variable
+func (g *generator) generate(v config.GenVar) (string, error) {
+	switch v.Type {
+	case config.GenTypeInt:
+		low, high, err := g.bounds(v)
+		if err != nil {
+			return "", err
+		}
+		value := g.between(low, high)
+		g.ints[v.Name] = value
+		return strconv.FormatInt(value, 10), nil
+	case config.GenTypeArray:
+		return g.array(v)
+	case config.GenTypePermutation:
+		n, err := g.length(v.Length)
+		if err != nil {
+			return "", err
+		}
+		values := make([]int64, n)
+		for i, p := range g.rng.Perm(n) {
+			values[i] = int64(p + 1)
+		}
+		return joinInts(values, separator(v)), nil
+	case config.GenTypeString:
+		return g.string(v)
+	case config.GenTypeGraph:
+		return g.graph(v)
+	}
+	return "", fmt.Errorf("unknown type %q", v.Type)
+}
+
+// bounds resolves the inclusive range of a variable
+func (g *generator) bounds(v config.GenVar) (int64, int64, error) {
+	low, err := v.Min.Resolve(g.ints)
+	if err != nil {
+		return 0, 0, err
+	}
+	high, err := v.Max.Resolve(g.ints)
+	if err != nil {
+		return 0, 0, err
+	}
+	if low > high {
+		return 0, 0, fmt.Errorf("min %d is greater than max %d", low, high)
+	}
+	return low, high, nil
+}
+
+// length resolves a length and checks that it is reasonable
+func (g *generator) length(value config.GenValue) (int, error) {
+	n, err := value.Resolve(g.ints)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > maxGeneratedItems {
+		return 0, fmt.Errorf("length %d is not between 0 and %d", n, maxGeneratedItems)
+	}
+	return int(n), nil
+}
+
+// between returns a uniformly random integer in [low, high]
+func (g *generator) between(low, high int64) int64 {
+	span := uint64(high-low) + 1
+	if span == 0 {
+		// The range covers every int64
+		return int64(g.rng.Uint64())
+	}
+	return low + int64(g.rng.Uint64N(span))
+}
+
+// array generates integers between min and max
+func (g *generator) array(v config.GenVar) (string, error) {
+	n, err := g.length(v.Length)
+	if err != nil {
+		return "", err
+	}
+	low, high, err := g.bounds(v)
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]int64, 0, n)
+	if v.Distinct {
+		if n > 0 && uint64(high-low) < uint64(n-1) {
+			return "", fmt.Errorf("cannot pick %d distinct values between %d and %d", n, low, high)
+		}
+		seen := make(map[int64]bool, n)
+		for len(values) < n {
+			value := g.between(low, high)
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			values = append(values, g.between(low, high))
+		}
+	}
+	if v.Sorted {
+		slices.Sort(values)
+	}
+	return joinInts(values, separator(v)), nil
+}
+
+// string generates characters of the alphabet
+func (g *generator) string(v config.GenVar) (string, error) {
+	n, err := g.length(v.Length)
+	if err != nil {
+		return "", err
+	}
+	alphabet := []rune(v.Alphabet)
+	if len(alphabet) == 0 {
+		alphabet = []rune(defaultAlphabet)
+	}
+
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteRune(alphabet[g.rng.IntN(len(alphabet))])
+	}
+	return b.String(), nil
+}
+
+// graph generates a simple graph on vertices 1..n as one "u v" or "u v w"
+// line per edge. Trees and connected graphs start from a random spanning tree
+func (g *generator) graph(v config.GenVar) (string, error) {
+	n, err := g.length(v.Vertices)
+	if err != nil {
+		return "", err
+	}
+	m := max(n-1, 0)
+	if !v.Tree {
+		if m, err = g.length(v.Edges); err != nil {
+			return "", err
+		}
+	}
+	maxEdges := int64(n) * int64(n-1)
+	if !v.Directed {
+		maxEdges /= 2
+	}
+	switch {
+	case int64(m) > maxEdges:
+		return "", fmt.Errorf("a simple graph with %d vertices has at most %d edges, not %d", n, maxEdges, m)
+	case (v.Tree || v.Connected) && n > 0 && m < n-1:
+		return "", fmt.Errorf("a connected graph with %d vertices needs at least %d edges, not %d", n, n-1, m)
+	}
+
+	type edge struct{ from, to int }
+	edges := make([]edge, 0, m)
+	seen := make(map[edge]bool, m)
+	add := func(from, to int) bool {
+		key := edge{from, to}
+		if !v.Directed && from > to {
+			key = edge{to, from}
+		}
+		if from == to || seen[key] {
+			return false
+		}
+		seen[key] = true
+		edges = append(edges, edge{from, to})
+		return true
+	}
+
+	if v.Tree || v.Connected {
+		// Attach each vertex to an earlier one of a random order
+		order := g.rng.Perm(n)
+		for i := 1; i < n; i++ {
+			add(order[g.rng.IntN(i)]+1, order[i]+1)
+		}
+	}
+	for len(edges) < m {
+		add(g.rng.IntN(n)+1, g.rng.IntN(n)+1)
+	}
+	g.rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+
+	var low, high int64
+	if v.Weighted {
+		if low, high, err = g.bounds(v); err != nil {
+			return "", err
+		}
+	}
+	lines := make([]string, len(edges))
+	for i, e := range edges {
+		lines[i] = fmt.Sprintf("%d %d", e.from, e.to)
+		if v.Weighted {
+			lines[i] += " " + strconv.FormatInt(g.between(low, high), 10)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// separator returns what separates array elements
+func separator(v config.GenVar) string {
+	if v.Separator == "" {
+		return " "
+	}
+	return v.Separator
+}
+
+// joinInts formats integers separated by sep
+func joinInts(values []int64, sep string) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.FormatInt(value, 10)
+	}
+	return strings.Join(parts, sep)
+}
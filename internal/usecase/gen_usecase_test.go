@@ -0,0 +1,286 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// writeGenSpec writes test/gen.toml below dir
+func writeGenSpec(t *testing.T, dir, content string) {
+	t.Helper()
+	testDir := filepath.Join(dir, "test")
+	assert.NoError(t, os.MkdirAll(testDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(testDir, config.GenSpecFile), []byte(content), 0644))
+}
+
+// loadGenSpec writes and loads a spec
+func loadGenSpec(t *testing.T, content string) *config.GenSpec {
+	t.Helper()
+	dir := t.TempDir()
+	writeGenSpec(t, dir, content)
+	spec, err := config.LoadGenSpec(filepath.Join(dir, "test"))
+	if err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	return spec
+}
+
+// parseInts parses space separated integers
+func parseInts(t *testing.T, line string) []int64 {
+	t.Helper()
+	var values []int64
+	for _, field := range strings.Fields(line) {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			t.Fatalf("not an integer: %q", field)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func TestGenerateInput_ArraysAndStrings(t *testing.T) {
+	// Given
+	spec := loadGenSpec(t, `format = "{n}\n{a}\n{p}\n{s}"
+
+[[var]]
+name = "n"
+type = "int"
+min = 5
+max = 20
+
+[[var]]
+name = "a"
+type = "array"
+length = "n"
+min = "-n"
+max = "n"
+distinct = true
+sorted = true
+
+[[var]]
+name = "p"
+type = "permutation"
+length = "n"
+
+[[var]]
+name = "s"
+type = "string"
+length = "2*n"
+alphabet = "xy"
+`)
+
+	for seed := uint64(1); seed <= 50; seed++ {
+		// When
+		input, err := usecase.GenerateInput(spec, seed)
+
+		// Then
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+		assert.Len(t, lines, 4)
+		n := parseInts(t, lines[0])[0]
+		assert.True(t, n >= 5 && n <= 20, "n = %d", n)
+
+		a := parseInts(t, lines[1])
+		assert.Len(t, a, int(n))
+		assert.True(t, slices.IsSorted(a), "not sorted: %v", a)
+		assert.Len(t, slices.Compact(slices.Clone(a)), int(n), "not distinct: %v", a)
+		assert.True(t, a[0] >= -n && a[len(a)-1] <= n, "out of range: %v", a)
+
+		p := parseInts(t, lines[2])
+		slices.Sort(p)
+		for i, value := range p {
+			assert.Equal(t, int64(i+1), value)
+		}
+
+		assert.Len(t, lines[3], int(2*n))
+		assert.Empty(t, strings.Trim(lines[3], "xy"))
+	}
+}
+
+func TestGenerateInput_EmptyDistinctArray(t *testing.T) {
+	// Given
+	spec := loadGenSpec(t, "format = \"{n}\\n{a}\"\n\n[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 0\nmax = 0\n\n"+
+		"[[var]]\nname = \"a\"\ntype = \"array\"\nlength = \"n\"\nmin = 1\nmax = 1\ndistinct = true")
+
+	// When
+	input, err := usecase.GenerateInput(spec, 1)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "0\n", input)
+}
+
+func TestGenerateInput_Graphs(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    string
+		edges    func(n int) int
+		weights  bool
+		directed bool
+	}{
+		{name: "tree", graph: "tree = true", edges: func(n int) int { return n - 1 }},
+		{name: "connected", graph: "edges = \"2*n\"\nconnected = true", edges: func(n int) int { return 2 * n }},
+		{name: "weighted", graph: "edges = \"n\"\nweighted = true\nmin = 1\nmax = 9", edges: func(n int) int { return n }, weights: true},
+		{name: "directed", graph: "edges = \"4*n\"\ndirected = true", edges: func(n int) int { return 4 * n }, directed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			content := "format = \"{n}\\n{g}\"\n\n[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 5\nmax = 10\n\n" +
+				"[[var]]\nname = \"g\"\ntype = \"graph\"\nvertices = \"n\"\n" + tt.graph + "\n"
+			spec := loadGenSpec(t, content)
+
+			for seed := uint64(1); seed <= 20; seed++ {
+				// When
+				input, err := usecase.GenerateInput(spec, seed)
+
+				// Then
+				if err != nil {
+					t.Fatalf("seed %d: %v", seed, err)
+				}
+				lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+				n := int(parseInts(t, lines[0])[0])
+				edges := lines[1:]
+				assert.Len(t, edges, tt.edges(n))
+
+				// Every edge is simple and, for trees and connected graphs, all vertices are reached
+				parent := make([]int, n+1)
+				for i := range parent {
+					parent[i] = i
+				}
+				var find func(int) int
+				find = func(v int) int {
+					if parent[v] != v {
+						parent[v] = find(parent[v])
+					}
+					return parent[v]
+				}
+				seen := make(map[[2]int64]bool)
+				for _, line := range edges {
+					values := parseInts(t, line)
+					if tt.weights {
+						assert.Len(t, values, 3)
+						assert.True(t, values[2] >= 1 && values[2] <= 9)
+					} else {
+						assert.Len(t, values, 2)
+					}
+					u, v := values[0], values[1]
+					assert.NotEqual(t, u, v, "self loop")
+					assert.True(t, u >= 1 && v >= 1 && u <= int64(n) && v <= int64(n))
+					key := [2]int64{u, v}
+					if !tt.directed {
+						key = [2]int64{min(u, v), max(u, v)}
+					}
+					assert.False(t, seen[key], "multi edge %d-%d", u, v)
+					seen[key] = true
+					parent[find(int(u))] = find(int(v))
+				}
+				if tt.name == "tree" || tt.name == "connected" {
+					for v := 2; v <= n; v++ {
+						assert.Equal(t, find(1), find(v), "vertex %d is not connected", v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateInput_SameSeedSameInput(t *testing.T) {
+	// Given
+	spec := loadGenSpec(t, "[[var]]\nname = \"a\"\ntype = \"array\"\nlength = 100\nmin = 0\nmax = 1_000_000_000\n")
+
+	// When
+	first, err1 := usecase.GenerateInput(spec, 7)
+	second, err2 := usecase.GenerateInput(spec, 7)
+	other, err3 := usecase.GenerateInput(spec, 8)
+
+	// Then
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
+
+func TestGenerateInput_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown placeholder",
+			content: "format = \"{m}\"\n[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 1\nmax = 2",
+			wantErr: "{m}",
+		},
+		{
+			name:    "reference to a later var",
+			content: "[[var]]\nname = \"s\"\ntype = \"string\"\nlength = \"n\"\n[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 1\nmax = 2",
+			wantErr: "n is not an int variable",
+		},
+		{
+			name:    "too few distinct values",
+			content: "[[var]]\nname = \"a\"\ntype = \"array\"\nlength = 5\nmin = 1\nmax = 3\ndistinct = true",
+			wantErr: "cannot pick 5 distinct values",
+		},
+		{
+			name:    "too many edges",
+			content: "[[var]]\nname = \"g\"\ntype = \"graph\"\nvertices = 3\nedges = 4",
+			wantErr: "at most 3 edges",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			spec := loadGenSpec(t, tt.content)
+
+			// When
+			_, err := usecase.GenerateInput(spec, 1)
+
+			// Then
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.wantErr)
+			}
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenUseCase_Execute_WritesFiles(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeGenSpec(t, dir, "[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 1\nmax = 100\n")
+	outDir := filepath.Join(dir, "test", "gen")
+	uc := usecase.NewGenUseCase("test")
+
+	// When
+	inputs, err := uc.Execute(context.Background(), usecase.GenOptions{Dir: dir, Seed: 10, Count: 3, OutDir: outDir})
+
+	// Then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Len(t, inputs, 3)
+	for i, input := range inputs {
+		assert.Equal(t, uint64(10+i), input.Seed)
+		assert.Equal(t, filepath.Join(outDir, "gen-"+strconv.Itoa(10+i)+".in"), input.Path)
+		content, err := os.ReadFile(input.Path)
+		assert.NoError(t, err)
+		assert.Equal(t, input.Content, string(content))
+	}
+}
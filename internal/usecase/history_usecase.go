@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// defaultHistoryPageSize is the number of submission records requested per page
+const defaultHistoryPageSize = 100
+
+// HistoryUseCase handles operations on the local submission history
+type HistoryUseCase struct {
+	submissionRepo repository.SubmissionRepository
+	remoteRepo     repository.SubmissionHistoryRepository
+	sessionRepo    repository.SessionRepository
+	logger         *logger.Logger
+}
+
+// NewHistoryUseCase creates a new HistoryUseCase that imports the records of
+// remoteRepo into the local history of submissionRepo
+func NewHistoryUseCase(
+	submissionRepo repository.SubmissionRepository,
+	remoteRepo repository.SubmissionHistoryRepository,
+	sessionRepo repository.SessionRepository,
+) *HistoryUseCase {
+	return &HistoryUseCase{
+		submissionRepo: submissionRepo,
+		remoteRepo:     remoteRepo,
+		sessionRepo:    sessionRepo,
+		logger:         logger.WithGroup("history_usecase"),
+	}
+}
+
+// HistorySyncOptions contains options for importing the submission history
+type HistorySyncOptions struct {
+	User     string // Optional: AOJ user ID (defaults to the logged-in user)
+	Full     bool   // Optional: read every page instead of stopping at the first known submission
+	NoSource bool   // Optional: do not download the source code of imported submissions
+	PageSize int    // Optional: records per request (defaults to 100)
+
+	// Progress is called after each page with the number of submissions imported so far
+	Progress func(imported int)
+}
+
+// HistorySyncResult describes an import of the submission history
+type HistorySyncResult struct {
+	User          string `json:"user"`
+	Fetched       int    `json:"fetched"`
+	Imported      int    `json:"imported"`
+	Updated       int    `json:"updated"`        // known submissions whose verdict was still pending
+	MissingSource int    `json:"missing_source"` // imported without source code
+}
+
+// Sync pages through the user's submissions on AOJ, newest first, and adds
+// the ones missing from the local history. Unless opts.Full is set, it stops
+// after the first page that contains an already known submission.
+// Submissions that cannot be saved do not stop the sync; they are returned
+// as a *cerrors.MultiError together with the result
+func (uc *HistoryUseCase) Sync(ctx context.Context, opts HistorySyncOptions) (*HistorySyncResult, error) {
+	user, err := uc.resolveUser(ctx, opts.User)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultHistoryPageSize
+	}
+
+	known, err := uc.knownSubmissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "syncing submission history", "user", user, "known", len(known))
+	defer uc.logger.Time(ctx, OperationHistorySync, historySyncHint)()
+
+	result := &HistorySyncResult{User: user}
+	failed := cerrors.NewMultiError("submissions")
+	fetchSource := !opts.NoSource
+	for page := 0; ; page++ {
+		records, err := uc.remoteRepo.GetUserSubmissions(ctx, user, page, pageSize)
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to fetch submission history")
+		}
+		result.Fetched += len(records)
+
+		reachedKnown := false
+		for _, record := range records {
+			if local, ok := known[record.JudgeID()]; ok {
+				reachedKnown = true
+				failed.Add(record.JudgeID(), uc.updatePending(ctx, local, record, result))
+				continue
+			}
+
+			if fetchSource {
+				source, err := uc.remoteRepo.GetSourceCode(ctx, record.JudgeID())
+				switch {
+				case err == nil:
+					record = withSourceCode(record, source)
+				case ctx.Err() != nil:
+					return nil, ctx.Err()
+				case cerrors.IsAppError(err, cerrors.CodeUnauthorized):
+					// Only the author may read the source code; do not ask again
+					uc.logger.WarnContext(ctx, "source code unavailable, importing metadata only", "error", err)
+					fetchSource = false
+				default:
+					uc.logger.WarnContext(ctx, "failed to fetch source code", "judge_id", record.JudgeID(), "error", err)
+				}
+			}
+			if record.SourceCode() == "" {
+				result.MissingSource++
+			}
+
+			if err := uc.submissionRepo.Save(ctx, record); err != nil {
+				failed.Add(record.JudgeID(), cerrors.Wrap(err, "failed to save submission history"))
+				continue
+			}
+			known[record.JudgeID()] = record
+			result.Imported++
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(result.Imported)
+		}
+		if len(records) < pageSize || (reachedKnown && !opts.Full) {
+			break
+		}
+	}
+
+	uc.logger.InfoContext(ctx, "synced submission history",
+		"user", user,
+		"fetched", result.Fetched,
+		"imported", result.Imported,
+		"updated", result.Updated,
+		"failed", failed.Len())
+	return result, failed.Err()
+}
+
+// resolveUser returns user, or the username of the current session
+func (uc *HistoryUseCase) resolveUser(ctx context.Context, user string) (string, error) {
+	if user != "" {
+		return user, nil
+	}
+
+	session, err := uc.sessionRepo.GetCurrent(ctx)
+	if err != nil && !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		return "", cerrors.Wrap(err, "failed to get current session")
+	}
+	if session == nil {
+		noSession := cerrors.ErrNoSession()
+		noSession.Hint = "Run 'aoj login' to sign in to AOJ first, or pass --user."
+		return "", noSession
+	}
+	return session.Username(), nil
+}
+
+// knownSubmissions returns the local history by judge ID; submissions that
+// never reached the judge are left out
+func (uc *HistoryUseCase) knownSubmissions(ctx context.Context) (map[string]*entity.Submission, error) {
+	submissions, err := uc.submissionRepo.Search(ctx, repository.SubmissionSearchCriteria{})
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+
+	known := make(map[string]*entity.Submission, len(submissions))
+	for _, submission := range submissions {
+		if submission.JudgeID() != "" {
+			known[submission.JudgeID()] = submission
+		}
+	}
+	return known, nil
+}
+
+// updatePending stores the verdict of record in a local submission that was
+// still waiting for it, e.g. because the watch was interrupted
+func (uc *HistoryUseCase) updatePending(ctx context.Context, local, record *entity.Submission, result *HistorySyncResult) error {
+	if local.Status().IsFinal() || !record.Status().IsFinal() {
+		return nil
+	}
+
+	local.UpdateResult(record.Status(), record.Score(), record.Time(), record.Memory(), local.Message())
+	if err := uc.submissionRepo.Save(ctx, local); err != nil {
+		return cerrors.Wrap(err, "failed to save submission history")
+	}
+	result.Updated++
+	return nil
+}
+
+// withSourceCode returns a copy of submission with the given source code
+func withSourceCode(submission *entity.Submission, source string) *entity.Submission {
+	restored := entity.RestoreSubmission(
+		submission.ID(),
+		submission.ProblemID(),
+		submission.Language(),
+		source,
+		submission.Status(),
+		submission.Score(),
+		submission.Time(),
+		submission.Memory(),
+		submission.Message(),
+		submission.SubmittedAt(),
+		submission.JudgedAt(),
+	)
+	restored.SetJudgeID(submission.JudgeID())
+	return restored
+}
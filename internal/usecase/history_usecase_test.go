@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// fakeHistoryRepository serves records newest first in pages
+type fakeHistoryRepository struct {
+	records   []*entity.Submission
+	sources   map[string]string
+	sourceErr error
+	pages     []int // requested pages
+}
+
+func (f *fakeHistoryRepository) GetUserSubmissions(_ context.Context, _ string, page, size int) ([]*entity.Submission, error) {
+	f.pages = append(f.pages, page)
+	start := min(page*size, len(f.records))
+	end := min(start+size, len(f.records))
+	return f.records[start:end], nil
+}
+
+func (f *fakeHistoryRepository) GetSourceCode(_ context.Context, judgeID string) (string, error) {
+	if f.sourceErr != nil {
+		return "", f.sourceErr
+	}
+	return f.sources[judgeID], nil
+}
+
+// judgedRecord returns a submission record as served by the judge
+func judgedRecord(judgeID string, status entity.SubmissionStatus, submittedAt time.Time) *entity.Submission {
+	submission := entity.RestoreSubmission(model.MustNewSubmissionID(judgeID), model.MustNewProblemID("ITP1_1_A"),
+		"C++17", "", status, 100, 10*time.Millisecond, 1024, "", submittedAt, nil)
+	submission.SetJudgeID(judgeID)
+	return submission
+}
+
+func TestHistoryUseCase_Sync(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	records := []*entity.Submission{
+		judgedRecord("105", entity.StatusAccepted, now),
+		judgedRecord("104", entity.StatusWrongAnswer, now.Add(-time.Minute)),
+		judgedRecord("103", entity.StatusAccepted, now.Add(-2*time.Minute)),
+		judgedRecord("102", entity.StatusAccepted, now.Add(-3*time.Minute)),
+		judgedRecord("101", entity.StatusAccepted, now.Add(-4*time.Minute)),
+	}
+
+	tests := []struct {
+		name         string
+		full         bool
+		wantPages    []int
+		wantImported int
+	}{
+		{name: "stops at the first known submission", wantPages: []int{0, 1}, wantImported: 3},
+		{name: "full", full: true, wantPages: []int{0, 1, 2}, wantImported: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			pending := judgedRecord("103", entity.StatusJudging, now.Add(-2*time.Minute))
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Search", mock.Anything, mock.Anything).Return([]*entity.Submission{pending}, nil)
+			submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+			remote := &fakeHistoryRepository{records: records, sources: map[string]string{"105": "int main() {}\n"}}
+			var progress []int
+			uc := NewHistoryUseCase(submissionRepo, remote, &MockSessionRepository{})
+
+			// When
+			result, err := uc.Sync(context.Background(), HistorySyncOptions{
+				User:     "alice",
+				Full:     tt.full,
+				PageSize: 2,
+				Progress: func(imported int) { progress = append(progress, imported) },
+			})
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPages, remote.pages)
+			assert.Equal(t, "alice", result.User)
+			assert.Equal(t, tt.wantImported, result.Imported)
+			assert.Equal(t, 1, result.Updated)
+			assert.Equal(t, tt.wantImported-1, result.MissingSource)
+			assert.Equal(t, entity.StatusAccepted, pending.Status())
+			assert.Len(t, progress, len(tt.wantPages))
+
+			saved := submissionRepo.Calls[1].Arguments.Get(1).(*entity.Submission)
+			assert.Equal(t, "105", saved.JudgeID())
+			assert.Equal(t, "int main() {}\n", saved.SourceCode())
+		})
+	}
+}
+
+func TestHistoryUseCase_Sync_SourceUnauthorized(t *testing.T) {
+	// Given
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Search", mock.Anything, mock.Anything).Return([]*entity.Submission{}, nil)
+	submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	remote := &fakeHistoryRepository{
+		records:   []*entity.Submission{judgedRecord("2", entity.StatusAccepted, now), judgedRecord("1", entity.StatusAccepted, now)},
+		sourceErr: cerrors.NewAppError(cerrors.CodeUnauthorized, "authentication required", nil),
+	}
+	uc := NewHistoryUseCase(submissionRepo, remote, &MockSessionRepository{})
+
+	// When
+	result, err := uc.Sync(context.Background(), HistorySyncOptions{User: "bob"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 2, result.MissingSource)
+}
+
+func TestHistoryUseCase_Sync_UserFromSession(t *testing.T) {
+	// Given
+	session := entity.NewSession(model.MustGenerateSessionID(), "carol", "token", time.Now().Add(time.Hour))
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Search", mock.Anything, mock.Anything).Return([]*entity.Submission{}, nil)
+	uc := NewHistoryUseCase(submissionRepo, &fakeHistoryRepository{}, sessionRepo)
+
+	// When
+	result, err := uc.Sync(context.Background(), HistorySyncOptions{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "carol", result.User)
+	assert.Equal(t, 0, result.Imported)
+}
+
+func TestHistoryUseCase_Sync_NotLoggedIn(t *testing.T) {
+	// Given
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(nil, cerrors.NewAppError(cerrors.CodeNotFound, "no current session", nil))
+	uc := NewHistoryUseCase(&MockSubmissionRepository{}, &fakeHistoryRepository{}, sessionRepo)
+
+	// When
+	_, err := uc.Sync(context.Background(), HistorySyncOptions{})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeUnauthorized), "got %v", err)
+}
+
+func TestHistoryUseCase_Sync_SaveFailures(t *testing.T) {
+	// Given
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	isRecord := func(judgeID string) any {
+		return mock.MatchedBy(func(s *entity.Submission) bool { return s.JudgeID() == judgeID })
+	}
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Search", mock.Anything, mock.Anything).Return([]*entity.Submission{}, nil)
+	submissionRepo.On("Save", mock.Anything, isRecord("2")).Return(cerrors.New("disk full"))
+	submissionRepo.On("Save", mock.Anything, isRecord("1")).Return(nil)
+	remote := &fakeHistoryRepository{records: []*entity.Submission{
+		judgedRecord("2", entity.StatusAccepted, now),
+		judgedRecord("1", entity.StatusAccepted, now.Add(-time.Minute)),
+	}}
+	uc := NewHistoryUseCase(submissionRepo, remote, &MockSessionRepository{})
+
+	// When
+	result, err := uc.Sync(context.Background(), HistorySyncOptions{User: "alice", NoSource: true})
+
+	// Then
+	var failed *cerrors.MultiError
+	if !cerrors.As(err, &failed) {
+		t.Fatalf("expected a MultiError, got %v", err)
+	}
+	assert.Equal(t, "2", failed.Errors()[0].Item)
+	assert.Equal(t, 1, result.Imported)
+}
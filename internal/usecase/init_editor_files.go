@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// vscodeTasks represents .vscode/tasks.json
+type vscodeTasks struct {
+	Version string       `json:"version"`
+	Tasks   []vscodeTask `json:"tasks"`
+}
+
+// vscodeTask represents a single VS Code task
+type vscodeTask struct {
+	Label   string       `json:"label"`
+	Type    string       `json:"type"`
+	Command string       `json:"command"`
+	Group   *vscodeGroup `json:"group,omitempty"`
+}
+
+// vscodeGroup represents the group of a VS Code task
+type vscodeGroup struct {
+	Kind      string `json:"kind"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// writeEditorFiles writes editor and language server configuration for the solution language
+func (uc *InitUseCase) writeEditorFiles(dir, problemID, sourceFile string) error {
+	ext := strings.ToLower(filepath.Ext(sourceFile))
+
+	if err := writeVSCodeTasks(dir, buildCommand(ext, sourceFile)); err != nil {
+		return err
+	}
+
+	switch ext {
+	case ".c":
+		if err := writeFileIfNotExists(filepath.Join(dir, "compile_flags.txt"), []byte("-std=c11\n-Wall\n-Wextra\n"), 0644); err != nil {
+			return cerrors.Wrap(err, "failed to create compile_flags.txt")
+		}
+	case ".cpp", ".cc", ".cxx":
+		if err := writeFileIfNotExists(filepath.Join(dir, "compile_flags.txt"), []byte("-std=c++17\n-Wall\n-Wextra\n"), 0644); err != nil {
+			return cerrors.Wrap(err, "failed to create compile_flags.txt")
+		}
+	case ".go":
+		goMod := fmt.Sprintf("module %s\n\ngo 1.21\n", strings.ToLower(problemID))
+		if err := writeFileIfNotExists(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+			return cerrors.Wrap(err, "failed to create go.mod")
+		}
+	}
+
+	return nil
+}
+
+// writeVSCodeTasks writes .vscode/tasks.json with build and test tasks
+func writeVSCodeTasks(dir, build string) error {
+	tasks := vscodeTasks{Version: "2.0.0"}
+	if build != "" {
+		tasks.Tasks = append(tasks.Tasks, vscodeTask{
+			Label:   "build",
+			Type:    "shell",
+			Command: build,
+			Group:   &vscodeGroup{Kind: "build", IsDefault: true},
+		})
+	}
+	tasks.Tasks = append(tasks.Tasks, vscodeTask{
+		Label:   "test",
+		Type:    "shell",
+		Command: "aoj test",
+		Group:   &vscodeGroup{Kind: "test", IsDefault: true},
+	})
+
+	content, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode tasks.json")
+	}
+
+	vscodeDir := filepath.Join(dir, ".vscode")
+	if err := os.MkdirAll(vscodeDir, 0755); err != nil {
+		return cerrors.Wrap(err, "failed to create .vscode directory")
+	}
+	if err := writeFileIfNotExists(filepath.Join(vscodeDir, "tasks.json"), append(content, '\n'), 0644); err != nil {
+		return cerrors.Wrap(err, "failed to create tasks.json")
+	}
+
+	return nil
+}
+
+// buildCommand returns the shell command that compiles the solution, or "" for interpreted languages
+func buildCommand(ext, sourceFile string) string {
+	switch ext {
+	case ".c":
+		return "gcc -std=c11 -O2 -o a.out " + sourceFile
+	case ".cpp", ".cc", ".cxx":
+		return "g++ -std=c++17 -O2 -o a.out " + sourceFile
+	case ".go":
+		return "go build -o main ."
+	case ".java":
+		return "javac " + sourceFile
+	case ".rs":
+		return "rustc -O -o a.out " + sourceFile
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// InitLanguage describes a language whose solution file init can scaffold
+type InitLanguage struct {
+	Name      string // configuration key such as "python" or "cpp17"
+	AOJName   string // AOJ language ID such as "Python3"
+	Extension string // file extension without the dot
+}
+
+// builtinTemplates holds the solution templates used for extensions without a
+// default template. They are evaluated with TemplateData
+var builtinTemplates = map[string]string{
+	"go": `package main
+
+import (
+	"fmt"
+)
+
+func main() {
+	// TODO: Implement solution for {{.ProblemID}}
+	fmt.Println("Hello, AOJ!")
+}
+`,
+	"cpp": `#include <bits/stdc++.h>
+using namespace std;
+
+int main() {
+    ios::sync_with_stdio(false);
+    cin.tie(nullptr);
+
+    // TODO: Implement solution for {{.ProblemID}}
+
+    return 0;
+}
+`,
+	"md": `# {{.ProblemID}}{{with .Title}} {{.}}{{end}}
+{{with .Statement}}
+{{.}}{{else}}{{with .Description}}
+{{.}}
+{{end}}{{end}}
+{{.URL}}
+`,
+	"c": `#include <stdio.h>
+
+int main(void) {
+    /* TODO: Implement solution for {{.ProblemID}} */
+    return 0;
+}
+`,
+	"py": `import sys
+
+
+def main():
+    # TODO: Implement solution for {{.ProblemID}}
+    pass
+
+
+if __name__ == "__main__":
+    main()
+`,
+	"java": `import java.util.*;
+
+public class Main {
+    public static void main(String[] args) {
+        Scanner sc = new Scanner(System.in);
+        // TODO: Implement solution for {{.ProblemID}}
+    }
+}
+`,
+	"rs": `use std::io::{self, Read};
+
+fn main() {
+    let mut input = String::new();
+    io::stdin().read_to_string(&mut input).unwrap();
+    let mut tokens = input.split_whitespace();
+    // TODO: Implement solution for {{.ProblemID}}
+    let _ = tokens.next();
+}
+`,
+	"kt": `fun main() {
+    // TODO: Implement solution for {{.ProblemID}}
+    val line = readLine() ?: return
+    println(line)
+}
+`,
+	"cs": `using System;
+using System.Linq;
+
+public class Program
+{
+    public static void Main()
+    {
+        // TODO: Implement solution for {{.ProblemID}}
+        var line = Console.ReadLine();
+        Console.WriteLine(line);
+    }
+}
+`,
+	"js": `const input = require("fs").readFileSync(0, "utf8");
+const lines = input.split("\n");
+
+// TODO: Implement solution for {{.ProblemID}}
+console.log(lines[0]);
+`,
+	"rb": `# TODO: Implement solution for {{.ProblemID}}
+lines = $stdin.read.split("\n")
+puts lines[0]
+`,
+}
+
+// findInitLanguage looks up a language by its configuration key, its AOJ
+// language ID or its extension, ignoring case
+func findInitLanguage(languages []InitLanguage, name string) (InitLanguage, error) {
+	name = strings.TrimSpace(name)
+	for _, match := range []func(InitLanguage) bool{
+		func(l InitLanguage) bool { return strings.EqualFold(l.Name, name) },
+		func(l InitLanguage) bool { return strings.EqualFold(l.AOJName, name) },
+		func(l InitLanguage) bool { return strings.EqualFold(l.Extension, strings.TrimPrefix(name, ".")) },
+	} {
+		for _, lang := range languages {
+			if match(lang) {
+				return lang, nil
+			}
+		}
+	}
+
+	names := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		names = append(names, lang.Name)
+	}
+	return InitLanguage{}, cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		fmt.Sprintf("unknown language '%s'. Available: %s", name, strings.Join(names, ", ")),
+		nil,
+	)
+}
+
+// sourceFileFor returns the solution file name for a language, keeping the
+// configured base name. Java solutions must be named after the Main class
+func sourceFileFor(lang InitLanguage, sourceFile string) string {
+	if strings.EqualFold(lang.Extension, "java") {
+		return "Main.java"
+	}
+	base := strings.TrimSuffix(sourceFile, filepath.Ext(sourceFile))
+	return base + "." + lang.Extension
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/htmlmd"
+)
+
+// statementLanguages are the languages AOJ provides statements in, tried in
+// order when no language is preferred
+var statementLanguages = []string{"en", "ja"}
+
+// validateStatementLanguage accepts "" (no preference) and the languages of statementLanguages
+func validateStatementLanguage(language string) error {
+	if language == "" || slices.Contains(statementLanguages, language) {
+		return nil
+	}
+	return cerrors.WithHint(
+		cerrors.NewAppError(cerrors.CodeInvalidInput, fmt.Sprintf("unknown statement language %q", language), nil),
+		"AOJ provides statements in "+strings.Join(statementLanguages, " and "),
+	)
+}
+
+// statementLanguageOrder returns the languages to try for a statement: the
+// preferred one first, then the others in case the problem lacks it
+func statementLanguageOrder(preferred string) []string {
+	if preferred == "" {
+		return statementLanguages
+	}
+	order := []string{preferred}
+	for _, language := range statementLanguages {
+		if language != preferred {
+			order = append(order, language)
+		}
+	}
+	return order
+}
+
+// statement downloads the problem statement, preferably in language, and
+// converts it to Markdown. It returns "" if no statement is available, which
+// is not an error for init
+func (uc *InitUseCase) statement(ctx context.Context, pid model.ProblemID, language string, testCases []model.TestCase) string {
+	html, _, err := fetchStatement(ctx, uc.layout.Statements, pid, statementLanguageOrder(language), uc.logger)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "problem statement is not available", "problem_id", pid.String(), "error", err)
+		return ""
+	}
+
+	markdown, err := renderStatement(html, htmlmd.Markdown)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to convert the problem statement", "error", err)
+		return ""
+	}
+	return withSamples(dropTitle(markdown), testCases)
+}
+
+// samplesFromStatement extracts the samples shown in the problem statement
+func (uc *InitUseCase) samplesFromStatement(ctx context.Context, pid model.ProblemID) []model.TestCase {
+	for _, language := range statementLanguages {
+		html, err := uc.layout.Statements.GetStatement(ctx, pid, language)
+		if err != nil {
+			uc.logger.DebugContext(ctx, "statement not available", "language", language, "error", err)
+			continue
+		}
+
+		samples, err := htmlmd.ExtractSamples(html)
+		if err != nil {
+			uc.logger.WarnContext(ctx, "failed to parse the problem statement", "error", err)
+			return []model.TestCase{}
+		}
+		if len(samples) == 0 {
+			continue
+		}
+
+		testCases := make([]model.TestCase, 0, len(samples))
+		for i, sample := range samples {
+			testCases = append(testCases, *model.NewTestCase(i+1, sample.Input, sample.Output))
+		}
+		uc.logger.InfoContext(ctx, "extracted samples from the problem statement", "count", len(testCases), "language", language)
+		return testCases
+	}
+	return []model.TestCase{}
+}
+
+// dropTitle removes a leading top-level heading, which repeats the README title
+func dropTitle(markdown string) string {
+	if !strings.HasPrefix(markdown, "# ") {
+		return markdown
+	}
+	_, rest, _ := strings.Cut(markdown, "\n")
+	return strings.TrimLeft(rest, "\n")
+}
+
+// withSamples appends the sample cases as code blocks unless the statement
+// already shows samples in code blocks
+func withSamples(markdown string, testCases []model.TestCase) string {
+	if len(testCases) == 0 || strings.Contains(markdown, "```") {
+		return markdown
+	}
+
+	var b strings.Builder
+	b.WriteString(markdown)
+	b.WriteString("\n## Samples\n")
+	for i, tc := range testCases {
+		fmt.Fprintf(&b, "\n### Sample Input %d\n\n```\n%s```\n", i+1, withTrailingNewline(tc.Input()))
+		fmt.Fprintf(&b, "\n### Sample Output %d\n\n```\n%s```\n", i+1, withTrailingNewline(tc.Expected()))
+	}
+	return b.String()
+}
+
+func withTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
@@ -8,29 +8,116 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
 )
 
 // InitUseCase handles problem initialization operations
 type InitUseCase struct {
 	problemRepo repository.ProblemRepository
+	layout      InitLayout
+	templates   *TemplateUseCase
 	logger      *logger.Logger
 }
 
-// NewInitUseCase creates a new InitUseCase
-func NewInitUseCase(problemRepo repository.ProblemRepository) *InitUseCase {
+// InitLayout describes the files generated in a problem directory
+type InitLayout struct {
+	Root         string // workspace root where problem directories are created (default: current directory)
+	SourceFile   string // solution file name
+	TestDir      string // directory for sample test cases
+	CreateReadme bool   // write README.md with the problem statement
+	CreateNotes  bool   // write an empty notes.md
+	ScaffoldDir  string // optional directory whose files are copied into the problem directory
+	EditorFiles  bool   // write .vscode/tasks.json and language server configuration
+	// Language selects the solution language when init is not given one; empty keeps SourceFile as is
+	Language    string
+	Languages   []InitLanguage // languages that can be selected
+	TemplateDir string         // directory of the named templates managed by 'aoj template'
+	// SaveStatement downloads the problem statement into README.md
+	SaveStatement bool
+	// StatementLanguage is the preferred language of that statement, "en" or "ja"; empty prefers English
+	StatementLanguage string
+	Statements        repository.StatementRepository // required by SaveStatement; also the source of missing samples
+	// Sessions supplies the username of template variables; optional
+	Sessions repository.SessionRepository
+	Clock    clock.Clock // supplies the date of template variables; defaults to the system clock
+	// Aliases are accepted as problem IDs; the directory keeps the alias as its name
+	Aliases ProblemAliases
+}
+
+// InitOptions holds options for a single init
+type InitOptions struct {
+	Language string // Optional: solution language (defaults to the layout's language)
+	Template string // Optional: named template (defaults to the language's default template)
+	// StatementLanguage is the preferred language of the saved statement (defaults to the layout's)
+	StatementLanguage string
+	// Samples are saved instead of the samples downloaded from AOJ, e.g. the
+	// ones parsed by competitive-companion; nil downloads them
+	Samples []model.TestCase
+}
+
+// DefaultInitLayout returns the default problem directory layout
+func DefaultInitLayout() InitLayout {
+	return InitLayout{
+		SourceFile: "main.go",
+		TestDir:    "test",
+	}
+}
+
+// NewInitUseCase creates a new InitUseCase that generates the given layout.
+// Empty fields of layout take their default values
+func NewInitUseCase(problemRepo repository.ProblemRepository, layout InitLayout) *InitUseCase {
+	defaults := DefaultInitLayout()
+	if layout.SourceFile == "" {
+		layout.SourceFile = defaults.SourceFile
+	}
+	if layout.TestDir == "" {
+		layout.TestDir = defaults.TestDir
+	}
+	if layout.Clock == nil {
+		layout.Clock = clock.System()
+	}
+
 	return &InitUseCase{
 		problemRepo: problemRepo,
+		layout:      layout,
+		templates:   NewTemplateUseCase(layout.TemplateDir),
 		logger:      logger.WithGroup("init_usecase"),
 	}
 }
 
-// Execute executes the init use case
+// Execute executes the init use case with the default options
 func (uc *InitUseCase) Execute(ctx context.Context, problemID string) error {
-	uc.logger.InfoContext(ctx, "initializing problem directory", "problem_id", problemID)
+	return uc.ExecuteWithOptions(ctx, problemID, InitOptions{})
+}
+
+// ExecuteAll initializes every problem of problemIDs with the same options.
+// A problem that fails does not stop the others; the failures are returned
+// together as a *cerrors.MultiError. onDone, if not nil, is called after each problem
+func (uc *InitUseCase) ExecuteAll(ctx context.Context, problemIDs []string, opts InitOptions, onDone func(problemID string, err error)) error {
+	failed := cerrors.NewMultiError("problems")
+	for _, problemID := range problemIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err := uc.ExecuteWithOptions(ctx, problemID, opts)
+		if onDone != nil {
+			onDone(problemID, err)
+		}
+		failed.Add(problemID, err)
+	}
+	return failed.Err()
+}
+
+// ExecuteWithOptions executes the init use case
+func (uc *InitUseCase) ExecuteWithOptions(ctx context.Context, problemID string, opts InitOptions) error {
+	uc.logger.InfoContext(ctx, "initializing problem directory", "problem_id", problemID, "language", opts.Language)
 
 	// Validate input
 	if strings.TrimSpace(problemID) == "" {
@@ -42,61 +129,293 @@ func (uc *InitUseCase) Execute(ctx context.Context, problemID string) error {
 	}
 
 	// Create ProblemID value object
-	pid, err := model.NewProblemID(problemID)
+	pid, err := uc.layout.Aliases.ParseProblemID(problemID)
 	if err != nil {
 		return cerrors.Wrap(err, "invalid problem ID")
 	}
 
+	statementLanguage := opts.StatementLanguage
+	if statementLanguage == "" {
+		statementLanguage = uc.layout.StatementLanguage
+	}
+	if err := validateStatementLanguage(statementLanguage); err != nil {
+		return err
+	}
+
+	sourceFile, err := uc.sourceFile(ctx, opts)
+	if err != nil {
+		return err
+	}
+	sourceText, err := uc.sourceTemplate(ctx, sourceFile, opts.Template)
+	if err != nil {
+		return err
+	}
+
 	// Create problem directory
-	if err := os.MkdirAll(problemID, 0755); err != nil {
+	dir := uc.ProblemDir(problemID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
 		return cerrors.Wrap(err, "failed to create problem directory")
 	}
 
-	// Get test cases from repository
-	testCases, err := uc.problemRepo.GetTestCases(ctx, pid)
-	if err != nil {
-		uc.logger.WarnContext(ctx, "failed to get test cases, continuing with empty test cases", "error", err)
-		testCases = []model.TestCase{}
+	// Get test cases from repository unless they were given
+	testCases := opts.Samples
+	if testCases == nil {
+		testCases = uc.downloadSamples(ctx, pid)
 	}
 
 	// Create test directory and save test cases
-	testDir := filepath.Join(problemID, "test")
+	testDir := filepath.Join(dir, uc.layout.TestDir)
 	if err := os.MkdirAll(testDir, 0755); err != nil {
 		return cerrors.Wrap(err, "failed to create test directory")
 	}
 
 	// Save test cases
+	checksums := config.TestChecksums{}
 	for i, tc := range testCases {
-		inputFile := filepath.Join(testDir, fmt.Sprintf("sample-%d.in", i+1))
-		outputFile := filepath.Join(testDir, fmt.Sprintf("sample-%d.out", i+1))
+		inputName := fmt.Sprintf("sample-%d.in", i+1)
+		outputName := fmt.Sprintf("sample-%d.out", i+1)
+		inputFile := filepath.Join(testDir, inputName)
+		outputFile := filepath.Join(testDir, outputName)
 
+		trace.File("write", inputFile)
 		if err := os.WriteFile(inputFile, []byte(tc.Input()), 0644); err != nil {
 			return cerrors.Wrap(err, fmt.Sprintf("failed to write test input file %s", inputFile))
 		}
 
+		trace.File("write", outputFile)
 		if err := os.WriteFile(outputFile, []byte(tc.Expected()), 0644); err != nil {
 			return cerrors.Wrap(err, fmt.Sprintf("failed to write test output file %s", outputFile))
 		}
+		checksums[inputName] = dataChecksum([]byte(tc.Input()))
+		checksums[outputName] = dataChecksum([]byte(tc.Expected()))
+	}
+	if err := recordTestChecksums(testDir, checksums); err != nil {
+		return err
 	}
 
-	// Create main.go template
-	mainTemplate := `package main
+	// Get problem metadata such as the title and the time limit
+	problem, err := uc.problemRepo.GetByID(ctx, pid)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to get problem metadata", "error", err)
+		problem = nil
+	}
+	data := newTemplateData(pid, problem, testCases, sourceFile, uc.username(ctx), uc.layout.Clock.Now())
+	if uc.layout.SaveStatement && uc.layout.Statements != nil {
+		data.Statement = uc.statement(ctx, pid, statementLanguage, testCases)
+	}
 
-import (
-	"fmt"
-)
+	// Create solution file
+	source := uc.render(ctx, sourceFile, sourceText, data)
+	trace.File("write", filepath.Join(dir, sourceFile))
+	if err := os.WriteFile(filepath.Join(dir, sourceFile), []byte(source), 0644); err != nil {
+		return cerrors.Wrap(err, fmt.Sprintf("failed to create %s", sourceFile))
+	}
+
+	// Record the solution file unless it is the configured default, so that test finds it
+	if problem != nil || sourceFile != uc.layout.SourceFile {
+		if err := uc.writeProblemConfig(pid, problem, dir, sourceFile); err != nil {
+			return err
+		}
+	}
+
+	if uc.layout.CreateReadme || uc.layout.SaveStatement {
+		if err := uc.writeReadme(ctx, dir, data); err != nil {
+			return err
+		}
+	}
 
-func main() {
-	// TODO: Implement solution for %s
-	fmt.Println("Hello, AOJ!")
+	if uc.layout.CreateNotes {
+		if err := writeFileIfNotExists(filepath.Join(dir, NotesFileName), notesTemplate(problemID), 0644); err != nil {
+			return cerrors.Wrap(err, "failed to create "+NotesFileName)
+		}
+	}
+
+	if uc.layout.ScaffoldDir != "" {
+		if err := uc.copyScaffold(dir); err != nil {
+			return err
+		}
+	}
+
+	if uc.layout.EditorFiles {
+		if err := uc.writeEditorFiles(dir, problemID, sourceFile); err != nil {
+			return err
+		}
+	}
+
+	uc.logger.InfoContext(ctx, "successfully initialized problem directory",
+		"problem_id", problemID,
+		"dir", dir)
+	return nil
 }
-`
-	mainContent := fmt.Sprintf(mainTemplate, problemID)
-	mainFile := filepath.Join(problemID, "main.go")
-	if err := os.WriteFile(mainFile, []byte(mainContent), 0644); err != nil {
-		return cerrors.Wrap(err, "failed to create main.go")
+
+// downloadSamples returns the samples of a problem from AOJ, or none if they are unavailable
+func (uc *InitUseCase) downloadSamples(ctx context.Context, pid model.ProblemID) []model.TestCase {
+	stopTiming := uc.logger.Time(ctx, OperationSampleDownload, sampleDownloadHint)
+	testCases, err := uc.problemRepo.GetTestCases(ctx, pid)
+	stopTiming()
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to get test cases, continuing with empty test cases", "error", err)
+		testCases = []model.TestCase{}
+	}
+	if len(testCases) == 0 && uc.layout.Statements != nil {
+		// judgedat lacks the samples of some older problems; fall back to the statement
+		testCases = uc.samplesFromStatement(ctx, pid)
 	}
+	return testCases
+}
+
+// ProblemDir returns the directory a problem is initialized in
+func (uc *InitUseCase) ProblemDir(problemID string) string {
+	return filepath.Join(uc.layout.Root, problemID)
+}
+
+// sourceFile returns the solution file name for the selected language. Without
+// a language, a named template selects the language of its extension
+func (uc *InitUseCase) sourceFile(ctx context.Context, opts InitOptions) (string, error) {
+	language := opts.Language
+	if language == "" && opts.Template != "" {
+		info, err := uc.templates.find(ctx, opts.Template)
+		if err != nil {
+			return "", err
+		}
+		lang, err := findInitLanguage(uc.layout.Languages, info.Extension)
+		if err != nil {
+			lang = InitLanguage{Extension: info.Extension}
+		}
+		return sourceFileFor(lang, uc.layout.SourceFile), nil
+	}
+	if language == "" {
+		language = uc.layout.Language
+	}
+	if language == "" {
+		return uc.layout.SourceFile, nil
+	}
+
+	lang, err := findInitLanguage(uc.layout.Languages, language)
+	if err != nil {
+		return "", err
+	}
+	return sourceFileFor(lang, uc.layout.SourceFile), nil
+}
 
-	uc.logger.InfoContext(ctx, "successfully initialized problem directory", "problem_id", problemID)
+// sourceTemplate returns the template of the solution file: the named
+// template, the default template of its language or the built-in template
+func (uc *InitUseCase) sourceTemplate(ctx context.Context, sourceFile, name string) (string, error) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(sourceFile)), ".")
+	return uc.resolveTemplate(ctx, ext, name)
+}
+
+// resolveTemplate returns the named template, the default template of an
+// extension or its built-in template, which is empty for unknown extensions
+func (uc *InitUseCase) resolveTemplate(ctx context.Context, ext, name string) (string, error) {
+	if uc.layout.TemplateDir != "" || name != "" {
+		content, ok, err := uc.templates.Resolve(ctx, ext, name)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return content, nil
+		}
+	}
+	return builtinTemplates[ext], nil
+}
+
+// username returns the user of the current session, or "" if not logged in
+func (uc *InitUseCase) username(ctx context.Context) string {
+	if uc.layout.Sessions == nil {
+		return ""
+	}
+	session, err := uc.layout.Sessions.GetCurrent(ctx)
+	if err != nil || session == nil {
+		return ""
+	}
+	return session.Username()
+}
+
+// writeProblemConfig stores the problem metadata and the solution file used by
+// later commands in problem.toml
+func (uc *InitUseCase) writeProblemConfig(pid model.ProblemID, problem *entity.Problem, dir, sourceFile string) error {
+	problemConfig := &config.ProblemConfig{ProblemID: pid.String()}
+	if problem != nil {
+		problemConfig.Title = problem.Title()
+		problemConfig.TimeLimit = problem.TimeLimit().Seconds()
+		problemConfig.MemoryLimit = problem.MemoryLimit()
+	}
+	if sourceFile != uc.layout.SourceFile {
+		problemConfig.SourceFile = sourceFile
+	}
+	if err := config.SaveProblemConfig(dir, problemConfig); err != nil {
+		return cerrors.Wrap(err, "failed to create "+config.ProblemConfigFile)
+	}
 	return nil
 }
+
+// writeReadme writes README.md from the default template of .md files or the
+// built-in one with the problem statement and a link to AOJ
+func (uc *InitUseCase) writeReadme(ctx context.Context, dir string, data TemplateData) error {
+	text, err := uc.resolveTemplate(ctx, "md", "")
+	if err != nil {
+		return err
+	}
+
+	content := uc.render(ctx, "README.md", text, data)
+	trace.File("write", filepath.Join(dir, "README.md"))
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(content), 0644); err != nil {
+		return cerrors.Wrap(err, "failed to create README.md")
+	}
+	return nil
+}
+
+// copyScaffold copies the files of the scaffold directory into the problem directory
+// without overwriting generated files
+func (uc *InitUseCase) copyScaffold(dir string) error {
+	scaffoldDir := uc.layout.ScaffoldDir
+	if _, err := os.Stat(scaffoldDir); err != nil {
+		return cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("scaffold directory %s not found", scaffoldDir),
+			err,
+		)
+	}
+
+	err := filepath.WalkDir(scaffoldDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(scaffoldDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// Keep the mode, e.g. so that scripts stay executable
+		return writeFileIfNotExists(target, content, info.Mode().Perm())
+	})
+	if err != nil {
+		return cerrors.Wrap(err, "failed to copy scaffold files")
+	}
+
+	return nil
+}
+
+// writeFileIfNotExists writes a file with perm unless it already exists
+func writeFileIfNotExists(path string, content []byte, perm os.FileMode) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	trace.File("write", path)
+	return os.WriteFile(path, content, perm)
+}
@@ -4,23 +4,29 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
 	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
 )
 
 // MockProblemRepository is a mock implementation of ProblemRepository
 type MockProblemRepository struct {
+	problem   *entity.Problem
 	testCases []model.TestCase
 	getError  error
 	saveError error
 }
 
 func (m *MockProblemRepository) GetByID(_ context.Context, _ model.ProblemID) (*entity.Problem, error) {
-	return nil, nil
+	return m.problem, nil
 }
 
 func (m *MockProblemRepository) GetByIDs(_ context.Context, _ []model.ProblemID) ([]*entity.Problem, error) {
@@ -59,7 +65,7 @@ func TestInitUseCase_Execute_EmptyProblemID(t *testing.T) {
 
 	ctx := context.Background()
 	mockRepo := &MockProblemRepository{}
-	uc := usecase.NewInitUseCase(mockRepo)
+	uc := usecase.NewInitUseCase(mockRepo, usecase.DefaultInitLayout())
 
 	err := uc.Execute(ctx, "")
 	if err == nil {
@@ -82,7 +88,7 @@ func TestInitUseCase_Execute_Success(t *testing.T) {
 			*model.NewTestCase(1, "5\n", "5\n"),
 		},
 	}
-	uc := usecase.NewInitUseCase(mockRepo)
+	uc := usecase.NewInitUseCase(mockRepo, usecase.DefaultInitLayout())
 
 	problemID := "ALDS1_1_A"
 	err := uc.Execute(ctx, problemID)
@@ -107,3 +113,326 @@ func TestInitUseCase_Execute_Success(t *testing.T) {
 		t.Errorf("test directory was not created")
 	}
 }
+
+func TestInitUseCase_Execute_CustomLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	scaffoldDir := filepath.Join(tmpDir, "scaffold")
+	if err := os.MkdirAll(filepath.Join(scaffoldDir, "lib"), 0755); err != nil {
+		t.Fatalf("failed to create scaffold directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(scaffoldDir, "lib", "util.hpp"), []byte("// util\n"), 0644); err != nil {
+		t.Fatalf("failed to create scaffold file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(scaffoldDir, "main.cpp"), []byte("// scaffold\n"), 0644); err != nil {
+		t.Fatalf("failed to create scaffold file: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	ctx := context.Background()
+	mockRepo := &MockProblemRepository{
+		testCases: []model.TestCase{
+			*model.NewTestCase(1, "5\n", "5\n"),
+		},
+	}
+	uc := usecase.NewInitUseCase(mockRepo, usecase.InitLayout{
+		SourceFile:   "main.cpp",
+		TestDir:      "samples",
+		CreateReadme: true,
+		CreateNotes:  true,
+		ScaffoldDir:  scaffoldDir,
+	})
+
+	problemID := "ALDS1_1_A"
+	if err := uc.Execute(ctx, problemID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"main.cpp", "samples/sample-1.in", "README.md", "notes.md", "lib/util.hpp"} {
+		if _, err := os.Stat(filepath.Join(problemID, name)); os.IsNotExist(err) {
+			t.Errorf("%s was not created", name)
+		}
+	}
+
+	// Generated files take precedence over scaffold files
+	content, err := os.ReadFile(filepath.Join(problemID, "main.cpp"))
+	if err != nil {
+		t.Fatalf("failed to read main.cpp: %v", err)
+	}
+	if string(content) == "// scaffold\n" {
+		t.Errorf("main.cpp was overwritten by the scaffold")
+	}
+}
+
+func TestInitUseCase_Execute_ScaffoldKeepsFileModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not kept on Windows")
+	}
+	tmpDir := t.TempDir()
+	scaffoldDir := filepath.Join(tmpDir, "scaffold")
+	if err := os.MkdirAll(scaffoldDir, 0755); err != nil {
+		t.Fatalf("failed to create scaffold directory: %v", err)
+	}
+	modes := map[string]os.FileMode{"run.sh": 0755, "secret.txt": 0600, "notes.txt": 0644}
+	for name, mode := range modes {
+		if err := os.WriteFile(filepath.Join(scaffoldDir, name), []byte("x\n"), mode); err != nil {
+			t.Fatalf("failed to create scaffold file: %v", err)
+		}
+		if err := os.Chmod(filepath.Join(scaffoldDir, name), mode); err != nil {
+			t.Fatalf("failed to set the mode of %s: %v", name, err)
+		}
+	}
+	t.Chdir(tmpDir)
+
+	layout := usecase.DefaultInitLayout()
+	layout.ScaffoldDir = scaffoldDir
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	problemID := "ITP1_1_A"
+	if err := uc.Execute(context.Background(), problemID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, mode := range modes {
+		info, err := os.Stat(filepath.Join(problemID, name))
+		if err != nil {
+			t.Fatalf("%s was not copied: %v", name, err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("%s has mode %v, want %v", name, info.Mode().Perm(), mode)
+		}
+	}
+}
+
+func TestInitUseCase_Execute_EditorFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	ctx := context.Background()
+	layout := usecase.DefaultInitLayout()
+	layout.EditorFiles = true
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	problemID := "ITP1_1_A"
+	if err := uc.Execute(ctx, problemID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tasks, err := os.ReadFile(filepath.Join(problemID, ".vscode", "tasks.json"))
+	if err != nil {
+		t.Fatalf("tasks.json was not created: %v", err)
+	}
+	if !strings.Contains(string(tasks), "go build -o main .") {
+		t.Errorf("tasks.json does not contain the Go build task: %s", tasks)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(problemID, "go.mod"))
+	if err != nil {
+		t.Fatalf("go.mod was not created: %v", err)
+	}
+	if !strings.HasPrefix(string(goMod), "module itp1_1_a\n") {
+		t.Errorf("unexpected go.mod: %s", goMod)
+	}
+}
+
+func TestInitUseCase_Execute_WorkspaceRoot(t *testing.T) {
+	t.Chdir(t.TempDir())
+	root := t.TempDir()
+
+	layout := usecase.DefaultInitLayout()
+	layout.Root = root
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	problemID := "ITP1_1_A"
+	if err := uc.Execute(context.Background(), problemID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := uc.ProblemDir(problemID); got != filepath.Join(root, problemID) {
+		t.Errorf("unexpected problem directory: %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(root, problemID, "main.go")); os.IsNotExist(err) {
+		t.Errorf("main.go was not created in the workspace root")
+	}
+	if _, err := os.Stat(problemID); !os.IsNotExist(err) {
+		t.Errorf("problem directory was created in the current directory")
+	}
+}
+
+func TestInitUseCase_ExecuteAll(t *testing.T) {
+	t.Chdir(t.TempDir())
+	root := t.TempDir()
+
+	layout := usecase.DefaultInitLayout()
+	layout.Root = root
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	var done []string
+	err := uc.ExecuteAll(context.Background(), []string{"ITP1_1_A", "not a problem", "ITP1_1_B"}, usecase.InitOptions{},
+		func(problemID string, _ error) { done = append(done, problemID) })
+
+	var failed *cerrors.MultiError
+	if !cerrors.As(err, &failed) {
+		t.Fatalf("expected a MultiError, got %v", err)
+	}
+	if failed.Len() != 1 || failed.Errors()[0].Item != "not a problem" {
+		t.Errorf("unexpected failures: %v", err)
+	}
+	if len(done) != 3 {
+		t.Errorf("expected every problem to be attempted, got %v", done)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ITP1_1_B", "main.go")); err != nil {
+		t.Errorf("problem after the failure was not initialized: %v", err)
+	}
+}
+
+func TestInitUseCase_Execute_ProblemConfig(t *testing.T) {
+	root := t.TempDir()
+	pid, _ := model.NewProblemID("ITP1_1_A")
+	problem := entity.NewProblem(pid, "Hello World", "", 1500*time.Millisecond, 131072, "", 0)
+
+	layout := usecase.DefaultInitLayout()
+	layout.Root = root
+	uc := usecase.NewInitUseCase(&MockProblemRepository{problem: problem}, layout)
+
+	if err := uc.Execute(context.Background(), "ITP1_1_A"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	problemConfig, err := config.LoadProblemConfig(filepath.Join(root, "ITP1_1_A"))
+	if err != nil {
+		t.Fatalf("problem.toml was not created: %v", err)
+	}
+	if problemConfig.Title != "Hello World" || problemConfig.MemoryLimit != 131072 {
+		t.Errorf("unexpected problem config: %+v", problemConfig)
+	}
+	if got := problemConfig.TimeLimitDuration(); got != 1500*time.Millisecond {
+		t.Errorf("unexpected time limit: %s", got)
+	}
+}
+
+func initTestLanguages() []usecase.InitLanguage {
+	return []usecase.InitLanguage{
+		{Name: "cpp17", AOJName: "C++17", Extension: "cpp"},
+		{Name: "java", AOJName: "Java", Extension: "java"},
+		{Name: "python", AOJName: "Python3", Extension: "py"},
+		{Name: "rust", AOJName: "Rust", Extension: "rs"},
+		{Name: "kotlin", AOJName: "Kotlin", Extension: "kt"},
+		{Name: "csharp", AOJName: "C#", Extension: "cs"},
+		{Name: "javascript", AOJName: "JavaScript", Extension: "js"},
+		{Name: "ruby", AOJName: "Ruby", Extension: "rb"},
+	}
+}
+
+func TestInitUseCase_Execute_Language(t *testing.T) {
+	root := t.TempDir()
+	layout := usecase.DefaultInitLayout()
+	layout.Root = root
+	layout.Language = "C++17"
+	layout.Languages = initTestLanguages()
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	tests := []struct {
+		problemID string
+		language  string
+		want      string
+		contains  string
+	}{
+		{problemID: "ITP1_1_A", language: "python", want: "main.py", contains: "def main():"},
+		{problemID: "ITP1_1_B", language: "Java", want: "Main.java", contains: "public class Main"},
+		{problemID: "ITP1_1_C", language: "", want: "main.cpp", contains: "int main()"},
+		{problemID: "ITP1_1_D", language: "rust", want: "main.rs", contains: "fn main()"},
+		{problemID: "ITP1_2_A", language: "Kotlin", want: "main.kt", contains: "fun main()"},
+		{problemID: "ITP1_2_B", language: "C#", want: "main.cs", contains: "public static void Main()"},
+		{problemID: "ITP1_2_C", language: "js", want: "main.js", contains: "readFileSync(0"},
+		{problemID: "ITP1_2_D", language: "ruby", want: "main.rb", contains: "$stdin.read"},
+	}
+	for _, tt := range tests {
+		if err := uc.ExecuteWithOptions(context.Background(), tt.problemID, usecase.InitOptions{Language: tt.language}); err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.language, err)
+		}
+
+		dir := filepath.Join(root, tt.problemID)
+		content, err := os.ReadFile(filepath.Join(dir, tt.want))
+		if err != nil {
+			t.Fatalf("%s was not created: %v", tt.want, err)
+		}
+		if !strings.Contains(string(content), tt.contains) || !strings.Contains(string(content), tt.problemID) {
+			t.Errorf("unexpected template in %s: %s", tt.want, content)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "main.go")); !os.IsNotExist(err) {
+			t.Errorf("main.go was created for %q", tt.language)
+		}
+
+		problemConfig, err := config.LoadProblemConfig(dir)
+		if err != nil {
+			t.Fatalf("problem.toml was not created: %v", err)
+		}
+		if problemConfig.SourceFile != tt.want {
+			t.Errorf("problem.toml records %q, want %q", problemConfig.SourceFile, tt.want)
+		}
+	}
+}
+
+func TestInitUseCase_Execute_UnknownLanguage(t *testing.T) {
+	root := t.TempDir()
+	layout := usecase.DefaultInitLayout()
+	layout.Root = root
+	layout.Languages = initTestLanguages()
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	err := uc.ExecuteWithOptions(context.Background(), "ITP1_1_A", usecase.InitOptions{Language: "cobol"})
+	if err == nil || !strings.Contains(err.Error(), "python") {
+		t.Fatalf("expected an error listing the available languages, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ITP1_1_A")); !os.IsNotExist(err) {
+		t.Errorf("problem directory was created for an unknown language")
+	}
+}
+
+func TestInitUseCase_Execute_Template(t *testing.T) {
+	root := t.TempDir()
+	templateDir := t.TempDir()
+	templates := usecase.NewTemplateUseCase(templateDir)
+	ctx := context.Background()
+	for name, content := range map[string]string{"fast.cpp": "// fast\n", "script.py": "# script\n"} {
+		source := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(source, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write template source: %v", err)
+		}
+		if _, err := templates.Add(ctx, strings.TrimSuffix(name, filepath.Ext(name)), source, false); err != nil {
+			t.Fatalf("failed to add template: %v", err)
+		}
+	}
+	if _, err := templates.Use(ctx, "fast"); err != nil {
+		t.Fatalf("failed to set the default template: %v", err)
+	}
+
+	layout := usecase.DefaultInitLayout()
+	layout.Root = root
+	layout.Language = "cpp17"
+	layout.Languages = initTestLanguages()
+	layout.TemplateDir = templateDir
+	uc := usecase.NewInitUseCase(&MockProblemRepository{}, layout)
+
+	if err := uc.Execute(ctx, "ITP1_1_A"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ExecuteWithOptions(ctx, "ITP1_1_B", usecase.InitOptions{Template: "script"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.ExecuteWithOptions(ctx, "ITP1_1_C", usecase.InitOptions{Language: "java", Template: "script"}); err == nil {
+		t.Errorf("expected an error for a template of another language")
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(root, "ITP1_1_A", "main.cpp"): "// fast\n",
+		filepath.Join(root, "ITP1_1_B", "main.py"):  "# script\n",
+	} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%s was not created: %v", path, err)
+		}
+		if string(content) != want {
+			t.Errorf("unexpected content of %s: %q", path, content)
+		}
+	}
+}
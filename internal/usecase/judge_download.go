@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// defaultMaxCaseFileSize caps a single downloaded input or output file
+const defaultMaxCaseFileSize = 256 << 20
+
+// downloadJudgeFile streams one part of a judge test case to path. The data
+// goes to a temporary file that replaces path only when it is complete, so an
+// interrupted or truncated download never leaves a partial test case behind.
+// It returns the SHA-256 sum of the data in hex
+func (uc *TestCaseUseCase) downloadJudgeFile(
+	ctx context.Context,
+	problemID model.ProblemID,
+	serial int,
+	part repository.JudgeCasePart,
+	path string,
+	progress func(n int64),
+) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to create test case file")
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	writer := &cappedWriter{
+		w:        io.MultiWriter(tmp, hash),
+		limit:    uc.settings.MaxCaseFileSize,
+		tooLarge: caseTooLarge(serial, part, uc.settings.MaxCaseFileSize),
+		progress: progress,
+	}
+	_, err = uc.testCaseRepo.DownloadJudgeCase(ctx, problemID, serial, part, writer)
+	if err != nil {
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", cerrors.Wrap(err, "failed to write test case file")
+	}
+	trace.File("write", path)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", cerrors.Wrap(err, "failed to write test case file")
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// caseTooLarge returns the error of a test case file above the size cap
+func caseTooLarge(serial int, part repository.JudgeCasePart, limit int64) error {
+	return cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		fmt.Sprintf("judge-%d.%s is larger than the limit of %s", serial, part, FormatSize(limit)),
+		nil,
+	)
+}
+
+// cappedWriter fails once more than limit bytes are written and reports the
+// progress of every write
+type cappedWriter struct {
+	w        io.Writer
+	written  int64
+	limit    int64
+	tooLarge error
+	progress func(n int64)
+}
+
+func (c *cappedWriter) Write(p []byte) (int, error) {
+	if c.written+int64(len(p)) > c.limit {
+		return 0, c.tooLarge
+	}
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	if c.progress != nil && n > 0 {
+		c.progress(int64(n))
+	}
+	if err != nil {
+		return n, cerrors.Wrap(err, "failed to write test case file")
+	}
+	return n, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// CompanionPort is the port the competitive-companion browser extension sends problems to
+const CompanionPort = 10043
+
+// CompanionProblem is a problem parsed by the competitive-companion browser
+// extension, see https://github.com/jmerle/competitive-companion
+type CompanionProblem struct {
+	Name        string          `json:"name"`
+	Group       string          `json:"group"`
+	URL         string          `json:"url"`
+	Interactive bool            `json:"interactive"`
+	MemoryLimit int             `json:"memoryLimit"` // in MB
+	TimeLimit   int             `json:"timeLimit"`   // in milliseconds
+	Tests       []CompanionTest `json:"tests"`
+	Batch       CompanionBatch  `json:"batch"`
+}
+
+// CompanionTest is a sample of a CompanionProblem
+type CompanionTest struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// CompanionBatch identifies the problems sent together, e.g. all problems of a contest
+type CompanionBatch struct {
+	ID   string `json:"id"`
+	Size int    `json:"size"`
+}
+
+// ListenResult describes a problem received from competitive-companion
+type ListenResult struct {
+	ProblemID string `json:"problem_id"`
+	Name      string `json:"name"`
+	Dir       string `json:"dir"`
+	Samples   int    `json:"samples"`
+}
+
+// ListenUseCase creates problem directories for the problems sent by competitive-companion
+type ListenUseCase struct {
+	initUseCase *InitUseCase
+	logger      *logger.Logger
+}
+
+// NewListenUseCase creates a new listen use case
+func NewListenUseCase(initUseCase *InitUseCase) *ListenUseCase {
+	return &ListenUseCase{
+		initUseCase: initUseCase,
+		logger:      logger.WithGroup("listen_usecase"),
+	}
+}
+
+// Import initializes the directory of a received problem like aoj init,
+// with the samples of the extension instead of the downloaded ones
+func (uc *ListenUseCase) Import(ctx context.Context, problem CompanionProblem, opts InitOptions) (*ListenResult, error) {
+	uc.logger.InfoContext(ctx, "received problem", "name", problem.Name, "url", problem.URL, "tests", len(problem.Tests))
+
+	problemID, err := ProblemIDFromURL(problem.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Samples = make([]model.TestCase, 0, len(problem.Tests))
+	for i, test := range problem.Tests {
+		opts.Samples = append(opts.Samples, *model.NewTestCase(i+1, test.Input, test.Output))
+	}
+	if err := uc.initUseCase.ExecuteWithOptions(ctx, problemID.String(), opts); err != nil {
+		return nil, err
+	}
+
+	return &ListenResult{
+		ProblemID: problemID.String(),
+		Name:      problem.Name,
+		Dir:       uc.initUseCase.ProblemDir(problemID.String()),
+		Samples:   len(problem.Tests),
+	}, nil
+}
+
+// ProblemIDFromURL returns the ID of the AOJ problem at rawURL, such as
+// https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A, a course or challenge
+// page ending in the ID, or the old description.jsp?id=ITP1_1_A
+func ProblemIDFromURL(rawURL string) (model.ProblemID, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.HasSuffix(u.Hostname(), "u-aizu.ac.jp") {
+		return model.ProblemID{}, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("%s is not an AOJ problem", rawURL),
+			err,
+		)
+	}
+
+	candidate := u.Query().Get("id")
+	if candidate == "" {
+		candidate = path.Base(strings.TrimSuffix(u.Path, "/"))
+	}
+	problemID, err := model.NewProblemID(candidate)
+	if err != nil {
+		return model.ProblemID{}, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("no problem ID found in %s", rawURL),
+			err,
+		)
+	}
+	return problemID, nil
+}
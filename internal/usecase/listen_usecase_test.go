@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestProblemIDFromURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A", want: "ITP1_1_A"},
+		{url: "https://onlinejudge.u-aizu.ac.jp/courses/lesson/2/ITP1/1/ITP1_1_A", want: "ITP1_1_A"},
+		{url: "https://onlinejudge.u-aizu.ac.jp/challenges/sources/ICPC/Regional/1160/", want: "1160"},
+		{url: "http://judge.u-aizu.ac.jp/onlinejudge/description.jsp?id=0001&lang=jp", want: "0001"},
+		{url: "https://atcoder.jp/contests/abc123/tasks/abc123_a", wantErr: true},
+		{url: "https://onlinejudge.u-aizu.ac.jp/home", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			// When
+			problemID, err := usecase.ProblemIDFromURL(tt.url)
+
+			// Then
+			if tt.wantErr {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProblemIDFromURL failed: %v", err)
+			}
+			assert.Equal(t, tt.want, problemID.String())
+		})
+	}
+}
+
+func TestListenUseCase_Import(t *testing.T) {
+	// Given
+	layout := usecase.DefaultInitLayout()
+	layout.Root = t.TempDir()
+	downloaded := []model.TestCase{*model.NewTestCase(1, "downloaded\n", "downloaded\n")}
+	uc := usecase.NewListenUseCase(usecase.NewInitUseCase(&MockProblemRepository{testCases: downloaded}, layout))
+	problem := usecase.CompanionProblem{
+		Name: "Hello World",
+		URL:  "https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A",
+		Tests: []usecase.CompanionTest{
+			{Input: "", Output: "Hello World\n"},
+			{Input: "x\n", Output: "Hello World\n"},
+		},
+	}
+
+	// When
+	result, err := uc.Import(context.Background(), problem, usecase.InitOptions{})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	dir := filepath.Join(layout.Root, "ITP1_1_A")
+	assert.Equal(t, &usecase.ListenResult{ProblemID: "ITP1_1_A", Name: "Hello World", Dir: dir, Samples: 2}, result)
+	input, err := os.ReadFile(filepath.Join(dir, layout.TestDir, "sample-2.in"))
+	if err != nil {
+		t.Fatalf("sample-2.in was not created: %v", err)
+	}
+	assert.Equal(t, "x\n", string(input))
+	output, err := os.ReadFile(filepath.Join(dir, layout.TestDir, "sample-1.out"))
+	if err != nil {
+		t.Fatalf("sample-1.out was not created: %v", err)
+	}
+	assert.Equal(t, "Hello World\n", string(output))
+}
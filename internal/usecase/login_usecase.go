@@ -78,6 +78,13 @@ func (uc *LoginUseCase) Execute(ctx context.Context, request LoginRequest) (*Log
 		return nil, cerrors.Wrap(err, "failed to set current session")
 	}
 
+	// Opportunistically clean up sessions left behind by earlier logins
+	if deleted, err := uc.sessionRepo.DeleteExpired(ctx); err != nil {
+		uc.logger.WarnContext(ctx, "failed to delete expired sessions", "error", err)
+	} else if deleted > 0 {
+		uc.logger.DebugContext(ctx, "deleted expired sessions", "deleted_count", deleted)
+	}
+
 	uc.logger.InfoContext(ctx, "login successful", 
 		"username", request.Username, 
 		"session_id", session.ID().MaskedString())
@@ -88,9 +88,9 @@ func (m *MockSessionRepository) DeleteByUsername(ctx context.Context, username s
 	return args.Error(0)
 }
 
-func (m *MockSessionRepository) DeleteExpired(ctx context.Context) error {
+func (m *MockSessionRepository) DeleteExpired(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
-	return args.Error(0)
+	return args.Int(0), args.Error(1)
 }
 
 func (m *MockSessionRepository) Exists(ctx context.Context, id model.SessionID) (bool, error) {
@@ -197,6 +197,7 @@ func TestLoginUseCase_Execute_ShouldSucceedWithValidCredentials(t *testing.T) {
 	mockAuthRepo.On("Login", ctx, "testuser", "password123").Return(expectedSession, nil)
 	mockSessionRepo.On("Save", ctx, expectedSession).Return(nil)
 	mockSessionRepo.On("SetCurrent", ctx, expectedSession).Return(nil)
+	mockSessionRepo.On("DeleteExpired", ctx).Return(2, nil)
 
 	// When
 	response, err := usecase.Execute(ctx, request)
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// NotesFileName is the name of the notes file in a problem directory
+const NotesFileName = "notes.md"
+
+// NoteUseCase manages the notes kept alongside each solution
+type NoteUseCase struct {
+	root    string
+	aliases ProblemAliases
+	logger  *logger.Logger
+}
+
+// NoteSettings holds the optional settings of the note use case
+type NoteSettings struct {
+	Aliases ProblemAliases // problem aliases accepted as problem IDs and directory names
+}
+
+// NewNoteUseCase creates a new NoteUseCase for problems under the workspace
+// root. An empty root means the current directory
+func NewNoteUseCase(root string, settings NoteSettings) *NoteUseCase {
+	return &NoteUseCase{
+		root:    root,
+		aliases: settings.Aliases,
+		logger:  logger.WithGroup("note_usecase"),
+	}
+}
+
+// Note locates the notes of a problem
+type Note struct {
+	ProblemID string `json:"problem_id"`
+	Path      string `json:"path"`
+}
+
+// Locate returns the notes file of the problem. Without a problem ID, the
+// current directory is the problem directory
+func (uc *NoteUseCase) Locate(problemID string) (*Note, error) {
+	dir := "."
+	if problemID != "" {
+		dir = filepath.Join(uc.root, problemID)
+		// The directory is named after the problem ID when init was not given the alias
+		if target, ok := uc.aliases[problemID]; ok {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				dir = filepath.Join(uc.root, target)
+			}
+		}
+	}
+	id, err := resolveProblemID(dir, problemID, uc.aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no directory for "+id.String()+". Run 'aoj init "+id.String()+"' first",
+			err,
+		)
+	}
+	return &Note{ProblemID: id.String(), Path: filepath.Join(dir, NotesFileName)}, nil
+}
+
+// Prepare returns the notes file of the problem, creating it from the
+// template when missing so that it can be opened in an editor
+func (uc *NoteUseCase) Prepare(ctx context.Context, problemID string) (*Note, error) {
+	note, err := uc.Locate(problemID)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileIfNotExists(note.Path, notesTemplate(note.ProblemID), 0644); err != nil {
+		return nil, cerrors.Wrap(err, "failed to create "+NotesFileName)
+	}
+
+	uc.logger.DebugContext(ctx, "notes ready", "problem_id", note.ProblemID, "path", note.Path)
+	return note, nil
+}
+
+// Read returns the content of the problem's notes
+func (uc *NoteUseCase) Read(ctx context.Context, problemID string) (*Note, string, error) {
+	note, err := uc.Locate(problemID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := os.ReadFile(note.Path)
+	if os.IsNotExist(err) {
+		return nil, "", cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no notes for "+note.ProblemID+". Run 'aoj note edit "+note.ProblemID+"' to write some",
+			err,
+		)
+	}
+	if err != nil {
+		return nil, "", cerrors.Wrap(err, "failed to read "+NotesFileName)
+	}
+
+	uc.logger.DebugContext(ctx, "notes read", "problem_id", note.ProblemID, "bytes", len(content))
+	return note, string(content), nil
+}
+
+// notesTemplate returns the initial content of a notes file
+func notesTemplate(problemID string) []byte {
+	return []byte("# " + problemID + " notes\n")
+}
+
+// notesSummary returns the first line of the notes in dir that is neither
+// blank nor a heading, or an empty string if there is none
+func notesSummary(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, NotesFileName))
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestNoteUseCase_PrepareAndRead(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "ITP1_1_A"), 0755))
+	uc := NewNoteUseCase(root, NoteSettings{})
+	ctx := context.Background()
+
+	// When
+	_, _, errBefore := uc.Read(ctx, "ITP1_1_A")
+	note, err := uc.Prepare(ctx, "ITP1_1_A")
+
+	// Then
+	assert.True(t, cerrors.IsAppError(errBefore, cerrors.CodeNotFound), "got %v", errBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "ITP1_1_A", NotesFileName), note.Path)
+
+	assert.NoError(t, os.WriteFile(note.Path, []byte("# ITP1_1_A notes\n\nWatch the trailing newline\n"), 0644))
+	_, err = uc.Prepare(ctx, "ITP1_1_A")
+	assert.NoError(t, err)
+
+	_, content, err := uc.Read(ctx, "ITP1_1_A")
+	assert.NoError(t, err)
+	assert.Equal(t, "# ITP1_1_A notes\n\nWatch the trailing newline\n", content, "Prepare keeps existing notes")
+}
+
+func TestNoteUseCase_Locate(t *testing.T) {
+	root := t.TempDir()
+	uc := NewNoteUseCase(root, NoteSettings{})
+
+	t.Run("problem not initialized", func(t *testing.T) {
+		_, err := uc.Locate("ITP1_1_B")
+
+		assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+	})
+
+	t.Run("invalid problem ID", func(t *testing.T) {
+		_, err := uc.Locate("../etc")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNoteUseCase_Locate_Alias(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "two-sum"), 0755))
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "ITP1_6_D"), 0755))
+	uc := NewNoteUseCase(root, NoteSettings{Aliases: ProblemAliases{"two-sum": "ITP1_6_D", "matrix": "ITP1_7_D"}})
+
+	// When
+	byAliasDir, aliasDirErr := uc.Locate("two-sum")
+	missing, missingErr := uc.Locate("matrix")
+
+	// Then
+	if aliasDirErr != nil {
+		t.Fatalf("Locate failed: %v", aliasDirErr)
+	}
+	assert.Equal(t, "ITP1_6_D", byAliasDir.ProblemID)
+	assert.Equal(t, filepath.Join(root, "two-sum", NotesFileName), byAliasDir.Path)
+	assert.Nil(t, missing)
+	assert.True(t, cerrors.IsAppError(missingErr, cerrors.CodeNotFound), "got %v", missingErr)
+}
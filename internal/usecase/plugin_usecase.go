@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// pluginContextVersion is incremented when fields of PluginContext change meaning
+const pluginContextVersion = 1
+
+// PluginUseCase runs external subcommands
+type PluginUseCase struct {
+	host     service.PluginHost
+	settings PluginSettings
+	logger   *logger.Logger
+}
+
+// PluginSettings contains what plugins are told about the environment
+type PluginSettings struct {
+	ConfigDir     string
+	WorkspaceRoot string
+	Sessions      repository.SessionRepository // tells the logged in user; nil leaves it out
+	Clock         clock.Clock
+	Aliases       ProblemAliases // problem aliases that directory names may use
+}
+
+// NewPluginUseCase creates a new PluginUseCase
+func NewPluginUseCase(host service.PluginHost, settings PluginSettings) *PluginUseCase {
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+
+	return &PluginUseCase{
+		host:     host,
+		settings: settings,
+		logger:   logger.WithGroup("plugin_usecase"),
+	}
+}
+
+// PluginContext is passed to plugins as JSON in AOJ_CONTEXT. It never contains
+// credentials
+type PluginContext struct {
+	Version       int    `json:"version"`
+	ConfigDir     string `json:"config_dir"`
+	WorkspaceRoot string `json:"workspace_root"`
+	ProblemID     string `json:"problem_id,omitempty"`  // problem of the current directory
+	ProblemDir    string `json:"problem_dir,omitempty"` // absolute path of the current directory
+	User          string `json:"user,omitempty"`        // user of a valid session
+	Offline       bool   `json:"offline,omitempty"`     // aoj was run with --offline
+}
+
+// List returns the installed plugins
+func (uc *PluginUseCase) List(ctx context.Context) ([]service.Plugin, error) {
+	plugins, err := uc.host.List(ctx)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list plugins")
+	}
+	return plugins, nil
+}
+
+// Find returns the plugin providing the subcommand, or a NotFound error
+func (uc *PluginUseCase) Find(ctx context.Context, name string) (*service.Plugin, error) {
+	return uc.host.Find(ctx, name)
+}
+
+// Run executes the plugin with the context in its environment and returns
+// its exit status
+func (uc *PluginUseCase) Run(ctx context.Context, plugin service.Plugin, args []string) (int, error) {
+	env, err := uc.environment(uc.Context(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	code, err := uc.host.Run(ctx, plugin, args, env)
+	if err != nil {
+		return 0, err
+	}
+	uc.logger.DebugContext(ctx, "plugin finished", "name", plugin.Name, "exit_code", code)
+	return code, nil
+}
+
+// Context describes the environment the plugin runs in
+func (uc *PluginUseCase) Context(ctx context.Context) PluginContext {
+	pluginCtx := PluginContext{
+		Version:       pluginContextVersion,
+		ConfigDir:     uc.settings.ConfigDir,
+		WorkspaceRoot: uc.settings.WorkspaceRoot,
+		Offline:       offline.IsOffline(ctx),
+	}
+
+	if problemID, err := resolveProblemID(".", "", uc.settings.Aliases); err == nil {
+		pluginCtx.ProblemID = problemID.String()
+		if dir, err := filepath.Abs("."); err == nil {
+			pluginCtx.ProblemDir = dir
+		}
+	}
+
+	if uc.settings.Sessions != nil {
+		session, err := uc.settings.Sessions.GetCurrent(ctx)
+		if err == nil && session != nil && !session.IsExpiredAt(uc.settings.Clock.Now()) {
+			pluginCtx.User = session.Username()
+		} else {
+			uc.logger.DebugContext(ctx, "no session for plugin context", "error", err)
+		}
+	}
+	return pluginCtx
+}
+
+// environment returns the variables describing the context: AOJ_CONTEXT holds
+// all of it as JSON and the others hold single fields for shell scripts
+func (uc *PluginUseCase) environment(pluginCtx PluginContext) ([]string, error) {
+	content, err := json.Marshal(pluginCtx)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to encode plugin context")
+	}
+
+	env := []string{
+		"AOJ_CONTEXT=" + string(content),
+		"AOJ_CONFIG_DIR=" + pluginCtx.ConfigDir,
+		"AOJ_WORKSPACE_ROOT=" + pluginCtx.WorkspaceRoot,
+		"AOJ_PROBLEM_ID=" + pluginCtx.ProblemID,
+		"AOJ_USER=" + pluginCtx.User,
+	}
+	if pluginCtx.Offline {
+		env = append(env, "AOJ_OFFLINE=1")
+	}
+	return env, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/offline"
+)
+
+// recordingPluginHost records how plugins are run
+type recordingPluginHost struct {
+	service.PluginHost
+	args []string
+	env  []string
+}
+
+func (h *recordingPluginHost) Run(_ context.Context, _ service.Plugin, args []string, env []string) (int, error) {
+	h.args, h.env = args, env
+	return 2, nil
+}
+
+func TestPluginUseCase_Run(t *testing.T) {
+	// Given
+	t.Chdir(t.TempDir())
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "alice", "secret-token", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	host := &recordingPluginHost{}
+	uc := NewPluginUseCase(host, PluginSettings{ConfigDir: "/config/aoj", WorkspaceRoot: "/work", Sessions: sessionRepo})
+
+	// When
+	code, err := uc.Run(offline.WithOffline(context.Background(), true), service.Plugin{Name: "graph"}, []string{"--days", "30"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, code)
+	assert.Equal(t, []string{"--days", "30"}, host.args)
+	assert.Contains(t, host.env, "AOJ_USER=alice")
+	assert.Contains(t, host.env, "AOJ_WORKSPACE_ROOT=/work")
+	assert.Contains(t, host.env, "AOJ_PROBLEM_ID=", "the temporary directory is not a problem directory")
+	assert.Contains(t, host.env, "AOJ_OFFLINE=1")
+
+	var pluginCtx PluginContext
+	for _, variable := range host.env {
+		if value, ok := strings.CutPrefix(variable, "AOJ_CONTEXT="); ok {
+			assert.NoError(t, json.Unmarshal([]byte(value), &pluginCtx))
+		}
+		assert.NotContains(t, variable, "secret-token")
+	}
+	assert.Equal(t, PluginContext{Version: 1, ConfigDir: "/config/aoj", WorkspaceRoot: "/work", User: "alice", Offline: true}, pluginCtx)
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// resolveProblemID returns the explicit problem ID or infers it from dir and
+// its ancestors, nearest first: the ID recorded in problem.toml, then the
+// directory name, which may be an alias. Solutions kept in subdirectories
+// such as ITP1_1_A/solutions/cpp thereby resolve to their problem
+func resolveProblemID(dir, explicitID string, aliases ProblemAliases) (model.ProblemID, error) {
+	if explicitID != "" {
+		return aliases.ParseProblemID(explicitID)
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return model.ProblemID{}, cerrors.Wrap(err, "failed to resolve problem directory")
+	}
+
+	var tried []string
+	for current := abs; ; current = filepath.Dir(current) {
+		if problemConfig, err := config.LoadProblemConfig(current); err == nil && problemConfig.ProblemID != "" {
+			return aliases.ParseProblemID(problemConfig.ProblemID)
+		}
+
+		name := filepath.Base(current)
+		if problemID, err := aliases.ParseProblemID(name); err == nil {
+			return problemID, nil
+		}
+		if filepath.Dir(current) == current {
+			break
+		}
+		tried = append(tried, name)
+	}
+
+	return model.ProblemID{}, cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		fmt.Sprintf("could not determine problem ID of %s: no %s and no directory named after a problem or alias (tried %s). Please specify --problem-id",
+			abs, config.ProblemConfigFile, strings.Join(tried, ", ")),
+		nil,
+	)
+}
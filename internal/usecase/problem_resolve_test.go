@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+func TestResolveProblemID_Ancestors(t *testing.T) {
+	root := t.TempDir()
+	aliases := ProblemAliases{"two-sum": "ITP1_6_D"}
+
+	recorded := filepath.Join(root, "renamed")
+	assert.NoError(t, os.MkdirAll(filepath.Join(recorded, "solutions"), 0755))
+	assert.NoError(t, config.SaveProblemConfig(recorded, &config.ProblemConfig{ProblemID: "ALDS1_1_A"}))
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{"problem directory", filepath.Join(root, "ITP1_1_A"), "ITP1_1_A"},
+		{"nested solution directory", filepath.Join(root, "ITP1", "ITP1_1_A", "solutions", "cpp"), "ITP1_1_A"},
+		{"problem.toml of an ancestor", filepath.Join(recorded, "solutions"), "ALDS1_1_A"},
+		{"alias of an ancestor", filepath.Join(root, "two-sum", "attempt2"), "ITP1_6_D"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			assert.NoError(t, os.MkdirAll(tt.dir, 0755))
+
+			// When
+			problemID, err := resolveProblemID(tt.dir, "", aliases)
+
+			// Then
+			if err != nil {
+				t.Fatalf("resolveProblemID failed: %v", err)
+			}
+			assert.Equal(t, tt.want, problemID.String())
+		})
+	}
+}
+
+func TestResolveProblemID_NotFound(t *testing.T) {
+	// Given
+	dir := filepath.Join(t.TempDir(), "scratch", "cpp")
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+
+	// When
+	_, err := resolveProblemID(dir, "", nil)
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+	assert.Contains(t, err.Error(), "tried cpp, scratch, ")
+	assert.Contains(t, err.Error(), "--problem-id")
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// DefaultProblemSources maps the built-in source names to their AOJ archive
+// categories, "<large>/<middle>" as in /challenges/cl/icpc/domestic
+var DefaultProblemSources = map[string]string{
+	"icpc-domestic": "icpc/domestic",
+	"icpc-asia":     "icpc/regional",
+	"jag-prelim":    "jag/prelim",
+	"jag-regional":  "jag/regional",
+}
+
+// ProblemSource is an archive of past contests on AOJ
+type ProblemSource struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// ProblemResolver turns source references such as icpc-domestic:2020/A into
+// AOJ problem IDs, so that archived contest problems are initialized, tested
+// and submitted like any other problem
+type ProblemResolver struct {
+	challengeRepo repository.ChallengeRepository
+	sources       map[string]string
+	logger        *logger.Logger
+}
+
+// NewProblemResolver creates a new ProblemResolver knowing the built-in
+// sources and the given ones, which take precedence
+func NewProblemResolver(challengeRepo repository.ChallengeRepository, sources map[string]string) *ProblemResolver {
+	merged := make(map[string]string, len(DefaultProblemSources)+len(sources))
+	for name, category := range DefaultProblemSources {
+		merged[name] = category
+	}
+	for name, category := range sources {
+		merged[strings.ToLower(name)] = strings.Trim(category, "/")
+	}
+
+	return &ProblemResolver{
+		challengeRepo: challengeRepo,
+		sources:       merged,
+		logger:        logger.WithGroup("problem_resolver"),
+	}
+}
+
+// isSourceReference reports whether value is a source reference rather than a problem ID or alias
+func isSourceReference(value string) bool {
+	return strings.Contains(value, ":")
+}
+
+// Sources returns the known sources sorted by name
+func (r *ProblemResolver) Sources() []ProblemSource {
+	sources := make([]ProblemSource, 0, len(r.sources))
+	for name, category := range r.sources {
+		sources = append(sources, ProblemSource{Name: name, Category: category})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources
+}
+
+// Contests returns the contests of a source, newest first
+func (r *ProblemResolver) Contests(ctx context.Context, source string) ([]repository.ChallengeContest, error) {
+	category, ok := r.sources[strings.ToLower(strings.TrimSpace(source))]
+	if !ok {
+		names := make([]string, 0, len(r.sources))
+		for _, s := range r.Sources() {
+			names = append(names, s.Name)
+		}
+		return nil, cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeNotFound, "unknown problem source "+source, nil),
+			"Known sources: "+strings.Join(names, ", ")+". Add others under [sources] in config.toml",
+		)
+	}
+
+	contests, err := r.challengeRepo.ListContests(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	sorted := append([]repository.ChallengeContest(nil), contests...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Year > sorted[j].Year })
+	return sorted, nil
+}
+
+// Expand replaces the source references of refs with the problem IDs they
+// stand for: <source>:<contest> is every problem of the contest and
+// <source>:<contest>/<letter> one of them, where contest is a year or an
+// abbreviation. Other values are kept as they are
+func (r *ProblemResolver) Expand(ctx context.Context, refs []string) ([]string, error) {
+	problemIDs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if !isSourceReference(ref) {
+			problemIDs = append(problemIDs, ref)
+			continue
+		}
+		ids, err := r.resolve(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		r.logger.DebugContext(ctx, "resolved source reference", "reference", ref, "problem_ids", ids)
+		problemIDs = append(problemIDs, ids...)
+	}
+	return problemIDs, nil
+}
+
+// resolve returns the problem IDs of a single source reference
+func (r *ProblemResolver) resolve(ctx context.Context, ref string) ([]string, error) {
+	source, rest, _ := strings.Cut(strings.TrimSpace(ref), ":")
+	key, letter, hasLetter := strings.Cut(rest, "/")
+	if key == "" || (hasLetter && letter == "") {
+		return nil, cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeInvalidInput, "invalid problem reference "+ref, nil),
+			"Use <source>:<year or contest>[/<letter>], e.g. icpc-domestic:2020/A",
+		)
+	}
+
+	contests, err := r.Contests(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	contest, err := findContest(contests, source, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasLetter {
+		ids := make([]string, 0, len(contest.Problems))
+		for _, problem := range contest.Problems {
+			ids = append(ids, problem.ID)
+		}
+		return ids, nil
+	}
+
+	index, ok := problemIndex(letter)
+	if !ok || index >= len(contest.Problems) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("%s has no problem %s (it has %d problems)", contest.Abbr, letter, len(contest.Problems)),
+			nil,
+		)
+	}
+	return []string{contest.Problems[index].ID}, nil
+}
+
+// findContest returns the contest whose abbreviation or year is key
+func findContest(contests []repository.ChallengeContest, source, key string) (*repository.ChallengeContest, error) {
+	for i := range contests {
+		if strings.EqualFold(contests[i].Abbr, key) {
+			return &contests[i], nil
+		}
+	}
+
+	year, err := strconv.Atoi(key)
+	var matches []*repository.ChallengeContest
+	if err == nil {
+		for i := range contests {
+			if contests[i].Year == year {
+				matches = append(matches, &contests[i])
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeNotFound, fmt.Sprintf("no contest %s in %s", key, source), nil),
+			"Run 'aoj source list "+source+"' to see its contests",
+		)
+	case 1:
+		return matches[0], nil
+	default:
+		abbrs := make([]string, 0, len(matches))
+		for _, contest := range matches {
+			abbrs = append(abbrs, contest.Abbr)
+		}
+		return nil, cerrors.WithHint(
+			cerrors.NewAppError(cerrors.CodeConflict, fmt.Sprintf("%s has several contests in %d", source, year), nil),
+			"Name one of them instead: "+strings.Join(abbrs, ", "),
+		)
+	}
+}
+
+// problemIndex converts a problem letter (A, B, ...) or a 1-based number into a 0-based index
+func problemIndex(letter string) (int, bool) {
+	if n, err := strconv.Atoi(letter); err == nil {
+		return n - 1, n > 0
+	}
+	if len(letter) != 1 {
+		return 0, false
+	}
+	c := strings.ToUpper(letter)[0]
+	if c < 'A' || c > 'Z' {
+		return 0, false
+	}
+	return int(c - 'A'), true
+}
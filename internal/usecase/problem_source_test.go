@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// stubChallengeRepository serves the contests of each category
+type stubChallengeRepository struct {
+	contests map[string][]repository.ChallengeContest
+}
+
+func (r *stubChallengeRepository) ListContests(_ context.Context, category string) ([]repository.ChallengeContest, error) {
+	contests, ok := r.contests[category]
+	if !ok {
+		return nil, cerrors.NewAppError(cerrors.CodeNotFound, "contest archive "+category+" not found on AOJ", nil)
+	}
+	return contests, nil
+}
+
+func newTestResolver() *ProblemResolver {
+	return NewProblemResolver(&stubChallengeRepository{contests: map[string][]repository.ChallengeContest{
+		"icpc/domestic": {
+			{Year: 2019, Abbr: "ICPCOOC2019", Problems: []repository.ChallengeProblem{{ID: "1626"}}},
+			{Year: 2020, Abbr: "ICPCOOC2020", Problems: []repository.ChallengeProblem{{ID: "1630"}, {ID: "1631"}, {ID: "1632"}}},
+		},
+		"jag/spring": {
+			{Year: 2021, Abbr: "JAGSpring2021Day1", Problems: []repository.ChallengeProblem{{ID: "3300"}}},
+			{Year: 2021, Abbr: "JAGSpring2021Day2", Problems: []repository.ChallengeProblem{{ID: "3310"}}},
+		},
+	}}, map[string]string{"JAG-Spring": "/jag/spring/"})
+}
+
+func TestProblemResolver_Expand(t *testing.T) {
+	tests := []struct {
+		name string
+		refs []string
+		want []string
+	}{
+		{name: "problem IDs and aliases are kept", refs: []string{"ITP1_1_A", "two-sum"}, want: []string{"ITP1_1_A", "two-sum"}},
+		{name: "whole contest by year", refs: []string{"icpc-domestic:2020"}, want: []string{"1630", "1631", "1632"}},
+		{name: "problem by letter", refs: []string{"icpc-domestic:2020/b", "ITP1_1_A"}, want: []string{"1631", "ITP1_1_A"}},
+		{name: "problem by number", refs: []string{"icpc-domestic:2020/3"}, want: []string{"1632"}},
+		{name: "contest by abbreviation", refs: []string{"ICPC-Domestic:icpcooc2019/A"}, want: []string{"1626"}},
+		{name: "configured source", refs: []string{"jag-spring:JAGSpring2021Day2"}, want: []string{"3310"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			resolver := newTestResolver()
+
+			// When
+			got, err := resolver.Expand(context.Background(), tt.refs)
+
+			// Then
+			if err != nil {
+				t.Fatalf("Expand failed: %v", err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProblemResolver_Expand_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		code cerrors.ErrorCode
+	}{
+		{name: "unknown source", ref: "atcoder:2020", code: cerrors.CodeNotFound},
+		{name: "unknown contest", ref: "icpc-domestic:1999", code: cerrors.CodeNotFound},
+		{name: "letter out of range", ref: "icpc-domestic:2020/D", code: cerrors.CodeNotFound},
+		{name: "several contests in a year", ref: "jag-spring:2021", code: cerrors.CodeConflict},
+		{name: "missing contest", ref: "icpc-domestic:", code: cerrors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			resolver := newTestResolver()
+
+			// When
+			_, err := resolver.Expand(context.Background(), []string{tt.ref})
+
+			// Then
+			assert.True(t, cerrors.IsAppError(err, tt.code), "got %v", err)
+		})
+	}
+}
+
+func TestProblemResolver_Sources(t *testing.T) {
+	// Given
+	resolver := newTestResolver()
+
+	// When
+	sources := resolver.Sources()
+
+	// Then
+	assert.Equal(t, []ProblemSource{
+		{Name: "icpc-asia", Category: "icpc/regional"},
+		{Name: "icpc-domestic", Category: "icpc/domestic"},
+		{Name: "jag-prelim", Category: "jag/prelim"},
+		{Name: "jag-regional", Category: "jag/regional"},
+		{Name: "jag-spring", Category: "jag/spring"},
+	}, sources)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ProgressUseCase reports how much of a course is solved
+type ProgressUseCase struct {
+	courseRepo     repository.CourseRepository
+	submissionRepo repository.SubmissionRepository
+	logger         *logger.Logger
+}
+
+// NewProgressUseCase creates a new ProgressUseCase. submissionRepo tells which
+// problems are solved
+func NewProgressUseCase(courseRepo repository.CourseRepository, submissionRepo repository.SubmissionRepository) *ProgressUseCase {
+	return &ProgressUseCase{
+		courseRepo:     courseRepo,
+		submissionRepo: submissionRepo,
+		logger:         logger.WithGroup("progress_usecase"),
+	}
+}
+
+// CourseProgress is the completion of a course
+type CourseProgress struct {
+	Course   string            `json:"course"`
+	Title    string            `json:"title"`
+	Solved   int               `json:"solved"`
+	Total    int               `json:"total"`
+	Chapters []ChapterProgress `json:"chapters"`
+}
+
+// ChapterProgress is the completion of a topic of a course
+type ChapterProgress struct {
+	Chapter  int                        `json:"chapter"`
+	Title    string                     `json:"title"`
+	Solved   int                        `json:"solved"`
+	Total    int                        `json:"total"`
+	Unsolved []repository.CourseProblem `json:"unsolved"`
+}
+
+// Progress returns the solved and remaining problems of a course per chapter
+func (uc *ProgressUseCase) Progress(ctx context.Context, course string) (*CourseProgress, error) {
+	catalogue, err := uc.courseRepo.GetCourse(ctx, course)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read course "+course)
+	}
+
+	accepted := entity.StatusAccepted
+	submissions, err := uc.submissionRepo.Search(ctx, repository.SubmissionSearchCriteria{Status: &accepted})
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+	solved := make(map[string]bool, len(submissions))
+	for _, submission := range submissions {
+		solved[submission.ProblemID().String()] = true
+	}
+
+	progress := &CourseProgress{
+		Course:   catalogue.Name,
+		Title:    catalogue.Title,
+		Chapters: make([]ChapterProgress, 0, len(catalogue.Topics)),
+	}
+	for _, topic := range catalogue.Topics {
+		chapter := ChapterProgress{
+			Chapter:  topic.Chapter,
+			Title:    topic.Title,
+			Total:    len(topic.Problems),
+			Unsolved: []repository.CourseProblem{},
+		}
+		for _, problem := range topic.Problems {
+			if solved[problem.ID] {
+				chapter.Solved++
+			} else {
+				chapter.Unsolved = append(chapter.Unsolved, problem)
+			}
+		}
+		progress.Solved += chapter.Solved
+		progress.Total += chapter.Total
+		progress.Chapters = append(progress.Chapters, chapter)
+	}
+
+	uc.logger.InfoContext(ctx, "computed course progress", "course", progress.Course, "solved", progress.Solved, "total", progress.Total)
+	return progress, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// stubCourseRepository serves one course
+type stubCourseRepository struct {
+	course *repository.Course
+}
+
+func (r *stubCourseRepository) GetCourse(_ context.Context, name string) (*repository.Course, error) {
+	if r.course == nil || name != r.course.Name {
+		return nil, cerrors.NewAppError(cerrors.CodeNotFound, "course "+name+" not found on AOJ", nil)
+	}
+	return r.course, nil
+}
+
+func TestProgressUseCase_Progress(t *testing.T) {
+	// Given
+	courses := &stubCourseRepository{course: &repository.Course{
+		Name:  "ITP1",
+		Title: "Introduction to Programming I",
+		Topics: []repository.CourseTopic{
+			{Chapter: 1, Title: "Getting Started", Problems: []repository.CourseProblem{{ID: "ITP1_1_A"}, {ID: "ITP1_1_B"}}},
+			{Chapter: 2, Title: "Branch on Condition", Problems: []repository.CourseProblem{{ID: "ITP1_2_A"}, {ID: "ITP1_2_B", Title: "Range"}}},
+		},
+	}}
+	submittedAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Search", mock.Anything, mock.Anything).Return([]*entity.Submission{
+		acceptedSubmission("ITP1_1_A", "C++17", "", "1", submittedAt),
+		acceptedSubmission("ITP1_1_B", "C++17", "", "2", submittedAt),
+		acceptedSubmission("ITP1_1_B", "C++17", "", "3", submittedAt),
+		acceptedSubmission("ITP1_2_A", "C++17", "", "4", submittedAt),
+		acceptedSubmission("ALDS1_1_A", "C++17", "", "5", submittedAt),
+	}, nil)
+	uc := NewProgressUseCase(courses, submissionRepo)
+
+	// When
+	progress, err := uc.Progress(context.Background(), "ITP1")
+
+	// Then
+	if err != nil {
+		t.Fatalf("Progress failed: %v", err)
+	}
+	assert.Equal(t, 3, progress.Solved)
+	assert.Equal(t, 4, progress.Total)
+	assert.Equal(t, []ChapterProgress{
+		{Chapter: 1, Title: "Getting Started", Solved: 2, Total: 2, Unsolved: []repository.CourseProblem{}},
+		{Chapter: 2, Title: "Branch on Condition", Solved: 1, Total: 2, Unsolved: []repository.CourseProblem{{ID: "ITP1_2_B", Title: "Range"}}},
+	}, progress.Chapters)
+}
+
+func TestProgressUseCase_Progress_UnknownCourse(t *testing.T) {
+	// Given
+	uc := NewProgressUseCase(&stubCourseRepository{}, &MockSubmissionRepository{})
+
+	// When
+	_, err := uc.Progress(context.Background(), "XYZ1")
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// RandomUseCase picks practice problems at random
+type RandomUseCase struct {
+	problemRepo    repository.ProblemRepository
+	submissionRepo repository.SubmissionRepository
+	difficulty     *DifficultyUseCase
+	rand           *rand.Rand
+	logger         *logger.Logger
+}
+
+// RandomSettings holds the optional settings of the random use case
+type RandomSettings struct {
+	Difficulty *DifficultyUseCase // estimated difficulties to show and filter by
+	Rand       *rand.Rand         // source of the draws; nil seeds one randomly
+}
+
+// NewRandomUseCase creates a new RandomUseCase. submissionRepo tells which
+// problems are solved
+func NewRandomUseCase(problemRepo repository.ProblemRepository, submissionRepo repository.SubmissionRepository, settings RandomSettings) *RandomUseCase {
+	if settings.Rand == nil {
+		settings.Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	return &RandomUseCase{
+		problemRepo:    problemRepo,
+		submissionRepo: submissionRepo,
+		difficulty:     settings.Difficulty,
+		rand:           settings.Rand,
+		logger:         logger.WithGroup("random_usecase"),
+	}
+}
+
+// RandomOptions contains options for picking a problem
+type RandomOptions struct {
+	Category string // Optional: prefix of the problem ID, e.g. ITP or ALDS1_1
+	Title    string // Optional: substring of the title
+	Unsolved bool   // Optional: skip problems accepted in the local history
+	// Difficulty optionally skips problems whose estimated difficulty is out
+	// of range or unknown
+	Difficulty DifficultyRange
+}
+
+// RandomProblem describes the picked problem
+type RandomProblem struct {
+	ProblemID   string        `json:"problem_id"`
+	Title       string        `json:"title"`
+	TimeLimit   time.Duration `json:"time_limit"`
+	MemoryLimit int64         `json:"memory_limit"` // in KB
+	URL         string        `json:"url"`
+	Difficulty  *float64      `json:"difficulty,omitempty"` // estimated; nil when unknown
+	Candidates  int           `json:"candidates"`           // number of problems matching the filters
+}
+
+// Pick returns a random problem matching the filters
+func (uc *RandomUseCase) Pick(ctx context.Context, opts RandomOptions) (*RandomProblem, error) {
+	problems, err := uc.problemRepo.Search(ctx, repository.ProblemSearchCriteria{Category: opts.Category, Title: opts.Title})
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list problems")
+	}
+
+	if opts.Unsolved {
+		solved, err := uc.solvedProblems(ctx)
+		if err != nil {
+			return nil, err
+		}
+		unsolved := make([]*entity.Problem, 0, len(problems))
+		for _, problem := range problems {
+			if !solved[problem.ID().String()] {
+				unsolved = append(unsolved, problem)
+			}
+		}
+		problems = unsolved
+	}
+
+	var difficulties map[string]float64
+	if opts.Difficulty.IsSet() {
+		difficulties, err = uc.difficulty.Table(ctx)
+		if err != nil {
+			return nil, err
+		}
+		inRange := make([]*entity.Problem, 0, len(problems))
+		for _, problem := range problems {
+			if difficulty, ok := difficulties[problem.ID().String()]; ok && opts.Difficulty.Contains(difficulty) {
+				inRange = append(inRange, problem)
+			}
+		}
+		problems = inRange
+	} else {
+		difficulties = uc.difficulty.Lookup(ctx)
+	}
+
+	if len(problems) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no problems match the filters",
+			nil,
+		)
+	}
+
+	problem := problems[uc.rand.IntN(len(problems))]
+	uc.logger.InfoContext(ctx, "picked random problem", "problem_id", problem.ID().String(), "candidates", len(problems))
+	picked := &RandomProblem{
+		ProblemID:   problem.ID().String(),
+		Title:       problem.Title(),
+		TimeLimit:   problem.TimeLimit(),
+		MemoryLimit: problem.MemoryLimit(),
+		URL:         aojProblemURL + problem.ID().String(),
+		Candidates:  len(problems),
+	}
+	if difficulty, ok := difficulties[picked.ProblemID]; ok {
+		picked.Difficulty = &difficulty
+	}
+	return picked, nil
+}
+
+// solvedProblems returns the IDs of the problems accepted in the local history
+func (uc *RandomUseCase) solvedProblems(ctx context.Context) (map[string]bool, error) {
+	accepted := entity.StatusAccepted
+	submissions, err := uc.submissionRepo.Search(ctx, repository.SubmissionSearchCriteria{Status: &accepted})
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+
+	solved := make(map[string]bool, len(submissions))
+	for _, submission := range submissions {
+		solved[submission.ProblemID().String()] = true
+	}
+	return solved, nil
+}
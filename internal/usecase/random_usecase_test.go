@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// listProblemRepository lists problems filtered by category like the AOJ repository
+type listProblemRepository struct {
+	repository.ProblemRepository
+	ids []string
+}
+
+func (r *listProblemRepository) Search(_ context.Context, criteria repository.ProblemSearchCriteria) ([]*entity.Problem, error) {
+	var problems []*entity.Problem
+	for _, id := range r.ids {
+		if strings.HasPrefix(id, criteria.Category) {
+			problems = append(problems, entity.NewProblem(model.MustNewProblemID(id), "Title of "+id, "", time.Second, 131072, "", 0))
+		}
+	}
+	return problems, nil
+}
+
+// staticDifficulties provides a fixed difficulty table
+type staticDifficulties map[string]float64
+
+func (d staticDifficulties) Difficulties(_ context.Context) (map[string]float64, error) {
+	return d, nil
+}
+
+func TestRandomUseCase_Pick(t *testing.T) {
+	problems := &listProblemRepository{ids: []string{"ITP1_1_A", "ITP1_1_B", "ITP1_1_C", "ALDS1_1_A"}}
+
+	tests := []struct {
+		name         string
+		opts         RandomOptions
+		solved       []string
+		difficulties staticDifficulties
+		want         []string // possible picks
+		wantCode     cerrors.ErrorCode
+	}{
+		{name: "category", opts: RandomOptions{Category: "ITP"}, want: []string{"ITP1_1_A", "ITP1_1_B", "ITP1_1_C"}},
+		{name: "unsolved", opts: RandomOptions{Category: "ITP", Unsolved: true}, solved: []string{"ITP1_1_A", "ITP1_1_C"}, want: []string{"ITP1_1_B"}},
+		{name: "solved problems count without --unsolved", opts: RandomOptions{Category: "ALDS"}, solved: []string{"ALDS1_1_A"}, want: []string{"ALDS1_1_A"}},
+		{name: "everything solved", opts: RandomOptions{Category: "ALDS", Unsolved: true}, solved: []string{"ALDS1_1_A"}, wantCode: cerrors.CodeNotFound},
+		{name: "unknown category", opts: RandomOptions{Category: "XYZ"}, wantCode: cerrors.CodeNotFound},
+		{
+			name:         "difficulty range skips unrated problems",
+			opts:         RandomOptions{Category: "ITP", Difficulty: DifficultyRange{Min: 100, Max: 200}},
+			difficulties: staticDifficulties{"ITP1_1_A": 50, "ITP1_1_B": 100, "ITP1_1_C": 250, "ALDS1_1_A": 150},
+			want:         []string{"ITP1_1_B"},
+		},
+		{name: "difficulty without a table", opts: RandomOptions{Difficulty: DifficultyRange{Max: 200}}, wantCode: cerrors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			var accepted []*entity.Submission
+			for i, id := range tt.solved {
+				accepted = append(accepted, acceptedSubmission(id, "C++17", "", string(rune('1'+i)), time.Now()))
+			}
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Search", mock.Anything, mock.Anything).Return(accepted, nil)
+			uc := NewRandomUseCase(problems, submissionRepo, RandomSettings{Rand: rand.New(rand.NewPCG(1, 2))})
+			if tt.difficulties != nil {
+				uc.difficulty = NewDifficultyUseCase(tt.difficulties)
+			}
+
+			// When
+			picked, err := uc.Pick(context.Background(), tt.opts)
+
+			// Then
+			if tt.wantCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, tt.want, picked.ProblemID)
+			assert.Equal(t, len(tt.want), picked.Candidates)
+			assert.Equal(t, "Title of "+picked.ProblemID, picked.Title)
+			assert.Equal(t, "https://onlinejudge.u-aizu.ac.jp/problems/"+picked.ProblemID, picked.URL)
+			if difficulty, ok := tt.difficulties[picked.ProblemID]; ok {
+				assert.Equal(t, &difficulty, picked.Difficulty)
+			} else {
+				assert.Nil(t, picked.Difficulty)
+			}
+		})
+	}
+}
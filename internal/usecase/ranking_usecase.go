@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// Orders of aoj ranking
+const (
+	RankByTime   = "time"
+	RankByMemory = "memory"
+	RankByLength = "length"
+)
+
+// Ranking defaults
+const (
+	rankingFetchSize    = 1000 // solutions requested from the judge
+	defaultRankingLimit = 10   // solutions shown
+)
+
+// RankingUseCase ranks the accepted solutions of a problem
+type RankingUseCase struct {
+	solutionRepo   repository.SolutionRepository
+	submissionRepo repository.SubmissionRepository
+	aliases        ProblemAliases
+	logger         *logger.Logger
+}
+
+// RankingSettings holds the optional settings of the ranking use case
+type RankingSettings struct {
+	Aliases ProblemAliases // problem aliases accepted as problem IDs
+}
+
+// NewRankingUseCase creates a new RankingUseCase. submissionRepo provides the
+// user's own accepted submissions and may be nil
+func NewRankingUseCase(solutionRepo repository.SolutionRepository, submissionRepo repository.SubmissionRepository, settings RankingSettings) *RankingUseCase {
+	return &RankingUseCase{
+		solutionRepo:   solutionRepo,
+		submissionRepo: submissionRepo,
+		aliases:        settings.Aliases,
+		logger:         logger.WithGroup("ranking_usecase"),
+	}
+}
+
+// RankingOptions contains options for ranking solutions
+type RankingOptions struct {
+	ProblemID string
+	By        string // RankByTime (default), RankByMemory or RankByLength
+	Language  string // Optional: only rank solutions in this language; "C++" matches every C++ version
+	Limit     int    // Optional: number of solutions to return (defaults to 10)
+}
+
+// RankedSolution is a solution with its position in the ranking
+type RankedSolution struct {
+	Rank int `json:"rank"`
+	repository.Solution
+}
+
+// RankingResult holds the top solutions of a problem
+type RankingResult struct {
+	ProblemID string           `json:"problem_id"`
+	By        string           `json:"by"`
+	Total     int              `json:"total"` // number of ranked solutions
+	Median    RankedSolution   `json:"median"`
+	Solutions []RankedSolution `json:"solutions"`
+	// Own is the user's best accepted submission in the local history, ranked
+	// among the solutions; nil if there is none
+	Own *RankedSolution `json:"own,omitempty"`
+}
+
+// Rank fetches the accepted solutions of a problem and orders them by
+// execution time, memory or code length, ties broken by the other metrics
+// and then by submission date
+func (uc *RankingUseCase) Rank(ctx context.Context, opts RankingOptions) (*RankingResult, error) {
+	problemID, err := uc.aliases.ParseProblemID(opts.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	if opts.By == "" {
+		opts.By = RankByTime
+	}
+	less, ok := rankingOrders[opts.By]
+	if !ok {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("unknown ranking order '%s'. Available: %s, %s, %s", opts.By, RankByTime, RankByMemory, RankByLength),
+			nil,
+		)
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultRankingLimit
+	}
+
+	solutions, err := uc.solutionRepo.GetByProblemID(ctx, problemID, rankingFetchSize)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to fetch solutions")
+	}
+	if opts.Language != "" {
+		solutions = filterSolutionsByLanguage(solutions, opts.Language)
+	}
+	if len(solutions) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no accepted solutions found for "+problemID.String(),
+			nil,
+		)
+	}
+	sort.SliceStable(solutions, func(i, j int) bool { return less(solutions[i], solutions[j]) })
+
+	ranked := make([]RankedSolution, len(solutions))
+	for i, solution := range solutions {
+		ranked[i] = RankedSolution{Rank: i + 1, Solution: solution}
+	}
+
+	result := &RankingResult{
+		ProblemID: problemID.String(),
+		By:        opts.By,
+		Total:     len(ranked),
+		Median:    ranked[(len(ranked)-1)/2],
+		Solutions: ranked[:min(opts.Limit, len(ranked))],
+		Own:       uc.ownSolution(ctx, problemID, opts.Language, ranked, less),
+	}
+	uc.logger.InfoContext(ctx, "ranked solutions", "problem_id", problemID.String(), "by", opts.By, "total", result.Total)
+	return result, nil
+}
+
+// rankingOrders compare solutions by each metric, falling back to the others
+var rankingOrders = map[string]func(a, b repository.Solution) bool{
+	RankByTime: func(a, b repository.Solution) bool {
+		return compareSolutions(a, b, int64(a.Time-b.Time), a.Memory-b.Memory, a.CodeSize-b.CodeSize)
+	},
+	RankByMemory: func(a, b repository.Solution) bool {
+		return compareSolutions(a, b, a.Memory-b.Memory, int64(a.Time-b.Time), a.CodeSize-b.CodeSize)
+	},
+	RankByLength: func(a, b repository.Solution) bool {
+		return compareSolutions(a, b, a.CodeSize-b.CodeSize, int64(a.Time-b.Time), a.Memory-b.Memory)
+	},
+}
+
+// compareSolutions reports whether a ranks before b given the differences of
+// their metrics in order of precedence; earlier submissions win ties
+func compareSolutions(a, b repository.Solution, diffs ...int64) bool {
+	for _, diff := range diffs {
+		if diff != 0 {
+			return diff < 0
+		}
+	}
+	return a.SubmittedAt.Before(b.SubmittedAt)
+}
+
+// filterSolutionsByLanguage keeps the solutions in lang; a language
+// without version matches every version of it
+func filterSolutionsByLanguage(solutions []repository.Solution, lang string) []repository.Solution {
+	matched := make([]repository.Solution, 0, len(solutions))
+	for _, solution := range solutions {
+		if strings.EqualFold(solution.Language, lang) || strings.EqualFold(language.Family(solution.Language), lang) {
+			matched = append(matched, solution)
+		}
+	}
+	return matched
+}
+
+// ownSolution ranks the user's best accepted submission of the local history
+// among the ranked solutions
+func (uc *RankingUseCase) ownSolution(ctx context.Context, problemID model.ProblemID, language string, ranked []RankedSolution, less func(a, b repository.Solution) bool) *RankedSolution {
+	if uc.submissionRepo == nil {
+		return nil
+	}
+	accepted := entity.StatusAccepted
+	submissions, err := uc.submissionRepo.Search(ctx, repository.SubmissionSearchCriteria{ProblemID: &problemID, Status: &accepted})
+	if err != nil {
+		uc.logger.DebugContext(ctx, "own submissions unavailable", "error", err)
+		return nil
+	}
+
+	var best *repository.Solution
+	for _, submission := range submissions {
+		solution := repository.Solution{
+			JudgeID:     submission.JudgeID(),
+			Language:    submission.Language(),
+			Time:        submission.Time(),
+			Memory:      submission.Memory(),
+			CodeSize:    int64(len(submission.SourceCode())),
+			SubmittedAt: submission.SubmittedAt(),
+		}
+		if language != "" && len(filterSolutionsByLanguage([]repository.Solution{solution}, language)) == 0 {
+			continue
+		}
+		if best == nil || less(solution, *best) {
+			best = &solution
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	// The judge's entry of the submission has the exact code size
+	for _, r := range ranked {
+		if best.JudgeID != "" && r.JudgeID == best.JudgeID {
+			return &r
+		}
+	}
+	rank := 1 + sort.Search(len(ranked), func(i int) bool { return !less(ranked[i].Solution, *best) })
+	return &RankedSolution{Rank: rank, Solution: *best}
+}
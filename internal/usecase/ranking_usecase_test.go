@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// stubSolutionRepository serves a fixed list of solutions
+type stubSolutionRepository struct {
+	solutions []repository.Solution
+}
+
+func (s *stubSolutionRepository) GetByProblemID(_ context.Context, _ model.ProblemID, limit int) ([]repository.Solution, error) {
+	return s.solutions[:min(limit, len(s.solutions))], nil
+}
+
+// rankingSolutions returns solutions that rank differently by each metric
+func rankingSolutions() []repository.Solution {
+	day := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	return []repository.Solution{
+		{JudgeID: "1", UserID: "alice", Language: "C++17", Time: 20 * time.Millisecond, Memory: 3000, CodeSize: 500, SubmittedAt: day},
+		{JudgeID: "2", UserID: "bob", Language: "Python3", Time: 0, Memory: 9000, CodeSize: 100, SubmittedAt: day},
+		{JudgeID: "3", UserID: "carol", Language: "C++14", Time: 10 * time.Millisecond, Memory: 2000, CodeSize: 800, SubmittedAt: day},
+		{JudgeID: "4", UserID: "dave", Language: "C++17", Time: 10 * time.Millisecond, Memory: 2000, CodeSize: 800, SubmittedAt: day.Add(-time.Hour)},
+	}
+}
+
+func TestRankingUseCase_Rank(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     RankingOptions
+		want     []string // user IDs in rank order
+		wantCode cerrors.ErrorCode
+	}{
+		{name: "by time", opts: RankingOptions{ProblemID: "ITP1_1_A"}, want: []string{"bob", "dave", "carol", "alice"}},
+		{name: "by memory", opts: RankingOptions{ProblemID: "ITP1_1_A", By: RankByMemory}, want: []string{"dave", "carol", "alice", "bob"}},
+		{name: "by length", opts: RankingOptions{ProblemID: "ITP1_1_A", By: RankByLength}, want: []string{"bob", "alice", "dave", "carol"}},
+		{name: "language family", opts: RankingOptions{ProblemID: "ITP1_1_A", Language: "c++"}, want: []string{"dave", "carol", "alice"}},
+		{name: "language version", opts: RankingOptions{ProblemID: "ITP1_1_A", Language: "C++17", Limit: 1}, want: []string{"dave"}},
+		{name: "unknown order", opts: RankingOptions{ProblemID: "ITP1_1_A", By: "score"}, wantCode: cerrors.CodeInvalidInput},
+		{name: "no solutions", opts: RankingOptions{ProblemID: "ITP1_1_A", Language: "Rust"}, wantCode: cerrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			uc := NewRankingUseCase(&stubSolutionRepository{solutions: rankingSolutions()}, nil, RankingSettings{})
+
+			// When
+			result, err := uc.Rank(context.Background(), tt.opts)
+
+			// Then
+			if tt.wantCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			var users []string
+			for i, solution := range result.Solutions {
+				assert.Equal(t, i+1, solution.Rank)
+				users = append(users, solution.UserID)
+			}
+			assert.Equal(t, tt.want, users)
+			assert.Nil(t, result.Own)
+		})
+	}
+}
+
+func TestRankingUseCase_Rank_Own(t *testing.T) {
+	// Given
+	judged := acceptedSubmission("ITP1_1_A", "C++17", "int main() {}\n", "3", time.Now())
+	local := entity.NewSubmissionAt(model.MustNewSubmissionID("900"), model.MustNewProblemID("ITP1_1_A"), "C++17", "x", time.Now())
+	local.UpdateResult(entity.StatusAccepted, 100, 15*time.Millisecond, 100, "")
+
+	tests := []struct {
+		name        string
+		submissions []*entity.Submission
+		wantRank    int
+		wantJudgeID string
+	}{
+		{name: "listed by the judge", submissions: []*entity.Submission{judged, local}, wantRank: 3, wantJudgeID: "3"},
+		{name: "only in the local history", submissions: []*entity.Submission{local}, wantRank: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Search", mock.Anything, mock.Anything).Return(tt.submissions, nil)
+			uc := NewRankingUseCase(&stubSolutionRepository{solutions: rankingSolutions()}, submissionRepo, RankingSettings{})
+
+			// When
+			result, err := uc.Rank(context.Background(), RankingOptions{ProblemID: "ITP1_1_A"})
+
+			// Then
+			assert.NoError(t, err)
+			if result.Own == nil {
+				t.Fatalf("expected the own solution to be ranked")
+			}
+			assert.Equal(t, tt.wantRank, result.Own.Rank)
+			assert.Equal(t, tt.wantJudgeID, result.Own.JudgeID)
+			assert.Equal(t, "dave", result.Median.UserID)
+		})
+	}
+}
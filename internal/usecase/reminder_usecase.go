@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// ReminderJobName names the scheduled job of the practice reminder
+const ReminderJobName = "remind"
+
+// DefaultReminderTime is when the reminder is shown unless another time is given
+const DefaultReminderTime = "20:00"
+
+// ReminderSettings configures the reminder use case
+type ReminderSettings struct {
+	Streak    *StreakUseCase
+	Scheduler service.Scheduler
+	Notifier  service.Notifier
+	ConfigDir string // passed to the scheduled command, which does not see the environment of the shell
+}
+
+// ReminderInstallOptions configures installing the reminder
+type ReminderInstallOptions struct {
+	Executable string // path of the aoj binary to schedule
+	At         string // local time of day as HH:MM (defaults to DefaultReminderTime)
+}
+
+// ReminderInstallResult describes an installed reminder
+type ReminderInstallResult struct {
+	At       string   `json:"at"`
+	Location string   `json:"location"`
+	Command  []string `json:"command"`
+}
+
+// ReminderCheckResult describes a run of the scheduled reminder
+type ReminderCheckResult struct {
+	Streak   *StreakReport `json:"streak"`
+	Message  string        `json:"message,omitempty"`  // empty if a problem was solved today
+	Notified bool          `json:"notified,omitempty"` // the message was shown as a desktop notification
+}
+
+// ReminderUseCase reminds to practice on days without an accepted submission
+type ReminderUseCase struct {
+	settings ReminderSettings
+	logger   *logger.Logger
+}
+
+// NewReminderUseCase creates a new reminder use case
+func NewReminderUseCase(settings ReminderSettings) *ReminderUseCase {
+	return &ReminderUseCase{
+		settings: settings,
+		logger:   logger.WithGroup("reminder_usecase"),
+	}
+}
+
+// Install schedules aoj remind check to run every day at opts.At
+func (uc *ReminderUseCase) Install(ctx context.Context, opts ReminderInstallOptions) (*ReminderInstallResult, error) {
+	at := opts.At
+	if at == "" {
+		at = DefaultReminderTime
+	}
+	timeOfDay, err := time.Parse("15:04", at)
+	if err != nil {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("invalid reminder time %q. Use HH:MM, e.g. 21:30", at),
+			err,
+		)
+	}
+	if opts.Executable == "" {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "the path of the aoj executable is unknown", nil)
+	}
+
+	command := []string{opts.Executable}
+	if uc.settings.ConfigDir != "" {
+		command = append(command, "--config-dir", uc.settings.ConfigDir)
+	}
+	command = append(command, "remind", "check", "--notify")
+
+	location, err := uc.settings.Scheduler.Install(ctx, service.DailyJob{
+		Name:    ReminderJobName,
+		Command: command,
+		Hour:    timeOfDay.Hour(),
+		Minute:  timeOfDay.Minute(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "reminder installed", "at", at, "location", location)
+	return &ReminderInstallResult{At: timeOfDay.Format("15:04"), Location: location, Command: command}, nil
+}
+
+// Uninstall removes the scheduled reminder. It reports whether one was installed
+func (uc *ReminderUseCase) Uninstall(ctx context.Context) (bool, error) {
+	removed, err := uc.settings.Scheduler.Uninstall(ctx, ReminderJobName)
+	if err != nil {
+		return false, err
+	}
+	uc.logger.InfoContext(ctx, "reminder uninstalled", "removed", removed)
+	return removed, nil
+}
+
+// Check returns a reminder message unless a problem was solved today. With
+// notify the message is also shown as a desktop notification; a failed
+// notification is logged, as the message is printed anyway
+func (uc *ReminderUseCase) Check(ctx context.Context, notify bool) (*ReminderCheckResult, error) {
+	streak, err := uc.settings.Streak.Streak(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReminderCheckResult{Streak: streak}
+	if streak.SolvedToday {
+		return result, nil
+	}
+	result.Message = reminderMessage(streak)
+
+	if notify && uc.settings.Notifier != nil {
+		if err := uc.settings.Notifier.Notify(ctx, "AOJ practice", result.Message); err != nil {
+			uc.logger.WarnContext(ctx, "failed to show the reminder notification", "error", err)
+		} else {
+			result.Notified = true
+		}
+	}
+	return result, nil
+}
+
+// reminderMessage asks to solve a problem today, mentioning the streak at stake
+func reminderMessage(streak *StreakReport) string {
+	switch streak.Current {
+	case 0:
+		return "No problem solved today yet. Solve one to start a streak!"
+	case 1:
+		return "No problem solved today yet. Solve one to keep your 1-day streak!"
+	default:
+		return fmt.Sprintf("No problem solved today yet. Solve one to keep your %d-day streak!", streak.Current)
+	}
+}
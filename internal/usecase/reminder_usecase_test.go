@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// recordingScheduler remembers the installed jobs
+type recordingScheduler struct {
+	jobs map[string]service.DailyJob
+}
+
+func (s *recordingScheduler) Install(_ context.Context, job service.DailyJob) (string, error) {
+	s.jobs[job.Name] = job
+	return "test scheduler", nil
+}
+
+func (s *recordingScheduler) Uninstall(_ context.Context, name string) (bool, error) {
+	_, ok := s.jobs[name]
+	delete(s.jobs, name)
+	return ok, nil
+}
+
+// recordingNotifier remembers the shown messages
+type recordingNotifier struct {
+	messages []string
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, _, message string) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestReminderUseCase_Install(t *testing.T) {
+	// Given
+	scheduler := &recordingScheduler{jobs: map[string]service.DailyJob{}}
+	uc := NewReminderUseCase(ReminderSettings{Scheduler: scheduler, ConfigDir: "/home/alice/.aoj-cli"})
+
+	// When
+	result, err := uc.Install(context.Background(), ReminderInstallOptions{Executable: "/usr/local/bin/aoj", At: "21:30"})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	assert.Equal(t, "21:30", result.At)
+	assert.Equal(t, service.DailyJob{
+		Name:    ReminderJobName,
+		Command: []string{"/usr/local/bin/aoj", "--config-dir", "/home/alice/.aoj-cli", "remind", "check", "--notify"},
+		Hour:    21,
+		Minute:  30,
+	}, scheduler.jobs[ReminderJobName])
+
+	removed, err := uc.Uninstall(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.Empty(t, scheduler.jobs)
+}
+
+func TestReminderUseCase_Install_InvalidTime(t *testing.T) {
+	// Given
+	uc := NewReminderUseCase(ReminderSettings{Scheduler: &recordingScheduler{jobs: map[string]service.DailyJob{}}})
+
+	// When
+	_, err := uc.Install(context.Background(), ReminderInstallOptions{Executable: "aoj", At: "9pm"})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+}
+
+func TestReminderUseCase_Check(t *testing.T) {
+	now := time.Date(2026, 5, 10, 21, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		daysAgo     []int
+		wantMessage string
+	}{
+		{name: "solved today", daysAgo: []int{0, 1}},
+		{name: "streak at stake", daysAgo: []int{1, 2, 3}, wantMessage: "No problem solved today yet. Solve one to keep your 3-day streak!"},
+		{name: "no streak", daysAgo: []int{4}, wantMessage: "No problem solved today yet. Solve one to start a streak!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			notifier := &recordingNotifier{}
+			uc := NewReminderUseCase(ReminderSettings{Streak: newStreakTestUseCase(now, tt.daysAgo...), Notifier: notifier})
+
+			// When
+			result, err := uc.Check(context.Background(), true)
+
+			// Then
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			assert.Equal(t, tt.wantMessage, result.Message)
+			if tt.wantMessage == "" {
+				assert.Empty(t, notifier.messages)
+			} else {
+				assert.Equal(t, []string{tt.wantMessage}, notifier.messages)
+				assert.True(t, result.Notified)
+			}
+		})
+	}
+}
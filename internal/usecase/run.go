@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// RunOptions contains options for running a solution interactively
+type RunOptions struct {
+	Dir        string    // Optional: problem directory (defaults to the current directory)
+	SourceFile string    // Optional: solution file (defaults to the configured source file)
+	InputFile  string    // Optional: file to use as standard input instead of Stdin
+	Sanitize   string    // Optional: sanitizers to build C and C++ solutions with, e.g. "address,undefined"
+	Stdin      io.Reader // standard input of the solution, usually the terminal
+	Stdout     io.Writer
+	Stderr     io.Writer // also receives the compiler output of a fresh build
+}
+
+// RunReport holds the outcome of a run
+type RunReport struct {
+	SourceFile  string        `json:"source_file"`
+	BuildOutput string        `json:"build_output,omitempty"`
+	BuildCached bool          `json:"build_cached"`
+	ExitCode    int           `json:"exit_code"`
+	Duration    time.Duration `json:"duration"`
+	MemoryKB    int64         `json:"memory_kb"`
+}
+
+// Run builds the solution if needed and runs it once with its standard
+// streams connected to the given ones, so it can be used like a plain program
+func (uc *TestUseCase) Run(ctx context.Context, opts RunOptions) (*RunReport, error) {
+	uc.logger.InfoContext(ctx, "running solution", "source_file", opts.SourceFile, "input_file", opts.InputFile)
+
+	_, spec, err := uc.prepare(opts.Dir, opts.SourceFile, opts.Sanitize)
+	if err != nil {
+		return nil, err
+	}
+
+	stdin := opts.Stdin
+	if opts.InputFile != "" {
+		file, err := os.Open(opts.InputFile)
+		if err != nil {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				fmt.Sprintf("failed to open input file %s", opts.InputFile),
+				err,
+			)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		stdin = file
+	}
+
+	build, err := uc.build(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if !build.Success {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"compile error:\n"+build.Output,
+			nil,
+		)
+	}
+
+	report := &RunReport{
+		SourceFile:  spec.SourceFile,
+		BuildOutput: build.Output,
+		BuildCached: build.Cached,
+	}
+	// Show compiler warnings of a fresh build before the program's own output
+	if build.Output != "" && !build.Cached && opts.Stderr != nil {
+		_, _ = io.WriteString(opts.Stderr, build.Output)
+	}
+
+	result, err := uc.runner.RunAttached(ctx, spec, stdin, opts.Stdout, opts.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	report.ExitCode = result.ExitCode
+	report.Duration = result.Duration
+	report.MemoryKB = result.MemoryKB
+
+	uc.logger.InfoContext(ctx, "run finished", "exit_code", report.ExitCode, "duration", report.Duration)
+	return report, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestTestUseCase_Run(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{})
+	inputFile := filepath.Join(t.TempDir(), "my.txt")
+	assert.NoError(t, os.WriteFile(inputFile, []byte("4 5\n"), 0644))
+
+	tests := []struct {
+		name string
+		opts RunOptions
+		want string
+	}{
+		{name: "stdin", opts: RunOptions{Stdin: strings.NewReader("1 2\n")}, want: "3\n"},
+		{name: "input file", opts: RunOptions{InputFile: inputFile, Stdin: strings.NewReader("1 2\n")}, want: "9\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newTestTestUseCase(&fakeSolutionRunner{})
+			var stdout strings.Builder
+			tt.opts.Dir = dir
+			tt.opts.Stdout = &stdout
+
+			// When
+			report, err := uc.Run(context.Background(), tt.opts)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, "main.cpp", report.SourceFile)
+			assert.Equal(t, 0, report.ExitCode)
+			assert.Equal(t, tt.want, stdout.String())
+		})
+	}
+}
+
+func TestTestUseCase_Run_Errors(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{})
+
+	// When
+	_, missingErr := newTestTestUseCase(&fakeSolutionRunner{}).Run(context.Background(), RunOptions{Dir: dir, InputFile: filepath.Join(dir, "none.txt")})
+	_, buildErr := newTestTestUseCase(&fakeSolutionRunner{buildFails: true}).Run(context.Background(), RunOptions{Dir: dir, Stdin: strings.NewReader("")})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(missingErr, cerrors.CodeNotFound))
+	assert.True(t, cerrors.IsAppError(buildErr, cerrors.CodeInvalidInput))
+}
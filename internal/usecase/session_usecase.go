@@ -0,0 +1,114 @@
+// Package usecase implements application business logic.
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// SessionUseCase handles local session management operations
+type SessionUseCase struct {
+	sessionRepo repository.SessionRepository
+	clock       clock.Clock
+	logger      *logger.Logger
+}
+
+// NewSessionUseCase creates a new SessionUseCase that reads the current time
+// from clk, or from the system clock if clk is nil
+func NewSessionUseCase(sessionRepo repository.SessionRepository, clk clock.Clock) *SessionUseCase {
+	if clk == nil {
+		clk = clock.System()
+	}
+	return &SessionUseCase{
+		sessionRepo: sessionRepo,
+		clock:       clk,
+		logger:      logger.WithGroup("session_usecase"),
+	}
+}
+
+// Prune deletes all expired sessions and returns how many were deleted
+func (uc *SessionUseCase) Prune(ctx context.Context) (int, error) {
+	uc.logger.InfoContext(ctx, "pruning expired sessions")
+
+	deleted, err := uc.sessionRepo.DeleteExpired(ctx)
+	if err != nil {
+		return 0, cerrors.Wrap(err, "failed to delete expired sessions")
+	}
+
+	uc.logger.InfoContext(ctx, "expired sessions pruned", "deleted_count", deleted)
+	return deleted, nil
+}
+
+// RequireActive returns the current session, or a CodeUnauthorized error if
+// there is none or it has expired
+func (uc *SessionUseCase) RequireActive(ctx context.Context) (*entity.Session, error) {
+	return activeSession(ctx, uc.sessionRepo, uc.clock)
+}
+
+// activeSession returns the current session of sessionRepo, or a
+// CodeUnauthorized error if there is none or it has expired at the time of clk
+func activeSession(ctx context.Context, sessionRepo repository.SessionRepository, clk clock.Clock) (*entity.Session, error) {
+	session, err := sessionRepo.GetCurrent(ctx)
+	if err != nil && !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		return nil, cerrors.Wrap(err, "failed to get current session")
+	}
+	if session == nil {
+		return nil, cerrors.ErrNoSession()
+	}
+	if session.IsExpiredAt(clk.Now()) {
+		return nil, cerrors.ErrSessionExpired()
+	}
+	return session, nil
+}
+
+// SessionInfo describes a stored session without exposing its token
+type SessionInfo struct {
+	MaskedID  string    `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Expired   bool      `json:"expired"`
+	Current   bool      `json:"current"`
+}
+
+// List returns all stored sessions ordered by creation time
+func (uc *SessionUseCase) List(ctx context.Context) ([]SessionInfo, error) {
+	uc.logger.DebugContext(ctx, "listing sessions")
+
+	sessions, err := uc.sessionRepo.List(ctx)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list sessions")
+	}
+
+	// A missing current session is not an error when listing
+	current, err := uc.sessionRepo.GetCurrent(ctx)
+	if err != nil && !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		uc.logger.WarnContext(ctx, "failed to get current session", "error", err)
+	}
+
+	now := uc.clock.Now()
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, SessionInfo{
+			MaskedID:  session.ID().MaskedString(),
+			Username:  session.Username(),
+			CreatedAt: session.CreatedAt(),
+			ExpiresAt: session.ExpiresAt(),
+			Expired:   session.IsExpiredAt(now),
+			Current:   current != nil && current.ID().Equals(session.ID()),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+	})
+
+	return infos, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+func TestSessionUseCase_List_MarksCurrentSession(t *testing.T) {
+	// Given
+	mockSessionRepo := &MockSessionRepository{}
+	usecase := NewSessionUseCase(mockSessionRepo, nil)
+	ctx := context.Background()
+
+	now := time.Now()
+	older := entity.RestoreSession(model.MustGenerateSessionID(), "user1", "token1",
+		now.Add(-time.Hour), now.Add(-25*time.Hour), now.Add(-2*time.Hour))
+	newer := entity.RestoreSession(model.MustGenerateSessionID(), "user1", "token2",
+		now.Add(23*time.Hour), now.Add(-time.Hour), now)
+
+	mockSessionRepo.On("List", ctx).Return([]*entity.Session{newer, older}, nil)
+	mockSessionRepo.On("GetCurrent", ctx).Return(newer, nil)
+
+	// When
+	infos, err := usecase.List(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, infos, 2)
+	assert.Equal(t, older.ID().MaskedString(), infos[0].MaskedID)
+	assert.True(t, infos[0].Expired)
+	assert.False(t, infos[0].Current)
+	assert.True(t, infos[1].Current)
+	assert.False(t, infos[1].Expired)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestSessionUseCase_List_WithoutCurrentSession(t *testing.T) {
+	// Given
+	mockSessionRepo := &MockSessionRepository{}
+	usecase := NewSessionUseCase(mockSessionRepo, nil)
+	ctx := context.Background()
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	mockSessionRepo.On("List", ctx).Return([]*entity.Session{session}, nil)
+	mockSessionRepo.On("GetCurrent", ctx).Return(nil, cerrors.NewAppError(cerrors.CodeNotFound, "no current session", nil))
+
+	// When
+	infos, err := usecase.List(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, infos, 1)
+	assert.False(t, infos[0].Current)
+}
+
+func TestSessionUseCase_List_UsesClock(t *testing.T) {
+	// Given
+	mockSessionRepo := &MockSessionRepository{}
+	fakeClock := clock.NewFake(time.Now())
+	usecase := NewSessionUseCase(mockSessionRepo, fakeClock)
+	ctx := context.Background()
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	mockSessionRepo.On("List", ctx).Return([]*entity.Session{session}, nil)
+	mockSessionRepo.On("GetCurrent", ctx).Return(session, nil)
+
+	// When
+	fakeClock.Advance(2 * time.Hour)
+	infos, err := usecase.List(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, infos, 1)
+	assert.True(t, infos[0].Expired)
+}
+
+func TestSessionUseCase_Prune(t *testing.T) {
+	// Given
+	mockSessionRepo := &MockSessionRepository{}
+	usecase := NewSessionUseCase(mockSessionRepo, nil)
+	ctx := context.Background()
+	mockSessionRepo.On("DeleteExpired", ctx).Return(3, nil)
+
+	// When
+	deleted, err := usecase.Prune(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+}
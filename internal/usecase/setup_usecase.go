@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// SetupUseCase writes config.toml from the answers of the setup wizard
+type SetupUseCase struct {
+	configPath string
+	languages  []InitLanguage
+	templates  *TemplateUseCase
+	logger     *logger.Logger
+}
+
+// NewSetupUseCase creates a new SetupUseCase for the config file at configPath
+func NewSetupUseCase(configPath string, languages []InitLanguage, templates *TemplateUseCase) *SetupUseCase {
+	return &SetupUseCase{
+		configPath: configPath,
+		languages:  languages,
+		templates:  templates,
+		logger:     logger.WithGroup("setup_usecase"),
+	}
+}
+
+// SetupChoices are the current settings and what the wizard can offer
+type SetupChoices struct {
+	Language      string         // AOJ name of the current language
+	WorkspaceRoot string         // current workspace root, possibly empty
+	Languages     []InitLanguage // languages to choose from
+	Templates     []TemplateInfo // stored templates of all languages
+	ConfigExists  bool           // config.toml was written before
+}
+
+// SetupAnswers are the settings chosen in the wizard
+type SetupAnswers struct {
+	Language      string // language name, AOJ name or extension
+	Template      string // optional: stored template to make the default of the language
+	WorkspaceRoot string // optional: directory for problem directories, created if missing
+}
+
+// SetupResult describes the written configuration
+type SetupResult struct {
+	ConfigPath    string
+	BackupPath    string // previous config.toml, empty if there was none
+	Language      InitLanguage
+	SourceFile    string
+	Template      string
+	WorkspaceRoot string
+}
+
+// Choices returns the current settings as defaults for the wizard
+func (uc *SetupUseCase) Choices(ctx context.Context) (*SetupChoices, error) {
+	cfg, err := config.Load(uc.configPath)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := uc.templates.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, statErr := os.Stat(uc.configPath)
+	return &SetupChoices{
+		Language:      cfg.Submit.Language,
+		WorkspaceRoot: cfg.Workspace.Root,
+		Languages:     uc.languages,
+		Templates:     templates,
+		ConfigExists:  statErr == nil,
+	}, nil
+}
+
+// Apply updates config.toml with the answers, keeping the settings the wizard
+// does not ask about. An existing file is backed up as config.toml.bak
+func (uc *SetupUseCase) Apply(ctx context.Context, answers SetupAnswers) (*SetupResult, error) {
+	lang, err := findInitLanguage(uc.languages, answers.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(uc.configPath)
+	if err != nil {
+		return nil, err
+	}
+	sourceFile := sourceFileFor(lang, cfg.Submit.SourceFile)
+	cfg.Version = config.CurrentVersion
+	cfg.Init.Language = lang.AOJName
+	cfg.Submit.Language = lang.AOJName
+	cfg.Submit.SourceFile = sourceFile
+	cfg.Workspace.Root = strings.TrimSpace(answers.WorkspaceRoot)
+	if err := config.ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	if answers.Template != "" {
+		info, err := uc.templates.find(ctx, answers.Template)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(info.Extension, lang.Extension) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				"template "+info.Name+" is for ."+info.Extension+" files, not "+lang.AOJName,
+				nil,
+			)
+		}
+	}
+
+	if cfg.Workspace.Root != "" {
+		if err := os.MkdirAll(config.ExpandHome(cfg.Workspace.Root), 0755); err != nil {
+			return nil, cerrors.Wrap(err, "failed to create workspace root")
+		}
+	}
+
+	result := &SetupResult{
+		ConfigPath:    uc.configPath,
+		Language:      lang,
+		SourceFile:    sourceFile,
+		Template:      answers.Template,
+		WorkspaceRoot: cfg.Workspace.Root,
+	}
+	if previous, err := os.ReadFile(uc.configPath); err == nil {
+		result.BackupPath = uc.configPath + ".bak"
+		trace.File("write", result.BackupPath)
+		if err := os.WriteFile(result.BackupPath, previous, 0644); err != nil {
+			return nil, cerrors.Wrap(err, "failed to back up config file")
+		}
+	}
+	if err := config.Save(cfg, uc.configPath); err != nil {
+		return nil, err
+	}
+
+	if answers.Template != "" {
+		if _, err := uc.templates.Use(ctx, answers.Template); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.logger.InfoContext(ctx, "setup completed", "language", lang.AOJName, "template", answers.Template, "workspace_root", cfg.Workspace.Root)
+	return result, nil
+}
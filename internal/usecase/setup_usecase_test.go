@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/usecase"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+func newSetupUseCase(t *testing.T) (*usecase.SetupUseCase, *usecase.TemplateUseCase, string) {
+	t.Helper()
+	dir := t.TempDir()
+	templates := usecase.NewTemplateUseCase(filepath.Join(dir, "templates"))
+	configPath := filepath.Join(dir, "config.toml")
+	return usecase.NewSetupUseCase(configPath, initTestLanguages(), templates), templates, configPath
+}
+
+func TestSetupUseCase_Apply(t *testing.T) {
+	// Given
+	uc, templates, configPath := newSetupUseCase(t)
+	ctx := context.Background()
+	source := filepath.Join(t.TempDir(), "fast.py")
+	assert.NoError(t, os.WriteFile(source, []byte("import sys\n"), 0644))
+	if _, err := templates.Add(ctx, "fast", source, false); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	assert.NoError(t, os.WriteFile(configPath, []byte("[test]\ntimeout = 5.0\n"), 0644))
+	workspace := filepath.Join(t.TempDir(), "aoj")
+
+	// When
+	result, err := uc.Apply(ctx, usecase.SetupAnswers{Language: "python", Template: "fast", WorkspaceRoot: workspace})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	assert.Equal(t, "main.py", result.SourceFile)
+	assert.Equal(t, configPath+".bak", result.BackupPath)
+
+	cfg, err := config.Load(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "Python3", cfg.Init.Language)
+	assert.Equal(t, "Python3", cfg.Submit.Language)
+	assert.Equal(t, "main.py", cfg.Submit.SourceFile)
+	assert.Equal(t, workspace, cfg.Workspace.Root)
+	assert.Equal(t, 5.0, cfg.Test.Timeout, "settings the wizard does not ask about are kept")
+	assert.DirExists(t, workspace)
+
+	list, err := templates.List(ctx)
+	assert.NoError(t, err)
+	assert.True(t, list[0].Default)
+
+	choices, err := uc.Choices(ctx)
+	assert.NoError(t, err)
+	assert.True(t, choices.ConfigExists)
+	assert.Equal(t, "Python3", choices.Language)
+}
+
+func TestSetupUseCase_Apply_Invalid(t *testing.T) {
+	uc, templates, configPath := newSetupUseCase(t)
+	ctx := context.Background()
+	source := filepath.Join(t.TempDir(), "fast.cpp")
+	assert.NoError(t, os.WriteFile(source, []byte("int main() {}\n"), 0644))
+	if _, err := templates.Add(ctx, "fast", source, false); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	_, langErr := uc.Apply(ctx, usecase.SetupAnswers{Language: "cobol"})
+	_, templateErr := uc.Apply(ctx, usecase.SetupAnswers{Language: "python", Template: "fast"})
+
+	assert.True(t, cerrors.IsAppError(langErr, cerrors.CodeInvalidInput), "got %v", langErr)
+	assert.True(t, cerrors.IsAppError(templateErr, cerrors.CodeInvalidInput), "got %v", templateErr)
+	assert.NoFileExists(t, configPath, "nothing is written when an answer is invalid")
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/textenc"
+)
+
+// MaxSourceSize is the largest source code AOJ accepts, in bytes
+const MaxSourceSize = 64 * 1024
+
+// prepareSource converts the content of a source file to the UTF-8 text AOJ
+// expects. Conversions and suspicious content are returned as warnings;
+// text that is not convertible is an error
+func prepareSource(filePath string, content []byte) (string, []string, error) {
+	source, encoding, err := textenc.ToUTF8(content)
+	if err != nil {
+		return "", nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("%s is not UTF-8 text. Save it as UTF-8 and submit again", filePath),
+			err,
+		)
+	}
+
+	var warnings []string
+	switch encoding {
+	case textenc.UTF8:
+	case textenc.UTF8BOM:
+		warnings = append(warnings, fmt.Sprintf("removed the byte order mark of %s", filePath))
+	default:
+		warnings = append(warnings, fmt.Sprintf("converted %s from %s to UTF-8", filePath, encoding))
+	}
+
+	if strings.TrimSpace(source) == "" {
+		warnings = append(warnings, fmt.Sprintf("%s is empty", filePath))
+	}
+	if textenc.IsBinary([]byte(source)) {
+		warnings = append(warnings, fmt.Sprintf("%s contains NUL bytes and looks like a binary file", filePath))
+	}
+	return source, warnings, nil
+}
+
+// checkSourceSize rejects sources larger than AOJ accepts
+func checkSourceSize(filePath, source string) error {
+	if len(source) <= MaxSourceSize {
+		return nil
+	}
+	return cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		fmt.Sprintf("%s is %s, larger than the %s AOJ accepts", filePath, FormatSize(int64(len(source))), FormatSize(MaxSourceSize)),
+		nil,
+	)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
+)
+
+// SourceTransform holds the snippets added around a submitted source. The
+// local file is left untouched
+type SourceTransform struct {
+	Prelude  string
+	Epilogue string
+}
+
+// findTransform returns the transform for an AOJ language, matched exactly
+// first and by language family (C++ for C++17) otherwise
+func findTransform(transforms map[string]SourceTransform, lang string) (SourceTransform, bool) {
+	for _, match := range []func(string) bool{
+		func(key string) bool { return strings.EqualFold(key, lang) },
+		func(key string) bool { return strings.EqualFold(key, language.Family(lang)) },
+	} {
+		for key, transform := range transforms {
+			if match(key) {
+				return transform, true
+			}
+		}
+	}
+	return SourceTransform{}, false
+}
+
+// apply surrounds source with the prelude and the epilogue, each on lines of its own
+func (t SourceTransform) apply(source string) string {
+	var b strings.Builder
+	if t.Prelude != "" {
+		b.WriteString(withTrailingNewline(t.Prelude))
+	}
+	b.WriteString(source)
+	if t.Epilogue != "" {
+		if source != "" && !strings.HasSuffix(source, "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString(withTrailingNewline(t.Epilogue))
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/htmlmd"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// statementBaseURL resolves the relative image paths of statements, which
+// AOJ serves next to its problem pages
+const statementBaseURL = "https://judge.u-aizu.ac.jp/onlinejudge/"
+
+// StatementUseCase shows problem statements
+type StatementUseCase struct {
+	statements repository.StatementRepository
+	aliases    ProblemAliases
+	language   string
+	difficulty *DifficultyUseCase
+	logger     *logger.Logger
+}
+
+// StatementSettings holds the optional settings of the statement use case
+type StatementSettings struct {
+	Aliases    ProblemAliases     // problem aliases accepted besides problem IDs
+	Language   string             // preferred statement language, "en" or "ja"; empty prefers English
+	Difficulty *DifficultyUseCase // estimated difficulties shown with the statement
+}
+
+// NewStatementUseCase creates a new StatementUseCase
+func NewStatementUseCase(statements repository.StatementRepository, settings StatementSettings) *StatementUseCase {
+	return &StatementUseCase{
+		statements: statements,
+		aliases:    settings.Aliases,
+		language:   settings.Language,
+		difficulty: settings.Difficulty,
+		logger:     logger.WithGroup("statement_usecase"),
+	}
+}
+
+// StatementOptions holds options for showing a statement
+type StatementOptions struct {
+	Format htmlmd.Format
+	// Language is the preferred statement language, "en" or "ja"; empty uses the configured one
+	Language string
+}
+
+// Statement is a problem statement rendered for display
+type Statement struct {
+	ProblemID string `json:"problem_id"`
+	Language  string `json:"language"`
+	// Preferred is the language asked for by the options or the config, empty if neither did
+	Preferred  string   `json:"preferred_language,omitempty"`
+	Difficulty *float64 `json:"difficulty,omitempty"` // estimated; nil when unknown
+	Content    string   `json:"content"`
+}
+
+// Show returns the statement of a problem rendered as opts asks. Without a
+// problem ID, the problem of the current directory is shown. A problem
+// without a statement in the preferred language is shown in the other one.
+// The estimated difficulty is added when a difficulty table knows it
+func (uc *StatementUseCase) Show(ctx context.Context, problemID string, opts StatementOptions) (*Statement, error) {
+	preferred := opts.Language
+	if preferred == "" {
+		preferred = uc.language
+	}
+	if err := validateStatementLanguage(preferred); err != nil {
+		return nil, err
+	}
+
+	pid, err := resolveProblemID(".", problemID, uc.aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	html, language, err := fetchStatement(ctx, uc.statements, pid, statementLanguageOrder(preferred), uc.logger)
+	if err != nil {
+		return nil, err
+	}
+	if preferred != "" && language != preferred {
+		uc.logger.InfoContext(ctx, "statement not available in the preferred language", "problem_id", pid.String(), "preferred", preferred, "language", language)
+	}
+
+	content, err := renderStatement(html, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	statement := &Statement{ProblemID: pid.String(), Language: language, Preferred: preferred, Content: content}
+	if difficulty, ok := uc.difficulty.Lookup(ctx)[statement.ProblemID]; ok {
+		statement.Difficulty = &difficulty
+	}
+	return statement, nil
+}
+
+// fetchStatement returns the HTML statement in the first available of
+// languages and its language
+func fetchStatement(ctx context.Context, statements repository.StatementRepository, pid model.ProblemID, languages []string, log *logger.Logger) (string, string, error) {
+	var lastErr error
+	for _, language := range languages {
+		html, err := statements.GetStatement(ctx, pid, language)
+		if err == nil {
+			return html, language, nil
+		}
+		log.DebugContext(ctx, "statement not available", "language", language, "error", err)
+		lastErr = err
+	}
+	if cerrors.IsAppError(lastErr, cerrors.CodeNotFound) {
+		return "", "", cerrors.NewAppError(cerrors.CodeNotFound, "problem "+pid.String()+" has no statement on AOJ", lastErr)
+	}
+	return "", "", lastErr
+}
+
+// renderStatement converts a statement to Markdown or terminal text, the
+// same way for aoj show and the README written by init
+func renderStatement(html string, format htmlmd.Format) (string, error) {
+	content, err := htmlmd.Render(html, htmlmd.Options{Format: format, BaseURL: statementBaseURL})
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to convert the problem statement")
+	}
+	return content, nil
+}
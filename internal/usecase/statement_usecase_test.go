@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/htmlmd"
+)
+
+func TestStatementUseCase_Show(t *testing.T) {
+	// Given
+	statements := &stubStatementRepository{statements: map[string]string{
+		"ja": `<h1>合計</h1><p>$1 \le N \le 100$ の整数を出力せよ。</p>`,
+	}}
+	uc := NewStatementUseCase(statements, StatementSettings{
+		Aliases:    ProblemAliases{"sum": "ITP1_1_A"},
+		Difficulty: NewDifficultyUseCase(staticDifficulties{"ITP1_1_A": 100}),
+	})
+	ctx := context.Background()
+
+	// When
+	text, err := uc.Show(ctx, "sum", StatementOptions{Format: htmlmd.Text})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	difficulty := 100.0
+	assert.Equal(t, &Statement{
+		ProblemID:  "ITP1_1_A",
+		Language:   "ja",
+		Difficulty: &difficulty,
+		Content:    "合計\n==\n\n1 ≤ N ≤ 100 の整数を出力せよ。\n",
+	}, text)
+
+	// When
+	markdown, err := uc.Show(ctx, "ITP1_1_A", StatementOptions{Format: htmlmd.Markdown})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "# 合計\n\n$1 \\le N \\le 100$ の整数を出力せよ。\n", markdown.Content)
+}
+
+func TestStatementUseCase_Show_NotFound(t *testing.T) {
+	// Given
+	uc := NewStatementUseCase(&stubStatementRepository{}, StatementSettings{})
+
+	// When
+	_, err := uc.Show(context.Background(), "ITP1_1_A", StatementOptions{Format: htmlmd.Text})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
+
+func TestStatementUseCase_Show_Language(t *testing.T) {
+	statements := &stubStatementRepository{statements: map[string]string{
+		"en": "<p>Hello</p>",
+		"ja": "<p>こんにちは</p>",
+	}}
+
+	tests := []struct {
+		name       string
+		configured string
+		requested  string
+		want       string
+		wantLang   string
+	}{
+		{name: "English by default", want: "Hello\n", wantLang: "en"},
+		{name: "configured language", configured: "ja", want: "こんにちは\n", wantLang: "ja"},
+		{name: "requested language wins", configured: "ja", requested: "en", want: "Hello\n", wantLang: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			uc := NewStatementUseCase(statements, StatementSettings{Language: tt.configured})
+
+			// When
+			statement, err := uc.Show(context.Background(), "ITP1_1_A", StatementOptions{Format: htmlmd.Text, Language: tt.requested})
+
+			// Then
+			if err != nil {
+				t.Fatalf("Show failed: %v", err)
+			}
+			assert.Equal(t, tt.want, statement.Content)
+			assert.Equal(t, tt.wantLang, statement.Language)
+		})
+	}
+}
+
+func TestStatementUseCase_Show_LanguageFallback(t *testing.T) {
+	// Given
+	statements := &stubStatementRepository{statements: map[string]string{"ja": "<p>こんにちは</p>"}}
+	uc := NewStatementUseCase(statements, StatementSettings{})
+
+	// When
+	statement, err := uc.Show(context.Background(), "ITP1_1_A", StatementOptions{Language: "en"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "ja", statement.Language)
+	assert.Equal(t, "en", statement.Preferred)
+
+	// When
+	configured := NewStatementUseCase(statements, StatementSettings{Language: "en"})
+	statement, err = configured.Show(context.Background(), "ITP1_1_A", StatementOptions{})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "ja", statement.Language)
+	assert.Equal(t, "en", statement.Preferred)
+
+	// When
+	_, err = uc.Show(context.Background(), "ITP1_1_A", StatementOptions{Language: "fr"})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+}
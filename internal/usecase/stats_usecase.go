@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// Failure codes of command runs that did not fail with an AppError
+const (
+	FailureInterrupted = "INTERRUPTED"
+	FailureUnknown     = "UNKNOWN"
+)
+
+// StatsUseCase records and reports local statistics
+type StatsUseCase struct {
+	usageRepo repository.UsageStatsRepository
+	clock     clock.Clock
+	logger    *logger.Logger
+}
+
+// NewStatsUseCase creates a new StatsUseCase that reads the current time from
+// clk, the system clock if nil
+func NewStatsUseCase(usageRepo repository.UsageStatsRepository, clk clock.Clock) *StatsUseCase {
+	if clk == nil {
+		clk = clock.System()
+	}
+	return &StatsUseCase{
+		usageRepo: usageRepo,
+		clock:     clk,
+		logger:    logger.WithGroup("stats_usecase"),
+	}
+}
+
+// CommandStats is the usage of one command
+type CommandStats struct {
+	Command string `json:"command"`
+	repository.CommandUsage
+}
+
+// CLIStatsReport holds the usage of all commands that were run
+type CLIStatsReport struct {
+	Since    time.Time      `json:"since"`
+	Commands []CommandStats `json:"commands"` // most used first
+}
+
+// RecordCommand adds a finished run of a command, such as "submit" or
+// "history sync", that took duration and failed with err unless it is nil
+func (uc *StatsUseCase) RecordCommand(ctx context.Context, command string, duration time.Duration, err error) error {
+	stats, loadErr := uc.usageRepo.Load(ctx)
+	if loadErr != nil {
+		return loadErr
+	}
+
+	now := uc.clock.Now()
+	if stats.Since.IsZero() {
+		stats.Since = now
+	}
+	usage := stats.Commands[command]
+	usage.Runs++
+	usage.TotalMillis += duration.Milliseconds()
+	usage.MaxMillis = max(usage.MaxMillis, duration.Milliseconds())
+	usage.LastRun = now
+	if err != nil {
+		usage.Failures++
+		if usage.FailureCodes == nil {
+			usage.FailureCodes = map[string]int{}
+		}
+		usage.FailureCodes[failureCode(err)]++
+	}
+	stats.Commands[command] = usage
+
+	return uc.usageRepo.Save(ctx, stats)
+}
+
+// CLIStats returns the usage of all commands, the most used first
+func (uc *StatsUseCase) CLIStats(ctx context.Context) (*CLIStatsReport, error) {
+	stats, err := uc.usageRepo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CLIStatsReport{Since: stats.Since, Commands: make([]CommandStats, 0, len(stats.Commands))}
+	for command, usage := range stats.Commands {
+		report.Commands = append(report.Commands, CommandStats{Command: command, CommandUsage: usage})
+	}
+	sort.Slice(report.Commands, func(i, j int) bool {
+		a, b := report.Commands[i], report.Commands[j]
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return a.Command < b.Command
+	})
+	return report, nil
+}
+
+// ResetCLI deletes the usage of all commands
+func (uc *StatsUseCase) ResetCLI(ctx context.Context) error {
+	if err := uc.usageRepo.Save(ctx, repository.UsageStats{Commands: map[string]repository.CommandUsage{}}); err != nil {
+		return err
+	}
+	uc.logger.InfoContext(ctx, "usage statistics reset")
+	return nil
+}
+
+// failureCode classifies why a command failed
+func failureCode(err error) string {
+	if cerrors.Is(err, context.Canceled) {
+		return FailureInterrupted
+	}
+	if code := cerrors.GetErrorCode(err); code != "" {
+		return string(code)
+	}
+	return FailureUnknown
+}
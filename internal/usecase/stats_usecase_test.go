@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// memoryUsageStatsRepository keeps the usage statistics in memory
+type memoryUsageStatsRepository struct {
+	stats repository.UsageStats
+}
+
+func (r *memoryUsageStatsRepository) Load(_ context.Context) (repository.UsageStats, error) {
+	stats := r.stats
+	stats.Commands = map[string]repository.CommandUsage{}
+	for command, usage := range r.stats.Commands {
+		stats.Commands[command] = usage
+	}
+	return stats, nil
+}
+
+func (r *memoryUsageStatsRepository) Save(_ context.Context, stats repository.UsageStats) error {
+	r.stats = stats
+	return nil
+}
+
+func TestStatsUseCase_RecordCommand(t *testing.T) {
+	// Given
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	uc := NewStatsUseCase(&memoryUsageStatsRepository{}, clk)
+	ctx := context.Background()
+	networkErr := cerrors.NewAppError(cerrors.CodeNetworkError, "failed to connect to AOJ", nil)
+
+	// When
+	errs := []error{
+		uc.RecordCommand(ctx, "test", 300*time.Millisecond, nil),
+		uc.RecordCommand(ctx, "submit", 4*time.Second, nil),
+		uc.RecordCommand(ctx, "submit", 2*time.Second, fmt.Errorf("submission failed: %w", networkErr)),
+		uc.RecordCommand(ctx, "submit", time.Second, context.Canceled),
+	}
+	clk.Advance(time.Hour)
+	errs = append(errs, uc.RecordCommand(ctx, "test", 100*time.Millisecond, fmt.Errorf("boom")))
+	report, err := uc.CLIStats(ctx)
+
+	// Then
+	for _, recordErr := range errs {
+		assert.NoError(t, recordErr)
+	}
+	if err != nil {
+		t.Fatalf("CLIStats failed: %v", err)
+	}
+	assert.Equal(t, start, report.Since)
+	if len(report.Commands) != 2 {
+		t.Fatalf("expected 2 commands, got %+v", report.Commands)
+	}
+	submit, test := report.Commands[0], report.Commands[1]
+	assert.Equal(t, "submit", submit.Command)
+	assert.Equal(t, 3, submit.Runs)
+	assert.Equal(t, 2, submit.Failures)
+	assert.Equal(t, map[string]int{"NETWORK_ERROR": 1, FailureInterrupted: 1}, submit.FailureCodes)
+	assert.Equal(t, 2333*time.Millisecond, submit.Average())
+	assert.Equal(t, 4*time.Second, submit.Max())
+	assert.Equal(t, start, submit.LastRun)
+	assert.Equal(t, "test", test.Command)
+	assert.Equal(t, map[string]int{FailureUnknown: 1}, test.FailureCodes)
+	assert.Equal(t, start.Add(time.Hour), test.LastRun)
+}
+
+func TestStatsUseCase_ResetCLI(t *testing.T) {
+	// Given
+	uc := NewStatsUseCase(&memoryUsageStatsRepository{}, nil)
+	ctx := context.Background()
+	_ = uc.RecordCommand(ctx, "init", time.Second, nil)
+
+	// When
+	err := uc.ResetCLI(ctx)
+	report, statsErr := uc.CLIStats(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.NoError(t, statsErr)
+	assert.Empty(t, report.Commands)
+	assert.True(t, report.Since.IsZero())
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// dayLayout formats the days of a streak
+const dayLayout = "2006-01-02"
+
+// StreakUseCase reports the days on which problems were solved
+type StreakUseCase struct {
+	submissionRepo repository.SubmissionRepository
+	clock          clock.Clock
+	logger         *logger.Logger
+}
+
+// NewStreakUseCase creates a new StreakUseCase that reads the days of the
+// local history, which includes the submissions imported by aoj history sync
+func NewStreakUseCase(submissionRepo repository.SubmissionRepository, clk clock.Clock) *StreakUseCase {
+	if clk == nil {
+		clk = clock.System()
+	}
+	return &StreakUseCase{
+		submissionRepo: submissionRepo,
+		clock:          clk,
+		logger:         logger.WithGroup("streak_usecase"),
+	}
+}
+
+// StreakReport describes the days with at least one accepted submission, in local time
+type StreakReport struct {
+	Current      int        `json:"current"` // consecutive days up to today, or up to yesterday if nothing was solved today yet
+	Longest      int        `json:"longest"`
+	SolvedToday  bool       `json:"solved_today"`
+	ActiveDays   int        `json:"active_days"`
+	LastAccepted *time.Time `json:"last_accepted,omitempty"`
+}
+
+// Streak returns the current and longest runs of days with an accepted submission
+func (uc *StreakUseCase) Streak(ctx context.Context) (*StreakReport, error) {
+	accepted := entity.StatusAccepted
+	perDay, last, err := uc.perDay(ctx, &accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(uc.clock.Now())
+	report := &StreakReport{
+		SolvedToday:  perDay[today.Format(dayLayout)] > 0,
+		ActiveDays:   len(perDay),
+		LastAccepted: last,
+	}
+
+	// A streak is alive until a whole day passes without an accepted submission
+	day := today
+	if !report.SolvedToday {
+		day = day.AddDate(0, 0, -1)
+	}
+	for perDay[day.Format(dayLayout)] > 0 {
+		report.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	days := make([]string, 0, len(perDay))
+	for key := range perDay {
+		days = append(days, key)
+	}
+	sort.Strings(days)
+	run := 0
+	var previous time.Time
+	for _, key := range days {
+		day, err := time.ParseInLocation(dayLayout, key, today.Location())
+		if err != nil {
+			continue
+		}
+		if run > 0 && previous.AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		report.Longest = max(report.Longest, run)
+		previous = day
+	}
+	return report, nil
+}
+
+// perDay counts the submissions of each local day, only those with the
+// status unless it is nil, and returns the time of the latest one
+func (uc *StreakUseCase) perDay(ctx context.Context, status *entity.SubmissionStatus) (map[string]int, *time.Time, error) {
+	submissions, err := uc.submissionRepo.Search(ctx, repository.SubmissionSearchCriteria{Status: status})
+	if err != nil {
+		return nil, nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+
+	perDay := make(map[string]int)
+	var last *time.Time
+	location := uc.clock.Now().Location()
+	for _, submission := range submissions {
+		at := submission.SubmittedAt().In(location)
+		perDay[at.Format(dayLayout)]++
+		if last == nil || at.After(*last) {
+			last = &at
+		}
+	}
+	uc.logger.DebugContext(ctx, "read submissions", "count", len(submissions), "days", len(perDay))
+	return perDay, last, nil
+}
+
+// DefaultHeatmapWeeks is how many weeks the heatmap covers unless told otherwise
+const DefaultHeatmapWeeks = 52
+
+// HeatmapOptions configures the activity calendar
+type HeatmapOptions struct {
+	Weeks       int  // Optional: weeks to cover up to the current one (defaults to DefaultHeatmapWeeks)
+	Submissions bool // count all submissions instead of accepted ones
+}
+
+// HeatmapDay is the number of submissions of one day
+type HeatmapDay struct {
+	Date  string `json:"date"` // YYYY-MM-DD in local time
+	Count int    `json:"count"`
+}
+
+// Heatmap is a calendar of submissions per day like the contribution graph of
+// GitHub. Days run from a Sunday to today, so that they fill whole weeks but
+// the last one
+type Heatmap struct {
+	Accepted   bool         `json:"accepted"` // the days count accepted submissions only
+	Weeks      int          `json:"weeks"`
+	Days       []HeatmapDay `json:"days"`
+	Max        int          `json:"max"`
+	Total      int          `json:"total"`
+	ActiveDays int          `json:"active_days"`
+}
+
+// Heatmap counts the submissions of each day of the last weeks
+func (uc *StreakUseCase) Heatmap(ctx context.Context, opts HeatmapOptions) (*Heatmap, error) {
+	weeks := opts.Weeks
+	if weeks <= 0 {
+		weeks = DefaultHeatmapWeeks
+	}
+	var status *entity.SubmissionStatus
+	if !opts.Submissions {
+		accepted := entity.StatusAccepted
+		status = &accepted
+	}
+	perDay, _, err := uc.perDay(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(uc.clock.Now())
+	start := today.AddDate(0, 0, -7*(weeks-1)-int(today.Weekday()))
+	heatmap := &Heatmap{Accepted: !opts.Submissions, Weeks: weeks}
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dayLayout)
+		count := perDay[date]
+		heatmap.Days = append(heatmap.Days, HeatmapDay{Date: date, Count: count})
+		heatmap.Max = max(heatmap.Max, count)
+		heatmap.Total += count
+		if count > 0 {
+			heatmap.ActiveDays++
+		}
+	}
+	return heatmap, nil
+}
+
+// startOfDay returns midnight of the day of t in its location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// newStreakTestUseCase serves accepted submissions made the given numbers of days before now
+func newStreakTestUseCase(now time.Time, daysAgo ...int) *StreakUseCase {
+	submissions := make([]*entity.Submission, 0, len(daysAgo))
+	for i, days := range daysAgo {
+		submittedAt := now.AddDate(0, 0, -days)
+		submissions = append(submissions, acceptedSubmission("ITP1_1_A", "C++17", "", strconv.Itoa(i+1), submittedAt))
+	}
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Search", mock.Anything, mock.Anything).Return(submissions, nil)
+	return NewStreakUseCase(submissionRepo, clock.NewFake(now))
+}
+
+func TestStreakUseCase_Streak(t *testing.T) {
+	now := time.Date(2026, 5, 10, 21, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		daysAgo     []int
+		wantCurrent int
+		wantLongest int
+		wantToday   bool
+		wantActive  int
+	}{
+		{name: "no history"},
+		{name: "solved today", daysAgo: []int{0, 0, 1, 2}, wantCurrent: 3, wantLongest: 3, wantToday: true, wantActive: 3},
+		{name: "not solved today yet", daysAgo: []int{1, 2}, wantCurrent: 2, wantLongest: 2, wantActive: 2},
+		{name: "broken streak", daysAgo: []int{2, 3}, wantCurrent: 0, wantLongest: 2, wantActive: 2},
+		{name: "longest in the past", daysAgo: []int{0, 5, 6, 7, 8}, wantCurrent: 1, wantLongest: 4, wantToday: true, wantActive: 5},
+		{name: "across a month", daysAgo: []int{9, 10, 11}, wantCurrent: 0, wantLongest: 3, wantActive: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			uc := newStreakTestUseCase(now, tt.daysAgo...)
+
+			// When
+			report, err := uc.Streak(context.Background())
+
+			// Then
+			if err != nil {
+				t.Fatalf("Streak failed: %v", err)
+			}
+			assert.Equal(t, tt.wantCurrent, report.Current)
+			assert.Equal(t, tt.wantLongest, report.Longest)
+			assert.Equal(t, tt.wantToday, report.SolvedToday)
+			assert.Equal(t, tt.wantActive, report.ActiveDays)
+			assert.Equal(t, len(tt.daysAgo) > 0, report.LastAccepted != nil)
+		})
+	}
+}
+
+func TestStreakUseCase_Heatmap(t *testing.T) {
+	// Given: Wednesday, May 13
+	now := time.Date(2026, 5, 13, 21, 0, 0, 0, time.Local)
+	uc := newStreakTestUseCase(now, 0, 0, 3, 20)
+
+	// When
+	heatmap, err := uc.Heatmap(context.Background(), HeatmapOptions{Weeks: 2})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Heatmap failed: %v", err)
+	}
+	assert.Len(t, heatmap.Days, 11)
+	assert.Equal(t, HeatmapDay{Date: "2026-05-03", Count: 0}, heatmap.Days[0])
+	assert.Equal(t, HeatmapDay{Date: "2026-05-10", Count: 1}, heatmap.Days[7])
+	assert.Equal(t, HeatmapDay{Date: "2026-05-13", Count: 2}, heatmap.Days[10])
+	assert.Equal(t, 2, heatmap.Max)
+	assert.Equal(t, 3, heatmap.Total)
+	assert.Equal(t, 2, heatmap.ActiveDays)
+	assert.True(t, heatmap.Accepted)
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// defaultBatchDelay is the default shortest time between two submissions of SubmitAll
+const defaultBatchDelay = 5 * time.Second
+
+// SubmitAllOptions contains options for submitting every problem that passes its samples
+type SubmitAllOptions struct {
+	Patterns     []string      // Optional: problem directories, as for verify (defaults to "./...")
+	Delay        time.Duration // Optional: shortest time between two submissions (defaults to the configured one)
+	Watch        bool          // Optional: wait for the verdicts even if not configured
+	NoWatch      bool          // Optional: do not wait for the verdicts even if configured
+	NoGit        bool          // Optional: do not commit accepted solutions even if submit.git_commit_on_ac is set
+	PollInterval time.Duration // Optional: first interval between verdict polls (defaults to the configured one)
+	Timeout      time.Duration // Optional: stop waiting for each verdict after this long; 0 waits until it is final
+
+	// OnTested is called after the samples of each problem were run
+	OnTested func(result VerifyResult)
+	// OnSubmitted is called with every problem that reached AOJ or failed to
+	OnSubmitted func(result SubmitAllResult)
+	// OnStatus is called with every verdict change while waiting for the
+	// verdicts; calls for different problems never overlap
+	OnStatus func(problem string, status entity.SubmissionStatus)
+	// OnWarning is called with problems of source files that do not stop
+	// their submission and before a failed attempt to send one is retried
+	OnWarning func(message string)
+}
+
+// SubmitAllResult is the outcome of one problem directory of SubmitAll
+type SubmitAllResult struct {
+	Dir        string
+	Test       *TestReport
+	Submission *entity.Submission // nil if the problem was not submitted
+	GitCommit  *GitCommitResult   // nil if no commit was attempted
+	Err        error              // why the problem could not be submitted or judged
+}
+
+// SourceFile returns the path of the tested solution file
+func (r SubmitAllResult) SourceFile() string {
+	return filepath.Join(r.Dir, r.Test.SourceFile)
+}
+
+// SubmitAllReport holds the results of all problem directories of SubmitAll
+type SubmitAllReport struct {
+	Results     []SubmitAllResult
+	Interrupted bool
+}
+
+// SubmittedCount returns the number of problems that reached AOJ
+func (r *SubmitAllReport) SubmittedCount() int {
+	submitted := 0
+	for _, result := range r.Results {
+		if result.Submission != nil {
+			submitted++
+		}
+	}
+	return submitted
+}
+
+// AcceptedCount returns the number of problems that AOJ accepted
+func (r *SubmitAllReport) AcceptedCount() int {
+	accepted := 0
+	for _, result := range r.Results {
+		if result.Submission != nil && result.Submission.IsAccepted() {
+			accepted++
+		}
+	}
+	return accepted
+}
+
+// SubmitAll runs the samples of every problem directory matched by the
+// patterns and submits the problems that pass, one at a time and at least
+// the batch delay apart so that AOJ is not flooded. The verdicts are then
+// awaited together. A problem that cannot be submitted does not stop the
+// others, except for an invalid session
+func (uc *SubmitUseCase) SubmitAll(ctx context.Context, tests *TestUseCase, opts SubmitAllOptions) (*SubmitAllReport, error) {
+	verified, err := tests.Verify(ctx, VerifyOptions{Patterns: opts.Patterns, Progress: opts.OnTested})
+	if err != nil {
+		return nil, err
+	}
+	report := &SubmitAllReport{Interrupted: verified.Interrupted}
+	for _, result := range verified.Results {
+		report.Results = append(report.Results, SubmitAllResult{Dir: result.Dir, Test: result.Report})
+	}
+	if report.Interrupted {
+		return report, nil
+	}
+
+	delay := cmp.Or(opts.Delay, uc.settings.BatchDelay)
+	var submitted []*SubmitAllResult
+	var lastSent time.Time
+	for i := range report.Results {
+		result := &report.Results[i]
+		if result.Test.Verdict != VerdictAccepted {
+			continue
+		}
+		if !lastSent.IsZero() {
+			if wait := delay - time.Since(lastSent); wait > 0 {
+				uc.logger.DebugContext(ctx, "waiting before the next submission", "delay", wait)
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+				case <-timer.C:
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			break
+		}
+
+		result.Submission, result.Err = uc.Execute(ctx, SubmitOptions{
+			Dir:       result.Dir,
+			FilePath:  result.SourceFile(),
+			NoWatch:   true,
+			NoGit:     true,
+			OnWarning: opts.OnWarning,
+		})
+		lastSent = time.Now()
+		if opts.OnSubmitted != nil {
+			opts.OnSubmitted(*result)
+		}
+		if result.Submission != nil {
+			submitted = append(submitted, result)
+		}
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			break
+		}
+		if cerrors.IsAppError(result.Err, cerrors.CodeUnauthorized) {
+			return report, result.Err
+		}
+	}
+
+	if (uc.settings.Watch || opts.Watch) && !opts.NoWatch {
+		uc.waitForVerdicts(ctx, submitted, opts)
+	}
+	for _, result := range submitted {
+		if result.Err != nil || !result.Submission.Status().IsFinal() {
+			continue
+		}
+		uc.recordVerdictDetails(ctx, result.Submission)
+		if uc.shouldCommit(result.Submission, opts.NoGit) {
+			commit := uc.commitAccepted(ctx, result.Submission, result.SourceFile())
+			result.GitCommit = &commit
+		}
+	}
+	if ctx.Err() != nil {
+		report.Interrupted = true
+	}
+
+	uc.logger.InfoContext(ctx, "submitted all problems",
+		"problems", len(report.Results),
+		"submitted", report.SubmittedCount(),
+		"accepted", report.AcceptedCount())
+	return report, nil
+}
+
+// waitForVerdicts waits for the verdicts of the submitted problems at the
+// same time, recording why waiting failed in their results
+func (uc *SubmitUseCase) waitForVerdicts(ctx context.Context, submitted []*SubmitAllResult, opts SubmitAllOptions) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, result := range submitted {
+		watch := watchOptions{
+			enabled:  true,
+			interval: opts.PollInterval,
+			timeout:  opts.Timeout,
+		}
+		if opts.OnStatus != nil {
+			problem := result.Submission.ProblemID().String()
+			watch.onStatus = func(status entity.SubmissionStatus) {
+				mu.Lock()
+				defer mu.Unlock()
+				opts.OnStatus(problem, status)
+			}
+		}
+		wg.Go(func() {
+			if !result.Submission.Status().IsFinal() {
+				result.Err = uc.waitForVerdict(ctx, result.Submission, watch)
+			}
+		})
+	}
+	wg.Wait()
+}
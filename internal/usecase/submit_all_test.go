@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+)
+
+func TestSubmitUseCase_SubmitAll(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	writeSamples(t, filepath.Join(root, "ITP1_1_A"), map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+	writeSamples(t, filepath.Join(root, "ITP1_1_B"), map[string][2]string{"sample-1": {"1 2\n", "4\n"}})
+	writeSamples(t, filepath.Join(root, "ITP1_1_C"), map[string][2]string{"sample-1": {"2 2\n", "4\n"}})
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+
+	verdicts := map[string]entity.SubmissionStatus{
+		"1": entity.StatusAccepted,
+		"3": entity.StatusWrongAnswer,
+	}
+	var sent []time.Time
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			submission := args.Get(1).(*entity.Submission)
+			if submission.ProblemID().String() == "ITP1_1_C" {
+				submission.SetJudgeID("3")
+			} else {
+				submission.SetJudgeID("1")
+			}
+			sent = append(sent, time.Now())
+		}).
+		Return(nil)
+	for judgeID, status := range verdicts {
+		statuses := make(chan entity.SubmissionStatus, 2)
+		statuses <- entity.StatusJudging
+		statuses <- status
+		close(statuses)
+		submissionRepo.On("WatchStatus", mock.Anything, model.MustNewSubmissionID(judgeID), time.Millisecond).
+			Return((<-chan entity.SubmissionStatus)(statuses), nil)
+	}
+	cases := map[string][]entity.CaseResult{
+		"1": {{Serial: 1, Status: entity.StatusAccepted}, {Serial: 2, Status: entity.StatusAccepted}},
+		"3": {{Serial: 1, Status: entity.StatusAccepted}, {Serial: 2, Status: entity.StatusWrongAnswer}},
+	}
+	for judgeID, results := range cases {
+		submissionRepo.On("GetJudgeDetail", mock.Anything, mock.MatchedBy(func(s *entity.Submission) bool {
+			return s.JudgeID() == judgeID
+		})).Return(repository.JudgeDetail{Cases: results}, nil)
+	}
+	submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}},
+		SubmitSettings{Watch: true, PollInterval: time.Millisecond})
+	tests := newTestTestUseCase(&fakeSolutionRunner{})
+
+	var mu sync.Mutex
+	seen := make(map[string][]entity.SubmissionStatus)
+
+	// When
+	report, err := uc.SubmitAll(context.Background(), tests, SubmitAllOptions{
+		Patterns: []string{root + "/..."},
+		Delay:    50 * time.Millisecond,
+		OnStatus: func(problem string, status entity.SubmissionStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[problem] = append(seen[problem], status)
+		},
+	})
+
+	// Then
+	assert.NoError(t, err)
+	if len(report.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(report.Results))
+	}
+	submissionRepo.AssertNumberOfCalls(t, "Submit", 2)
+	if len(sent) == 2 {
+		assert.GreaterOrEqual(t, sent[1].Sub(sent[0]), 50*time.Millisecond, "submissions are spaced by the delay")
+	}
+
+	accepted, failing, rejected := report.Results[0], report.Results[1], report.Results[2]
+	if assert.NotNil(t, accepted.Submission) {
+		assert.True(t, accepted.Submission.IsAccepted())
+		assert.Len(t, accepted.Submission.CaseResults(), 2)
+		assert.Zero(t, accepted.Submission.FailedCase())
+	}
+	assert.Nil(t, failing.Submission, "problems failing their samples are not submitted")
+	assert.Equal(t, VerdictWrongAnswer, failing.Test.Verdict)
+	if assert.NotNil(t, rejected.Submission) {
+		assert.Equal(t, entity.StatusWrongAnswer, rejected.Submission.Status())
+		assert.Equal(t, 2, rejected.Submission.FailedCase())
+	}
+	assert.Equal(t, 2, report.SubmittedCount())
+	assert.Equal(t, 1, report.AcceptedCount())
+	assert.Equal(t, map[string][]entity.SubmissionStatus{
+		"ITP1_1_A": {entity.StatusJudging, entity.StatusAccepted},
+		"ITP1_1_C": {entity.StatusJudging, entity.StatusWrongAnswer},
+	}, seen)
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+const (
+	// defaultSubmitRetryAttempts is how often a submission is sent while AOJ fails with transient errors
+	defaultSubmitRetryAttempts = 3
+	// defaultSubmitRetryBackoff is the delay before the second attempt, doubled for each further one
+	defaultSubmitRetryBackoff = 2 * time.Second
+	// defaultQueuePollInterval is the default shortest interval between judge queue lookups
+	defaultQueuePollInterval = 5 * time.Second
+)
+
+// isRetryableSubmitError returns true if sending a submission failed for a
+// reason that may go away by itself, such as a server error or a timeout
+func isRetryableSubmitError(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && cerrors.IsRetryable(err)
+}
+
+// isQueueableSubmitError returns true if a submission that failed with err
+// should be kept for 'aoj submit --retry-pending'
+func isQueueableSubmitError(ctx context.Context, err error) bool {
+	return isRetryableSubmitError(ctx, err) || cerrors.IsAppError(err, cerrors.CodeOffline)
+}
+
+// send submits to AOJ, trying again with doubling delays while AOJ fails with
+// transient errors
+func (uc *SubmitUseCase) send(ctx context.Context, submission *entity.Submission, onWarning func(string)) error {
+	delay := uc.settings.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := uc.submissionRepo.Submit(ctx, submission)
+		if err == nil || attempt >= uc.settings.RetryAttempts || !isRetryableSubmitError(ctx, err) {
+			return err
+		}
+
+		uc.logger.WarnContext(ctx, "submission failed, retrying", "attempt", attempt, "delay", delay, "error", err)
+		if onWarning != nil {
+			onWarning(fmt.Sprintf("%v; retrying in %s (attempt %d of %d)", err, delay, attempt+1, uc.settings.RetryAttempts))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// enqueue keeps a submission that could not be sent because of err for
+// 'aoj submit --retry-pending'. Errors that will not go away by waiting are
+// returned unchanged
+func (uc *SubmitUseCase) enqueue(ctx context.Context, err error, pending repository.PendingSubmission) error {
+	if uc.settings.Pending == nil || !isQueueableSubmitError(ctx, err) {
+		return err
+	}
+
+	queue, listErr := uc.settings.Pending.List(ctx)
+	if listErr != nil {
+		uc.logger.WarnContext(ctx, "failed to read pending submissions", "error", listErr)
+		return err
+	}
+	pending.QueuedAt = uc.clock.Now()
+	pending.Attempts = 1
+	pending.LastError = err.Error()
+	queue = append(queue, pending)
+	if saveErr := uc.settings.Pending.SaveAll(ctx, queue); saveErr != nil {
+		uc.logger.WarnContext(ctx, "failed to queue submission", "error", saveErr)
+		return err
+	}
+
+	uc.logger.InfoContext(ctx, "queued submission", "problem_id", pending.ProblemID, "pending", len(queue))
+	return cerrors.WithHint(
+		cerrors.NewAppError(
+			cerrors.GetErrorCode(err),
+			fmt.Sprintf("the submission was queued (%d pending)", len(queue)),
+			err,
+		),
+		"Send it with 'aoj submit --retry-pending' once AOJ is reachable again.",
+	)
+}
+
+// RetryPendingOptions contains options for sending queued submissions
+type RetryPendingOptions struct {
+	Watch   bool // Optional: wait for the verdicts even if not configured
+	NoWatch bool // Optional: do not wait for the verdicts even if configured
+
+	PollInterval time.Duration // Optional: first interval between verdict polls (defaults to the configured one)
+	Timeout      time.Duration // Optional: stop waiting for each verdict after this long; 0 waits until it is final
+
+	// OnSubmitted is called with every queued submission that reached AOJ
+	OnSubmitted func(submission *entity.Submission)
+	// OnStatus is called with every verdict change while waiting for a verdict
+	OnStatus func(status entity.SubmissionStatus)
+	// OnQueue is called whenever the place of a submission in the judge queue
+	// changes while it waits for the judge
+	OnQueue func(queue repository.JudgeQueue)
+	// OnWarning is called before a failed attempt is retried
+	OnWarning func(message string)
+}
+
+// RejectedSubmission is a queued submission that AOJ refused and that was
+// dropped from the queue
+type RejectedSubmission struct {
+	Pending repository.PendingSubmission
+	Err     error
+}
+
+// RetryPendingResult holds the outcome of sending queued submissions
+type RetryPendingResult struct {
+	Submitted []*entity.Submission
+	Rejected  []RejectedSubmission
+	Remaining int // submissions still queued
+}
+
+// RetryPending sends the queued submissions, oldest first. Submissions that
+// reach AOJ or are refused by it leave the queue. When AOJ is still
+// unavailable, or the session is not valid, the rest stays queued and the
+// error is returned
+func (uc *SubmitUseCase) RetryPending(ctx context.Context, opts RetryPendingOptions) (*RetryPendingResult, error) {
+	if uc.settings.Pending == nil {
+		return nil, cerrors.NewAppError(cerrors.CodeInternalServer, "the submission queue is not available", nil)
+	}
+	queue, err := uc.settings.Pending.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RetryPendingResult{}
+	watch := watchOptions{
+		enabled:  (uc.settings.Watch || opts.Watch) && !opts.NoWatch,
+		interval: opts.PollInterval,
+		timeout:  opts.Timeout,
+		onStatus: opts.OnStatus,
+		onQueue:  opts.OnQueue,
+	}
+	var stopErr error
+	for i, pending := range queue {
+		submission, err := uc.sendPending(ctx, pending, watch, opts)
+		if submission != nil {
+			result.Submitted = append(result.Submitted, submission)
+			if opts.OnSubmitted != nil {
+				opts.OnSubmitted(submission)
+			}
+			continue
+		}
+		if isQueueableSubmitError(ctx, err) || cerrors.IsAppError(err, cerrors.CodeUnauthorized) || ctx.Err() != nil {
+			pending.Attempts++
+			pending.LastError = err.Error()
+			queue = append([]repository.PendingSubmission{pending}, queue[i+1:]...)
+			stopErr = err
+			break
+		}
+		uc.logger.WarnContext(ctx, "queued submission rejected", "problem_id", pending.ProblemID, "error", err)
+		result.Rejected = append(result.Rejected, RejectedSubmission{Pending: pending, Err: err})
+	}
+	if stopErr == nil {
+		queue = nil
+	}
+	result.Remaining = len(queue)
+
+	if err := uc.settings.Pending.SaveAll(ctx, queue); err != nil {
+		return result, err
+	}
+	uc.logger.InfoContext(ctx, "retried pending submissions",
+		"submitted", len(result.Submitted),
+		"rejected", len(result.Rejected),
+		"remaining", result.Remaining)
+	return result, stopErr
+}
+
+// sendPending submits a queued submission. The submission is returned once it
+// reached AOJ, even if waiting for its verdict failed
+func (uc *SubmitUseCase) sendPending(
+	ctx context.Context,
+	pending repository.PendingSubmission,
+	watch watchOptions,
+	opts RetryPendingOptions,
+) (*entity.Submission, error) {
+	problemID, err := model.NewProblemID(pending.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	submission, err := uc.submit(ctx, problemID, pending.Language, pending.SourceCode, watch, opts.OnWarning)
+	if submission != nil && err != nil {
+		uc.logger.WarnContext(ctx, "failed to get the verdict of a queued submission", "error", err)
+	}
+	return submission, err
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// memoryPendingSubmissionRepository keeps the submission queue in memory
+type memoryPendingSubmissionRepository struct {
+	pending []repository.PendingSubmission
+}
+
+func (r *memoryPendingSubmissionRepository) List(_ context.Context) ([]repository.PendingSubmission, error) {
+	return append([]repository.PendingSubmission(nil), r.pending...), nil
+}
+
+func (r *memoryPendingSubmissionRepository) SaveAll(_ context.Context, pending []repository.PendingSubmission) error {
+	r.pending = append([]repository.PendingSubmission(nil), pending...)
+	return nil
+}
+
+// newQueueingSubmitUseCase returns a SubmitUseCase with a valid session that
+// retries quickly and queues into pending
+func newQueueingSubmitUseCase(submissionRepo *MockSubmissionRepository, pending *memoryPendingSubmissionRepository) *SubmitUseCase {
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	return NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{
+			Pending:       pending,
+			RetryAttempts: 3,
+			RetryBackoff:  time.Millisecond,
+		})
+}
+
+func TestSubmitUseCase_Execute_Retry(t *testing.T) {
+	unavailable := cerrors.NewAppError(cerrors.CodeServiceUnavailable, "AOJ server error", nil)
+	tests := []struct {
+		name      string
+		errors    []error // returned by the attempts in order, nil once sent
+		wantCalls int
+		wantErr   cerrors.ErrorCode
+		queued    bool
+	}{
+		{name: "recovers", errors: []error{unavailable, nil}, wantCalls: 2},
+		{name: "still unavailable", errors: []error{unavailable, unavailable, unavailable}, wantCalls: 3, wantErr: cerrors.CodeServiceUnavailable, queued: true},
+		{name: "timeout", errors: []error{
+			cerrors.NewAppError(cerrors.CodeTimeout, "AOJ did not respond in time", nil),
+			cerrors.NewAppError(cerrors.CodeTimeout, "AOJ did not respond in time", nil),
+			cerrors.NewAppError(cerrors.CodeTimeout, "AOJ did not respond in time", nil),
+		}, wantCalls: 3, wantErr: cerrors.CodeTimeout, queued: true},
+		{name: "offline", errors: []error{cerrors.NewAppError(cerrors.CodeOffline, "offline", nil)}, wantCalls: 1, wantErr: cerrors.CodeOffline, queued: true},
+		{name: "rejected", errors: []error{cerrors.NewAppError(cerrors.CodeInvalidInput, "invalid submission request", nil)}, wantCalls: 1, wantErr: cerrors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+			assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+			submissionRepo := &MockSubmissionRepository{}
+			for _, err := range tt.errors {
+				submissionRepo.On("Submit", mock.Anything, mock.Anything).Return(err).Once()
+			}
+			pending := &memoryPendingSubmissionRepository{}
+			uc := newQueueingSubmitUseCase(submissionRepo, pending)
+			var warnings []string
+
+			// When
+			submission, err := uc.Execute(context.Background(), SubmitOptions{
+				ProblemID: "ITP1_1_A",
+				FilePath:  sourcePath,
+				OnWarning: func(message string) { warnings = append(warnings, message) },
+			})
+
+			// Then
+			submissionRepo.AssertNumberOfCalls(t, "Submit", tt.wantCalls)
+			assert.Len(t, warnings, tt.wantCalls-1, "every retry is announced")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, submission)
+				assert.Empty(t, pending.pending)
+				return
+			}
+			assert.Nil(t, submission)
+			assert.True(t, cerrors.IsAppError(err, tt.wantErr), "unexpected error: %v", err)
+			if !tt.queued {
+				assert.Empty(t, pending.pending)
+				return
+			}
+			assert.NotEmpty(t, cerrors.GetAllHints(err))
+			if assert.Len(t, pending.pending, 1) {
+				assert.Equal(t, "ITP1_1_A", pending.pending[0].ProblemID)
+				assert.Equal(t, "C++17", pending.pending[0].Language)
+				assert.Equal(t, "int main() {}", pending.pending[0].SourceCode)
+				assert.Equal(t, sourcePath, pending.pending[0].SourceFile)
+			}
+		})
+	}
+}
+
+func TestSubmitUseCase_RetryPending(t *testing.T) {
+	queue := []repository.PendingSubmission{
+		{ProblemID: "ITP1_1_A", Language: "C++14", SourceCode: "a", Attempts: 1},
+		{ProblemID: "ITP1_1_B", Language: "C++14", SourceCode: "b", Attempts: 1},
+		{ProblemID: "ITP1_1_C", Language: "C++14", SourceCode: "c", Attempts: 1},
+	}
+	bySource := func(source string) interface{} {
+		return mock.MatchedBy(func(s *entity.Submission) bool { return s.SourceCode() == source })
+	}
+
+	t.Run("sends the queue", func(t *testing.T) {
+		// Given
+		submissionRepo := &MockSubmissionRepository{}
+		submissionRepo.On("Submit", mock.Anything, bySource("a")).Return(nil)
+		submissionRepo.On("Submit", mock.Anything, bySource("b")).
+			Return(cerrors.NewAppError(cerrors.CodeInvalidInput, "invalid submission request", nil))
+		submissionRepo.On("Submit", mock.Anything, bySource("c")).Return(nil)
+		pending := &memoryPendingSubmissionRepository{pending: queue}
+		uc := newQueueingSubmitUseCase(submissionRepo, pending)
+
+		// When
+		result, err := uc.RetryPending(context.Background(), RetryPendingOptions{})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, result.Submitted, 2)
+		assert.Equal(t, "ITP1_1_C", result.Submitted[1].ProblemID().String())
+		if assert.Len(t, result.Rejected, 1) {
+			assert.Equal(t, "ITP1_1_B", result.Rejected[0].Pending.ProblemID)
+		}
+		assert.Zero(t, result.Remaining)
+		assert.Empty(t, pending.pending)
+	})
+
+	t.Run("AOJ still unavailable", func(t *testing.T) {
+		// Given
+		submissionRepo := &MockSubmissionRepository{}
+		submissionRepo.On("Submit", mock.Anything, bySource("a")).Return(nil)
+		submissionRepo.On("Submit", mock.Anything, bySource("b")).
+			Return(cerrors.NewAppError(cerrors.CodeServiceUnavailable, "AOJ server error", nil))
+		pending := &memoryPendingSubmissionRepository{pending: queue}
+		uc := newQueueingSubmitUseCase(submissionRepo, pending)
+
+		// When
+		result, err := uc.RetryPending(context.Background(), RetryPendingOptions{})
+
+		// Then
+		assert.True(t, cerrors.IsAppError(err, cerrors.CodeServiceUnavailable), "unexpected error: %v", err)
+		assert.Len(t, result.Submitted, 1)
+		assert.Equal(t, 2, result.Remaining)
+		if assert.Len(t, pending.pending, 2) {
+			assert.Equal(t, "ITP1_1_B", pending.pending[0].ProblemID)
+			assert.Equal(t, 2, pending.pending[0].Attempts)
+			assert.Contains(t, pending.pending[0].LastError, "AOJ server error")
+			assert.Equal(t, queue[2], pending.pending[1])
+		}
+		submissionRepo.AssertNotCalled(t, "Submit", mock.Anything, bySource("c"))
+	})
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+)
+
+// ScoreReport compares the score of a judged submission with the earlier
+// submissions of its problem in the local history
+type ScoreReport struct {
+	ProblemID    string
+	Score        int
+	PreviousBest int // best score of the earlier submissions
+	Earlier      int // judged earlier submissions of the problem in the history
+}
+
+// NewBest returns true if the submission scored more than every earlier one
+func (r ScoreReport) NewBest() bool {
+	return r.Score > r.PreviousBest
+}
+
+// Best returns the best score of the problem, including the submission
+func (r ScoreReport) Best() int {
+	return max(r.Score, r.PreviousBest)
+}
+
+// scoreReport looks up the best score of the submission's problem in the
+// local history, which keeps the score of every submission made or synced
+func (uc *SubmitUseCase) scoreReport(ctx context.Context, submission *entity.Submission) ScoreReport {
+	report := ScoreReport{
+		ProblemID: submission.ProblemID().String(),
+		Score:     submission.Score(),
+	}
+
+	criteria := repository.NewSubmissionSearchCriteria().WithProblemID(submission.ProblemID()).WithLimit(0)
+	history, err := uc.submissionRepo.Search(ctx, criteria)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to read the best score from the history", "error", err)
+		return report
+	}
+	for _, earlier := range history {
+		if earlier.ID().Equals(submission.ID()) || !earlier.Status().IsFinal() {
+			continue
+		}
+		report.Earlier++
+		report.PreviousBest = max(report.PreviousBest, earlier.Score())
+	}
+	return report
+}
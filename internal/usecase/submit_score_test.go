@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+)
+
+func TestSubmitUseCase_Execute_ReportsScore(t *testing.T) {
+	earlier := func(id int64, score int, status entity.SubmissionStatus) *entity.Submission {
+		submission := entity.NewSubmission(model.NewSubmissionIDFromInt(id), model.MustNewProblemID("DPL_1_A"), "C++17", "")
+		submission.UpdateResult(status, score, 0, 0, "")
+		return submission
+	}
+
+	tests := []struct {
+		name    string
+		history []*entity.Submission
+		want    ScoreReport
+		newBest bool
+	}{
+		{
+			name: "first submission",
+			want: ScoreReport{ProblemID: "DPL_1_A", Score: 60},
+		},
+		{
+			name:    "new best",
+			history: []*entity.Submission{earlier(1, 40, entity.StatusWrongAnswer), earlier(2, 0, entity.StatusPending)},
+			want:    ScoreReport{ProblemID: "DPL_1_A", Score: 60, PreviousBest: 40, Earlier: 1},
+			newBest: true,
+		},
+		{
+			name:    "below the best",
+			history: []*entity.Submission{earlier(1, 80, entity.StatusTimeLimitExceeded), earlier(2, 20, entity.StatusWrongAnswer)},
+			want:    ScoreReport{ProblemID: "DPL_1_A", Score: 60, PreviousBest: 80, Earlier: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+			assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+			sessionRepo := &MockSessionRepository{}
+			sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Submit", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					submission := args.Get(1).(*entity.Submission)
+					submission.SetJudgeID("12345")
+					submission.UpdateResult(entity.StatusWrongAnswer, 0, 0, 0, "")
+				}).
+				Return(nil)
+			submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).
+				Return(repository.JudgeDetail{Score: 60, Time: 20 * time.Millisecond, Memory: 1024}, nil)
+			submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+			submissionRepo.On("Search", mock.Anything, mock.Anything).Return(tt.history, nil)
+
+			uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+				&stubLanguageRepository{languages: []string{"C++17"}},
+				SubmitSettings{Language: "C++17"})
+
+			var reports []ScoreReport
+			opts := SubmitOptions{
+				ProblemID: "DPL_1_A",
+				FilePath:  sourcePath,
+				OnScore:   func(report ScoreReport) { reports = append(reports, report) },
+			}
+
+			// When
+			submission, err := uc.Execute(context.Background(), opts)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, 60, submission.Score())
+			if len(reports) != 1 {
+				t.Fatalf("expected one score report, got %d", len(reports))
+			}
+			assert.Equal(t, tt.want, reports[0])
+			assert.Equal(t, tt.newBest, reports[0].Earlier > 0 && reports[0].NewBest())
+		})
+	}
+}
@@ -2,16 +2,23 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
 	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/fuzzy"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
 )
 
@@ -19,26 +26,127 @@ import (
 type SubmitUseCase struct {
 	submissionRepo repository.SubmissionRepository
 	sessionRepo    repository.SessionRepository
+	languageRepo   repository.LanguageRepository
+	settings       SubmitSettings
+	clock          clock.Clock
 	logger         *logger.Logger
 }
 
-// NewSubmitUseCase creates a new SubmitUseCase
+// SubmitSettings holds the configured defaults of the submit use case
+type SubmitSettings struct {
+	SourceFile   string        // preferred source file; the newest recognized file is used if it does not exist
+	Language     string        // preferred version of the detected language, e.g. C++17 for .cpp files
+	Watch        bool          // wait for the verdict after submitting
+	PollInterval time.Duration // interval between verdict polls; 0 means the repository default
+	Clock        clock.Clock   // defaults to the system clock
+
+	// JudgeQueue reports the place of a submission in the judge queue while
+	// waiting for its verdict, looked up at most every QueuePollInterval; nil
+	// disables it
+	JudgeQueue        repository.JudgeQueueRepository
+	QueuePollInterval time.Duration
+
+	// Git commits accepted solutions when GitCommitOnAC is set; nil disables it
+	Git           service.VersionControl
+	GitCommitOnAC bool
+	GitTag        string // tag created on the commit; {problem} and {id} are replaced, empty for none
+
+	// Todos is the practice list whose item is marked done on AC; nil disables it
+	Todos repository.TodoRepository
+
+	// Transforms add snippets to submitted sources, keyed by AOJ language or language family
+	Transforms map[string]SourceTransform
+
+	// Aliases are accepted as problem IDs and directory names
+	Aliases ProblemAliases
+
+	// Pending keeps submissions that could not be sent while AOJ was
+	// unavailable; nil disables queueing
+	Pending repository.PendingSubmissionRepository
+	// RetryAttempts is how often a submission is sent while AOJ fails with
+	// transient errors, and RetryBackoff the delay before the second attempt,
+	// doubled for each further one
+	RetryAttempts int
+	RetryBackoff  time.Duration
+
+	// BatchDelay is the shortest time between two submissions of SubmitAll
+	BatchDelay time.Duration
+}
+
+// NewSubmitUseCase creates a new SubmitUseCase with configured defaults
 func NewSubmitUseCase(
 	submissionRepo repository.SubmissionRepository,
 	sessionRepo repository.SessionRepository,
+	languageRepo repository.LanguageRepository,
+	settings SubmitSettings,
 ) *SubmitUseCase {
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+	if settings.RetryAttempts <= 0 {
+		settings.RetryAttempts = defaultSubmitRetryAttempts
+	}
+	if settings.RetryBackoff <= 0 {
+		settings.RetryBackoff = defaultSubmitRetryBackoff
+	}
+	if settings.QueuePollInterval <= 0 {
+		settings.QueuePollInterval = defaultQueuePollInterval
+	}
+	if settings.BatchDelay <= 0 {
+		settings.BatchDelay = defaultBatchDelay
+	}
+
 	return &SubmitUseCase{
 		submissionRepo: submissionRepo,
 		sessionRepo:    sessionRepo,
+		languageRepo:   languageRepo,
+		settings:       settings,
+		clock:          settings.Clock,
 		logger:         logger.WithGroup("submit_usecase"),
 	}
 }
 
+// StdinSource is the source file path that reads the source code from stdin
+const StdinSource = "-"
+
 // SubmitOptions contains options for submission
 type SubmitOptions struct {
-	ProblemID string // Optional: explicit problem ID (defaults to directory name)
-	FilePath  string // Optional: source file path (defaults to main.go)
-	Language  string // Optional: language (defaults to auto-detect from extension)
+	Dir         string // Optional: problem directory (defaults to the current directory)
+	ProblemID   string // Optional: explicit problem ID or alias (inferred from the directory by default)
+	FilePath    string // Optional: source file path (defaults to the configured or newest source file); StdinSource reads Stdin
+	Language    string // Optional: language (defaults to auto-detect from extension)
+	Watch       bool   // Optional: wait for the verdict even if not configured
+	NoWatch     bool   // Optional: do not wait for the verdict even if configured
+	NoGit       bool   // Optional: do not commit the solution even if submit.git_commit_on_ac is set
+	NoTransform bool   // Optional: submit the file as is even if submit.transform applies
+
+	PollInterval time.Duration // Optional: first interval between verdict polls (defaults to the configured one)
+	Timeout      time.Duration // Optional: stop waiting for the verdict after this long; 0 waits until it is final
+
+	// Stdin is read when FilePath is StdinSource; nil reads os.Stdin
+	Stdin io.Reader
+
+	// OnStatus is called with every verdict change while waiting for the verdict
+	OnStatus func(status entity.SubmissionStatus)
+	// OnQueue is called whenever the place of the submission in the judge
+	// queue changes while it waits for the judge
+	OnQueue func(queue repository.JudgeQueue)
+	// OnGitCommit is called after trying to commit an accepted solution
+	OnGitCommit func(result GitCommitResult)
+	// OnScore is called once a submission is judged with a score, to compare
+	// it with the best score of the problem
+	OnScore func(report ScoreReport)
+	// OnWarning is called with problems of the source file that do not stop
+	// the submission and before a failed attempt to send it is retried
+	OnWarning func(message string)
+}
+
+// GitCommitResult describes the commit of an accepted solution
+type GitCommitResult struct {
+	Message string
+	Hash    string // empty if the solution had no changes to commit
+	Tag     string // empty if no tag was created
+	Err     error  // why the commit or the tag failed; the submission itself is not affected
 }
 
 // Execute executes the submit use case
@@ -46,7 +154,8 @@ func (uc *SubmitUseCase) Execute(ctx context.Context, opts SubmitOptions) (*enti
 	uc.logger.InfoContext(ctx, "starting submission", "options", fmt.Sprintf("%+v", opts))
 
 	// Determine problem ID
-	problemID, err := uc.determineProblemID(opts.ProblemID)
+	dir := cmp.Or(opts.Dir, ".")
+	problemID, err := resolveProblemID(dir, opts.ProblemID, uc.settings.Aliases)
 	if err != nil {
 		return nil, err
 	}
@@ -55,45 +164,234 @@ func (uc *SubmitUseCase) Execute(ctx context.Context, opts SubmitOptions) (*enti
 	// Determine source file path
 	filePath := opts.FilePath
 	if filePath == "" {
-		filePath = "main.go" // Default
+		filePath, err = uc.detectSourceFile(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Read source code
-	sourceCode, err := os.ReadFile(filePath)
+	fromStdin := filePath == StdinSource
+	sourceName := filePath
+	var content []byte
+	if fromStdin {
+		if opts.Language == "" && uc.settings.Language == "" {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				"the language of a source read from stdin cannot be detected. Please specify --language",
+				nil,
+			)
+		}
+		sourceName = "stdin"
+		stdin := opts.Stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		content, err = io.ReadAll(stdin)
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to read source code from stdin")
+		}
+	} else {
+		content, err = os.ReadFile(filePath)
+		if err != nil {
+			return nil, cerrors.Wrap(err, fmt.Sprintf("failed to read source file: %s", filePath))
+		}
+	}
+	uc.logger.InfoContext(ctx, "read source file", "file_path", sourceName, "size", len(content))
+
+	// Check the encoding and size before contacting AOJ
+	sourceCode, warnings, err := prepareSource(sourceName, content)
 	if err != nil {
-		return nil, cerrors.Wrap(err, fmt.Sprintf("failed to read source file: %s", filePath))
+		return nil, err
+	}
+	if err := checkSourceSize(sourceName, sourceCode); err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		uc.logger.WarnContext(ctx, "suspicious source file", "warning", warning)
+		if opts.OnWarning != nil {
+			opts.OnWarning(warning)
+		}
 	}
-	uc.logger.InfoContext(ctx, "read source file", "file_path", filePath, "size", len(sourceCode))
 
 	// Determine language
 	language := opts.Language
-	if language == "" {
-		language = uc.detectLanguage(filePath)
+	switch {
+	case language != "":
+	case fromStdin:
+		language = uc.settings.Language
+	default:
+		language = uc.defaultLanguage(filePath)
+	}
+	language, err = uc.validateLanguage(ctx, language)
+	if err != nil {
+		return nil, err
 	}
 	uc.logger.InfoContext(ctx, "determined language", "language", language)
 
-	// Get current session
-	session, err := uc.sessionRepo.GetCurrent(ctx)
+	if transform, ok := findTransform(uc.settings.Transforms, language); ok && !opts.NoTransform {
+		sourceCode = transform.apply(sourceCode)
+		uc.logger.InfoContext(ctx, "applied source transform", "language", language, "size", len(sourceCode))
+		if err := checkSourceSize(sourceName, sourceCode); err != nil {
+			return nil, err
+		}
+	}
+
+	watch := watchOptions{
+		enabled:  (uc.settings.Watch || opts.Watch) && !opts.NoWatch,
+		interval: opts.PollInterval,
+		timeout:  opts.Timeout,
+		onStatus: opts.OnStatus,
+		onQueue:  opts.OnQueue,
+	}
+	submission, err := uc.submit(ctx, problemID, language, sourceCode, watch, opts.OnWarning)
+	if submission == nil && err != nil {
+		pending := repository.PendingSubmission{
+			ProblemID:  problemID.String(),
+			Language:   language,
+			SourceCode: sourceCode,
+		}
+		if !fromStdin {
+			pending.SourceFile = filePath
+		}
+		return nil, uc.enqueue(ctx, err, pending)
+	}
+	if err == nil && opts.OnScore != nil && submission.Status().IsFinal() && submission.Score() > 0 {
+		opts.OnScore(uc.scoreReport(ctx, submission))
+	}
+	// A source read from stdin has no file to commit
+	if err == nil && !fromStdin && uc.shouldCommit(submission, opts.NoGit) {
+		result := uc.commitAccepted(ctx, submission, filePath)
+		if opts.OnGitCommit != nil {
+			opts.OnGitCommit(result)
+		}
+	}
+	return submission, err
+}
+
+// shouldCommit reports whether an accepted solution is committed
+func (uc *SubmitUseCase) shouldCommit(submission *entity.Submission, noGit bool) bool {
+	return submission.IsAccepted() && uc.settings.GitCommitOnAC && uc.settings.Git != nil && !noGit
+}
+
+// commitAccepted commits the accepted solution file with a message such as
+// "AC ITP1_1_A (C++17, 0.01s)" and tags the commit if configured. Failures
+// are only logged, as the submission has already succeeded
+func (uc *SubmitUseCase) commitAccepted(ctx context.Context, submission *entity.Submission, filePath string) GitCommitResult {
+	details := submission.Language()
+	if submission.Time() > 0 {
+		details += fmt.Sprintf(", %.2fs", submission.Time().Seconds())
+	}
+	result := GitCommitResult{
+		Message: fmt.Sprintf("AC %s (%s)", submission.ProblemID().String(), details),
+	}
+
+	result.Hash, result.Err = uc.settings.Git.Commit(ctx, []string{filePath}, result.Message)
+	if result.Err != nil {
+		uc.logger.WarnContext(ctx, "failed to commit accepted solution", "error", result.Err)
+		return result
+	}
+	if result.Hash == "" || uc.settings.GitTag == "" {
+		return result
+	}
+
+	id := submission.JudgeID()
+	if id == "" {
+		id = submission.ID().String()
+	}
+	tag := strings.NewReplacer("{problem}", submission.ProblemID().String(), "{id}", id).Replace(uc.settings.GitTag)
+	if err := uc.settings.Git.Tag(ctx, filepath.Dir(filePath), tag); err != nil {
+		uc.logger.WarnContext(ctx, "failed to tag accepted solution", "tag", tag, "error", err)
+		result.Err = err
+		return result
+	}
+	result.Tag = tag
+	return result
+}
+
+// ResubmitOptions contains options for resubmission
+type ResubmitOptions struct {
+	ProblemID    string // Optional: explicit problem ID or alias (inferred from the directory by default)
+	SubmissionID string // Optional: submission to resubmit (defaults to the latest for the problem)
+}
+
+// Resubmit submits the source code of a previous submission again
+func (uc *SubmitUseCase) Resubmit(ctx context.Context, opts ResubmitOptions) (*entity.Submission, error) {
+	uc.logger.InfoContext(ctx, "starting resubmission", "options", fmt.Sprintf("%+v", opts))
+
+	previous, err := uc.findPreviousSubmission(ctx, opts)
 	if err != nil {
-		return nil, cerrors.Wrap(err, "failed to get current session")
+		return nil, err
 	}
+	uc.logger.InfoContext(ctx, "found previous submission",
+		"submission_id", previous.ID().String(),
+		"problem_id", previous.ProblemID().String())
 
-	if session == nil {
-		return nil, cerrors.NewAppError(
-			cerrors.CodeUnauthorized,
-			"no active session found. Please login first with 'aoj login'",
-			nil,
-		)
+	submission, err := uc.submit(ctx, previous.ProblemID(), previous.Language(), previous.SourceCode(), watchOptions{enabled: uc.settings.Watch}, nil)
+	if submission == nil && err != nil {
+		return nil, uc.enqueue(ctx, err, repository.PendingSubmission{
+			ProblemID:  previous.ProblemID().String(),
+			Language:   previous.Language(),
+			SourceCode: previous.SourceCode(),
+		})
 	}
+	return submission, err
+}
 
-	if session.IsExpired() {
+// findPreviousSubmission looks up the submission to resubmit in the local history
+func (uc *SubmitUseCase) findPreviousSubmission(ctx context.Context, opts ResubmitOptions) (*entity.Submission, error) {
+	if opts.SubmissionID != "" {
+		submissionID, err := model.NewSubmissionID(opts.SubmissionID)
+		if err != nil {
+			return nil, err
+		}
+		return uc.submissionRepo.GetByID(ctx, submissionID)
+	}
+
+	problemID, err := uc.determineProblemID(opts.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := uc.submissionRepo.GetByProblemID(ctx, problemID, 1)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+	if len(submissions) == 0 {
 		return nil, cerrors.NewAppError(
-			cerrors.CodeUnauthorized,
-			"session has expired. Please login again with 'aoj login'",
+			cerrors.CodeNotFound,
+			fmt.Sprintf("no previous submission found for %s", problemID.String()),
 			nil,
 		)
 	}
 
+	return submissions[0], nil
+}
+
+// watchOptions controls waiting for the verdict of a submission
+type watchOptions struct {
+	enabled  bool
+	interval time.Duration // 0 means the configured interval
+	timeout  time.Duration // 0 waits until the verdict is final
+	onStatus func(entity.SubmissionStatus)
+	onQueue  func(repository.JudgeQueue)
+}
+
+// submit submits the source code to AOJ with the current session and, if
+// watching is enabled, waits for the verdict. A submission that was accepted
+// by AOJ is returned even if waiting for its verdict fails
+func (uc *SubmitUseCase) submit(
+	ctx context.Context,
+	problemID model.ProblemID,
+	language, sourceCode string,
+	watch watchOptions,
+	onWarning func(string),
+) (*entity.Submission, error) {
+	// Require an active session
+	if _, err := activeSession(ctx, uc.sessionRepo, uc.clock); err != nil {
+		return nil, err
+	}
+
 	// Generate submission ID
 	submissionID, err := model.GenerateSubmissionID()
 	if err != nil {
@@ -101,15 +399,16 @@ func (uc *SubmitUseCase) Execute(ctx context.Context, opts SubmitOptions) (*enti
 	}
 
 	// Create submission entity
-	submission := entity.NewSubmission(
+	submission := entity.NewSubmissionAt(
 		submissionID,
 		problemID,
 		language,
-		string(sourceCode),
+		sourceCode,
+		uc.clock.Now(),
 	)
 
 	// Submit to AOJ
-	if err := uc.submissionRepo.Submit(ctx, submission); err != nil {
+	if err := uc.send(ctx, submission, onWarning); err != nil {
 		uc.logger.ErrorContext(ctx, "submission failed", "error", err)
 		return nil, cerrors.Wrap(err, "failed to submit solution")
 	}
@@ -118,63 +417,283 @@ func (uc *SubmitUseCase) Execute(ctx context.Context, opts SubmitOptions) (*enti
 		"submission_id", submissionID.String(),
 		"problem_id", problemID.String())
 
+	if watch.enabled && !submission.Status().IsFinal() {
+		if err := uc.waitForVerdict(ctx, submission, watch); err != nil {
+			return submission, err
+		}
+	}
+	uc.recordVerdictDetails(ctx, submission)
+
 	return submission, nil
 }
 
-// determineProblemID determines the problem ID from options or current directory
-func (uc *SubmitUseCase) determineProblemID(explicitID string) (model.ProblemID, error) {
-	if explicitID != "" {
-		return model.NewProblemID(explicitID)
+// recordVerdictDetails fetches what AOJ tells about a verdict beyond the
+// status, such as the compiler message, the score or the results per test
+// case, and marks the problem done in the TODO list once accepted
+func (uc *SubmitUseCase) recordVerdictDetails(ctx context.Context, submission *entity.Submission) {
+	problemID := submission.ProblemID()
+	switch {
+	case submission.Status() == entity.StatusCompileError:
+		uc.fetchCompileError(ctx, submission)
+	case submission.Status().IsFinal():
+		uc.fetchJudgeDetail(ctx, submission)
+	}
+
+	if submission.IsAccepted() && uc.settings.Todos != nil {
+		if done, err := completeTodo(ctx, uc.settings.Todos, problemID, uc.clock.Now()); err != nil {
+			uc.logger.WarnContext(ctx, "failed to update TODO list", "error", err)
+		} else if done {
+			uc.logger.InfoContext(ctx, "marked TODO done", "problem_id", problemID.String())
+		}
+	}
+}
+
+// waitForVerdict watches the submission on AOJ until it is judged and records
+// the verdict. While the submission waits for the judge, its place in the judge
+// queue is reported to watch.onQueue
+func (uc *SubmitUseCase) waitForVerdict(ctx context.Context, submission *entity.Submission, watch watchOptions) error {
+	if submission.JudgeID() == "" {
+		uc.logger.WarnContext(ctx, "AOJ did not return a judge ID, cannot wait for the verdict")
+		return nil
+	}
+	judgeID, err := model.NewSubmissionID(submission.JudgeID())
+	if err != nil {
+		return cerrors.Wrap(err, "invalid judge ID returned by AOJ")
+	}
+
+	watchCtx := ctx
+	if watch.timeout > 0 {
+		var cancel context.CancelFunc
+		watchCtx, cancel = context.WithTimeout(ctx, watch.timeout)
+		defer cancel()
+	}
+	interval := cmp.Or(watch.interval, uc.settings.PollInterval)
+	statuses, err := uc.submissionRepo.WatchStatus(watchCtx, judgeID, interval)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to watch the verdict")
 	}
 
-	// Get current directory name
-	cwd, err := os.Getwd()
+	var queueTimer *time.Timer
+	var queueTick <-chan time.Time
+	if watch.onQueue != nil && uc.settings.JudgeQueue != nil {
+		queueTimer = time.NewTimer(0)
+		defer queueTimer.Stop()
+		queueTick = queueTimer.C
+	}
+	var lastQueue repository.JudgeQueue
+	for statuses != nil {
+		select {
+		case status, ok := <-statuses:
+			if !ok {
+				statuses = nil
+				continue
+			}
+			submission.UpdateStatus(status)
+			if watch.onStatus != nil {
+				watch.onStatus(status)
+			}
+			if status != entity.StatusPending {
+				// Judging has started, so the queue no longer matters
+				queueTick = nil
+			}
+		case <-queueTick:
+			queue, err := uc.settings.JudgeQueue.GetJudgeQueue(watchCtx, judgeID)
+			switch {
+			case err != nil:
+				uc.logger.DebugContext(ctx, "failed to get the judge queue", "error", err)
+			case queue.Position > 0 && queue != lastQueue:
+				lastQueue = queue
+				watch.onQueue(queue)
+			}
+			queueTimer.Reset(max(interval, uc.settings.QueuePollInterval))
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if watchCtx.Err() != nil {
+		return cerrors.WithHint(
+			cerrors.NewAppError(
+				cerrors.CodeTimeout,
+				fmt.Sprintf("stopped waiting for the verdict of submission %s after %s", submission.JudgeID(), watch.timeout),
+				watchCtx.Err(),
+			),
+			"The submission was sent; fetch its verdict later with 'aoj history sync'.",
+		)
+	}
+	if !submission.Status().IsFinal() {
+		return cerrors.NewAppError(
+			cerrors.CodeServiceUnavailable,
+			fmt.Sprintf("stopped waiting for the verdict of submission %s", submission.JudgeID()),
+			nil,
+		)
+	}
+
+	if err := uc.submissionRepo.Save(ctx, submission); err != nil {
+		uc.logger.WarnContext(ctx, "failed to record verdict", "error", err)
+	}
+	return nil
+}
+
+// fetchJudgeDetail records the score, time and memory of a judged submission
+// and its results per test case, including the first case a rejected
+// submission failed
+func (uc *SubmitUseCase) fetchJudgeDetail(ctx context.Context, submission *entity.Submission) {
+	detail, err := uc.submissionRepo.GetJudgeDetail(ctx, submission)
 	if err != nil {
-		return model.ProblemID{}, cerrors.Wrap(err, "failed to get current directory")
+		// The verdict itself is still valid, so only warn
+		uc.logger.WarnContext(ctx, "failed to get the judge detail", "error", err)
+		return
+	}
+	if detail.IsEmpty() {
+		return
 	}
+	submission.UpdateResult(submission.Status(), detail.Score, detail.Time, detail.Memory, submission.Message())
+	submission.SetCaseResults(detail.Cases)
 
-	dirName := filepath.Base(cwd)
+	if err := uc.submissionRepo.Save(ctx, submission); err != nil {
+		uc.logger.WarnContext(ctx, "failed to record the judge detail", "error", err)
+	}
+}
 
-	// Try to parse directory name as problem ID
-	problemID, err := model.NewProblemID(dirName)
+// fetchCompileError attaches the compiler message to a submission rejected with COMPILE_ERROR
+func (uc *SubmitUseCase) fetchCompileError(ctx context.Context, submission *entity.Submission) {
+	message, err := uc.submissionRepo.GetCompileError(ctx, submission)
 	if err != nil {
-		return model.ProblemID{}, cerrors.NewAppError(
-			cerrors.CodeInvalidInput,
-			fmt.Sprintf("could not determine problem ID from directory name '%s'. Please specify --problem-id", dirName),
-			err,
+		// The verdict itself is still valid, so only warn
+		uc.logger.WarnContext(ctx, "failed to get compile error", "error", err)
+		return
+	}
+	submission.SetCompileError(message)
+
+	if err := uc.submissionRepo.Save(ctx, submission); err != nil {
+		uc.logger.WarnContext(ctx, "failed to record compile error", "error", err)
+	}
+}
+
+// LatestSubmissions returns at most limit submissions of a problem from the
+// local history, newest first. The problem is the explicit ID or alias, or
+// the one of dir
+func (uc *SubmitUseCase) LatestSubmissions(ctx context.Context, dir, explicitID string, limit int) (model.ProblemID, []*entity.Submission, error) {
+	problemID, err := resolveProblemID(cmp.Or(dir, "."), explicitID, uc.settings.Aliases)
+	if err != nil {
+		return model.ProblemID{}, nil, err
+	}
+	submissions, err := uc.submissionRepo.GetByProblemID(ctx, problemID, limit)
+	if err != nil {
+		return model.ProblemID{}, nil, cerrors.Wrap(err, "failed to read submission history")
+	}
+	return problemID, submissions, nil
+}
+
+// determineProblemID determines the problem ID from options or the current directory
+func (uc *SubmitUseCase) determineProblemID(explicitID string) (model.ProblemID, error) {
+	return resolveProblemID(".", explicitID, uc.settings.Aliases)
+}
+
+// validateLanguage checks the language against the languages accepted by AOJ
+// and returns its canonical spelling
+func (uc *SubmitUseCase) validateLanguage(ctx context.Context, language string) (string, error) {
+	languages, err := uc.languageRepo.List(ctx)
+	if err != nil {
+		// Let the judge decide when the language list is unavailable
+		uc.logger.WarnContext(ctx, "failed to get supported languages, skipping validation", "error", err)
+		return language, nil
+	}
+
+	for _, supported := range languages {
+		if supported == language {
+			return supported, nil
+		}
+	}
+	for _, supported := range languages {
+		if strings.EqualFold(supported, language) {
+			return supported, nil
+		}
+	}
+
+	message := fmt.Sprintf("unsupported language '%s'", language)
+	if suggestion, ok := fuzzy.Closest(language, languages); ok {
+		message += fmt.Sprintf(". Did you mean %s?", suggestion)
+	} else {
+		message += fmt.Sprintf(". Supported languages: %s", strings.Join(languages, ", "))
+	}
+
+	return "", cerrors.NewAppError(cerrors.CodeInvalidInput, message, nil)
+}
+
+// detectSourceFile returns the source file to submit from dir: the configured
+// source file if it exists, otherwise the most recently modified file with a
+// recognized extension
+func (uc *SubmitUseCase) detectSourceFile(ctx context.Context, dir string) (string, error) {
+	if uc.settings.SourceFile != "" {
+		preferred := filepath.Join(dir, uc.settings.SourceFile)
+		if info, err := os.Stat(preferred); err == nil && info.Mode().IsRegular() {
+			return preferred, nil
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to read problem directory")
+	}
+
+	var candidates []string
+	var newest string
+	var newestTime time.Time
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if _, ok := language.ByExtension(filepath.Ext(entry.Name())); !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		candidates = append(candidates, entry.Name())
+		if newest == "" || info.ModTime().After(newestTime) {
+			newest = entry.Name()
+			newestTime = info.ModTime()
+		}
+	}
+
+	if newest == "" {
+		return "", cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no source file found in the current directory. Please specify --file",
+			nil,
 		)
 	}
+	if len(candidates) > 1 {
+		uc.logger.WarnContext(ctx, "multiple source files found, submitting the most recently modified one",
+			"file", newest,
+			"candidates", strings.Join(candidates, ", "))
+	}
+
+	return filepath.Join(dir, newest), nil
+}
 
-	return problemID, nil
+// defaultLanguage returns the configured language if it is a version of the
+// language detected from the file extension, and the detected language otherwise
+func (uc *SubmitUseCase) defaultLanguage(filePath string) string {
+	detected := uc.detectLanguage(filePath)
+	if uc.settings.Language != "" && language.Family(uc.settings.Language) == language.Family(detected) {
+		return uc.settings.Language
+	}
+	return detected
 }
 
 // detectLanguage detects the language from file extension
 func (uc *SubmitUseCase) detectLanguage(filePath string) string {
-	ext := strings.ToLower(filepath.Ext(filePath))
-
-	languageMap := map[string]string{
-		".c":     "C",
-		".cpp":   "C++14",
-		".cc":    "C++14",
-		".cxx":   "C++14",
-		".c++":   "C++14",
-		".java":  "JAVA",
-		".py":    "Python3",
-		".rb":    "Ruby",
-		".go":    "Go",
-		".js":    "JavaScript",
-		".cs":    "C#",
-		".php":   "PHP",
-		".d":     "D",
-		".rs":    "Rust",
-		".kt":    "Kotlin",
-		".scala": "Scala",
-	}
-
-	if lang, ok := languageMap[ext]; ok {
-		return lang
+	if lang, ok := language.ByExtension(filepath.Ext(filePath)); ok {
+		return lang.ID
 	}
 
 	// Default to C++ if unknown
-	return "C++14"
+	lang, _ := language.ByExtension("cpp")
+	return lang.ID
 }
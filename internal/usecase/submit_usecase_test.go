@@ -0,0 +1,783 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// MockSubmissionRepository is a mock implementation of SubmissionRepository
+type MockSubmissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubmissionRepository) Submit(ctx context.Context, submission *entity.Submission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+func (m *MockSubmissionRepository) GetByID(ctx context.Context, id model.SubmissionID) (*entity.Submission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Submission), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) GetByProblemID(ctx context.Context, problemID model.ProblemID, limit int) ([]*entity.Submission, error) {
+	args := m.Called(ctx, problemID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Submission), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) GetRecent(ctx context.Context, limit int) ([]*entity.Submission, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Submission), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) GetStatus(ctx context.Context, id model.SubmissionID) (entity.SubmissionStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.SubmissionStatus), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) GetCompileError(ctx context.Context, submission *entity.Submission) (string, error) {
+	args := m.Called(ctx, submission)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) GetJudgeDetail(ctx context.Context, submission *entity.Submission) (repository.JudgeDetail, error) {
+	args := m.Called(ctx, submission)
+	return args.Get(0).(repository.JudgeDetail), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) WatchStatus(ctx context.Context, id model.SubmissionID, interval time.Duration) (<-chan entity.SubmissionStatus, error) {
+	args := m.Called(ctx, id, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan entity.SubmissionStatus), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) Search(ctx context.Context, criteria repository.SubmissionSearchCriteria) ([]*entity.Submission, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Submission), args.Error(1)
+}
+
+func (m *MockSubmissionRepository) Save(ctx context.Context, submission *entity.Submission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+func (m *MockSubmissionRepository) Delete(ctx context.Context, id model.SubmissionID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSubmissionRepository) Exists(ctx context.Context, id model.SubmissionID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// stubLanguageRepository is a fixed LanguageRepository for testing
+type stubLanguageRepository struct {
+	languages []string
+	err       error
+}
+
+func (r *stubLanguageRepository) List(_ context.Context) ([]string, error) {
+	return r.languages, r.err
+}
+
+func TestSubmitUseCase_validateLanguage(t *testing.T) {
+	languageRepo := &stubLanguageRepository{languages: []string{"C", "C++14", "C++17", "JAVA", "Python3"}}
+	uc := NewSubmitUseCase(nil, nil, languageRepo, SubmitSettings{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		language string
+		want     string
+		wantErr  string
+	}{
+		{name: "exact match", language: "C++17", want: "C++17"},
+		{name: "case-insensitive match", language: "java", want: "JAVA"},
+		{name: "typo suggests closest", language: "Pyhton3", wantErr: "Did you mean Python3?"},
+		{name: "unknown lists supported", language: "Brainfuck", wantErr: "Supported languages: C, C++14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.validateLanguage(ctx, tt.language)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubmitUseCase_validateLanguage_ListUnavailable(t *testing.T) {
+	languageRepo := &stubLanguageRepository{err: cerrors.New("unavailable")}
+	uc := NewSubmitUseCase(nil, nil, languageRepo, SubmitSettings{})
+
+	got, err := uc.validateLanguage(context.Background(), "Whitespace")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Whitespace", got)
+}
+
+func TestSubmitUseCase_Execute_UsesClock(t *testing.T) {
+	// Given
+	fakeNow := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	session := entity.RestoreSession(model.MustGenerateSessionID(), "user1", "token1",
+		fakeNow.Add(time.Hour), fakeNow.Add(-time.Hour), fakeNow)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).Return(nil)
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{Clock: clock.NewFake(fakeNow)})
+
+	// When
+	submission, err := uc.Execute(context.Background(), SubmitOptions{ProblemID: "ITP1_1_A", FilePath: sourcePath, Language: "C++17"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, fakeNow, submission.SubmittedAt())
+}
+
+func TestSubmitUseCase_Execute_SessionExpiredAtClock(t *testing.T) {
+	// Given
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	// The session is still valid in real time but expired at the fake time
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	submissionRepo := &MockSubmissionRepository{}
+	fakeClock := clock.NewFake(time.Now())
+	fakeClock.Advance(2 * time.Hour)
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{Clock: fakeClock})
+
+	// When
+	_, err := uc.Execute(context.Background(), SubmitOptions{ProblemID: "ITP1_1_A", FilePath: sourcePath, Language: "C++17"})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeUnauthorized))
+	submissionRepo.AssertNotCalled(t, "Submit", mock.Anything, mock.Anything)
+}
+
+func TestSubmitUseCase_detectSourceFile(t *testing.T) {
+	writeSource := func(t *testing.T, dir, name string, age time.Duration) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte("source"), 0644))
+		modTime := time.Now().Add(-age)
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	t.Run("newest recognized file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSource(t, dir, "main.cpp", 2*time.Hour)
+		writeSource(t, dir, "Main.java", time.Hour)
+		writeSource(t, dir, "notes.md", 0)
+		uc := NewSubmitUseCase(nil, nil, nil, SubmitSettings{})
+
+		got, err := uc.detectSourceFile(context.Background(), dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "Main.java"), got)
+	})
+
+	t.Run("configured file wins", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSource(t, dir, "main.cpp", 2*time.Hour)
+		writeSource(t, dir, "main.py", 0)
+		uc := NewSubmitUseCase(nil, nil, nil, SubmitSettings{SourceFile: "main.cpp"})
+
+		got, err := uc.detectSourceFile(context.Background(), dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "main.cpp"), got)
+	})
+
+	t.Run("missing configured file falls back", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSource(t, dir, "main.py", 0)
+		uc := NewSubmitUseCase(nil, nil, nil, SubmitSettings{SourceFile: "main.cpp"})
+
+		got, err := uc.detectSourceFile(context.Background(), dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "main.py"), got)
+	})
+
+	t.Run("no source file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSource(t, dir, "README.md", 0)
+		uc := NewSubmitUseCase(nil, nil, nil, SubmitSettings{})
+
+		_, err := uc.detectSourceFile(context.Background(), dir)
+
+		assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound))
+	})
+}
+
+func TestSubmitUseCase_defaultLanguage(t *testing.T) {
+	uc := NewSubmitUseCase(nil, nil, nil, SubmitSettings{Language: "C++17"})
+
+	assert.Equal(t, "C++17", uc.defaultLanguage("main.cpp"))
+	assert.Equal(t, "Python3", uc.defaultLanguage("main.py"))
+
+	unconfigured := NewSubmitUseCase(nil, nil, nil, SubmitSettings{})
+	assert.Equal(t, "C++17", unconfigured.defaultLanguage("main.cc"), "the default of the language catalogue")
+	assert.Equal(t, "JAVA", unconfigured.defaultLanguage("Main.java"))
+	assert.Equal(t, "C++17", unconfigured.defaultLanguage("main.unknown"))
+}
+
+func TestSubmitUseCase_Execute_WaitsForVerdict(t *testing.T) {
+	// Given
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+
+	statuses := make(chan entity.SubmissionStatus, 2)
+	statuses <- entity.StatusJudging
+	statuses <- entity.StatusAccepted
+	close(statuses)
+
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.Submission).SetJudgeID("12345")
+		}).
+		Return(nil)
+	submissionRepo.On("WatchStatus", mock.Anything, model.MustNewSubmissionID("12345"), time.Millisecond).
+		Return((<-chan entity.SubmissionStatus)(statuses), nil)
+	submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).Return(repository.JudgeDetail{
+		Score: 100, Time: 10 * time.Millisecond, Memory: 1024,
+		Cases: []entity.CaseResult{
+			{Serial: 1, Label: "case1", Status: entity.StatusAccepted, Time: 10 * time.Millisecond, Memory: 1024},
+		},
+	}, nil)
+	submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}},
+		SubmitSettings{Language: "C++17", Watch: true, PollInterval: time.Millisecond})
+
+	var seen []entity.SubmissionStatus
+	opts := SubmitOptions{
+		ProblemID: "ITP1_1_A",
+		FilePath:  sourcePath,
+		OnStatus:  func(status entity.SubmissionStatus) { seen = append(seen, status) },
+	}
+
+	// When
+	submission, err := uc.Execute(context.Background(), opts)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "C++17", submission.Language())
+	assert.True(t, submission.IsAccepted())
+	assert.Equal(t, []entity.SubmissionStatus{entity.StatusJudging, entity.StatusAccepted}, seen)
+	assert.Len(t, submission.CaseResults(), 1, "the case results are fetched once judged")
+	assert.Zero(t, submission.FailedCase())
+	assert.Equal(t, 100, submission.Score())
+	assert.Equal(t, 10*time.Millisecond, submission.Time())
+	submissionRepo.AssertExpectations(t)
+}
+
+// fakeJudgeQueueRepository answers judge queue lookups in order, repeating the last
+type fakeJudgeQueueRepository struct {
+	queues  []repository.JudgeQueue
+	lookups chan struct{}
+}
+
+func (r *fakeJudgeQueueRepository) GetJudgeQueue(_ context.Context, _ model.SubmissionID) (repository.JudgeQueue, error) {
+	queue := r.queues[0]
+	if len(r.queues) > 1 {
+		r.queues = r.queues[1:]
+	}
+	select {
+	case r.lookups <- struct{}{}:
+	default:
+	}
+	return queue, nil
+}
+
+func TestSubmitUseCase_Execute_ReportsJudgeQueue(t *testing.T) {
+	// Given
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+
+	queueRepo := &fakeJudgeQueueRepository{
+		queues: []repository.JudgeQueue{
+			{Position: 3, Length: 4},
+			{Position: 3, Length: 4},
+			{Position: 1, Length: 2},
+		},
+		lookups: make(chan struct{}),
+	}
+	statuses := make(chan entity.SubmissionStatus)
+	go func() {
+		defer close(statuses)
+		statuses <- entity.StatusPending
+		for range 3 {
+			<-queueRepo.lookups
+		}
+		statuses <- entity.StatusJudging
+		statuses <- entity.StatusAccepted
+	}()
+
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.Submission).SetJudgeID("12345")
+		}).
+		Return(nil)
+	submissionRepo.On("WatchStatus", mock.Anything, model.MustNewSubmissionID("12345"), time.Millisecond).
+		Return((<-chan entity.SubmissionStatus)(statuses), nil)
+	submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).Return(repository.JudgeDetail{}, nil)
+	submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}},
+		SubmitSettings{Watch: true, JudgeQueue: queueRepo, QueuePollInterval: time.Millisecond})
+
+	var seen []repository.JudgeQueue
+	opts := SubmitOptions{
+		ProblemID:    "ITP1_1_A",
+		FilePath:     sourcePath,
+		PollInterval: time.Millisecond,
+		OnQueue:      func(queue repository.JudgeQueue) { seen = append(seen, queue) },
+	}
+
+	// When
+	submission, err := uc.Execute(context.Background(), opts)
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, submission.IsAccepted())
+	assert.Equal(t, []repository.JudgeQueue{{Position: 3, Length: 4}, {Position: 1, Length: 2}}, seen,
+		"unchanged places are reported once")
+}
+
+func TestSubmitUseCase_Execute_WatchTimeout(t *testing.T) {
+	// Given
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+
+	statuses := make(chan entity.SubmissionStatus)
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.Submission).SetJudgeID("12345")
+		}).
+		Return(nil)
+	submissionRepo.On("WatchStatus", mock.Anything, model.MustNewSubmissionID("12345"), time.Duration(0)).
+		Run(func(args mock.Arguments) {
+			// Like the AOJ repository, stop watching when the context ends
+			ctx := args.Get(0).(context.Context)
+			go func() {
+				defer close(statuses)
+				statuses <- entity.StatusJudging
+				<-ctx.Done()
+			}()
+		}).
+		Return((<-chan entity.SubmissionStatus)(statuses), nil)
+
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{})
+
+	// When
+	submission, err := uc.Execute(context.Background(), SubmitOptions{
+		ProblemID: "ITP1_1_A",
+		FilePath:  sourcePath,
+		Watch:     true,
+		Timeout:   10 * time.Millisecond,
+	})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeTimeout), "unexpected error: %v", err)
+	assert.NotEmpty(t, cerrors.GetAllHints(err))
+	if submission == nil {
+		t.Fatalf("expected the sent submission")
+	}
+	assert.Equal(t, entity.StatusJudging, submission.Status())
+	submissionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestSubmitUseCase_Execute_NoWatch(t *testing.T) {
+	// Given
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).Return(nil)
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{Watch: true})
+
+	// When
+	submission, err := uc.Execute(context.Background(), SubmitOptions{ProblemID: "ITP1_1_A", FilePath: sourcePath, NoWatch: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, submission.IsPending())
+	submissionRepo.AssertNotCalled(t, "WatchStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubmitUseCase_Execute_RecordsFailedCase(t *testing.T) {
+	// Given
+	sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+	assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+	session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+
+	statuses := make(chan entity.SubmissionStatus, 1)
+	statuses <- entity.StatusWrongAnswer
+	close(statuses)
+
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("Submit", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.Submission).SetJudgeID("12345")
+		}).
+		Return(nil)
+	submissionRepo.On("WatchStatus", mock.Anything, model.MustNewSubmissionID("12345"), time.Millisecond).
+		Return((<-chan entity.SubmissionStatus)(statuses), nil)
+	submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).Return(repository.JudgeDetail{
+		Time: 30 * time.Millisecond, Memory: 2048,
+		Cases: []entity.CaseResult{
+			{Serial: 6, Label: "case6", Status: entity.StatusAccepted, Time: 20 * time.Millisecond, Memory: 1024},
+			{Serial: 7, Label: "case7", Status: entity.StatusWrongAnswer, Time: 30 * time.Millisecond, Memory: 2048},
+			{Serial: 8, Label: "case8"},
+		},
+	}, nil)
+	submissionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+		&stubLanguageRepository{languages: []string{"C++17"}},
+		SubmitSettings{Language: "C++17", Watch: true, PollInterval: time.Millisecond})
+
+	// When
+	submission, err := uc.Execute(context.Background(), SubmitOptions{ProblemID: "ITP1_1_A", FilePath: sourcePath})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, entity.StatusWrongAnswer, submission.Status())
+	assert.Equal(t, 7, submission.FailedCase())
+	if assert.Len(t, submission.CaseResults(), 3) {
+		assert.Equal(t, 30*time.Millisecond, submission.CaseResults()[1].Time)
+		assert.True(t, submission.CaseResults()[2].Skipped())
+	}
+	submissionRepo.AssertExpectations(t)
+}
+
+// fakeVersionControl records commits and tags instead of running git
+type fakeVersionControl struct {
+	commits []string
+	tags    []string
+}
+
+func (v *fakeVersionControl) Commit(_ context.Context, files []string, message string) (string, error) {
+	v.commits = append(v.commits, strings.Join(files, ",")+": "+message)
+	return "abc1234", nil
+}
+
+func (v *fakeVersionControl) Tag(_ context.Context, _, name string) error {
+	v.tags = append(v.tags, name)
+	return nil
+}
+
+func TestSubmitUseCase_Execute_GitCommitOnAC(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      entity.SubmissionStatus
+		noGit       bool
+		wantCommits []string
+		wantTags    []string
+	}{
+		{
+			name:        "accepted",
+			status:      entity.StatusAccepted,
+			wantCommits: []string{"main.cpp: AC ITP1_1_A (C++17, 0.01s)"},
+			wantTags:    []string{"ac/ITP1_1_A-12345"},
+		},
+		{name: "rejected", status: entity.StatusWrongAnswer},
+		{name: "disabled with --no-git", status: entity.StatusAccepted, noGit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+			assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+			sessionRepo := &MockSessionRepository{}
+			sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Submit", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					submission := args.Get(1).(*entity.Submission)
+					submission.SetJudgeID("12345")
+					submission.UpdateResult(tt.status, 100, 10*time.Millisecond, 1024, "")
+				}).
+				Return(nil)
+			submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).Return(repository.JudgeDetail{}, nil)
+
+			git := &fakeVersionControl{}
+			uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+				&stubLanguageRepository{languages: []string{"C++17"}},
+				SubmitSettings{Language: "C++17", Git: git, GitCommitOnAC: true, GitTag: "ac/{problem}-{id}"})
+
+			var result *GitCommitResult
+			opts := SubmitOptions{
+				ProblemID:   "ITP1_1_A",
+				FilePath:    sourcePath,
+				NoGit:       tt.noGit,
+				OnGitCommit: func(r GitCommitResult) { result = &r },
+			}
+
+			// When
+			_, err := uc.Execute(context.Background(), opts)
+
+			// Then
+			assert.NoError(t, err)
+			var commits []string
+			for _, commit := range git.commits {
+				commits = append(commits, strings.TrimPrefix(commit, filepath.Dir(sourcePath)+string(filepath.Separator)))
+			}
+			assert.Equal(t, tt.wantCommits, commits)
+			assert.Equal(t, tt.wantTags, git.tags)
+			if tt.wantCommits == nil {
+				assert.Nil(t, result)
+				return
+			}
+			if result == nil {
+				t.Fatalf("expected a commit result")
+			}
+			assert.Equal(t, GitCommitResult{Message: "AC ITP1_1_A (C++17, 0.01s)", Hash: "abc1234", Tag: "ac/ITP1_1_A-12345"}, *result)
+		})
+	}
+}
+
+func TestSubmitUseCase_Execute_PreparesSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      []byte
+		wantSource   string
+		wantWarnings int
+		wantErr      string
+	}{
+		{name: "utf-8", content: []byte("int main() {}"), wantSource: "int main() {}"},
+		{name: "bom", content: []byte("\xEF\xBB\xBFint main() {}"), wantSource: "int main() {}", wantWarnings: 1},
+		{name: "shift_jis comment", content: []byte("// \x82\xA0\nint main() {}"), wantSource: "// あ\nint main() {}", wantWarnings: 1},
+		{name: "empty", content: []byte("\n"), wantSource: "\n", wantWarnings: 1},
+		{name: "binary", content: []byte("\x7FELF\x00\x00"), wantSource: "\x7FELF\x00\x00", wantWarnings: 1},
+		{name: "too large", content: []byte(strings.Repeat("a", MaxSourceSize+1)), wantErr: "larger than the 64.0 KiB AOJ accepts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+			assert.NoError(t, os.WriteFile(sourcePath, tt.content, 0644))
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+			sessionRepo := &MockSessionRepository{}
+			sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Submit", mock.Anything, mock.Anything).Return(nil)
+			uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+				&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{})
+			var warnings []string
+
+			// When
+			submission, err := uc.Execute(context.Background(), SubmitOptions{
+				ProblemID: "ITP1_1_A",
+				FilePath:  sourcePath,
+				OnWarning: func(message string) { warnings = append(warnings, message) },
+			})
+
+			// Then
+			if tt.wantErr != "" {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				submissionRepo.AssertNotCalled(t, "Submit", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			assert.Equal(t, tt.wantSource, submission.SourceCode())
+			assert.Len(t, warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func TestSubmitUseCase_Execute_Stdin(t *testing.T) {
+	tests := []struct {
+		name         string
+		language     string
+		configured   string
+		wantLanguage string
+		wantErr      string
+	}{
+		{name: "explicit language", language: "python3", wantLanguage: "Python3"},
+		{name: "configured language", configured: "C++17", wantLanguage: "C++17"},
+		{name: "no language", wantErr: "Please specify --language"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+			sessionRepo := &MockSessionRepository{}
+			sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Submit", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.Submission).UpdateResult(entity.StatusAccepted, 100, 10*time.Millisecond, 1024, "")
+				}).
+				Return(nil)
+			submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).Return(repository.JudgeDetail{}, nil)
+			git := &fakeVersionControl{}
+			uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+				&stubLanguageRepository{languages: []string{"C++17", "Python3"}},
+				SubmitSettings{Language: tt.configured, Git: git, GitCommitOnAC: true})
+
+			// When
+			submission, err := uc.Execute(context.Background(), SubmitOptions{
+				Dir:       t.TempDir(),
+				ProblemID: "ITP1_1_A",
+				FilePath:  StdinSource,
+				Language:  tt.language,
+				Stdin:     strings.NewReader("print(1)\n"),
+			})
+
+			// Then
+			if tt.wantErr != "" {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+				assert.ErrorContains(t, err, tt.wantErr)
+				submissionRepo.AssertNotCalled(t, "Submit", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			assert.Equal(t, "print(1)\n", submission.SourceCode())
+			assert.Equal(t, tt.wantLanguage, submission.Language())
+			assert.Empty(t, git.commits, "a source read from stdin has no file to commit")
+		})
+	}
+}
+
+func TestSubmitUseCase_LatestSubmissions(t *testing.T) {
+	// Given
+	dir := filepath.Join(t.TempDir(), "ITP1_1_A")
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	latest := entity.NewSubmission(model.NewSubmissionIDFromInt(2), model.MustNewProblemID("ITP1_1_A"), "C++17", "")
+	submissionRepo := &MockSubmissionRepository{}
+	submissionRepo.On("GetByProblemID", mock.Anything, model.MustNewProblemID("ITP1_1_A"), 3).
+		Return([]*entity.Submission{latest}, nil)
+	uc := NewSubmitUseCase(submissionRepo, &MockSessionRepository{}, &stubLanguageRepository{}, SubmitSettings{})
+
+	// When
+	problemID, submissions, err := uc.LatestSubmissions(context.Background(), dir, "", 3)
+
+	// Then
+	if err != nil {
+		t.Fatalf("LatestSubmissions failed: %v", err)
+	}
+	assert.Equal(t, "ITP1_1_A", problemID.String())
+	assert.Equal(t, []*entity.Submission{latest}, submissions)
+}
+
+func TestSubmitUseCase_Execute_Transform(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		noTransform bool
+		want        string
+	}{
+		{name: "exact language", key: "C++17", want: "#define NDEBUG\nint main() {}\n// end\n"},
+		{name: "language family", key: "c++", want: "#define NDEBUG\nint main() {}\n// end\n"},
+		{name: "other language", key: "Python", want: "int main() {}"},
+		{name: "disabled", key: "C++", noTransform: true, want: "int main() {}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+			assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+			sessionRepo := &MockSessionRepository{}
+			sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Submit", mock.Anything, mock.Anything).Return(nil)
+			uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+				&stubLanguageRepository{languages: []string{"C++17"}}, SubmitSettings{
+					Transforms: map[string]SourceTransform{tt.key: {Prelude: "#define NDEBUG", Epilogue: "// end"}},
+				})
+
+			// When
+			submission, err := uc.Execute(context.Background(), SubmitOptions{ProblemID: "ITP1_1_A", FilePath: sourcePath, NoTransform: tt.noTransform})
+
+			// Then
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			assert.Equal(t, tt.want, submission.SourceCode())
+			local, err := os.ReadFile(sourcePath)
+			assert.NoError(t, err)
+			assert.Equal(t, "int main() {}", string(local), "the local file is untouched")
+		})
+	}
+}
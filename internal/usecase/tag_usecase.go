@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// tagPattern is the form of a tag once lowercased, e.g. dp, union-find or bit-dp
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_+.-]*$`)
+
+// TagUseCase manages the tags users put on problems
+type TagUseCase struct {
+	tagRepo repository.TagRepository
+	aliases ProblemAliases
+	logger  *logger.Logger
+}
+
+// TagSettings holds the optional settings of the tag use case
+type TagSettings struct {
+	Aliases ProblemAliases // problem aliases accepted as problem IDs
+}
+
+// NewTagUseCase creates a new TagUseCase
+func NewTagUseCase(tagRepo repository.TagRepository, settings TagSettings) *TagUseCase {
+	return &TagUseCase{
+		tagRepo: tagRepo,
+		aliases: settings.Aliases,
+		logger:  logger.WithGroup("tag_usecase"),
+	}
+}
+
+// TaggedProblem is a problem with its tags
+type TaggedProblem struct {
+	ProblemID string   `json:"problem_id"`
+	Tags      []string `json:"tags"`
+}
+
+// Add puts tags on a problem and returns all its tags
+func (uc *TagUseCase) Add(ctx context.Context, problemID string, tags []string) (*TaggedProblem, error) {
+	id, err := uc.aliases.ParseProblemID(problemID)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	all, err := uc.tagRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := all[id.String()]
+	for _, tag := range normalized {
+		if !slices.Contains(current, tag) {
+			current = append(current, tag)
+		}
+	}
+	sort.Strings(current)
+	all[id.String()] = current
+
+	if err := uc.tagRepo.SaveAll(ctx, all); err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "tagged problem", "problem_id", id.String(), "tags", normalized)
+	return &TaggedProblem{ProblemID: id.String(), Tags: current}, nil
+}
+
+// Remove takes tags off a problem and returns its remaining tags
+func (uc *TagUseCase) Remove(ctx context.Context, problemID string, tags []string) (*TaggedProblem, error) {
+	id, err := uc.aliases.ParseProblemID(problemID)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	all, err := uc.tagRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := all[id.String()]
+	for _, tag := range normalized {
+		index := slices.Index(current, tag)
+		if index < 0 {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				fmt.Sprintf("%s is not tagged %s", id.String(), tag),
+				nil,
+			)
+		}
+		current = slices.Delete(current, index, index+1)
+	}
+	if len(current) == 0 {
+		delete(all, id.String())
+	} else {
+		all[id.String()] = current
+	}
+
+	if err := uc.tagRepo.SaveAll(ctx, all); err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "untagged problem", "problem_id", id.String(), "tags", normalized)
+	return &TaggedProblem{ProblemID: id.String(), Tags: slices.Clip(current)}, nil
+}
+
+// List returns the problems having every one of tags, or all tagged problems
+// without tags, sorted by problem ID
+func (uc *TagUseCase) List(ctx context.Context, tags []string) ([]TaggedProblem, error) {
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	all, err := uc.tagRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]TaggedProblem, 0, len(all))
+	for problemID, problemTags := range all {
+		if hasAllTags(problemTags, normalized) {
+			problems = append(problems, TaggedProblem{ProblemID: problemID, Tags: problemTags})
+		}
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].ProblemID < problems[j].ProblemID })
+	return problems, nil
+}
+
+// Tags returns the tags of every tagged problem, keyed by problem ID
+func (uc *TagUseCase) Tags(ctx context.Context) (map[string][]string, error) {
+	return uc.tagRepo.List(ctx)
+}
+
+// normalizeTags lowercases tags and rejects malformed ones
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				fmt.Sprintf("invalid tag '%s'. Use letters, digits and - _ + . e.g. dp or union-find", tag),
+				nil,
+			)
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
+
+// hasAllTags reports whether problemTags contains every one of tags
+func hasAllTags(problemTags, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(problemTags, tag) {
+			return false
+		}
+	}
+	return true
+}
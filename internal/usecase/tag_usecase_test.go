@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// memoryTagRepository keeps the tags in memory
+type memoryTagRepository struct {
+	tags map[string][]string
+}
+
+func (r *memoryTagRepository) List(_ context.Context) (map[string][]string, error) {
+	tags := maps.Clone(r.tags)
+	if tags == nil {
+		tags = map[string][]string{}
+	}
+	return tags, nil
+}
+
+func (r *memoryTagRepository) SaveAll(_ context.Context, tags map[string][]string) error {
+	r.tags = maps.Clone(tags)
+	return nil
+}
+
+func TestTagUseCase_AddRemoveList(t *testing.T) {
+	// Given
+	repo := &memoryTagRepository{}
+	uc := NewTagUseCase(repo, TagSettings{Aliases: ProblemAliases{"two-sum": "ITP1_6_D"}})
+	ctx := context.Background()
+
+	// When
+	_, errA := uc.Add(ctx, "ITP1_7_B", []string{"greedy", "DP"})
+	_, errB := uc.Add(ctx, "two-sum", []string{"dp", "two-pointers"})
+	tagged, errC := uc.Add(ctx, "ITP1_7_B", []string{"dp"})
+
+	// Then
+	assert.NoError(t, errA)
+	assert.NoError(t, errB)
+	assert.NoError(t, errC)
+	assert.Equal(t, []string{"dp", "greedy"}, tagged.Tags)
+
+	all, err := uc.List(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []TaggedProblem{
+		{ProblemID: "ITP1_6_D", Tags: []string{"dp", "two-pointers"}},
+		{ProblemID: "ITP1_7_B", Tags: []string{"dp", "greedy"}},
+	}, all)
+
+	greedy, err := uc.List(ctx, []string{"dp", "Greedy"})
+	assert.NoError(t, err)
+	assert.Equal(t, []TaggedProblem{{ProblemID: "ITP1_7_B", Tags: []string{"dp", "greedy"}}}, greedy)
+
+	// When - the last tag of a problem is removed
+	untagged, err := uc.Remove(ctx, "ITP1_6_D", []string{"dp", "two-pointers"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, untagged.Tags)
+	assert.NotContains(t, repo.tags, "ITP1_6_D")
+}
+
+func TestTagUseCase_Errors(t *testing.T) {
+	uc := NewTagUseCase(&memoryTagRepository{tags: map[string][]string{"ITP1_7_B": {"dp"}}}, TagSettings{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		run      func() error
+		wantCode cerrors.ErrorCode
+	}{
+		{
+			name:     "invalid tag",
+			run:      func() error { _, err := uc.Add(ctx, "ITP1_7_B", []string{"two words"}); return err },
+			wantCode: cerrors.CodeInvalidInput,
+		},
+		{
+			name:     "missing tag",
+			run:      func() error { _, err := uc.Remove(ctx, "ITP1_7_B", []string{"greedy"}); return err },
+			wantCode: cerrors.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			err := tt.run()
+
+			// Then
+			assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+)
+
+// aojProblemURL is the URL prefix of problem pages on AOJ
+const aojProblemURL = "https://onlinejudge.u-aizu.ac.jp/problems/"
+
+// TemplateData holds the variables available to solution and README templates
+type TemplateData struct {
+	ProblemID   string
+	Title       string // empty if the problem metadata is unavailable
+	Description string
+	Statement   string // statement converted to Markdown; empty unless init.save_statement is set
+	URL         string
+	TimeLimit   time.Duration // 0 if unknown
+	MemoryLimit int64         // in KB, 0 if unknown
+	SampleCount int
+	Samples     []TemplateSample
+	SourceFile  string
+	Username    string // empty if not logged in
+	Date        time.Time
+}
+
+// TemplateSample is a sample input and its expected output
+type TemplateSample struct {
+	Input  string
+	Output string
+}
+
+// MemoryLimitMB returns the memory limit in megabytes
+func (d TemplateData) MemoryLimitMB() int64 {
+	return d.MemoryLimit / 1024
+}
+
+// newTemplateData collects the template variables of a problem
+func newTemplateData(pid model.ProblemID, problem *entity.Problem, testCases []model.TestCase, sourceFile, username string, now time.Time) TemplateData {
+	data := TemplateData{
+		ProblemID:   pid.String(),
+		URL:         aojProblemURL + pid.String(),
+		SampleCount: len(testCases),
+		SourceFile:  sourceFile,
+		Username:    username,
+		Date:        now,
+	}
+	if problem != nil {
+		data.Title = problem.Title()
+		data.Description = strings.TrimRight(problem.Description(), "\n")
+		data.TimeLimit = problem.TimeLimit()
+		data.MemoryLimit = problem.MemoryLimit()
+	}
+	for _, tc := range testCases {
+		data.Samples = append(data.Samples, TemplateSample{Input: tc.Input(), Output: tc.Expected()})
+	}
+	return data
+}
+
+// templateFuncs returns the functions available to templates
+func templateFuncs(data TemplateData) template.FuncMap {
+	return template.FuncMap{
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"trim":    strings.TrimSpace,
+		"replace": func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"date":    func(layout string) string { return data.Date.Format(layout) },
+	}
+}
+
+// renderTemplate evaluates a template with the given variables
+func renderTemplate(name, text string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs(data)).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// render evaluates a template, falling back to its raw text if it is not a
+// valid template, e.g. because the code contains a literal {{
+func (uc *InitUseCase) render(ctx context.Context, name, text string, data TemplateData) string {
+	content, err := renderTemplate(name, text, data)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to evaluate template, writing it unchanged", "template", name, "error", err)
+		return text
+	}
+	return content
+}
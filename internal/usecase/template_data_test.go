@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// sampleProblemRepository returns a fixed problem with two samples
+type sampleProblemRepository struct {
+	repository.ProblemRepository
+}
+
+func (r *sampleProblemRepository) GetByID(_ context.Context, id model.ProblemID) (*entity.Problem, error) {
+	return entity.NewProblem(id, "Hello World", "Print Hello World.\n", 2*time.Second, 131072, "", 0), nil
+}
+
+func (r *sampleProblemRepository) GetByIDs(ctx context.Context, ids []model.ProblemID) ([]*entity.Problem, error) {
+	problems := make([]*entity.Problem, 0, len(ids))
+	for _, id := range ids {
+		problem, _ := r.GetByID(ctx, id)
+		problems = append(problems, problem)
+	}
+	return problems, nil
+}
+
+func (r *sampleProblemRepository) GetTestCases(_ context.Context, _ model.ProblemID) ([]model.TestCase, error) {
+	return []model.TestCase{
+		*model.NewTestCase(1, "1\n", "2\n"),
+		*model.NewTestCase(2, "3\n", "4\n"),
+	}, nil
+}
+
+func TestInitUseCase_Execute_TemplateVariables(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	root := t.TempDir()
+	templateDir := t.TempDir()
+	templates := NewTemplateUseCase(templateDir)
+	sources := map[string]string{
+		"vars.cpp": "// {{.ProblemID}} {{.Title | upper}} {{.TimeLimit}} {{.MemoryLimitMB}}MB " +
+			"{{.SampleCount}} samples by {{.Username}} on {{date \"2006-01-02\"}}\n// {{.URL}}\n",
+		"readme.md": "# {{.Title}}\n{{range .Samples}}in={{.Input}}{{end}}",
+		"raw.py":    "grid = {{1, 2}}\n",
+	}
+	for file, content := range sources {
+		source := filepath.Join(t.TempDir(), file)
+		assert.NoError(t, os.WriteFile(source, []byte(content), 0644))
+		info, err := templates.Add(ctx, file[:len(file)-len(filepath.Ext(file))], source, false)
+		if err != nil {
+			t.Fatalf("failed to add template: %v", err)
+		}
+		_, err = templates.Use(ctx, info.Name)
+		assert.NoError(t, err)
+	}
+
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("GetCurrent", mock.Anything).
+		Return(entity.NewSession(model.MustGenerateSessionID(), "alice", "token", time.Now().Add(time.Hour)), nil)
+
+	uc := NewInitUseCase(&sampleProblemRepository{}, InitLayout{
+		Root:         root,
+		SourceFile:   "main.cpp",
+		CreateReadme: true,
+		TemplateDir:  templateDir,
+		Sessions:     sessionRepo,
+		Clock:        clock.NewFake(time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)),
+		Languages:    []InitLanguage{{Name: "python", AOJName: "Python3", Extension: "py"}},
+	})
+
+	// When
+	err := uc.Execute(ctx, "ITP1_1_A")
+	pyErr := uc.ExecuteWithOptions(ctx, "ITP1_1_B", InitOptions{Language: "python"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.NoError(t, pyErr)
+	source, err := os.ReadFile(filepath.Join(root, "ITP1_1_A", "main.cpp"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// ITP1_1_A HELLO WORLD 2s 128MB 2 samples by alice on 2026-04-01\n"+
+		"// https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n", string(source))
+	readme, err := os.ReadFile(filepath.Join(root, "ITP1_1_A", "README.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Hello World\nin=1\nin=3\n", string(readme))
+	raw, err := os.ReadFile(filepath.Join(root, "ITP1_1_B", "main.py"))
+	assert.NoError(t, err)
+	assert.Equal(t, "grid = {{1, 2}}\n", string(raw), "an invalid template is written unchanged")
+}
+
+func TestRenderTemplate_BuiltinReadme(t *testing.T) {
+	pid := model.MustNewProblemID("ITP1_1_A")
+
+	withoutProblem, err := renderTemplate("README.md", builtinTemplates["md"], newTemplateData(pid, nil, nil, "main.go", "", time.Time{}))
+	assert.NoError(t, err)
+	assert.Equal(t, "# ITP1_1_A\n\nhttps://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n", withoutProblem)
+
+	problem := entity.NewProblem(pid, "Hello World", "Print it.\n\n", time.Second, 0, "", 0)
+	withProblem, err := renderTemplate("README.md", builtinTemplates["md"], newTemplateData(pid, problem, nil, "main.go", "", time.Time{}))
+	assert.NoError(t, err)
+	assert.Equal(t, "# ITP1_1_A Hello World\n\nPrint it.\n\nhttps://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n", withProblem)
+}
+
+// stubStatementRepository serves statements by language
+type stubStatementRepository struct {
+	statements map[string]string
+}
+
+func (r *stubStatementRepository) GetStatement(_ context.Context, id model.ProblemID, language string) (string, error) {
+	html, ok := r.statements[language]
+	if !ok {
+		return "", cerrors.NewAppError(cerrors.CodeNotFound, "no statement for "+id.String(), nil)
+	}
+	return html, nil
+}
+
+func TestInitUseCase_Execute_SaveStatement(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements map[string]string
+		want       string
+	}{
+		{
+			name: "samples in the statement",
+			statements: map[string]string{
+				"en": "<h1>Hello World</h1><p>Print <b>Hello</b>.</p><h2>Sample Input</h2><pre>\n1\n</pre>",
+			},
+			want: "# ITP1_1_A Hello World\n\nPrint **Hello**.\n\n## Sample Input\n\n```\n1\n```\n\n" +
+				"https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n",
+		},
+		{
+			name:       "japanese statement without samples",
+			statements: map[string]string{"ja": "<p>こんにちは</p>"},
+			want: "# ITP1_1_A Hello World\n\nこんにちは\n\n## Samples\n\n" +
+				"### Sample Input 1\n\n```\n1\n```\n\n### Sample Output 1\n\n```\n2\n```\n\n" +
+				"### Sample Input 2\n\n```\n3\n```\n\n### Sample Output 2\n\n```\n4\n```\n\n" +
+				"https://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n",
+		},
+		{
+			name:       "no statement",
+			statements: map[string]string{},
+			want:       "# ITP1_1_A Hello World\n\nPrint Hello World.\n\nhttps://onlinejudge.u-aizu.ac.jp/problems/ITP1_1_A\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			root := t.TempDir()
+			uc := NewInitUseCase(&sampleProblemRepository{}, InitLayout{
+				Root:          root,
+				SaveStatement: true,
+				Statements:    &stubStatementRepository{statements: tt.statements},
+			})
+
+			// When
+			err := uc.Execute(context.Background(), "ITP1_1_A")
+
+			// Then
+			assert.NoError(t, err)
+			readme, err := os.ReadFile(filepath.Join(root, "ITP1_1_A", "README.md"))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(readme))
+		})
+	}
+}
+
+func TestInitUseCase_Execute_StatementLanguage(t *testing.T) {
+	statements := &stubStatementRepository{statements: map[string]string{
+		"en": "<h2>Sample Input</h2><pre>\n1\n</pre>",
+		"ja": "<h2>入力例</h2><pre>\n1\n</pre>",
+	}}
+
+	tests := []struct {
+		name     string
+		layout   string
+		option   string
+		contains string
+	}{
+		{name: "English by default", contains: "## Sample Input"},
+		{name: "configured language", layout: "ja", contains: "## 入力例"},
+		{name: "option wins", layout: "ja", option: "en", contains: "## Sample Input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			root := t.TempDir()
+			uc := NewInitUseCase(&sampleProblemRepository{}, InitLayout{
+				Root:              root,
+				SaveStatement:     true,
+				StatementLanguage: tt.layout,
+				Statements:        statements,
+			})
+
+			// When
+			err := uc.ExecuteWithOptions(context.Background(), "ITP1_1_A", InitOptions{StatementLanguage: tt.option})
+
+			// Then
+			if err != nil {
+				t.Fatalf("ExecuteWithOptions failed: %v", err)
+			}
+			readme, err := os.ReadFile(filepath.Join(root, "ITP1_1_A", "README.md"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(readme), tt.contains)
+		})
+	}
+}
+
+// noSamplesProblemRepository is a problem whose samples are missing from judgedat
+type noSamplesProblemRepository struct {
+	sampleProblemRepository
+}
+
+func (r *noSamplesProblemRepository) GetTestCases(_ context.Context, _ model.ProblemID) ([]model.TestCase, error) {
+	return []model.TestCase{}, nil
+}
+
+func TestInitUseCase_Execute_SamplesFromStatement(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	uc := NewInitUseCase(&noSamplesProblemRepository{}, InitLayout{
+		Root: root,
+		Statements: &stubStatementRepository{statements: map[string]string{
+			"en": "<h2>Input</h2><p>n</p>",
+			"ja": "<h2>入力例 1</h2><pre>\n1 2\n</pre><h2>出力例 1</h2><pre>\n3\n</pre>",
+		}},
+	})
+
+	// When
+	err := uc.Execute(context.Background(), "ITP1_1_A")
+
+	// Then
+	assert.NoError(t, err)
+	input, err := os.ReadFile(filepath.Join(root, "ITP1_1_A", "test", "sample-1.in"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1 2\n", string(input))
+	output, err := os.ReadFile(filepath.Join(root, "ITP1_1_A", "test", "sample-1.out"))
+	assert.NoError(t, err)
+	assert.Equal(t, "3\n", string(output))
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/filelock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// TemplateDefaultsFile stores the default template of each language in the template directory
+const TemplateDefaultsFile = "defaults.toml"
+
+// TemplateInfo describes a stored template
+type TemplateInfo struct {
+	Name      string `json:"name"`
+	Extension string `json:"extension"` // language extension without the dot
+	Path      string `json:"path"`
+	Default   bool   `json:"default"` // used by init for its language
+}
+
+// TemplateUseCase manages the named solution templates stored in a directory.
+// A template named fast-io for C++ is stored as fast-io.cpp
+type TemplateUseCase struct {
+	dir    string
+	logger *logger.Logger
+}
+
+// NewTemplateUseCase creates a new TemplateUseCase for the templates in dir
+func NewTemplateUseCase(dir string) *TemplateUseCase {
+	return &TemplateUseCase{
+		dir:    dir,
+		logger: logger.WithGroup("template_usecase"),
+	}
+}
+
+// List returns the stored templates sorted by name
+func (uc *TemplateUseCase) List(_ context.Context) ([]TemplateInfo, error) {
+	entries, err := os.ReadDir(uc.dir)
+	if os.IsNotExist(err) {
+		return []TemplateInfo{}, nil
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list templates")
+	}
+
+	defaults, err := uc.loadDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]TemplateInfo, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := strings.TrimPrefix(filepath.Ext(name), ".")
+		if !entry.Type().IsRegular() || ext == "" || name == TemplateDefaultsFile || strings.HasPrefix(name, ".") {
+			continue
+		}
+		info := TemplateInfo{
+			Name:      strings.TrimSuffix(name, filepath.Ext(name)),
+			Extension: ext,
+			Path:      filepath.Join(uc.dir, name),
+		}
+		info.Default = defaults[ext] == info.Name
+		templates = append(templates, info)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Add stores the file at sourcePath as a template. The language is taken from
+// the file's extension. An existing template is only replaced with force
+func (uc *TemplateUseCase) Add(ctx context.Context, name, sourcePath string, force bool) (*TemplateInfo, error) {
+	if err := validateTemplateName(name); err != nil {
+		return nil, err
+	}
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(sourcePath)), ".")
+	if ext == "" || name+"."+ext == TemplateDefaultsFile {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("cannot tell the language of %s from its extension", sourcePath),
+			nil,
+		)
+	}
+
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("template source %s not found", sourcePath),
+			err,
+		)
+	}
+
+	existing, err := uc.find(ctx, name)
+	if err != nil && !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+		return nil, err
+	}
+	if existing != nil && !force {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeConflict,
+			fmt.Sprintf("template %s already exists; use --force to replace it", name),
+			nil,
+		)
+	}
+
+	if err := os.MkdirAll(uc.dir, 0755); err != nil {
+		return nil, cerrors.Wrap(err, "failed to create template directory")
+	}
+	path := filepath.Join(uc.dir, name+"."+ext)
+	if err := filelock.WriteFileAtomic(path, content, 0644); err != nil {
+		return nil, cerrors.Wrap(err, "failed to write template "+name)
+	}
+	if existing != nil && existing.Path != path {
+		// The template was replaced by one for another language
+		trace.File("remove", existing.Path)
+		if err := os.Remove(existing.Path); err != nil {
+			return nil, cerrors.Wrap(err, "failed to remove the old template "+name)
+		}
+		if existing.Default {
+			if err := uc.setDefault(existing.Extension, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	uc.logger.InfoContext(ctx, "template added", "name", name, "extension", ext)
+	return uc.find(ctx, name)
+}
+
+// Show returns the content of a template
+func (uc *TemplateUseCase) Show(ctx context.Context, name string) (string, error) {
+	info, err := uc.find(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(info.Path)
+	if err != nil {
+		return "", cerrors.Wrap(err, "failed to read template "+name)
+	}
+	return string(content), nil
+}
+
+// Use makes a template the default of its language
+func (uc *TemplateUseCase) Use(ctx context.Context, name string) (*TemplateInfo, error) {
+	info, err := uc.find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.setDefault(info.Extension, info.Name); err != nil {
+		return nil, err
+	}
+	info.Default = true
+
+	uc.logger.InfoContext(ctx, "default template changed", "name", name, "extension", info.Extension)
+	return info, nil
+}
+
+// Resolve returns the content of the named template, or of the default
+// template for the extension when name is empty. ok is false if no template applies
+func (uc *TemplateUseCase) Resolve(ctx context.Context, ext, name string) (content string, ok bool, err error) {
+	if name == "" {
+		defaults, err := uc.loadDefaults()
+		if err != nil {
+			return "", false, err
+		}
+		if name = defaults[ext]; name == "" {
+			return "", false, nil
+		}
+	}
+
+	info, err := uc.find(ctx, name)
+	if err != nil {
+		return "", false, err
+	}
+	if ext != "" && !strings.EqualFold(info.Extension, ext) {
+		return "", false, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("template %s is for .%s files, not .%s", name, info.Extension, ext),
+			nil,
+		)
+	}
+
+	data, err := os.ReadFile(info.Path)
+	if err != nil {
+		return "", false, cerrors.Wrap(err, "failed to read template "+name)
+	}
+	return string(data), true, nil
+}
+
+// find returns the stored template with the given name
+func (uc *TemplateUseCase) find(ctx context.Context, name string) (*TemplateInfo, error) {
+	templates, err := uc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range templates {
+		if info.Name == name {
+			return &info, nil
+		}
+	}
+	return nil, cerrors.NewAppError(
+		cerrors.CodeNotFound,
+		fmt.Sprintf("template %s not found. Run 'aoj template list' to see the stored templates", name),
+		nil,
+	)
+}
+
+// loadDefaults reads the default template name of each extension
+func (uc *TemplateUseCase) loadDefaults() (map[string]string, error) {
+	defaults := make(map[string]string)
+	if _, err := toml.DecodeFile(filepath.Join(uc.dir, TemplateDefaultsFile), &defaults); err != nil && !os.IsNotExist(err) {
+		return nil, cerrors.Wrap(err, "failed to read "+TemplateDefaultsFile)
+	}
+	return defaults, nil
+}
+
+// setDefault records the default template of an extension; an empty name removes it
+func (uc *TemplateUseCase) setDefault(ext, name string) error {
+	defaults, err := uc.loadDefaults()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		delete(defaults, ext)
+	} else {
+		defaults[ext] = name
+	}
+
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(defaults); err != nil {
+		return cerrors.Wrap(err, "failed to encode "+TemplateDefaultsFile)
+	}
+	if err := os.MkdirAll(uc.dir, 0755); err != nil {
+		return cerrors.Wrap(err, "failed to create template directory")
+	}
+	if err := filelock.WriteFileAtomic(filepath.Join(uc.dir, TemplateDefaultsFile), []byte(buf.String()), 0644); err != nil {
+		return cerrors.Wrap(err, "failed to write "+TemplateDefaultsFile)
+	}
+	return nil
+}
+
+// validateTemplateName rejects names that cannot be stored as a file name
+func validateTemplateName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("invalid template name '%s': use letters, digits, '-' or '_'", name),
+			nil,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func writeTemplateSource(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestTemplateUseCase_AddUseResolve(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	uc := NewTemplateUseCase(filepath.Join(t.TempDir(), "templates"))
+	fastIO := writeTemplateSource(t, "fast-io.cpp", "// fast io\n")
+	script := writeTemplateSource(t, "script.py", "# script\n")
+
+	// When
+	_, addErr := uc.Add(ctx, "fast-io", fastIO, false)
+	_, addPyErr := uc.Add(ctx, "script", script, false)
+	_, noDefault, noDefaultErr := uc.Resolve(ctx, "cpp", "")
+	used, useErr := uc.Use(ctx, "fast-io")
+	content, ok, resolveErr := uc.Resolve(ctx, "cpp", "")
+	templates, listErr := uc.List(ctx)
+
+	// Then
+	assert.NoError(t, addErr)
+	assert.NoError(t, addPyErr)
+	assert.NoError(t, noDefaultErr)
+	assert.False(t, noDefault)
+	assert.NoError(t, useErr)
+	assert.Equal(t, "cpp", used.Extension)
+	assert.NoError(t, resolveErr)
+	assert.True(t, ok)
+	assert.Equal(t, "// fast io\n", content)
+	assert.NoError(t, listErr)
+	assert.Len(t, templates, 2)
+	assert.Equal(t, "fast-io", templates[0].Name)
+	assert.True(t, templates[0].Default)
+	assert.Equal(t, "script", templates[1].Name)
+	assert.False(t, templates[1].Default)
+}
+
+func TestTemplateUseCase_Add_Existing(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	uc := NewTemplateUseCase(t.TempDir())
+	_, err := uc.Add(ctx, "basic", writeTemplateSource(t, "a.cpp", "old\n"), false)
+	assert.NoError(t, err)
+	_, err = uc.Use(ctx, "basic")
+	assert.NoError(t, err)
+
+	// When
+	_, conflictErr := uc.Add(ctx, "basic", writeTemplateSource(t, "b.cpp", "new\n"), false)
+	_, replaceErr := uc.Add(ctx, "basic", writeTemplateSource(t, "c.py", "python\n"), true)
+
+	// Then
+	assert.True(t, cerrors.IsAppError(conflictErr, cerrors.CodeConflict))
+	assert.NoError(t, replaceErr)
+	templates, err := uc.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, templates, 1)
+	assert.Equal(t, "py", templates[0].Extension)
+	_, ok, err := uc.Resolve(ctx, "cpp", "")
+	assert.NoError(t, err)
+	assert.False(t, ok, "the replaced template must no longer be the C++ default")
+}
+
+func TestTemplateUseCase_Errors(t *testing.T) {
+	ctx := context.Background()
+	uc := NewTemplateUseCase(t.TempDir())
+	source := writeTemplateSource(t, "main.cpp", "int main() {}\n")
+	_, err := uc.Add(ctx, "basic", source, false)
+	assert.NoError(t, err)
+
+	_, err = uc.Add(ctx, "../escape", source, false)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+
+	_, err = uc.Add(ctx, "noext", writeTemplateSource(t, "Makefile", "all:\n"), false)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+
+	_, err = uc.Show(ctx, "missing")
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound))
+
+	_, _, err = uc.Resolve(ctx, "py", "basic")
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+}
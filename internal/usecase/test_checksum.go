@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// fileChecksum returns the SHA-256 sum of a file in hex
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", cerrors.Wrap(err, "failed to read "+path)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// dataChecksum returns the SHA-256 sum of data in hex
+func dataChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordTestChecksums adds checksums, keyed by file name, to the checksum file
+// of a test directory
+func recordTestChecksums(testDir string, sums config.TestChecksums) error {
+	if len(sums) == 0 {
+		return nil
+	}
+	recorded, err := config.LoadTestChecksums(testDir)
+	if err != nil {
+		return err
+	}
+	for name, sum := range sums {
+		recorded[name] = sum
+	}
+	return config.SaveTestChecksums(testDir, recorded)
+}
+
+// corruptedTestFiles returns the files among names, relative to testDir, whose
+// content no longer matches their recorded checksum. Files without a checksum
+// are assumed to be intact
+func corruptedTestFiles(testDir string, names []string) ([]string, error) {
+	sums, err := config.LoadTestChecksums(testDir)
+	if err != nil || len(sums) == 0 {
+		return nil, err
+	}
+
+	var corrupted []string
+	for _, name := range names {
+		want, ok := sums[name]
+		if !ok {
+			continue
+		}
+		got, err := fileChecksum(filepath.Join(testDir, name))
+		if err != nil || got != want {
+			corrupted = append(corrupted, name)
+		}
+	}
+	return corrupted, nil
+}
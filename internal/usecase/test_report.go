@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// Formats of machine-readable test reports
+const (
+	ReportFormatJUnit = "junit"
+	ReportFormatTAP   = "tap"
+)
+
+// ResolveReportFormat validates the report format, inferring it from the
+// file extension if it is empty: .xml is JUnit and .tap is TAP
+func ResolveReportFormat(path, format string) (string, error) {
+	switch format {
+	case ReportFormatJUnit, ReportFormatTAP:
+		return format, nil
+	case "":
+	default:
+		return "", cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("unknown report format '%s'. Available: %s, %s", format, ReportFormatJUnit, ReportFormatTAP),
+			nil,
+		)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return ReportFormatJUnit, nil
+	case ".tap":
+		return ReportFormatTAP, nil
+	default:
+		return "", cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("cannot infer the report format of '%s'. Use a .xml or .tap file or pass --report-format", path),
+			nil,
+		)
+	}
+}
+
+// WriteReport saves the test reports of one or more problems to path as JUnit XML or TAP
+func (uc *TestUseCase) WriteReport(path, format string, reports ...*TestReport) error {
+	format, err := ResolveReportFormat(path, format)
+	if err != nil {
+		return err
+	}
+
+	write := WriteJUnitReport
+	if format == ReportFormatTAP {
+		write = WriteTAPReport
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return cerrors.Wrap(err, "failed to create report directory")
+		}
+	}
+	trace.File("write", path)
+	file, err := os.Create(path)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create report file")
+	}
+	if err := write(file, reports...); err != nil {
+		_ = file.Close()
+		return cerrors.Wrap(err, "failed to write report")
+	}
+	if err := file.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to write report")
+	}
+	return nil
+}
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnitReport writes the reports as JUnit XML with one test suite per
+// problem. A problem that failed to build is reported as an erroneous "build" case
+func WriteJUnitReport(w io.Writer, reports ...*TestReport) error {
+	root := junitTestSuites{}
+	for _, report := range reports {
+		root.Suites = append(root.Suites, junitSuite(report))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSuite converts the report of one problem to a JUnit test suite
+func junitSuite(report *TestReport) junitTestSuite {
+	suite := junitTestSuite{Name: report.Problem}
+	var total time.Duration
+
+	if message, output, failed := buildFailure(report); failed {
+		suite.Errors = 1
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "build",
+			ClassName: report.Problem,
+			Time:      junitSeconds(0),
+			Error:     &junitProblem{Message: message, Type: report.Verdict, Text: output},
+		})
+	}
+	for _, c := range report.Cases {
+		total += c.Duration
+		tc := junitTestCase{
+			Name:      c.Name,
+			ClassName: report.Problem,
+			Time:      junitSeconds(c.Duration),
+			SystemErr: c.Stderr,
+		}
+		switch {
+		case c.Verdict == VerdictSkipped:
+			suite.Skipped++
+			tc.Skipped = &junitSkipped{Message: c.Description}
+		case !c.Passed():
+			suite.Failures++
+			tc.Failure = &junitProblem{Message: c.Verdict, Type: c.Verdict, Text: failureDetail(c)}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = junitSeconds(total)
+	return suite
+}
+
+// buildFailure returns a message and details if the problem could not be
+// tested because its solution did not build or could not be run at all
+func buildFailure(report *TestReport) (message, output string, failed bool) {
+	switch report.Verdict {
+	case VerdictCompileError:
+		return "compile error", report.BuildOutput, true
+	case VerdictError:
+		return report.Error, report.Error, true
+	default:
+		return "", "", false
+	}
+}
+
+// junitSeconds formats a duration in seconds as JUnit expects
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteTAPReport writes the reports in the Test Anything Protocol, version 13.
+// Failed cases carry a YAML block with the verdict and, for wrong answers, the
+// expected and actual output. With several reports, each case name starts with
+// the problem
+func WriteTAPReport(w io.Writer, reports ...*TestReport) error {
+	var b strings.Builder
+	b.WriteString("TAP version 13\n")
+
+	type tapCase struct {
+		prefix string
+		report *TestReport
+		result *CaseResult // nil for the build of a problem that failed to build
+	}
+	var cases []tapCase
+	for _, report := range reports {
+		prefix := ""
+		if len(reports) > 1 {
+			prefix = report.Problem + " "
+		}
+		if _, _, failed := buildFailure(report); failed {
+			cases = append(cases, tapCase{prefix: prefix, report: report})
+			continue
+		}
+		for i := range report.Cases {
+			cases = append(cases, tapCase{prefix: prefix, report: report, result: &report.Cases[i]})
+		}
+	}
+
+	fmt.Fprintf(&b, "1..%d\n", len(cases))
+	for i, tc := range cases {
+		if tc.result == nil {
+			_, output, _ := buildFailure(tc.report)
+			fmt.Fprintf(&b, "not ok %d - %s\n", i+1, tapEscape(tc.prefix+"build"))
+			b.WriteString("  ---\n")
+			fmt.Fprintf(&b, "  verdict: %s\n", tc.report.Verdict)
+			writeTAPScalar(&b, "output", output)
+			b.WriteString("  ...\n")
+			continue
+		}
+
+		c := tc.result
+		name := tapEscape(tc.prefix + c.Name)
+		if c.Description != "" {
+			name += " " + tapEscape(c.Description)
+		}
+		switch {
+		case c.Verdict == VerdictSkipped:
+			fmt.Fprintf(&b, "ok %d - %s # SKIP\n", i+1, name)
+		case c.Passed():
+			fmt.Fprintf(&b, "ok %d - %s\n", i+1, name)
+		default:
+			fmt.Fprintf(&b, "not ok %d - %s\n", i+1, name)
+			b.WriteString("  ---\n")
+			fmt.Fprintf(&b, "  verdict: %s\n", c.Verdict)
+			fmt.Fprintf(&b, "  time_ms: %d\n", c.Duration.Milliseconds())
+			if c.Verdict == VerdictWrongAnswer {
+				writeTAPScalar(&b, "expected", c.Expected)
+				writeTAPScalar(&b, "actual", c.Actual)
+			}
+			if c.Stderr != "" {
+				writeTAPScalar(&b, "stderr", c.Stderr)
+			}
+			b.WriteString("  ...\n")
+		}
+	}
+	for _, report := range reports {
+		if report.Interrupted {
+			b.WriteString("Bail out! Interrupted\n")
+			break
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// tapEscape escapes "#", which starts a directive such as SKIP in a TAP description
+func tapEscape(s string) string {
+	return strings.ReplaceAll(s, "#", `\#`)
+}
+
+// writeTAPScalar writes a literal block scalar of the YAML diagnostics
+func writeTAPScalar(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "  %s: |\n", key)
+	for _, line := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+		b.WriteString("    " + line + "\n")
+	}
+}
+
+// failureDetail describes why a test case failed
+func failureDetail(c CaseResult) string {
+	if c.Verdict != VerdictWrongAnswer {
+		return ""
+	}
+	return "Expected:\n" + strings.TrimRight(c.Expected, "\n") + "\nActual:\n" + strings.TrimRight(c.Actual, "\n")
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// exportedReport has a passed, a wrong and a skipped case
+func exportedReport() *TestReport {
+	return &TestReport{
+		Problem: "ITP1_1_A",
+		Verdict: VerdictWrongAnswer,
+		Total:   3,
+		Cases: []CaseResult{
+			{Name: "sample-1", Verdict: VerdictAccepted, Expected: "3\n", Actual: "3\n", Duration: 12 * time.Millisecond},
+			{Name: "sample-2", Verdict: VerdictWrongAnswer, Expected: "7\n", Actual: "8\n", Stderr: "debug", Duration: 300 * time.Millisecond},
+			{Name: "judge-1", Verdict: VerdictSkipped, Description: "slow"},
+		},
+	}
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+
+	// When
+	err := WriteJUnitReport(&out, exportedReport())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="ITP1_1_A" tests="3" failures="1" errors="0" skipped="1" time="0.312">
+    <testcase name="sample-1" classname="ITP1_1_A" time="0.012"></testcase>
+    <testcase name="sample-2" classname="ITP1_1_A" time="0.300">
+      <failure message="WA" type="WA">Expected:&#xA;7&#xA;Actual:&#xA;8</failure>
+      <system-err>debug</system-err>
+    </testcase>
+    <testcase name="judge-1" classname="ITP1_1_A" time="0.000">
+      <skipped message="slow"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, out.String())
+}
+
+func TestWriteTAPReport(t *testing.T) {
+	// Given
+	var out bytes.Buffer
+
+	// When
+	err := WriteTAPReport(&out, exportedReport())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `TAP version 13
+1..3
+ok 1 - sample-1
+not ok 2 - sample-2
+  ---
+  verdict: WA
+  time_ms: 300
+  expected: |
+    7
+  actual: |
+    8
+  stderr: |
+    debug
+  ...
+ok 3 - judge-1 slow # SKIP
+`, out.String())
+}
+
+func TestWriteReport_CompileError(t *testing.T) {
+	// Given
+	report := &TestReport{Problem: "ITP1_1_A", Verdict: VerdictCompileError, BuildOutput: "main.cpp:1: error\n"}
+	var junit, tap bytes.Buffer
+
+	// When
+	junitErr := WriteJUnitReport(&junit, report)
+	tapErr := WriteTAPReport(&tap, report)
+
+	// Then
+	assert.NoError(t, junitErr)
+	assert.NoError(t, tapErr)
+	assert.Contains(t, junit.String(), `errors="1"`)
+	assert.Contains(t, junit.String(), `<error message="compile error" type="CE">main.cpp:1: error&#xA;</error>`)
+	assert.Equal(t, "TAP version 13\n1..1\nnot ok 1 - build\n  ---\n  verdict: CE\n  output: |\n    main.cpp:1: error\n  ...\n", tap.String())
+}
+
+func TestTestUseCase_WriteReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		format  string
+		prefix  string
+		wantErr bool
+	}{
+		{name: "junit from extension", path: "reports/junit.xml", prefix: "<?xml"},
+		{name: "tap from extension", path: "results.tap", prefix: "TAP version 13"},
+		{name: "explicit format", path: "results.txt", format: ReportFormatTAP, prefix: "TAP version 13"},
+		{name: "unknown extension", path: "results.txt", wantErr: true},
+		{name: "unknown format", path: "results.xml", format: "html", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			path := filepath.Join(t.TempDir(), tt.path)
+			uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+			// When
+			err := uc.WriteReport(path, tt.format, exportedReport())
+
+			// Then
+			if tt.wantErr {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+				return
+			}
+			assert.NoError(t, err)
+			content, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(content, []byte(tt.prefix)), string(content))
+		})
+	}
+}
+
+func TestWriteTAPReport_SeveralProblems(t *testing.T) {
+	// Given
+	broken := &TestReport{Problem: "ALDS1_1_A", Verdict: VerdictError, Error: "source file main.cpp not found"}
+	passed := &TestReport{Problem: "ITP1_1_B", Verdict: VerdictAccepted, Cases: []CaseResult{{Name: "sample-1", Verdict: VerdictAccepted}}}
+	var out bytes.Buffer
+
+	// When
+	err := WriteTAPReport(&out, broken, passed)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "TAP version 13\n1..2\n"+
+		"not ok 1 - ALDS1_1_A build\n  ---\n  verdict: ERROR\n  output: |\n    source file main.cpp not found\n  ...\n"+
+		"ok 2 - ITP1_1_B sample-1\n", out.String())
+}
@@ -0,0 +1,619 @@
+// Package usecase implements application business logic.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// Local verdicts reported by the test use case
+const (
+	VerdictAccepted          = "AC"
+	VerdictWrongAnswer       = "WA"
+	VerdictTimeLimitExceeded = "TLE"
+	VerdictRuntimeError      = "RE"
+	VerdictCompileError      = "CE"
+	VerdictSkipped           = "SKIP" // skipped by test/meta.toml
+)
+
+const (
+	defaultLocalTestTimeout = 2 * time.Second
+	sampleInputExtension    = ".in"
+	sampleOutputExtension   = ".out"
+	sourceFilePlaceholder   = "{file}"
+	transcriptExtension     = ".transcript"
+)
+
+// LanguageCommand describes how to build and run solutions with a file extension
+type LanguageCommand struct {
+	Extension    string // file extension without the leading dot
+	BuildCommand string // {file} is replaced with the source file name
+	RunCommand   string // {file} is replaced with the source file name
+}
+
+// TestSettings holds the defaults used by the test use case
+type TestSettings struct {
+	SourceFile      string            // default solution file
+	TestDir         string            // directory of sample test cases inside the problem directory
+	Timeout         time.Duration     // time limit per test case when the problem's limit is unknown
+	TimeLimitFactor float64           // multiplier applied to the problem's time limit
+	SpeedFactor     float64           // how many times slower this machine is than the judge
+	TLEWarnRatio    float64           // fraction of the time limit above which a likely TLE is reported
+	Languages       []LanguageCommand // first match by extension wins
+	Interactor      string            // interactor command for interactive problems, empty for normal ones
+	ExtraBuildFlags string            // appended to the build command of C and C++ solutions
+	Aliases         ProblemAliases    // problem aliases that directory names may use
+
+	// Results records the outcome of every complete run for aoj list; nil records nothing
+	Results repository.LocalResultRepository
+	Clock   clock.Clock // tells when a run finished; defaults to the system clock
+}
+
+// TestUseCase handles running solutions against sample test cases locally
+type TestUseCase struct {
+	runner   service.SolutionRunner
+	settings TestSettings
+	logger   *logger.Logger
+}
+
+// NewTestUseCase creates a new TestUseCase
+func NewTestUseCase(runner service.SolutionRunner, settings TestSettings) *TestUseCase {
+	defaults := DefaultInitLayout()
+	if settings.SourceFile == "" {
+		settings.SourceFile = defaults.SourceFile
+	}
+	if settings.TestDir == "" {
+		settings.TestDir = defaults.TestDir
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultLocalTestTimeout
+	}
+	if settings.TimeLimitFactor <= 0 {
+		settings.TimeLimitFactor = 1
+	}
+	if settings.SpeedFactor <= 0 {
+		settings.SpeedFactor = 1
+	}
+	if settings.TLEWarnRatio <= 0 {
+		settings.TLEWarnRatio = defaultTLEWarnRatio
+	}
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+
+	return &TestUseCase{
+		runner:   runner,
+		settings: settings,
+		logger:   logger.WithGroup("test_usecase"),
+	}
+}
+
+// TestOptions contains options for running tests
+type TestOptions struct {
+	Dir        string        // Optional: problem directory (defaults to the current directory)
+	SourceFile string        // Optional: solution file (defaults to the configured source file)
+	Case       int           // Optional: run only the sample with this number
+	Timeout    time.Duration // Optional: time limit per test case
+	Aggregate  bool          // Run all samples as a single input stream (ICPC-style datasets)
+	Interactor string        // Optional: interactor command (defaults to problem.toml, then the configured one)
+	Sanitize   string        // Optional: sanitizers to build C and C++ solutions with, e.g. "address,undefined"
+
+	// OnCase is called with the result of every test case as soon as it has run
+	OnCase func(result CaseResult)
+}
+
+// CaseResult holds the result of a single test case
+type CaseResult struct {
+	Name     string        `json:"name"`
+	Verdict  string        `json:"verdict"`
+	Input    string        `json:"input"`
+	Expected string        `json:"expected"`
+	Actual   string        `json:"actual"`
+	Stderr   string        `json:"stderr,omitempty"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"duration"`
+	MemoryKB int64         `json:"memory_kb"`
+
+	Description string `json:"description,omitempty"` // from test/meta.toml
+	Interactor  string `json:"interactor,omitempty"`  // stderr of the interactor, usually why it rejected the solution
+	Transcript  string `json:"transcript,omitempty"`  // file holding the dialogue with the interactor
+}
+
+// Passed returns true if the test case was accepted
+func (r CaseResult) Passed() bool {
+	return r.Verdict == VerdictAccepted
+}
+
+// TestReport holds the results of a test run
+type TestReport struct {
+	Problem     string        `json:"problem"` // problem ID, or the directory name if it is unknown
+	SourceFile  string        `json:"source_file"`
+	BuildOutput string        `json:"build_output,omitempty"`
+	Verdict     string        `json:"verdict"`
+	Cases       []CaseResult  `json:"cases"`
+	Total       int           `json:"total"`                 // number of test cases selected to run
+	Interrupted bool          `json:"interrupted,omitempty"` // the run was cancelled before all cases finished
+	Error       string        `json:"error,omitempty"`       // why the problem could not be tested, with VerdictError
+	Corrupted   []string      `json:"corrupted,omitempty"`   // test files that no longer match their recorded checksum
+	TimeLimit   time.Duration `json:"time_limit,omitempty"`  // judge time limit of the problem, 0 if unknown
+	TLEWarnings []TLEWarning  `json:"tle_warnings,omitempty"`
+}
+
+// PassedCount returns the number of accepted test cases
+func (r *TestReport) PassedCount() int {
+	passed := 0
+	for _, c := range r.Cases {
+		if c.Passed() {
+			passed++
+		}
+	}
+	return passed
+}
+
+// SkippedCount returns the number of test cases skipped by test/meta.toml
+func (r *TestReport) SkippedCount() int {
+	skipped := 0
+	for _, c := range r.Cases {
+		if c.Verdict == VerdictSkipped {
+			skipped++
+		}
+	}
+	return skipped
+}
+
+// Slowest returns the longest running time among the test cases that were run
+func (r *TestReport) Slowest() time.Duration {
+	var slowest time.Duration
+	for _, c := range r.Cases {
+		slowest = max(slowest, c.Duration)
+	}
+	return slowest
+}
+
+// Execute builds the solution and runs it against the sample test cases
+func (uc *TestUseCase) Execute(ctx context.Context, opts TestOptions) (*TestReport, error) {
+	uc.logger.InfoContext(ctx, "starting local test", "options", fmt.Sprintf("%+v", opts))
+
+	dir, spec, err := uc.prepare(opts.Dir, opts.SourceFile, opts.Sanitize)
+	if err != nil {
+		return nil, err
+	}
+	timeLimit := uc.problemTimeLimit(ctx, dir)
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = uc.settings.Timeout
+		if timeLimit > 0 {
+			timeout = time.Duration(float64(timeLimit) * uc.settings.TimeLimitFactor)
+		}
+	}
+
+	testCases, err := uc.loadTestCases(dir, opts.Case)
+	if err != nil {
+		return nil, err
+	}
+	interactor := uc.interactor(dir, opts.Interactor)
+	if interactor != "" && opts.Aggregate {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"--aggregate cannot be used with an interactor",
+			nil,
+		)
+	}
+	meta, err := config.LoadTestMeta(filepath.Join(dir, uc.settings.TestDir))
+	if err != nil {
+		return nil, err
+	}
+	corrupted := uc.checkTestFiles(ctx, dir, testCases)
+	testCases, skipped := skipTestCases(testCases, meta, opts.Case)
+	if opts.Aggregate && len(testCases) > 1 {
+		testCases = []model.TestCase{*model.AggregateTestCases(testCases)}
+	}
+
+	report := &TestReport{Problem: uc.problemName(dir), SourceFile: spec.SourceFile, Total: len(testCases) + len(skipped), Corrupted: corrupted, TimeLimit: timeLimit}
+
+	build, err := uc.build(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	report.BuildOutput = build.Output
+	if !build.Success {
+		report.Verdict = VerdictCompileError
+		uc.recordResult(ctx, dir, opts, report)
+		return report, nil
+	}
+
+	for _, tc := range testCases {
+		caseMeta := meta.Case(tc.Name())
+		caseTimeout := timeout
+		if opts.Timeout <= 0 && caseMeta.Timeout > 0 {
+			caseTimeout = caseMeta.TimeoutDuration()
+		}
+
+		var caseResult CaseResult
+		if interactor != "" {
+			caseResult, err = uc.runInteractive(ctx, dir, spec, interactor, tc, caseTimeout)
+		} else {
+			var result *service.RunResult
+			result, err = uc.runner.Run(ctx, spec, tc.Input(), caseTimeout)
+			if err == nil {
+				caseResult = judge(tc, result)
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				// Keep the results of the cases that finished before the interruption
+				report.Interrupted = true
+				break
+			}
+			return nil, err
+		}
+		caseResult.Description = caseMeta.Description
+		report.Cases = append(report.Cases, caseResult)
+		if opts.OnCase != nil {
+			opts.OnCase(caseResult)
+		}
+	}
+	if !report.Interrupted {
+		report.Cases = append(report.Cases, skipped...)
+	}
+
+	report.Verdict = overallVerdict(report.Cases)
+	report.TLEWarnings = uc.tleWarnings(report.Cases, timeLimit)
+	uc.logger.InfoContext(ctx, "local test finished",
+		"verdict", report.Verdict,
+		"passed", report.PassedCount(),
+		"skipped", report.SkippedCount(),
+		"total", report.Total,
+		"interrupted", report.Interrupted)
+
+	uc.recordResult(ctx, dir, opts, report)
+	return report, nil
+}
+
+// recordResult saves the outcome of a run over every test case of a problem
+// whose ID is known. Runs of a single case and interrupted runs are not
+// recorded, as they do not tell whether the solution passes
+func (uc *TestUseCase) recordResult(ctx context.Context, dir string, opts TestOptions, report *TestReport) {
+	if uc.settings.Results == nil || opts.Case > 0 || report.Interrupted {
+		return
+	}
+	problemID, err := resolveProblemID(dir, "", uc.settings.Aliases)
+	if err != nil {
+		return
+	}
+
+	result := repository.LocalResult{
+		ProblemID: problemID.String(),
+		Verdict:   report.Verdict,
+		Passed:    report.PassedCount(),
+		Total:     report.Total,
+		TestedAt:  uc.settings.Clock.Now(),
+	}
+	if err := uc.settings.Results.Save(ctx, result); err != nil {
+		uc.logger.WarnContext(ctx, "failed to record local test result", "problem_id", result.ProblemID, "error", err)
+	}
+}
+
+// prepare resolves the problem directory and the commands for the solution file
+func (uc *TestUseCase) prepare(dir, sourceFile, sanitize string) (string, service.RunSpec, error) {
+	if dir == "" {
+		dir = "."
+	}
+	if sourceFile == "" {
+		sourceFile = uc.settings.SourceFile
+		// init records the solution file in problem.toml when another language was chosen
+		if problemConfig, err := config.LoadProblemConfig(dir); err == nil && problemConfig.SourceFile != "" {
+			sourceFile = problemConfig.SourceFile
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, sourceFile)); err != nil {
+		return "", service.RunSpec{}, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("source file %s not found", sourceFile),
+			err,
+		)
+	}
+
+	spec, err := uc.runSpec(dir, sourceFile, sanitize)
+	if err != nil {
+		return "", service.RunSpec{}, err
+	}
+	return dir, spec, nil
+}
+
+// interactor returns the interactor command of the problem: the explicit one,
+// the one in problem.toml, or the configured one
+func (uc *TestUseCase) interactor(dir, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if problemConfig, err := config.LoadProblemConfig(dir); err == nil && problemConfig.Interactor != "" {
+		return problemConfig.Interactor
+	}
+	return uc.settings.Interactor
+}
+
+// runInteractive runs a test case against the interactor and saves the
+// dialogue next to the test case as <name>.transcript
+func (uc *TestUseCase) runInteractive(ctx context.Context, dir string, spec service.RunSpec, interactor string, tc model.TestCase, timeout time.Duration) (CaseResult, error) {
+	result, err := uc.runner.RunInteractive(ctx, spec, service.InteractorSpec{
+		Command:  interactor,
+		Input:    tc.Input(),
+		Expected: tc.Expected(),
+	}, timeout)
+	if err != nil {
+		return CaseResult{}, err
+	}
+
+	caseResult := judgeInteractive(tc, result)
+	transcript := filepath.Join(uc.settings.TestDir, tc.GetDisplayName()+transcriptExtension)
+	trace.File("write", filepath.Join(dir, transcript))
+	if err := os.WriteFile(filepath.Join(dir, transcript), []byte(formatTranscript(result.Transcript)), 0644); err != nil {
+		uc.logger.WarnContext(ctx, "failed to write transcript", "error", err)
+	} else {
+		caseResult.Transcript = transcript
+	}
+	return caseResult, nil
+}
+
+// problemName returns the problem ID of dir for reports, falling back to the
+// directory name when it cannot be determined
+func (uc *TestUseCase) problemName(dir string) string {
+	if problemID, err := resolveProblemID(dir, "", uc.settings.Aliases); err == nil {
+		return problemID.String()
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		return filepath.Base(abs)
+	}
+	return dir
+}
+
+// problemTimeLimit returns the time limit stored in problem.toml, or 0 if unknown
+func (uc *TestUseCase) problemTimeLimit(ctx context.Context, dir string) time.Duration {
+	problemConfig, err := config.LoadProblemConfig(dir)
+	if err != nil {
+		if !cerrors.IsAppError(err, cerrors.CodeNotFound) {
+			uc.logger.WarnContext(ctx, "failed to load problem config", "error", err)
+		}
+		return 0
+	}
+	return problemConfig.TimeLimitDuration()
+}
+
+// runSpec resolves the build and run commands for a solution file
+func (uc *TestUseCase) runSpec(dir, sourceFile, sanitize string) (service.RunSpec, error) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(sourceFile)), ".")
+	for _, lang := range uc.settings.Languages {
+		if strings.EqualFold(lang.Extension, ext) {
+			buildCommand := strings.ReplaceAll(lang.BuildCommand, sourceFilePlaceholder, sourceFile)
+			flags, err := uc.buildFlags(ext, sanitize)
+			if err != nil {
+				return service.RunSpec{}, err
+			}
+			if buildCommand != "" && flags != "" {
+				buildCommand += " " + flags
+			}
+			return service.RunSpec{
+				Dir:          dir,
+				SourceFile:   sourceFile,
+				BuildCommand: buildCommand,
+				RunCommand:   strings.ReplaceAll(lang.RunCommand, sourceFilePlaceholder, sourceFile),
+			}, nil
+		}
+	}
+
+	return service.RunSpec{}, cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		fmt.Sprintf("don't know how to run %s files", filepath.Ext(sourceFile)),
+		nil,
+	)
+}
+
+// loadTestCases reads the sample test cases from the test directory
+func (uc *TestUseCase) loadTestCases(dir string, only int) ([]model.TestCase, error) {
+	testDir := filepath.Join(dir, uc.settings.TestDir)
+	inputs, err := listTestInputs(testDir)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases := make([]model.TestCase, 0, len(inputs))
+	for i, inputFile := range inputs {
+		if only > 0 && i+1 != only {
+			continue
+		}
+
+		input, err := os.ReadFile(inputFile)
+		if err != nil {
+			return nil, cerrors.Wrap(err, fmt.Sprintf("failed to read %s", inputFile))
+		}
+		expected, err := os.ReadFile(strings.TrimSuffix(inputFile, sampleInputExtension) + sampleOutputExtension)
+		if err != nil {
+			return nil, cerrors.Wrap(err, fmt.Sprintf("failed to read expected output for %s", inputFile))
+		}
+
+		name := strings.TrimSuffix(filepath.Base(inputFile), sampleInputExtension)
+		testCases = append(testCases, *model.NewNamedTestCase(i+1, string(input), string(expected), name))
+	}
+
+	if len(testCases) == 0 {
+		if only > 0 {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				fmt.Sprintf("test case %d not found in %s", only, testDir),
+				nil,
+			)
+		}
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("no test cases found in %s", testDir),
+			nil,
+		)
+	}
+
+	return testCases, nil
+}
+
+// checkTestFiles returns the files of the loaded test cases that no longer
+// match the checksums recorded when they were downloaded
+func (uc *TestUseCase) checkTestFiles(ctx context.Context, dir string, testCases []model.TestCase) []string {
+	names := make([]string, 0, 2*len(testCases))
+	for _, tc := range testCases {
+		names = append(names, tc.Name()+sampleInputExtension, tc.Name()+sampleOutputExtension)
+	}
+	corrupted, err := corruptedTestFiles(filepath.Join(dir, uc.settings.TestDir), names)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to verify test files", "error", err)
+		return nil
+	}
+	if len(corrupted) > 0 {
+		uc.logger.WarnContext(ctx, "test files do not match their checksums", "files", corrupted)
+	}
+	return corrupted
+}
+
+// skipTestCases separates the cases marked as skipped in test/meta.toml. A
+// case selected explicitly with --case always runs
+func skipTestCases(testCases []model.TestCase, meta *config.TestMeta, only int) ([]model.TestCase, []CaseResult) {
+	if only > 0 {
+		return testCases, nil
+	}
+
+	run := make([]model.TestCase, 0, len(testCases))
+	var skipped []CaseResult
+	for _, tc := range testCases {
+		caseMeta := meta.Case(tc.Name())
+		if !caseMeta.Skip {
+			run = append(run, tc)
+			continue
+		}
+		skipped = append(skipped, CaseResult{
+			Name:        tc.GetDisplayName(),
+			Verdict:     VerdictSkipped,
+			Input:       tc.Input(),
+			Expected:    tc.Expected(),
+			Description: caseMeta.Description,
+		})
+	}
+	return run, skipped
+}
+
+// listTestInputs returns the input files of the test directory in natural order,
+// which is the order in which cases are numbered
+func listTestInputs(testDir string) ([]string, error) {
+	inputs, err := filepath.Glob(filepath.Join(testDir, "*"+sampleInputExtension))
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list test cases")
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		return naturalLess(filepath.Base(inputs[i]), filepath.Base(inputs[j]))
+	})
+	return inputs, nil
+}
+
+// judge compares the execution result with the expected output
+func judge(tc model.TestCase, result *service.RunResult) CaseResult {
+	caseResult := CaseResult{
+		Name:     tc.GetDisplayName(),
+		Input:    tc.Input(),
+		Expected: tc.Expected(),
+		Actual:   result.Stdout,
+		Stderr:   result.Stderr,
+		ExitCode: result.ExitCode,
+		Duration: result.Duration,
+		MemoryKB: result.MemoryKB,
+	}
+
+	switch {
+	case result.TimedOut:
+		caseResult.Verdict = VerdictTimeLimitExceeded
+	case result.ExitCode != 0:
+		caseResult.Verdict = VerdictRuntimeError
+	case tc.CompareOutput(result.Stdout):
+		caseResult.Verdict = VerdictAccepted
+	default:
+		caseResult.Verdict = VerdictWrongAnswer
+	}
+
+	return caseResult
+}
+
+// judgeInteractive derives the verdict of an interactive run: the interactor
+// decides whether the answer is correct
+func judgeInteractive(tc model.TestCase, result *service.InteractiveResult) CaseResult {
+	caseResult := judge(tc, &result.RunResult)
+	caseResult.Interactor = result.InteractorStderr
+
+	switch {
+	case result.TimedOut:
+		caseResult.Verdict = VerdictTimeLimitExceeded
+	case result.ExitCode != 0:
+		caseResult.Verdict = VerdictRuntimeError
+	case result.InteractorExitCode == 0:
+		caseResult.Verdict = VerdictAccepted
+	default:
+		caseResult.Verdict = VerdictWrongAnswer
+	}
+	return caseResult
+}
+
+// formatTranscript writes the dialogue with "<" marking the lines the solution
+// received and ">" the lines it sent
+func formatTranscript(lines []service.TranscriptLine) string {
+	var b strings.Builder
+	for _, line := range lines {
+		marker := "< "
+		if line.FromSolution {
+			marker = "> "
+		}
+		b.WriteString(marker + line.Text + "\n")
+	}
+	return b.String()
+}
+
+// overallVerdict returns the first non-accepted verdict, or AC when all cases
+// that ran passed
+func overallVerdict(cases []CaseResult) string {
+	for _, c := range cases {
+		if !c.Passed() && c.Verdict != VerdictSkipped {
+			return c.Verdict
+		}
+	}
+	return VerdictAccepted
+}
+
+// naturalLess orders names so that sample-2 comes before sample-10
+func naturalLess(a, b string) bool {
+	prefixA, numA, okA := splitTrailingNumber(strings.TrimSuffix(a, sampleInputExtension))
+	prefixB, numB, okB := splitTrailingNumber(strings.TrimSuffix(b, sampleInputExtension))
+	if okA && okB && prefixA == prefixB {
+		return numA < numB
+	}
+	return a < b
+}
+
+// splitTrailingNumber splits "sample-12" into "sample-" and 12
+func splitTrailingNumber(name string) (string, int, bool) {
+	i := len(name)
+	for i > 0 && name[i-1] >= '0' && name[i-1] <= '9' {
+		i--
+	}
+	n, err := strconv.Atoi(name[i:])
+	return name[:i], n, err == nil
+}
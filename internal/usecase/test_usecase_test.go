@@ -0,0 +1,499 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// memoryLocalResultRepository keeps the local test outcomes in memory
+type memoryLocalResultRepository struct {
+	results map[string]repository.LocalResult
+}
+
+func (r *memoryLocalResultRepository) List(_ context.Context) (map[string]repository.LocalResult, error) {
+	results := map[string]repository.LocalResult{}
+	for id, result := range r.results {
+		results[id] = result
+	}
+	return results, nil
+}
+
+func (r *memoryLocalResultRepository) Save(_ context.Context, result repository.LocalResult) error {
+	if r.results == nil {
+		r.results = map[string]repository.LocalResult{}
+	}
+	r.results[result.ProblemID] = result
+	return nil
+}
+
+// fakeSolutionRunner sums pairs of integers until a "0 0" line, like a typical ICPC solution
+type fakeSolutionRunner struct {
+	buildFails  bool
+	builds      []service.RunSpec
+	inputs      []string
+	timeouts    []time.Duration
+	interactors []string
+}
+
+func (r *fakeSolutionRunner) Build(_ context.Context, spec service.RunSpec) (*service.BuildResult, error) {
+	r.builds = append(r.builds, spec)
+	if r.buildFails {
+		return &service.BuildResult{Success: false, Output: "main.cpp:1: error"}, nil
+	}
+	return &service.BuildResult{Success: true}, nil
+}
+
+func (r *fakeSolutionRunner) Run(_ context.Context, _ service.RunSpec, input string, timeout time.Duration) (*service.RunResult, error) {
+	r.inputs = append(r.inputs, input)
+	r.timeouts = append(r.timeouts, timeout)
+
+	var out strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(input), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return &service.RunResult{ExitCode: 1, Stderr: "bad input"}, nil
+		}
+		a, _ := strconv.Atoi(fields[0])
+		b, _ := strconv.Atoi(fields[1])
+		if a == 0 && b == 0 {
+			break
+		}
+		out.WriteString(strconv.Itoa(a+b) + "\n")
+	}
+	return &service.RunResult{Stdout: out.String()}, nil
+}
+
+// RunInteractive plays an interactor that sends the test input and accepts
+// the solution if its answer equals the expected output
+func (r *fakeSolutionRunner) RunInteractive(ctx context.Context, spec service.RunSpec, interactor service.InteractorSpec, timeout time.Duration) (*service.InteractiveResult, error) {
+	r.interactors = append(r.interactors, interactor.Command)
+	run, err := r.Run(ctx, spec, interactor.Input, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &service.InteractiveResult{RunResult: *run}
+	for _, line := range strings.Split(strings.TrimSpace(interactor.Input), "\n") {
+		result.Transcript = append(result.Transcript, service.TranscriptLine{Text: line})
+	}
+	for _, line := range strings.Split(strings.TrimSpace(run.Stdout), "\n") {
+		result.Transcript = append(result.Transcript, service.TranscriptLine{FromSolution: true, Text: line})
+	}
+	if run.Stdout != interactor.Expected {
+		result.InteractorExitCode = 1
+		result.InteractorStderr = "wrong answer"
+	}
+	return result, nil
+}
+
+func (r *fakeSolutionRunner) RunAttached(ctx context.Context, spec service.RunSpec, stdin io.Reader, stdout, _ io.Writer) (*service.RunResult, error) {
+	input, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.Run(ctx, spec, string(input), 0)
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.WriteString(stdout, result.Stdout)
+	return result, err
+}
+
+// interruptingRunner cancels the context once the given number of runs has finished,
+// like a user pressing Ctrl-C during a test run
+type interruptingRunner struct {
+	fakeSolutionRunner
+	runsBeforeCancel int
+	cancel           context.CancelFunc
+}
+
+func (r *interruptingRunner) Run(ctx context.Context, spec service.RunSpec, input string, timeout time.Duration) (*service.RunResult, error) {
+	if len(r.inputs) == r.runsBeforeCancel {
+		r.cancel()
+		return nil, ctx.Err()
+	}
+	return r.fakeSolutionRunner.Run(ctx, spec, input, timeout)
+}
+
+func writeSamples(t *testing.T, dir string, samples map[string][2]string) {
+	t.Helper()
+	testDir := filepath.Join(dir, "test")
+	assert.NoError(t, os.MkdirAll(testDir, 0755))
+	for name, sample := range samples {
+		assert.NoError(t, os.WriteFile(filepath.Join(testDir, name+".in"), []byte(sample[0]), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(testDir, name+".out"), []byte(sample[1]), 0644))
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.cpp"), []byte("int main() {}\n"), 0644))
+}
+
+func newTestTestUseCase(runner service.SolutionRunner) *TestUseCase {
+	return NewTestUseCase(runner, TestSettings{
+		SourceFile: "main.cpp",
+		Languages: []LanguageCommand{
+			{Extension: "cpp", BuildCommand: "g++ {file}", RunCommand: "./a.out"},
+		},
+	})
+}
+
+func TestTestUseCase_Execute_PerCase(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1":  {"1 2\n0 0\n", "3\n"},
+		"sample-2":  {"2 2\n0 0\n", "5\n"},
+		"sample-10": {"5 5\n0 0\n", "10\n"},
+	})
+	uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, VerdictWrongAnswer, report.Verdict)
+	assert.Len(t, report.Cases, 3)
+	assert.Equal(t, []string{"sample-1", "sample-2", "sample-10"},
+		[]string{report.Cases[0].Name, report.Cases[1].Name, report.Cases[2].Name})
+	assert.Equal(t, 2, report.PassedCount())
+}
+
+func TestTestUseCase_Execute_RecordsResult(t *testing.T) {
+	// Given
+	dir := filepath.Join(t.TempDir(), "ITP1_1_A")
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n0 0\n", "3\n"},
+		"sample-2": {"2 2\n0 0\n", "5\n"},
+	})
+	results := &memoryLocalResultRepository{}
+	testedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	uc := NewTestUseCase(&fakeSolutionRunner{}, TestSettings{
+		SourceFile: "main.cpp",
+		Languages:  []LanguageCommand{{Extension: "cpp", BuildCommand: "g++ {file}", RunCommand: "./a.out"}},
+		Results:    results,
+		Clock:      clock.NewFake(testedAt),
+	})
+
+	// When
+	_, singleErr := uc.Execute(context.Background(), TestOptions{Dir: dir, Case: 1})
+	recordedAfterSingle := len(results.results)
+	_, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, singleErr)
+	assert.NoError(t, err)
+	assert.Zero(t, recordedAfterSingle)
+	assert.Equal(t, repository.LocalResult{
+		ProblemID: "ITP1_1_A",
+		Verdict:   VerdictWrongAnswer,
+		Passed:    1,
+		Total:     2,
+		TestedAt:  testedAt,
+	}, results.results["ITP1_1_A"])
+}
+
+func TestTestUseCase_Execute_Aggregate(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n0 0\n", "3\n"},
+		"sample-2": {"5 5\n7 1\n0 0\n", "10\n8\n"},
+	})
+	runner := &fakeSolutionRunner{}
+	uc := newTestTestUseCase(runner)
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir, Aggregate: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, VerdictAccepted, report.Verdict)
+	assert.Len(t, report.Cases, 1)
+	assert.Equal(t, []string{"1 2\n5 5\n7 1\n0 0\n"}, runner.inputs)
+	assert.Equal(t, "3\n10\n8\n", report.Cases[0].Expected)
+}
+
+func TestTestUseCase_Execute_ProblemTimeLimit(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n0 0\n", "3\n"}})
+	assert.NoError(t, config.SaveProblemConfig(dir, &config.ProblemConfig{ProblemID: "ITP1_1_A", TimeLimit: 1}))
+	runner := &fakeSolutionRunner{}
+	uc := NewTestUseCase(runner, TestSettings{
+		SourceFile:      "main.cpp",
+		Timeout:         5 * time.Second,
+		TimeLimitFactor: 1.5,
+		Languages: []LanguageCommand{
+			{Extension: "cpp", BuildCommand: "g++ {file}", RunCommand: "./a.out"},
+		},
+	})
+
+	// When
+	_, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+	assert.NoError(t, err)
+	_, err = uc.Execute(context.Background(), TestOptions{Dir: dir, Timeout: 3 * time.Second})
+	assert.NoError(t, err)
+
+	// Then
+	assert.Equal(t, []time.Duration{1500 * time.Millisecond, 3 * time.Second}, runner.timeouts)
+}
+
+func TestTestUseCase_Execute_ProblemSourceFile(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n0 0\n", "3\n"}})
+	assert.NoError(t, os.Rename(filepath.Join(dir, "main.cpp"), filepath.Join(dir, "solution.cpp")))
+	assert.NoError(t, config.SaveProblemConfig(dir, &config.ProblemConfig{ProblemID: "ITP1_1_A", SourceFile: "solution.cpp"}))
+	uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "solution.cpp", report.SourceFile)
+}
+
+func TestTestUseCase_Execute_DefaultTimeout(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n0 0\n", "3\n"}})
+	runner := &fakeSolutionRunner{}
+	uc := newTestTestUseCase(runner)
+
+	// When
+	_, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{defaultLocalTestTimeout}, runner.timeouts)
+}
+
+func TestTestUseCase_Execute_Interrupted(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n0 0\n", "3\n"},
+		"sample-2": {"2 2\n0 0\n", "4\n"},
+		"sample-3": {"3 2\n0 0\n", "5\n"},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc := newTestTestUseCase(&interruptingRunner{runsBeforeCancel: 2, cancel: cancel})
+
+	// When
+	report, err := uc.Execute(ctx, TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, report.Interrupted)
+	assert.Len(t, report.Cases, 2)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.PassedCount())
+}
+
+func TestTestUseCase_Execute_CompileError(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n0 0\n", "3\n"}})
+	uc := newTestTestUseCase(&fakeSolutionRunner{buildFails: true})
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, VerdictCompileError, report.Verdict)
+	assert.Equal(t, "main.cpp:1: error", report.BuildOutput)
+	assert.Empty(t, report.Cases)
+}
+
+func TestTestUseCase_Execute_UnknownLanguage(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.bf"), []byte("+"), 0644))
+	uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+	// When
+	_, err := uc.Execute(context.Background(), TestOptions{Dir: dir, SourceFile: "main.bf"})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+}
+
+func TestTestUseCase_Execute_Interactive(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n", "3\n"},
+		"sample-2": {"2 2\n", "5\n"},
+	})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, config.ProblemConfigFile),
+		[]byte("problem_id = \"ITP1_1_A\"\ninteractor_command = \"./judge {input}\"\n"), 0644))
+	runner := &fakeSolutionRunner{}
+	uc := NewTestUseCase(runner, TestSettings{
+		SourceFile: "main.cpp",
+		Interactor: "./configured",
+		Languages:  []LanguageCommand{{Extension: "cpp", RunCommand: "./a.out"}},
+	})
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"./judge {input}", "./judge {input}"}, runner.interactors)
+	assert.Equal(t, VerdictWrongAnswer, report.Verdict)
+	assert.Equal(t, VerdictAccepted, report.Cases[0].Verdict)
+	assert.Equal(t, VerdictWrongAnswer, report.Cases[1].Verdict)
+	assert.Equal(t, "wrong answer", report.Cases[1].Interactor)
+	assert.Equal(t, filepath.Join("test", "sample-2.transcript"), report.Cases[1].Transcript)
+	transcript, err := os.ReadFile(filepath.Join(dir, "test", "sample-2.transcript"))
+	assert.NoError(t, err)
+	assert.Equal(t, "< 2 2\n> 4\n", string(transcript))
+}
+
+func TestTestUseCase_Execute_InteractiveAggregate(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+	uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+	// When
+	_, err := uc.Execute(context.Background(), TestOptions{Dir: dir, Aggregate: true, Interactor: "./judge"})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+}
+
+func TestTestUseCase_Execute_TestMeta(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n", "3\n"},
+		"sample-2": {"2 2\n", "5\n"},
+		"sample-3": {"3 3\n", "6\n"},
+	})
+	meta := &config.TestMeta{}
+	meta.SetCase("sample-1", config.CaseMeta{Timeout: 7, Description: "small"})
+	meta.SetCase("sample-2", config.CaseMeta{Skip: true, Description: "wrong expected output"})
+	assert.NoError(t, config.SaveTestMeta(filepath.Join(dir, "test"), meta))
+
+	tests := []struct {
+		name         string
+		opts         TestOptions
+		wantVerdict  string
+		wantTimeouts []time.Duration
+		wantSkipped  int
+	}{
+		{
+			name:         "skips and overrides",
+			opts:         TestOptions{Dir: dir},
+			wantVerdict:  VerdictAccepted,
+			wantTimeouts: []time.Duration{7 * time.Second, defaultLocalTestTimeout},
+			wantSkipped:  1,
+		},
+		{
+			name:         "explicit timeout wins",
+			opts:         TestOptions{Dir: dir, Timeout: time.Second},
+			wantVerdict:  VerdictAccepted,
+			wantTimeouts: []time.Duration{time.Second, time.Second},
+			wantSkipped:  1,
+		},
+		{
+			name:         "selected case runs even if skipped",
+			opts:         TestOptions{Dir: dir, Case: 2},
+			wantVerdict:  VerdictWrongAnswer,
+			wantTimeouts: []time.Duration{defaultLocalTestTimeout},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeSolutionRunner{}
+			uc := newTestTestUseCase(runner)
+
+			// When
+			report, err := uc.Execute(context.Background(), tt.opts)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVerdict, report.Verdict)
+			assert.Equal(t, tt.wantTimeouts, runner.timeouts)
+			assert.Equal(t, tt.wantSkipped, report.SkippedCount())
+			assert.Equal(t, len(tt.wantTimeouts)+tt.wantSkipped, report.Total)
+		})
+	}
+}
+
+func TestTestUseCase_Execute_BuildFlags(t *testing.T) {
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.py"), []byte("print(3)\n"), 0644))
+
+	tests := []struct {
+		name       string
+		opts       TestOptions
+		wantBuild  string
+		wantErrMsg string
+	}{
+		{name: "extra flags", opts: TestOptions{Dir: dir}, wantBuild: "g++ main.cpp -Wall"},
+		{
+			name:      "sanitizers",
+			opts:      TestOptions{Dir: dir, Sanitize: "address,undefined"},
+			wantBuild: "g++ main.cpp -Wall -fsanitize=address,undefined -g -fno-omit-frame-pointer",
+		},
+		{name: "invalid sanitizer", opts: TestOptions{Dir: dir, Sanitize: "address; rm -rf"}, wantErrMsg: "invalid sanitizer list"},
+		{name: "not C or C++", opts: TestOptions{Dir: dir, SourceFile: "main.py", Sanitize: "address"}, wantErrMsg: "only supported for C and C++"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			runner := &fakeSolutionRunner{}
+			uc := NewTestUseCase(runner, TestSettings{
+				SourceFile:      "main.cpp",
+				ExtraBuildFlags: " -Wall ",
+				Languages: []LanguageCommand{
+					{Extension: "cpp", BuildCommand: "g++ {file}", RunCommand: "./a.out"},
+					{Extension: "py", RunCommand: "python3 {file}"},
+				},
+			})
+
+			// When
+			_, err := uc.Execute(context.Background(), tt.opts)
+
+			// Then
+			if tt.wantErrMsg != "" {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				assert.Empty(t, runner.builds)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantBuild, runner.builds[0].BuildCommand)
+		})
+	}
+}
+
+func TestCountCompilerWarnings(t *testing.T) {
+	output := "main.cpp: In function 'int main()':\n" +
+		"main.cpp:3:9: warning: unused variable 'x' [-Wunused-variable]\n" +
+		"main.cpp:4:5: warning: comparison of integer expressions [-Wsign-compare]\n"
+
+	assert.Equal(t, 2, CountCompilerWarnings(output))
+	assert.Equal(t, 0, CountCompilerWarnings(""))
+}
@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+const (
+	// judgeCasePrefix is the file name prefix of downloaded judge test cases
+	judgeCasePrefix = "judge-"
+	// defaultLargeDownloadSize is the total size above which a download must be confirmed
+	defaultLargeDownloadSize = 10 << 20
+)
+
+// TestCaseSettings holds the defaults used by the test case use case
+type TestCaseSettings struct {
+	TestDir           string         // directory of test cases inside the problem directory
+	LargeDownloadSize int64          // downloads larger than this in bytes must be confirmed
+	MaxCaseFileSize   int64          // largest input or output file downloaded, in bytes
+	Aliases           ProblemAliases // problem aliases accepted as problem IDs and directory names
+
+	// Problems is the source of the samples restored by Verify, may be nil
+	Problems repository.ProblemRepository
+}
+
+// TestCaseUseCase handles downloading judge test cases
+type TestCaseUseCase struct {
+	testCaseRepo repository.TestCaseRepository
+	problemRepo  repository.ProblemRepository
+	settings     TestCaseSettings
+	logger       *logger.Logger
+}
+
+// NewTestCaseUseCase creates a new TestCaseUseCase
+func NewTestCaseUseCase(testCaseRepo repository.TestCaseRepository, settings TestCaseSettings) *TestCaseUseCase {
+	if settings.TestDir == "" {
+		settings.TestDir = DefaultInitLayout().TestDir
+	}
+	if settings.LargeDownloadSize <= 0 {
+		settings.LargeDownloadSize = defaultLargeDownloadSize
+	}
+	if settings.MaxCaseFileSize <= 0 {
+		settings.MaxCaseFileSize = defaultMaxCaseFileSize
+	}
+
+	return &TestCaseUseCase{
+		testCaseRepo: testCaseRepo,
+		problemRepo:  settings.Problems,
+		settings:     settings,
+		logger:       logger.WithGroup("testcase_usecase"),
+	}
+}
+
+// PullOptions contains options for downloading judge test cases
+type PullOptions struct {
+	Dir       string // Optional: problem directory (defaults to the current directory)
+	ProblemID string // Optional: problem ID (defaults to problem.toml or the directory name)
+	All       bool   // Download every judge test case
+	Cases     []int  // Serial numbers of the cases to download when All is false
+
+	// Confirm is asked before downloads larger than the configured size.
+	// A nil Confirm cancels such downloads
+	Confirm func(count int, size int64) bool
+	// Progress is called after each downloaded case
+	Progress func(done, total int)
+	// BytesProgress is called while downloading with the bytes received so
+	// far and the total announced by the headers
+	BytesProgress func(done, total int64)
+}
+
+// PullResult holds the outcome of a download
+type PullResult struct {
+	ProblemID string   `json:"problem_id"`
+	Files     []string `json:"files"` // input files written, relative to the problem directory
+	Size      int64    `json:"size"`  // total bytes of the downloaded cases
+	// Checksums are the SHA-256 sums of the written input and output files,
+	// keyed by their path relative to the problem directory
+	Checksums map[string]string `json:"checksums"`
+}
+
+// Pull downloads judge test cases into the test directory as judge-<n>.in
+// and judge-<n>.out, where aoj test picks them up next to the samples
+func (uc *TestCaseUseCase) Pull(ctx context.Context, opts PullOptions) (*PullResult, error) {
+	if !opts.All && len(opts.Cases) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"specify the test cases to download or use --all",
+			nil,
+		)
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	problemID, err := resolveProblemID(dir, opts.ProblemID, uc.settings.Aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := uc.testCaseRepo.ListHeaders(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectTestCases(headers, opts.All, opts.Cases)
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	for _, header := range selected {
+		if header.InputSize > uc.settings.MaxCaseFileSize {
+			return nil, caseTooLarge(header.Serial, repository.JudgeCaseInput, uc.settings.MaxCaseFileSize)
+		}
+		if header.OutputSize > uc.settings.MaxCaseFileSize {
+			return nil, caseTooLarge(header.Serial, repository.JudgeCaseOutput, uc.settings.MaxCaseFileSize)
+		}
+		size += header.InputSize + header.OutputSize
+	}
+	if size > uc.settings.LargeDownloadSize && (opts.Confirm == nil || !opts.Confirm(len(selected), size)) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("download of %d test cases (%s) cancelled", len(selected), FormatSize(size)),
+			nil,
+		)
+	}
+
+	testDir := filepath.Join(dir, uc.settings.TestDir)
+	if err := os.MkdirAll(testDir, 0755); err != nil {
+		return nil, cerrors.Wrap(err, "failed to create test directory")
+	}
+
+	uc.logger.InfoContext(ctx, "downloading judge test cases",
+		"problem_id", problemID.String(),
+		"count", len(selected),
+		"size", size)
+
+	result := &PullResult{ProblemID: problemID.String(), Size: size, Checksums: make(map[string]string)}
+	var received int64
+	progress := func(n int64) {
+		received += n
+		if opts.BytesProgress != nil {
+			opts.BytesProgress(received, size)
+		}
+	}
+	stopTiming := uc.logger.Time(ctx, OperationTestCaseDownload, testCaseDownloadHint)
+	err = uc.pullCases(ctx, problemID, dir, selected, result, progress, opts.Progress)
+	stopTiming()
+
+	// Record the checksums of what was written even if a later case failed
+	checksums := config.TestChecksums{}
+	for path, sum := range result.Checksums {
+		checksums[filepath.Base(path)] = sum
+	}
+	if recordErr := recordTestChecksums(testDir, checksums); err == nil {
+		err = recordErr
+	}
+	return result, err
+}
+
+// pullCases downloads the selected judge test cases into the test directory
+// of dir, adding the written files to result
+func (uc *TestCaseUseCase) pullCases(
+	ctx context.Context,
+	problemID model.ProblemID,
+	dir string,
+	selected []repository.TestCaseHeader,
+	result *PullResult,
+	progress func(n int64),
+	caseProgress func(done, total int),
+) error {
+	for i, header := range selected {
+		name := filepath.Join(uc.settings.TestDir, judgeCasePrefix+strconv.Itoa(header.Serial))
+		for _, file := range []struct {
+			part repository.JudgeCasePart
+			path string
+		}{
+			{repository.JudgeCaseInput, name + sampleInputExtension},
+			{repository.JudgeCaseOutput, name + sampleOutputExtension},
+		} {
+			sum, err := uc.downloadJudgeFile(ctx, problemID, header.Serial, file.part, filepath.Join(dir, file.path), progress)
+			if err != nil {
+				return err
+			}
+			result.Checksums[filepath.ToSlash(file.path)] = sum
+		}
+		result.Files = append(result.Files, name+sampleInputExtension)
+
+		if caseProgress != nil {
+			caseProgress(i+1, len(selected))
+		}
+	}
+	return nil
+}
+
+// selectTestCases picks the requested cases from the available ones
+func selectTestCases(headers []repository.TestCaseHeader, all bool, serials []int) ([]repository.TestCaseHeader, error) {
+	if len(headers) == 0 {
+		return nil, cerrors.NewAppError(cerrors.CodeNotFound, "no judge test cases are available", nil)
+	}
+	if all {
+		return headers, nil
+	}
+
+	selected := make([]repository.TestCaseHeader, 0, len(serials))
+	var missing []string
+	for _, serial := range serials {
+		index := slices.IndexFunc(headers, func(h repository.TestCaseHeader) bool { return h.Serial == serial })
+		if index < 0 {
+			missing = append(missing, strconv.Itoa(serial))
+			continue
+		}
+		if !slices.ContainsFunc(selected, func(h repository.TestCaseHeader) bool { return h.Serial == serial }) {
+			selected = append(selected, headers[index])
+		}
+	}
+	if len(missing) > 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("test case %s not found (available: 1-%d)", strings.Join(missing, ", "), headers[len(headers)-1].Serial),
+			nil,
+		)
+	}
+	return selected, nil
+}
+
+// FormatSize formats a byte count for display, e.g. "1.5 MiB"
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KiB", "MiB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f GiB", value)
+}
+
+// TestCaseInfo describes a local test case and its annotations from test/meta.toml
+type TestCaseInfo struct {
+	Number      int           `json:"number"` // as selected with aoj test --case
+	Name        string        `json:"name"`
+	InputSize   int64         `json:"input_size"`
+	Timeout     time.Duration `json:"timeout,omitempty"`
+	Skip        bool          `json:"skip,omitempty"`
+	Description string        `json:"description,omitempty"`
+}
+
+// CaseMetaUpdate holds the annotations to change; nil fields are kept
+type CaseMetaUpdate struct {
+	Timeout     *time.Duration // 0 removes the override
+	Skip        *bool
+	Description *string
+}
+
+// List returns the test cases of a problem directory with their annotations
+func (uc *TestCaseUseCase) List(_ context.Context, dir string) ([]TestCaseInfo, error) {
+	testDir := uc.testDir(dir)
+	inputs, err := listTestInputs(testDir)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := config.LoadTestMeta(testDir)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]TestCaseInfo, 0, len(inputs))
+	for i, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), sampleInputExtension)
+		caseMeta := meta.Case(name)
+		info := TestCaseInfo{
+			Number:      i + 1,
+			Name:        name,
+			Timeout:     caseMeta.TimeoutDuration(),
+			Skip:        caseMeta.Skip,
+			Description: caseMeta.Description,
+		}
+		if stat, err := os.Stat(input); err == nil {
+			info.InputSize = stat.Size()
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Annotate changes the annotations of the test cases given by number or name
+// in test/meta.toml
+func (uc *TestCaseUseCase) Annotate(ctx context.Context, dir string, refs []string, update CaseMetaUpdate) ([]TestCaseInfo, error) {
+	infos, err := uc.List(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	if update.Timeout != nil && *update.Timeout < 0 {
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "timeout must not be negative", nil)
+	}
+
+	selected := make([]TestCaseInfo, 0, len(refs))
+	for _, ref := range refs {
+		info, err := findTestCase(infos, ref)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, info)
+	}
+
+	testDir := uc.testDir(dir)
+	meta, err := config.LoadTestMeta(testDir)
+	if err != nil {
+		return nil, err
+	}
+	for i, info := range selected {
+		caseMeta := meta.Case(info.Name)
+		if update.Timeout != nil {
+			caseMeta.Timeout = update.Timeout.Seconds()
+		}
+		if update.Skip != nil {
+			caseMeta.Skip = *update.Skip
+		}
+		if update.Description != nil {
+			caseMeta.Description = *update.Description
+		}
+		meta.SetCase(info.Name, caseMeta)
+
+		selected[i].Timeout = caseMeta.TimeoutDuration()
+		selected[i].Skip = caseMeta.Skip
+		selected[i].Description = caseMeta.Description
+	}
+
+	if err := config.SaveTestMeta(testDir, meta); err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "updated test case annotations", "cases", refs)
+	return selected, nil
+}
+
+// testDir returns the test directory of a problem directory
+func (uc *TestCaseUseCase) testDir(dir string) string {
+	if dir == "" {
+		dir = "."
+	}
+	return filepath.Join(dir, uc.settings.TestDir)
+}
+
+// findTestCase looks up a test case by its number or name
+func findTestCase(infos []TestCaseInfo, ref string) (TestCaseInfo, error) {
+	for _, info := range infos {
+		if info.Name == ref {
+			return info, nil
+		}
+	}
+	if number, err := strconv.Atoi(ref); err == nil && number >= 1 && number <= len(infos) {
+		return infos[number-1], nil
+	}
+	return TestCaseInfo{}, cerrors.NewAppError(
+		cerrors.CodeNotFound,
+		fmt.Sprintf("test case %s not found. Run 'aoj testcase list' to see the available cases", ref),
+		nil,
+	)
+}
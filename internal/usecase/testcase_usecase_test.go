@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// fakeTestCaseRepository serves three judge test cases and records downloads
+type fakeTestCaseRepository struct {
+	size       int64
+	downloaded []int
+}
+
+func (r *fakeTestCaseRepository) ListHeaders(_ context.Context, _ model.ProblemID) ([]repository.TestCaseHeader, error) {
+	headers := make([]repository.TestCaseHeader, 0, 3)
+	for serial := 1; serial <= 3; serial++ {
+		headers = append(headers, repository.TestCaseHeader{Serial: serial, InputSize: r.size, OutputSize: r.size})
+	}
+	return headers, nil
+}
+
+func (r *fakeTestCaseRepository) GetJudgeCase(_ context.Context, _ model.ProblemID, serial int) (*model.TestCase, error) {
+	r.downloaded = append(r.downloaded, serial)
+	return model.NewTestCase(serial, "in\n", "out\n"), nil
+}
+
+func (r *fakeTestCaseRepository) DownloadJudgeCase(_ context.Context, _ model.ProblemID, serial int, part repository.JudgeCasePart, w io.Writer) (int64, error) {
+	if part == repository.JudgeCaseInput {
+		r.downloaded = append(r.downloaded, serial)
+	}
+	n, err := io.WriteString(w, string(part)+"\n")
+	return int64(n), err
+}
+
+func TestTestCaseUseCase_Pull(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    PullOptions
+		want    []int
+		errCode cerrors.ErrorCode
+	}{
+		{name: "all cases", opts: PullOptions{All: true}, want: []int{1, 2, 3}},
+		{name: "selected cases", opts: PullOptions{Cases: []int{3, 1, 3}}, want: []int{3, 1}},
+		{name: "unknown case", opts: PullOptions{Cases: []int{4}}, errCode: cerrors.CodeNotFound},
+		{name: "nothing selected", opts: PullOptions{}, errCode: cerrors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dir := filepath.Join(t.TempDir(), "ITP1_1_A")
+			repo := &fakeTestCaseRepository{size: 10}
+			uc := NewTestCaseUseCase(repo, TestCaseSettings{})
+			tt.opts.Dir = dir
+
+			// When
+			result, err := uc.Pull(context.Background(), tt.opts)
+
+			// Then
+			if tt.errCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.errCode), "unexpected error: %v", err)
+				assert.Empty(t, repo.downloaded)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "ITP1_1_A", result.ProblemID)
+			assert.Equal(t, tt.want, repo.downloaded)
+			assert.Equal(t, int64(len(tt.want)*20), result.Size)
+			input, err := os.ReadFile(filepath.Join(dir, "test", "judge-1.in"))
+			assert.NoError(t, err)
+			assert.Equal(t, "in\n", string(input))
+			output, err := os.ReadFile(filepath.Join(dir, "test", "judge-1.out"))
+			assert.NoError(t, err)
+			assert.Equal(t, "out\n", string(output))
+		})
+	}
+}
+
+func TestTestCaseUseCase_Pull_LargeDownload(t *testing.T) {
+	tests := []struct {
+		name    string
+		confirm func(count int, size int64) bool
+		want    []int
+	}{
+		{name: "no prompt", confirm: nil},
+		{name: "declined", confirm: func(int, int64) bool { return false }},
+		{name: "confirmed", confirm: func(int, int64) bool { return true }, want: []int{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			repo := &fakeTestCaseRepository{size: 100}
+			uc := NewTestCaseUseCase(repo, TestCaseSettings{LargeDownloadSize: 500})
+			var asked int64
+			confirm := tt.confirm
+			if confirm != nil {
+				confirm = func(count int, size int64) bool {
+					asked = size
+					return tt.confirm(count, size)
+				}
+			}
+
+			// When
+			_, err := uc.Pull(context.Background(), PullOptions{
+				Dir:       t.TempDir(),
+				ProblemID: "ITP1_1_A",
+				All:       true,
+				Confirm:   confirm,
+			})
+
+			// Then
+			assert.Equal(t, tt.want, repo.downloaded)
+			if tt.want == nil {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(600), asked)
+			}
+		})
+	}
+}
+
+func TestTestCaseUseCase_Pull_Checksums(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	uc := NewTestCaseUseCase(&fakeTestCaseRepository{size: 3}, TestCaseSettings{})
+	var received, total int64
+
+	// When
+	result, err := uc.Pull(context.Background(), PullOptions{
+		Dir:           dir,
+		ProblemID:     "ITP1_1_A",
+		Cases:         []int{2},
+		BytesProgress: func(done, size int64) { received, total = done, size },
+	})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+	assert.Equal(t, map[string]string{
+		"test/judge-2.in":  "ab5080369a968a3638a5a5e0df9932a3656766bec904667f72438fd49cd515b0",
+		"test/judge-2.out": "54034ac5c6e9ea95734ec2b729fd6d62abf64af34a9f9ce5d466cb788191a73d",
+	}, result.Checksums)
+	assert.Equal(t, int64(7), received)
+	assert.Equal(t, int64(6), total)
+	entries, err := os.ReadDir(filepath.Join(dir, "test"))
+	assert.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"checksums.sha256", "judge-2.in", "judge-2.out"}, names, "temporary files must not be left behind")
+	recorded, err := config.LoadTestChecksums(filepath.Join(dir, "test"))
+	assert.NoError(t, err)
+	assert.Equal(t, config.TestChecksums{
+		"judge-2.in":  result.Checksums["test/judge-2.in"],
+		"judge-2.out": result.Checksums["test/judge-2.out"],
+	}, recorded)
+}
+
+func TestTestCaseUseCase_Pull_MaxCaseFileSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int64
+		limit  int64
+		called bool
+	}{
+		{name: "announced size above the limit", size: 100, limit: 50, called: false},
+		{name: "received data above the limit", size: 1, limit: 2, called: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dir := t.TempDir()
+			repo := &fakeTestCaseRepository{size: tt.size}
+			uc := NewTestCaseUseCase(repo, TestCaseSettings{MaxCaseFileSize: tt.limit})
+
+			// When
+			_, err := uc.Pull(context.Background(), PullOptions{Dir: dir, ProblemID: "ITP1_1_A", Cases: []int{1}})
+
+			// Then
+			assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "unexpected error: %v", err)
+			assert.Equal(t, tt.called, len(repo.downloaded) > 0)
+			_, statErr := os.Stat(filepath.Join(dir, "test", "judge-1.in"))
+			assert.True(t, os.IsNotExist(statErr), "an oversized case must not be written")
+			entries, _ := os.ReadDir(filepath.Join(dir, "test"))
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", FormatSize(512))
+	assert.Equal(t, "1.5 KiB", FormatSize(1536))
+	assert.Equal(t, "10.0 MiB", FormatSize(10<<20))
+	assert.Equal(t, "2.0 GiB", FormatSize(2<<30))
+}
+
+func TestTestCaseUseCase_Annotate(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1 2\n", "3\n"},
+		"sample-2": {"2 2\n", "4\n"},
+		"judge-10": {"5 5\n", "10\n"},
+	})
+	uc := NewTestCaseUseCase(&fakeTestCaseRepository{}, TestCaseSettings{})
+	ctx := context.Background()
+	skip := true
+	timeout := 5 * time.Second
+	description := "all equal"
+
+	// When
+	_, skipErr := uc.Annotate(ctx, dir, []string{"sample-2", "1"}, CaseMetaUpdate{Skip: &skip})
+	_, setErr := uc.Annotate(ctx, dir, []string{"judge-10"}, CaseMetaUpdate{Timeout: &timeout, Description: &description})
+	_, missingErr := uc.Annotate(ctx, dir, []string{"4"}, CaseMetaUpdate{Skip: &skip})
+	infos, err := uc.List(ctx, dir)
+
+	// Then
+	assert.NoError(t, skipErr)
+	assert.NoError(t, setErr)
+	assert.True(t, cerrors.IsAppError(missingErr, cerrors.CodeNotFound))
+	assert.NoError(t, err)
+	assert.Equal(t, []TestCaseInfo{
+		{Number: 1, Name: "judge-10", InputSize: 4, Timeout: 5 * time.Second, Description: "all equal", Skip: true},
+		{Number: 2, Name: "sample-1", InputSize: 4},
+		{Number: 3, Name: "sample-2", InputSize: 4, Skip: true},
+	}, infos)
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// samplePrefix is the file name prefix of the sample test cases saved by init
+const samplePrefix = "sample-"
+
+// TestFileStatus is the integrity of a downloaded test file
+type TestFileStatus string
+
+const (
+	TestFileOK        TestFileStatus = "ok"        // matches its checksum
+	TestFileCorrupted TestFileStatus = "corrupted" // changed since it was downloaded
+	TestFileMissing   TestFileStatus = "missing"   // deleted since it was downloaded
+	TestFileRestored  TestFileStatus = "restored"  // was damaged and has been downloaded again
+)
+
+// TestFileCheck is the outcome of verifying a single test file
+type TestFileCheck struct {
+	Name   string         `json:"name"` // file name in the test directory
+	Status TestFileStatus `json:"status"`
+	Error  string         `json:"error,omitempty"` // why a damaged file could not be restored
+}
+
+// Damaged returns true if the file is still corrupted or missing
+func (c TestFileCheck) Damaged() bool {
+	return c.Status == TestFileCorrupted || c.Status == TestFileMissing
+}
+
+// VerifyTestDataOptions contains options for verifying downloaded test data
+type VerifyTestDataOptions struct {
+	Dir       string // problem directory, defaults to the current directory
+	ProblemID string // problem ID or alias, inferred from the directory if empty
+	Restore   bool   // download damaged files again
+}
+
+// VerifyTestDataResult holds the outcome of verifying downloaded test data
+type VerifyTestDataResult struct {
+	Files []TestFileCheck `json:"files"`
+}
+
+// DamagedCount returns the number of files that are still corrupted or missing
+func (r *VerifyTestDataResult) DamagedCount() int {
+	damaged := 0
+	for _, file := range r.Files {
+		if file.Damaged() {
+			damaged++
+		}
+	}
+	return damaged
+}
+
+// Verify checks the downloaded samples and judge test cases against the
+// checksums recorded when they were downloaded, and with Restore downloads
+// the damaged ones again
+func (uc *TestCaseUseCase) Verify(ctx context.Context, opts VerifyTestDataOptions) (*VerifyTestDataResult, error) {
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	testDir := uc.testDir(dir)
+	sums, err := config.LoadTestChecksums(testDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(sums) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("no checksums recorded in %s; they are recorded by 'aoj init' and 'aoj testcase pull'", testDir),
+			nil,
+		)
+	}
+
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		baseI, baseJ := strings.TrimSuffix(names[i], filepath.Ext(names[i])), strings.TrimSuffix(names[j], filepath.Ext(names[j]))
+		if baseI != baseJ {
+			return naturalLess(baseI, baseJ)
+		}
+		return names[i] < names[j]
+	})
+
+	result := &VerifyTestDataResult{Files: make([]TestFileCheck, 0, len(names))}
+	damaged := 0
+	for _, name := range names {
+		check := TestFileCheck{Name: name, Status: TestFileOK}
+		sum, err := fileChecksum(filepath.Join(testDir, name))
+		switch {
+		case os.IsNotExist(err):
+			check.Status = TestFileMissing
+		case err != nil || sum != sums[name]:
+			check.Status = TestFileCorrupted
+		}
+		if check.Damaged() {
+			damaged++
+		}
+		result.Files = append(result.Files, check)
+	}
+	if !opts.Restore || damaged == 0 {
+		return result, nil
+	}
+
+	problemID, err := resolveProblemID(dir, opts.ProblemID, uc.settings.Aliases)
+	if err != nil {
+		return result, err
+	}
+	restorer := &testDataRestorer{uc: uc, problemID: problemID, testDir: testDir}
+	for i, check := range result.Files {
+		if !check.Damaged() {
+			continue
+		}
+		sum, err := restorer.restore(ctx, check.Name)
+		if err != nil {
+			result.Files[i].Error = err.Error()
+			continue
+		}
+		sums[check.Name] = sum
+		result.Files[i].Status = TestFileRestored
+	}
+	uc.logger.InfoContext(ctx, "verified test data",
+		"problem_id", problemID.String(),
+		"damaged", damaged,
+		"remaining", result.DamagedCount())
+
+	return result, config.SaveTestChecksums(testDir, sums)
+}
+
+// testDataRestorer downloads damaged test files of a problem again
+type testDataRestorer struct {
+	uc        *TestCaseUseCase
+	problemID model.ProblemID
+	testDir   string
+	samples   []model.TestCase // fetched on first use
+	fetched   bool
+}
+
+// restore downloads a test file again and returns its new checksum
+func (r *testDataRestorer) restore(ctx context.Context, name string) (string, error) {
+	base, ext := strings.TrimSuffix(name, filepath.Ext(name)), filepath.Ext(name)
+	if ext != sampleInputExtension && ext != sampleOutputExtension {
+		return "", fmt.Errorf("%s is not a test case file", name)
+	}
+	path := filepath.Join(r.testDir, name)
+
+	if serial, ok := caseSerial(base, judgeCasePrefix); ok {
+		part := repository.JudgeCaseInput
+		if ext == sampleOutputExtension {
+			part = repository.JudgeCaseOutput
+		}
+		return r.uc.downloadJudgeFile(ctx, r.problemID, serial, part, path, nil)
+	}
+
+	serial, ok := caseSerial(base, samplePrefix)
+	if !ok {
+		return "", fmt.Errorf("%s was not downloaded from AOJ", name)
+	}
+	if !r.fetched {
+		if r.uc.problemRepo == nil {
+			return "", fmt.Errorf("samples cannot be downloaded")
+		}
+		samples, err := r.uc.problemRepo.GetTestCases(ctx, r.problemID)
+		if err != nil {
+			return "", err
+		}
+		r.samples, r.fetched = samples, true
+	}
+	if serial > len(r.samples) {
+		return "", fmt.Errorf("sample %d is no longer available from AOJ", serial)
+	}
+
+	content := r.samples[serial-1].Input()
+	if ext == sampleOutputExtension {
+		content = r.samples[serial-1].Expected()
+	}
+	trace.File("write", path)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", cerrors.Wrap(err, "failed to write "+name)
+	}
+	return dataChecksum([]byte(content)), nil
+}
+
+// caseSerial parses the number of a test case named like "judge-3"
+func caseSerial(base, prefix string) (int, bool) {
+	number, ok := strings.CutPrefix(base, prefix)
+	if !ok {
+		return 0, false
+	}
+	serial, err := strconv.Atoi(number)
+	if err != nil || serial <= 0 {
+		return 0, false
+	}
+	return serial, true
+}
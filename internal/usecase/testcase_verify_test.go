@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// writeVerifiedSamples writes test data as downloaded, with its checksums
+func writeVerifiedSamples(t *testing.T, dir string) string {
+	t.Helper()
+	writeSamples(t, dir, map[string][2]string{
+		"sample-1": {"1\n", "2\n"},
+		"sample-2": {"3\n", "4\n"},
+		"judge-2":  {"in\n", "out\n"},
+	})
+	testDir := filepath.Join(dir, "test")
+	sums := config.TestChecksums{}
+	for _, name := range []string{"sample-1.in", "sample-1.out", "sample-2.in", "sample-2.out", "judge-2.in", "judge-2.out"} {
+		sum, err := fileChecksum(filepath.Join(testDir, name))
+		if err != nil {
+			t.Fatalf("fileChecksum() error = %v", err)
+		}
+		sums[name] = sum
+	}
+	if err := config.SaveTestChecksums(testDir, sums); err != nil {
+		t.Fatalf("SaveTestChecksums() error = %v", err)
+	}
+	return testDir
+}
+
+func TestTestCaseUseCase_Verify(t *testing.T) {
+	tests := []struct {
+		name    string
+		restore bool
+		want    []TestFileCheck
+	}{
+		{
+			name: "report only",
+			want: []TestFileCheck{
+				{Name: "judge-2.in", Status: TestFileMissing},
+				{Name: "judge-2.out", Status: TestFileOK},
+				{Name: "sample-1.in", Status: TestFileOK},
+				{Name: "sample-1.out", Status: TestFileCorrupted},
+				{Name: "sample-2.in", Status: TestFileOK},
+				{Name: "sample-2.out", Status: TestFileOK},
+			},
+		},
+		{
+			name:    "restore",
+			restore: true,
+			want: []TestFileCheck{
+				{Name: "judge-2.in", Status: TestFileRestored},
+				{Name: "judge-2.out", Status: TestFileOK},
+				{Name: "sample-1.in", Status: TestFileOK},
+				{Name: "sample-1.out", Status: TestFileRestored},
+				{Name: "sample-2.in", Status: TestFileOK},
+				{Name: "sample-2.out", Status: TestFileOK},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dir := filepath.Join(t.TempDir(), "ITP1_1_A")
+			testDir := writeVerifiedSamples(t, dir)
+			assert.NoError(t, os.WriteFile(filepath.Join(testDir, "sample-1.out"), []byte("2"), 0644))
+			assert.NoError(t, os.Remove(filepath.Join(testDir, "judge-2.in")))
+			repo := &fakeTestCaseRepository{}
+			uc := NewTestCaseUseCase(repo, TestCaseSettings{Problems: &sampleProblemRepository{}})
+
+			// When
+			result, err := uc.Verify(context.Background(), VerifyTestDataOptions{Dir: dir, Restore: tt.restore})
+
+			// Then
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			assert.Equal(t, tt.want, result.Files)
+			if !tt.restore {
+				assert.Equal(t, 2, result.DamagedCount())
+				assert.Empty(t, repo.downloaded)
+				return
+			}
+			assert.Zero(t, result.DamagedCount())
+			assert.Equal(t, []int{2}, repo.downloaded)
+			output, err := os.ReadFile(filepath.Join(testDir, "sample-1.out"))
+			assert.NoError(t, err)
+			assert.Equal(t, "2\n", string(output))
+			again, err := uc.Verify(context.Background(), VerifyTestDataOptions{Dir: dir})
+			assert.NoError(t, err)
+			assert.Zero(t, again.DamagedCount())
+		})
+	}
+}
+
+func TestTestCaseUseCase_Verify_RestoreFails(t *testing.T) {
+	// Given
+	dir := filepath.Join(t.TempDir(), "ITP1_1_A")
+	testDir := writeVerifiedSamples(t, dir)
+	assert.NoError(t, os.WriteFile(filepath.Join(testDir, "sample-2.in"), nil, 0644))
+	uc := NewTestCaseUseCase(&fakeTestCaseRepository{}, TestCaseSettings{})
+
+	// When
+	result, err := uc.Verify(context.Background(), VerifyTestDataOptions{Dir: dir, Restore: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.DamagedCount())
+	assert.Equal(t, TestFileCorrupted, result.Files[4].Status)
+	assert.NotEmpty(t, result.Files[4].Error)
+}
+
+func TestTestCaseUseCase_Verify_NoChecksums(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1\n", "2\n"}})
+	uc := NewTestCaseUseCase(&fakeTestCaseRepository{}, TestCaseSettings{})
+
+	// When
+	_, err := uc.Verify(context.Background(), VerifyTestDataOptions{Dir: dir})
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "unexpected error: %v", err)
+}
+
+func TestTestUseCase_Execute_CorruptedTestFiles(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	testDir := writeVerifiedSamples(t, dir)
+	assert.NoError(t, os.WriteFile(filepath.Join(testDir, "judge-2.out"), []byte("changed\n"), 0644))
+	uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"judge-2.out"}, report.Corrupted)
+}
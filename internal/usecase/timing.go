@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+)
+
+// Operations timed for slow-operation hints. Their thresholds are configured
+// in [log.slow_thresholds]
+const (
+	OperationSampleDownload   = "sample_download"
+	OperationTestCaseDownload = "testcase_download"
+	OperationBuild            = "build"
+	OperationHistorySync      = "history_sync"
+)
+
+// Hints shown when an operation is slow
+const (
+	sampleDownloadHint = "downloaded samples are cached under ~/.aoj-cli/cache; " +
+		"pass --offline to reuse them without contacting AOJ"
+	testCaseDownloadHint = "judge cases are fetched one at a time to spare AOJ; " +
+		"pull only the cases you need, e.g. 'aoj testcase pull 3'"
+	buildHint = "builds are cached while the source and flags are unchanged; " +
+		"heavy headers such as bits/stdc++.h make the first build slow"
+	historySyncHint = "without --full, syncing stops at the first page with a known submission; " +
+		"--no-source skips downloading source code"
+)
+
+// build compiles a solution, timing the compilation for slow-operation hints
+func (uc *TestUseCase) build(ctx context.Context, spec service.RunSpec) (*service.BuildResult, error) {
+	defer uc.logger.Time(ctx, OperationBuild, buildHint)()
+	return uc.runner.Build(ctx, spec)
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import "time"
+
+// defaultTLEWarnRatio is the fraction of the time limit above which a likely TLE is reported
+const defaultTLEWarnRatio = 0.8
+
+// TLEWarning reports a test case that passed locally but whose estimated
+// running time on the judge comes close to or above the time limit
+type TLEWarning struct {
+	Case      string        `json:"case"`
+	Local     time.Duration `json:"local"`     // measured running time on this machine
+	Estimated time.Duration `json:"estimated"` // local time scaled by the speed factor
+	Ratio     float64       `json:"ratio"`     // estimated time divided by the time limit
+}
+
+// EstimateJudgeTime scales a running time measured on this machine to the judge
+func (uc *TestUseCase) EstimateJudgeTime(local time.Duration) time.Duration {
+	return time.Duration(float64(local) / uc.settings.SpeedFactor)
+}
+
+// tleWarnings returns the cases that did not time out locally but whose
+// estimated judge time exceeds the warning ratio of the time limit. Nothing is
+// reported when the time limit of the problem is unknown
+func (uc *TestUseCase) tleWarnings(cases []CaseResult, timeLimit time.Duration) []TLEWarning {
+	if timeLimit <= 0 {
+		return nil
+	}
+
+	var warnings []TLEWarning
+	for _, c := range cases {
+		if c.Verdict == VerdictTimeLimitExceeded || c.Verdict == VerdictSkipped {
+			continue
+		}
+		estimated := uc.EstimateJudgeTime(c.Duration)
+		ratio := float64(estimated) / float64(timeLimit)
+		if ratio >= uc.settings.TLEWarnRatio {
+			warnings = append(warnings, TLEWarning{Case: c.Name, Local: c.Duration, Estimated: estimated, Ratio: ratio})
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/service"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/config"
+)
+
+// timedRunner is a fakeSolutionRunner whose runs take the given time
+type timedRunner struct {
+	fakeSolutionRunner
+	duration time.Duration
+}
+
+func (r *timedRunner) Run(ctx context.Context, spec service.RunSpec, input string, timeout time.Duration) (*service.RunResult, error) {
+	result, err := r.fakeSolutionRunner.Run(ctx, spec, input, timeout)
+	if result != nil {
+		result.Duration = r.duration
+	}
+	return result, err
+}
+
+func TestTestUseCase_tleWarnings(t *testing.T) {
+	cases := []CaseResult{
+		{Name: "sample-1", Verdict: VerdictAccepted, Duration: 100 * time.Millisecond},
+		{Name: "sample-2", Verdict: VerdictAccepted, Duration: 900 * time.Millisecond},
+		{Name: "sample-3", Verdict: VerdictWrongAnswer, Duration: 1200 * time.Millisecond},
+		{Name: "sample-4", Verdict: VerdictTimeLimitExceeded, Duration: 1500 * time.Millisecond},
+		{Name: "sample-5", Verdict: VerdictSkipped},
+	}
+
+	tests := []struct {
+		name        string
+		speedFactor float64
+		timeLimit   time.Duration
+		want        []string
+	}{
+		{name: "same speed as the judge", speedFactor: 1, timeLimit: time.Second, want: []string{"sample-2", "sample-3"}},
+		{name: "twice as slow as the judge", speedFactor: 2, timeLimit: time.Second, want: nil},
+		{name: "ten times faster than the judge", speedFactor: 0.1, timeLimit: time.Second, want: []string{"sample-1", "sample-2", "sample-3"}},
+		{name: "unknown time limit", speedFactor: 1, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			uc := NewTestUseCase(&fakeSolutionRunner{}, TestSettings{SpeedFactor: tt.speedFactor})
+
+			// When
+			warnings := uc.tleWarnings(cases, tt.timeLimit)
+
+			// Then
+			var names []string
+			for _, warning := range warnings {
+				names = append(names, warning.Case)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestTestUseCase_Execute_WarnsOfLikelyTLE(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writeSamples(t, dir, map[string][2]string{"sample-1": {"1 2\n0 0\n", "3\n"}})
+	assert.NoError(t, config.SaveProblemConfig(dir, &config.ProblemConfig{ProblemID: "ITP1_1_A", TimeLimit: 1}))
+	uc := NewTestUseCase(&timedRunner{duration: 1800 * time.Millisecond}, TestSettings{
+		SourceFile:      "main.cpp",
+		TimeLimitFactor: 3,
+		SpeedFactor:     2,
+		TLEWarnRatio:    0.85,
+		Languages: []LanguageCommand{
+			{Extension: "cpp", BuildCommand: "g++ {file}", RunCommand: "./a.out"},
+		},
+	})
+
+	// When
+	report, err := uc.Execute(context.Background(), TestOptions{Dir: dir})
+
+	// Then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, VerdictAccepted, report.Verdict)
+	assert.Equal(t, time.Second, report.TimeLimit)
+	assert.Equal(t, []TLEWarning{{
+		Case:      "sample-1",
+		Local:     1800 * time.Millisecond,
+		Estimated: 900 * time.Millisecond,
+		Ratio:     0.9,
+	}}, report.TLEWarnings)
+}
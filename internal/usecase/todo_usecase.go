@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// TodoUseCase manages the list of problems to practice
+type TodoUseCase struct {
+	todoRepo repository.TodoRepository
+	clock    clock.Clock
+	aliases  ProblemAliases
+	logger   *logger.Logger
+}
+
+// TodoSettings holds the optional settings of the todo use case
+type TodoSettings struct {
+	Clock   clock.Clock    // tells when items are added and done; defaults to the system clock
+	Aliases ProblemAliases // problem aliases accepted as problem IDs
+}
+
+// NewTodoUseCase creates a new TodoUseCase
+func NewTodoUseCase(todoRepo repository.TodoRepository, settings TodoSettings) *TodoUseCase {
+	if settings.Clock == nil {
+		settings.Clock = clock.System()
+	}
+
+	return &TodoUseCase{
+		todoRepo: todoRepo,
+		clock:    settings.Clock,
+		aliases:  settings.Aliases,
+		logger:   logger.WithGroup("todo_usecase"),
+	}
+}
+
+// TodoAddOptions contains options for adding a problem to the list
+type TodoAddOptions struct {
+	Note  string        // Optional: reminder shown in the list
+	Delay time.Duration // Optional: do not suggest the problem before this much time has passed
+}
+
+// Add puts a problem on the list. A done problem is added again
+func (uc *TodoUseCase) Add(ctx context.Context, problemID string, opts TodoAddOptions) (*repository.TodoItem, error) {
+	id, err := uc.aliases.ParseProblemID(problemID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.todoRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	item := repository.TodoItem{ProblemID: id.String(), Note: opts.Note, AddedAt: now}
+	if opts.Delay > 0 {
+		due := now.Add(opts.Delay)
+		item.Due = &due
+	}
+
+	index := findTodo(items, id.String())
+	switch {
+	case index < 0:
+		items = append(items, item)
+	case !items[index].IsDone():
+		return nil, cerrors.NewAppError(
+			cerrors.CodeConflict,
+			id.String()+" is already on the TODO list",
+			nil,
+		)
+	default:
+		item.Reviews = items[index].Reviews
+		items = append(append(items[:index:index], items[index+1:]...), item)
+	}
+
+	if err := uc.todoRepo.SaveAll(ctx, items); err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "added TODO", "problem_id", item.ProblemID)
+	return &item, nil
+}
+
+// List returns the pending items, the ones due first, followed by the done
+// items if all is set
+func (uc *TodoUseCase) List(ctx context.Context, all bool) ([]repository.TodoItem, error) {
+	items, err := uc.todoRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make([]repository.TodoItem, 0, len(items))
+	for _, item := range items {
+		if all || !item.IsDone() {
+			listed = append(listed, item)
+		}
+	}
+	sort.SliceStable(listed, func(i, j int) bool {
+		a, b := listed[i], listed[j]
+		if a.IsDone() != b.IsDone() {
+			return !a.IsDone()
+		}
+		if a.IsDone() {
+			return a.DoneAt.Before(*b.DoneAt)
+		}
+		return todoDue(a).Before(todoDue(b))
+	})
+	return listed, nil
+}
+
+// Next returns the first pending item that is due
+func (uc *TodoUseCase) Next(ctx context.Context) (*repository.TodoItem, error) {
+	items, err := uc.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, cerrors.NewAppError(cerrors.CodeNotFound, "the TODO list is empty. Add problems with 'aoj todo add'", nil)
+	}
+	if !items[0].IsDueAt(uc.clock.Now()) {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			fmt.Sprintf("nothing is due yet; %s is due on %s", items[0].ProblemID, items[0].Due.Format("2006-01-02 15:04")),
+			nil,
+		)
+	}
+	return &items[0], nil
+}
+
+// Done marks a pending problem as done. With a positive again, the problem
+// stays on the list and is due for review after that much time
+func (uc *TodoUseCase) Done(ctx context.Context, problemID string, again time.Duration) (*repository.TodoItem, error) {
+	id, err := uc.aliases.ParseProblemID(problemID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.todoRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := findTodo(items, id.String())
+	if index < 0 || items[index].IsDone() {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			id.String()+" is not on the TODO list",
+			nil,
+		)
+	}
+
+	now := uc.clock.Now()
+	item := &items[index]
+	if again > 0 {
+		due := now.Add(again)
+		item.Due = &due
+		item.Reviews++
+	} else {
+		item.DoneAt = &now
+	}
+
+	if err := uc.todoRepo.SaveAll(ctx, items); err != nil {
+		return nil, err
+	}
+	uc.logger.InfoContext(ctx, "completed TODO", "problem_id", item.ProblemID, "review", again > 0)
+	return item, nil
+}
+
+// completeTodo marks the problem's pending item done at now, e.g. after an
+// accepted submission. It reports whether the problem was on the list
+func completeTodo(ctx context.Context, todoRepo repository.TodoRepository, problemID model.ProblemID, now time.Time) (bool, error) {
+	items, err := todoRepo.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	index := findTodo(items, problemID.String())
+	if index < 0 || items[index].IsDone() {
+		return false, nil
+	}
+
+	items[index].DoneAt = &now
+	if err := todoRepo.SaveAll(ctx, items); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findTodo returns the index of the problem's item, or -1
+func findTodo(items []repository.TodoItem, problemID string) int {
+	for i, item := range items {
+		if item.ProblemID == problemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// todoDue returns when the item becomes due
+func todoDue(item repository.TodoItem) time.Time {
+	if item.Due != nil {
+		return *item.Due
+	}
+	return item.AddedAt
+}
+
+// ParseInterval parses a delay such as 3d, 2w or a Go duration like 12h
+func ParseInterval(s string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			if count, err := strconv.Atoi(n); err == nil && count >= 0 {
+				return time.Duration(count) * unit, nil
+			}
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		fmt.Sprintf("invalid interval '%s'. Use e.g. 3d, 2w or 12h", s),
+		nil,
+	)
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// memoryTodoRepository keeps the TODO list in memory
+type memoryTodoRepository struct {
+	items []repository.TodoItem
+}
+
+func (r *memoryTodoRepository) List(_ context.Context) ([]repository.TodoItem, error) {
+	return append([]repository.TodoItem(nil), r.items...), nil
+}
+
+func (r *memoryTodoRepository) SaveAll(_ context.Context, items []repository.TodoItem) error {
+	r.items = append([]repository.TodoItem(nil), items...)
+	return nil
+}
+
+func todoIDs(items []repository.TodoItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProblemID)
+	}
+	return ids
+}
+
+func TestTodoUseCase_AddListNext(t *testing.T) {
+	// Given
+	clk := clock.NewFake(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC))
+	uc := NewTodoUseCase(&memoryTodoRepository{}, TodoSettings{Clock: clk})
+	ctx := context.Background()
+
+	// When
+	_, errA := uc.Add(ctx, "ITP1_1_A", TodoAddOptions{Delay: 48 * time.Hour})
+	clk.Advance(time.Minute)
+	_, errB := uc.Add(ctx, "ITP1_1_B", TodoAddOptions{Note: "retry with DP"})
+	_, errDup := uc.Add(ctx, "ITP1_1_B", TodoAddOptions{})
+	_, errInvalid := uc.Add(ctx, "not a problem", TodoAddOptions{})
+
+	// Then
+	assert.NoError(t, errA)
+	assert.NoError(t, errB)
+	assert.True(t, cerrors.IsAppError(errDup, cerrors.CodeConflict), "got %v", errDup)
+	assert.Error(t, errInvalid)
+
+	items, err := uc.List(ctx, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ITP1_1_B", "ITP1_1_A"}, todoIDs(items))
+	assert.Equal(t, "retry with DP", items[0].Note)
+
+	next, err := uc.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ITP1_1_B", next.ProblemID)
+
+	_, err = uc.Done(ctx, "ITP1_1_B", 0)
+	assert.NoError(t, err)
+	_, err = uc.Next(ctx)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "ITP1_1_A is not due yet, got %v", err)
+
+	clk.Advance(48 * time.Hour)
+	next, err = uc.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ITP1_1_A", next.ProblemID)
+
+	all, err := uc.List(ctx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ITP1_1_A", "ITP1_1_B"}, todoIDs(all))
+	assert.True(t, all[1].IsDone())
+}
+
+func TestTodoUseCase_Next_Empty(t *testing.T) {
+	uc := NewTodoUseCase(&memoryTodoRepository{}, TodoSettings{})
+
+	_, err := uc.Next(context.Background())
+
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
+
+func TestTodoUseCase_Done(t *testing.T) {
+	// Given
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	repo := &memoryTodoRepository{}
+	uc := NewTodoUseCase(repo, TodoSettings{Clock: clk})
+	ctx := context.Background()
+	_, err := uc.Add(ctx, "ALDS1_1_A", TodoAddOptions{})
+	assert.NoError(t, err)
+
+	// When
+	reviewed, errAgain := uc.Done(ctx, "ALDS1_1_A", 7*24*time.Hour)
+	done, errDone := uc.Done(ctx, "ALDS1_1_A", 0)
+	_, errMissing := uc.Done(ctx, "ALDS1_1_A", 0)
+
+	// Then
+	assert.NoError(t, errAgain)
+	assert.False(t, reviewed.IsDone())
+	assert.Equal(t, start.Add(7*24*time.Hour), *reviewed.Due)
+	assert.Equal(t, 1, reviewed.Reviews)
+	assert.NoError(t, errDone)
+	assert.True(t, done.IsDone())
+	assert.True(t, cerrors.IsAppError(errMissing, cerrors.CodeNotFound), "got %v", errMissing)
+
+	// Adding a done problem again keeps its review count
+	readded, err := uc.Add(ctx, "ALDS1_1_A", TodoAddOptions{})
+	assert.NoError(t, err)
+	assert.False(t, readded.IsDone())
+	assert.Equal(t, 1, readded.Reviews)
+	assert.Len(t, repo.items, 1)
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "3d", want: 3 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "-1d", wantErr: true},
+		{input: "soon", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseInterval(tt.input)
+
+			if tt.wantErr {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubmitUseCase_Execute_CompletesTodoOnAC(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   entity.SubmissionStatus
+		wantDone bool
+	}{
+		{name: "accepted", status: entity.StatusAccepted, wantDone: true},
+		{name: "rejected", status: entity.StatusWrongAnswer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			sourcePath := filepath.Join(t.TempDir(), "main.cpp")
+			assert.NoError(t, os.WriteFile(sourcePath, []byte("int main() {}"), 0644))
+
+			session := entity.NewSessionWithDuration(model.MustGenerateSessionID(), "user1", "token1", time.Hour)
+			sessionRepo := &MockSessionRepository{}
+			sessionRepo.On("GetCurrent", mock.Anything).Return(session, nil)
+			submissionRepo := &MockSubmissionRepository{}
+			submissionRepo.On("Submit", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.Submission).UpdateResult(tt.status, 100, 10*time.Millisecond, 1024, "")
+				}).
+				Return(nil)
+			submissionRepo.On("GetJudgeDetail", mock.Anything, mock.Anything).Return(repository.JudgeDetail{}, nil)
+
+			todos := &memoryTodoRepository{items: []repository.TodoItem{
+				{ProblemID: "ITP1_1_A", AddedAt: time.Now()},
+				{ProblemID: "ITP1_1_B", AddedAt: time.Now()},
+			}}
+			uc := NewSubmitUseCase(submissionRepo, sessionRepo,
+				&stubLanguageRepository{languages: []string{"C++17"}},
+				SubmitSettings{Language: "C++17", Todos: todos})
+
+			// When
+			_, err := uc.Execute(context.Background(), SubmitOptions{ProblemID: "ITP1_1_A", FilePath: sourcePath})
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDone, todos.items[0].IsDone())
+			assert.False(t, todos.items[1].IsDone())
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// VerdictError marks a problem that could not be tested by verify, e.g.
+// because its source file is missing
+const VerdictError = "ERROR"
+
+// recursivePattern is the suffix of a verify pattern that also searches subdirectories
+const recursivePattern = "/..."
+
+// VerifyOptions contains options for verifying a solutions repository
+type VerifyOptions struct {
+	// Patterns select problem directories like Go package patterns: "dir"
+	// is a single directory and "dir/..." also searches its subdirectories.
+	// Empty means "./..."
+	Patterns []string
+	Progress func(VerifyResult) // Optional: called after each problem
+}
+
+// VerifyResult is the outcome of testing one problem directory
+type VerifyResult struct {
+	Dir    string      `json:"dir"`
+	Report *TestReport `json:"report"`
+}
+
+// Passed returns true if all test cases of the problem were accepted
+func (r VerifyResult) Passed() bool {
+	return r.Report.Verdict == VerdictAccepted
+}
+
+// VerifyReport holds the results of all verified problems
+type VerifyReport struct {
+	Results     []VerifyResult `json:"results"`
+	Duration    time.Duration  `json:"duration"`
+	Interrupted bool           `json:"interrupted,omitempty"`
+}
+
+// PassedCount returns the number of problems whose samples all passed
+func (r *VerifyReport) PassedCount() int {
+	passed := 0
+	for _, result := range r.Results {
+		if result.Passed() {
+			passed++
+		}
+	}
+	return passed
+}
+
+// Err returns nil if every problem passed and otherwise a *cerrors.MultiError
+// with the reason of each failed problem
+func (r *VerifyReport) Err() error {
+	failed := cerrors.NewMultiError("problems")
+	for _, result := range r.Results {
+		if result.Passed() {
+			continue
+		}
+		report := result.Report
+		if report.Verdict == VerdictError {
+			failed.Add(result.Dir, cerrors.New(report.Error))
+			continue
+		}
+		failed.Add(result.Dir, cerrors.Errorf("%s, %d/%d cases passed", report.Verdict, report.PassedCount(), len(report.Cases)))
+	}
+	return failed.Err()
+}
+
+// Reports returns the test report of every problem
+func (r *VerifyReport) Reports() []*TestReport {
+	reports := make([]*TestReport, 0, len(r.Results))
+	for _, result := range r.Results {
+		reports = append(reports, result.Report)
+	}
+	return reports
+}
+
+// Verify builds and tests every problem directory matched by the patterns
+// against its cached samples. A problem that cannot be tested is reported
+// with VerdictError instead of stopping the run
+func (uc *TestUseCase) Verify(ctx context.Context, opts VerifyOptions) (*VerifyReport, error) {
+	dirs, err := uc.findProblemDirs(opts.Patterns)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeNotFound,
+			"no problem directories with sample test cases found",
+			nil,
+		)
+	}
+	uc.logger.InfoContext(ctx, "verifying problems", "count", len(dirs))
+
+	report := &VerifyReport{}
+	start := time.Now()
+	for _, dir := range dirs {
+		testReport, err := uc.Execute(ctx, TestOptions{Dir: dir})
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			break
+		}
+		if err != nil {
+			testReport = &TestReport{Problem: uc.problemName(dir), Verdict: VerdictError, Error: err.Error()}
+		}
+
+		result := VerifyResult{Dir: dir, Report: testReport}
+		report.Results = append(report.Results, result)
+		if opts.Progress != nil {
+			opts.Progress(result)
+		}
+	}
+	report.Duration = time.Since(start)
+
+	return report, nil
+}
+
+// findProblemDirs returns the directories matched by the patterns that hold
+// sample test cases, in lexical order. Hidden directories such as .git are
+// not searched, nor are the subdirectories of a problem
+func (uc *TestUseCase) findProblemDirs(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"." + recursivePattern}
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	add := func(dir string) {
+		dir = filepath.Clean(dir)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, pattern := range patterns {
+		pattern = filepath.ToSlash(pattern)
+		root, recursive := strings.CutSuffix(pattern, recursivePattern)
+		if pattern == "..." {
+			root, recursive = ".", true
+		}
+		if root == "" {
+			root = "."
+		}
+		root = filepath.FromSlash(root)
+
+		info, err := os.Stat(root)
+		if err != nil || !info.IsDir() {
+			return nil, cerrors.NewAppError(cerrors.CodeNotFound, "directory "+root+" not found", err)
+		}
+		if !recursive {
+			if uc.hasSamples(root) {
+				add(root)
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() {
+				return nil
+			}
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if uc.hasSamples(path) {
+				add(path)
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to search problem directories")
+		}
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// hasSamples reports whether dir is a problem directory with sample test cases
+func (uc *TestUseCase) hasSamples(dir string) bool {
+	inputs, err := listTestInputs(filepath.Join(dir, uc.settings.TestDir))
+	return err == nil && len(inputs) > 0
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestTestUseCase_Verify(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	passing := filepath.Join(root, "ITP1", "ITP1_1_A")
+	failing := filepath.Join(root, "ITP1", "ITP1_1_B")
+	noSource := filepath.Join(root, "ALDS1_1_A")
+	writeSamples(t, passing, map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+	writeSamples(t, failing, map[string][2]string{"sample-1": {"1 2\n", "4\n"}})
+	writeSamples(t, noSource, map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+	assert.NoError(t, os.Remove(filepath.Join(noSource, "main.cpp")))
+	writeSamples(t, filepath.Join(root, ".git", "hidden"), map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "notes"), 0755))
+
+	var progress []string
+	uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+	// When
+	report, err := uc.Verify(context.Background(), VerifyOptions{
+		Patterns: []string{root + "/..."},
+		Progress: func(result VerifyResult) { progress = append(progress, result.Dir) },
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{noSource, passing, failing}, progress)
+	if len(report.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(report.Results))
+	}
+	assert.Equal(t, VerdictError, report.Results[0].Report.Verdict)
+	assert.Equal(t, "ALDS1_1_A", report.Results[0].Report.Problem)
+	assert.Contains(t, report.Results[0].Report.Error, "main.cpp not found")
+	assert.Equal(t, VerdictAccepted, report.Results[1].Report.Verdict)
+	assert.Equal(t, "ITP1_1_A", report.Results[1].Report.Problem)
+	assert.Equal(t, VerdictWrongAnswer, report.Results[2].Report.Verdict)
+	assert.Equal(t, 1, report.PassedCount())
+	assert.Len(t, report.Reports(), 3)
+
+	var failed *cerrors.MultiError
+	if !cerrors.As(report.Err(), &failed) {
+		t.Fatalf("expected a MultiError, got %v", report.Err())
+	}
+	assert.Equal(t, 2, failed.Len())
+	assert.Equal(t, failing, failed.Errors()[1].Item)
+	assert.Contains(t, failed.Errors()[1].Error(), "WA, 0/1 cases passed")
+}
+
+func TestTestUseCase_Verify_Patterns(t *testing.T) {
+	root := t.TempDir()
+	problem := filepath.Join(root, "volume", "ITP1_1_A")
+	writeSamples(t, problem, map[string][2]string{"sample-1": {"1 2\n", "3\n"}})
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantCode cerrors.ErrorCode
+	}{
+		{name: "single directory", patterns: []string{problem}, want: []string{problem}},
+		{name: "recursive", patterns: []string{filepath.Join(root, "volume") + "/..."}, want: []string{problem}},
+		{name: "duplicates", patterns: []string{problem, root + "/..."}, want: []string{problem}},
+		{name: "not a problem directory", patterns: []string{root}, wantCode: cerrors.CodeNotFound},
+		{name: "missing directory", patterns: []string{filepath.Join(root, "missing") + "/..."}, wantCode: cerrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			uc := newTestTestUseCase(&fakeSolutionRunner{})
+
+			// When
+			report, err := uc.Verify(context.Background(), VerifyOptions{Patterns: tt.patterns})
+
+			// Then
+			if tt.wantCode != "" {
+				assert.True(t, cerrors.IsAppError(err, tt.wantCode), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			var dirs []string
+			for _, result := range report.Results {
+				dirs = append(dirs, result.Dir)
+			}
+			assert.Equal(t, tt.want, dirs)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package usecase implements application business logic.
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// WorkspaceUseCase handles operations on the workspace root where problem directories live
+type WorkspaceUseCase struct {
+	root           string
+	submissionRepo repository.SubmissionRepository
+	aliases        ProblemAliases
+	tagRepo        repository.TagRepository
+	resultRepo     repository.LocalResultRepository
+	difficulty     *DifficultyUseCase
+	logger         *logger.Logger
+}
+
+// WorkspaceSettings holds the optional sources of what List shows besides the directories
+type WorkspaceSettings struct {
+	Aliases    ProblemAliases                   // names of problem directories besides problem IDs
+	Tags       repository.TagRepository         // tags of the problems
+	Results    repository.LocalResultRepository // last local test outcomes of the problems
+	Difficulty *DifficultyUseCase               // estimated difficulties of the problems
+}
+
+// NewWorkspaceUseCase creates a new WorkspaceUseCase. An empty root means the current directory
+func NewWorkspaceUseCase(root string, submissionRepo repository.SubmissionRepository, settings WorkspaceSettings) *WorkspaceUseCase {
+	return &WorkspaceUseCase{
+		root:           root,
+		submissionRepo: submissionRepo,
+		aliases:        settings.Aliases,
+		tagRepo:        settings.Tags,
+		resultRepo:     settings.Results,
+		difficulty:     settings.Difficulty,
+		logger:         logger.WithGroup("workspace_usecase"),
+	}
+}
+
+// Root returns the workspace root directory
+func (uc *WorkspaceUseCase) Root() string {
+	if uc.root == "" {
+		return "."
+	}
+	return uc.root
+}
+
+// ProblemEntry describes an initialized problem directory in the workspace
+type ProblemEntry struct {
+	ProblemID     string     `json:"problem_id"`
+	Dir           string     `json:"dir"`
+	LocalVerdict  string     `json:"local_verdict,omitempty"` // of the last complete aoj test run
+	TestedAt      *time.Time `json:"tested_at,omitempty"`
+	RemoteVerdict string     `json:"remote_verdict,omitempty"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+	Notes         string     `json:"notes,omitempty"` // first line of notes.md
+	Tags          []string   `json:"tags,omitempty"`
+	Difficulty    *float64   `json:"difficulty,omitempty"` // estimated; nil when unknown
+}
+
+// List returns the problems initialized in the workspace with their last
+// local and remote verdicts, notes, tags and difficulty. With tags, only the
+// problems having every one of them are listed
+func (uc *WorkspaceUseCase) List(ctx context.Context, tags ...string) ([]ProblemEntry, error) {
+	root := uc.Root()
+	uc.logger.DebugContext(ctx, "listing workspace problems", "root", root, "tags", tags)
+
+	filter, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	problemTags := map[string][]string{}
+	if uc.tagRepo != nil {
+		if problemTags, err = uc.tagRepo.List(ctx); err != nil {
+			return nil, err
+		}
+	}
+	localResults := map[string]repository.LocalResult{}
+	if uc.resultRepo != nil {
+		if localResults, err = uc.resultRepo.List(ctx); err != nil {
+			uc.logger.WarnContext(ctx, "failed to read local test results", "error", err)
+		}
+	}
+	difficulties := uc.difficulty.Lookup(ctx)
+
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return []ProblemEntry{}, nil
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read workspace directory")
+	}
+
+	problems := make([]ProblemEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		problemID, err := uc.aliases.ParseProblemID(entry.Name())
+		if err != nil {
+			continue
+		}
+		if !hasAllTags(problemTags[problemID.String()], filter) {
+			continue
+		}
+
+		problem := ProblemEntry{
+			ProblemID: problemID.String(),
+			Dir:       filepath.Join(root, entry.Name()),
+			Tags:      problemTags[problemID.String()],
+		}
+		problem.Notes = notesSummary(problem.Dir)
+		if difficulty, ok := difficulties[problem.ProblemID]; ok {
+			problem.Difficulty = &difficulty
+		}
+		if result, ok := localResults[problem.ProblemID]; ok {
+			testedAt := result.TestedAt
+			problem.LocalVerdict = result.Verdict
+			problem.TestedAt = &testedAt
+		}
+
+		submissions, err := uc.submissionRepo.GetByProblemID(ctx, problemID, 1)
+		if err != nil {
+			uc.logger.WarnContext(ctx, "failed to read submission history",
+				"problem_id", problemID.String(),
+				"error", err)
+		} else if len(submissions) > 0 {
+			submittedAt := submissions[0].SubmittedAt()
+			problem.RemoteVerdict = string(submissions[0].Status())
+			problem.SubmittedAt = &submittedAt
+		}
+
+		problems = append(problems, problem)
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		return problems[i].ProblemID < problems[j].ProblemID
+	})
+
+	return problems, nil
+}
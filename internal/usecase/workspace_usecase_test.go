@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/entity"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/repository"
+)
+
+func TestWorkspaceUseCase_List(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	for _, dir := range []string{"ITP1_1_B", "ITP1_1_A", "notes"} {
+		assert.NoError(t, os.Mkdir(filepath.Join(root, dir), 0755))
+	}
+	notes := "# ITP1_1_A notes\n\n- Use long long; the sum overflows int\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "ITP1_1_A", NotesFileName), []byte(notes), 0644))
+
+	accepted := entity.NewSubmission(model.NewSubmissionIDFromInt(1), model.MustNewProblemID("ITP1_1_A"), "C++17", "source")
+	accepted.UpdateStatus(entity.StatusAccepted)
+
+	mockSubmissionRepo := &MockSubmissionRepository{}
+	mockSubmissionRepo.On("GetByProblemID", mock.Anything, model.MustNewProblemID("ITP1_1_A"), 1).
+		Return([]*entity.Submission{accepted}, nil)
+	mockSubmissionRepo.On("GetByProblemID", mock.Anything, model.MustNewProblemID("ITP1_1_B"), 1).
+		Return([]*entity.Submission{}, nil)
+
+	testedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	results := &memoryLocalResultRepository{results: map[string]repository.LocalResult{
+		"ITP1_1_B": {ProblemID: "ITP1_1_B", Verdict: VerdictWrongAnswer, Passed: 1, Total: 2, TestedAt: testedAt},
+	}}
+	uc := NewWorkspaceUseCase(root, mockSubmissionRepo, WorkspaceSettings{Results: results})
+
+	// When
+	problems, err := uc.List(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, problems, 2)
+	assert.Equal(t, "ITP1_1_A", problems[0].ProblemID)
+	assert.Equal(t, filepath.Join(root, "ITP1_1_A"), problems[0].Dir)
+	assert.Equal(t, "ACCEPTED", problems[0].RemoteVerdict)
+	assert.NotNil(t, problems[0].SubmittedAt)
+	assert.Equal(t, "Use long long; the sum overflows int", problems[0].Notes)
+	assert.Empty(t, problems[0].LocalVerdict)
+	assert.Nil(t, problems[0].TestedAt)
+	assert.Equal(t, "ITP1_1_B", problems[1].ProblemID)
+	assert.Equal(t, VerdictWrongAnswer, problems[1].LocalVerdict)
+	assert.Equal(t, testedAt, *problems[1].TestedAt)
+	assert.Empty(t, problems[1].RemoteVerdict)
+	assert.Empty(t, problems[1].Notes)
+	mockSubmissionRepo.AssertExpectations(t)
+}
+
+func TestWorkspaceUseCase_List_Tags(t *testing.T) {
+	// Given
+	root := t.TempDir()
+	for _, dir := range []string{"ITP1_7_B", "ITP1_6_D"} {
+		assert.NoError(t, os.Mkdir(filepath.Join(root, dir), 0755))
+	}
+	mockSubmissionRepo := &MockSubmissionRepository{}
+	mockSubmissionRepo.On("GetByProblemID", mock.Anything, mock.Anything, 1).Return([]*entity.Submission{}, nil)
+	tags := &memoryTagRepository{tags: map[string][]string{"ITP1_7_B": {"dp", "greedy"}, "ALDS1_1_A": {"dp"}}}
+	uc := NewWorkspaceUseCase(root, mockSubmissionRepo, WorkspaceSettings{Tags: tags})
+
+	// When
+	problems, err := uc.List(context.Background(), "DP")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, problems, 1)
+	assert.Equal(t, "ITP1_7_B", problems[0].ProblemID)
+	assert.Equal(t, []string{"dp", "greedy"}, problems[0].Tags)
+}
+
+func TestWorkspaceUseCase_Root_DefaultsToCurrentDirectory(t *testing.T) {
+	uc := NewWorkspaceUseCase("", &MockSubmissionRepository{}, WorkspaceSettings{})
+
+	assert.Equal(t, ".", uc.Root())
+}
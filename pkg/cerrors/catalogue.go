@@ -0,0 +1,133 @@
+package cerrors
+
+import "fmt"
+
+// docsBaseURL is the documentation that errors link to.
+const docsBaseURL = "https://github.com/YuminosukeSato/AOJ-cli#"
+
+// Exit codes of the CLI by kind of error.
+const (
+	ExitFailure     = 1 // anything else, such as failing tests
+	ExitUsage       = 2 // invalid input
+	ExitAuth        = 3 // no valid session or access denied
+	ExitNotFound    = 4 // problem, submission or file not found
+	ExitUnavailable = 5 // AOJ unreachable, unavailable or offline mode
+)
+
+// codeInfo describes how errors of one code are presented by default.
+type codeInfo struct {
+	exitCode int
+	hint     string
+	docs     string
+}
+
+// catalogue holds the defaults of each error code.
+var catalogue = map[ErrorCode]codeInfo{
+	CodeInvalidInput: {exitCode: ExitUsage},
+	CodeUnauthorized: {
+		exitCode: ExitAuth,
+		hint:     "Run 'aoj login' to sign in to AOJ again.",
+		docs:     docsBaseURL + "aoj-login",
+	},
+	CodeForbidden: {exitCode: ExitAuth},
+	CodeNotFound:  {exitCode: ExitNotFound},
+	CodeNetworkError: {
+		exitCode: ExitUnavailable,
+		hint:     "Check your internet connection, or pass --offline to use cached data.",
+		docs:     docsBaseURL + "offline-mode",
+	},
+	CodeServiceUnavailable: {
+		exitCode: ExitUnavailable,
+		hint:     "AOJ is currently unavailable. Please try again later.",
+	},
+	CodeTimeout: {
+		exitCode: ExitUnavailable,
+		hint:     "AOJ did not respond in time. Please try again later.",
+	},
+	CodeOffline: {
+		exitCode: ExitUnavailable,
+		docs:     docsBaseURL + "offline-mode",
+	},
+}
+
+// ExitCode returns the exit status of the CLI for err: 0 for nil and
+// ExitFailure for errors without a code of their own.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if info, ok := catalogue[GetErrorCode(err)]; ok {
+		return info.exitCode
+	}
+	return ExitFailure
+}
+
+// DefaultHint returns the hint shown for errors of code that carry none of their own.
+func DefaultHint(code ErrorCode) string {
+	return catalogue[code].hint
+}
+
+// Docs returns the documentation URL of err: its own if it has one,
+// otherwise the one of its code, or "" if there is none.
+func Docs(err error) string {
+	var appErr *AppError
+	if !As(err, &appErr) {
+		return ""
+	}
+	if appErr.DocsURL != "" {
+		return appErr.DocsURL
+	}
+	return catalogue[appErr.Code].docs
+}
+
+// ErrNoSession reports that a command needs a login but there is no session.
+func ErrNoSession() *AppError {
+	return &AppError{
+		Code:    CodeUnauthorized,
+		Message: "no active session found",
+		Hint:    "Run 'aoj login' to sign in to AOJ first.",
+		DocsURL: docsBaseURL + "aoj-login",
+	}
+}
+
+// ErrSessionExpired reports that the stored session has expired.
+func ErrSessionExpired() *AppError {
+	return &AppError{
+		Code:    CodeUnauthorized,
+		Message: "session has expired",
+	}
+}
+
+// ErrSessionRejected reports that AOJ refused the session of a request.
+func ErrSessionRejected() *AppError {
+	return &AppError{
+		Code:    CodeUnauthorized,
+		Message: "AOJ rejected the session",
+	}
+}
+
+// ErrProblemNotFound reports that AOJ has no problem with the given ID.
+func ErrProblemNotFound(problemID string) *AppError {
+	return &AppError{
+		Code:    CodeNotFound,
+		Message: "problem " + problemID + " not found",
+		Hint:    "Check the problem ID, e.g. ITP1_1_A; IDs are case-sensitive.",
+	}
+}
+
+// ErrJudgeUnavailable reports that AOJ answered with an error status.
+func ErrJudgeUnavailable(status string) *AppError {
+	return &AppError{
+		Code:    CodeServiceUnavailable,
+		Message: fmt.Sprintf("AOJ is unavailable (%s)", status),
+	}
+}
+
+// ErrAOJUnreachable reports that no connection to AOJ could be made.
+func ErrAOJUnreachable(err error) *AppError {
+	return &AppError{
+		Code:    CodeNetworkError,
+		Message: "failed to connect to AOJ",
+		Err:     err,
+	}
+}
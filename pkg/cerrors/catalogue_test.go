@@ -0,0 +1,45 @@
+package cerrors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: 0},
+		{name: "plain error", err: New("tests failed"), want: ExitFailure},
+		{name: "invalid input", err: NewAppError(CodeInvalidInput, "bad flag", nil), want: ExitUsage},
+		{name: "wrapped expired session", err: Wrap(ErrSessionExpired(), "submit failed"), want: ExitAuth},
+		{name: "problem not found", err: ErrProblemNotFound("ITP1_1_Z"), want: ExitNotFound},
+		{name: "judge unavailable", err: ErrJudgeUnavailable("503 Service Unavailable"), want: ExitUnavailable},
+		{name: "internal error", err: NewAppError(CodeInternalServer, "panic", nil), want: ExitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestDocs(t *testing.T) {
+	assert.Equal(t, docsBaseURL+"aoj-login", Docs(ErrNoSession()))
+	assert.Equal(t, docsBaseURL+"offline-mode", Docs(Wrap(ErrAOJUnreachable(New("dial tcp")), "init failed")))
+	assert.Empty(t, Docs(ErrProblemNotFound("ITP1_1_Z")))
+	assert.Empty(t, Docs(New("plain")))
+}
+
+func TestCatalogueErrors(t *testing.T) {
+	unreachable := ErrAOJUnreachable(New("dial tcp: timeout"))
+
+	assert.Equal(t, "failed to connect to AOJ: dial tcp: timeout", unreachable.Error())
+	assert.Equal(t, "problem ITP1_1_Z not found", ErrProblemNotFound("ITP1_1_Z").Error())
+	assert.True(t, IsAppError(ErrSessionRejected(), CodeUnauthorized))
+	assert.NotEmpty(t, ErrNoSession().Hint)
+}
@@ -45,6 +45,11 @@ func WithHint(err error, msg string) error {
 	return errors.WithHint(err, msg)
 }
 
+// GetAllHints returns the hints attached to an error and its causes.
+func GetAllHints(err error) []string {
+	return errors.GetAllHints(err)
+}
+
 // Is checks if an error matches a target error.
 func Is(err, target error) bool {
 	return errors.Is(err, target)
@@ -109,13 +114,18 @@ const (
 	CodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
 	CodeTimeout            ErrorCode = "TIMEOUT"
 	CodeNetworkError       ErrorCode = "NETWORK_ERROR"
+	CodeOffline            ErrorCode = "OFFLINE"
 )
 
 // AppError represents an application-specific error with a code.
+// Hint and DocsURL are optional; errors without them are presented with the
+// defaults of their code, see catalogue.go.
 type AppError struct {
 	Code    ErrorCode
 	Message string
 	Err     error
+	Hint    string // how to resolve the error
+	DocsURL string // where the error is explained
 }
 
 // Error implements the error interface.
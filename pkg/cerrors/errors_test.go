@@ -149,4 +149,19 @@ func TestWithHint(t *testing.T) {
 	// Check that the error still behaves as expected
 	assert.True(t, Is(hintedErr, baseErr))
 	assert.Error(t, hintedErr)
-}
\ No newline at end of file
+}
+
+func TestGetAllHints(t *testing.T) {
+	// Given
+	baseErr := WithHint(NewAppError(CodeUnauthorized, "session expired", nil), "run 'aoj login'")
+	wrappedErr := Wrap(baseErr, "submission failed")
+
+	// When
+	hints := GetAllHints(wrappedErr)
+	plainHints := GetAllHints(New("plain error"))
+
+	// Then
+	assert.Equal(t, []string{"run 'aoj login'"}, hints)
+	assert.Empty(t, plainHints)
+	assert.True(t, IsAppError(wrappedErr, CodeUnauthorized))
+}
@@ -0,0 +1,105 @@
+package cerrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemError is the failure of one item of a batch operation, such as one
+// problem of 'aoj init' with several problem IDs.
+type ItemError struct {
+	Item string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *ItemError) Error() string {
+	if e.Item == "" {
+		return e.Err.Error()
+	}
+	return e.Item + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects the failures of a batch operation so that it can go on
+// after one item fails and report every failed item at the end. The zero
+// value is ready to use. Is and As look into every collected error.
+type MultiError struct {
+	noun   string
+	errors []*ItemError
+}
+
+// NewMultiError creates a MultiError whose message counts failed items as
+// noun, e.g. "problems".
+func NewMultiError(noun string) *MultiError {
+	return &MultiError{noun: noun}
+}
+
+// Add records the failure of item; nil errors are ignored.
+func (m *MultiError) Add(item string, err error) {
+	if err != nil {
+		m.errors = append(m.errors, &ItemError{Item: item, Err: err})
+	}
+}
+
+// Len returns the number of failed items.
+func (m *MultiError) Len() int {
+	return len(m.errors)
+}
+
+// Errors returns the failures in the order they were added.
+func (m *MultiError) Errors() []*ItemError {
+	return m.errors
+}
+
+// Err returns m if any item failed and nil otherwise, so that batch
+// operations can end with `return multi.Err()`.
+func (m *MultiError) Err() error {
+	if len(m.errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error implements the error interface with one line per failed item.
+func (m *MultiError) Error() string {
+	noun := m.noun
+	if noun == "" {
+		noun = "items"
+	}
+	if len(m.errors) == 1 {
+		return m.errors[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s failed:", len(m.errors), noun)
+	for _, err := range m.errors {
+		b.WriteString("\n  ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the collected errors.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.errors))
+	for i, err := range m.errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Join returns an error wrapping the non-nil errors, or nil if there are none.
+func Join(errs ...error) error {
+	multi := &MultiError{}
+	for _, err := range errs {
+		if err != nil {
+			multi.errors = append(multi.errors, &ItemError{Err: err})
+		}
+	}
+	return multi.Err()
+}
@@ -0,0 +1,47 @@
+package cerrors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiError(t *testing.T) {
+	// Given
+	multi := NewMultiError("problems")
+
+	// When
+	multi.Add("ITP1_1_A", nil)
+	multi.Add("ITP1_1_Z", ErrProblemNotFound("ITP1_1_Z"))
+	multi.Add("ALDS1_1_A", Wrap(context.DeadlineExceeded, "download failed"))
+
+	// Then
+	err := multi.Err()
+	assert.Equal(t, 2, multi.Len())
+	assert.Equal(t, "2 problems failed:\n"+
+		"  ITP1_1_Z: problem ITP1_1_Z not found\n"+
+		"  ALDS1_1_A: download failed: context deadline exceeded", err.Error())
+	assert.True(t, Is(err, context.DeadlineExceeded))
+	assert.True(t, IsAppError(err, CodeNotFound))
+	assert.Equal(t, ExitNotFound, ExitCode(err))
+}
+
+func TestMultiError_Empty(t *testing.T) {
+	multi := NewMultiError("problems")
+	multi.Add("ITP1_1_A", nil)
+
+	assert.NoError(t, multi.Err())
+}
+
+func TestMultiError_Single(t *testing.T) {
+	multi := &MultiError{}
+	multi.Add("ITP1_1_Z", New("boom"))
+
+	assert.EqualError(t, multi.Err(), "ITP1_1_Z: boom")
+}
+
+func TestJoin(t *testing.T) {
+	assert.NoError(t, Join(nil, nil))
+	assert.EqualError(t, Join(New("a"), nil, New("b")), "2 items failed:\n  a\n  b")
+}
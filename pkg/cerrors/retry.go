@@ -0,0 +1,43 @@
+package cerrors
+
+import (
+	"context"
+	"io"
+	"net"
+)
+
+// retryableCodes are the error codes of failures that may go away by themselves.
+var retryableCodes = map[ErrorCode]bool{
+	CodeServiceUnavailable: true,
+	CodeTimeout:            true,
+	CodeNetworkError:       true,
+}
+
+// IsRetryable reports whether the operation that failed with err may succeed
+// when it is tried again unchanged. AppErrors are classified by their code;
+// other errors are retryable if they wrap a temporary network failure such as
+// a timeout, a refused or reset connection or a connection closed early.
+// Cancellation is never retryable, but callers must check whether their own
+// context is done, since an expired deadline looks like a timeout.
+func IsRetryable(err error) bool {
+	if err == nil || Is(err, context.Canceled) {
+		return false
+	}
+	if code := GetErrorCode(err); code != "" {
+		return retryableCodes[code]
+	}
+
+	var dnsErr *net.DNSError
+	if As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if As(err, &opErr) {
+		return true
+	}
+	return Is(err, io.ErrUnexpectedEOF) || Is(err, io.EOF)
+}
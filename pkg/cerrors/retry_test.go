@@ -0,0 +1,41 @@
+package cerrors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "judge unavailable", err: ErrJudgeUnavailable("503 Service Unavailable"), want: true},
+		{name: "wrapped timeout code", err: Wrap(NewAppError(CodeTimeout, "no response", nil), "submit failed"), want: true},
+		{name: "unreachable", err: ErrAOJUnreachable(refused), want: true},
+		{name: "expired session", err: ErrSessionExpired(), want: false},
+		{name: "not found wrapping a network error", err: NewAppError(CodeNotFound, "gone", refused), want: false},
+		{name: "refused connection", err: &url.Error{Op: "Get", URL: "https://judgeapi.u-aizu.ac.jp", Err: refused}, want: true},
+		{name: "connection closed early", err: fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "temporary DNS failure", err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}, want: true},
+		{name: "unknown host", err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}, want: false},
+		{name: "canceled", err: Wrap(context.Canceled, "interrupted"), want: false},
+		{name: "plain error", err: New("invalid JSON"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
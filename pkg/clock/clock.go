@@ -0,0 +1,117 @@
+// Package clock provides an injectable source of the current time.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time and waits for it to pass
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has passed
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is a Clock backed by time.Now
+type systemClock struct{}
+
+// Now returns the current wall clock time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for d on the wall clock
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// System returns a Clock backed by time.Now
+func System() Clock {
+	return systemClock{}
+}
+
+// frozenClock is a Clock that always tells the same time
+type frozenClock struct {
+	now time.Time
+}
+
+// Now returns the frozen time
+func (c frozenClock) Now() time.Time {
+	return c.now
+}
+
+// After waits for d on the wall clock, so that polling still makes progress
+func (frozenClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Frozen returns a Clock that always tells now but waits on the wall clock
+func Frozen(now time.Time) Clock {
+	return frozenClock{now: now}
+}
+
+// Fake is a Clock that stands still until it is set or advanced
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+// fakeWaiter is a pending After of a Fake clock
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewFake creates a Fake clock set to now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time the clock is set to
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the clock to now
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+	f.fire()
+}
+
+// Advance moves the clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.fire()
+}
+
+// After returns a channel that receives the time once the clock has been
+// set or advanced by at least d
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	f.waiters = append(f.waiters, fakeWaiter{deadline: f.now.Add(d), ch: ch})
+	f.fire()
+	return ch
+}
+
+// fire releases the waiters whose deadline has passed; f.mu must be held
+func (f *Fake) fire() {
+	pending := f.waiters[:0]
+	for _, w := range f.waiters {
+		if w.deadline.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- f.now
+	}
+	f.waiters = pending
+}
@@ -0,0 +1,67 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem(t *testing.T) {
+	before := time.Now()
+	now := System().Now()
+
+	assert.False(t, now.Before(before))
+	assert.WithinDuration(t, time.Now(), now, time.Second)
+}
+
+func TestFrozen(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	frozen := Frozen(now)
+
+	<-frozen.After(time.Millisecond)
+	assert.Equal(t, now, frozen.Now())
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	assert.Equal(t, start, fake.Now())
+
+	fake.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(90*time.Minute), fake.Now())
+
+	fake.Set(start)
+	assert.Equal(t, start, fake.Now())
+}
+
+func TestFake_After(t *testing.T) {
+	// Given
+	fake := NewFake(time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC))
+	immediate := fake.After(0)
+	later := fake.After(time.Minute)
+
+	// When
+	fake.Advance(30 * time.Second)
+	_, firedEarly := receive(later)
+	fake.Advance(30 * time.Second)
+	firedAt, fired := receive(later)
+
+	// Then
+	_, immediateFired := receive(immediate)
+	assert.True(t, immediateFired)
+	assert.False(t, firedEarly)
+	assert.True(t, fired)
+	assert.Equal(t, fake.Now(), firedAt)
+}
+
+// receive returns the value of ch if one is ready
+func receive(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
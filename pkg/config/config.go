@@ -2,33 +2,64 @@
 package config
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
 	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/storage"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Login  LoginConfig  `toml:"login"`
-	Init   InitConfig   `toml:"init"`
-	Test   TestConfig   `toml:"test"`
-	Submit SubmitConfig `toml:"submit"`
+	Version    int              `toml:"version"` // layout version, see CurrentVersion
+	Strict     bool             `toml:"strict"`  // unknown keys are errors instead of warnings
+	Login      LoginConfig      `toml:"login"`
+	Init       InitConfig       `toml:"init"`
+	Test       TestConfig       `toml:"test"`
+	Submit     SubmitConfig     `toml:"submit"`
+	Workspace  WorkspaceConfig  `toml:"workspace"`
+	Storage    StorageConfig    `toml:"storage"`
+	Log        LogConfig        `toml:"log"`
+	API        APIConfig        `toml:"api"`
+	Difficulty DifficultyConfig `toml:"difficulty"`
+	// Aliases are names accepted wherever a problem ID is, e.g. two-sum = "ITP1_6_D"
+	Aliases map[string]string `toml:"aliases,omitempty"`
+	// Sources are archives of past contests on AOJ in addition to the
+	// built-in ones, as name = "<large>/<middle>" category, e.g. jag-spring = "jag/spring"
+	Sources map[string]string `toml:"sources,omitempty"`
+	// Profiles are named sets of settings that override the ones above,
+	// selected with --profile or AOJ_PROFILE
+	Profiles map[string]map[string]interface{} `toml:"profiles,omitempty"`
 }
 
 // LoginConfig holds login-related configuration
 type LoginConfig struct {
-	SessionFile string `toml:"session_file"`
+	SessionFile     string `toml:"session_file"`
+	EncryptSessions bool   `toml:"encrypt_sessions"`
 }
 
 // InitConfig holds init command configuration
 type InitConfig struct {
 	TemplateFile    string `toml:"template_file"`
-	Language        string `toml:"language"`
+	Language        string `toml:"language"` // default language of init --lang; empty keeps source_file as is
 	FetchTestcases  bool   `toml:"fetch_testcases"`
 	DefaultTemplate string `toml:"default_template"`
+	SourceFile      string `toml:"source_file"`    // solution file created in the problem directory
+	TestDir         string `toml:"test_dir"`       // directory for sample test cases
+	CreateReadme    bool   `toml:"create_readme"`  // write README.md with the problem statement
+	CreateNotes     bool   `toml:"create_notes"`   // write an empty notes.md
+	ScaffoldDir     string `toml:"scaffold_dir"`   // extra files copied into every problem directory
+	EditorFiles     bool   `toml:"editor_files"`   // write .vscode/tasks.json, compile_flags.txt or go.mod
+	SaveStatement   bool   `toml:"save_statement"` // write the problem statement as Markdown to README.md
+	// StatementLanguage is the preferred language of statements saved by init
+	// and shown by aoj show, "en" or "ja"; empty prefers English
+	StatementLanguage string `toml:"statement_language"`
 }
 
 // TestConfig holds test command configuration
@@ -37,13 +68,86 @@ type TestConfig struct {
 	RunCommand   string  `toml:"run_command"`
 	Timeout      float64 `toml:"timeout"`
 	Parallel     bool    `toml:"parallel"`
+	// TimeLimitFactor scales the problem's time limit stored in problem.toml
+	TimeLimitFactor float64 `toml:"time_limit_factor"`
+	// SpeedFactor is how many times slower this machine runs solutions than
+	// the AOJ judge; local times are divided by it to estimate judge times
+	SpeedFactor float64 `toml:"speed_factor"`
+	// TLEWarnRatio is the fraction of the time limit above which an estimated
+	// judge time is reported as a likely TLE on the judge
+	TLEWarnRatio float64 `toml:"tle_warn_ratio"`
+	// InteractorCommand is the judge program of interactive problems, connected
+	// to the solution through pipes. {input} and {output} are replaced with the
+	// files of the test case
+	InteractorCommand string `toml:"interactor_command"`
+	// ExtraBuildFlags are appended to the build command of C and C++ solutions,
+	// e.g. "-Wall -Wextra"
+	ExtraBuildFlags string `toml:"extra_build_flags"`
 }
 
 // SubmitConfig holds submit command configuration
 type SubmitConfig struct {
-	SourceFile string `toml:"source_file"`
-	Language   string `toml:"language"`
-	Watch      bool   `toml:"watch"`
+	SourceFile    string `toml:"source_file"`
+	Language      string `toml:"language"`
+	Watch         bool   `toml:"watch"`
+	GitCommitOnAC bool   `toml:"git_commit_on_ac"` // commit the solution file after an accepted verdict
+	GitTag        string `toml:"git_tag"`          // tag for those commits, e.g. "ac/{problem}"; empty for none
+	// RetryAttempts is how often a submission is sent while AOJ fails with
+	// server errors or timeouts before it is queued for 'aoj submit --retry-pending'
+	RetryAttempts int `toml:"retry_attempts"`
+	// BatchDelay is the shortest time in seconds between two submissions of 'aoj submit --all'
+	BatchDelay float64 `toml:"batch_delay"`
+	// Transform adds snippets to the submitted source, keyed by AOJ language
+	// (e.g. "C++17") or language family (e.g. "C++" or "Python")
+	Transform map[string]SourceTransform `toml:"transform"`
+}
+
+// SourceTransform holds the snippets added around a submitted source
+type SourceTransform struct {
+	Prelude  string `toml:"prelude"`  // inserted before the source, e.g. "#define NDEBUG"
+	Epilogue string `toml:"epilogue"` // appended after the source
+}
+
+// WorkspaceConfig holds workspace configuration
+type WorkspaceConfig struct {
+	Root string `toml:"root"` // directory where all problem directories live
+}
+
+// StorageConfig holds configuration of the local data store
+type StorageConfig struct {
+	// Backend is "file" (one file per entry) or "kv" (a single store.db file)
+	Backend string `toml:"backend"`
+	// ProblemCacheHours is how long fetched problem metadata is reused before
+	// it is fetched again; 0 always fetches and only uses the cache offline
+	ProblemCacheHours float64 `toml:"problem_cache_hours"`
+	// UsageStats records the runs, durations and failures of commands in
+	// stats.json for 'aoj stats --cli'; the file is never uploaded
+	UsageStats bool `toml:"usage_stats"`
+}
+
+// LogConfig holds configuration of logging and progress output
+type LogConfig struct {
+	// SlowThresholds are the seconds after which an operation such as
+	// testcase_download is reported as slow together with a hint; 0 never does
+	SlowThresholds map[string]float64 `toml:"slow_thresholds"`
+	MaxSizeMB      int                `toml:"max_size_mb"`  // rotate --log-file beyond this size; 0 means 10
+	MaxBackups     int                `toml:"max_backups"`  // rotated log files to keep; 0 means 3
+	MaxAgeDays     int                `toml:"max_age_days"` // remove rotated log files older than this; 0 keeps them regardless of age
+}
+
+// APIConfig holds the endpoints of AOJ, e.g. to point the CLI at a mirror or a test server
+type APIConfig struct {
+	BaseURL string `toml:"base_url"` // judge API for sessions, submissions and verdicts
+	DataURL string `toml:"data_url"` // judge data API for problems and test cases
+}
+
+// DifficultyConfig holds the source of the estimated problem difficulties
+type DifficultyConfig struct {
+	// URL is an http(s) URL or a local path of a JSON difficulty table,
+	// e.g. an export of the AOJ-ICPC table; empty disables difficulties
+	URL string `toml:"url"`
+	// CacheHours is how long a downloaded table is reused
+	CacheHours float64 `toml:"cache_hours"`
 }
 
 // LanguageConfig represents language-specific configuration
@@ -63,6 +167,7 @@ func DefaultConfig() *Config {
 	aojDir := filepath.Join(homeDir, ".aoj-cli")
 
 	return &Config{
+		Version: CurrentVersion,
 		Login: LoginConfig{
 			SessionFile: filepath.Join(aojDir, "session.json"),
 		},
@@ -71,54 +176,75 @@ func DefaultConfig() *Config {
 			Language:        "C++17",
 			FetchTestcases:  true,
 			DefaultTemplate: defaultCppTemplate,
+			SourceFile:      "main.go",
+			TestDir:         "test",
 		},
 		Test: TestConfig{
-			BuildCommand: "g++ -std=c++17 -O2 -o a.out main.cpp",
-			RunCommand:   "./a.out",
-			Timeout:      2.0,
-			Parallel:     true,
+			BuildCommand:    "g++ -std=c++17 -O2 -o a.out main.cpp",
+			RunCommand:      "./a.out",
+			Timeout:         2.0,
+			Parallel:        true,
+			TimeLimitFactor: 1.0,
+			SpeedFactor:     1.0,
+			TLEWarnRatio:    0.8,
 		},
 		Submit: SubmitConfig{
-			SourceFile: "main.cpp",
-			Language:   "C++17",
-			Watch:      true,
+			SourceFile:    "main.cpp",
+			Language:      "C++17",
+			Watch:         true,
+			RetryAttempts: 3,
+			BatchDelay:    5,
+		},
+		Storage: StorageConfig{
+			Backend:           storage.BackendFile,
+			ProblemCacheHours: 7 * 24,
+			UsageStats:        true,
+		},
+		API: APIConfig{
+			BaseURL: DefaultBaseURL,
+			DataURL: DefaultDataURL,
+		},
+		Difficulty: DifficultyConfig{
+			CacheHours: 7 * 24,
+		},
+		Log: LogConfig{
+			SlowThresholds: map[string]float64{
+				"sample_download":   10,
+				"testcase_download": 10,
+				"build":             30,
+				"history_sync":      120,
+			},
+			MaxSizeMB:  10,
+			MaxBackups: 3,
 		},
 	}
 }
 
-// DefaultLanguages returns the default language configurations
+// DefaultLanguages returns the default language configurations. Their AOJ
+// language IDs and extensions come from the language catalogue
 func DefaultLanguages() Languages {
 	return Languages{
-		"cpp17": {
-			Extension:     "cpp",
-			BuildCommand:  "g++ -std=c++17 -O2 -o a.out {file}",
-			RunCommand:    "./a.out",
-			AOJLanguageID: "C++17",
-		},
-		"cpp23": {
-			Extension:     "cpp",
-			BuildCommand:  "g++ -std=c++23 -O2 -o a.out {file}",
-			RunCommand:    "./a.out",
-			AOJLanguageID: "C++23",
-		},
-		"python": {
-			Extension:     "py",
-			BuildCommand:  "",
-			RunCommand:    "python3 {file}",
-			AOJLanguageID: "Python3",
-		},
-		"java": {
-			Extension:     "java",
-			BuildCommand:  "javac {file}",
-			RunCommand:    "java Main",
-			AOJLanguageID: "Java",
-		},
-		"go": {
-			Extension:     "go",
-			BuildCommand:  "go build -o main {file}",
-			RunCommand:    "./main",
-			AOJLanguageID: "Go",
-		},
+		"cpp17":      defaultLanguage("C++17", "g++ -std=c++17 -O2 -o a.out {file}", "./a.out"),
+		"cpp23":      defaultLanguage("C++23", "g++ -std=c++23 -O2 -o a.out {file}", "./a.out"),
+		"python":     defaultLanguage("Python3", "", "python3 {file}"),
+		"java":       defaultLanguage("JAVA", "javac {file}", "java Main"),
+		"go":         defaultLanguage("Go", "go build -o main {file}", "./main"),
+		"rust":       defaultLanguage("Rust", "rustc --edition 2021 -O -o main {file}", "./main"),
+		"kotlin":     defaultLanguage("Kotlin", "kotlinc {file} -include-runtime -d main.jar", "java -jar main.jar"),
+		"csharp":     defaultLanguage("C#", "mcs -out:main.exe {file}", "mono main.exe"),
+		"javascript": defaultLanguage("JavaScript", "", "node {file}"),
+		"ruby":       defaultLanguage("Ruby", "", "ruby {file}"),
+	}
+}
+
+// defaultLanguage configures a language of the catalogue with its usual extension
+func defaultLanguage(id, buildCommand, runCommand string) LanguageConfig {
+	lang := language.MustLookup(id)
+	return LanguageConfig{
+		Extension:     lang.Extension(),
+		BuildCommand:  buildCommand,
+		RunCommand:    runCommand,
+		AOJLanguageID: lang.ID,
 	}
 }
 
@@ -154,9 +280,41 @@ func Load(filePath string) (*Config, error) {
 		return config, nil
 	}
 
-	if _, err := toml.DecodeFile(filePath, config); err != nil {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read config file")
+	}
+
+	// Upgrade older layouts before decoding
+	var raw map[string]interface{}
+	if _, err := toml.Decode(string(content), &raw); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode config file")
+	}
+	migrated, err := migrate(raw)
+	if err != nil {
+		return nil, err
+	}
+	if migrated {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+			return nil, cerrors.Wrap(err, "failed to encode migrated config")
+		}
+		if err := writeMigrated(filePath, content, buf.Bytes()); err != nil {
+			logger.Warn("failed to write migrated config, using it for this run only", "path", filePath, "error", err)
+		}
+		content = buf.Bytes()
+	}
+
+	meta, err := toml.Decode(string(content), config)
+	if err != nil {
 		return nil, cerrors.Wrap(err, "failed to decode config file")
 	}
+	if err := checkUnknownKeys(meta, "", config.Strict); err != nil {
+		return nil, err
+	}
+	if err := checkProfiles(config); err != nil {
+		return nil, err
+	}
 
 	logger.Debug("config loaded successfully", "path", filePath)
 	return config, nil
@@ -169,6 +327,7 @@ func Save(config *Config, filePath string) error {
 		return cerrors.Wrap(err, "failed to create config directory")
 	}
 
+	trace.File("write", filePath)
 	file, err := os.Create(filePath)
 	if err != nil {
 		return cerrors.Wrap(err, "failed to create config file")
@@ -188,8 +347,16 @@ func Save(config *Config, filePath string) error {
 	return nil
 }
 
-// GetConfigDir returns the configuration directory path
+// GetConfigDir returns the configuration directory path: the one set with
+// SetConfigDir, AOJ_CONFIG_DIR or ~/.aoj-cli
 func GetConfigDir() (string, error) {
+	if configDirOverride != "" {
+		return configDirOverride, nil
+	}
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return ExpandHome(dir), nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", cerrors.Wrap(err, "failed to get user home directory")
@@ -199,6 +366,20 @@ func GetConfigDir() (string, error) {
 	return configDir, nil
 }
 
+// ExpandHome expands a leading ~ in a path to the user's home directory.
+// Both ~/ and the platform separator (~\ on Windows) are accepted
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[1:])
+}
+
 // GetConfigPath returns the default configuration file path
 func GetConfigPath() (string, error) {
 	configDir, err := GetConfigDir()
@@ -206,7 +387,7 @@ func GetConfigPath() (string, error) {
 		return "", err
 	}
 
-	return filepath.Join(configDir, "config.toml"), nil
+	return filepath.Join(configDir, FileName), nil
 }
 
 // EnsureConfigDir ensures the configuration directory exists
@@ -284,5 +465,57 @@ func ValidateConfig(config *Config) error {
 		)
 	}
 
+	if config.Submit.RetryAttempts < 1 {
+		return invalidConfig("submit.retry_attempts must be at least 1")
+	}
+
+	if config.Submit.BatchDelay < 1 {
+		return invalidConfig("submit.batch_delay must be at least 1 second")
+	}
+
+	if config.Storage.Backend != storage.BackendFile && config.Storage.Backend != storage.BackendKV {
+		return cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"storage backend must be \"file\" or \"kv\"",
+			nil,
+		)
+	}
+
+	if lang := config.Init.StatementLanguage; lang != "" && lang != "en" && lang != "ja" {
+		return invalidConfig("init.statement_language must be \"en\" or \"ja\"")
+	}
+
+	if config.Storage.ProblemCacheHours < 0 {
+		return invalidConfig("storage.problem_cache_hours cannot be negative")
+	}
+
+	if err := validateAPIURL("api.base_url", config.API.BaseURL); err != nil {
+		return err
+	}
+	if err := validateAPIURL("api.data_url", config.API.DataURL); err != nil {
+		return err
+	}
+
+	if config.Difficulty.CacheHours < 0 {
+		return invalidConfig("difficulty.cache_hours cannot be negative")
+	}
+
+	for operation, seconds := range config.Log.SlowThresholds {
+		if seconds < 0 {
+			return invalidConfig("log.slow_thresholds.%s cannot be negative", operation)
+		}
+	}
+	if config.Log.MaxSizeMB < 0 || config.Log.MaxBackups < 0 || config.Log.MaxAgeDays < 0 {
+		return invalidConfig("log.max_size_mb, log.max_backups and log.max_age_days cannot be negative")
+	}
+
+	if err := validateCommands(config); err != nil {
+		return err
+	}
+
+	if err := ValidateLanguages(DefaultLanguages()); err != nil {
+		return err
+	}
+
 	return nil
 }
\ No newline at end of file
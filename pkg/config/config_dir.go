@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// FileName is the name of the configuration file in the configuration directory
+const FileName = "config.toml"
+
+// Default endpoints of AOJ
+const (
+	DefaultBaseURL = "https://judgeapi.u-aizu.ac.jp"
+	DefaultDataURL = "https://judgedat.u-aizu.ac.jp"
+)
+
+// ConfigDirEnv is the environment variable that overrides the configuration directory
+const ConfigDirEnv = "AOJ_CONFIG_DIR"
+
+// configDirOverride is the directory set with SetConfigDir, if any
+var configDirOverride string
+
+// SetConfigDir makes GetConfigDir return dir, e.g. from --config-dir; ""
+// restores the default of AOJ_CONFIG_DIR or ~/.aoj-cli
+func SetConfigDir(dir string) {
+	configDirOverride = dir
+}
+
+// CheckWritable creates dir if needed and reports an error unless files can
+// be created in it
+func CheckWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return cerrors.Wrap(err, "failed to create directory "+dir)
+	}
+	file, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return cerrors.Wrap(err, "directory "+dir+" is not writable")
+	}
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+	return nil
+}
+
+// FallbackDataDir creates and returns the per-user directory that holds
+// sessions, caches and history while the configuration directory is
+// read-only: aoj-cli in the user cache directory or, if that is not writable
+// either, a private directory in the temporary directory
+func FallbackDataDir() (string, error) {
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		dir := filepath.Join(cacheDir, "aoj-cli")
+		if err := CheckWritable(dir); err == nil {
+			return dir, nil
+		}
+	}
+
+	name := "aoj-cli"
+	if uid := os.Getuid(); uid >= 0 {
+		// Unlike on Windows, the temporary directory is shared by all users
+		name = fmt.Sprintf("aoj-cli-%d", uid)
+	}
+	dir := filepath.Join(os.TempDir(), name)
+	if err := os.Mkdir(dir, 0700); err != nil && !os.IsExist(err) {
+		return "", cerrors.Wrap(err, "failed to create directory "+dir)
+	}
+	// The name is predictable, so another user may have created it first
+	if err := checkPrivateDir(dir); err != nil {
+		return "", err
+	}
+	if err := CheckWritable(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigDir_Override(t *testing.T) {
+	// Given
+	t.Setenv(ConfigDirEnv, "/env/aoj")
+	defer SetConfigDir("")
+
+	// When
+	fromEnv, envErr := GetConfigDir()
+	SetConfigDir("/flag/aoj")
+	fromFlag, flagErr := GetConfigDir()
+	path, pathErr := GetConfigPath()
+
+	// Then
+	assert.NoError(t, envErr)
+	assert.NoError(t, flagErr)
+	assert.NoError(t, pathErr)
+	assert.Equal(t, "/env/aoj", fromEnv)
+	assert.Equal(t, "/flag/aoj", fromFlag)
+	assert.Equal(t, filepath.Join("/flag/aoj", "config.toml"), path)
+}
+
+func TestCheckWritable(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Getuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+
+	// Given
+	dir := t.TempDir()
+	readOnly := filepath.Join(dir, "readonly")
+	if err := os.Mkdir(readOnly, 0555); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	// When
+	writableErr := CheckWritable(filepath.Join(dir, "new"))
+	readOnlyErr := CheckWritable(readOnly)
+
+	// Then
+	assert.NoError(t, writableErr)
+	assert.Error(t, readOnlyErr)
+	entries, _ := os.ReadDir(filepath.Join(dir, "new"))
+	assert.Empty(t, entries)
+}
+
+func TestFallbackDataDir_UserCacheDir(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the user cache directory follows XDG_CACHE_HOME only on Linux")
+	}
+
+	// Given
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+
+	// When
+	dir, err := FallbackDataDir()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, "aoj-cli"), dir)
+}
+
+func TestCheckPrivateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the temporary directory is per user on Windows")
+	}
+
+	// Given
+	dir := t.TempDir()
+	private := filepath.Join(dir, "private")
+	shared := filepath.Join(dir, "shared")
+	link := filepath.Join(dir, "link")
+	if err := os.Mkdir(private, 0700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.Mkdir(shared, 0700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.Chmod(shared, 0777); err != nil {
+		t.Fatalf("failed to change mode: %v", err)
+	}
+	if err := os.Symlink(private, link); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	// When / Then
+	assert.NoError(t, checkPrivateDir(private))
+	assert.Error(t, checkPrivateDir(shared))
+	assert.Error(t, checkPrivateDir(link))
+}
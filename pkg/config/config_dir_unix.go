@@ -0,0 +1,28 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// checkPrivateDir refuses dir unless it is a directory, not a symlink, owned
+// by the current user and accessible by nobody else
+func checkPrivateDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to inspect directory "+dir)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !info.IsDir() || !ok || int(stat.Uid) != os.Getuid() || info.Mode().Perm() != 0700 {
+		return cerrors.NewAppError(
+			cerrors.CodeForbidden,
+			"refusing to use "+dir+": it must be a directory of the current user with mode 0700",
+			nil,
+		)
+	}
+	return nil
+}
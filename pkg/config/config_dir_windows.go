@@ -0,0 +1,8 @@
+//go:build windows
+
+package config
+
+// checkPrivateDir accepts dir; the temporary directory on Windows is per user
+func checkPrivateDir(_ string) error {
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/internal/domain/model/language"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -22,6 +24,8 @@ func TestDefaultConfig(t *testing.T) {
 	assert.NotEmpty(t, config.Test.RunCommand)
 	assert.Greater(t, config.Test.Timeout, 0.0)
 	assert.True(t, config.Test.Parallel)
+	assert.Equal(t, 1.0, config.Test.SpeedFactor)
+	assert.Equal(t, 0.8, config.Test.TLEWarnRatio)
 	assert.Equal(t, "main.cpp", config.Submit.SourceFile)
 	assert.Equal(t, "C++17", config.Submit.Language)
 	assert.True(t, config.Submit.Watch)
@@ -55,7 +59,62 @@ func TestDefaultLanguages(t *testing.T) {
 	assert.Equal(t, "java", java.Extension)
 	assert.Contains(t, java.BuildCommand, "javac")
 	assert.Equal(t, "java Main", java.RunCommand)
-	assert.Equal(t, "Java", java.AOJLanguageID)
+	assert.Equal(t, "JAVA", java.AOJLanguageID)
+}
+
+func TestDefaultLanguages_MoreLanguages(t *testing.T) {
+	tests := []struct {
+		name      string
+		aojID     string
+		extension string
+		build     string
+		run       string
+	}{
+		{name: "rust", aojID: "Rust", extension: "rs", build: "rustc", run: "./main"},
+		{name: "kotlin", aojID: "Kotlin", extension: "kt", build: "kotlinc", run: "java -jar main.jar"},
+		{name: "csharp", aojID: "C#", extension: "cs", build: "mcs", run: "mono main.exe"},
+		{name: "javascript", aojID: "JavaScript", extension: "js", run: "node {file}"},
+		{name: "ruby", aojID: "Ruby", extension: "rb", run: "ruby {file}"},
+	}
+
+	languages := DefaultLanguages()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, ok := languages[tt.name]
+			if !ok {
+				t.Fatalf("%s is not a default language", tt.name)
+			}
+			assert.Equal(t, tt.aojID, lang.AOJLanguageID)
+			assert.Equal(t, tt.extension, lang.Extension)
+			if tt.build == "" {
+				assert.Empty(t, lang.BuildCommand)
+			} else {
+				assert.Contains(t, lang.BuildCommand, tt.build)
+				assert.Contains(t, lang.BuildCommand, "{file}")
+			}
+			assert.Equal(t, tt.run, lang.RunCommand)
+		})
+	}
+}
+
+func TestDefaults_MatchLanguageCatalogue(t *testing.T) {
+	// Given
+	config := DefaultConfig()
+
+	// When
+	detected, ok := language.ByExtension(filepath.Ext(config.Submit.SourceFile))
+
+	// Then
+	if !ok {
+		t.Fatalf("no language for %s", config.Submit.SourceFile)
+	}
+	assert.Equal(t, detected.ID, config.Submit.Language, "the default source file is submitted in the default language")
+	assert.Equal(t, detected.ID, config.Init.Language)
+	for name, lang := range DefaultLanguages() {
+		known, ok := language.Lookup(lang.AOJLanguageID)
+		assert.True(t, ok, "%s: unknown AOJ language %s", name, lang.AOJLanguageID)
+		assert.Contains(t, known.Extensions, lang.Extension, name)
+	}
 }
 
 func TestLoadNonExistentFile(t *testing.T) {
@@ -234,6 +293,46 @@ func TestValidateConfig(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "test timeout must be positive")
 	})
+
+	t.Run("Unknown storage backend", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Storage.Backend = "sqlite"
+		err := ValidateConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "storage backend")
+	})
+
+	t.Run("Invalid API URL", func(t *testing.T) {
+		config := DefaultConfig()
+		config.API.DataURL = "judgedat.u-aizu.ac.jp"
+		err := ValidateConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "api.data_url")
+	})
+
+	t.Run("Negative slow threshold", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Log.SlowThresholds["build"] = -1
+		err := ValidateConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "log.slow_thresholds.build")
+	})
+
+	t.Run("Negative log rotation", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Log.MaxAgeDays = -1
+		err := ValidateConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "log.max_age_days")
+	})
+
+	t.Run("Unknown statement language", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Init.StatementLanguage = "fr"
+		err := ValidateConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "init.statement_language")
+	})
 }
 
 func TestDefaultTemplate(t *testing.T) {
@@ -276,4 +375,15 @@ func TestLoadDefaultAndSaveDefault(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Python3", loadedConfig.Init.Language)
 	assert.Equal(t, 10.0, loadedConfig.Test.Timeout)
-}
\ No newline at end of file
+}
+
+func TestExpandHome(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	assert.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(homeDir, "aoj"), ExpandHome("~/aoj"))
+	assert.Equal(t, homeDir, ExpandHome("~"))
+	assert.Equal(t, "/tmp/aoj", ExpandHome("/tmp/aoj"))
+	assert.Equal(t, "~user/aoj", ExpandHome("~user/aoj"))
+	assert.Equal(t, filepath.Join(homeDir, "aoj"), ExpandHome("~"+string(filepath.Separator)+"aoj"))
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// GenSpecFile is the name of the optional input generator spec in the test directory
+const GenSpecFile = "gen.toml"
+
+// Types of generated values
+const (
+	GenTypeInt         = "int"
+	GenTypeArray       = "array"
+	GenTypePermutation = "permutation"
+	GenTypeString      = "string"
+	GenTypeGraph       = "graph"
+)
+
+// genNamePattern restricts variable names to what can be used as a placeholder
+var genNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// genValuePattern matches references to earlier int variables such as "n",
+// "-n", "n-1" or "2*n+1"
+var genValuePattern = regexp.MustCompile(`^(-)?(?:(\d+)\s*\*\s*)?([a-z_][a-z0-9_]*)(?:\s*([+-])\s*(\d+))?$`)
+
+// GenSpec describes random inputs of a problem: variables generated in order
+// and a format that prints them
+type GenSpec struct {
+	// Format is the input with {name} placeholders. Without it each variable
+	// is printed on its own line
+	Format string   `toml:"format"`
+	Vars   []GenVar `toml:"var"`
+}
+
+// GenVar describes one generated variable
+type GenVar struct {
+	Name string `toml:"name"`
+	Type string `toml:"type"` // int, array, permutation, string or graph
+
+	// Min and Max bound ints, array elements and edge weights, inclusive
+	Min GenValue `toml:"min"`
+	Max GenValue `toml:"max"`
+	// Length is the length of arrays, permutations and strings
+	Length GenValue `toml:"length"`
+
+	Distinct  bool   `toml:"distinct"`  // array elements differ from each other
+	Sorted    bool   `toml:"sorted"`    // array elements are in ascending order
+	Separator string `toml:"separator"` // between array elements, " " by default
+	Alphabet  string `toml:"alphabet"`  // characters of strings, a-z by default
+
+	Vertices  GenValue `toml:"vertices"`
+	Edges     GenValue `toml:"edges"` // n-1 for trees
+	Tree      bool     `toml:"tree"`
+	Connected bool     `toml:"connected"`
+	Directed  bool     `toml:"directed"`
+	Weighted  bool     `toml:"weighted"` // each edge line ends with a weight between Min and Max
+}
+
+// GenValue is an integer given literally, e.g. 100000, or as a multiple of
+// an earlier int variable plus an offset, e.g. "n", "-n", "n-1" or "2*n"
+type GenValue struct {
+	Var    string // empty for literals
+	Scale  int64  // multiplies the variable, 1 if not given
+	Offset int64  // the literal, or what is added to the variable
+	Set    bool   // the value was given in the spec
+}
+
+// UnmarshalTOML implements toml.Unmarshaler for integers and strings
+func (v *GenValue) UnmarshalTOML(data any) error {
+	switch value := data.(type) {
+	case int64:
+		*v = GenValue{Offset: value, Set: true}
+		return nil
+	case string:
+		return v.parse(value)
+	default:
+		return fmt.Errorf("expected an integer or a variable such as \"n-1\", got %v", data)
+	}
+}
+
+// parse reads a literal or a reference to a variable
+func (v *GenValue) parse(text string) error {
+	if literal, err := strconv.ParseInt(text, 10, 64); err == nil {
+		*v = GenValue{Offset: literal, Set: true}
+		return nil
+	}
+	match := genValuePattern.FindStringSubmatch(text)
+	if match == nil {
+		return fmt.Errorf("invalid value %q, use an integer or a variable such as \"n\", \"n-1\" or \"2*n\"", text)
+	}
+
+	*v = GenValue{Var: match[3], Scale: 1, Set: true}
+	if match[2] != "" {
+		v.Scale, _ = strconv.ParseInt(match[2], 10, 64)
+	}
+	if match[1] == "-" {
+		v.Scale = -v.Scale
+	}
+	if match[5] != "" {
+		v.Offset, _ = strconv.ParseInt(match[5], 10, 64)
+		if match[4] == "-" {
+			v.Offset = -v.Offset
+		}
+	}
+	return nil
+}
+
+// Resolve returns the value given the int variables generated so far
+func (v GenValue) Resolve(vars map[string]int64) (int64, error) {
+	if v.Var == "" {
+		return v.Offset, nil
+	}
+	value, ok := vars[v.Var]
+	if !ok {
+		return 0, fmt.Errorf("%s is not an int variable defined before", v.Var)
+	}
+	return v.Scale*value + v.Offset, nil
+}
+
+// LoadGenSpec loads the generator spec of a test directory
+func LoadGenSpec(testDir string) (*GenSpec, error) {
+	path := filepath.Join(testDir, GenSpecFile)
+	var spec GenSpec
+	if _, err := toml.DecodeFile(path, &spec); err != nil {
+		if os.IsNotExist(err) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				fmt.Sprintf("%s not found; describe the input there first", path),
+				err,
+			)
+		}
+		return nil, cerrors.NewAppError(cerrors.CodeInvalidInput, "failed to decode "+GenSpecFile, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks the names and types of the variables
+func (s *GenSpec) Validate() error {
+	if len(s.Vars) == 0 {
+		return invalidConfig("%s defines no [[var]]", GenSpecFile)
+	}
+
+	seen := make(map[string]bool)
+	for i, v := range s.Vars {
+		if !genNamePattern.MatchString(v.Name) {
+			return invalidConfig("%s: var %d has an invalid name %q; use lowercase letters, digits and _", GenSpecFile, i+1, v.Name)
+		}
+		if seen[v.Name] {
+			return invalidConfig("%s: var %s is defined twice", GenSpecFile, v.Name)
+		}
+		seen[v.Name] = true
+
+		switch v.Type {
+		case GenTypeInt, GenTypeArray:
+			if !v.Min.Set || !v.Max.Set {
+				return invalidConfig("%s: var %s needs min and max", GenSpecFile, v.Name)
+			}
+		case GenTypePermutation, GenTypeString:
+		case GenTypeGraph:
+			if !v.Vertices.Set {
+				return invalidConfig("%s: graph %s needs vertices", GenSpecFile, v.Name)
+			}
+			if !v.Tree && !v.Edges.Set {
+				return invalidConfig("%s: graph %s needs edges unless it is a tree", GenSpecFile, v.Name)
+			}
+			if v.Weighted && (!v.Min.Set || !v.Max.Set) {
+				return invalidConfig("%s: weighted graph %s needs min and max weights", GenSpecFile, v.Name)
+			}
+		default:
+			return invalidConfig("%s: var %s has unknown type %q (allowed: int, array, permutation, string, graph)", GenSpecFile, v.Name, v.Type)
+		}
+		if (v.Type == GenTypeArray || v.Type == GenTypePermutation || v.Type == GenTypeString) && !v.Length.Set {
+			return invalidConfig("%s: var %s needs a length", GenSpecFile, v.Name)
+		}
+	}
+	return nil
+}
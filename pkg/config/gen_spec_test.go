@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestLoadGenSpec(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	content := `format = "{n}\n{a}"
+
+[[var]]
+name = "n"
+type = "int"
+min = 1
+max = 100_000
+
+[[var]]
+name = "a"
+type = "array"
+length = "2*n-1"
+min = "-n"
+max = "n"
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, GenSpecFile), []byte(content), 0644))
+
+	// When
+	spec, err := LoadGenSpec(dir)
+
+	// Then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, "{n}\n{a}", spec.Format)
+	assert.Len(t, spec.Vars, 2)
+	assert.Equal(t, GenValue{Offset: 100000, Set: true}, spec.Vars[0].Max)
+	assert.Equal(t, GenValue{Var: "n", Scale: 2, Offset: -1, Set: true}, spec.Vars[1].Length)
+	assert.Equal(t, GenValue{Var: "n", Scale: -1, Set: true}, spec.Vars[1].Min)
+	assert.Equal(t, GenValue{Var: "n", Scale: 1, Set: true}, spec.Vars[1].Max)
+
+	length, err := spec.Vars[1].Length.Resolve(map[string]int64{"n": 5})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), length)
+	_, err = spec.Vars[1].Length.Resolve(map[string]int64{})
+	assert.Error(t, err)
+}
+
+func TestLoadGenSpec_Missing(t *testing.T) {
+	_, err := LoadGenSpec(t.TempDir())
+
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+}
+
+func TestGenSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no vars", content: `format = "1"`, wantErr: "no [[var]]"},
+		{name: "bad name", content: "[[var]]\nname = \"N\"\ntype = \"int\"\nmin = 1\nmax = 2", wantErr: "invalid name"},
+		{name: "duplicate", content: "[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 1\nmax = 2\n[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 1\nmax = 2", wantErr: "defined twice"},
+		{name: "unknown type", content: "[[var]]\nname = \"x\"\ntype = \"float\"", wantErr: "unknown type"},
+		{name: "int without max", content: "[[var]]\nname = \"n\"\ntype = \"int\"\nmin = 1", wantErr: "needs min and max"},
+		{name: "string without length", content: "[[var]]\nname = \"s\"\ntype = \"string\"", wantErr: "needs a length"},
+		{name: "graph without edges", content: "[[var]]\nname = \"g\"\ntype = \"graph\"\nvertices = 5", wantErr: "needs edges"},
+		{name: "bad reference", content: "[[var]]\nname = \"s\"\ntype = \"string\"\nlength = \"n/2\"", wantErr: "invalid value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, GenSpecFile), []byte(tt.content), 0644))
+
+			// When
+			_, err := LoadGenSpec(dir)
+
+			// Then
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.wantErr)
+			}
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// CurrentVersion is the layout version of config.toml written by this build.
+// Files without a version key are version 0
+const CurrentVersion = 1
+
+// migration upgrades the raw contents of config.toml from one version to the
+// next. apply reports whether anything besides the version had to change
+type migration struct {
+	from        int
+	description string
+	apply       func(raw map[string]interface{}) bool
+}
+
+// migrations are applied in order to files older than CurrentVersion.
+// Append a migration whenever a key is renamed or a section is restructured
+var migrations = []migration{
+	{
+		from:        0,
+		description: "move [user] keys to [init] and [submit], rename submit.wait_result to submit.watch",
+		apply:       migrateLegacyUserSection,
+	},
+}
+
+// migrate upgrades raw to CurrentVersion. It returns whether the file content
+// changed and has to be written back
+func migrate(raw map[string]interface{}) (bool, error) {
+	version, err := rawVersion(raw)
+	if err != nil {
+		return false, err
+	}
+	if version > CurrentVersion {
+		return false, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			fmt.Sprintf("config.toml has version %d but this aoj only understands up to version %d. Please upgrade aoj", version, CurrentVersion),
+			nil,
+		)
+	}
+
+	changed := false
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		if m.apply(raw) {
+			logger.Info("migrated config", "from_version", m.from, "change", m.description)
+			changed = true
+		}
+	}
+	if changed {
+		raw["version"] = int64(CurrentVersion)
+	}
+	return changed, nil
+}
+
+// rawVersion returns the version key of raw, 0 when it is missing
+func rawVersion(raw map[string]interface{}) (int, error) {
+	value, ok := raw["version"]
+	if !ok {
+		return 0, nil
+	}
+	version, ok := value.(int64)
+	if !ok || version < 0 {
+		return 0, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"config version must be a non-negative integer",
+			nil,
+		)
+	}
+	return int(version), nil
+}
+
+// writeMigrated replaces the config file with the migrated contents, keeping
+// the original next to it as <file>.bak
+func writeMigrated(filePath string, original, migrated []byte) error {
+	trace.File("write", filePath+".bak")
+	if err := os.WriteFile(filePath+".bak", original, 0644); err != nil {
+		return cerrors.Wrap(err, "failed to back up config file")
+	}
+	trace.File("write", filePath)
+	if err := os.WriteFile(filePath, migrated, 0644); err != nil {
+		return cerrors.Wrap(err, "failed to write migrated config file")
+	}
+	return nil
+}
+
+// migrateLegacyUserSection upgrades the original layout, which kept the
+// default language and template in [user], the test timeout in milliseconds
+// and the watch setting as submit.wait_result
+func migrateLegacyUserSection(raw map[string]interface{}) bool {
+	changed := moveKey(raw, "submit", "wait_result", "submit", "watch")
+
+	user, ok := raw["user"].(map[string]interface{})
+	if !ok {
+		return changed
+	}
+
+	if language, ok := user["default_language"].(string); ok {
+		if lang, found := GetLanguageConfig(language); found {
+			language = lang.AOJLanguageID
+		}
+		setDefault(raw, "init", "language", language)
+		setDefault(raw, "submit", "language", language)
+		delete(user, "default_language")
+	}
+	moveKey(raw, "user", "default_template", "init", "template_file")
+
+	// Only the layout with [user] measured the timeout in milliseconds
+	if test, ok := raw["test"].(map[string]interface{}); ok {
+		switch timeout := test["timeout"].(type) {
+		case int64:
+			test["timeout"] = float64(timeout) / 1000
+		case float64:
+			test["timeout"] = timeout / 1000
+		}
+	}
+
+	if len(user) == 0 {
+		delete(raw, "user")
+	}
+	return true
+}
+
+// moveKey renames fromTable.fromKey to toTable.toKey unless the new key is
+// already set, and reports whether the old key existed
+func moveKey(raw map[string]interface{}, fromTable, fromKey, toTable, toKey string) bool {
+	from, ok := raw[fromTable].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	value, ok := from[fromKey]
+	if !ok {
+		return false
+	}
+	delete(from, fromKey)
+	setDefault(raw, toTable, toKey, value)
+	return true
+}
+
+// setDefault sets table.key, creating the table, unless the key is already set
+func setDefault(raw map[string]interface{}, table, key string, value interface{}) {
+	section, ok := raw[table].(map[string]interface{})
+	if !ok {
+		section = make(map[string]interface{})
+		raw[table] = section
+	}
+	if _, exists := section[key]; !exists {
+		section[key] = value
+	}
+}
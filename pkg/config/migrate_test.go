@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+func TestLoad_MigratesLegacyLayout(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "config.toml")
+	legacy := `[user]
+default_language = "python"
+default_template = "~/templates/main.py"
+
+[test]
+timeout = 3000
+diff_mode = "unified"
+
+[submit]
+wait_result = false
+`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// When
+	cfg, err := Load(path)
+
+	// Then
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	assert.Equal(t, CurrentVersion, cfg.Version)
+	assert.Equal(t, "Python3", cfg.Init.Language)
+	assert.Equal(t, "Python3", cfg.Submit.Language)
+	assert.Equal(t, "~/templates/main.py", cfg.Init.TemplateFile)
+	assert.Equal(t, 3.0, cfg.Test.Timeout)
+	assert.False(t, cfg.Submit.Watch)
+
+	backup, err := os.ReadFile(path + ".bak")
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, string(backup))
+
+	// The migrated file is loaded as is the next time
+	reloaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, reloaded)
+	written, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(written), "[user]")
+	assert.Contains(t, string(written), "diff_mode", "unknown keys are kept")
+}
+
+func TestLoad_MigrationIsTraced(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	trace.Enable(&buf)
+	defer trace.Enable(nil)
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[submit]\nwait_result = false\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// When
+	_, err := Load(path)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "write "+path+".bak\n")
+	assert.Contains(t, buf.String(), "write "+path+"\n")
+}
+
+func TestLoad_CurrentLayoutIsNotRewritten(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "# my settings\n[test]\ntimeout = 2.5\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// When
+	cfg, err := Load(path)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, cfg.Test.Timeout)
+	written, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(written), "comments survive when there is nothing to migrate")
+	_, err = os.Stat(path + ".bak")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLoad_RejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("version = 99\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	_, err := Load(path)
+
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+}
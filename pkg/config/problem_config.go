@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// ProblemConfigFile is the name of the configuration file stored in each problem directory
+const ProblemConfigFile = "problem.toml"
+
+// ProblemConfig holds problem metadata stored in a problem directory
+type ProblemConfig struct {
+	ProblemID   string  `toml:"problem_id"`
+	Title       string  `toml:"title,omitempty"`
+	TimeLimit   float64 `toml:"time_limit,omitempty"`         // in seconds
+	MemoryLimit int64   `toml:"memory_limit,omitempty"`       // in KB
+	SourceFile  string  `toml:"source_file,omitempty"`        // solution file if it differs from init.source_file
+	Interactor  string  `toml:"interactor_command,omitempty"` // interactor of an interactive problem, overrides test.interactor_command
+}
+
+// TimeLimitDuration returns the time limit as a duration, or 0 if unknown
+func (c *ProblemConfig) TimeLimitDuration() time.Duration {
+	return time.Duration(c.TimeLimit * float64(time.Second))
+}
+
+// LoadProblemConfig loads the problem configuration from a problem directory
+func LoadProblemConfig(dir string) (*ProblemConfig, error) {
+	filePath := filepath.Join(dir, ProblemConfigFile)
+
+	var config ProblemConfig
+	if _, err := toml.DecodeFile(filePath, &config); err != nil {
+		if os.IsNotExist(err) {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeNotFound,
+				ProblemConfigFile+" not found in "+dir,
+				err,
+			)
+		}
+		return nil, cerrors.Wrap(err, "failed to decode problem config")
+	}
+
+	return &config, nil
+}
+
+// SaveProblemConfig saves the problem configuration to a problem directory
+func SaveProblemConfig(dir string, config *ProblemConfig) error {
+	trace.File("write", filepath.Join(dir, ProblemConfigFile))
+	file, err := os.Create(filepath.Join(dir, ProblemConfigFile))
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create problem config")
+	}
+
+	if err := toml.NewEncoder(file).Encode(config); err != nil {
+		_ = file.Close()
+		return cerrors.Wrap(err, "failed to encode problem config")
+	}
+
+	if err := file.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to write problem config")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestSaveAndLoadProblemConfig(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	original := &ProblemConfig{
+		ProblemID:   "ALDS1_1_A",
+		Title:       "Insertion Sort",
+		TimeLimit:   0.5,
+		MemoryLimit: 131072,
+	}
+
+	// When
+	err := SaveProblemConfig(dir, original)
+	assert.NoError(t, err)
+	loaded, err := LoadProblemConfig(dir)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, original, loaded)
+	assert.Equal(t, 500*time.Millisecond, loaded.TimeLimitDuration())
+}
+
+func TestLoadProblemConfigNotFound(t *testing.T) {
+	// When
+	_, err := LoadProblemConfig(t.TempDir())
+
+	// Then
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound))
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// ProfileEnv is the environment variable that selects a profile when the
+// --profile flag is not given
+const ProfileEnv = "AOJ_PROFILE"
+
+// LoadWithProfile loads configuration from the specified file and applies the
+// named profile on top of it. An empty name applies no profile
+func LoadWithProfile(filePath, profile string) (*Config, error) {
+	config, err := Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		return config, nil
+	}
+	if err := config.ApplyProfile(profile); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadDefaultWithProfile loads configuration from the default location with
+// the named profile, or the one in AOJ_PROFILE when name is empty
+func LoadDefaultWithProfile(profile string) (*Config, error) {
+	if profile == "" {
+		profile = os.Getenv(ProfileEnv)
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadWithProfile(configPath, profile)
+}
+
+// ApplyProfile overrides the settings of c with those of the named profile
+func (c *Config) ApplyProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		message := "profile " + name + " is not defined in config.toml"
+		if len(c.Profiles) > 0 {
+			message += ". Available profiles: " + strings.Join(slices.Sorted(maps.Keys(c.Profiles)), ", ")
+		}
+		return cerrors.NewAppError(cerrors.CodeNotFound, message, nil)
+	}
+
+	if _, err := decodeProfile(profile, c); err != nil {
+		return cerrors.Wrap(err, "failed to apply profile "+name)
+	}
+	return nil
+}
+
+// checkProfiles reports unknown keys in every profile, so that a misspelling
+// is noticed before the profile is used
+func checkProfiles(config *Config) error {
+	for _, name := range slices.Sorted(maps.Keys(config.Profiles)) {
+		meta, err := decodeProfile(config.Profiles[name], DefaultConfig())
+		if err != nil {
+			return cerrors.Wrap(err, "failed to decode profile "+name)
+		}
+		if err := checkUnknownKeys(meta, "profiles."+name+".", config.Strict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeProfile decodes the settings of a profile into config, overriding
+// only the keys the profile sets
+func decodeProfile(profile map[string]interface{}, config *Config) (toml.MetaData, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(profile); err != nil {
+		return toml.MetaData{}, err
+	}
+	return toml.Decode(buf.String(), config)
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+const profilesConfig = `[submit]
+language = "C++17"
+watch = true
+
+[profiles.contest.submit]
+watch = false
+
+[profiles.practice.submit]
+language = "Python3"
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadWithProfile(t *testing.T) {
+	// Given
+	path := writeConfig(t, profilesConfig)
+
+	// When
+	base, baseErr := LoadWithProfile(path, "")
+	contest, contestErr := LoadWithProfile(path, "contest")
+
+	// Then
+	if baseErr != nil || contestErr != nil {
+		t.Fatalf("LoadWithProfile failed: %v, %v", baseErr, contestErr)
+	}
+	assert.True(t, base.Submit.Watch)
+	assert.False(t, contest.Submit.Watch)
+	assert.Equal(t, "C++17", contest.Submit.Language, "settings the profile does not set are kept")
+	assert.Equal(t, base.Test, contest.Test)
+}
+
+func TestLoadWithProfile_Undefined(t *testing.T) {
+	path := writeConfig(t, profilesConfig)
+
+	_, err := LoadWithProfile(path, "icpc")
+
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeNotFound), "got %v", err)
+	assert.ErrorContains(t, err, "Available profiles: contest, practice")
+}
+
+func TestLoadDefaultWithProfile_Environment(t *testing.T) {
+	// Given
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(ProfileEnv, "practice")
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath failed: %v", err)
+	}
+	assert.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+	assert.NoError(t, os.WriteFile(configPath, []byte(profilesConfig), 0644))
+
+	// When
+	fromEnv, envErr := LoadDefaultWithProfile("")
+	fromFlag, flagErr := LoadDefaultWithProfile("contest")
+
+	// Then
+	assert.NoError(t, envErr)
+	assert.NoError(t, flagErr)
+	assert.Equal(t, "Python3", fromEnv.Submit.Language)
+	assert.Equal(t, "C++17", fromFlag.Submit.Language, "the flag takes precedence over the environment")
+}
+
+func TestLoad_UnknownKeysInProfile(t *testing.T) {
+	path := writeConfig(t, "strict = true\n[profiles.contest.submit]\nwach = false\n")
+
+	_, err := Load(path)
+
+	assert.ErrorContains(t, err, "profiles.contest.submit.wach (did you mean profiles.contest.submit.watch?)")
+}
+
+func TestSave_KeepsProfiles(t *testing.T) {
+	// Given
+	path := writeConfig(t, profilesConfig)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// When
+	cfg.Workspace.Root = "/tmp/aoj"
+	assert.NoError(t, Save(cfg, path))
+
+	// Then
+	contest, err := LoadWithProfile(path, "contest")
+	assert.NoError(t, err)
+	assert.False(t, contest.Submit.Watch)
+	assert.Equal(t, "/tmp/aoj", contest.Workspace.Root)
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// TestChecksumFile is the name of the file in the test directory holding the
+// SHA-256 sums of the downloaded test data, in the format of sha256sum so that
+// 'sha256sum -c' can check it too
+const TestChecksumFile = "checksums.sha256"
+
+// TestChecksums maps the file names in a test directory to their SHA-256 sums in hex
+type TestChecksums map[string]string
+
+// LoadTestChecksums loads the checksums of a test directory. A missing file
+// yields no checksums
+func LoadTestChecksums(testDir string) (TestChecksums, error) {
+	file, err := os.Open(filepath.Join(testDir, TestChecksumFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TestChecksums{}, nil
+		}
+		return nil, cerrors.Wrap(err, "failed to open "+TestChecksumFile)
+	}
+	defer func() { _ = file.Close() }()
+
+	sums := TestChecksums{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		sum, name, ok := strings.Cut(text, " ")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		if !ok || len(sum) != 64 || name == "" {
+			return nil, cerrors.NewAppError(
+				cerrors.CodeInvalidInput,
+				fmt.Sprintf("invalid line %d in %s", line, TestChecksumFile),
+				nil,
+			)
+		}
+		sums[name] = strings.ToLower(sum)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, cerrors.Wrap(err, "failed to read "+TestChecksumFile)
+	}
+	return sums, nil
+}
+
+// SaveTestChecksums saves the checksums to a test directory, sorted by file name
+func SaveTestChecksums(testDir string, sums TestChecksums) error {
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", sums[name], name)
+	}
+	trace.File("write", filepath.Join(testDir, TestChecksumFile))
+	if err := os.WriteFile(filepath.Join(testDir, TestChecksumFile), []byte(b.String()), 0644); err != nil {
+		return cerrors.Wrap(err, "failed to write "+TestChecksumFile)
+	}
+	return nil
+}
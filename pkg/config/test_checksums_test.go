@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndLoadTestChecksums(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	sums := TestChecksums{
+		"sample-1.out": strings.Repeat("b", 64),
+		"sample-1.in":  strings.Repeat("a", 64),
+	}
+
+	// When
+	err := SaveTestChecksums(dir, sums)
+	assert.NoError(t, err)
+	loaded, err := LoadTestChecksums(dir)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, sums, loaded)
+	content, err := os.ReadFile(filepath.Join(dir, TestChecksumFile))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 64)+"  sample-1.in\n"+strings.Repeat("b", 64)+"  sample-1.out\n", string(content))
+}
+
+func TestLoadTestChecksums(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    TestChecksums
+		wantErr bool
+	}{
+		{name: "binary mode marker", content: strings.Repeat("A", 64) + " *judge-1.in\n\n", want: TestChecksums{"judge-1.in": strings.Repeat("a", 64)}},
+		{name: "short sum", content: "abc  judge-1.in\n", wantErr: true},
+		{name: "missing name", content: strings.Repeat("a", 64) + "\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, TestChecksumFile), []byte(tt.content), 0644))
+
+			// When
+			sums, err := LoadTestChecksums(dir)
+
+			// Then
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sums)
+		})
+	}
+}
+
+func TestLoadTestChecksums_Missing(t *testing.T) {
+	// When
+	sums, err := LoadTestChecksums(t.TempDir())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, sums)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// TestMetaFile is the name of the optional test case metadata file in the test directory
+const TestMetaFile = "meta.toml"
+
+// TestMeta holds annotations of the test cases in a test directory
+type TestMeta struct {
+	Cases map[string]CaseMeta `toml:"cases,omitempty"` // keyed by case name, e.g. "sample-1"
+}
+
+// CaseMeta holds the annotations of a single test case
+type CaseMeta struct {
+	Timeout     float64 `toml:"timeout,omitempty"` // in seconds, overrides the time limit
+	Skip        bool    `toml:"skip,omitempty"`
+	Description string  `toml:"description,omitempty"`
+}
+
+// TimeoutDuration returns the timeout as a duration, or 0 if not set
+func (m CaseMeta) TimeoutDuration() time.Duration {
+	return time.Duration(m.Timeout * float64(time.Second))
+}
+
+// IsZero returns true if the case has no annotations
+func (m CaseMeta) IsZero() bool {
+	return m == CaseMeta{}
+}
+
+// Case returns the annotations of a test case
+func (m *TestMeta) Case(name string) CaseMeta {
+	return m.Cases[name]
+}
+
+// SetCase sets the annotations of a test case, removing cases without any
+func (m *TestMeta) SetCase(name string, meta CaseMeta) {
+	if meta.IsZero() {
+		delete(m.Cases, name)
+		return
+	}
+	if m.Cases == nil {
+		m.Cases = make(map[string]CaseMeta)
+	}
+	m.Cases[name] = meta
+}
+
+// LoadTestMeta loads the test case metadata of a test directory. A missing
+// file yields empty metadata
+func LoadTestMeta(testDir string) (*TestMeta, error) {
+	var meta TestMeta
+	if _, err := toml.DecodeFile(filepath.Join(testDir, TestMetaFile), &meta); err != nil {
+		if os.IsNotExist(err) {
+			return &TestMeta{}, nil
+		}
+		return nil, cerrors.Wrap(err, "failed to decode "+TestMetaFile)
+	}
+	return &meta, nil
+}
+
+// SaveTestMeta saves the test case metadata to a test directory
+func SaveTestMeta(testDir string, meta *TestMeta) error {
+	trace.File("write", filepath.Join(testDir, TestMetaFile))
+	file, err := os.Create(filepath.Join(testDir, TestMetaFile))
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create "+TestMetaFile)
+	}
+
+	if err := toml.NewEncoder(file).Encode(meta); err != nil {
+		_ = file.Close()
+		return cerrors.Wrap(err, "failed to encode "+TestMetaFile)
+	}
+
+	if err := file.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to write "+TestMetaFile)
+	}
+	return nil
+}
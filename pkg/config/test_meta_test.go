@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndLoadTestMeta(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	original := &TestMeta{}
+	original.SetCase("sample-1", CaseMeta{Timeout: 5, Description: "large input"})
+	original.SetCase("judge-3", CaseMeta{Skip: true})
+	original.SetCase("sample-2", CaseMeta{})
+
+	// When
+	err := SaveTestMeta(dir, original)
+	assert.NoError(t, err)
+	loaded, err := LoadTestMeta(dir)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, original, loaded)
+	assert.Len(t, loaded.Cases, 2)
+	assert.Equal(t, 5*time.Second, loaded.Case("sample-1").TimeoutDuration())
+	assert.True(t, loaded.Case("judge-3").Skip)
+	assert.True(t, loaded.Case("sample-2").IsZero())
+}
+
+func TestLoadTestMeta_Missing(t *testing.T) {
+	// When
+	meta, err := LoadTestMeta(t.TempDir())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, meta.Cases)
+}
+
+func TestLoadTestMeta_Invalid(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, TestMetaFile), []byte("[cases\n"), 0644))
+
+	// When
+	_, err := LoadTestMeta(dir)
+
+	// Then
+	assert.Error(t, err)
+}
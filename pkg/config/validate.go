@@ -0,0 +1,174 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/fuzzy"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/logger"
+)
+
+// sourceFilePlaceholder is replaced with the solution file in build and run commands
+const sourceFilePlaceholder = "{file}"
+
+// placeholderPattern matches {name} placeholders in commands and templates
+var placeholderPattern = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// checkUnknownKeys reports keys of the config file that no setting uses,
+// usually misspellings. They are errors in strict mode and warnings otherwise.
+// prefix is prepended to the reported keys
+func checkUnknownKeys(meta toml.MetaData, prefix string, strict bool) error {
+	var undecoded []toml.Key
+	for _, key := range meta.Undecoded() {
+		// Profiles are checked on their own by checkProfiles
+		if prefix == "" && key[0] == "profiles" {
+			continue
+		}
+		undecoded = append(undecoded, key)
+	}
+	if len(undecoded) == 0 {
+		return nil
+	}
+
+	known := knownKeys(reflect.TypeOf(Config{}), "")
+	problems := make([]string, 0, len(undecoded))
+	for _, key := range undecoded {
+		problem := prefix + key.String()
+		if suggestion, ok := fuzzy.Closest(key.String(), known); ok {
+			problem += fmt.Sprintf(" (did you mean %s%s?)", prefix, suggestion)
+		}
+		if !strict {
+			logger.Warn("unknown key in config file", "key", problem)
+		}
+		problems = append(problems, problem)
+	}
+
+	if !strict {
+		return nil
+	}
+	return cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		"unknown keys in config file: "+strings.Join(problems, ", "),
+		nil,
+	)
+}
+
+// knownKeys lists the dotted keys of the settings in t
+func knownKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+		if field.Type.Kind() == reflect.Struct {
+			keys = append(keys, knownKeys(field.Type, key+".")...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ValidateLanguages checks that every language can be built, run and submitted
+func ValidateLanguages(languages Languages) error {
+	for _, name := range slices.Sorted(maps.Keys(languages)) {
+		lang := languages[name]
+		switch {
+		case lang.Extension == "":
+			return invalidConfig("language %s has no extension", name)
+		case strings.TrimSpace(lang.RunCommand) == "":
+			return invalidConfig("language %s has no run_command", name)
+		case lang.AOJLanguageID == "":
+			return invalidConfig("language %s has no aoj_language_id", name)
+		}
+		if err := validateCommand("languages."+name+".build_command", lang.BuildCommand, sourceFilePlaceholder); err != nil {
+			return err
+		}
+		if err := validateCommand("languages."+name+".run_command", lang.RunCommand, sourceFilePlaceholder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCommands checks the commands run by aoj test and the templates of submit
+func validateCommands(config *Config) error {
+	commands := []struct {
+		key          string
+		value        string
+		placeholders []string
+	}{
+		{"test.build_command", config.Test.BuildCommand, []string{sourceFilePlaceholder}},
+		{"test.run_command", config.Test.RunCommand, []string{sourceFilePlaceholder}},
+		{"test.interactor_command", config.Test.InteractorCommand, []string{"{input}", "{output}"}},
+		{"submit.git_tag", config.Submit.GitTag, []string{"{problem}", "{id}"}},
+	}
+	for _, command := range commands {
+		if err := validateCommand(command.key, command.value, command.placeholders...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCommand checks that a command line has balanced quotes and only uses
+// the given placeholders. Empty commands are valid
+func validateCommand(key, command string, placeholders ...string) error {
+	if command == "" {
+		return nil
+	}
+
+	var quote rune
+	escaped := false
+	for _, r := range command {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case r == quote:
+			quote = 0
+		}
+	}
+	if quote != 0 {
+		return invalidConfig("%s has an unterminated %c quote", key, quote)
+	}
+
+	for _, loc := range placeholderPattern.FindAllStringIndex(command, -1) {
+		placeholder := command[loc[0]:loc[1]]
+		// ${name} is a shell variable, not a placeholder
+		if loc[0] > 0 && command[loc[0]-1] == '$' {
+			continue
+		}
+		if !slices.Contains(placeholders, placeholder) {
+			return invalidConfig("%s uses unknown placeholder %s (allowed: %s)", key, placeholder, strings.Join(placeholders, ", "))
+		}
+	}
+	return nil
+}
+
+// validateAPIURL checks that the value of key is an absolute http(s) URL
+func validateAPIURL(key, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalidConfig("%s must be an http or https URL, got %q", key, value)
+	}
+	return nil
+}
+
+// invalidConfig returns an invalid input error with a formatted message
+func invalidConfig(format string, args ...interface{}) error {
+	return cerrors.NewAppError(cerrors.CodeInvalidInput, fmt.Sprintf(format, args...), nil)
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestLoad_UnknownKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "warning by default", content: "[test]\ntimeot = 3.0\n"},
+		{name: "error in strict mode", content: "strict = true\n[test]\ntimeot = 3.0\n", wantErr: "test.timeot (did you mean test.timeout?)"},
+		{name: "known keys in strict mode", content: "strict = true\n[test]\ntimeout = 3.0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			// When
+			_, err := Load(path)
+
+			// Then
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), "got %v", err)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateConfig_Commands(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(*Config) {}},
+		{name: "shell syntax", modify: func(c *Config) { c.Test.RunCommand = `sh -c 'echo "${HOME}"' && find . -exec echo {} \;` }},
+		{name: "escaped quote", modify: func(c *Config) { c.Test.RunCommand = `echo \"` }},
+		{name: "unterminated quote", modify: func(c *Config) { c.Test.BuildCommand = `g++ "main.cpp` }, wantErr: "test.build_command has an unterminated \" quote"},
+		{name: "unknown placeholder", modify: func(c *Config) { c.Test.InteractorCommand = "judge {input} {file}" }, wantErr: "test.interactor_command uses unknown placeholder {file}"},
+		{name: "git tag", modify: func(c *Config) { c.Submit.GitTag = "ac/{problem}-{id}" }},
+		{name: "misspelled git tag placeholder", modify: func(c *Config) { c.Submit.GitTag = "ac/{problme}" }, wantErr: "submit.git_tag uses unknown placeholder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(config)
+
+			err := ValidateConfig(config)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateLanguages(t *testing.T) {
+	assert.NoError(t, ValidateLanguages(DefaultLanguages()))
+
+	err := ValidateLanguages(Languages{"rust": {Extension: "rs", RunCommand: "./main"}})
+	assert.ErrorContains(t, err, "language rust has no aoj_language_id")
+
+	err = ValidateLanguages(Languages{"rust": {Extension: "rs", BuildCommand: "rustc {src}", RunCommand: "./main", AOJLanguageID: "Rust"}})
+	assert.ErrorContains(t, err, "languages.rust.build_command uses unknown placeholder {src}")
+}
+
+func TestLoad_SubmitTransform(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "strict = true\n[submit.transform.\"C++\"]\nprelude = \"#define NDEBUG\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SourceTransform{Prelude: "#define NDEBUG"}, cfg.Submit.Transform["C++"])
+}
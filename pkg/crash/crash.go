@@ -0,0 +1,60 @@
+// Package crash writes local crash reports for panics and unexpected errors.
+package crash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// DirName is the name of the crash report directory inside the config directory
+const DirName = "crash"
+
+// IsUnexpected reports whether an error indicates a bug rather than a user or network problem
+func IsUnexpected(err error) bool {
+	return cerrors.IsAppError(err, cerrors.CodeInternalServer)
+}
+
+// PanicError converts a recovered panic value into an unexpected error
+// carrying the stack of the panic
+func PanicError(recovered any) error {
+	return cerrors.WithDetail(
+		cerrors.NewAppError(cerrors.CodeInternalServer, fmt.Sprintf("panic: %v", recovered), nil),
+		string(debug.Stack()),
+	)
+}
+
+// WriteReport writes a crash report for err into dir and returns its path.
+// The report stays on the local machine and is never sent anywhere; secrets
+// in the arguments and the error are masked so that it can be shared
+func WriteReport(dir string, err error, args []string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", cerrors.Wrap(err, "failed to create crash report directory")
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "AOJ CLI crash report\n\n")
+	fmt.Fprintf(&report, "Time: %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&report, "Command: %s\n", strings.Join(redact.Args(args), " "))
+	fmt.Fprintf(&report, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(&report, "Module: %s %s\n", info.Main.Path, info.Main.Version)
+	}
+	// %+v prints the full error chain with stack traces and details
+	fmt.Fprintf(&report, "\nError:\n%s\n", redact.Text(fmt.Sprintf("%+v", err)))
+
+	path := filepath.Join(dir, now.Format("20060102-150405")+".log")
+	trace.File("write", path)
+	if err := os.WriteFile(path, []byte(report.String()), 0600); err != nil {
+		return "", cerrors.Wrap(err, "failed to write crash report")
+	}
+	return path, nil
+}
@@ -0,0 +1,39 @@
+package crash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestIsUnexpected(t *testing.T) {
+	assert.True(t, IsUnexpected(cerrors.Wrap(cerrors.NewAppError(cerrors.CodeInternalServer, "boom", nil), "submit")))
+	assert.True(t, IsUnexpected(PanicError("index out of range")))
+	assert.False(t, IsUnexpected(cerrors.NewAppError(cerrors.CodeNetworkError, "failed to connect", nil)))
+	assert.False(t, IsUnexpected(cerrors.New("file not found")))
+}
+
+func TestWriteReport(t *testing.T) {
+	// Given
+	dir := filepath.Join(t.TempDir(), DirName)
+	err := cerrors.Wrap(PanicError("nil map"), "submission failed")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// When
+	path, writeErr := WriteReport(dir, err, []string{"aoj", "submit", "-p", "ITP1_1_A"}, now)
+
+	// Then
+	assert.NoError(t, writeErr)
+	assert.Equal(t, filepath.Join(dir, "20240102-030405.log"), path)
+
+	content, readErr := os.ReadFile(path)
+	assert.NoError(t, readErr)
+	assert.Contains(t, string(content), "Command: aoj submit -p ITP1_1_A")
+	assert.Contains(t, string(content), "submission failed: panic: nil map")
+	assert.Contains(t, string(content), "TestWriteReport")
+}
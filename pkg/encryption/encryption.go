@@ -0,0 +1,208 @@
+// Package encryption provides authenticated encryption for data stored on disk.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+const (
+	envelopeVersion = 1
+	algorithmName   = "AES-256-GCM/PBKDF2-SHA256"
+	saltSize        = 16
+	keySize         = 32
+	kdfIterations   = 100000
+)
+
+// PassphraseEnvVar is the environment variable holding the passphrase used for encryption
+const PassphraseEnvVar = "AOJ_SESSION_PASSPHRASE"
+
+// Cipher encrypts and decrypts data
+type Cipher interface {
+	// Encrypt encrypts plaintext and returns a self-describing envelope
+	Encrypt(plaintext []byte) ([]byte, error)
+
+	// Decrypt decrypts an envelope produced by Encrypt
+	Decrypt(data []byte) ([]byte, error)
+}
+
+// envelope is the on-disk representation of encrypted data
+type envelope struct {
+	Version    int    `json:"version"`
+	Algorithm  string `json:"algorithm"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// AESCipher implements Cipher with AES-GCM and a PBKDF2-derived key
+type AESCipher struct {
+	secret []byte
+}
+
+// NewPassphraseCipher creates a cipher keyed by the given passphrase
+func NewPassphraseCipher(passphrase string) (*AESCipher, error) {
+	if passphrase == "" {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"encryption passphrase cannot be empty",
+			nil,
+		)
+	}
+	return &AESCipher{secret: []byte(passphrase)}, nil
+}
+
+// NewMachineCipher creates a cipher keyed by a secret derived from the current machine and user
+func NewMachineCipher() (*AESCipher, error) {
+	secret, err := machineSecret()
+	if err != nil {
+		return nil, err
+	}
+	return &AESCipher{secret: secret}, nil
+}
+
+// NewDefaultCipher creates a passphrase cipher when PassphraseEnvVar is set,
+// and a machine-derived cipher otherwise
+func NewDefaultCipher() (*AESCipher, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return NewPassphraseCipher(passphrase)
+	}
+	return NewMachineCipher()
+}
+
+// Encrypt encrypts plaintext and returns a JSON envelope
+func (c *AESCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, cerrors.Wrap(err, "failed to generate salt")
+	}
+
+	gcm, err := c.newGCM(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, cerrors.Wrap(err, "failed to generate nonce")
+	}
+
+	env := envelope{
+		Version:    envelopeVersion,
+		Algorithm:  algorithmName,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to encode encrypted envelope")
+	}
+	return data, nil
+}
+
+// Decrypt decrypts a JSON envelope produced by Encrypt
+func (c *AESCipher) Decrypt(data []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, cerrors.Wrap(err, "failed to decode encrypted envelope")
+	}
+
+	if env.Version != envelopeVersion || env.Algorithm != algorithmName {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"unsupported encryption format",
+			nil,
+		)
+	}
+
+	gcm, err := c.newGCM(env.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(env.Nonce) != gcm.NonceSize() {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"invalid encryption nonce",
+			nil,
+		)
+	}
+
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeUnauthorized,
+			"failed to decrypt data: wrong passphrase or corrupted file",
+			err,
+		)
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted reports whether data looks like an envelope produced by Encrypt
+func IsEncrypted(data []byte) bool {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return false
+	}
+	return env.Algorithm != "" && len(env.Ciphertext) > 0
+}
+
+func (c *AESCipher) newGCM(salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, string(c.secret), salt, kdfIterations, keySize)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to derive encryption key")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to create GCM")
+	}
+	return gcm, nil
+}
+
+// machineSecret builds a secret from the machine ID (when available), hostname and user
+func machineSecret() ([]byte, error) {
+	var parts []string
+
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		if content, err := os.ReadFile(path); err == nil {
+			parts = append(parts, strings.TrimSpace(string(content)))
+			break
+		}
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		parts = append(parts, hostname)
+	}
+
+	if u, err := user.Current(); err == nil {
+		parts = append(parts, u.Uid, u.Username)
+	}
+
+	if len(parts) == 0 {
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInternalServer,
+			"failed to derive a machine key. Set "+PassphraseEnvVar+" to encrypt sessions with a passphrase",
+			nil,
+		)
+	}
+
+	return []byte("aoj-cli:" + strings.Join(parts, ":")), nil
+}
@@ -0,0 +1,59 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestAESCipher_RoundTrip(t *testing.T) {
+	c, err := NewPassphraseCipher("correct horse")
+	assert.NoError(t, err)
+
+	encrypted, err := c.Encrypt([]byte("secret token"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "secret token")
+	assert.True(t, IsEncrypted(encrypted))
+
+	decrypted, err := c.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret token", string(decrypted))
+}
+
+func TestAESCipher_WrongPassphrase(t *testing.T) {
+	c, err := NewPassphraseCipher("correct horse")
+	assert.NoError(t, err)
+	encrypted, err := c.Encrypt([]byte("secret token"))
+	assert.NoError(t, err)
+
+	other, err := NewPassphraseCipher("battery staple")
+	assert.NoError(t, err)
+
+	_, err = other.Decrypt(encrypted)
+	assert.Error(t, err)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeUnauthorized))
+}
+
+func TestNewPassphraseCipher_Empty(t *testing.T) {
+	_, err := NewPassphraseCipher("")
+	assert.Error(t, err)
+}
+
+func TestNewMachineCipher(t *testing.T) {
+	c, err := NewMachineCipher()
+	assert.NoError(t, err)
+
+	encrypted, err := c.Encrypt([]byte(`{"token":"abc"}`))
+	assert.NoError(t, err)
+
+	decrypted, err := c.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"token":"abc"}`, string(decrypted))
+}
+
+func TestIsEncrypted(t *testing.T) {
+	assert.False(t, IsEncrypted([]byte(`{"id":"abc","token":"xyz"}`)))
+	assert.False(t, IsEncrypted([]byte("not json")))
+}
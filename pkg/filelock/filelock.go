@@ -0,0 +1,84 @@
+// Package filelock provides advisory inter-process file locks.
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// Lock is an exclusive advisory lock held on a lock file
+type Lock struct {
+	file *os.File
+}
+
+// Acquire blocks until an exclusive lock on path is obtained.
+// The lock file is created if it does not exist.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, cerrors.Wrap(err, "failed to create lock directory")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to open lock file")
+	}
+
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return nil, cerrors.Wrap(err, "failed to acquire file lock")
+	}
+
+	return &Lock{file: file}, nil
+}
+
+// Release releases the lock
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	unlockErr := unlockFile(l.file)
+	closeErr := l.file.Close()
+	l.file = nil
+
+	if unlockErr != nil {
+		return cerrors.Wrap(unlockErr, "failed to release file lock")
+	}
+	if closeErr != nil {
+		return cerrors.Wrap(closeErr, "failed to close lock file")
+	}
+	return nil
+}
+
+// WriteFileAtomic writes data to a temporary file and renames it over path,
+// so readers never observe a partially written file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	trace.File("write", path)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return cerrors.Wrap(err, "failed to create temporary file")
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return cerrors.Wrap(err, "failed to write temporary file")
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return cerrors.Wrap(err, "failed to close temporary file")
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		_ = os.Remove(tmpName)
+		return cerrors.Wrap(err, "failed to set file permissions")
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return cerrors.Wrap(err, "failed to replace file")
+	}
+	return nil
+}
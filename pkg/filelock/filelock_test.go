@@ -0,0 +1,73 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquire_SerializesCriticalSections(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "test.lock")
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		active  int
+		maxSeen int
+	)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lock, err := Acquire(lockPath)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			active++
+			if active > maxSeen {
+				maxSeen = active
+			}
+			mu.Unlock()
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+
+			assert.NoError(t, lock.Release())
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxSeen)
+}
+
+func TestRelease_Nil(t *testing.T) {
+	var lock *Lock
+	assert.NoError(t, lock.Release())
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "current")
+
+	assert.NoError(t, WriteFileAtomic(path, []byte("first"), 0600))
+	assert.NoError(t, WriteFileAtomic(path, []byte("second"), 0600))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(content))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,63 @@
+// Package fuzzy provides approximate string matching for "did you mean" suggestions.
+package fuzzy
+
+import (
+	"strings"
+)
+
+// Distance returns the case-insensitive Levenshtein distance between a and b
+func Distance(a, b string) int {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// Closest returns the candidate closest to target. ok is false when no
+// candidate is within a reasonable distance of target.
+func Closest(target string, candidates []string) (best string, ok bool) {
+	bestDistance := -1
+	for _, candidate := range candidates {
+		d := Distance(target, candidate)
+		if bestDistance < 0 || d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+
+	if bestDistance < 0 || bestDistance > maxDistance(target) {
+		return "", false
+	}
+	return best, true
+}
+
+// maxDistance scales the accepted edit distance with the length of the input
+func maxDistance(target string) int {
+	n := len([]rune(target))
+	switch {
+	case n <= 2:
+		return 1
+	case n <= 6:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,32 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance("C++17", "c++17"))
+	assert.Equal(t, 1, Distance("C++16", "C++17"))
+	assert.Equal(t, 3, Distance("kitten", "sitting"))
+	assert.Equal(t, 5, Distance("", "hello"))
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"C", "C++14", "C++17", "JAVA", "Python3", "Rust"}
+
+	best, ok := Closest("C++7", candidates)
+	assert.True(t, ok)
+	assert.Equal(t, "C++17", best)
+
+	best, ok = Closest("pyhton3", candidates)
+	assert.True(t, ok)
+	assert.Equal(t, "Python3", best)
+
+	_, ok = Closest("Brainfuck", candidates)
+	assert.False(t, ok)
+
+	_, ok = Closest("C++", nil)
+	assert.False(t, ok)
+}
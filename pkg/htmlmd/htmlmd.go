@@ -0,0 +1,506 @@
+// Package htmlmd converts the HTML of problem statements to Markdown or plain
+// text and extracts their samples. TeX formulas written for MathJax are kept
+// for Markdown and turned into readable text for terminals.
+package htmlmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// node is an element or, if tag is empty, a text node of the parsed document
+type node struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*node
+}
+
+// skippedTags are elements whose content is never rendered
+var skippedTags = map[string]bool{"script": true, "style": true, "head": true, "title": true}
+
+// impliedEnds lists, for elements whose end tag HTML lets authors omit, the
+// open elements a new start tag closes, e.g. <li> closes the previous <li>
+var impliedEnds = map[string][]string{
+	"li": {"li"},
+	"p":  {"p"},
+	"dt": {"dt", "dd"},
+	"dd": {"dt", "dd"},
+	"tr": {"td", "th", "tr"},
+	"td": {"td", "th"},
+	"th": {"td", "th"},
+}
+
+// spaces matches runs of whitespace collapsed to a single space outside <pre>
+var spaces = regexp.MustCompile(`\s+`)
+
+// blankLines matches three or more newlines, which are reduced to one blank line
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// Format selects the output of Render
+type Format int
+
+const (
+	// Markdown keeps TeX as $...$ and $$...$$ for renderers with math support
+	Markdown Format = iota
+	// Text is plain text for terminals, with TeX turned into Unicode where possible
+	Text
+)
+
+// Options controls how a statement is rendered
+type Options struct {
+	Format Format
+	// BaseURL resolves relative image and link URLs; empty keeps them as they are
+	BaseURL string
+}
+
+// Convert converts an HTML fragment to Markdown. <pre> blocks become fenced
+// code blocks so that sample inputs and outputs keep their exact content
+func Convert(html string) (string, error) {
+	return Render(html, Options{Format: Markdown})
+}
+
+// Render converts an HTML fragment to the format of opts
+func Render(html string, opts Options) (string, error) {
+	root, err := parse(html)
+	if err != nil {
+		return "", err
+	}
+
+	r := &renderer{opts: opts}
+	r.children(root)
+	out := blankLines.ReplaceAllString(r.out.String(), "\n\n")
+	return strings.TrimSpace(out) + "\n", nil
+}
+
+// parse builds the node tree with the lenient HTML mode of encoding/xml
+func parse(html string) (*node, error) {
+	decoder := xml.NewDecoder(strings.NewReader(html))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &node{tag: "#root"}
+	stack := []*node{root}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return root, nil
+		}
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) && syntaxErr.Msg == "unexpected EOF" {
+			// Elements left open at the end of the fragment
+			return root, nil
+		}
+		if err != nil {
+			return nil, cerrors.Wrap(err, "failed to parse HTML")
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{tag: strings.ToLower(t.Name.Local), attrs: make(map[string]string)}
+			for _, attr := range t.Attr {
+				n.attrs[strings.ToLower(attr.Name.Local)] = attr.Value
+			}
+			for len(stack) > 1 && slices.Contains(impliedEnds[n.tag], stack[len(stack)-1].tag) {
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			// Close the innermost open element of that name; end tags of
+			// elements already closed implicitly are ignored
+			tag := strings.ToLower(t.Name.Local)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].tag == tag {
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, &node{text: string(t)})
+		}
+	}
+}
+
+// renderer writes Markdown for a node tree
+type renderer struct {
+	out       strings.Builder
+	listDepth int
+	opts      Options
+}
+
+func (r *renderer) children(n *node) {
+	for _, child := range n.children {
+		r.node(child)
+	}
+}
+
+func (r *renderer) node(n *node) {
+	if n.tag == "" {
+		r.text(n.text)
+		return
+	}
+	if n.tag == "script" && strings.HasPrefix(n.attrs["type"], "math/tex") {
+		// MathJax 2 keeps formulas in <script type="math/tex; mode=display">
+		r.math(rawText(n), strings.Contains(n.attrs["type"], "mode=display"))
+		return
+	}
+	if skippedTags[n.tag] {
+		return
+	}
+
+	switch n.tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.blockBreak()
+		r.heading(n)
+		r.blockBreak()
+	case "p", "div", "section", "center", "blockquote", "dl":
+		r.blockBreak()
+		r.children(n)
+		r.blockBreak()
+	case "br":
+		r.trimTrailingSpace()
+		r.out.WriteString("\n")
+	case "hr":
+		r.blockBreak()
+		r.out.WriteString("---")
+		r.blockBreak()
+	case "pre":
+		r.blockBreak()
+		if r.opts.Format == Text {
+			r.preformatted(rawText(n))
+			r.blockBreak()
+			return
+		}
+		r.out.WriteString("```\n" + strings.TrimLeft(rawText(n), "\n"))
+		if !strings.HasSuffix(r.out.String(), "\n") {
+			r.out.WriteString("\n")
+		}
+		r.out.WriteString("```")
+		r.blockBreak()
+	case "ul", "ol":
+		if r.listDepth == 0 {
+			r.blockBreak()
+		}
+		r.listDepth++
+		number := 0
+		for _, child := range n.children {
+			if child.tag != "li" {
+				continue
+			}
+			number++
+			r.lineBreak()
+			marker := "- "
+			if n.tag == "ol" {
+				marker = strconv.Itoa(number) + ". "
+			}
+			r.out.WriteString(strings.Repeat("  ", r.listDepth-1) + marker)
+			r.children(child)
+		}
+		r.listDepth--
+		if r.listDepth == 0 {
+			r.blockBreak()
+		}
+	case "dt":
+		r.lineBreak()
+		r.wrap(n, "**")
+		r.lineBreak()
+	case "dd":
+		r.lineBreak()
+		if r.opts.Format == Text {
+			r.out.WriteString("  ")
+		} else {
+			r.out.WriteString(": ")
+		}
+		r.children(n)
+		r.lineBreak()
+	case "table":
+		r.blockBreak()
+		r.table(n)
+		r.blockBreak()
+	case "b", "strong":
+		r.wrap(n, "**")
+	case "i", "em":
+		r.wrap(n, "*")
+	case "code", "tt", "kbd", "samp":
+		r.wrap(n, "`")
+	case "sup":
+		r.out.WriteString("^" + r.inline(n))
+	case "sub":
+		r.out.WriteString("_" + r.inline(n))
+	case "a":
+		r.link(n)
+	case "img":
+		r.image(n)
+	default:
+		r.children(n)
+	}
+}
+
+// text writes text with whitespace collapsed and its TeX formulas rendered
+func (r *renderer) text(s string) {
+	for _, seg := range splitMath(s) {
+		if seg.math {
+			r.math(seg.text, seg.display)
+		} else {
+			r.plain(seg.text)
+		}
+	}
+}
+
+// plain writes text with whitespace collapsed
+func (r *renderer) plain(s string) {
+	s = spaces.ReplaceAllString(s, " ")
+	if s == "" {
+		return
+	}
+	current := r.out.String()
+	if current == "" || strings.HasSuffix(current, "\n") || strings.HasSuffix(current, " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	r.out.WriteString(s)
+}
+
+// math writes a TeX formula, as $...$ or a $$ block for Markdown and as
+// readable text for terminals
+func (r *renderer) math(tex string, display bool) {
+	tex = strings.TrimSpace(spaces.ReplaceAllString(tex, " "))
+	if tex == "" {
+		return
+	}
+
+	switch {
+	case r.opts.Format == Text && display:
+		r.blockBreak()
+		r.out.WriteString("    " + texToText(tex))
+		r.blockBreak()
+	case r.opts.Format == Text:
+		r.plain(texToText(tex))
+	case display:
+		r.blockBreak()
+		r.out.WriteString("$$\n" + tex + "\n$$")
+		r.blockBreak()
+	default:
+		r.out.WriteString("$" + tex + "$")
+	}
+}
+
+// heading writes a heading as #-prefixed Markdown or as underlined text
+func (r *renderer) heading(n *node) {
+	title := r.inline(n)
+	level := int(n.tag[1] - '0')
+	if r.opts.Format != Text {
+		r.out.WriteString(strings.Repeat("#", level) + " " + title)
+		return
+	}
+
+	underline := "-"
+	if level == 1 {
+		underline = "="
+	}
+	r.out.WriteString(title + "\n" + strings.Repeat(underline, utf8.RuneCountInString(title)))
+}
+
+// preformatted writes the text of a <pre> block indented by four spaces
+func (r *renderer) preformatted(text string) {
+	text = strings.TrimRight(strings.TrimLeft(text, "\r\n"), "\r\n")
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSuffix(line, "\r"); line != "" {
+			r.out.WriteString("    " + line)
+		}
+		r.out.WriteString("\n")
+	}
+}
+
+// link writes a link as [text](href), or as text followed by its URL
+func (r *renderer) link(n *node) {
+	text := r.inline(n)
+	href := n.attrs["href"]
+	switch {
+	case href == "" || text == "":
+		r.out.WriteString(text)
+	case r.opts.Format == Text && (text == href || strings.HasPrefix(href, "#")):
+		r.out.WriteString(text)
+	case r.opts.Format == Text:
+		r.out.WriteString(text + " (" + r.resolve(href) + ")")
+	default:
+		r.out.WriteString("[" + text + "](" + r.resolve(href) + ")")
+	}
+}
+
+// image writes an image as ![alt](src), or as a placeholder with its URL
+func (r *renderer) image(n *node) {
+	src := n.attrs["src"]
+	if src == "" {
+		return
+	}
+	if r.opts.Format != Text {
+		r.out.WriteString("![" + n.attrs["alt"] + "](" + r.resolve(src) + ")")
+		return
+	}
+
+	label := "[image]"
+	if alt := strings.TrimSpace(n.attrs["alt"]); alt != "" {
+		label = "[image: " + alt + "]"
+	}
+	r.plain(" " + label + " " + r.resolve(src) + " ")
+}
+
+// resolve returns ref relative to the base URL of the options
+func (r *renderer) resolve(ref string) string {
+	if r.opts.BaseURL == "" {
+		return ref
+	}
+	base, err := url.Parse(r.opts.BaseURL)
+	if err != nil {
+		return ref
+	}
+	target, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(target).String()
+}
+
+// wrap writes the inline content of n between markers, e.g. **bold**. Plain
+// text has no markup, so the content is written as it is
+func (r *renderer) wrap(n *node, marker string) {
+	if r.opts.Format == Text {
+		r.children(n)
+		return
+	}
+	text := r.inline(n)
+	if text == "" {
+		return
+	}
+	r.out.WriteString(marker + text + marker)
+}
+
+// table writes a table, using its first row as the header
+func (r *renderer) table(n *node) {
+	var rows [][]string
+	var collect func(n *node)
+	collect = func(n *node) {
+		for _, child := range n.children {
+			switch child.tag {
+			case "tr":
+				var cells []string
+				for _, cell := range child.children {
+					if cell.tag == "td" || cell.tag == "th" {
+						cells = append(cells, r.inline(cell))
+					}
+				}
+				rows = append(rows, cells)
+			case "thead", "tbody", "tfoot":
+				collect(child)
+			}
+		}
+	}
+	collect(n)
+	if len(rows) == 0 {
+		return
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if r.opts.Format == Text {
+		r.textTable(rows, columns)
+		return
+	}
+	for i, row := range rows {
+		for j := range row {
+			row[j] = strings.ReplaceAll(row[j], "|", `\|`)
+		}
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		r.out.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			r.out.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+}
+
+// textTable writes rows as columns aligned with spaces
+func (r *renderer) textTable(rows [][]string, columns int) {
+	widths := make([]int, columns)
+	for _, row := range rows {
+		for i, text := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(text))
+		}
+	}
+	for _, row := range rows {
+		var line strings.Builder
+		for i, text := range row {
+			line.WriteString(text + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(text)+2))
+		}
+		r.out.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+}
+
+// blockBreak ends the current block with a blank line
+func (r *renderer) blockBreak() {
+	r.trimTrailingSpace()
+	current := r.out.String()
+	switch {
+	case current == "" || strings.HasSuffix(current, "\n\n"):
+	case strings.HasSuffix(current, "\n"):
+		r.out.WriteString("\n")
+	default:
+		r.out.WriteString("\n\n")
+	}
+}
+
+// lineBreak starts a new line unless the output already is at the start of one
+func (r *renderer) lineBreak() {
+	r.trimTrailingSpace()
+	current := r.out.String()
+	if current != "" && !strings.HasSuffix(current, "\n") {
+		r.out.WriteString("\n")
+	}
+}
+
+func (r *renderer) trimTrailingSpace() {
+	current := r.out.String()
+	trimmed := strings.TrimRight(current, " ")
+	if len(trimmed) != len(current) {
+		r.out.Reset()
+		r.out.WriteString(trimmed)
+	}
+}
+
+// inline renders the content of n on a single line
+func (r *renderer) inline(n *node) string {
+	child := &renderer{opts: r.opts}
+	child.children(n)
+	return strings.TrimSpace(spaces.ReplaceAllString(child.out.String(), " "))
+}
+
+// rawText returns the text of n and its descendants without collapsing whitespace
+func rawText(n *node) string {
+	if n.tag == "" {
+		return n.text
+	}
+	if n.tag == "br" {
+		return "\n"
+	}
+	var b strings.Builder
+	for _, child := range n.children {
+		b.WriteString(rawText(child))
+	}
+	return b.String()
+}
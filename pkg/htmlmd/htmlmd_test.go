@@ -0,0 +1,134 @@
+package htmlmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert_Statement(t *testing.T) {
+	// Given
+	html := `<H1>Hello World</H1>
+<p>
+Write a program which prints "Hello World" to standard output.
+</p>
+<H2>Input</H2>
+<p>There is no input for this problem.<br>Constraints: 1 &le; <var>n</var> &lt; 10<sup>5</sup></p>
+<h2>Sample Input 1</h2>
+<pre>
+1 2
+3 4
+</pre>
+<ul><li>first <b>bold</b></li><li>second <a href="https://example.com">link</a></li></ul>
+<table><tr><th>a</th><th>b</th></tr><tr><td>1</td><td>2</td></tr></table>
+<script>alert(1)</script>`
+
+	// When
+	markdown, err := Convert(html)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `# Hello World
+
+Write a program which prints "Hello World" to standard output.
+
+## Input
+
+There is no input for this problem.
+Constraints: 1 ≤ n < 10^5
+
+## Sample Input 1
+
+`+"```"+`
+1 2
+3 4
+`+"```"+`
+
+- first **bold**
+- second [link](https://example.com)
+
+| a | b |
+| --- | --- |
+| 1 | 2 |
+`, markdown)
+}
+
+func TestConvert_UnclosedTags(t *testing.T) {
+	markdown, err := Convert("<p>one<p>two<ol><li>a<li>b</ol>")
+
+	assert.NoError(t, err)
+	assert.Contains(t, markdown, "one")
+	assert.Contains(t, markdown, "1. a")
+	assert.Contains(t, markdown, "2. b")
+}
+
+func TestRender_Markdown_Math(t *testing.T) {
+	// Given
+	html := `<p>Given $N$ integers \(a_1, \ldots, a_N\).</p>
+<p>\[ \sum_{i=1}^N a_i \]</p>
+<script type="math/tex; mode=display">x^2</script>
+<p><img src="IMAGE1/fig.png" alt="figure"> <a href="https://example.com">link</a></p>`
+
+	// When
+	markdown, err := Render(html, Options{Format: Markdown, BaseURL: "https://judge.u-aizu.ac.jp/onlinejudge/"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `Given $N$ integers $a_1, \ldots, a_N$.
+
+$$
+\sum_{i=1}^N a_i
+$$
+
+$$
+x^2
+$$
+
+![figure](https://judge.u-aizu.ac.jp/onlinejudge/IMAGE1/fig.png) [link](https://example.com)
+`, markdown)
+}
+
+func TestRender_Text(t *testing.T) {
+	// Given
+	html := `<H1>Sum</H1>
+<p>Given $N$ integers, print <b>their sum</b>.</p>
+<h2>Constraints</h2>
+<ul><li>$1 \le N \le 10^{5}$</li></ul>
+<p>$$\sum_{i=1}^N a_i$$</p>
+<h2>Sample Input 1</h2>
+<pre>
+3
+1 2 3
+</pre>
+<p><img src="/images/fig.png"> See <a href="https://example.com/faq">the FAQ</a>.</p>
+<table><tr><th>N</th><th>Score</th></tr><tr><td>10</td><td>30</td></tr></table>`
+
+	// When
+	text, err := Render(html, Options{Format: Text, BaseURL: "https://judge.u-aizu.ac.jp/onlinejudge/"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `Sum
+===
+
+Given N integers, print their sum.
+
+Constraints
+-----------
+
+- 1 ≤ N ≤ 10^5
+
+    Σ_(i=1)^N a_i
+
+Sample Input 1
+--------------
+
+    3
+    1 2 3
+
+[image] https://judge.u-aizu.ac.jp/images/fig.png See the FAQ (https://example.com/faq).
+
+N   Score
+10  30
+`, text)
+}
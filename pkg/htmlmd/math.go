@@ -0,0 +1,220 @@
+package htmlmd
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// segment is a piece of text, either plain or a TeX formula
+type segment struct {
+	text    string
+	math    bool
+	display bool
+}
+
+// mathDelimiters are the delimiters MathJax recognizes, tried in order so
+// that $$ is not taken for an empty $...$
+var mathDelimiters = []struct {
+	open, close string
+	display     bool
+}{
+	{"$$", "$$", true},
+	{`\[`, `\]`, true},
+	{`\(`, `\)`, false},
+	{"$", "$", false},
+}
+
+// splitMath splits text into plain text and TeX formulas. An escaped \$ and
+// delimiters without a closing one are plain text
+func splitMath(s string) []segment {
+	var (
+		segments []segment
+		plain    strings.Builder
+	)
+	flush := func() {
+		if plain.Len() > 0 {
+			segments = append(segments, segment{text: plain.String()})
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], `\$`) {
+			plain.WriteByte('$')
+			i += 2
+			continue
+		}
+
+		matched := false
+		for _, d := range mathDelimiters {
+			if !strings.HasPrefix(s[i:], d.open) {
+				continue
+			}
+			start := i + len(d.open)
+			end := strings.Index(s[start:], d.close)
+			if end <= 0 {
+				continue
+			}
+			flush()
+			segments = append(segments, segment{text: s[start : start+end], math: true, display: d.display})
+			i = start + end + len(d.close)
+			matched = true
+			break
+		}
+		if !matched {
+			plain.WriteByte(s[i])
+			i++
+		}
+	}
+	flush()
+	return segments
+}
+
+// texSymbols maps TeX commands to the text they are shown as. Relations
+// and operators are padded with spaces, which are collapsed afterwards
+var texSymbols = map[string]string{
+	"le": " ≤ ", "leq": " ≤ ", "leqq": " ≤ ", "ge": " ≥ ", "geq": " ≥ ", "geqq": " ≥ ",
+	"ne": " ≠ ", "neq": " ≠ ", "lt": " < ", "gt": " > ", "equiv": " ≡ ", "approx": " ≈ ",
+	"sim": " ~ ", "times": " × ", "cdot": " · ", "div": " ÷ ", "pm": " ± ",
+	"in": " ∈ ", "notin": " ∉ ", "subset": " ⊂ ", "subseteq": " ⊆ ", "cup": " ∪ ", "cap": " ∩ ",
+	"land": " ∧ ", "wedge": " ∧ ", "lor": " ∨ ", "vee": " ∨ ", "oplus": " ⊕ ",
+	"to": " → ", "rightarrow": " → ", "leftarrow": " ← ", "gets": " ← ",
+	"Rightarrow": " ⇒ ", "leftrightarrow": " ↔ ", "mod": " mod ", "bmod": " mod ",
+	"neg": "¬", "lnot": "¬", "forall": "∀", "exists": "∃", "infty": "∞",
+	"sum": "Σ", "prod": "Π", "ldots": "…", "dots": "…", "cdots": "⋯", "vdots": "⋮",
+	"lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉", "langle": "⟨", "rangle": "⟩",
+	"mid": " | ", "vert": "|", "|": "‖", "prime": "′", "circ": "∘", "ast": "*",
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ε", "varepsilon": "ε",
+	"theta": "θ", "lambda": "λ", "mu": "μ", "pi": "π", "sigma": "σ", "phi": "φ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Sigma": "Σ", "Omega": "Ω",
+	",": " ", ";": " ", ":": " ", "!": "", " ": " ", "quad": " ", "qquad": " ", `\`: " ",
+	"{": "{", "}": "}", "$": "$", "%": "%", "&": "&", "#": "#", "_": "_",
+	"left": "", "right": "", "big": "", "Big": "", "bigg": "", "Bigg": "",
+	"displaystyle": "", "textstyle": "", "limits": "", "nolimits": "", "rm": "", "bf": "", "it": "",
+}
+
+// texArgumentCommands are commands shown as their argument, such as \mathrm{mod}
+var texArgumentCommands = map[string]bool{
+	"text": true, "mbox": true, "textrm": true, "textbf": true, "textit": true, "texttt": true,
+	"mathrm": true, "mathit": true, "mathbf": true, "mathsf": true, "mathtt": true, "mathcal": true,
+	"mathbb": true, "operatorname": true, "boldsymbol": true,
+	"overline": true, "underline": true, "bar": true, "hat": true, "tilde": true, "vec": true,
+}
+
+// texToText turns a TeX formula into readable text, e.g. "1 \le N \le 10^{5}"
+// into "1 ≤ N ≤ 10^5". Unknown commands are shown by name
+func texToText(tex string) string {
+	var b strings.Builder
+	for i := 0; i < len(tex); {
+		switch c := tex[i]; c {
+		case '\\':
+			name, next := texCommand(tex, i+1)
+			i = next
+			switch {
+			case name == "frac" || name == "dfrac" || name == "tfrac":
+				numerator, next := texGroup(tex, i)
+				denominator, next := texGroup(tex, next)
+				i = next
+				b.WriteString(texOperand(texToText(numerator)) + "/" + texOperand(texToText(denominator)))
+			case name == "binom":
+				n, next := texGroup(tex, i)
+				k, next := texGroup(tex, next)
+				i = next
+				b.WriteString("C(" + texToText(n) + ", " + texToText(k) + ")")
+			case name == "sqrt":
+				arg, next := texGroup(tex, i)
+				i = next
+				b.WriteString("√" + texOperand(texToText(arg)))
+			case texArgumentCommands[name]:
+				arg, next := texGroup(tex, i)
+				i = next
+				b.WriteString(texToText(arg))
+			default:
+				if symbol, ok := texSymbols[name]; ok {
+					b.WriteString(symbol)
+				} else {
+					b.WriteString(name)
+				}
+			}
+		case '^', '_':
+			arg, next := texGroup(tex, i+1)
+			i = next
+			b.WriteString(string(c) + texOperand(texToText(arg)))
+		case '{', '}':
+			i++
+		case '~':
+			b.WriteByte(' ')
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return strings.TrimSpace(spaces.ReplaceAllString(b.String(), " "))
+}
+
+// texCommand returns the name of the command starting at i, after the
+// backslash, and the index following it. Names are letters or one symbol
+func texCommand(tex string, i int) (string, int) {
+	if i >= len(tex) {
+		return "", i
+	}
+	end := i
+	for end < len(tex) && isASCIILetter(tex[end]) {
+		end++
+	}
+	if end == i {
+		_, size := utf8.DecodeRuneInString(tex[i:])
+		end = i + size
+	}
+	return tex[i:end], end
+}
+
+// texGroup returns the argument starting at i: the content of a {...}
+// group, a command or a single character
+func texGroup(tex string, i int) (string, int) {
+	for i < len(tex) && tex[i] == ' ' {
+		i++
+	}
+	if i >= len(tex) {
+		return "", i
+	}
+
+	switch tex[i] {
+	case '{':
+		depth := 0
+		for j := i; j < len(tex); j++ {
+			switch tex[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					return tex[i+1 : j], j + 1
+				}
+			}
+		}
+		return tex[i+1:], len(tex)
+	case '\\':
+		_, next := texCommand(tex, i+1)
+		return tex[i:next], next
+	default:
+		_, size := utf8.DecodeRuneInString(tex[i:])
+		return tex[i : i+size], i + size
+	}
+}
+
+// texOperand parenthesizes text made of more than one term, e.g. i+1 in a_(i+1)
+func texOperand(text string) string {
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '′' {
+			return "(" + text + ")"
+		}
+	}
+	return text
+}
+
+func isASCIILetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,49 @@
+package htmlmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitMath(t *testing.T) {
+	// When
+	segments := splitMath(`Given $N$ and \(a_i\), costs \$5 $$\sum a_i$$ \[x\] $`)
+
+	// Then
+	assert.Equal(t, []segment{
+		{text: "Given "},
+		{text: "N", math: true},
+		{text: " and "},
+		{text: "a_i", math: true},
+		{text: ", costs $5 "},
+		{text: `\sum a_i`, math: true, display: true},
+		{text: " "},
+		{text: "x", math: true, display: true},
+		{text: " $"},
+	}, segments)
+}
+
+func TestTexToText(t *testing.T) {
+	tests := []struct {
+		tex  string
+		want string
+	}{
+		{tex: `1 \le N \le 10^{5}`, want: "1 ≤ N ≤ 10^5"},
+		{tex: `a_{i+1} \neq a_i`, want: "a_(i+1) ≠ a_i"},
+		{tex: `\frac{n(n-1)}{2}`, want: "(n(n-1))/2"},
+		{tex: `\frac12`, want: "1/2"},
+		{tex: `\sum_{i=1}^{N} A_i`, want: "Σ_(i=1)^N A_i"},
+		{tex: `10^9+7 \times \mathrm{mod}`, want: "10^9+7 × mod"},
+		{tex: `\sqrt{x^2}`, want: "√(x^2)"},
+		{tex: `\binom{n}{k}`, want: "C(n, k)"},
+		{tex: `\{ 1,\ 2 \}`, want: "{ 1, 2 }"},
+		{tex: `\left( \unknown \right)`, want: "( unknown )"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tex, func(t *testing.T) {
+			assert.Equal(t, tt.want, texToText(tt.tex))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package htmlmd
+
+import (
+	"strings"
+)
+
+// Sample is a sample input and its expected output shown in a statement
+type Sample struct {
+	Input  string
+	Output string
+}
+
+// ExtractSamples returns the samples of a statement. Each <pre> block is
+// classified by the text right before it, such as "Sample Input 1" or "入力例 1",
+// and inputs are paired with the outputs that follow them
+func ExtractSamples(html string) ([]Sample, error) {
+	root, err := parse(html)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		samples []Sample
+		inputs  []string
+		label   string
+	)
+	var walk func(n *node)
+	walk = func(n *node) {
+		for _, child := range n.children {
+			switch {
+			case child.tag == "":
+				if text := strings.TrimSpace(child.text); text != "" {
+					label = text
+				}
+			case child.tag == "pre":
+				content := sampleText(rawText(child))
+				switch sampleKind(label) {
+				case "input":
+					inputs = append(inputs, content)
+				case "output":
+					if len(inputs) > 0 {
+						samples = append(samples, Sample{Input: inputs[0], Output: content})
+						inputs = inputs[1:]
+					}
+				}
+				label = ""
+			case !skippedTags[child.tag]:
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+	return samples, nil
+}
+
+// sampleKind tells whether a label introduces a sample input or output
+func sampleKind(label string) string {
+	label = strings.ToLower(label)
+	switch {
+	case strings.Contains(label, "sample input"), strings.Contains(label, "入力例"):
+		return "input"
+	case strings.Contains(label, "sample output"), strings.Contains(label, "出力例"):
+		return "output"
+	default:
+		return ""
+	}
+}
+
+// sampleText drops the newline that directly follows <pre> and ends the text with a newline
+func sampleText(text string) string {
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "\r"), "\n")
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text
+}
@@ -0,0 +1,47 @@
+package htmlmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractSamples(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []Sample
+	}{
+		{
+			name: "english headings",
+			html: `<h2>Sample Input 1</h2><pre>
+1 2
+</pre><h2>Sample Output 1</h2><pre>
+3
+</pre><H2>Sample Input 2</H2><pre>5 5</pre><H2>Sample Output 2</H2><pre>10</pre>`,
+			want: []Sample{{Input: "1 2\n", Output: "3\n"}, {Input: "5 5\n", Output: "10\n"}},
+		},
+		{
+			name: "japanese labels in paragraphs",
+			html: `<p><b>入力例</b></p><pre>3
+1 2 3
+</pre><p>出力例</p><pre>6
+</pre><pre>not a sample</pre>`,
+			want: []Sample{{Input: "3\n1 2 3\n", Output: "6\n"}},
+		},
+		{
+			name: "no samples",
+			html: `<h2>Input</h2><pre>n</pre>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, err := ExtractSamples(tt.html)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, samples)
+		})
+	}
+}
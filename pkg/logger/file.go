@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxFileSize = 10 << 20 // 10 MiB
+	defaultMaxBackups  = 3
+	backupTimeFormat   = "20060102-150405.000"
+)
+
+// FileConfig holds the configuration of a rotating log file
+type FileConfig struct {
+	Path       string
+	MaxSize    int64         // rotate when the file grows beyond this many bytes (default: 10 MiB)
+	MaxAge     time.Duration // remove rotated files older than this (0 keeps them regardless of age)
+	MaxBackups int           // number of rotated files to keep (default: 3)
+}
+
+// RotatingFile is an io.Writer that appends to a file and rotates it by size,
+// removing old rotated files by age and count
+type RotatingFile struct {
+	config FileConfig
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+}
+
+// OpenRotatingFile opens or creates the log file described by config
+func OpenRotatingFile(config FileConfig) (*RotatingFile, error) {
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultMaxFileSize
+	}
+	if config.MaxBackups <= 0 {
+		config.MaxBackups = defaultMaxBackups
+	}
+
+	f := &RotatingFile{config: config}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.removeOldBackups()
+	return f, nil
+}
+
+// Write appends p to the log file, rotating it first if it would grow too large
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.size+int64(len(p)) > f.config.MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// open opens the log file for appending
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.config.Path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames the current log file with a timestamp suffix and starts a new one
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	backup := f.config.Path + "." + time.Now().Format(backupTimeFormat)
+	if err := os.Rename(f.config.Path, backup); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.removeOldBackups()
+	return nil
+}
+
+// removeOldBackups deletes rotated files beyond the configured age and count
+func (f *RotatingFile) removeOldBackups() {
+	matches, err := filepath.Glob(f.config.Path + ".*")
+	if err != nil {
+		return
+	}
+
+	var backups []string
+	for _, match := range matches {
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(match, f.config.Path+".")); err == nil {
+			backups = append(backups, match)
+		}
+	}
+
+	// Timestamp suffixes sort chronologically, so this puts the newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	for i, backup := range backups {
+		expired := false
+		if f.config.MaxAge > 0 {
+			if info, err := os.Stat(backup); err == nil && time.Since(info.ModTime()) > f.config.MaxAge {
+				expired = true
+			}
+		}
+		if i >= f.config.MaxBackups || expired {
+			_ = os.Remove(backup)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), "logs", "aoj.log")
+	f, err := OpenRotatingFile(FileConfig{Path: path, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("failed to open log file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// When
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		assert.NoError(t, err)
+		// Backup names have millisecond resolution
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Then
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "fourth\n", string(content))
+
+	backups, err := filepath.Glob(path + ".*")
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestRotatingFile_RemovesExpiredBackups(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aoj.log")
+	expired := path + ".20200101-000000.000"
+	assert.NoError(t, os.WriteFile(expired, []byte("old\n"), 0600))
+	old := time.Now().Add(-48 * time.Hour)
+	assert.NoError(t, os.Chtimes(expired, old, old))
+	unrelated := path + ".bak"
+	assert.NoError(t, os.WriteFile(unrelated, []byte("keep\n"), 0600))
+
+	// When
+	f, err := OpenRotatingFile(FileConfig{Path: path, MaxAge: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("failed to open log file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Then
+	assert.NoFileExists(t, expired)
+	assert.FileExists(t, unrelated)
+}
+
+func TestLogger_AttachFile(t *testing.T) {
+	// Given
+	console := &bytes.Buffer{}
+	logger := New(Config{Level: LevelInfo, Format: FormatText, Output: console})
+	grouped := logger.WithGroup("submit_command")
+	path := filepath.Join(t.TempDir(), "aoj.log")
+
+	// When
+	assert.NoError(t, logger.AttachFile(FileConfig{Path: path}))
+	grouped.Debug("debug details", "problem_id", "ITP1_1_A")
+	grouped.Info("submitted")
+	assert.NoError(t, logger.Close())
+	grouped.Info("after close")
+
+	// Then
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "submit_command.problem_id=ITP1_1_A")
+	assert.Contains(t, string(content), "submitted")
+	assert.NotContains(t, string(content), "after close")
+
+	assert.NotContains(t, console.String(), "debug details")
+	assert.Equal(t, 2, strings.Count(console.String(), "\n"))
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"log/slog"
 	"os"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/redact"
 )
 
 // Level represents the log level
@@ -22,6 +24,7 @@ const (
 // Logger wraps slog.Logger with additional functionality
 type Logger struct {
 	logger *slog.Logger
+	file   *fileSink
 }
 
 // Config holds logger configuration
@@ -29,6 +32,7 @@ type Config struct {
 	Level  Level
 	Format Format
 	Output io.Writer
+	File   *FileConfig // Optional: also write debug logs to a rotating file
 }
 
 // Format represents the log output format
@@ -46,24 +50,63 @@ func New(config Config) *Logger {
 		config.Output = os.Stderr
 	}
 
-	var handler slog.Handler
+	console := newHandler(config.Format, config.Output, slog.Level(config.Level))
+	file := &fileSink{format: config.Format}
+
+	l := &Logger{
+		logger: slog.New(&requestIDHandler{next: &teeHandler{console: console, file: file}}),
+		file:   file,
+	}
+
+	if config.File != nil {
+		if err := l.AttachFile(*config.File); err != nil {
+			l.Warn("failed to open log file", "path", config.File.Path, "error", err)
+		}
+	}
+
+	return l
+}
 
+// newHandler creates a slog handler for the given format
+func newHandler(format Format, output io.Writer, level slog.Level) slog.Handler {
 	opts := &slog.HandlerOptions{
-		Level: slog.Level(config.Level),
+		Level:       level,
+		ReplaceAttr: redactAttr,
 	}
 
-	switch config.Format {
+	switch format {
 	case FormatJSON:
-		handler = slog.NewJSONHandler(config.Output, opts)
+		return slog.NewJSONHandler(output, opts)
 	case FormatText:
-		handler = slog.NewTextHandler(config.Output, opts)
+		return slog.NewTextHandler(output, opts)
 	default:
-		handler = slog.NewTextHandler(config.Output, opts)
+		return slog.NewTextHandler(output, opts)
 	}
+}
 
-	return &Logger{
-		logger: slog.New(handler),
+// redactAttr masks string values of sensitive keys such as "token" and
+// secrets in error messages, so that callers cannot leak them by accident
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	switch value := a.Value.Any().(type) {
+	case string:
+		if redact.IsSensitiveKey(a.Key) {
+			return slog.String(a.Key, redact.Mask(value))
+		}
+	case error:
+		return slog.String(a.Key, redact.Text(value.Error()))
 	}
+	return a
+}
+
+// AttachFile starts writing debug logs to a rotating file in addition to the console.
+// Loggers already derived from l with With or WithGroup write to the file as well
+func (l *Logger) AttachFile(config FileConfig) error {
+	return l.file.attach(config)
+}
+
+// Close closes the log file, if any
+func (l *Logger) Close() error {
+	return l.file.close()
 }
 
 // Default creates a logger with default configuration
@@ -119,6 +162,7 @@ func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
 func (l *Logger) With(args ...any) *Logger {
 	return &Logger{
 		logger: l.logger.With(args...),
+		file:   l.file,
 	}
 }
 
@@ -126,6 +170,7 @@ func (l *Logger) With(args ...any) *Logger {
 func (l *Logger) WithGroup(name string) *Logger {
 	return &Logger{
 		logger: l.logger.WithGroup(name),
+		file:   l.file,
 	}
 }
 
@@ -192,6 +237,11 @@ func ErrorContext(ctx context.Context, msg string, args ...any) {
 	global.ErrorContext(ctx, msg, args...)
 }
 
+// AttachFile starts writing debug logs of the global logger to a rotating file
+func AttachFile(config FileConfig) error {
+	return global.AttachFile(config)
+}
+
 // With returns a new logger with the given attributes using the global logger
 func With(args ...any) *Logger {
 	return global.With(args...)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -294,4 +295,74 @@ func TestGlobalFunctions(t *testing.T) {
 		groupLogger := WithGroup("global")
 		assert.NotNil(t, groupLogger)
 	})
-}
\ No newline at end of file
+}
+
+func TestRequestID(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := New(Config{
+		Level:  LevelDebug,
+		Format: FormatJSON,
+		Output: buf,
+	})
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	logger.InfoContext(ctx, "with id")
+	logger.Info("without id")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var withID, withoutID map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &withID))
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &withoutID))
+	assert.Equal(t, "abc123", withID[RequestIDKey])
+	assert.NotContains(t, withoutID, RequestIDKey)
+
+	assert.Equal(t, "abc123", RequestIDFromContext(ctx))
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+	assert.Len(t, NewRequestID(), 16)
+	assert.NotEqual(t, NewRequestID(), NewRequestID())
+}
+
+func TestRequestIDWithGroups(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := New(Config{
+		Level:  LevelDebug,
+		Format: FormatJSON,
+		Output: buf,
+	})
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	logger.With("app", "aoj").WithGroup("submit").With("problem_id", "ITP1_1_A").WithGroup("http").
+		InfoContext(ctx, "request", "status", 200)
+
+	var data map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+	assert.Equal(t, "abc123", data[RequestIDKey])
+	assert.Equal(t, "aoj", data["app"])
+	assert.Equal(t, map[string]interface{}{
+		"problem_id": "ITP1_1_A",
+		"http":       map[string]interface{}{"status": float64(200)},
+	}, data["submit"])
+}
+
+func TestRedaction(t *testing.T) {
+	// Given
+	buf := &bytes.Buffer{}
+	logger := New(Config{Level: LevelDebug, Format: FormatText, Output: buf})
+
+	// When
+	logger.Info("login",
+		"user", "alice",
+		"password", "hunter2",
+		"session_id", "0123456789abcdef0123456789abcdef",
+		"error", errors.New("request failed: Bearer abc123"))
+
+	// Then
+	output := buf.String()
+	assert.Contains(t, output, "user=alice")
+	assert.Contains(t, output, "session_id=0123************************cdef")
+	for _, secret := range []string{"hunter2", "456789abcdef012345", "abc123"} {
+		assert.NotContains(t, output, secret)
+	}
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// RequestIDKey is the attribute key of the correlation ID attached to log records
+const RequestIDKey = "request_id"
+
+type requestIDKey struct{}
+
+// NewRequestID generates a short random correlation ID
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a copy of ctx that carries the correlation ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the correlation ID stored in ctx, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDHandler adds the correlation ID from the context to every record.
+// Groups and attributes are applied here rather than in the next handler so
+// that the correlation ID stays a top-level attribute
+type requestIDHandler struct {
+	next   slog.Handler
+	groups []string      // groups opened with WithGroup, outermost first
+	attrs  [][]slog.Attr // attributes added at each group depth
+}
+
+// Enabled reports whether the next handler accepts records at the level
+func (h *requestIDHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle nests the record attributes in the open groups, adds the correlation ID
+// and passes the record on
+func (h *requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+
+	for depth := len(h.groups); depth >= 0; depth-- {
+		if depth < len(h.attrs) {
+			attrs = append(append([]slog.Attr{}, h.attrs[depth]...), attrs...)
+		}
+		if depth > 0 {
+			attrs = []slog.Attr{{Key: h.groups[depth-1], Value: slog.GroupValue(attrs...)}}
+		}
+	}
+
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	if id := RequestIDFromContext(ctx); id != "" {
+		record.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	record.AddAttrs(attrs...)
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler that adds attrs to every record
+func (h *requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	clone := h.clone()
+	for len(clone.attrs) <= len(clone.groups) {
+		clone.attrs = append(clone.attrs, nil)
+	}
+	depth := len(clone.groups)
+	clone.attrs[depth] = append(append([]slog.Attr{}, clone.attrs[depth]...), attrs...)
+	return clone
+}
+
+// WithGroup returns a handler that qualifies later attributes with name
+func (h *requestIDHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := h.clone()
+	clone.groups = append(clone.groups, name)
+	return clone
+}
+
+// clone returns a copy of h that does not share slices with it
+func (h *requestIDHandler) clone() *requestIDHandler {
+	return &requestIDHandler{
+		next:   h.next,
+		groups: append([]string{}, h.groups...),
+		attrs:  append([][]slog.Attr{}, h.attrs...),
+	}
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// fileSink holds the log file shared by a logger and all loggers derived from it
+type fileSink struct {
+	format  Format
+	mu      sync.RWMutex
+	writer  *RotatingFile
+	handler slog.Handler
+}
+
+// attach opens the log file, replacing any file attached before
+func (s *fileSink) attach(config FileConfig) error {
+	writer, err := OpenRotatingFile(config)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer != nil {
+		_ = s.writer.Close()
+	}
+	s.writer = writer
+	s.handler = newHandler(s.format, writer, slog.LevelDebug)
+	return nil
+}
+
+// close detaches and closes the log file
+func (s *fileSink) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	s.writer = nil
+	s.handler = nil
+	return err
+}
+
+// current returns the handler of the log file, or nil if no file is attached
+func (s *fileSink) current() slog.Handler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.handler
+}
+
+// teeHandler writes records to the console and, when attached, to the log file.
+// Attributes and groups are replayed on the file handler because the file may
+// be attached after loggers were derived
+type teeHandler struct {
+	console slog.Handler
+	file    *fileSink
+	derive  []func(slog.Handler) slog.Handler
+}
+
+// Enabled reports whether either output accepts records at the level
+func (h *teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.console.Enabled(ctx, level) || h.file.current() != nil
+}
+
+// Handle writes the record to every output that accepts it
+func (h *teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	if h.console.Enabled(ctx, r.Level) {
+		firstErr = h.console.Handle(ctx, r.Clone())
+	}
+
+	if fileHandler := h.file.current(); fileHandler != nil {
+		for _, derive := range h.derive {
+			fileHandler = derive(fileHandler)
+		}
+		if err := fileHandler.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// WithAttrs returns a handler that adds attrs to every record
+func (h *teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(h.console.WithAttrs(attrs), func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+// WithGroup returns a handler that qualifies later attributes with name
+func (h *teeHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(h.console.WithGroup(name), func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+// with returns a copy of h with a derived console handler and file derivation step
+func (h *teeHandler) with(console slog.Handler, derive func(slog.Handler) slog.Handler) *teeHandler {
+	steps := make([]func(slog.Handler) slog.Handler, len(h.derive), len(h.derive)+1)
+	copy(steps, h.derive)
+	return &teeHandler{
+		console: console,
+		file:    h.file,
+		derive:  append(steps, derive),
+	}
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"time"
+)
+
+// SlowOperation describes an operation that took longer than its threshold
+type SlowOperation struct {
+	Name      string
+	Elapsed   time.Duration
+	Threshold time.Duration
+	Hint      string // how the operation could be sped up; may be empty
+}
+
+// SlowOperations decides which operations are slow and who is told about them
+type SlowOperations struct {
+	// Thresholds are keyed by operation name; operations without a positive
+	// threshold are never slow
+	Thresholds map[string]time.Duration
+	// Report is called once for every slow operation
+	Report func(SlowOperation)
+}
+
+type slowOperationsKey struct{}
+
+// WithSlowOperations returns a copy of ctx in which operations timed with
+// Logger.Time are reported as slow according to slow
+func WithSlowOperations(ctx context.Context, slow SlowOperations) context.Context {
+	return context.WithValue(ctx, slowOperationsKey{}, slow)
+}
+
+// Time starts timing an operation and returns the function that ends it.
+// Ending logs the duration at debug level and reports the operation with hint
+// if it exceeded the threshold set with WithSlowOperations, e.g.
+//
+//	defer l.Time(ctx, "build", "")()
+func (l *Logger) Time(ctx context.Context, operation, hint string) func() {
+	started := time.Now()
+	return func() {
+		elapsed := time.Since(started)
+		l.DebugContext(ctx, "operation finished", "operation", operation, "elapsed", elapsed)
+
+		slow, ok := ctx.Value(slowOperationsKey{}).(SlowOperations)
+		if !ok || slow.Report == nil {
+			return
+		}
+		threshold := slow.Thresholds[operation]
+		if threshold <= 0 || elapsed <= threshold {
+			return
+		}
+		slow.Report(SlowOperation{Name: operation, Elapsed: elapsed, Threshold: threshold, Hint: hint})
+	}
+}
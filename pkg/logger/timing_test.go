@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_Time(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	l := New(Config{Level: LevelDebug, Format: FormatText, Output: &buf})
+	var reported []SlowOperation
+	ctx := WithSlowOperations(context.Background(), SlowOperations{
+		Thresholds: map[string]time.Duration{"download": time.Nanosecond, "build": time.Hour, "disabled": 0},
+		Report:     func(op SlowOperation) { reported = append(reported, op) },
+	})
+
+	// When
+	for _, operation := range []string{"download", "build", "disabled", "unknown"} {
+		stop := l.Time(ctx, operation, "try --offline")
+		time.Sleep(time.Millisecond)
+		stop()
+	}
+
+	// Then
+	if len(reported) != 1 {
+		t.Fatalf("expected one slow operation, got %+v", reported)
+	}
+	assert.Equal(t, "download", reported[0].Name)
+	assert.Equal(t, "try --offline", reported[0].Hint)
+	assert.Equal(t, time.Nanosecond, reported[0].Threshold)
+	assert.Greater(t, reported[0].Elapsed, time.Nanosecond)
+	assert.Equal(t, 4, strings.Count(buf.String(), "operation finished"))
+}
+
+func TestLogger_Time_WithoutSlowOperations(t *testing.T) {
+	l := New(Config{Level: LevelInfo, Output: &bytes.Buffer{}})
+
+	assert.NotPanics(t, func() { l.Time(context.Background(), "download", "")() })
+}
@@ -0,0 +1,41 @@
+// Package offline provides helpers for running commands without network access.
+package offline
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+type contextKey struct{}
+
+// WithOffline returns a copy of ctx that carries the offline flag
+func WithOffline(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, contextKey{}, enabled)
+}
+
+// IsOffline reports whether the context was marked as offline
+func IsOffline(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	enabled, _ := ctx.Value(contextKey{}).(bool)
+	return enabled
+}
+
+// Error returns the error reported when an operation needs network access in offline mode
+func Error(operation string) error {
+	return cerrors.NewAppError(
+		cerrors.CodeOffline,
+		operation+" requires network access. Run the command again without --offline",
+		nil,
+	)
+}
+
+// Check returns an offline error for the operation if ctx is offline
+func Check(ctx context.Context, operation string) error {
+	if IsOffline(ctx) {
+		return Error(operation)
+	}
+	return nil
+}
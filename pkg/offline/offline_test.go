@@ -0,0 +1,27 @@
+package offline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func TestIsOffline(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsOffline(ctx))
+	assert.True(t, IsOffline(WithOffline(ctx, true)))
+	assert.False(t, IsOffline(WithOffline(ctx, false)))
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, Check(ctx, "submit"))
+
+	err := Check(WithOffline(ctx, true), "submit")
+	assert.Error(t, err)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeOffline))
+	assert.Contains(t, err.Error(), "submit requires network access")
+}
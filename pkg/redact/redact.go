@@ -0,0 +1,120 @@
+// Package redact masks secrets such as passwords, session tokens and cookies
+// before they are written to logs, traces or error messages.
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces a secret that is hidden completely
+const Placeholder = "[REDACTED]"
+
+// revealLength is the shortest secret of which Mask keeps the first and last
+// characters. Shorter secrets, such as most passwords, are hidden completely
+const revealLength = 24
+
+// sensitiveWords are parts of keys, headers and parameters that name a secret
+var sensitiveWords = []string{"password", "passwd", "token", "secret", "session", "cookie", "authorization"}
+
+// secretPattern finds secrets in free text: bearer tokens and key=value or
+// "key": "value" pairs with a sensitive key
+var secretPattern = regexp.MustCompile(
+	`(?i)(bearer\s+|(?:password|passwd|token|secret|session(?:_?id)?|cookie)["']?\s*[:=]\s*["']?)([^\s"'&,;]+)`)
+
+// IsSensitiveKey reports whether a key, header or parameter name may carry a secret
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range sensitiveWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask hides a secret but keeps its length and, if it is long enough to stay
+// secret, its first and last four characters so that it can be recognized.
+// Masking a masked value does not change it
+func Mask(secret string) string {
+	if len(secret) < revealLength {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", len(secret)-8) + secret[len(secret)-4:]
+}
+
+// Text masks bearer tokens and the values of sensitive key=value pairs in
+// free text such as error messages
+func Text(text string) string {
+	return secretPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := secretPattern.FindStringSubmatch(match)
+		return parts[1] + Mask(parts[2])
+	})
+}
+
+// Args returns a copy of command line arguments with the values of
+// sensitive flags, given as "--password=x" or "--password x", replaced.
+// Negated boolean flags such as "--no-persist-session" take no value
+func Args(args []string) []string {
+	masked := make([]string, len(args))
+	copy(masked, args)
+	for i := 0; i < len(masked); i++ {
+		name, _, hasValue := strings.Cut(masked[i], "=")
+		if !strings.HasPrefix(name, "-") || !IsSensitiveKey(name) {
+			continue
+		}
+		if hasValue {
+			masked[i] = name + "=" + Placeholder
+		} else if !strings.HasPrefix(name, "--no-") && i+1 < len(masked) && !strings.HasPrefix(masked[i+1], "-") {
+			i++
+			masked[i] = Placeholder
+		}
+	}
+	return masked
+}
+
+// URL returns u with its password and the values of sensitive query parameters replaced
+func URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	masked := *u
+	query := masked.Query()
+	changed := false
+	for key := range query {
+		if IsSensitiveKey(key) {
+			query.Set(key, Placeholder)
+			changed = true
+		}
+	}
+	if changed {
+		masked.RawQuery = query.Encode()
+	}
+	return masked.Redacted()
+}
+
+// Headers returns the headers as a map with credentials and cookies replaced
+func Headers(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for key, values := range header {
+		if IsSensitiveKey(key) {
+			result[key] = Placeholder
+			continue
+		}
+		result[key] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// Form replaces the values of sensitive fields of a form in place
+func Form(form url.Values) url.Values {
+	for key := range form {
+		if IsSensitiveKey(key) {
+			form.Set(key, Placeholder)
+		}
+	}
+	return form
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/filelock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+// FileStore stores every key as a file named <root>/<bucket>/<key>
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a FileStore rooted at dir
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{root: dir}
+}
+
+// Get returns the content of a key's file
+func (s *FileStore) Get(bucket, key string) ([]byte, error) {
+	if err := validateKey(bucket, key); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(s.path(bucket, key))
+	if os.IsNotExist(err) {
+		return nil, notFound(bucket, key)
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to read "+joinKey(bucket, key))
+	}
+	return content, nil
+}
+
+// Put writes a key's file atomically, readable only by the owner
+func (s *FileStore) Put(bucket, key string, value []byte) error {
+	if err := validateKey(bucket, key); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir(bucket), 0755); err != nil {
+		return cerrors.Wrap(err, "failed to create storage directory")
+	}
+	if err := filelock.WriteFileAtomic(s.path(bucket, key), value, 0600); err != nil {
+		return cerrors.Wrap(err, "failed to write "+joinKey(bucket, key))
+	}
+	return nil
+}
+
+// Delete removes a key's file
+func (s *FileStore) Delete(bucket, key string) error {
+	if err := validateKey(bucket, key); err != nil {
+		return err
+	}
+
+	trace.File("remove", s.path(bucket, key))
+	if err := os.Remove(s.path(bucket, key)); err != nil && !os.IsNotExist(err) {
+		return cerrors.Wrap(err, "failed to delete "+joinKey(bucket, key))
+	}
+	return nil
+}
+
+// Keys lists the regular files of a bucket's directory, skipping hidden temporary files
+func (s *FileStore) Keys(bucket string) ([]string, error) {
+	entries, err := os.ReadDir(s.dir(bucket))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, cerrors.Wrap(err, "failed to list "+bucket)
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		keys = append(keys, entry.Name())
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Close does nothing; files are not kept open
+func (s *FileStore) Close() error {
+	return nil
+}
+
+func (s *FileStore) dir(bucket string) string {
+	return filepath.Join(s.root, filepath.FromSlash(bucket))
+}
+
+func (s *FileStore) path(bucket, key string) string {
+	return filepath.Join(s.dir(bucket), key)
+}
@@ -0,0 +1,424 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/filelock"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/trace"
+)
+
+const (
+	kvMagic      = "AOJKV1\n"
+	kvHeaderSize = int64(len(kvMagic) + 8) // magic followed by the generation
+	// kvRecordHeaderSize is the size of crc, op and the three lengths of a record
+	kvRecordHeaderSize = 4 + 1 + 4 + 4 + 4
+	// kvMaxRecordField guards against huge allocations when reading a corrupted record
+	kvMaxRecordField = 1 << 30
+	// kvCompactMinSize is the file size from which obsolete records are compacted away
+	kvCompactMinSize = 1 << 20
+)
+
+const (
+	kvOpPut    byte = 1
+	kvOpDelete byte = 2
+)
+
+// errTornRecord reports a record that was only partially written
+var errTornRecord = errors.New("torn record")
+
+// errCorruptRecord reports a complete record with a wrong checksum or length
+var errCorruptRecord = errors.New("corrupt record")
+
+// kvEntry is a live value and the size of the record that stored it
+type kvEntry struct {
+	value []byte
+	size  int64
+}
+
+// KVStore keeps all buckets in a single append-only file, so that it creates
+// one file instead of thousands on slow or network file systems.
+//
+// The file starts with a random generation number followed by put and delete
+// records. Every operation holds an inter-process lock and first applies the
+// records other processes appended since the last operation. Compaction
+// rewrites the file with a new generation, which makes other processes reload it
+type KVStore struct {
+	path       string
+	mu         sync.Mutex
+	generation uint64
+	offset     int64 // bytes of the file already applied; 0 if the file does not exist
+	live       int64 // bytes of records that hold live values
+	data       map[string]map[string]kvEntry
+}
+
+// OpenKVStore opens the store kept in the file at path, creating it on first write
+func OpenKVStore(path string) (*KVStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, cerrors.Wrap(err, "failed to create storage directory")
+	}
+
+	s := &KVStore{
+		path: path,
+		data: make(map[string]map[string]kvEntry),
+	}
+	if err := s.withLock(s.sync); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns the value of a key
+func (s *KVStore) Get(bucket, key string) ([]byte, error) {
+	if err := validateKey(bucket, key); err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	err := s.withLock(func() error {
+		if err := s.sync(); err != nil {
+			return err
+		}
+		entry, ok := s.data[bucket][key]
+		if !ok {
+			return notFound(bucket, key)
+		}
+		value = bytes.Clone(entry.value)
+		return nil
+	})
+	return value, err
+}
+
+// Put appends a record that sets the value of a key
+func (s *KVStore) Put(bucket, key string, value []byte) error {
+	if err := validateKey(bucket, key); err != nil {
+		return err
+	}
+
+	return s.withLock(func() error {
+		if err := s.sync(); err != nil {
+			return err
+		}
+		record := encodeKVRecord(kvOpPut, bucket, key, value)
+		if err := s.append(record); err != nil {
+			return err
+		}
+		s.apply(kvOpPut, bucket, key, bytes.Clone(value), int64(len(record)))
+		return s.maybeCompact()
+	})
+}
+
+// Delete appends a record that removes a key
+func (s *KVStore) Delete(bucket, key string) error {
+	if err := validateKey(bucket, key); err != nil {
+		return err
+	}
+
+	return s.withLock(func() error {
+		if err := s.sync(); err != nil {
+			return err
+		}
+		if _, ok := s.data[bucket][key]; !ok {
+			return nil
+		}
+		record := encodeKVRecord(kvOpDelete, bucket, key, nil)
+		if err := s.append(record); err != nil {
+			return err
+		}
+		s.apply(kvOpDelete, bucket, key, nil, int64(len(record)))
+		return s.maybeCompact()
+	})
+}
+
+// Keys returns the keys of a bucket in sorted order
+func (s *KVStore) Keys(bucket string) ([]string, error) {
+	var keys []string
+	err := s.withLock(func() error {
+		if err := s.sync(); err != nil {
+			return err
+		}
+		keys = make([]string, 0, len(s.data[bucket]))
+		for key := range s.data[bucket] {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+// Close does nothing; the file is only open during an operation
+func (s *KVStore) Close() error {
+	return nil
+}
+
+// withLock runs fn while holding both the in-process and the inter-process lock
+func (s *KVStore) withLock(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, err := filelock.Acquire(s.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	return fn()
+}
+
+// sync applies the records appended since the last operation, reloading the
+// whole file if it was compacted or replaced. A torn record left behind by a
+// crashed writer is truncated away. A corrupt record followed by others is
+// an error instead, so that the records after it are not lost
+func (s *KVStore) sync() error {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		s.reset(0)
+		return nil
+	}
+	if err != nil {
+		return cerrors.Wrap(err, "failed to open storage file")
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, kvHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		// A file without a complete header holds no records; it is rewritten on the next write
+		s.reset(0)
+		return nil
+	}
+	if string(header[:len(kvMagic)]) != kvMagic {
+		return cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			s.path+" is not an aoj storage file",
+			nil,
+		)
+	}
+
+	generation := binary.BigEndian.Uint64(header[len(kvMagic):])
+	if s.offset == 0 || generation != s.generation {
+		s.reset(generation)
+		s.offset = kvHeaderSize
+	}
+
+	if _, err := file.Seek(s.offset, io.SeekStart); err != nil {
+		return cerrors.Wrap(err, "failed to seek storage file")
+	}
+	reader := bufio.NewReader(file)
+	for {
+		op, bucket, key, value, size, err := readKVRecord(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if errors.Is(err, errCorruptRecord) && !atEOF(reader) {
+			return cerrors.WithHint(
+				cerrors.NewAppError(
+					cerrors.CodeInternalServer,
+					fmt.Sprintf("storage file %s is corrupted at byte %d", s.path, s.offset),
+					err,
+				),
+				"The file was left untouched. Restore it with 'aoj backup restore' or move it away to start with an empty store",
+			)
+		}
+		if errors.Is(err, errTornRecord) || errors.Is(err, errCorruptRecord) {
+			if err := os.Truncate(s.path, s.offset); err != nil {
+				return cerrors.Wrap(err, "failed to truncate torn storage record")
+			}
+			return nil
+		}
+		if err != nil {
+			return cerrors.Wrap(err, "failed to read storage file")
+		}
+		s.apply(op, bucket, key, value, size)
+		s.offset += size
+	}
+}
+
+// atEOF reports whether nothing follows in r
+func atEOF(r *bufio.Reader) bool {
+	_, err := r.Peek(1)
+	return err == io.EOF
+}
+
+// reset forgets all loaded records
+func (s *KVStore) reset(generation uint64) {
+	s.generation = generation
+	s.offset = 0
+	s.live = 0
+	s.data = make(map[string]map[string]kvEntry)
+}
+
+// apply updates the in-memory index with a record
+func (s *KVStore) apply(op byte, bucket, key string, value []byte, size int64) {
+	if old, ok := s.data[bucket][key]; ok {
+		s.live -= old.size
+		delete(s.data[bucket], key)
+	}
+	if op != kvOpPut {
+		return
+	}
+
+	if s.data[bucket] == nil {
+		s.data[bucket] = make(map[string]kvEntry)
+	}
+	s.data[bucket][key] = kvEntry{value: value, size: size}
+	s.live += size
+}
+
+// append writes a record to the end of the file, creating the file if needed
+func (s *KVStore) append(record []byte) error {
+	if s.offset == 0 {
+		generation := rand.Uint64()
+		if err := filelock.WriteFileAtomic(s.path, encodeKVHeader(generation), 0600); err != nil {
+			return cerrors.Wrap(err, "failed to create storage file")
+		}
+		s.generation = generation
+		s.offset = kvHeaderSize
+	}
+
+	trace.File("write", s.path)
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to open storage file")
+	}
+	if _, err := file.Write(record); err != nil {
+		_ = file.Close()
+		return cerrors.Wrap(err, "failed to write storage file")
+	}
+	if err := file.Close(); err != nil {
+		return cerrors.Wrap(err, "failed to close storage file")
+	}
+
+	s.offset += int64(len(record))
+	return nil
+}
+
+// maybeCompact rewrites the file without obsolete records once they take up
+// more than half of a large file
+func (s *KVStore) maybeCompact() error {
+	if s.offset < kvCompactMinSize || s.offset-kvHeaderSize <= 2*s.live {
+		return nil
+	}
+
+	generation := rand.Uint64()
+	var buf bytes.Buffer
+	s.writeCompacted(&buf, generation, nil)
+
+	if err := filelock.WriteFileAtomic(s.path, buf.Bytes(), 0600); err != nil {
+		return cerrors.Wrap(err, "failed to compact storage file")
+	}
+	s.generation = generation
+	s.offset = int64(buf.Len())
+	return nil
+}
+
+// writeCompacted writes a store file holding only the live values, leaving
+// out the entries skip reports; a nil skip keeps all of them
+func (s *KVStore) writeCompacted(buf *bytes.Buffer, generation uint64, skip func(bucket, key string) bool) {
+	buf.Write(encodeKVHeader(generation))
+
+	buckets := make([]string, 0, len(s.data))
+	for bucket := range s.data {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	for _, bucket := range buckets {
+		keys := make([]string, 0, len(s.data[bucket]))
+		for key := range s.data[bucket] {
+			if skip == nil || !skip(bucket, key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			buf.Write(encodeKVRecord(kvOpPut, bucket, key, s.data[bucket][key].value))
+		}
+	}
+}
+
+// Snapshot returns a compacted copy of the store file without the entries
+// skip reports. Unlike a copy of the file, it holds no deleted or overwritten
+// values, so that e.g. a backup without sessions contains no old tokens
+func (s *KVStore) Snapshot(skip func(bucket, key string) bool) ([]byte, error) {
+	var buf bytes.Buffer
+	err := s.withLock(func() error {
+		if err := s.sync(); err != nil {
+			return err
+		}
+		s.writeCompacted(&buf, rand.Uint64(), skip)
+		return nil
+	})
+	return buf.Bytes(), err
+}
+
+func encodeKVHeader(generation uint64) []byte {
+	header := make([]byte, kvHeaderSize)
+	copy(header, kvMagic)
+	binary.BigEndian.PutUint64(header[len(kvMagic):], generation)
+	return header
+}
+
+// encodeKVRecord encodes a record as
+// crc32 | op | len(bucket) | len(key) | len(value) | bucket | key | value,
+// where the checksum covers everything after itself
+func encodeKVRecord(op byte, bucket, key string, value []byte) []byte {
+	record := make([]byte, kvRecordHeaderSize, kvRecordHeaderSize+len(bucket)+len(key)+len(value))
+	record[4] = op
+	binary.BigEndian.PutUint32(record[5:], uint32(len(bucket)))
+	binary.BigEndian.PutUint32(record[9:], uint32(len(key)))
+	binary.BigEndian.PutUint32(record[13:], uint32(len(value)))
+	record = append(record, bucket...)
+	record = append(record, key...)
+	record = append(record, value...)
+	binary.BigEndian.PutUint32(record[0:], crc32.ChecksumIEEE(record[4:]))
+	return record
+}
+
+// readKVRecord reads the next record. It returns io.EOF at the end of the
+// file, errTornRecord for a record cut short by the end of the file and
+// errCorruptRecord for a record with an impossible length or a wrong checksum
+func readKVRecord(r io.Reader) (op byte, bucket, key string, value []byte, size int64, err error) {
+	header := make([]byte, kvRecordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF {
+			return 0, "", "", nil, 0, io.EOF
+		}
+		return 0, "", "", nil, 0, errTornRecord
+	}
+
+	bucketLen := binary.BigEndian.Uint32(header[5:])
+	keyLen := binary.BigEndian.Uint32(header[9:])
+	valueLen := binary.BigEndian.Uint32(header[13:])
+	if bucketLen > kvMaxRecordField || keyLen > kvMaxRecordField || valueLen > kvMaxRecordField {
+		return 0, "", "", nil, 0, errCorruptRecord
+	}
+
+	body := make([]byte, int(bucketLen)+int(keyLen)+int(valueLen))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, "", "", nil, 0, errTornRecord
+	}
+
+	checksum := crc32.NewIEEE()
+	_, _ = checksum.Write(header[4:])
+	_, _ = checksum.Write(body)
+	if checksum.Sum32() != binary.BigEndian.Uint32(header[0:]) {
+		return 0, "", "", nil, 0, errCorruptRecord
+	}
+
+	op = header[4]
+	bucket = string(body[:bucketLen])
+	key = string(body[bucketLen : bucketLen+keyLen])
+	value = body[bucketLen+keyLen:]
+	return op, bucket, key, value, int64(kvRecordHeaderSize + len(body)), nil
+}
@@ -0,0 +1,83 @@
+// Package storage provides the key-value stores that hold local data such as
+// sessions, caches and submission history.
+package storage
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// Storage backends selectable in the configuration
+const (
+	BackendFile = "file"
+	BackendKV   = "kv"
+)
+
+// KVFileName is the file the kv backend keeps all data in
+const KVFileName = "store.db"
+
+// Store is a key-value store whose keys are grouped into buckets.
+// The empty bucket is the root of the store
+type Store interface {
+	// Get returns the value of a key, or a CodeNotFound error if it does not exist
+	Get(bucket, key string) ([]byte, error)
+	// Put atomically replaces the value of a key
+	Put(bucket, key string, value []byte) error
+	// Delete removes a key. Deleting a missing key is not an error
+	Delete(bucket, key string) error
+	// Keys returns the keys of a bucket in sorted order
+	Keys(bucket string) ([]string, error)
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// Open opens the store of the given backend rooted at dir
+func Open(backend, dir string) (Store, error) {
+	switch backend {
+	case "", BackendFile:
+		return NewFileStore(dir), nil
+	case BackendKV:
+		return OpenKVStore(filepath.Join(dir, KVFileName))
+	default:
+		return nil, cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"unknown storage backend '"+backend+"'. Use '"+BackendFile+"' or '"+BackendKV+"'",
+			nil,
+		)
+	}
+}
+
+// IsNotFound reports whether err means that a key does not exist
+func IsNotFound(err error) bool {
+	return cerrors.IsAppError(err, cerrors.CodeNotFound)
+}
+
+// notFound returns the error reported for a missing key
+func notFound(bucket, key string) error {
+	return cerrors.NewAppError(
+		cerrors.CodeNotFound,
+		"key "+joinKey(bucket, key)+" not found",
+		nil,
+	)
+}
+
+// validateKey rejects keys that would escape their bucket in a file store
+func validateKey(bucket, key string) error {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return cerrors.NewAppError(
+			cerrors.CodeInvalidInput,
+			"invalid storage key '"+joinKey(bucket, key)+"'",
+			nil,
+		)
+	}
+	return nil
+}
+
+func joinKey(bucket, key string) string {
+	if bucket == "" {
+		return key
+	}
+	return bucket + "/" + key
+}
@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+func openStores(t *testing.T) map[string]Store {
+	t.Helper()
+	kv, err := OpenKVStore(filepath.Join(t.TempDir(), KVFileName))
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	return map[string]Store{
+		BackendFile: NewFileStore(t.TempDir()),
+		BackendKV:   kv,
+	}
+}
+
+func TestStore_PutGetDelete(t *testing.T) {
+	for name, store := range openStores(t) {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			assert.NoError(t, store.Put("sessions", "b", []byte("second")))
+			assert.NoError(t, store.Put("sessions", "a", []byte("first")))
+			assert.NoError(t, store.Put("", "current_session", []byte("a")))
+			assert.NoError(t, store.Put("sessions", "b", []byte("replaced")))
+
+			// When
+			value, getErr := store.Get("sessions", "b")
+			keys, keysErr := store.Keys("sessions")
+			deleteErr := store.Delete("sessions", "a")
+			_, missingErr := store.Get("sessions", "a")
+
+			// Then
+			assert.NoError(t, getErr)
+			assert.Equal(t, "replaced", string(value))
+			assert.NoError(t, keysErr)
+			assert.Equal(t, []string{"a", "b"}, keys)
+			assert.NoError(t, deleteErr)
+			assert.True(t, IsNotFound(missingErr))
+			assert.NoError(t, store.Delete("sessions", "a"))
+
+			current, err := store.Get("", "current_session")
+			assert.NoError(t, err)
+			assert.Equal(t, "a", string(current))
+			assert.NoError(t, store.Close())
+		})
+	}
+}
+
+func TestStore_EmptyBucket(t *testing.T) {
+	for name, store := range openStores(t) {
+		t.Run(name, func(t *testing.T) {
+			keys, err := store.Keys("history")
+
+			assert.NoError(t, err)
+			assert.Empty(t, keys)
+		})
+	}
+}
+
+func TestStore_InvalidKey(t *testing.T) {
+	for name, store := range openStores(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"", "..", "../escape", `a\b`} {
+				err := store.Put("sessions", key, []byte("x"))
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput), key)
+			}
+		})
+	}
+}
+
+func TestFileStore_Layout(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+
+	// When
+	assert.NoError(t, store.Put("cache/testcases", "ITP1_1_A.json", []byte("[]")))
+
+	// Then
+	path := filepath.Join(dir, "cache", "testcases", "ITP1_1_A.json")
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestKVStore_SharedBetweenProcesses(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), KVFileName)
+	first, err := OpenKVStore(path)
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	second, err := OpenKVStore(path)
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+
+	// When
+	assert.NoError(t, first.Put("history", "1.json", []byte("one")))
+	assert.NoError(t, second.Put("history", "2.json", []byte("two")))
+	assert.NoError(t, first.Delete("history", "1.json"))
+
+	// Then
+	keys, err := second.Keys("history")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2.json"}, keys)
+	value, err := first.Get("history", "2.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "two", string(value))
+}
+
+func TestKVStore_Compaction(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), KVFileName)
+	store, err := OpenKVStore(path)
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	other, err := OpenKVStore(path)
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	assert.NoError(t, other.Put("cache", "kept", []byte("value")))
+	value := bytes.Repeat([]byte("x"), 64*1024)
+
+	// When
+	for i := 0; i < 40; i++ {
+		assert.NoError(t, store.Put("cache", "big", value))
+	}
+
+	// Then
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Less(t, info.Size(), int64(kvCompactMinSize))
+
+	kept, err := other.Get("cache", "kept")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", string(kept))
+	big, err := other.Get("cache", "big")
+	assert.NoError(t, err)
+	assert.Equal(t, value, big)
+}
+
+func TestKVStore_RecoversFromTornRecord(t *testing.T) {
+	// Given
+	path := filepath.Join(t.TempDir(), KVFileName)
+	store, err := OpenKVStore(path)
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	assert.NoError(t, store.Put("sessions", "a", []byte("first")))
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatalf("failed to open store file: %v", err)
+	}
+	torn := encodeKVRecord(kvOpPut, "sessions", "b", []byte("second"))
+	_, err = file.Write(torn[:len(torn)-3])
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	// When
+	reopened, err := OpenKVStore(path)
+
+	// Then
+	assert.NoError(t, err)
+	keys, err := reopened.Keys("sessions")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, keys)
+	assert.NoError(t, reopened.Put("sessions", "c", []byte("third")))
+	value, err := store.Get("sessions", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, "third", string(value))
+}
+
+func TestKVStore_CorruptRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string // key of the record whose value is corrupted
+		wantErr bool
+	}{
+		{name: "in the middle", record: "a", wantErr: true},
+		{name: "at the end", record: "c", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			path := filepath.Join(t.TempDir(), KVFileName)
+			store, err := OpenKVStore(path)
+			if err != nil {
+				t.Fatalf("failed to open kv store: %v", err)
+			}
+			assert.NoError(t, store.Put("sessions", "a", []byte("first")))
+			assert.NoError(t, store.Put("sessions", "b", []byte("second")))
+			assert.NoError(t, store.Put("sessions", "c", []byte("third")))
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read store file: %v", err)
+			}
+			value := map[string]string{"a": "first", "c": "third"}[tt.record]
+			at := bytes.Index(content, []byte(value))
+			content[at] ^= 0xff
+			assert.NoError(t, os.WriteFile(path, content, 0600))
+
+			// When
+			reopened, err := OpenKVStore(path)
+
+			// Then
+			info, statErr := os.Stat(path)
+			assert.NoError(t, statErr)
+			if tt.wantErr {
+				assert.True(t, cerrors.IsAppError(err, cerrors.CodeInternalServer))
+				assert.Equal(t, int64(len(content)), info.Size())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Less(t, info.Size(), int64(len(content)))
+			keys, err := reopened.Keys("sessions")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"a", "b"}, keys)
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	fileStore, err := Open(BackendFile, dir)
+	assert.NoError(t, err)
+	assert.IsType(t, &FileStore{}, fileStore)
+
+	kvStore, err := Open(BackendKV, dir)
+	assert.NoError(t, err)
+	assert.IsType(t, &KVStore{}, kvStore)
+
+	_, err = Open("sqlite", dir)
+	assert.True(t, cerrors.IsAppError(err, cerrors.CodeInvalidInput))
+	assert.True(t, strings.Contains(err.Error(), "sqlite"))
+}
+
+func TestKVStore_Snapshot(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	store, err := OpenKVStore(filepath.Join(dir, KVFileName))
+	if err != nil {
+		t.Fatalf("failed to open kv store: %v", err)
+	}
+	assert.NoError(t, store.Put("history", "1.json", []byte("old")))
+	assert.NoError(t, store.Put("history", "1.json", []byte("new")))
+	assert.NoError(t, store.Put("sessions", "abc", []byte("token")))
+
+	// When
+	snapshot, err := store.Snapshot(func(bucket, _ string) bool { return bucket == "sessions" })
+
+	// Then
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	assert.NotContains(t, string(snapshot), "old")
+	assert.NotContains(t, string(snapshot), "token")
+
+	path := filepath.Join(t.TempDir(), KVFileName)
+	assert.NoError(t, os.WriteFile(path, snapshot, 0600))
+	restored, err := OpenKVStore(path)
+	if err != nil {
+		t.Fatalf("failed to open snapshot: %v", err)
+	}
+	value, err := restored.Get("history", "1.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(value))
+	keys, err := restored.Keys("sessions")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
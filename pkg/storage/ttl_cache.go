@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+// ttlEntry represents the JSON structure of a cached value
+type ttlEntry struct {
+	Value     json.RawMessage `json:"value"`
+	FetchedAt int64           `json:"fetched_at"`
+}
+
+// TTLCache keeps JSON encoded values in a bucket of a Store together with the
+// time they were saved, so that values fetched from the network are reused
+// while fresh and still available, stale, when the network is not
+type TTLCache[T any] struct {
+	store  Store
+	bucket string
+	ttl    time.Duration
+	clock  clock.Clock
+}
+
+// NewTTLCache creates a new TTLCache that keeps values in bucket of store and
+// treats them as fresh for ttl, measured with clk
+func NewTTLCache[T any](store Store, bucket string, ttl time.Duration, clk clock.Clock) *TTLCache[T] {
+	if clk == nil {
+		clk = clock.System()
+	}
+	return &TTLCache[T]{
+		store:  store,
+		bucket: bucket,
+		ttl:    ttl,
+		clock:  clk,
+	}
+}
+
+// Load returns the value saved under key, whether one was found and whether
+// it is still fresh. Missing values and values that cannot be decoded, such
+// as those of an older cache layout, are not found
+func (c *TTLCache[T]) Load(key string) (value T, found bool, fresh bool) {
+	content, err := c.store.Get(c.bucket, key)
+	if err != nil {
+		return value, false, false
+	}
+
+	var entry ttlEntry
+	if err := json.Unmarshal(content, &entry); err != nil || len(entry.Value) == 0 {
+		return value, false, false
+	}
+	var decoded T
+	if err := json.Unmarshal(entry.Value, &decoded); err != nil {
+		return value, false, false
+	}
+
+	fresh = c.clock.Now().Sub(time.Unix(entry.FetchedAt, 0)) < c.ttl
+	return decoded, true, fresh
+}
+
+// Save stores value under key as fetched now
+func (c *TTLCache[T]) Save(key string, value T) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode cache entry")
+	}
+	content, err := json.Marshal(ttlEntry{
+		Value:     encoded,
+		FetchedAt: c.clock.Now().Unix(),
+	})
+	if err != nil {
+		return cerrors.Wrap(err, "failed to encode cache entry")
+	}
+
+	if err := c.store.Put(c.bucket, key, content); err != nil {
+		return cerrors.Wrap(err, "failed to write cache entry")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/clock"
+)
+
+func TestTTLCache_LoadSave(t *testing.T) {
+	// Given
+	store := NewFileStore(t.TempDir())
+	clk := clock.NewFake(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	cache := NewTTLCache[[]string](store, "cache", time.Hour, clk)
+
+	// When
+	_, missingFound, _ := cache.Load("languages.json")
+	assert.NoError(t, cache.Save("languages.json", []string{"C++17", "Go"}))
+	fresh, freshFound, isFresh := cache.Load("languages.json")
+	clk.Advance(time.Hour)
+	stale, staleFound, isStillFresh := cache.Load("languages.json")
+
+	// Then
+	assert.False(t, missingFound)
+	assert.True(t, freshFound)
+	assert.True(t, isFresh)
+	assert.Equal(t, []string{"C++17", "Go"}, fresh)
+	assert.True(t, staleFound)
+	assert.False(t, isStillFresh)
+	assert.Equal(t, fresh, stale)
+}
+
+func TestTTLCache_Unreadable(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "not JSON", content: "not json"},
+		{name: "older layout", content: `{"languages":["C++17"],"fetched_at":1714564800}`},
+		{name: "wrong type", content: `{"value":{"C++17":true},"fetched_at":1714564800}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			store := NewFileStore(t.TempDir())
+			assert.NoError(t, store.Put("cache", "languages.json", []byte(tt.content)))
+			cache := NewTTLCache[[]string](store, "cache", time.Hour, nil)
+
+			// When
+			value, found, fresh := cache.Load("languages.json")
+
+			// Then
+			assert.False(t, found)
+			assert.False(t, fresh)
+			assert.Nil(t, value)
+		})
+	}
+}
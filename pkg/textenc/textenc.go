@@ -0,0 +1,85 @@
+// Package textenc detects the encoding of text files and converts them to UTF-8.
+package textenc
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/YuminosukeSato/AOJ-cli/pkg/cerrors"
+)
+
+// Names of the encodings ToUTF8 recognizes
+const (
+	UTF8        = "UTF-8"
+	UTF8BOM     = "UTF-8 with BOM"
+	UTF16LE     = "UTF-16LE"
+	UTF16BE     = "UTF-16BE"
+	ShiftJIS    = "Shift_JIS"
+	EUCJP       = "EUC-JP"
+	Windows1252 = "Windows-1252"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// fallbacks are tried in order for text that is not valid UTF-8. Japanese
+// encodings come first, as they reject most text in other encodings
+var fallbacks = []struct {
+	name     string
+	encoding encoding.Encoding
+}{
+	{ShiftJIS, japanese.ShiftJIS},
+	{EUCJP, japanese.EUCJP},
+	{Windows1252, charmap.Windows1252},
+}
+
+// ToUTF8 returns data as UTF-8 text without a byte order mark, together with
+// the encoding it was detected in
+func ToUTF8(data []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return string(data[len(bomUTF8):]), UTF8BOM, nil
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return decode(unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), data, UTF16LE)
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return decode(unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), data, UTF16BE)
+	case utf8.Valid(data):
+		return string(data), UTF8, nil
+	}
+
+	for _, fallback := range fallbacks {
+		text, name, err := decode(fallback.encoding, data, fallback.name)
+		if err == nil && !strings.ContainsRune(text, utf8.RuneError) {
+			return text, name, nil
+		}
+	}
+	return "", "", cerrors.NewAppError(
+		cerrors.CodeInvalidInput,
+		"text is neither UTF-8 nor in a recognized encoding",
+		nil,
+	)
+}
+
+// decode converts data from enc to UTF-8
+func decode(enc encoding.Encoding, data []byte, name string) (string, string, error) {
+	text, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", cerrors.Wrap(err, "failed to decode "+name+" text")
+	}
+	return string(text), name, nil
+}
+
+// IsBinary reports whether data looks like a binary file rather than text,
+// judging by NUL bytes, which text files do not contain
+func IsBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0
+}
@@ -0,0 +1,39 @@
+package textenc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToUTF8(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		wantText     string
+		wantEncoding string
+	}{
+		{name: "utf-8", data: []byte("こんにちは"), wantText: "こんにちは", wantEncoding: UTF8},
+		{name: "utf-8 bom", data: []byte("\xEF\xBB\xBFx"), wantText: "x", wantEncoding: UTF8BOM},
+		{name: "utf-16le", data: []byte{0xFF, 0xFE, 'h', 0, 'i', 0}, wantText: "hi", wantEncoding: UTF16LE},
+		{name: "utf-16be", data: []byte{0xFE, 0xFF, 0, 'h', 0, 'i'}, wantText: "hi", wantEncoding: UTF16BE},
+		{name: "shift_jis", data: []byte("\x82\xB1\x82\xF1"), wantText: "こん", wantEncoding: ShiftJIS},
+		{name: "euc-jp", data: []byte("\xA4\xB3\xA4\xF3\xA4\xCB\xA4\xC1\xA4\xCF"), wantText: "こんにちは", wantEncoding: EUCJP},
+		{name: "windows-1252", data: []byte("caf\xE9"), wantText: "café", wantEncoding: Windows1252},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, encoding, err := ToUTF8(tt.data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantText, text)
+			assert.Equal(t, tt.wantEncoding, encoding)
+		})
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	assert.True(t, IsBinary([]byte("\x7FELF\x02\x01\x00")))
+	assert.False(t, IsBinary([]byte("int main() {}\n")))
+}
@@ -0,0 +1,53 @@
+// Package trace prints the network requests and state-changing file system
+// actions of a command just before they happen, for debugging and auditing.
+package trace
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	mu  sync.Mutex
+	out io.Writer // nil while tracing is off
+)
+
+// Enable prints trace lines to w from now on; nil turns tracing off
+func Enable(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	out = w
+}
+
+// Enabled reports whether trace lines are printed
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return out != nil
+}
+
+// Request traces an outgoing HTTP request. url and body must already have
+// their secrets masked; an empty body is omitted
+func Request(method, url, body string) {
+	if body == "" {
+		printf("%s %s", method, url)
+		return
+	}
+	printf("%s %s %s", method, url, body)
+}
+
+// File traces a file system action such as "write" or "remove" on path
+func File(action, path string) {
+	printf("%s %s", action, path)
+}
+
+// printf writes one trace line if tracing is on
+func printf(format string, args ...any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if out == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(out, "trace: "+format+"\n", args...)
+}
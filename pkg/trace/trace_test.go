@@ -0,0 +1,33 @@
+package trace
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	Enable(&buf)
+	defer Enable(nil)
+
+	// When
+	Request("GET", "https://judgeapi.u-aizu.ac.jp/verdicts/1", "")
+	Request("POST", "https://judgeapi.u-aizu.ac.jp/session", `{"password":"[REDACTED]"}`)
+	File("write", "/tmp/ITP1_1_A/main.cpp")
+
+	// Then
+	assert.True(t, Enabled())
+	assert.Equal(t, "trace: GET https://judgeapi.u-aizu.ac.jp/verdicts/1\n"+
+		"trace: POST https://judgeapi.u-aizu.ac.jp/session {\"password\":\"[REDACTED]\"}\n"+
+		"trace: write /tmp/ITP1_1_A/main.cpp\n", buf.String())
+}
+
+func TestTrace_Disabled(t *testing.T) {
+	Enable(nil)
+
+	assert.False(t, Enabled())
+	assert.NotPanics(t, func() { File("remove", "/tmp/x") })
+}